@@ -0,0 +1,56 @@
+// Command genschema writes the JSON Schemas of the documents of package v1. It is run by
+// "go generate ./api/v1".
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	v1 "github.com/IgorBayerl/ReportGenerator/go_report_generator/api/v1"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/jsonschema"
+)
+
+// schemaBaseURL is the location of the schema directory, the base of the schema $ids.
+const schemaBaseURL = "https://raw.githubusercontent.com/IgorBayerl/ReportGenerator/main/go_report_generator/api/v1/schema/"
+
+// document is a document type of package v1 and the file of its schema.
+type document struct {
+	file  string
+	title string
+	value any
+}
+
+var documents = []document{
+	{"summary.schema.json", "ReportGenerator coverage model", v1.Summary{}},
+	{"parsedreport.schema.json", "ReportGenerator coverage model of a report file", v1.ParsedReport{}},
+	{"capabilities.schema.json", "ReportGenerator capabilities", v1.Capabilities{}},
+	{"runstatistics.schema.json", "ReportGenerator run statistics", v1.RunStatistics{}},
+}
+
+// generate returns the schema of the document.
+func generate(doc document) ([]byte, error) {
+	return jsonschema.Generate(reflect.TypeOf(doc.value), schemaBaseURL+doc.file, doc.title)
+}
+
+func main() {
+	dir := flag.String("dir", "schema", "Directory to write the schemas to")
+	flag.Parse()
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, doc := range documents {
+		content, err := generate(doc)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(*dir, doc.file), content, 0o644)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write the schema %s: %v\n", doc.file, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSchemasUpToDate expects the checked-in schemas to match the types of package v1.
+func TestSchemasUpToDate(t *testing.T) {
+	for _, doc := range documents {
+		want, err := generate(doc)
+		if err != nil {
+			t.Fatalf("failed to generate %s: %v", doc.file, err)
+		}
+		got, err := os.ReadFile(filepath.Join("..", "..", "schema", doc.file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", doc.file, err)
+		}
+		if !bytes.Equal(bytes.ReplaceAll(got, []byte("\r\n"), []byte("\n")), want) {
+			t.Errorf("schema/%s is out of date; run go generate ./api/v1", doc.file)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Package v1 defines version 1 of the JSON documents written by ReportGenerator: the
+// coverage model dumped with -dumpmodel, the -capabilities listing and the -statsjson
+// run statistics. Unlike the internal model, these types only change compatibly within a
+// major version: fields may be added, but are not removed, renamed or given another type.
+//
+// Every document carries its SchemaVersion. The JSON Schemas of the documents are in the
+// schema directory and are generated from these types with "go generate ./api/v1".
+package v1
+
+//go:generate go run ./internal/genschema -dir schema
+
+// SchemaVersion is the version of the documents of this package. Its major version is
+// that of the package; the minor version is raised when fields are added.
+const SchemaVersion = "1.0"
+
+// Values of Line.Status.
+const (
+	LineNotCoverable     = "notCoverable"
+	LineNotCovered       = "notCovered"
+	LinePartiallyCovered = "partiallyCovered"
+	LineCovered          = "covered"
+)
+
+// Values of Metric.Status.
+const (
+	MetricOk      = "ok"
+	MetricWarning = "warning"
+	MetricError   = "error"
+)
+
+// Values of CodeElement.Type.
+const (
+	CodeElementProperty = "property"
+	CodeElementMethod   = "method"
+)
+
+// Summary is the merged coverage model of all report files (reportgenerator-model.json).
+type Summary struct {
+	SchemaVersion string     `json:"schemaVersion" jsonschema:"pattern=^1\\.[0-9]+$"`
+	ParserName    string     `json:"parserName"`           // Parser of the reports, "MultiReport" if they were read by several parsers
+	Timestamp     int64      `json:"timestamp"`            // Time of the coverage data in Unix seconds, 0 if unknown
+	SourceDirs    []string   `json:"sourceDirs,omitempty"` // Source directories of the reports
+	Assemblies    []Assembly `json:"assemblies"`
+	Totals
+	TotalLines              int  `json:"totalLines"` // Physical lines of the unique source files
+	MethodCoverageAvailable bool `json:"methodCoverageAvailable"`
+	HiddenClasses           int  `json:"hiddenClasses"` // Classes removed by the class coverage filter
+	ExternalFiles           int  `json:"externalFiles"` // Unique files outside of the source directories
+
+	MissingSourceFiles []MissingSourceFile `json:"missingSourceFiles,omitempty"`
+	SkippedReports     []SkippedReport     `json:"skippedReports,omitempty"`
+	Directories        *DirectoryCoverage  `json:"directories,omitempty"`
+}
+
+// ParsedReport is the coverage model of a single report file before merging
+// (reportgenerator-model-<n>-<file>.json).
+type ParsedReport struct {
+	SchemaVersion           string     `json:"schemaVersion" jsonschema:"pattern=^1\\.[0-9]+$"`
+	ReportFile              string     `json:"reportFile"`
+	ParserName              string     `json:"parserName"`
+	SourceDirs              []string   `json:"sourceDirs,omitempty"`
+	SupportsBranchCoverage  bool       `json:"supportsBranchCoverage"`
+	MethodCoverageAvailable bool       `json:"methodCoverageAvailable"`
+	MinimumTimestamp        string     `json:"minimumTimestamp,omitempty" jsonschema:"format=date-time"`
+	MaximumTimestamp        string     `json:"maximumTimestamp,omitempty" jsonschema:"format=date-time"`
+	Assemblies              []Assembly `json:"assemblies"`
+
+	MissingSourceFiles []MissingSourceFile `json:"missingSourceFiles,omitempty"`
+	DeclaredTotals     *DeclaredTotals     `json:"declaredTotals,omitempty"` // Totals stated by the report itself
+}
+
+// Totals are the line and branch counts of a part of the model. The branch counts are
+// left out if the reports have no branch data for it.
+type Totals struct {
+	LinesCovered    int  `json:"linesCovered"`
+	LinesValid      int  `json:"linesValid"`
+	BranchesCovered *int `json:"branchesCovered,omitempty"`
+	BranchesValid   *int `json:"branchesValid,omitempty"`
+}
+
+// DeclaredTotals are the totals a report states for itself, e.g. the lines-covered and
+// line-rate attributes of Cobertura. Values the report does not state are left out.
+type DeclaredTotals struct {
+	LinesCovered    *int     `json:"linesCovered,omitempty"`
+	LinesValid      *int     `json:"linesValid,omitempty"`
+	BranchesCovered *int     `json:"branchesCovered,omitempty"`
+	BranchesValid   *int     `json:"branchesValid,omitempty"`
+	LineRate        *float64 `json:"lineRate,omitempty"`
+	BranchRate      *float64 `json:"branchRate,omitempty"`
+}
+
+// DirectoryCoverage is a node of the directory tree of the code files, with the coverage
+// summed over all files below it.
+type DirectoryCoverage struct {
+	Name            string               `json:"name"`
+	Path            string               `json:"path"`  // Slash-separated path within the tree, "" for the root
+	Files           int                  `json:"files"` // Unique files below the node
+	LinesCovered    int                  `json:"linesCovered"`
+	LinesValid      int                  `json:"linesValid"`
+	BranchesCovered int                  `json:"branchesCovered"`
+	BranchesValid   int                  `json:"branchesValid"`
+	HasBranchData   bool                 `json:"hasBranchData"`
+	Children        []*DirectoryCoverage `json:"children,omitempty"`
+}
+
+// MissingSourceFile is a source file referenced by a report that could not be found.
+type MissingSourceFile struct {
+	Path     string `json:"path"` // Path as the report lists it
+	Assembly string `json:"assembly"`
+	Class    string `json:"class"`
+}
+
+// SkippedReport is a report file that could not be parsed.
+type SkippedReport struct {
+	Path   string `json:"path"`
+	Parser string `json:"parser,omitempty"` // Empty if no parser supports the file
+	Error  string `json:"error"`
+}
+
+type Assembly struct {
+	Name    string  `json:"name"`
+	Classes []Class `json:"classes"`
+	Totals
+	TotalLines int      `json:"totalLines"`           // Unique files counted once
+	Complexity *float64 `json:"complexity,omitempty"` // Complexity the report declares for the assembly
+}
+
+type Class struct {
+	Name        string     `json:"name"` // Name as the report lists it
+	DisplayName string     `json:"displayName"`
+	Files       []CodeFile `json:"files"`
+	Methods     []Method   `json:"methods,omitempty"`
+	Totals
+	TotalLines          int                `json:"totalLines"`
+	CoveredMethods      int                `json:"coveredMethods"`
+	FullyCoveredMethods int                `json:"fullyCoveredMethods"`
+	TotalMethods        int                `json:"totalMethods"`
+	Metrics             map[string]float64 `json:"metrics,omitempty"`    // Method metrics aggregated by name
+	Complexity          *float64           `json:"complexity,omitempty"` // Complexity the report declares for the class
+	HistoricCoverages   []HistoricCoverage `json:"historicCoverages,omitempty"`
+	CoverageAge         *CoverageAge       `json:"coverageAge,omitempty"`
+}
+
+type CodeFile struct {
+	Path                      string         `json:"path"`
+	ReportPath                string         `json:"reportPath,omitempty"` // Path as the report lists it, if the file was found under another one
+	InSourceDirs              bool           `json:"inSourceDirs"`
+	Lines                     []Line         `json:"lines"`
+	CoveredLines              int            `json:"coveredLines"`
+	CoverableLines            int            `json:"coverableLines"`
+	TotalLines                int            `json:"totalLines"`
+	ApproximateBranchCoverage bool           `json:"approximateBranchCoverage"`
+	MethodMetrics             []MethodMetric `json:"methodMetrics,omitempty"`
+	CodeElements              []CodeElement  `json:"codeElements,omitempty"`
+}
+
+type Line struct {
+	Number            int            `json:"number"`
+	Hits              int            `json:"hits"` // -1 if the line is not coverable
+	Status            string         `json:"status" jsonschema:"enum=notCoverable|notCovered|partiallyCovered|covered"`
+	Content           string         `json:"content,omitempty"` // Source code, only with -dumpmodel-include-source
+	IsBranchPoint     bool           `json:"isBranchPoint"`
+	CoveredBranches   int            `json:"coveredBranches"`
+	TotalBranches     int            `json:"totalBranches"`
+	ConditionCoverage string         `json:"conditionCoverage,omitempty"`
+	Branches          []Branch       `json:"branches,omitempty"`
+	HitsByTest        map[string]int `json:"hitsByTest,omitempty"` // Hits by test name, if the report has per-test data
+}
+
+type Branch struct {
+	Identifier string `json:"identifier"`
+	Visits     int    `json:"visits"`
+	Type       string `json:"type,omitempty"` // Kind of the condition, e.g. "jump" or "switch"
+}
+
+type Method struct {
+	Name            string         `json:"name"`
+	Signature       string         `json:"signature"`
+	DisplayName     string         `json:"displayName"`
+	NestedClassName string         `json:"nestedClassName,omitempty"` // Raw name of the nested type the report lists the method in
+	FirstLine       int            `json:"firstLine"`
+	LastLine        int            `json:"lastLine"`
+	LineRate        float64        `json:"lineRate"`
+	BranchRate      *float64       `json:"branchRate,omitempty"`
+	Complexity      float64        `json:"complexity"`
+	Lines           []Line         `json:"lines,omitempty"`
+	MethodMetrics   []MethodMetric `json:"methodMetrics,omitempty"`
+}
+
+type CodeElement struct {
+	Name          string   `json:"name"`
+	FullName      string   `json:"fullName"`
+	Type          string   `json:"type" jsonschema:"enum=property|method"`
+	FirstLine     int      `json:"firstLine"`
+	LastLine      int      `json:"lastLine"`
+	CoverageQuota *float64 `json:"coverageQuota,omitempty"` // Percentage, left out if the element has no coverable lines
+	RawKey        string   `json:"rawKey,omitempty"`
+}
+
+// MethodMetric holds the metrics of a method.
+type MethodMetric struct {
+	Name    string   `json:"name"`
+	Line    int      `json:"line"`
+	Metrics []Metric `json:"metrics"`
+}
+
+type Metric struct {
+	Name   string   `json:"name"`
+	Value  *float64 `json:"value,omitempty"` // Left out if the value is not a finite number
+	Status string   `json:"status" jsonschema:"enum=ok|warning|error"`
+}
+
+// HistoricCoverage is the coverage of a class in an earlier run.
+type HistoricCoverage struct {
+	ExecutionTime       int64  `json:"executionTime"` // Unix seconds
+	Tag                 string `json:"tag,omitempty"`
+	CoveredLines        int    `json:"coveredLines"`
+	CoverableLines      int    `json:"coverableLines"`
+	TotalLines          int    `json:"totalLines"`
+	CoveredBranches     int    `json:"coveredBranches"`
+	TotalBranches       int    `json:"totalBranches"`
+	CoveredMethods      int    `json:"coveredMethods"`
+	FullyCoveredMethods int    `json:"fullyCoveredMethods"`
+	TotalMethods        int    `json:"totalMethods"`
+}
+
+// CoverageAge counts the runs in which the line coverage of a class was below a threshold.
+type CoverageAge struct {
+	Threshold float64 `json:"threshold"` // Line coverage in percent
+	Runs      int     `json:"runs"`      // Consecutive runs below the threshold, the current one included
+	Since     int64   `json:"since"`     // Unix seconds of the oldest of these runs
+}
+
+// Capabilities lists the parsers, report types and language formatters of a build
+// (-capabilities -capabilitiesformat json).
+type Capabilities struct {
+	SchemaVersion      string                `json:"schemaVersion" jsonschema:"pattern=^1\\.[0-9]+$"`
+	Parsers            []ParserCapability    `json:"parsers"`
+	ReportTypes        []string              `json:"reportTypes"`
+	LanguageFormatters []FormatterCapability `json:"languageFormatters"`
+}
+
+type ParserCapability struct {
+	Name          string `json:"name"`
+	DetectionHint string `json:"detectionHint,omitempty"`
+}
+
+type FormatterCapability struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+}
+
+// RunStatistics describes a run of the tool (-statsjson).
+type RunStatistics struct {
+	SchemaVersion  string  `json:"schemaVersion" jsonschema:"pattern=^1\\.[0-9]+$"`
+	ReportFiles    int     `json:"reportFiles"`
+	ParsedReports  int     `json:"parsedReports"`
+	SkippedReports int     `json:"skippedReports"`
+	FailedReports  int     `json:"failedReports"`
+	Assemblies     int     `json:"assemblies"`
+	Classes        int     `json:"classes"`
+	Files          int     `json:"files"`
+	DurationMs     int64   `json:"durationMs"`
+	Phases         []Phase `json:"phases"`
+}
+
+// Phase is the time spent in a phase of a run, such as parsing or writing the reports.
+type Phase struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"durationMs"`
+}
@@ -0,0 +1,146 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/IgorBayerl/ReportGenerator/go_report_generator/api/v1"
+)
+
+// TestSamples_StayReadable reads documents written by version 1.0 into the current types.
+// Removing, renaming or retyping a field breaks consumers and fails this test; the samples
+// must not be changed to make it pass.
+func TestSamples_StayReadable(t *testing.T) {
+	samples := []struct {
+		file string
+		doc  any
+	}{
+		{"summary.json", &v1.Summary{}},
+		{"parsedreport.json", &v1.ParsedReport{}},
+		{"capabilities.json", &v1.Capabilities{}},
+		{"runstatistics.json", &v1.RunStatistics{}},
+	}
+	for _, sample := range samples {
+		t.Run(sample.file, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("testdata", sample.file))
+			if err != nil {
+				t.Fatalf("failed to read the sample: %v", err)
+			}
+			decoder := json.NewDecoder(bytes.NewReader(content))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(sample.doc); err != nil {
+				t.Fatalf("the sample cannot be read anymore: %v", err)
+			}
+
+			// Writing the document again must keep every value of the sample.
+			written, err := json.Marshal(sample.doc)
+			if err != nil {
+				t.Fatalf("failed to write the document: %v", err)
+			}
+			var want, got any
+			if err := json.Unmarshal(content, &want); err != nil {
+				t.Fatalf("invalid sample: %v", err)
+			}
+			if err := json.Unmarshal(written, &got); err != nil {
+				t.Fatalf("invalid document: %v", err)
+			}
+			assertContains(t, "$", got, want)
+		})
+	}
+}
+
+// assertContains fails for every value of want that got lacks or has another value for.
+func assertContains(t *testing.T, path string, got, want any) {
+	t.Helper()
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			t.Errorf("%s: got %v, want an object", path, got)
+			return
+		}
+		for key, value := range w {
+			assertContains(t, path+"."+key, g[key], value)
+		}
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			t.Errorf("%s: got %v, want %d elements", path, got, len(w))
+			return
+		}
+		for i := range w {
+			assertContains(t, fmt.Sprintf("%s[%d]", path, i), g[i], w[i])
+		}
+	default:
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestSummarySample_Values(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "summary.json"))
+	if err != nil {
+		t.Fatalf("failed to read the sample: %v", err)
+	}
+	var summary v1.Summary
+	if err := json.Unmarshal(content, &summary); err != nil {
+		t.Fatalf("failed to read the sample: %v", err)
+	}
+
+	if !strings.HasPrefix(summary.SchemaVersion, "1.") {
+		t.Errorf("schemaVersion = %q, want 1.x", summary.SchemaVersion)
+	}
+	if summary.BranchesCovered == nil || *summary.BranchesCovered != 6 {
+		t.Errorf("branchesCovered = %v, want 6", summary.BranchesCovered)
+	}
+	class := summary.Assemblies[0].Classes[0]
+	line := class.Files[0].Lines[0]
+	if line.Status != v1.LinePartiallyCovered || line.HitsByTest["Shop.Tests.Add"] != 2 || len(line.Branches) != 2 {
+		t.Errorf("unexpected line %+v", line)
+	}
+	metric := class.Files[0].MethodMetrics[0].Metrics[0]
+	if metric.Value == nil || metric.Status == "" {
+		t.Errorf("unexpected metric %+v", metric)
+	}
+	if class.Files[0].CodeElements[0].Type != v1.CodeElementMethod {
+		t.Errorf("code element type = %q, want %q", class.Files[0].CodeElements[0].Type, v1.CodeElementMethod)
+	}
+	if class.CoverageAge == nil || len(class.HistoricCoverages) == 0 {
+		t.Error("the history of the class is missing")
+	}
+}
+
+func TestParsedReportSample_Values(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "parsedreport.json"))
+	if err != nil {
+		t.Fatalf("failed to read the sample: %v", err)
+	}
+	var report v1.ParsedReport
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatalf("failed to read the sample: %v", err)
+	}
+
+	if !strings.HasPrefix(report.SchemaVersion, "1.") {
+		t.Errorf("schemaVersion = %q, want 1.x", report.SchemaVersion)
+	}
+	if report.ReportFile == "" || !report.SupportsBranchCoverage || report.MinimumTimestamp == "" {
+		t.Errorf("unexpected report %+v", report)
+	}
+	line := report.Assemblies[0].Classes[0].Files[0].Lines[1]
+	if line.Status != v1.LinePartiallyCovered || len(line.Branches) != 2 {
+		t.Errorf("unexpected line %+v", line)
+	}
+	if d := report.DeclaredTotals; d == nil || d.LineRate == nil || *d.LineRate != 0.5 {
+		t.Errorf("declaredTotals = %+v, want a line rate of 0.5", d)
+	}
+	if len(report.MissingSourceFiles) != 1 {
+		t.Errorf("missingSourceFiles = %v, want one file", report.MissingSourceFiles)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	v1 "github.com/IgorBayerl/ReportGenerator/go_report_generator/api/v1"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+)
+
+// collectCapabilities lists what this build of the tool supports (-capabilities).
+func collectCapabilities(parserFactory *parsers.ParserFactory, langFactory *language.ProcessorFactory) v1.Capabilities {
+	caps := v1.Capabilities{SchemaVersion: v1.SchemaVersion, ReportTypes: reportconfig.SupportedReportTypes()}
+
+	for _, p := range parserFactory.Parsers() {
+		pc := v1.ParserCapability{Name: p.Name()}
+		if hinter, ok := p.(parsers.DetectionHinter); ok {
+			pc.DetectionHint = hinter.DetectionHint()
+		}
+		caps.Parsers = append(caps.Parsers, pc)
+	}
+
+	for _, p := range langFactory.Processors() {
+		fc := v1.FormatterCapability{Name: p.Name(), Extensions: []string{}}
+		if provider, ok := p.(language.ExtensionProvider); ok {
+			fc.Extensions = provider.FileExtensions()
+		}
+		caps.LanguageFormatters = append(caps.LanguageFormatters, fc)
+	}
+	return caps
+}
+
+// writeCapabilities prints the capabilities as a human-readable listing ("text") or as JSON ("json").
+func writeCapabilities(w io.Writer, caps v1.Capabilities, format string) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(caps)
+	case "", "text":
+	default:
+		return fmt.Errorf("unsupported capabilities format '%s' (expected text or json)", format)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Parsers:")
+	for _, p := range caps.Parsers {
+		fmt.Fprintf(tw, "  %s\t%s\n", p.Name, p.DetectionHint)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Report types:")
+	for _, t := range caps.ReportTypes {
+		fmt.Fprintf(tw, "  %s\n", t)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Language formatters:")
+	for _, f := range caps.LanguageFormatters {
+		extensions := strings.Join(f.Extensions, ", ")
+		if extensions == "" {
+			extensions = "(fallback for all other files)"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", f.Name, extensions)
+	}
+	return tw.Flush()
+}
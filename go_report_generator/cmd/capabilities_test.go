@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/IgorBayerl/ReportGenerator/go_report_generator/api/v1"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// TestWriteCapabilities_Golden compares the -capabilities output of this build with the
+// golden files. Run `go test ./cmd -update` after adding a parser, report type or formatter.
+func TestWriteCapabilities_Golden(t *testing.T) {
+	caps := collectCapabilities(newParserFactory(filereader.NewDefaultReader()), newLanguageProcessorFactory())
+
+	for _, format := range []string{"text", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := writeCapabilities(&out, caps, format); err != nil {
+				t.Fatalf("writeCapabilities returned error: %v", err)
+			}
+
+			golden := filepath.Join("testdata", "capabilities."+format+".golden")
+			if *updateGolden {
+				if err := os.WriteFile(golden, out.Bytes(), 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if !bytes.Equal(out.Bytes(), want) {
+				t.Errorf("capabilities output does not match %s:\n--- got ---\n%s\n--- want ---\n%s", golden, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestWriteCapabilities_UnknownFormat(t *testing.T) {
+	if err := writeCapabilities(&bytes.Buffer{}, v1.Capabilities{}, "xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// redacted replaces secrets in the command line written into the reports.
+const redacted = "xxxxx"
+
+// secretNamePattern matches the names of flags and URL query parameters whose values
+// are not written into the reports.
+var secretNamePattern = regexp.MustCompile(`(?i)token|secret|passw(or)?d|apikey|api_key|credential|signature|sig$`)
+
+// sanitizedCommandLine joins the program name and its arguments as they are recorded in
+// the reports. The values of flags and query parameters named like secrets and the
+// passwords of URLs are replaced with "xxxxx"; arguments with spaces are quoted.
+func sanitizedCommandLine(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := []string{filepath.Base(args[0])}
+	redactNext := false
+	for _, arg := range args[1:] {
+		switch {
+		case redactNext:
+			arg = redacted
+			redactNext = false
+		case strings.HasPrefix(arg, "-"):
+			name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+			switch {
+			case !secretNamePattern.MatchString(name):
+				if hasValue {
+					arg = arg[:len(arg)-len(value)] + sanitizeValue(value)
+				}
+			case hasValue:
+				arg = arg[:len(arg)-len(value)] + redacted
+			default:
+				redactNext = true
+			}
+		default:
+			arg = sanitizeValue(arg)
+		}
+		parts = append(parts, quoteArg(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// sanitizeValue removes the password and the secret query parameters of a URL. Other
+// values are returned unchanged.
+func sanitizeValue(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return value
+	}
+	query := u.Query()
+	changed := false
+	for key := range query {
+		if secretNamePattern.MatchString(key) {
+			query.Set(key, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
+
+// quoteArg quotes arguments that would be split or misread by a shell.
+func quoteArg(arg string) string {
+	if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+		return strconv.Quote(arg)
+	}
+	return arg
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+
+	"gopkg.in/yaml.v3"
+)
+
+// applyConfigFile sets the flags of fs that were not given on the command line from the
+// configuration file of -config, or from the first of reportconfig.ConfigFileNames in dir.
+// Flags on the command line take precedence over the file, the file over the defaults.
+func (f *cliFlags) applyConfigFile(fs *flag.FlagSet, dir string) error {
+	path, err := reportconfig.FindConfigFile(*f.configFile, dir)
+	if err != nil || path == "" {
+		return err
+	}
+	fileConfig, unknown, err := reportconfig.LoadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	commandLine := make(map[string]string)
+	fs.Visit(func(fl *flag.Flag) {
+		commandLine[fl.Name] = fl.Value.String()
+	})
+	for name, value := range reportconfig.MergeConfigValues(fileConfig.Values(), commandLine) {
+		if _, given := commandLine[name]; given {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid value %q of %s in configuration file %s: %w", value, name, path, err)
+		}
+	}
+	f.configFilePath = path
+	f.unknownConfigKeys = unknown
+	return nil
+}
+
+// writeEffectiveConfig writes the values of all configuration keys of fs as a YAML
+// configuration file. With -excludetests, the test excludes it adds to the filters are
+// listed in comments, so that the file can be read back without doubling them.
+func writeEffectiveConfig(w io.Writer, fs *flag.FlagSet) error {
+	values := make(map[string]string)
+	for _, key := range reportconfig.ConfigKeys() {
+		if fl := fs.Lookup(key); fl != nil {
+			values[key] = fl.Value.String()
+		}
+	}
+	effective, err := reportconfig.ConfigFromValues(values)
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(effective); err != nil {
+		return fmt.Errorf("failed to write the configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	if values["excludetests"] == "true" {
+		return writeTestExcludes(w)
+	}
+	return nil
+}
+
+// writeTestExcludes lists the filters -excludetests adds per parser as YAML comments.
+func writeTestExcludes(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# excludetests adds these filters to the filters above, by parser:\n")
+	for _, parser := range reportconfig.TestExcludeParsers() {
+		excludes := reportconfig.TestExcludesForParser(parser)
+		if len(excludes.AssemblyFilters) > 0 {
+			fmt.Fprintf(&b, "#   %s assemblyfilters: %s\n", parser, strings.Join(excludes.AssemblyFilters, ";"))
+		}
+		if len(excludes.FileFilters) > 0 {
+			fmt.Fprintf(&b, "#   %s filefilters: %s\n", parser, strings.Join(excludes.FileFilters, ";"))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
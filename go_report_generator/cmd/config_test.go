@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/logging"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// parseTestFlags registers the flags on a new flag set, parses args and applies the
+// configuration file like parseFlags does with the working directory dir.
+func parseTestFlags(t *testing.T, dir string, args ...string) (*cliFlags, *flag.FlagSet, error) {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	f := newCLIFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("failed to parse flags %v: %v", args, err)
+	}
+	return f, fs, f.applyConfigFile(fs, dir)
+}
+
+func writeTestConfig(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write configuration file: %v", err)
+	}
+	return path
+}
+
+func TestConfigFile_CommandLineTakesPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestConfig(t, dir, "custom.yaml", `report:
+  - coverage/a.xml
+  - coverage/b.xml
+reporttypes: [Html, TextSummary]
+title: From file
+tag: build-1
+maxhistoryfiles: 5
+metricthresholds:
+  CrapScore: "20:60"
+unknownsetting: 1
+`)
+
+	f, _, err := parseTestFlags(t, dir, "-config="+path, "-title=From command line", "-maxhistoryfiles=0")
+	if err != nil {
+		t.Fatalf("applyConfigFile returned error: %v", err)
+	}
+
+	checks := []struct{ name, got, want string }{
+		{"report", *f.reportsPatterns, "coverage/a.xml;coverage/b.xml"},
+		{"reporttypes", *f.reportTypes, "Html,TextSummary"},
+		{"title", *f.title, "From command line"},
+		{"tag", *f.tag, "build-1"},
+		{"metricthresholds", *f.metricThresholds, "CrapScore=20:60"},
+		{"output", *f.outputDir, "coverage-report"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("-%s = %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	if *f.maxHistoryFiles != 0 {
+		t.Errorf("-maxhistoryfiles = %d, want the command line value 0", *f.maxHistoryFiles)
+	}
+	if f.configFilePath != path || len(f.unknownConfigKeys) != 1 || f.unknownConfigKeys[0] != "unknownsetting" {
+		t.Errorf("configuration file %q with unknown keys %v, want %q with [unknownsetting]", f.configFilePath, f.unknownConfigKeys, path)
+	}
+}
+
+func TestConfigFile_DetectedInWorkingDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeTestConfig(t, dir, "reportgenerator.json", `{"sourcedirs": ["src", "lib"], "rawmode": true}`)
+
+	f, _, err := parseTestFlags(t, dir)
+	if err != nil {
+		t.Fatalf("applyConfigFile returned error: %v", err)
+	}
+	if *f.sourceDirs != "src,lib" || !*f.rawMode {
+		t.Errorf("-sourcedirs = %q, -rawmode = %v, want the values of the detected file", *f.sourceDirs, *f.rawMode)
+	}
+
+	f, _, err = parseTestFlags(t, t.TempDir())
+	if err != nil || f.configFilePath != "" {
+		t.Errorf("expected no configuration file, got %q, %v", f.configFilePath, err)
+	}
+}
+
+func TestConfigFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	malformed := writeTestConfig(t, dir, "malformed.yaml", "title: Backend\nreport:\n\t- a.xml\n")
+	invalidValue := writeTestConfig(t, dir, "invalid.yaml", "coveragequotarounding: ceil\nuncoveredlines: 3\n")
+
+	if _, _, err := parseTestFlags(t, dir, "-config="+malformed); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("expected an error pointing at line 3, got %v", err)
+	}
+	if _, _, err := parseTestFlags(t, dir, "-config="+filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing -config file")
+	}
+	// Values are validated like flags on the command line, when the run starts.
+	if f, _, err := parseTestFlags(t, dir, "-config="+invalidValue); err != nil || *f.quotaRounding != "ceil" {
+		t.Errorf("expected the value to be applied, got %q, %v", *f.quotaRounding, err)
+	}
+}
+
+func TestConfigKeys_MatchFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	newCLIFlags(fs)
+	notConfigurable := map[string]bool{"config": true, "printconfig": true, "capabilities": true, "capabilitiesformat": true, "version": true}
+
+	keys := make(map[string]bool)
+	for _, key := range reportconfig.ConfigKeys() {
+		keys[key] = true
+		if fs.Lookup(key) == nil {
+			t.Errorf("configuration key %q has no flag", key)
+		}
+	}
+	fs.VisitAll(func(fl *flag.Flag) {
+		if !keys[fl.Name] && !notConfigurable[fl.Name] {
+			t.Errorf("flag -%s has no configuration key", fl.Name)
+		}
+	})
+}
+
+func TestWriteEffectiveConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTestConfig(t, dir, "reportgenerator.yaml", "report: [a.xml, b.xml]\ntitle: From file\n")
+	_, fs, err := parseTestFlags(t, dir, "-tag=42")
+	if err != nil {
+		t.Fatalf("applyConfigFile returned error: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeEffectiveConfig(&out, fs); err != nil {
+		t.Fatalf("writeEffectiveConfig returned error: %v", err)
+	}
+
+	written := writeTestConfig(t, t.TempDir(), "reportgenerator.yaml", out.String())
+	cfg, unknown, err := reportconfig.LoadConfigFile(written)
+	if err != nil || len(unknown) != 0 {
+		t.Fatalf("the effective configuration cannot be read back: %v, unknown keys %v\n%s", err, unknown, out.String())
+	}
+	values := cfg.Values()
+	for key, want := range map[string]string{"report": "a.xml;b.xml", "title": "From file", "tag": "42", "output": "coverage-report", "reporttypes": "TextSummary,Html"} {
+		if values[key] != want {
+			t.Errorf("%s = %q, want %q\n%s", key, values[key], want, out.String())
+		}
+	}
+}
+
+func TestWriteEffectiveConfig_ListsTestExcludes(t *testing.T) {
+	_, fs, err := parseTestFlags(t, t.TempDir(), "-excludetests", "-assemblyfilters=-Legacy")
+	if err != nil {
+		t.Fatalf("applyConfigFile returned error: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeEffectiveConfig(&out, fs); err != nil {
+		t.Fatalf("writeEffectiveConfig returned error: %v", err)
+	}
+	for _, want := range []string{
+		"#   Cobertura assemblyfilters: -*.Tests;-*.Test;-*Tests\n",
+		"#   GoCover filefilters: -**/*_test.go;-**/testdata/**\n",
+		"#   LCov filefilters: -**/*.spec.*;-**/*.test.*;-**/__tests__/**\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in the configuration, got:\n%s", want, out.String())
+		}
+	}
+
+	written := writeTestConfig(t, t.TempDir(), "reportgenerator.yaml", out.String())
+	cfg, _, err := reportconfig.LoadConfigFile(written)
+	if err != nil {
+		t.Fatalf("the effective configuration cannot be read back: %v", err)
+	}
+	if got := cfg.Values()["assemblyfilters"]; got != "-Legacy" {
+		t.Errorf("assemblyfilters = %q, want only the filters of the user", got)
+	}
+}
+
+func TestCreateReportConfiguration_ValidatesTargetDirectory(t *testing.T) {
+	dir := t.TempDir()
+	outputFile := writeTestConfig(t, dir, "output", "")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	flags, _, err := parseTestFlags(t, dir, "-output", outputFile)
+	if err != nil {
+		t.Fatalf("failed to apply flags: %v", err)
+	}
+	if _, err := createReportConfiguration(flags, logging.Info, nil, nil, newLanguageProcessorFactory(), logger); err == nil || !strings.Contains(err.Error(), "is an existing file") {
+		t.Errorf("expected an error for an output directory that is a file, got %v", err)
+	}
+
+	flags, _, err = parseTestFlags(t, dir, "-output", outputFile, "-serve", ":0")
+	if err != nil {
+		t.Fatalf("failed to apply flags: %v", err)
+	}
+	if _, err := createReportConfiguration(flags, logging.Info, nil, nil, newLanguageProcessorFactory(), logger); err != nil {
+		t.Errorf("expected no check of the output directory with -serve, got %v", err)
+	}
+}
+
+func TestCreateReportConfiguration_PathCase(t *testing.T) {
+	dir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	flags, _, err := parseTestFlags(t, dir, "-output", filepath.Join(dir, "out"), "-pathcase", "Insensitive")
+	if err != nil {
+		t.Fatalf("failed to apply flags: %v", err)
+	}
+	reportConfig, err := createReportConfiguration(flags, logging.Info, nil, nil, newLanguageProcessorFactory(), logger)
+	if err != nil {
+		t.Fatalf("createReportConfiguration returned error: %v", err)
+	}
+	if got := reportConfig.Settings().PathCase; got != "insensitive" {
+		t.Errorf("PathCase = %q, want %q", got, "insensitive")
+	}
+
+	flags, _, err = parseTestFlags(t, dir, "-output", filepath.Join(dir, "out"), "-pathcase", "lower")
+	if err != nil {
+		t.Fatalf("failed to apply flags: %v", err)
+	}
+	if _, err := createReportConfiguration(flags, logging.Info, nil, nil, newLanguageProcessorFactory(), logger); err == nil || !strings.Contains(err.Error(), "-pathcase") {
+		t.Errorf("expected an error for an unknown path case mode, got %v", err)
+	}
+}
+
+func TestCreateReportConfiguration_FileRetryPolicy(t *testing.T) {
+	dir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	flags, _, err := parseTestFlags(t, dir, "-output", filepath.Join(dir, "out"), "-fileretries", "2", "-fileretrydelay", "50", "-fileretrytimeout", "3")
+	if err != nil {
+		t.Fatalf("failed to apply flags: %v", err)
+	}
+	reportConfig, err := createReportConfiguration(flags, logging.Info, nil, nil, newLanguageProcessorFactory(), logger)
+	if err != nil {
+		t.Fatalf("createReportConfiguration returned error: %v", err)
+	}
+	want := utils.FileRetryPolicy{Retries: 2, Delay: 50 * time.Millisecond, Timeout: 3 * time.Second}
+	if got := parsers.FileRetryPolicy(reportConfig); got.Retries != want.Retries || got.Delay != want.Delay || got.Timeout != want.Timeout {
+		t.Errorf("FileRetryPolicy = %+v, want %+v", got, want)
+	}
+
+	flags, _, err = parseTestFlags(t, dir, "-output", filepath.Join(dir, "out"), "-fileretries", "-1")
+	if err != nil {
+		t.Fatalf("failed to apply flags: %v", err)
+	}
+	if _, err := createReportConfiguration(flags, logging.Info, nil, nil, newLanguageProcessorFactory(), logger); err == nil || !strings.Contains(err.Error(), "-fileretries") {
+		t.Errorf("expected an error for a negative number of retries, got %v", err)
+	}
+}
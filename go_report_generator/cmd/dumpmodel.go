@@ -0,0 +1,182 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/dto"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// Modes of -dumpmodel.
+const (
+	dumpModelMerged  = "merged"
+	dumpModelPerFile = "perfile"
+)
+
+// modelDumpFilename is the file in the output directory the merged model is dumped to.
+const modelDumpFilename = "reportgenerator-model.json"
+
+// dumpModelValue is the value of -dumpmodel. It is a boolean flag, so that -dumpmodel
+// alone dumps the merged model, that also accepts -dumpmodel=perfile.
+type dumpModelValue struct {
+	mode string
+}
+
+// dumpModelFlag registers the -dumpmodel flag on fs.
+func dumpModelFlag(fs *flag.FlagSet, name, usage string) *dumpModelValue {
+	v := &dumpModelValue{}
+	fs.Var(v, name, usage)
+	return v
+}
+
+func (v *dumpModelValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.mode
+}
+
+func (v *dumpModelValue) Set(value string) error {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false":
+		v.mode = ""
+	case "true", dumpModelMerged:
+		v.mode = dumpModelMerged
+	case dumpModelPerFile:
+		v.mode = dumpModelPerFile
+	default:
+		return fmt.Errorf("unsupported mode %q (supported: %s, %s)", value, dumpModelMerged, dumpModelPerFile)
+	}
+	return nil
+}
+
+func (v *dumpModelValue) IsBoolFlag() bool { return true }
+
+// dumpParserResult writes the result of the index-th parsed report file (counting from 1)
+// into the output directory if -dumpmodel=perfile is set.
+func dumpParserResult(reportConfig *reportconfig.ReportConfiguration, index int, reportFile string, result *parsers.ParserResult) error {
+	appSettings := reportConfig.Settings()
+	if appSettings.DumpModel != dumpModelPerFile {
+		return nil
+	}
+	dump := *result
+	dump.Assemblies = dumpAssemblies(result.Assemblies, appSettings.DumpModelIncludeSource)
+	name := fmt.Sprintf("reportgenerator-model-%03d-%s.json", index, filepath.Base(reportFile))
+	return writeModelDump(reportConfig, name, dto.ParsedReport(reportFile, &dump))
+}
+
+// dumpSummary writes the merged model into the output directory if -dumpmodel is set.
+func dumpSummary(reportConfig *reportconfig.ReportConfiguration, summary *model.SummaryResult) error {
+	appSettings := reportConfig.Settings()
+	if appSettings.DumpModel == "" {
+		return nil
+	}
+	sorted := sortedSummary(summary, appSettings.DumpModelIncludeSource)
+	return writeModelDump(reportConfig, modelDumpFilename, dto.Summary(&sorted))
+}
+
+// writeModelDump writes the document v as indented JSON to the named file of the output directory.
+func writeModelDump(reportConfig *reportconfig.ReportConfiguration, name string, v any) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the model dump %s: %w", name, err)
+	}
+	dir := reportConfig.TargetDirectory()
+	if err := os.MkdirAll(utils.LongPath(dir), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(utils.LongPath(path), append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write the model dump: %w", err)
+	}
+	reportConfig.Logger().Info("Dumped coverage model", "file", path)
+	return nil
+}
+
+// sortedSummary returns a copy of summary whose assemblies are sorted like dumpAssemblies
+// sorts them, so that dumps of two runs can be compared with a diff.
+func sortedSummary(summary *model.SummaryResult, includeSource bool) model.SummaryResult {
+	sorted := *summary
+	sorted.Assemblies = dumpAssemblies(summary.Assemblies, includeSource)
+	return sorted
+}
+
+// dumpAssemblies returns a copy of the assemblies in which the assemblies, classes, files,
+// methods, code elements, metrics, lines and branches are sorted. Unless includeSource is
+// set, the source code of the lines is left out. The assemblies are not changed.
+func dumpAssemblies(assemblies []model.Assembly, includeSource bool) []model.Assembly {
+	result := slices.Clone(assemblies)
+	slices.SortStableFunc(result, func(a, b model.Assembly) int { return strings.Compare(a.Name, b.Name) })
+	for i := range result {
+		classes := slices.Clone(result[i].Classes)
+		slices.SortStableFunc(classes, func(a, b model.Class) int {
+			return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.DisplayName, b.DisplayName))
+		})
+		for j := range classes {
+			class := &classes[j]
+			class.Files = slices.Clone(class.Files)
+			slices.SortStableFunc(class.Files, func(a, b model.CodeFile) int { return strings.Compare(a.Path, b.Path) })
+			for k := range class.Files {
+				file := &class.Files[k]
+				file.Lines = dumpLines(file.Lines, includeSource)
+				file.MethodMetrics = dumpMethodMetrics(file.MethodMetrics)
+				file.CodeElements = slices.Clone(file.CodeElements)
+				slices.SortStableFunc(file.CodeElements, func(a, b model.CodeElement) int {
+					return cmp.Or(cmp.Compare(a.FirstLine, b.FirstLine), strings.Compare(a.FullName, b.FullName), strings.Compare(a.RawKey, b.RawKey))
+				})
+			}
+			class.Methods = slices.Clone(class.Methods)
+			slices.SortStableFunc(class.Methods, func(a, b model.Method) int {
+				return cmp.Or(cmp.Compare(a.FirstLine, b.FirstLine), strings.Compare(a.RawKey(), b.RawKey()))
+			})
+			for k := range class.Methods {
+				method := &class.Methods[k]
+				method.Lines = dumpLines(method.Lines, includeSource)
+				method.MethodMetrics = dumpMethodMetrics(method.MethodMetrics)
+			}
+		}
+		result[i].Classes = classes
+	}
+	return result
+}
+
+// dumpLines returns a copy of the lines sorted by number, with their branches sorted by
+// identifier and, unless includeSource is set, without their content.
+func dumpLines(lines []model.Line, includeSource bool) []model.Line {
+	result := slices.Clone(lines)
+	slices.SortStableFunc(result, func(a, b model.Line) int { return cmp.Compare(a.Number, b.Number) })
+	for i := range result {
+		if !includeSource {
+			result[i].Content = ""
+		}
+		result[i].Branch = slices.Clone(result[i].Branch)
+		slices.SortStableFunc(result[i].Branch, func(a, b model.BranchCoverageDetail) int {
+			return strings.Compare(a.Identifier, b.Identifier)
+		})
+	}
+	return result
+}
+
+// dumpMethodMetrics returns a copy of the method metrics sorted by line and name, with the
+// metrics of each sorted by name.
+func dumpMethodMetrics(methodMetrics []model.MethodMetric) []model.MethodMetric {
+	result := slices.Clone(methodMetrics)
+	slices.SortStableFunc(result, func(a, b model.MethodMetric) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), strings.Compare(a.Name, b.Name))
+	})
+	for i := range result {
+		result[i].Metrics = slices.Clone(result[i].Metrics)
+		slices.SortStableFunc(result[i].Metrics, func(a, b model.Metric) int { return strings.Compare(a.Name, b.Name) })
+	}
+	return result
+}
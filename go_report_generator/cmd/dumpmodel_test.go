@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	v1 "github.com/IgorBayerl/ReportGenerator/go_report_generator/api/v1"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/dto"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+// completeSummary returns a summary in which every field of the model is set, so that the
+// round trip test covers all of them. Fields added to the model must be set here too.
+func completeSummary() *model.SummaryResult {
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	line := func(number int, content string) model.Line {
+		return model.Line{
+			Number:        number,
+			Hits:          3,
+			IsBranchPoint: true,
+			Branch: []model.BranchCoverageDetail{
+				{Identifier: "1", Visits: 1, Type: "jump"},
+				{Identifier: "0", Visits: 3, Type: "jump"},
+			},
+			ConditionCoverage:        "50% (1/2)",
+			Content:                  content,
+			CoveredBranches:          1,
+			TotalBranches:            2,
+			LineCoverageByTestMethod: map[string]int{"Shop.Tests.Add": 2, "Shop.Tests.Remove": 1},
+			LineVisitStatus:          model.PartiallyCovered,
+		}
+	}
+	methodMetrics := []model.MethodMetric{{
+		Name: "Add(System.Int32)",
+		Line: 2,
+		Metrics: []model.Metric{
+			{Name: "Cyclomatic complexity", Value: 2.0, Status: model.StatusWarning},
+			{Name: "CrapScore", Value: 2.5, Status: model.StatusError},
+		},
+	}}
+	class := func(name, path string) model.Class {
+		return model.Class{
+			Name:        name,
+			DisplayName: name,
+			Files: []model.CodeFile{{
+				Path:                      "/src/" + path,
+				Lines:                     []model.Line{line(2, "  Add(x);"), line(1, "class Cart {")},
+				CoveredLines:              2,
+				CoverableLines:            2,
+				TotalLines:                2,
+				MethodMetrics:             methodMetrics,
+				CodeElements:              []model.CodeElement{{Name: "Add", FullName: "Add(System.Int32)", Type: model.MethodElementType, FirstLine: 2, LastLine: 2, CoverageQuota: floatPtr(100), RawKey: "Add(System.Int32)"}},
+				ApproximateBranchCoverage: true,
+				ReportPath:                path,
+				InSourceDirs:              true,
+			}},
+			Methods: []model.Method{{
+				Name:            "MoveNext",
+				Signature:       "()",
+				DisplayName:     "Add(System.Int32)",
+				LineRate:        1,
+				BranchRate:      floatPtr(0.5),
+				Complexity:      2,
+				Lines:           []model.Line{line(2, "  Add(x);")},
+				FirstLine:       2,
+				LastLine:        2,
+				MethodMetrics:   methodMetrics,
+				NestedClassName: name + "/<Add>d__1",
+			}},
+			LinesCovered:        2,
+			LinesValid:          2,
+			BranchesCovered:     intPtr(2),
+			BranchesValid:       intPtr(4),
+			TotalLines:          2,
+			CoveredMethods:      1,
+			FullyCoveredMethods: 1,
+			TotalMethods:        1,
+			Metrics:             map[string]float64{"Cyclomatic complexity": 2, "CrapScore": 2.5},
+			Complexity:          floatPtr(2),
+			HistoricCoverages:   []model.HistoricCoverage{{ExecutionTime: 1700000000, Tag: "build-1", CoveredLines: 1, CoverableLines: 2, TotalLines: 2, CoveredBranches: 1, TotalBranches: 4, CoveredMethods: 1, FullyCoveredMethods: 1, TotalMethods: 1}},
+			CoverageAge:         &model.CoverageAge{Threshold: 80, Runs: 2, Since: 1700000000},
+		}
+	}
+	return &model.SummaryResult{
+		ParserName: "Cobertura",
+		Timestamp:  1700000000,
+		SourceDirs: []string{"/src"},
+		Assemblies: []model.Assembly{
+			{Name: "Shop", Classes: []model.Class{class("Shop.Order", "Order.cs"), class("Shop.Cart", "Cart.cs")}, LinesCovered: 4, LinesValid: 4, BranchesCovered: intPtr(4), BranchesValid: intPtr(8), TotalLines: 4, Complexity: floatPtr(4)},
+			{Name: "Billing", Classes: []model.Class{class("Billing.Invoice", "Invoice.cs")}, LinesCovered: 2, LinesValid: 2, BranchesCovered: intPtr(2), BranchesValid: intPtr(4), TotalLines: 2, Complexity: floatPtr(2)},
+		},
+		LinesCovered:            6,
+		LinesValid:              6,
+		BranchesCovered:         intPtr(6),
+		BranchesValid:           intPtr(12),
+		TotalLines:              6,
+		MethodCoverageAvailable: true,
+		MissingSourceFiles:      []model.MissingSourceFile{{Path: "Gone.cs", Assembly: "Shop", Class: "Shop.Gone"}},
+		SkippedReports:          []model.SkippedReport{{Path: "broken.xml", Parser: "Cobertura", Error: "unexpected EOF"}},
+		HiddenClasses:           1,
+		ExternalFiles:           1,
+		Directories: &model.DirectoryCoverage{
+			Name: "/src", Path: "/src", Files: 3, LinesCovered: 6, LinesValid: 6, BranchesCovered: 6, BranchesValid: 12, HasBranchData: true,
+			Children: []*model.DirectoryCoverage{{Name: "Cart.cs", Path: "/src/Cart.cs", Files: 1, LinesCovered: 2, LinesValid: 2, BranchesCovered: 2, BranchesValid: 4, HasBranchData: true, Children: []*model.DirectoryCoverage{}}},
+		},
+	}
+}
+
+// assertAllFieldsSet fails for every zero field of v, with path naming the field, except
+// for the fields whose paths are optional.
+func assertAllFieldsSet(t *testing.T, path string, v reflect.Value, optional ...string) {
+	t.Helper()
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			t.Errorf("%s is nil", path)
+			return
+		}
+		assertAllFieldsSet(t, path, v.Elem(), optional...)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fieldPath := path + "." + v.Type().Field(i).Name
+			if v.Field(i).IsZero() {
+				if !slices.Contains(optional, fieldPath) {
+					t.Errorf("%s is not set", fieldPath)
+				}
+				continue
+			}
+			assertAllFieldsSet(t, fieldPath, v.Field(i), optional...)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			assertAllFieldsSet(t, path+"[]", v.Index(i), optional...)
+		}
+	}
+}
+
+func newDumpConfig(t *testing.T, reportFiles []string, outputDir string, appSettings *settings.Settings, opts ...reportconfig.Option) *reportconfig.ReportConfiguration {
+	t.Helper()
+	opts = append([]reportconfig.Option{
+		reportconfig.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSettings(appSettings),
+	}, opts...)
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, outputDir, opts...)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	return cfg
+}
+
+// TestDumpSummary_RoundTrip dumps a summary that sets every field of the model and reads
+// it back into the v1 document, so that fields the mapping leaves out are noticed.
+func TestDumpSummary_RoundTrip(t *testing.T) {
+	summary := completeSummary()
+	assertAllFieldsSet(t, "SummaryResult", reflect.ValueOf(summary))
+	// The leaves of the directory tree have no children, which the document leaves out.
+	assertAllFieldsSet(t, "v1.Summary", reflect.ValueOf(dto.Summary(summary)), "v1.Summary.Directories.Children[].Children")
+
+	dir := t.TempDir()
+	appSettings := settings.NewSettings()
+	appSettings.DumpModel = dumpModelMerged
+	appSettings.DumpModelIncludeSource = true
+	if err := dumpSummary(newDumpConfig(t, nil, dir, appSettings), summary); err != nil {
+		t.Fatalf("dumpSummary returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, modelDumpFilename))
+	if err != nil {
+		t.Fatalf("failed to read the dump: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	var got v1.Summary
+	if err := decoder.Decode(&got); err != nil {
+		t.Fatalf("failed to read the dump back: %v", err)
+	}
+	sorted := sortedSummary(summary, true)
+	if want := dto.Summary(&sorted); !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip changed the summary:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+// TestDumpSummary_DeterministicWithoutSource expects the dump not to depend on the order
+// of the assemblies, classes, lines and branches, and to leave out the source by default.
+func TestDumpSummary_DeterministicWithoutSource(t *testing.T) {
+	appSettings := settings.NewSettings()
+	appSettings.DumpModel = dumpModelMerged
+	dump := func(summary *model.SummaryResult) string {
+		t.Helper()
+		dir := t.TempDir()
+		if err := dumpSummary(newDumpConfig(t, nil, dir, appSettings), summary); err != nil {
+			t.Fatalf("dumpSummary returned error: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(dir, modelDumpFilename))
+		if err != nil {
+			t.Fatalf("failed to read the dump: %v", err)
+		}
+		return string(content)
+	}
+
+	summary := completeSummary()
+	first := dump(summary)
+	reversed := completeSummary()
+	reversed.Assemblies[0], reversed.Assemblies[1] = reversed.Assemblies[1], reversed.Assemblies[0]
+	classes := reversed.Assemblies[1].Classes
+	classes[0], classes[1] = classes[1], classes[0]
+	second := dump(reversed)
+
+	if first != second {
+		t.Errorf("dumps differ with the order of the model:\n%s\n---\n%s", first, second)
+	}
+	if strings.Contains(first, "Add(x);") {
+		t.Error("dump contains the source code without -dumpmodel-include-source")
+	}
+	if strings.Index(first, `"Billing"`) > strings.Index(first, `"Shop"`) {
+		t.Error("assemblies are not sorted by name")
+	}
+	if summary.Assemblies[0].Classes[0].Files[0].Lines[0].Content == "" {
+		t.Error("dumpSummary changed the summary")
+	}
+}
+
+func TestParseAndMergeReports_DumpModelPerFile(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	outputDir := t.TempDir()
+	appSettings := settings.NewSettings()
+	appSettings.DumpModel = dumpModelPerFile
+	cfg := newDumpConfig(t, reportFiles, outputDir, appSettings, reportconfig.WithSourceDirectories([]string{srcDir}))
+
+	if _, err := parseAndMergeReports(cfg.Logger(), cfg, newParserFactory(filereader.NewDefaultReader()), nil); err != nil {
+		t.Fatalf("parseAndMergeReports returned error: %v", err)
+	}
+
+	for _, name := range []string{"reportgenerator-model-001-coverage.xml.json", "reportgenerator-model-002-cover.out.json"} {
+		content, err := os.ReadFile(filepath.Join(outputDir, name))
+		if err != nil {
+			t.Fatalf("failed to read the dump of a report file: %v", err)
+		}
+		var dump v1.ParsedReport
+		if err := json.Unmarshal(content, &dump); err != nil {
+			t.Fatalf("invalid dump %s: %v", name, err)
+		}
+		if dump.SchemaVersion != v1.SchemaVersion || dump.ReportFile == "" || dump.ParserName == "" || len(dump.Assemblies) == 0 {
+			t.Errorf("dump %s lacks the report file, parser or assemblies: %+v", name, dump)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, modelDumpFilename))
+	if err != nil {
+		t.Fatalf("failed to read the merged dump: %v", err)
+	}
+	if !strings.Contains(string(content), `"reportPath": "Assembly0/Class0.cs"`) {
+		t.Error("merged dump lacks the path of a file as the report lists it")
+	}
+}
+
+func TestDumpModelValue(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	flags := newCLIFlags(fs)
+	if err := fs.Parse([]string{"-dumpmodel"}); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if got := flags.dumpModel.String(); got != dumpModelMerged {
+		t.Errorf("-dumpmodel = %q, want %q", got, dumpModelMerged)
+	}
+	if err := fs.Parse([]string{"-dumpmodel=perfile"}); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if got := flags.dumpModel.String(); got != dumpModelPerFile {
+		t.Errorf("-dumpmodel=perfile = %q, want %q", got, dumpModelPerFile)
+	}
+	if err := fs.Parse([]string{"-dumpmodel=everything"}); err == nil {
+		t.Error("expected an error for an unsupported mode")
+	}
+}
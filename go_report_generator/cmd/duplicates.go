@@ -0,0 +1,61 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+)
+
+// duplicateReportDetector recognizes coverage reports that are passed more than once, e.g.
+// when CI copies the same artifact to several locations. Merging such reports would count
+// their coverage twice.
+type duplicateReportDetector struct {
+	contentHashes map[string]string // content hash -> first report file
+	fingerprints  map[string]string // coverage fingerprint -> first report file
+}
+
+func newDuplicateReportDetector() *duplicateReportDetector {
+	return &duplicateReportDetector{
+		contentHashes: make(map[string]string),
+		fingerprints:  make(map[string]string),
+	}
+}
+
+// sameContentAs returns the earlier report file with exactly the same content as
+// reportFile, or "" if there is none. It is checked before parsing.
+func (d *duplicateReportDetector) sameContentAs(reportFile string) (string, error) {
+	f, err := os.Open(reportFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to open report file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash report file: %w", err)
+	}
+	return firstOrRecord(d.contentHashes, hex.EncodeToString(h.Sum(nil)), reportFile), nil
+}
+
+// sameCoverageAs returns the earlier report file whose parsed coverage is identical to
+// result, or "" if there is none. This catches duplicates that differ only in e.g.
+// timestamps or source paths.
+func (d *duplicateReportDetector) sameCoverageAs(reportFile string, result *parsers.ParserResult) string {
+	if len(result.Assemblies) == 0 {
+		return "" // Empty reports are not worth a warning
+	}
+	return firstOrRecord(d.fingerprints, analyzer.CoverageFingerprint(result), reportFile)
+}
+
+func firstOrRecord(seen map[string]string, key, reportFile string) string {
+	if first, ok := seen[key]; ok {
+		return first
+	}
+	seen[key] = reportFile
+	return ""
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+const duplicateTestReport = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.5" branch-rate="1" timestamp="%TIMESTAMP%" version="1.9">
+  <sources><source>%SOURCE%</source></sources>
+  <packages>
+    <package name="MyAssembly" line-rate="0.5" branch-rate="1">
+      <classes>
+        <class name="MyAssembly.Calc" filename="Calc.cs" line-rate="0.5" branch-rate="1">
+          <methods />
+          <lines>
+            <line number="3" hits="2" branch="false" />
+            <line number="4" hits="0" branch="false" />
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+func writeDuplicateTestReport(t *testing.T, path, timestamp, source string) string {
+	t.Helper()
+	content := strings.NewReplacer("%TIMESTAMP%", timestamp, "%SOURCE%", source).Replace(duplicateTestReport)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	return path
+}
+
+func parseReportsForTest(t *testing.T, reportFiles []string, failOnDuplicates bool) (int, error) {
+	t.Helper()
+	appSettings := settings.NewSettings()
+	appSettings.FailOnDuplicateReports = failOnDuplicates
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, t.TempDir(),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSettings(appSettings),
+	)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+
+	summary, err := parseAndMergeReports(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+	if err != nil {
+		return 0, err
+	}
+	return summary.LinesCovered, nil
+}
+
+func TestParseAndMergeReports_SkipsDuplicateReports(t *testing.T) {
+	dir := t.TempDir()
+	original := writeDuplicateTestReport(t, filepath.Join(dir, "coverage.xml"), "1700000000", "/agent1/src")
+	copied := filepath.Join(dir, "artifacts", "coverage.xml")
+	if err := os.MkdirAll(filepath.Dir(copied), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeDuplicateTestReport(t, copied, "1700000000", "/agent1/src")
+	rerun := writeDuplicateTestReport(t, filepath.Join(dir, "rerun.xml"), "1700000500", "/agent2/src")
+
+	single, err := parseReportsForTest(t, []string{original}, false)
+	if err != nil {
+		t.Fatalf("parsing a single report failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		reports []string
+	}{
+		{"SameContent", []string{original, copied}},
+		{"SameCoverageData", []string{original, rerun}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			linesCovered, err := parseReportsForTest(t, tc.reports, false)
+			if err != nil {
+				t.Fatalf("parseAndMergeReports returned error: %v", err)
+			}
+			if linesCovered != single {
+				t.Errorf("LinesCovered = %d, want %d as for the single report", linesCovered, single)
+			}
+
+			if _, err := parseReportsForTest(t, tc.reports, true); err == nil {
+				t.Error("expected an error with FailOnDuplicateReports")
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/history"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/runstats"
+)
+
+// runHistory is the history of a run with a -historydir: the store of the directory and
+// the snapshot of the current run, which is saved once the reports were written.
+type runHistory struct {
+	store   *history.Store
+	current history.Snapshot
+}
+
+// loadHistory reads the history directory, applying the retention settings, and sets the
+// HistoricCoverages and the CoverageAge of the classes from the earlier runs and the
+// current one. It returns nil if no history directory is configured.
+func loadHistory(reportCtx reporter.IBuilderContext, summary *model.SummaryResult, stats *runstats.Stats) (*runHistory, error) {
+	reportConfig := reportCtx.ReportConfiguration()
+	dir := reportConfig.HistoryDirectory()
+	if dir == "" {
+		return nil, nil
+	}
+	defer stats.Start("history")()
+	appSettings := reportCtx.Settings()
+	store := history.NewStore(dir,
+		history.WithFileNamePrefix(appSettings.HistoryFileNamePrefix),
+		history.WithMaxFiles(appSettings.MaximumNumberOfHistoricCoverageFiles),
+		history.WithRetentionDays(appSettings.HistoryRetentionDays),
+		history.WithPrune(appSettings.PruneHistory),
+		history.WithClock(reportCtx.Now),
+		history.WithLogger(reportCtx.Logger()),
+	)
+	snapshots, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	current := history.NewSnapshot(summary, reportCtx.Now(), reportConfig.Tag())
+	snapshots = append(snapshots, current)
+	history.Apply(summary, snapshots)
+	history.ApplyCoverageAge(summary, snapshots, appSettings.CoverageAgeThreshold)
+	reportCtx.Logger().Info("Loaded coverage history", "directory", dir, "files", len(snapshots)-1)
+	return &runHistory{store: store, current: current}, nil
+}
+
+// save writes the history file of the current run.
+func (h *runHistory) save(reportCtx reporter.IBuilderContext, stats *runstats.Stats) error {
+	if h == nil {
+		return nil
+	}
+	defer stats.Start("history")()
+	path, err := h.store.Save(h.current)
+	if err != nil {
+		return fmt.Errorf("failed to save coverage history: %w", err)
+	}
+	reportCtx.Logger().Info("Saved coverage history", "file", path)
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+// runWithHistory runs the pipeline like run() with a history directory at executionTime
+// and returns the summary the reports were generated from.
+func runWithHistory(t *testing.T, reportFiles []string, srcDir, historyDir string, appSettings *settings.Settings, executionTime time.Time) *model.SummaryResult {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, t.TempDir(),
+		reportconfig.WithLogger(logger),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSourceDirectories([]string{srcDir}),
+		reportconfig.WithHistoryDirectory(historyDir),
+		reportconfig.WithReportTypes([]string{"Html"}),
+		reportconfig.WithSettings(appSettings),
+	)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+	if err != nil {
+		t.Fatalf("parseAndMergeReports returned error: %v", err)
+	}
+
+	ctx := reporter.NewBuilderContext(cfg, cfg.Settings(), logger, reporter.WithClock(func() time.Time { return executionTime }))
+	runHistory, err := loadHistory(ctx, summary, nil)
+	if err != nil {
+		t.Fatalf("loadHistory returned error: %v", err)
+	}
+	if err := generateReports(ctx, summary, nil, nil); err != nil {
+		t.Fatalf("generateReports returned error: %v", err)
+	}
+	if err := runHistory.save(ctx, nil); err != nil {
+		t.Fatalf("save returned error: %v", err)
+	}
+	return summary
+}
+
+func TestHistory_RunsAddAndPruneHistoryFiles(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	historyDir := filepath.Join(t.TempDir(), "history")
+	appSettings := settings.NewSettings()
+	appSettings.MaximumNumberOfHistoricCoverageFiles = 2
+	appSettings.PruneHistory = true
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+
+	var summary *model.SummaryResult
+	for day := 0; day < 4; day++ {
+		summary = runWithHistory(t, reportFiles, srcDir, historyDir, appSettings, start.AddDate(0, 0, day))
+	}
+
+	entries, err := os.ReadDir(historyDir)
+	if err != nil {
+		t.Fatalf("failed to read history directory: %v", err)
+	}
+	// The last run read the 2 newest of 3 files, pruned the oldest and saved its own.
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	want := []string{"2024-05-02_08-00-00_CoverageHistory.xml", "2024-05-03_08-00-00_CoverageHistory.xml", "2024-05-04_08-00-00_CoverageHistory.xml"}
+	if len(names) != len(want) {
+		t.Fatalf("history files = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("history files = %v, want %v", names, want)
+			break
+		}
+	}
+
+	class := summary.Assemblies[0].Classes[0]
+	if len(class.HistoricCoverages) != 3 {
+		t.Fatalf("expected 2 earlier runs and the current one in the history of %s, got %d", class.Name, len(class.HistoricCoverages))
+	}
+	if last := class.HistoricCoverages[2]; last.ExecutionTime != start.AddDate(0, 0, 3).Unix() || last.CoverableLines != class.LinesValid {
+		t.Errorf("last history entry = %+v, want the current run", last)
+	}
+}
+
+func TestHistory_DisabledWithoutHistoryDirectory(t *testing.T) {
+	cfg, err := reportconfig.NewReportConfiguration(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	runHistory, err := loadHistory(reporter.NewBuilderContext(cfg, cfg.Settings(), nil), &model.SummaryResult{}, nil)
+	if err != nil || runHistory != nil {
+		t.Errorf("loadHistory() = %v, %v, want no history", runHistory, err)
+	}
+}
@@ -2,14 +2,20 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
@@ -17,14 +23,23 @@ import (
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/glob"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/logging"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/progress"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/version"
 
 	// reporters
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/badges"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/clover"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/deltasummary"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/htmlreport"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/htmlserve"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/lcov"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/textsummary"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/xmlsummary"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/runstats"
 
 	// language specific behaviours
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
@@ -36,63 +51,229 @@ import (
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers/cobertura"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers/gocover"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers/vscoverage"
 )
 
 type cliFlags struct {
 	// domain
 	reportsPatterns   *string
 	outputDir         *string
+	outputSubdirs     *bool
+	textSummaryFile   *string
 	reportTypes       *string
+	compareWith       *string
 	sourceDirs        *string
+	sourceRootHints   *string
+	autoDiscover      *bool
+	storeSources      *bool
+	sourceEncoding    *string
+	converter         *string
+	converterTimeout  *int
+	rawMode           *bool
+	keepNested        *bool
+	excludeGenerated  *bool
+	excludeTests      *bool
+	goApproxBranches  *bool
+	fullMethodRate    *float64
+	fullMethodBranch  *bool
+	exclusionComments *bool
+	exclusionMarkers  *string
+	assemblyGrouping  *int
+	uncoveredLines    *int
+	quotaRounding     *string
+	metricThresholds  *string
+	metrics           *string
+	failOnMissingSrc  *bool
+	mergeMode         *string
+	failOnDuplicates  *bool
+	failOnParseError  *bool
+	totalsTolerance   *float64
+	languageFormatter *string
 	tag               *string
+	tagLink           *string
 	title             *string
 	assemblyFilters   *string
 	classFilters      *string
 	fileFilters       *string
+	methodFilters     *string
 	rhAssemblyFilters *string
 	rhClassFilters    *string
+	classCoverage     *string
+	recomputeAggr     *bool
+	language          *string
+	translationsFile  *string
+	syntaxHighlight   *bool
+	maxLineLength     *int
+	minifiedLength    *int
+	summarySort       *string
+	summaryTopN       *int
+	incremental       *bool
+	serve             *string
+	longPaths         *bool
+	fileRetries       *int
+	fileRetryDelay    *int
+	fileRetryTimeout  *int
+	pathCase          *string
+	resolveSymlinks   *bool
+	mergeVendored     *bool
+	excludeExternal   *bool
+	strict            *bool
+	dumpModel         *dumpModelValue
+	dumpModelSource   *bool
+	historyDir        *string
+	maxHistoryFiles   *int
+	historyRetention  *int
+	pruneHistory      *bool
+	maxHistoryPoints  *int
+	coverageAge       *float64
+
+	// configuration file
+	configFile        *string
+	printConfig       *bool
+	configFilePath    string   // Configuration file applied by applyConfigFile, "" if none
+	unknownConfigKeys []string // Keys of the configuration file that are no flags
+
+	// informational
+	capabilities       *bool
+	capabilitiesFormat *string
+	version            *bool
 
 	// logging
 	verbose   *bool
 	verbosity *string
+	quiet     *bool
+	statsJSON *bool
 	logFile   *string
 	logFormat *string
 }
 
 func parseFlags() (*cliFlags, error) {
+	f := newCLIFlags(flag.CommandLine)
+	flag.Parse()
+	if err := f.applyConfigFile(flag.CommandLine, "."); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// newCLIFlags registers the command line flags on fs.
+func newCLIFlags(fs *flag.FlagSet) *cliFlags {
 	f := &cliFlags{
 		// domain flags
-		reportsPatterns:   flag.String("report", "", "Coverage report file paths or patterns (semicolon-separated)"),
-		outputDir:         flag.String("output", "coverage-report", "Output directory for generated reports"),
-		reportTypes:       flag.String("reporttypes", "TextSummary,Html", "Report types (comma-separated)"),
-		sourceDirs:        flag.String("sourcedirs", "", "Source directories (comma-separated)"),
-		tag:               flag.String("tag", "", "Optional tag, e.g. build number"),
-		title:             flag.String("title", "", "Optional report title (default: 'Coverage Report')"),
-		assemblyFilters:   flag.String("assemblyfilters", "", "Assembly filters (+Include;-Exclude)"),
-		classFilters:      flag.String("classfilters", "", "Class filters"),
-		fileFilters:       flag.String("filefilters", "", "File filters"),
-		rhAssemblyFilters: flag.String("riskhotspotassemblyfilters", "", "Risk-hotspot assembly filters"),
-		rhClassFilters:    flag.String("riskhotspotclassfilters", "", "Risk-hotspot class filters"),
+		reportsPatterns:   fs.String("report", "", "Coverage report file paths or patterns (semicolon-separated)"),
+		outputDir:         fs.String("output", "coverage-report", "Output directory for generated reports"),
+		outputSubdirs:     fs.Bool("outputsubdirs", false, "Write each report type into its own subdirectory of the output directory"),
+		textSummaryFile:   fs.String("textsummaryfile", "Summary.txt", "File name of the TextSummary report"),
+		reportTypes:       fs.String("reporttypes", "TextSummary,Html", "Report types (comma-separated), optionally with parameters, e.g. Html{title=Frontend Coverage},TextSummary"),
+		compareWith:       fs.String("comparewith", "", "Baseline coverage report file paths or patterns (semicolon-separated) for the DeltaSummary report"),
+		sourceDirs:        fs.String("sourcedirs", "", "Source directories (comma-separated)"),
+		sourceRootHints:   fs.String("sourceroot-hint", "", "Extra roots (comma-separated) to look up the files of Cobertura reports in, after the <source> roots of each report and before -sourcedirs"),
+		autoDiscover:      fs.Bool("autodiscoversources", false, "Index source directories (or the working directory) to resolve report paths that cannot be found directly"),
+		storeSources:      fs.Bool("storesources", false, "Keep the whole source of the covered files in the coverage data, so the reports show the code without reading the source files again"),
+		sourceEncoding:    fs.String("sourceencoding", "", "Encoding of the source files that have no byte order mark and are not valid UTF-8, e.g. windows-1252 (default: none, their bytes are shown as they are)"),
+		converter:         fs.String("coverageconverter", "", "Converter for Visual Studio .coverage files: path of dotnet-coverage or Microsoft.CodeCoverage.Console (default: looked up in PATH)"),
+		converterTimeout:  fs.Int("coverageconvertertimeout", 600, "Seconds a conversion of a .coverage file may take before it is aborted (0: no limit)"),
+		rawMode:           fs.Bool("rawmode", false, "Keep nested/compiler-generated classes and their raw names instead of merging and cleaning them up"),
+		keepNested:        fs.Bool("keepnestedclasses", false, "Report nested classes separately (e.g. \"Outer.Inner\") instead of merging them into their outermost class; compiler-generated nested types are still merged"),
+		excludeGenerated:  fs.Bool("excludegeneratedcode", true, "Exclude generated files (*.pb.go, *.Designer.cs, *.generated.*, '// Code generated ... DO NOT EDIT.' headers); use -excludegeneratedcode=false to keep them"),
+		excludeTests:      fs.Bool("excludetests", false, "Exclude test assemblies and files with default filters per report format, in addition to the given filters (see -printconfig)"),
+		goApproxBranches:  fs.Bool("goapproximatebranchcoverage", false, "Approximate branch coverage of Go code from the if/switch/select statements and the blocks of the cover profile"),
+		fullMethodRate:    fs.Float64("fullmethodcoverage-minlinerate", settings.NewSettings().FullMethodCoverageMinimumLineRate, "Share of the coverable lines of a method (greater than 0, at most 1) that must be covered for it to count as fully covered"),
+		fullMethodBranch:  fs.Bool("fullmethodcoverage-branches", false, "Count a method with branches as fully covered only if all of its branches are covered, too"),
+		exclusionComments: fs.Bool("exclusioncomments", false, "Treat source lines marked by coverage exclusion comments (e.g. // coverage:ignore, // coverage:ignore-start ... // coverage:ignore-end) as not coverable"),
+		exclusionMarkers:  fs.String("exclusionmarkers", "", "Override the coverage exclusion comments of languages (semicolon-separated Language=line|start|end|next), e.g. Go=nocover|nocover-start|nocover-end|"),
+		languageFormatter: fs.String("languageformatter", "", "Force a language formatter for all files: csharp, go or default (default: detect by file extension)"),
+		assemblyGrouping:  fs.Int("assemblygrouping", 0, "Namespace levels used to group classes within an assembly (0: group by assembly only)"),
+		uncoveredLines:    fs.Int("uncoveredlines", 0, "List the uncovered line ranges of the N classes with the most uncovered lines in TextSummary and Html (0: disabled)"),
+		metricThresholds:  fs.String("metricthresholds", "", "Override method metric thresholds (semicolon-separated Name=warning[:error]), e.g. CrapScore=20:60;Cyclomatic complexity=10"),
+		metrics:           fs.String("metrics", "", "Define metric explanation links and column order (semicolon-separated Name=[url][,order]); unknown names add custom metrics, e.g. Mutation score=https://stryker-mutator.io/,5"),
+		quotaRounding:     fs.String("coveragequotarounding", "truncate", "Rounding of coverage quotas: truncate (default, like ReportGenerator), round or floor"),
+		failOnMissingSrc:  fs.Bool("failonmissingsources", false, "Exit with a non-zero code if any referenced source file could not be found"),
+		mergeMode:         fs.String("mergemode", "sum", "How hits and branch visits reported more than once are combined: sum (default, for reports of separate runs such as test shards) or max (for several exports of the same run, e.g. an LCOV and a Cobertura file of one execution). Coverage percentages are the same in both modes"),
+		failOnDuplicates:  fs.Bool("failonduplicatereports", false, "Fail instead of skipping a report that duplicates an earlier one (same content or identical coverage data)"),
+		failOnParseError:  fs.Bool("failonparseerror", false, "Fail instead of skipping a report file that cannot be parsed (skipped files are listed in the reports)"),
+		totalsTolerance:   fs.Float64("declaredtotalstolerance", 0.01, "Relative difference allowed between the totals declared by a report (e.g. Cobertura lines-covered) and the parsed line data before a warning is logged (negative: no check)"),
+		tag:               fs.String("tag", "", "Optional tag, e.g. build number"),
+		tagLink:           fs.String("taglink", "", "Optional URL template for the tag, {tag} is replaced with the tag (e.g. https://ci.example.com/builds/{tag})"),
+		title:             fs.String("title", "", "Optional report title (default: 'Coverage Report')"),
+		assemblyFilters:   fs.String("assemblyfilters", "", "Assembly filters (+Include;-Exclude)"),
+		classFilters:      fs.String("classfilters", "", "Class filters"),
+		fileFilters:       fs.String("filefilters", "", "File filters"),
+		methodFilters:     fs.String("methodfilters", "", "Method filters, matched against the method name (e.g. \"get_Name()\") and the name qualified with its class (e.g. \"Shop.Cart.get_Name()\"): \"-get_*;-set_*;-*.Equals(*)\". Filtered methods are left out of the method coverage, metrics and method list; their lines still count towards the line and branch coverage"),
+		rhAssemblyFilters: fs.String("riskhotspotassemblyfilters", "", "Risk-hotspot assembly filters"),
+		rhClassFilters:    fs.String("riskhotspotclassfilters", "", "Risk-hotspot class filters"),
+		classCoverage:     fs.String("classcoveragefilter", "", "Keep only the classes whose line coverage is in this range, e.g. <100 or >=0<80 (classes without coverable lines count as 100%)"),
+		recomputeAggr:     fs.Bool("recomputeaggregates", false, "Recalculate the assembly and overall totals over the classes kept by -classcoveragefilter (default: totals of all classes)"),
+		language:          fs.String("language", "", "Language of the Html report strings: "+strings.Join(htmlreport.SupportedLanguages(), ", ")+" (default: from the LANG environment variable, otherwise en)"),
+		translationsFile:  fs.String("translationsfile", "", "JSON file with Html report strings that override the ones of the selected language, e.g. {\"Summary\": \"Overview\"}"),
+		syntaxHighlight:   fs.Bool("syntaxhighlight", false, "Color the keywords, strings and comments of the source code on the Html class pages (Go, C# and C-like languages)"),
+		maxLineLength:     fs.Int("maxlinelength", settings.NewSettings().MaximumLineLength, "Characters of a source line shown on the Html class pages before it is truncated; files of minified code are shown without their code (0: no limit)"),
+		minifiedLength:    fs.Int("minifiedlinelength", settings.NewSettings().MinifiedAverageLineLength, "Average characters per line above which a file counts as minified code and is shown without its code on the Html class pages (0: show the code of all files)"),
+		summarySort:       fs.String("summarysort", "", "Order of the classes embedded into the Html summary page and initial sorting of its class table: name, linecoverage, branchcoverage or uncoveredlines, optionally followed by :asc or :desc, e.g. uncoveredlines:desc (default: the order of the report)"),
+		summaryTopN:       fs.Int("summarytopn", 0, "Number of classes per assembly embedded into the Html summary page, in the order of -summarysort; the others are listed on the page of the assembly (0: no limit)"),
+		incremental:       fs.Bool("incremental", false, "Only rewrite the Html class pages whose content changed since the last run into the target directory, and delete the pages of classes that disappeared"),
+		serve:             fs.String("serve", "", "Serve the Html report on this address (e.g. :8080) instead of writing reports, and regenerate it when the report files change"),
+		longPaths:         fs.Bool("longpaths", false, `Windows only: access paths of 260 characters or more with the \\?\ prefix`),
+		fileRetries:       fs.Int("fileretries", utils.DefaultFileRetryPolicy.Retries, "Retries of report files that are locked by another process or not accessible, e.g. still held by the test host on Windows (0: no retries)"),
+		fileRetryDelay:    fs.Int("fileretrydelay", int(utils.DefaultFileRetryPolicy.Delay/time.Millisecond), "Milliseconds to wait before the first retry of a locked report file; the wait doubles with every further retry"),
+		fileRetryTimeout:  fs.Int("fileretrytimeout", int(utils.DefaultFileRetryPolicy.Timeout/time.Second), "Seconds to wait at most for a locked report file before it is skipped (0: no limit besides -fileretries)"),
+		pathCase:          fs.String("pathcase", "auto", "Whether file paths that differ only in case are the same file: auto (case-insensitive on Windows), sensitive or insensitive"),
+		resolveSymlinks:   fs.Bool("resolvesymlinks", false, "Resolve symbolic links in source file paths, so that a file reached through a link and its target is counted once (accesses the file system for every path)"),
+		mergeVendored:     fs.Bool("mergevendored", settings.NewSettings().MergeVendoredFiles, "Merge a file in a vendor directory with the file of the assembly whose path ends with the path after vendor/"),
+		excludeExternal:   fs.Bool("excludeexternalfiles", false, "Leave out the files outside the source directories (or the Go module root), e.g. generated files of obj/ or framework sources, instead of marking them as external"),
+		strict:            fs.Bool("strict", false, "Classify the files whose source could not be found as external instead of as part of the source directories"),
+		dumpModel:         dumpModelFlag(fs, "dumpmodel", "Write the merged coverage model as JSON to "+modelDumpFilename+" in the output directory for debugging; -dumpmodel=perfile also writes the model of each report file before merging"),
+		dumpModelSource:   fs.Bool("dumpmodel-include-source", false, "Keep the source code of the lines in the files of -dumpmodel"),
+		historyDir:        fs.String("historydir", "", "Directory to read the coverage history of earlier runs from and to save the history of this run to"),
+		maxHistoryFiles:   fs.Int("maxhistoryfiles", settings.NewSettings().MaximumNumberOfHistoricCoverageFiles, "Number of the newest history files read from -historydir (0: no limit)"),
+		historyRetention:  fs.Int("historyretentiondays", 0, "Ignore history files older than this number of days, before -maxhistoryfiles is applied (0: no limit)"),
+		pruneHistory:      fs.Bool("prunehistory", false, "Delete the history files ignored because of -historyretentiondays or -maxhistoryfiles"),
+		coverageAge:       fs.Float64("coverageagethreshold", settings.NewSettings().CoverageAgeThreshold, "Line coverage in percent below which the reports note since which run of -historydir a class has stayed below it (0: disabled)"),
+		maxHistoryPoints:  fs.Int("maxhistorypoints", settings.NewSettings().MaximumHistoricCoveragesPerClass, "Number of the newest history entries per class embedded into the Html report; older entries are summarized as their coverage range (0: no limit)"),
+
+		// configuration file
+		configFile:  fs.String("config", "", "YAML or JSON configuration file whose keys are flag names, e.g. \"title: Backend\" (default: reportgenerator.yaml, .yml or .json in the working directory, if present); flags on the command line take precedence"),
+		printConfig: fs.Bool("printconfig", false, "Print the effective configuration, merged from the configuration file and the flags, as YAML, then exit"),
+
+		// informational flags
+		capabilities:       fs.Bool("capabilities", false, "Print the supported parsers, report types and language formatters, then exit"),
+		capabilitiesFormat: fs.String("capabilitiesformat", "text", "Output format of -capabilities: text (default) or json"),
+		version:            fs.Bool("version", false, "Print the version, commit and build date, then exit"),
 
 		// logging flags
-		verbose:   flag.Bool("verbose", false, "Shortcut for Verbose logging (overridden by -verbosity)"),
-		verbosity: flag.String("verbosity", "Error", "Logging level: Verbose, Info, Warning, Error, Off"),
-		logFile:   flag.String("logfile", "", "Write logs to this file as well as the console"),
-		logFormat: flag.String("logformat", "text", "Log output format: text (default) or json"),
+		verbose:   fs.Bool("verbose", false, "Shortcut for Verbose logging (overridden by -verbosity)"),
+		verbosity: fs.String("verbosity", "Error", "Logging level: Verbose, Info, Warning, Error, Off"),
+		logFile:   fs.String("logfile", "", "Write logs to this file as well as the console"),
+		logFormat: fs.String("logformat", "text", "Log output format: text (default) or json"),
+		quiet:     fs.Bool("quiet", false, "Log errors only and print the run statistics as a single JSON line to stdout (overrides -verbose and -verbosity)"),
+		statsJSON: fs.Bool("statsjson", false, "Print the run statistics (report files, assemblies, classes, files, duration per phase) as a single JSON line to stdout"),
 	}
 
-	flag.Parse()
-	return f, nil
+	return f
+}
+
+// stderrIsTerminal reports whether stderr is a terminal, replaced in tests.
+var stderrIsTerminal = func() bool { return progress.IsTerminal(os.Stderr) }
+
+// showTerminalProgress decides whether the progress is drawn as a line on stderr: only
+// at Info verbosity, which logs few enough records for the line to stay readable, only
+// without -logfile, and only if stderr is not redirected to a file or a pipe.
+func showTerminalProgress(level logging.VerbosityLevel, logFile string, isTerminal bool) bool {
+	return level == logging.Info && logFile == "" && isTerminal
 }
 
-func buildLogger(f *cliFlags) (logging.VerbosityLevel, io.Closer, error) {
+// buildLogger initializes the default logger. The returned Terminal shows the progress
+// on stderr, nil if showTerminalProgress is false.
+func buildLogger(f *cliFlags) (logging.VerbosityLevel, *progress.Terminal, io.Closer, error) {
 	verbosityStr := strings.TrimSpace(*f.verbosity)
 	level, err := logging.ParseVerbosity(verbosityStr)
 	if err != nil && verbosityStr != "" {
-		return 0, nil, err
+		return 0, nil, nil, err
 	}
 
 	switch {
+	case *f.quiet:
+		level = logging.Error
 	case verbosityStr != "" && verbosityStr != "Error":
 	case *f.verbose:
 		level = logging.Verbose
@@ -105,20 +286,41 @@ func buildLogger(f *cliFlags) (logging.VerbosityLevel, io.Closer, error) {
 		File:      *f.logFile,
 		Format:    *f.logFormat,
 	}
+	var terminal *progress.Terminal
+	if showTerminalProgress(level, *f.logFile, stderrIsTerminal()) {
+		terminal = progress.NewTerminal(os.Stderr)
+		cfg.Console = terminal
+	}
 	closer, err := logging.Init(&cfg)
-	return level, closer, err
+	return level, terminal, closer, err
+}
+
+// newProgressReporter logs the progress at Info level and, if terminal is not nil, also
+// draws it on the terminal line.
+func newProgressReporter(logger *slog.Logger, terminal *progress.Terminal) progress.Reporter {
+	if terminal == nil {
+		return progress.NewLogReporter(logger)
+	}
+	return progress.Multi(progress.NewLogReporter(logger), terminal)
 }
 
 // Helpers
 
-func resolveAndValidateInputs(logger *slog.Logger, flags *cliFlags) ([]string, []string, error) {
+func resolveAndValidateInputs(logger *slog.Logger, flags *cliFlags, keyOf func(string) string, retry utils.FileRetryPolicy) ([]string, []string, error) {
 	if *flags.reportsPatterns == "" {
 		return nil, nil, fmt.Errorf("missing required -report flag")
 	}
+	return expandReportPatterns(logger, *flags.reportsPatterns, keyOf, retry)
+}
 
-	reportFilePatterns := strings.Split(*flags.reportsPatterns, ";")
+// expandReportPatterns expands semicolon-separated report file patterns into a
+// de-duplicated list of absolute file paths. keyOf returns the key by which the paths are
+// compared, see utils.PathKeyFunc. Locked files are retried according to retry.
+func expandReportPatterns(logger *slog.Logger, patterns string, keyOf func(string) string, retry utils.FileRetryPolicy) ([]string, []string, error) {
+	reportFilePatterns := strings.Split(patterns, ";")
 	var actualReportFiles []string
 	var invalidPatterns []string
+	var lockedFiles []string // Files that stayed inaccessible, with the time waited for them
 	seenFiles := make(map[string]struct{})
 
 	for _, pattern := range reportFilePatterns {
@@ -138,12 +340,17 @@ func resolveAndValidateInputs(logger *slog.Logger, flags *cliFlags) ([]string, [
 		}
 		for _, file := range expandedFiles {
 			absFile, _ := filepath.Abs(file)
-			if _, exists := seenFiles[absFile]; !exists {
-				if stat, err := os.Stat(absFile); err == nil && !stat.IsDir() {
+			if _, exists := seenFiles[keyOf(absFile)]; !exists {
+				// GOCOVERDIR directories are converted by the GoCover parser.
+				if stat, err := checkReportFile(absFile, retry); err == nil && (!stat.IsDir() || gocover.IsCoverageDirectory(absFile)) {
 					actualReportFiles = append(actualReportFiles, absFile)
-					seenFiles[absFile] = struct{}{}
+					seenFiles[keyOf(absFile)] = struct{}{}
 				} else if err != nil {
-					logger.Warn("Could not stat file from pattern", "pattern", trimmedPattern, "file", absFile, "error", err)
+					var retryErr *utils.FileRetryError
+					if errors.As(err, &retryErr) {
+						lockedFiles = append(lockedFiles, fmt.Sprintf("%s (waited %s)", absFile, retryErr.Waited))
+					}
+					logger.Warn("Could not access file from pattern", "pattern", trimmedPattern, "file", absFile, "error", err)
 					invalidPatterns = append(invalidPatterns, file)
 				}
 			}
@@ -151,17 +358,191 @@ func resolveAndValidateInputs(logger *slog.Logger, flags *cliFlags) ([]string, [
 	}
 
 	if len(actualReportFiles) == 0 {
+		if len(lockedFiles) > 0 {
+			return nil, invalidPatterns, fmt.Errorf("no valid report files found after expanding patterns; still locked: %s", strings.Join(lockedFiles, ", "))
+		}
 		return nil, invalidPatterns, fmt.Errorf("no valid report files found after expanding patterns")
 	}
+	if len(lockedFiles) > 0 {
+		logger.Warn("Skipped report files that stayed locked", "files", strings.Join(lockedFiles, ", "))
+	}
 
 	logger.Info("Found report files", "count", len(actualReportFiles))
 	logger.Debug("Report file list", "files", strings.Join(actualReportFiles, ", "))
 	return actualReportFiles, invalidPatterns, nil
 }
 
+// fileRetryPolicy returns the retry policy of locked report files given by the
+// -fileretries, -fileretrydelay and -fileretrytimeout flags.
+func fileRetryPolicy(flags *cliFlags) (utils.FileRetryPolicy, error) {
+	if *flags.fileRetries < 0 || *flags.fileRetryDelay < 0 || *flags.fileRetryTimeout < 0 {
+		return utils.FileRetryPolicy{}, fmt.Errorf("invalid file retry policy: -fileretries, -fileretrydelay and -fileretrytimeout must not be negative")
+	}
+	return utils.FileRetryPolicy{
+		Retries: *flags.fileRetries,
+		Delay:   time.Duration(*flags.fileRetryDelay) * time.Millisecond,
+		Timeout: time.Duration(*flags.fileRetryTimeout) * time.Second,
+	}, nil
+}
+
+// checkReportFile returns the file info of a report file and, for files, checks that it
+// can be opened. Both are retried according to retry while the file is locked.
+func checkReportFile(path string, retry utils.FileRetryPolicy) (fs.FileInfo, error) {
+	info, err := retry.Stat(path)
+	if err != nil || info.IsDir() {
+		return info, err
+	}
+	f, err := retry.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+	return info, nil
+}
+
 func createReportConfiguration(flags *cliFlags, verbosity logging.VerbosityLevel, actualReportFiles, invalidPatterns []string, langFactory *language.ProcessorFactory, logger *slog.Logger) (*reportconfig.ReportConfiguration, error) {
-	reportTypes := strings.Split(*flags.reportTypes, ",")
+	appSettings := settings.NewSettings()
+	appSettings.AutoDiscoverSourceFiles = *flags.autoDiscover
+	appSettings.CreateSubdirectoryForAllReportTypes = *flags.outputSubdirs
+	appSettings.TextSummaryFileName = *flags.textSummaryFile
+	appSettings.StoreSources = *flags.storeSources
+	if *flags.sourceEncoding != "" {
+		if _, err := filereader.LookupEncoding(*flags.sourceEncoding); err != nil {
+			return nil, fmt.Errorf("invalid -sourceencoding: %w", err)
+		}
+	}
+	appSettings.SourceFileEncoding = *flags.sourceEncoding
+	appSettings.CoverageConverter = *flags.converter
+	appSettings.CoverageConverterTimeoutInSeconds = *flags.converterTimeout
+	appSettings.RawMode = *flags.rawMode
+	appSettings.KeepNestedClasses = *flags.keepNested
+	appSettings.ExcludeGeneratedCode = *flags.excludeGenerated
+	appSettings.ExcludeTestProjects = *flags.excludeTests
+	appSettings.GoApproximateBranchCoverage = *flags.goApproxBranches
+	if !(*flags.fullMethodRate > 0 && *flags.fullMethodRate <= 1) {
+		return nil, fmt.Errorf("invalid -fullmethodcoverage-minlinerate value %g: must be greater than 0 and at most 1", *flags.fullMethodRate)
+	}
+	appSettings.FullMethodCoverageMinimumLineRate = *flags.fullMethodRate
+	appSettings.FullMethodCoverageRequiresBranches = *flags.fullMethodBranch
+	appSettings.ExcludeCoverageByComments = *flags.exclusionComments
+	appSettings.FailOnDuplicateReports = *flags.failOnDuplicates
+	appSettings.FailOnParseError = *flags.failOnParseError
+	appSettings.DeclaredTotalsTolerance = *flags.totalsTolerance
+	appSettings.RecomputeAggregates = *flags.recomputeAggr
+	appSettings.AssemblyGroupingLevel = *flags.assemblyGrouping
+	if *flags.uncoveredLines < 0 {
+		return nil, fmt.Errorf("invalid -uncoveredlines value %d: must not be negative", *flags.uncoveredLines)
+	}
+	appSettings.UncoveredLinesClassLimit = *flags.uncoveredLines
+	for _, limit := range []struct {
+		flag  string
+		value int
+	}{
+		{"-maxhistoryfiles", *flags.maxHistoryFiles},
+		{"-historyretentiondays", *flags.historyRetention},
+		{"-maxhistorypoints", *flags.maxHistoryPoints},
+	} {
+		if limit.value < 0 {
+			return nil, fmt.Errorf("invalid %s value %d: must not be negative", limit.flag, limit.value)
+		}
+	}
+	appSettings.MaximumNumberOfHistoricCoverageFiles = *flags.maxHistoryFiles
+	appSettings.HistoryRetentionDays = *flags.historyRetention
+	appSettings.PruneHistory = *flags.pruneHistory
+	appSettings.MaximumHistoricCoveragesPerClass = *flags.maxHistoryPoints
+	if *flags.coverageAge < 0 || *flags.coverageAge > 100 {
+		return nil, fmt.Errorf("invalid -coverageagethreshold value %g: must be between 0 and 100", *flags.coverageAge)
+	}
+	appSettings.CoverageAgeThreshold = *flags.coverageAge
+	appSettings.LanguageProcessor = *flags.languageFormatter
+	if *flags.language != "" {
+		if _, ok := htmlreport.ResolveLanguage(*flags.language); !ok {
+			return nil, fmt.Errorf("unsupported -language %q (supported: %s)", *flags.language, strings.Join(htmlreport.SupportedLanguages(), ", "))
+		}
+		appSettings.Language = *flags.language
+	} else {
+		appSettings.Language = os.Getenv("LANG")
+	}
+	appSettings.TranslationsFile = *flags.translationsFile
+	appSettings.SyntaxHighlight = *flags.syntaxHighlight
+	if *flags.maxLineLength < 0 {
+		return nil, fmt.Errorf("invalid -maxlinelength value %d: must not be negative", *flags.maxLineLength)
+	}
+	appSettings.MaximumLineLength = *flags.maxLineLength
+	if *flags.minifiedLength < 0 {
+		return nil, fmt.Errorf("invalid -minifiedlinelength value %d: must not be negative", *flags.minifiedLength)
+	}
+	appSettings.MinifiedAverageLineLength = *flags.minifiedLength
+	summarySort, err := htmlreport.ParseSummarySort(*flags.summarySort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -summarysort: %w", err)
+	}
+	appSettings.SummarySort = summarySort.String()
+	if *flags.summaryTopN < 0 {
+		return nil, fmt.Errorf("invalid -summarytopn value %d: must not be negative", *flags.summaryTopN)
+	}
+	appSettings.SummaryTopN = *flags.summaryTopN
+	appSettings.Incremental = *flags.incremental
+	appSettings.ResolveSymlinks = *flags.resolveSymlinks
+	pathCase, err := utils.ParsePathCaseMode(*flags.pathCase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -pathcase: %w", err)
+	}
+	appSettings.PathCase = pathCase.String()
+	if _, err := fileRetryPolicy(flags); err != nil {
+		return nil, err
+	}
+	appSettings.FileRetries = *flags.fileRetries
+	appSettings.FileRetryDelayInMilliseconds = *flags.fileRetryDelay
+	appSettings.FileRetryTimeoutInSeconds = *flags.fileRetryTimeout
+	appSettings.MergeVendoredFiles = *flags.mergeVendored
+	appSettings.ExcludeExternalFiles = *flags.excludeExternal
+	appSettings.StrictExternalFiles = *flags.strict
+	appSettings.DumpModel = flags.dumpModel.String()
+	appSettings.DumpModelIncludeSource = *flags.dumpModelSource
+	if appSettings.TranslationsFile != "" {
+		language, _ := htmlreport.ResolveLanguage(appSettings.Language)
+		if _, err := htmlreport.LoadTranslations(language, appSettings.TranslationsFile, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	roundingMode, err := utils.ParseRoundingMode(*flags.quotaRounding)
+	if err != nil {
+		return nil, err
+	}
+	appSettings.CoverageQuotaRoundingMode = roundingMode.String()
+
+	mergeMode, err := utils.ParseMergeMode(*flags.mergeMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -mergemode: %w", err)
+	}
+	appSettings.MergeMode = mergeMode.String()
+
+	thresholdOverrides, err := settings.ParseMetricThresholds(*flags.metricThresholds)
+	if err != nil {
+		return nil, err
+	}
+	for name, threshold := range thresholdOverrides {
+		appSettings.MetricThresholds[name] = threshold
+	}
+	if appSettings.Metrics, err = settings.ParseMetricDefinitions(*flags.metrics, appSettings.Metrics); err != nil {
+		return nil, fmt.Errorf("invalid -metrics: %w", err)
+	}
+	markerOverrides, err := settings.ParseExclusionMarkers(*flags.exclusionMarkers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -exclusionmarkers: %w", err)
+	}
+	for lang, markers := range markerOverrides {
+		appSettings.CoverageExclusionMarkers[lang] = markers
+	}
+
 	sourceDirsList := strings.Split(*flags.sourceDirs, ",")
+	for _, hint := range strings.Split(*flags.sourceRootHints, ",") {
+		if hint = strings.TrimSpace(hint); hint != "" {
+			appSettings.SourceRootHints = append(appSettings.SourceRootHints, hint)
+		}
+	}
 	assemblyFilterStrings := strings.Split(*flags.assemblyFilters, ";")
 	classFilterStrings := strings.Split(*flags.classFilters, ";")
 	fileFilterStrings := strings.Split(*flags.fileFilters, ";")
@@ -174,8 +555,10 @@ func createReportConfiguration(flags *cliFlags, verbosity logging.VerbosityLevel
 		reportconfig.WithInvalidPatterns(invalidPatterns),
 		reportconfig.WithTitle(*flags.title),
 		reportconfig.WithTag(*flags.tag),
+		reportconfig.WithTagLink(*flags.tagLink),
 		reportconfig.WithSourceDirectories(sourceDirsList),
-		reportconfig.WithReportTypes(reportTypes),
+		reportconfig.WithHistoryDirectory(*flags.historyDir),
+		reportconfig.WithReportTypeSpecs(*flags.reportTypes),
 		reportconfig.WithFilters(
 			assemblyFilterStrings,
 			classFilterStrings,
@@ -183,109 +566,421 @@ func createReportConfiguration(flags *cliFlags, verbosity logging.VerbosityLevel
 			rhAssemblyFilterStrings,
 			rhClassFilterStrings,
 		),
+		reportconfig.WithMethodFilters(strings.Split(*flags.methodFilters, ";")),
+		reportconfig.WithClassCoverageFilter(*flags.classCoverage),
 		reportconfig.WithLanguageProcessorFactory(langFactory),
+		reportconfig.WithSettings(appSettings),
 	}
 
-	return reportconfig.NewReportConfiguration(
+	reportConfig, err := reportconfig.NewReportConfiguration(
 		actualReportFiles,
 		*flags.outputDir,
 		opts...,
 	)
+	if err != nil {
+		return nil, err
+	}
+	// Fail before parsing if the reports could not be written anyway. -serve writes no
+	// report.
+	if *flags.serve == "" {
+		if err := reportconfig.ValidateTargetDirectory(reportConfig.TargetDirectory()); err != nil {
+			return nil, err
+		}
+	}
+	return reportConfig, nil
 }
 
-func parseAndMergeReports(logger *slog.Logger, reportConfig *reportconfig.ReportConfiguration, parserFactory *parsers.ParserFactory) (*model.SummaryResult, error) {
+// parseProgressInterval is after how many report files the parse progress is logged.
+const parseProgressInterval = 10
+
+func parseAndMergeReports(logger *slog.Logger, reportConfig *reportconfig.ReportConfiguration, parserFactory *parsers.ParserFactory, stats *runstats.Stats) (*model.SummaryResult, error) {
 	var parserResults []*parsers.ParserResult
-	var parserErrors []string
+	var skipped []model.SkippedReport
+	duplicates := newDuplicateReportDetector()
+	failOnDuplicates := reportConfig.Settings().FailOnDuplicateReports
+
+	// parseFile parses one report file and adds its result, or records why it was skipped.
+	parseFile := func(reportFile string) error {
+		logger.Info("Attempting to parse report file", "report_file", reportFile)
+		if original, err := duplicates.sameContentAs(reportFile); err != nil {
+			logger.Warn("Could not check report file for duplicates", "report_file", reportFile, "error", err)
+		} else if original != "" {
+			if failOnDuplicates {
+				return fmt.Errorf("report file %s has the same content as %s (-failonduplicatereports)", reportFile, original)
+			}
+			logger.Warn("Skipping report file with the same content as an earlier report", "report_file", reportFile, "duplicate_of", original)
+			stats.ReportSkipped()
+			return nil
+		}
 
-	for _, reportFile := range reportConfig.ReportFiles() {
-		logger.Info("Attempting to parse report file", "file", reportFile)
 		// Use the injected factory instance to find the right parser
-		parserInstance, err := parserFactory.FindParserForFile(reportFile)
+		parserInstance, err := parserFactory.FindParserForFile(reportFile, reportConfig)
 		if err != nil {
-			msg := fmt.Sprintf("no suitable parser found for file %s: %v", reportFile, err)
-			parserErrors = append(parserErrors, msg)
-			logger.Warn(msg)
-			continue
+			skipped = append(skipped, model.SkippedReport{Path: reportFile, Error: err.Error()})
+			logger.Warn("No suitable parser found for report file", "report_file", reportFile, "error", err)
+			stats.ReportFailed()
+			return nil
 		}
 
-		logger.Info("Using parser for file", "parser", parserInstance.Name(), "file", reportFile)
+		logger.Info("Using parser for file", "parser", parserInstance.Name(), "report_file", reportFile)
+
+		parserConfig, err := reportConfig.ForParser(parserInstance.Name())
+		if err != nil {
+			return err
+		}
 
 		// The Parse method will now use the language factory from the reportConfig
-		result, err := parserInstance.Parse(reportFile, reportConfig)
+		parseStart := time.Now()
+		result, err := parserInstance.Parse(reportFile, parserConfig)
 		if err != nil {
-			msg := fmt.Sprintf("error parsing file %s with %s: %v", reportFile, parserInstance.Name(), err)
-			parserErrors = append(parserErrors, msg)
-			logger.Error(msg)
-			continue
+			skipped = append(skipped, model.SkippedReport{Path: reportFile, Parser: parserInstance.Name(), Error: err.Error()})
+			logger.Error("Failed to parse report file", "report_file", reportFile, "parser", parserInstance.Name(), "error", err)
+			stats.ReportFailed()
+			return nil
+		}
+		if original := duplicates.sameCoverageAs(reportFile, result); original != "" {
+			if failOnDuplicates {
+				return fmt.Errorf("report file %s contains the same coverage data as %s (-failonduplicatereports)", reportFile, original)
+			}
+			logger.Warn("Skipping report file with the same coverage data as an earlier report", "report_file", reportFile, "duplicate_of", original)
+			stats.ReportSkipped()
+			return nil
 		}
 		parserResults = append(parserResults, result)
-		logger.Info("Successfully parsed file", "file", reportFile)
+		stats.ReportParsed()
+		if err := dumpParserResult(reportConfig, len(parserResults), reportFile, result); err != nil {
+			return err
+		}
+		logger.Info("Successfully parsed file",
+			"report_file", reportFile,
+			"parser", parserInstance.Name(),
+			"assemblies", len(result.Assemblies),
+			"classes", countParsedClasses(result.Assemblies),
+			"duration_ms", time.Since(parseStart).Milliseconds(),
+		)
 
 		if len(reportConfig.SourceDirectories()) == 0 && len(result.SourceDirectories) > 0 {
-			logger.Info("Report specified source directories, updating configuration", "file", reportFile, "dirs", result.SourceDirectories)
+			logger.Info("Report specified source directories, updating configuration", "report_file", reportFile, "dirs", result.SourceDirectories)
 			if err := reportconfig.WithSourceDirectories(result.SourceDirectories)(reportConfig); err != nil {
 				logger.Warn("Failed to apply source directories", "error", err)
 			}
 		}
+		return nil
 	}
 
+	stopParsing := stats.Start("parse")
+	parseProgress := reportConfig.Progress().Start(progress.Step{Action: "Parsed", Unit: "report files", Total: len(reportConfig.ReportFiles()), Every: parseProgressInterval})
+	for _, reportFile := range reportConfig.ReportFiles() {
+		err := parseFile(reportFile)
+		parseProgress.Increment()
+		if err != nil {
+			parseProgress.Done()
+			return nil, err
+		}
+	}
+	parseProgress.Done()
+
+	stopParsing()
+
 	if len(parserResults) == 0 {
 		errMsg := "no coverage reports could be parsed successfully"
-		if len(parserErrors) > 0 {
-			errMsg = fmt.Sprintf("%s. Errors:\n- %s", errMsg, strings.Join(parserErrors, "\n- "))
+		if len(skipped) > 0 {
+			errMsg = fmt.Sprintf("%s. Errors:\n- %s", errMsg, formatSkippedReports(skipped))
 		}
 		return nil, errors.New(errMsg)
 	}
+	if len(skipped) > 0 && reportConfig.Settings().FailOnParseError {
+		return nil, fmt.Errorf("%d report file(s) could not be parsed (-failonparseerror):\n- %s", len(skipped), formatSkippedReports(skipped))
+	}
 
+	defer stats.Start("merge")()
 	logger.Info("Merging parsed reports", "count", len(parserResults))
 	summaryResult, err := analyzer.MergeParserResults(parserResults, reportConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge parser results: %w", err)
 	}
-	logger.Info("Coverage data merged and analyzed")
+	keyOf := reporter.PathKeyFunc(reportConfig.Settings())
+	if s := reportConfig.Settings(); s.ExcludeCoverageByComments {
+		sourceReader, err := filereader.NewSourceFileReader(s.SourceFileEncoding)
+		if err != nil {
+			return nil, err
+		}
+		markersFor := func(path string) settings.ExclusionMarkers {
+			return settings.ExclusionMarkersFor(s.CoverageExclusionMarkers, reportConfig.LanguageProcessorFactory().FindProcessorForFile(path).Name())
+		}
+		excluded := analyzer.ApplyExclusionComments(summaryResult, markersFor, sourceReader.ReadFile, keyOf, logger)
+		logger.Info("Applied coverage exclusion comments", "excluded_lines", excluded)
+	}
+	if n := analyzer.NewFullMethodCoverage(reportConfig.Settings()).RecomputeMethodCoverage(summaryResult, reportConfig.Settings().Metrics, reportConfig.Settings().MetricThresholds, keyOf); n > 0 {
+		logger.Debug("Recomputed the coverage of methods from the merged lines", "methods", n)
+	}
+	if level := reportConfig.Settings().AssemblyGroupingLevel; level > 0 {
+		analyzer.ApplyAssemblyGrouping(summaryResult, level, keyOf)
+		logger.Info("Applied assembly grouping", "level", level, "groups", len(summaryResult.Assemblies))
+	}
+	if coverageRange := reportConfig.ClassCoverageFilter(); coverageRange != nil {
+		recompute := reportConfig.Settings().RecomputeAggregates
+		hidden := analyzer.ApplyClassCoverageFilter(summaryResult, coverageRange, recompute, keyOf)
+		logger.Info("Applied class coverage filter", "range", coverageRange.String(), "hidden_classes", hidden, "recompute_aggregates", recompute)
+	}
+	analyzer.BuildDirectoryTree(summaryResult, reportConfig.SourceDirectories(), keyOf)
+	summaryResult.SkippedReports = skipped
+	logger.Info("Coverage data merged and analyzed",
+		"assemblies", len(summaryResult.Assemblies),
+		"classes", countParsedClasses(summaryResult.Assemblies),
+		"lines_covered", summaryResult.LinesCovered,
+		"lines_valid", summaryResult.LinesValid,
+	)
+	stats.RecordSummary(summaryResult, keyOf)
+	if err := dumpSummary(reportConfig, summaryResult); err != nil {
+		return nil, err
+	}
 	return summaryResult, nil
 }
 
-func generateReports(reportCtx reporter.IBuilderContext, summaryResult *model.SummaryResult) error {
+// formatSkippedReports lists the skipped report files with their errors, one per line.
+func formatSkippedReports(skipped []model.SkippedReport) string {
+	lines := make([]string, len(skipped))
+	for i, s := range skipped {
+		if s.Parser == "" {
+			lines[i] = fmt.Sprintf("no suitable parser found for file %s: %s", s.Path, s.Error)
+		} else {
+			lines[i] = fmt.Sprintf("error parsing file %s with %s: %s", s.Path, s.Parser, s.Error)
+		}
+	}
+	return strings.Join(lines, "\n- ")
+}
+
+func countParsedClasses(assemblies []model.Assembly) int {
+	count := 0
+	for _, assembly := range assemblies {
+		count += len(assembly.Classes)
+	}
+	return count
+}
+
+// logSummary writes a single record with the key totals of the run, so that log
+// pipelines do not have to parse the generated reports.
+func logSummary(logger *slog.Logger, reportConfig *reportconfig.ReportConfiguration, summary *model.SummaryResult, start time.Time) {
+	attrs := []any{
+		"report_files", len(reportConfig.ReportFiles()),
+		"report_types", strings.Join(reportConfig.ReportTypes(), ","),
+		"parser", summary.ParserName,
+		"assemblies", len(summary.Assemblies),
+		"classes", countParsedClasses(summary.Assemblies),
+		"lines_covered", summary.LinesCovered,
+		"lines_valid", summary.LinesValid,
+	}
+	if summary.LinesValid > 0 {
+		attrs = append(attrs, "line_coverage", utils.CalculatePercentage(summary.LinesCovered, summary.LinesValid, 1))
+	}
+	if summary.BranchesCovered != nil && summary.BranchesValid != nil {
+		attrs = append(attrs, "branches_covered", *summary.BranchesCovered, "branches_valid", *summary.BranchesValid)
+	}
+	attrs = append(attrs,
+		"missing_source_files", reporter.CountMissingSourceFiles(summary.MissingSourceFiles, reporter.PathKeyFunc(reportConfig.Settings())),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	logger.Info("Coverage report summary", attrs...)
+}
+
+// parseBaseline parses the -comparewith reports that the DeltaSummary report compares
+// the current coverage with. It returns nil if no DeltaSummary report is requested.
+func parseBaseline(logger *slog.Logger, flags *cliFlags, reportConfig *reportconfig.ReportConfiguration, verbosity logging.VerbosityLevel, langFactory *language.ProcessorFactory, parserFactory *parsers.ParserFactory) (*model.SummaryResult, error) {
+	deltaRequested := slices.Contains(reportConfig.ReportTypes(), "DeltaSummary")
+	switch {
+	case !deltaRequested && *flags.compareWith != "":
+		logger.Warn("Ignoring -comparewith because the DeltaSummary report type is not requested")
+		return nil, nil
+	case !deltaRequested:
+		return nil, nil
+	case *flags.compareWith == "":
+		return nil, fmt.Errorf("the DeltaSummary report type requires the -comparewith flag")
+	}
+
+	baselineFiles, invalidPatterns, err := expandReportPatterns(logger, *flags.compareWith, reporter.PathKeyFunc(reportConfig.Settings()), parsers.FileRetryPolicy(reportConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve -comparewith reports: %w", err)
+	}
+	baselineConfig, err := createReportConfiguration(flags, verbosity, baselineFiles, invalidPatterns, langFactory, logger)
+	if err != nil {
+		return nil, err
+	}
+	baselineConfig.Settings().DumpModel = "" // Only the model of the current reports is dumped
+
+	logger.Info("Parsing baseline reports", "count", len(baselineFiles))
+	baseline, err := parseAndMergeReports(logger, baselineConfig, parserFactory, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse -comparewith reports: %w", err)
+	}
+	return baseline, nil
+}
+
+func generateReports(reportCtx reporter.IBuilderContext, summaryResult, baseline *model.SummaryResult, stats *runstats.Stats) error {
 	logger := reportCtx.Logger()
 	reportConfig := reportCtx.ReportConfiguration()
-	outputDir := reportConfig.TargetDirectory()
 
-	logger.Info("Generating reports", "directory", outputDir)
-	if err := os.MkdirAll(outputDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
-	}
+	logger.Info("Generating reports", "directory", reportConfig.TargetDirectory())
 
+	reportProgress := reportCtx.Progress().Start(progress.Step{Action: "Generated", Unit: "report types", Total: len(reportConfig.ReportTypes()), Every: 1})
+	defer reportProgress.Done()
 	for _, reportType := range reportConfig.ReportTypes() {
 		trimmedType := strings.TrimSpace(reportType)
-		logger.Info("Generating report", "type", trimmedType)
+		outputDir := reportConfig.TargetDirectoryForReportType(trimmedType)
+		logger.Info("Generating report", "type", trimmedType, "directory", outputDir)
+		if err := os.MkdirAll(outputDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
 
-		switch trimmedType {
-		case "TextSummary":
-			if err := textsummary.NewTextReportBuilder(outputDir, logger).CreateReport(summaryResult); err != nil {
-				return fmt.Errorf("failed to generate text report: %w", err)
-			}
-		case "Html":
-			if err := htmlreport.NewHtmlReportBuilder(outputDir, reportCtx).CreateReport(summaryResult); err != nil {
-				return fmt.Errorf("failed to generate HTML report: %w", err)
-			}
-		case "Lcov":
-			if err := lcov.NewLcovReportBuilder(outputDir).CreateReport(summaryResult); err != nil {
-				return fmt.Errorf("failed to generate lcov report: %w", err)
+		stop := stats.Start("report:" + trimmedType)
+		err := generateReport(reportCtx, trimmedType, outputDir, summaryResult, baseline)
+		stop()
+		if err != nil {
+			return err
+		}
+		reportProgress.Increment()
+	}
+	return nil
+}
+
+// generateReport writes a single report type into outputDir.
+func generateReport(reportCtx reporter.IBuilderContext, reportType, outputDir string, summaryResult, baseline *model.SummaryResult) error {
+	logger := reportCtx.Logger()
+	reportConfig := reportCtx.ReportConfiguration()
+
+	switch reportType {
+	case "TextSummary":
+		// The mode was validated when the configuration was created.
+		roundingMode, _ := utils.ParseRoundingMode(reportCtx.Settings().CoverageQuotaRoundingMode)
+		coverageAgeLimit := 0
+		if value := reportConfig.ReportTypeParameter("TextSummary", "coverageage"); value != "" {
+			if limit, err := strconv.Atoi(value); err == nil && limit >= 0 {
+				coverageAgeLimit = limit
+			} else {
+				logger.Warn("Invalid coverageage parameter of the TextSummary report, leaving out the section", "value", value)
 			}
 		}
+		builder := textsummary.NewTextReportBuilder(outputDir, logger,
+			textsummary.WithFileName(reportCtx.Settings().TextSummaryFileName),
+			textsummary.WithTitle(reportConfig.TitleForReportType("TextSummary")),
+			textsummary.WithDecimalPlaces(reportCtx.Settings().MaximumDecimalPlacesForCoverageQuotas),
+			textsummary.WithPercentageDecimalPlaces(reportCtx.Settings().MaximumDecimalPlacesForPercentageDisplay),
+			textsummary.WithCoverageQuotaRounding(roundingMode),
+			textsummary.WithClock(reportCtx.Now),
+			textsummary.WithGenerator(reportCtx.AppVersion(), reportCtx.CommandLine()),
+			textsummary.WithUncoveredLines(reportCtx.Settings().UncoveredLinesClassLimit),
+			textsummary.WithDirectoryTree(strings.EqualFold(reportConfig.ReportTypeParameter("TextSummary", "directories"), "true")),
+			textsummary.WithCoverageAge(coverageAgeLimit),
+			textsummary.WithAggregates(reportCtx.Aggregates(summaryResult)),
+			textsummary.WithPathCase(reporter.PathCaseMode(reportCtx.Settings())),
+		)
+		if err := builder.CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate text report: %w", err)
+		}
+	case "Html":
+		if err := htmlreport.NewHtmlReportBuilder(outputDir, reportCtx).CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate HTML report: %w", err)
+		}
+	case "HtmlSummary":
+		if err := htmlreport.NewHtmlSummaryReportBuilder(outputDir, reportCtx).CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate HTML summary report: %w", err)
+		}
+	case "Lcov":
+		if err := lcov.NewLcovReportBuilder(outputDir, logger, lcov.WithPathCase(reporter.PathCaseMode(reportCtx.Settings()))).CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate lcov report: %w", err)
+		}
+	case "CoverageGutters":
+		workspaceRoot := reportConfig.ReportTypeParameter("CoverageGutters", "workspace")
+		if workspaceRoot == "" {
+			workspaceRoot, _ = os.Getwd()
+		} else if absRoot, err := filepath.Abs(workspaceRoot); err == nil {
+			workspaceRoot = absRoot
+		}
+		builder := lcov.NewCoverageGuttersReportBuilder(outputDir, logger,
+			lcov.WithWorkspaceRoot(workspaceRoot),
+			lcov.WithWorkspaceOnly(strings.EqualFold(reportConfig.ReportTypeParameter("CoverageGutters", "workspaceonly"), "true")),
+			lcov.WithFileName(reportConfig.ReportTypeParameter("CoverageGutters", "file")),
+			lcov.WithPathCase(reporter.PathCaseMode(reportCtx.Settings())),
+		)
+		if err := builder.CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate Coverage Gutters report: %w", err)
+		}
+	case "XmlSummary":
+		// The mode was validated when the configuration was created.
+		roundingMode, _ := utils.ParseRoundingMode(reportCtx.Settings().CoverageQuotaRoundingMode)
+		builder := xmlsummary.NewXmlSummaryReportBuilder(outputDir, logger,
+			xmlsummary.WithDecimalPlaces(reportCtx.Settings().MaximumDecimalPlacesForCoverageQuotas),
+			xmlsummary.WithCoverageQuotaRounding(roundingMode),
+			xmlsummary.WithClock(reportCtx.Now),
+			xmlsummary.WithGenerator(reportCtx.AppVersion(), reportCtx.CommandLine()),
+			xmlsummary.WithAggregates(reportCtx.Aggregates(summaryResult)),
+		)
+		if err := builder.CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate XML summary report: %w", err)
+		}
+	case "Clover":
+		builder := clover.NewCloverReportBuilder(outputDir, logger,
+			clover.WithProjectName(reportConfig.TitleForReportType("Clover")),
+			clover.WithPackages(reportConfig.ReportTypeParameter("Clover", "packages")),
+			clover.WithTimestamp(reportConfig.ReportTypeParameter("Clover", "timestamp")),
+			clover.WithSourceDirectories(reportConfig.SourceDirectories()),
+			clover.WithClock(reportCtx.Now),
+			clover.WithPathCase(reporter.PathCaseMode(reportCtx.Settings())),
+		)
+		if err := builder.CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate Clover report: %w", err)
+		}
+	case "BadgesPerAssembly":
+		// The mode was validated when the configuration was created.
+		roundingMode, _ := utils.ParseRoundingMode(reportCtx.Settings().CoverageQuotaRoundingMode)
+		builder := badges.NewBadgesPerAssemblyReportBuilder(outputDir, logger,
+			badges.WithDecimalPlaces(reportCtx.Settings().MaximumDecimalPlacesForCoverageQuotas),
+			badges.WithCoverageQuotaRounding(roundingMode),
+			badges.WithAggregates(reportCtx.Aggregates(summaryResult)),
+		)
+		if err := builder.CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate badges per assembly: %w", err)
+		}
+	case "DeltaSummary":
+		if err := deltasummary.NewDeltaReportBuilder(outputDir, baseline, logger).CreateReport(summaryResult); err != nil {
+			return fmt.Errorf("failed to generate delta summary report: %w", err)
+		}
 	}
 	return nil
 }
 
+// newLanguageProcessorFactory creates all language processors of this build.
+func newLanguageProcessorFactory() *language.ProcessorFactory {
+	return language.NewProcessorFactory(
+		defaultformatter.NewDefaultProcessor(),
+		csharp.NewCSharpProcessor(),
+		golang.NewGoProcessor(),
+	)
+}
+
+// newParserFactory creates all coverage report parsers of this build.
+// The parsers read the source files with fileReader.
+func newParserFactory(fileReader filereader.Reader) *parsers.ParserFactory {
+	return parsers.NewParserFactory(
+		cobertura.NewCoberturaParser(fileReader),
+		gocover.NewGoCoverParser(fileReader),
+		vscoverage.NewVsCoverageParser(fileReader),
+	)
+}
+
 func run() error {
+	start := time.Now()
 	flags, err := parseFlags()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "flag error:", err)
 		os.Exit(1)
 	}
+	if *flags.version {
+		fmt.Fprintln(os.Stdout, "ReportGenerator", version.Get())
+		return nil
+	}
 
-	verbosity, closer, err := buildLogger(flags)
+	verbosity, terminal, closer, err := buildLogger(flags)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "logger init error:", err)
 		os.Exit(1)
@@ -295,23 +990,38 @@ func run() error {
 		defer closer.Close()
 	}
 
-	logger := slog.Default()
+	if flags.configFilePath != "" {
+		slog.Info("Using configuration file", "file", flags.configFilePath)
+		if len(flags.unknownConfigKeys) > 0 {
+			slog.Warn("Ignoring unknown keys of the configuration file", "file", flags.configFilePath, "keys", strings.Join(flags.unknownConfigKeys, ", "))
+		}
+	}
+	if *flags.printConfig {
+		return writeEffectiveConfig(os.Stdout, flag.CommandLine)
+	}
 
-	// Create all desired language processors and the factory that holds them.
-	langFactory := language.NewProcessorFactory(
-		defaultformatter.NewDefaultProcessor(),
-		csharp.NewCSharpProcessor(),
-		golang.NewGoProcessor(),
-	)
+	// -capabilities does not need any report, so it is handled before the inputs are validated.
+	if *flags.capabilities {
+		return writeCapabilities(os.Stdout, collectCapabilities(newParserFactory(filereader.NewDefaultReader()), newLanguageProcessorFactory()), *flags.capabilitiesFormat)
+	}
 
-	// The fileReader dependency is created here once from the central package.
-	prodFileReader := filereader.NewDefaultReader()
-	parserFactory := parsers.NewParserFactory(
-		cobertura.NewCoberturaParser(prodFileReader),
-		gocover.NewGoCoverParser(prodFileReader),
-	)
+	logger := slog.Default()
+	utils.EnableLongPaths(*flags.longPaths)
+	fileRetry, err := fileRetryPolicy(flags)
+	if err != nil {
+		return err
+	}
+	pathCase, err := utils.ParsePathCaseMode(*flags.pathCase)
+	if err != nil {
+		return fmt.Errorf("invalid -pathcase: %w", err)
+	}
+
+	langFactory := newLanguageProcessorFactory()
 
-	actualReportFiles, invalidPatterns, err := resolveAndValidateInputs(logger, flags)
+	stats := runstats.New()
+	stopGlob := stats.Start("glob")
+	actualReportFiles, invalidPatterns, err := resolveAndValidateInputs(logger, flags, utils.PathKeyFunc(pathCase, false), fileRetry)
+	stopGlob()
 	if err != nil {
 		if len(invalidPatterns) > 0 {
 			return fmt.Errorf("%w; invalid patterns: %s", err, strings.Join(invalidPatterns, ", "))
@@ -319,20 +1029,86 @@ func run() error {
 		return err
 	}
 
+	stats.AddReportFiles(len(actualReportFiles))
+
 	// Pass the language factory to create the configuration
 	reportConfig, err := createReportConfiguration(flags, verbosity, actualReportFiles, invalidPatterns, langFactory, logger)
 	if err != nil {
 		return err
 	}
+	if err := reportconfig.WithProgress(newProgressReporter(logger, terminal))(reportConfig); err != nil {
+		return err
+	}
+	sourceReader, err := filereader.NewSourceFileReader(reportConfig.Settings().SourceFileEncoding)
+	if err != nil {
+		return err
+	}
+	parserFactory := newParserFactory(sourceReader)
+
+	if *flags.serve != "" {
+		return serveReport(logger, reportConfig, parserFactory, *flags.serve)
+	}
 
 	// Pass the parser factory to the parsing logic
-	summaryResult, err := parseAndMergeReports(logger, reportConfig, parserFactory)
+	summaryResult, err := parseAndMergeReports(logger, reportConfig, parserFactory, stats)
 	if err != nil {
 		return err
 	}
 
-	reportCtx := reporter.NewBuilderContext(reportConfig, settings.NewSettings(), logger)
-	return generateReports(reportCtx, summaryResult)
+	baseline, err := parseBaseline(logger, flags, reportConfig, verbosity, langFactory, parserFactory)
+	if err != nil {
+		return err
+	}
+
+	reportCtx := reporter.NewBuilderContext(reportConfig, reportConfig.Settings(), logger, reporter.WithCommandLine(sanitizedCommandLine(os.Args)))
+	runHistory, err := loadHistory(reportCtx, summaryResult, stats)
+	if err != nil {
+		return err
+	}
+	if err := generateReports(reportCtx, summaryResult, baseline, stats); err != nil {
+		return err
+	}
+	// The history is saved after the reports, so that failed runs leave no entry.
+	if err := runHistory.save(reportCtx, stats); err != nil {
+		return err
+	}
+	logSummary(logger, reportConfig, summaryResult, start)
+	logger.Info("Run statistics", stats.LogAttrs()...)
+	if *flags.quiet || *flags.statsJSON {
+		if err := stats.WriteJSONLine(os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	if skipped := len(summaryResult.SkippedReports); skipped > 0 {
+		logger.Warn("Some report files could not be parsed and were skipped", "count", skipped)
+	}
+	if missing := reporter.CountMissingSourceFiles(summaryResult.MissingSourceFiles, reporter.PathKeyFunc(reportConfig.Settings())); missing > 0 {
+		logger.Warn("Some source files could not be found", "count", missing)
+		if *flags.failOnMissingSrc {
+			return fmt.Errorf("%d source file(s) could not be found (-failonmissingsources)", missing)
+		}
+	}
+	return nil
+}
+
+// serveReport serves the Html report on addr until the process is interrupted. No report
+// is written to the output directory.
+func serveReport(logger *slog.Logger, reportConfig *reportconfig.ReportConfiguration, parserFactory *parsers.ParserFactory, addr string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if reportConfig.Settings().DumpModel != "" {
+		logger.Warn("Ignoring -dumpmodel, no files are written with -serve")
+		reportConfig.Settings().DumpModel = ""
+	}
+	load := func() (*model.SummaryResult, error) {
+		return parseAndMergeReports(logger, reportConfig, parserFactory, nil)
+	}
+	reportCtx := reporter.NewBuilderContext(reportConfig, reportConfig.Settings(), logger, reporter.WithCommandLine(sanitizedCommandLine(os.Args)))
+	server := htmlserve.NewServer(reportCtx, load, htmlserve.WithWatchedFiles(reportConfig.ReportFiles()))
+	fmt.Fprintf(os.Stderr, "Serving the coverage report on %s (press Ctrl+C to stop)\n", addr)
+	return server.ListenAndServe(ctx, addr)
 }
 
 func main() {
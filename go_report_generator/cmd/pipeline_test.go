@@ -0,0 +1,783 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/logging"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/runstats"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// writePipelineFixtures writes a Cobertura report with several packages and classes, a Go
+// cover profile with several packages and files, and their sources. It returns the report
+// paths and the source directory.
+func writePipelineFixtures(t *testing.T) ([]string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "src")
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	var cobertura strings.Builder
+	cobertura.WriteString(`<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.5" branch-rate="0.5" timestamp="1700000000" version="1.9">
+  <sources><source>` + srcDir + `</source></sources>
+  <packages>
+`)
+	for p := 0; p < 3; p++ {
+		fmt.Fprintf(&cobertura, "    <package name=\"Assembly%d\" line-rate=\"0.5\" branch-rate=\"0.5\">\n      <classes>\n", p)
+		for c := 0; c < 6; c++ {
+			write(filepath.Join(srcDir, fmt.Sprintf("Assembly%d/Class%d.cs", p, c)), "class C\n{\n    int A() => 1;\n    int B(bool x) => x ? 1 : 2;\n}\n")
+			fmt.Fprintf(&cobertura, `        <class name="Assembly%[1]d.Class%[2]d" filename="Assembly%[1]d/Class%[2]d.cs" line-rate="0.5" branch-rate="0.5">
+          <methods />
+          <lines>
+            <line number="3" hits="%[2]d" branch="false" />
+            <line number="4" hits="0" branch="true" condition-coverage="50%% (1/2)" />
+          </lines>
+        </class>
+`, p, c)
+		}
+		cobertura.WriteString("      </classes>\n    </package>\n")
+	}
+	cobertura.WriteString("  </packages>\n</coverage>\n")
+	coberturaPath := filepath.Join(dir, "coverage.xml")
+	write(coberturaPath, cobertura.String())
+
+	profile := []string{"mode: set"}
+	write(filepath.Join(srcDir, "go.mod"), "module example.com/tool\n")
+	for _, pkg := range []string{"lexer", "parser", "ast", "eval"} {
+		for _, file := range []string{"a.go", "b.go", "c.go"} {
+			write(filepath.Join(srcDir, pkg, file), "package "+pkg+"\n\nfunc F() int {\n\treturn 1\n}\n")
+			profile = append(profile, fmt.Sprintf("%s/%s:4.2,4.10 1 %d", pkg, file, len(file)%2))
+		}
+	}
+	profilePath := filepath.Join(dir, "cover.out")
+	write(profilePath, strings.Join(profile, "\n")+"\n")
+
+	return []string{coberturaPath, profilePath}, srcDir
+}
+
+// pipelineTestCommandLine is the command line recorded by runPipelineForTest.
+const pipelineTestCommandLine = "ReportGenerator -reports=coverage.xml;cover.out"
+
+// runPipelineForTest parses and merges the reports and writes the Html and TextSummary
+// reports with a fixed clock, version and command line.
+func runPipelineForTest(t *testing.T, reportFiles []string, srcDir, outputDir string) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, outputDir,
+		reportconfig.WithLogger(logger),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSourceDirectories([]string{srcDir}),
+		reportconfig.WithReportTypes([]string{"Html", "TextSummary"}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+
+	summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+	if err != nil {
+		t.Fatalf("parseAndMergeReports returned error: %v", err)
+	}
+
+	fixedTime := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	ctx := reporter.NewBuilderContext(cfg, cfg.Settings(), logger,
+		reporter.WithClock(func() time.Time { return fixedTime }),
+		reporter.WithAppVersion("1.0.0-test"),
+		reporter.WithCommandLine(pipelineTestCommandLine),
+	)
+	if err := generateReports(ctx, summary, nil, nil); err != nil {
+		t.Fatalf("generateReports returned error: %v", err)
+	}
+}
+
+// TestPipeline_DeterministicOutput runs the whole pipeline twice on the same inputs and
+// expects byte-identical reports, independent of map iteration order.
+func TestPipeline_DeterministicOutput(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	first, second := t.TempDir(), t.TempDir()
+
+	runPipelineForTest(t, reportFiles, srcDir, first)
+	runPipelineForTest(t, reportFiles, srcDir, second)
+
+	pages, err := filepath.Glob(filepath.Join(first, "*.html"))
+	if err != nil || len(pages) < 2 {
+		t.Fatalf("expected the summary and class pages, got %v (err: %v)", pages, err)
+	}
+	for _, path := range append(pages, filepath.Join(first, "Summary.txt")) {
+		name := filepath.Base(path)
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		got, err := os.ReadFile(filepath.Join(second, name))
+		if err != nil {
+			t.Fatalf("failed to read %s of the second run: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s differs between two runs on the same inputs", name)
+		}
+	}
+}
+
+// TestPipeline_RecordsGenerator checks that the reports name the version of the generator
+// and the command line it was run with.
+func TestPipeline_RecordsGenerator(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	outputDir := t.TempDir()
+
+	runPipelineForTest(t, reportFiles, srcDir, outputDir)
+
+	for _, name := range []string{"index.html", "Summary.txt"} {
+		content, err := os.ReadFile(filepath.Join(outputDir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		for _, want := range []string{"ReportGenerator 1.0.0-test", pipelineTestCommandLine} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s does not contain %q", name, want)
+			}
+		}
+	}
+}
+
+// TestPipeline_TextSummaryDirectoryTree checks that TextSummary{directories=true} lists the
+// coverage of the directories below the source directory.
+func TestPipeline_TextSummaryDirectoryTree(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	outputDir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, outputDir,
+		reportconfig.WithLogger(logger),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSourceDirectories([]string{srcDir}),
+		reportconfig.WithReportTypeSpecs("TextSummary{directories=true}"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+
+	summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+	if err != nil {
+		t.Fatalf("parseAndMergeReports returned error: %v", err)
+	}
+	if err := generateReports(reporter.NewBuilderContext(cfg, cfg.Settings(), logger), summary, nil, nil); err != nil {
+		t.Fatalf("generateReports returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "Summary.txt"))
+	if err != nil {
+		t.Fatalf("failed to read Summary.txt: %v", err)
+	}
+	text := string(content)
+	if !strings.Contains(text, "Coverage by directory: "+filepath.ToSlash(srcDir)+"\n") {
+		t.Fatalf("Summary.txt has no directory tree for %s:\n%s", srcDir, text)
+	}
+	for _, want := range []string{"  Assembly0    41%    (5 of 12)", "  lexer        0%     (0 of 3)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Summary.txt does not contain %q:\n%s", want, text)
+		}
+	}
+}
+
+// TestPipeline_ReportTypesShowTheSameLineCoverage generates three report types from the
+// same summary and expects them to show the same line coverage quota, as they share the
+// aggregates of the report context.
+func TestPipeline_ReportTypesShowTheSameLineCoverage(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	outputDir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, outputDir,
+		reportconfig.WithLogger(logger),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSourceDirectories([]string{srcDir}),
+		reportconfig.WithReportTypes([]string{"Html", "TextSummary", "XmlSummary"}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+	if err != nil {
+		t.Fatalf("parseAndMergeReports returned error: %v", err)
+	}
+	if err := generateReports(reporter.NewBuilderContext(cfg, cfg.Settings(), logger), summary, nil, nil); err != nil {
+		t.Fatalf("generateReports returned error: %v", err)
+	}
+
+	find := func(file string, pattern *regexp.Regexp) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(outputDir, file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		match := pattern.FindSubmatch(content)
+		if match == nil {
+			t.Fatalf("%s does not match %s:\n%s", file, pattern, content)
+		}
+		return string(match[1])
+	}
+	html := find("index.html", regexp.MustCompile(`<div class="card-header">Line coverage</div>\s*<div class="card-body">\s*<div class="large cardpercentagebar cardpercentagebar\d+"[^>]*>([^<]+)</div>`))
+	text := find("Summary.txt", regexp.MustCompile(`(?m)^  Line coverage: (.+)$`))
+	xmlQuota, err := strconv.ParseFloat(find("Summary.xml", regexp.MustCompile(`<Linecoverage>([^<]+)</Linecoverage>`)), 64)
+	if err != nil {
+		t.Fatalf("invalid line coverage in Summary.xml: %v", err)
+	}
+
+	// Html and TextSummary show the quota of XmlSummary without decimal places.
+	xml := utils.FormatPercentageWithMode(xmlQuota, 0, utils.RoundingTruncate)
+	if html != text || text != xml {
+		t.Errorf("line coverage differs: Html %q, TextSummary %q, XmlSummary %v (%q)", html, text, xmlQuota, xml)
+	}
+	if want := utils.CalculatePercentageWithMode(summary.LinesCovered, summary.LinesValid, 1, utils.RoundingTruncate); xmlQuota != want {
+		t.Errorf("XmlSummary line coverage = %v, want %v", xmlQuota, want)
+	}
+}
+
+// TestPipeline_TextSummaryTitle expects the TextSummary heading to be the -title, unless
+// the report type has a title of its own.
+func TestPipeline_TextSummaryTitle(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for reportTypes, want := range map[string]string{
+		"TextSummary":                 "Nightly build",
+		"TextSummary{title=Frontend}": "Frontend",
+	} {
+		t.Run(reportTypes, func(t *testing.T) {
+			outputDir := t.TempDir()
+			cfg, err := reportconfig.NewReportConfiguration(reportFiles, outputDir,
+				reportconfig.WithLogger(logger),
+				reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+				reportconfig.WithSourceDirectories([]string{srcDir}),
+				reportconfig.WithTitle("Nightly build"),
+				reportconfig.WithReportTypeSpecs(reportTypes),
+			)
+			if err != nil {
+				t.Fatalf("failed to create report configuration: %v", err)
+			}
+			summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+			if err != nil {
+				t.Fatalf("parseAndMergeReports returned error: %v", err)
+			}
+			if err := generateReports(reporter.NewBuilderContext(cfg, cfg.Settings(), logger), summary, nil, nil); err != nil {
+				t.Fatalf("generateReports returned error: %v", err)
+			}
+
+			text, err := os.ReadFile(filepath.Join(outputDir, "Summary.txt"))
+			if err != nil {
+				t.Fatalf("failed to read Summary.txt: %v", err)
+			}
+			if !strings.HasPrefix(string(text), want+"\n") {
+				t.Errorf("expected Summary.txt to start with the title %q:\n%s", want, text)
+			}
+		})
+	}
+}
+
+// TestPipeline_RunStatistics checks that the run statistics count the parsed, duplicate and
+// unparseable report files and match the merged coverage data.
+func TestPipeline_RunStatistics(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	dir := filepath.Dir(reportFiles[0])
+	duplicate := filepath.Join(dir, "coverage-copy.xml")
+	unparseable := filepath.Join(dir, "notes.txt")
+	content, err := os.ReadFile(reportFiles[0])
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	for path, data := range map[string][]byte{duplicate: content, unparseable: []byte("no coverage here\n")} {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	reportFiles = append(reportFiles, duplicate, unparseable)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, t.TempDir(),
+		reportconfig.WithLogger(logger),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSourceDirectories([]string{srcDir}),
+		reportconfig.WithReportTypes([]string{"Html", "TextSummary"}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	stats := runstats.New()
+	stats.AddReportFiles(len(reportFiles))
+
+	summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), stats)
+	if err != nil {
+		t.Fatalf("parseAndMergeReports returned error: %v", err)
+	}
+	if err := generateReports(reporter.NewBuilderContext(cfg, cfg.Settings(), logger), summary, nil, stats); err != nil {
+		t.Fatalf("generateReports returned error: %v", err)
+	}
+
+	classes := 0
+	for _, assembly := range summary.Assemblies {
+		classes += len(assembly.Classes)
+	}
+	if stats.ReportFiles != 4 || stats.ParsedReports != 2 || stats.SkippedReports != 1 || stats.FailedReports != 1 {
+		t.Errorf("expected 4 report files, 2 parsed, 1 skipped and 1 failed, got %d, %d, %d and %d",
+			stats.ReportFiles, stats.ParsedReports, stats.SkippedReports, stats.FailedReports)
+	}
+	if stats.Assemblies != len(summary.Assemblies) || stats.Classes != classes || stats.Files != 30 {
+		t.Errorf("expected %d assemblies, %d classes and 30 files, got %d, %d and %d",
+			len(summary.Assemblies), classes, stats.Assemblies, stats.Classes, stats.Files)
+	}
+
+	var phases []string
+	for _, phase := range stats.Phases {
+		phases = append(phases, phase.Name)
+	}
+	if got, want := strings.Join(phases, ","), "parse,merge,report:Html,report:TextSummary"; got != want {
+		t.Errorf("expected phases %s, got %s", want, got)
+	}
+}
+
+// TestPipeline_Progress checks that parsing and report generation report their progress
+// through the progress reporter of the configuration.
+func TestPipeline_Progress(t *testing.T) {
+	reportFiles, srcDir := writePipelineFixtures(t)
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, t.TempDir(),
+		reportconfig.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSourceDirectories([]string{srcDir}),
+		reportconfig.WithReportTypes([]string{"Html", "TextSummary"}),
+		reportconfig.WithProgress(newProgressReporter(logger, nil)),
+	)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+
+	summary, err := parseAndMergeReports(cfg.Logger(), cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+	if err != nil {
+		t.Fatalf("parseAndMergeReports returned error: %v", err)
+	}
+	if err := generateReports(reporter.NewBuilderContext(cfg, cfg.Settings(), cfg.Logger()), summary, nil, nil); err != nil {
+		t.Fatalf("generateReports returned error: %v", err)
+	}
+
+	classes := countParsedClasses(summary.Assemblies)
+	for _, want := range []string{
+		`msg="Parsed 2/2 report files"`,
+		fmt.Sprintf(`msg="Rendered %d/%d class pages"`, classes, classes),
+		`msg="Generated 1/2 report types"`,
+		`msg="Generated 2/2 report types"`,
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected %s in the logs, got:\n%s", want, logs.String())
+		}
+	}
+}
+
+func TestShowTerminalProgress(t *testing.T) {
+	testCases := []struct {
+		name       string
+		level      logging.VerbosityLevel
+		logFile    string
+		isTerminal bool
+		want       bool
+	}{
+		{name: "InfoOnTerminal", level: logging.Info, isTerminal: true, want: true},
+		{name: "Piped", level: logging.Info},
+		{name: "LogFile", level: logging.Info, logFile: "run.log", isTerminal: true},
+		{name: "Verbose", level: logging.Verbose, isTerminal: true},
+		{name: "Error", level: logging.Error, isTerminal: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := showTerminalProgress(tc.level, tc.logFile, tc.isTerminal); got != tc.want {
+				t.Errorf("showTerminalProgress() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildLogger_TerminalProgress(t *testing.T) {
+	originalDefault, originalIsTerminal := slog.Default(), stderrIsTerminal
+	t.Cleanup(func() {
+		slog.SetDefault(originalDefault)
+		stderrIsTerminal = originalIsTerminal
+	})
+	stderrIsTerminal = func() bool { return true }
+
+	for _, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{args: []string{"-verbosity=Info"}, want: true},
+		{args: []string{"-verbosity=Info", "-quiet"}},
+		{args: []string{"-verbosity=Info", "-logfile=" + filepath.Join(t.TempDir(), "run.log")}},
+		{args: nil},
+	} {
+		flags, _, err := parseTestFlags(t, t.TempDir(), tc.args...)
+		if err != nil {
+			t.Fatalf("%v: failed to parse flags: %v", tc.args, err)
+		}
+		_, terminal, closer, err := buildLogger(flags)
+		if err != nil {
+			t.Fatalf("%v: buildLogger returned error: %v", tc.args, err)
+		}
+		if closer != nil {
+			closer.Close()
+		}
+		if got := terminal != nil; got != tc.want {
+			t.Errorf("%v: terminal progress = %v, want %v", tc.args, got, tc.want)
+		}
+	}
+}
+
+// TestPipeline_ExcludeTests expects -excludetests to remove test assemblies of Cobertura
+// reports and test files of Go profiles, and to keep names that only partially match.
+func TestPipeline_ExcludeTests(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "src")
+	files := map[string]string{
+		"go.mod":              "module example.com/shop\n",
+		"cart.go":             "package shop\n\nfunc F() int {\n\treturn 1\n}\n",
+		"cart_test.go":        "package shop\n\nfunc G() int {\n\treturn 1\n}\n",
+		"testdata/fixture.go": "package testdata\n\nfunc H() int {\n\treturn 1\n}\n",
+		"testing/helpers.go":  "package testing\n\nfunc I() int {\n\treturn 1\n}\n",
+	}
+	var cobertura strings.Builder
+	cobertura.WriteString(`<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="1" branch-rate="1" timestamp="1700000000" version="1.9">
+  <sources><source>` + srcDir + `</source></sources>
+  <packages>
+`)
+	for _, assembly := range []string{"Shop", "Shop.Tests", "ShopTests", "Testing"} {
+		fmt.Fprintf(&cobertura, `    <package name="%[1]s">
+      <classes>
+        <class name="%[1]s.Class" filename="%[1]s/Class.cs">
+          <methods />
+          <lines><line number="1" hits="1" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+`, assembly)
+		files[assembly+"/Class.cs"] = "class Class {}\n"
+	}
+	cobertura.WriteString("  </packages>\n</coverage>\n")
+	files["coverage/cobertura.xml"] = cobertura.String()
+	files["coverage/cover.out"] = "mode: set\n" +
+		"example.com/shop/cart.go:4.2,4.10 1 1\n" +
+		"example.com/shop/cart_test.go:4.2,4.10 1 1\n" +
+		"example.com/shop/testdata/fixture.go:4.2,4.10 1 1\n" +
+		"example.com/shop/testing/helpers.go:4.2,4.10 1 1\n"
+	for name, content := range files {
+		path := filepath.Join(srcDir, name)
+		if strings.HasPrefix(name, "coverage/") {
+			path = filepath.Join(dir, name)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	reportFiles := []string{filepath.Join(dir, "coverage", "cobertura.xml"), filepath.Join(dir, "coverage", "cover.out")}
+
+	parse := func(excludeTests bool) (assemblies []string, sourceFiles []string) {
+		t.Helper()
+		appSettings := settings.NewSettings()
+		appSettings.ExcludeTestProjects = excludeTests
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		cfg, err := reportconfig.NewReportConfiguration(reportFiles, t.TempDir(),
+			reportconfig.WithLogger(logger),
+			reportconfig.WithSettings(appSettings),
+			reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+			reportconfig.WithSourceDirectories([]string{srcDir}),
+		)
+		if err != nil {
+			t.Fatalf("failed to create report configuration: %v", err)
+		}
+		summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+		if err != nil {
+			t.Fatalf("parseAndMergeReports returned error: %v", err)
+		}
+		for _, assembly := range summary.Assemblies {
+			assemblies = append(assemblies, assembly.Name)
+			for _, class := range assembly.Classes {
+				for _, file := range class.Files {
+					sourceFiles = append(sourceFiles, filepath.ToSlash(strings.TrimPrefix(file.Path, srcDir)))
+				}
+			}
+		}
+		slices.Sort(assemblies)
+		slices.Sort(sourceFiles)
+		return assemblies, sourceFiles
+	}
+
+	assemblies, sourceFiles := parse(false)
+	if !slices.Contains(assemblies, "Shop.Tests") || !slices.Contains(sourceFiles, "/cart_test.go") {
+		t.Fatalf("expected the tests without -excludetests, got assemblies %v and files %v", assemblies, sourceFiles)
+	}
+
+	assemblies, sourceFiles = parse(true)
+	for _, excluded := range []string{"Shop.Tests", "ShopTests"} {
+		if slices.Contains(assemblies, excluded) {
+			t.Errorf("expected assembly %s to be excluded, got %v", excluded, assemblies)
+		}
+	}
+	for _, kept := range []string{"Shop", "Testing"} {
+		if !slices.Contains(assemblies, kept) {
+			t.Errorf("expected assembly %s to be kept, got %v", kept, assemblies)
+		}
+	}
+	want := []string{"/Shop/Class.cs", "/Testing/Class.cs", "/cart.go", "/testing/helpers.go"}
+	if !slices.Equal(sourceFiles, want) {
+		t.Errorf("source files = %v, want %v", sourceFiles, want)
+	}
+}
+
+func TestPipeline_ExclusionComments(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "src")
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n",
+		"cart.go": "package shop\n\nfunc F(ok bool) int {\n\tif !ok {\n\t\tpanic(\"unreachable\") // coverage:ignore\n\t}\n" +
+			"\t// coverage:ignore-start\n\tlog()\n\t// coverage:ignore-end\n\treturn 1\n}\n",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(srcDir, name)), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	profilePath := filepath.Join(dir, "cover.out")
+	profile := "mode: set\n" +
+		"example.com/shop/cart.go:4.2,4.9 1 1\n" +
+		"example.com/shop/cart.go:5.3,5.35 1 0\n" +
+		"example.com/shop/cart.go:8.2,8.7 1 0\n" +
+		"example.com/shop/cart.go:10.2,10.10 1 1\n"
+	if err := os.WriteFile(profilePath, []byte(profile), 0o644); err != nil {
+		t.Fatalf("failed to write the profile: %v", err)
+	}
+
+	parse := func(excludeByComments bool) (covered, valid int) {
+		t.Helper()
+		appSettings := settings.NewSettings()
+		appSettings.ExcludeCoverageByComments = excludeByComments
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		cfg, err := reportconfig.NewReportConfiguration([]string{profilePath}, t.TempDir(),
+			reportconfig.WithLogger(logger),
+			reportconfig.WithSettings(appSettings),
+			reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+			reportconfig.WithSourceDirectories([]string{srcDir}),
+		)
+		if err != nil {
+			t.Fatalf("failed to create report configuration: %v", err)
+		}
+		summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+		if err != nil {
+			t.Fatalf("parseAndMergeReports returned error: %v", err)
+		}
+		return summary.LinesCovered, summary.LinesValid
+	}
+
+	if covered, valid := parse(false); covered != 2 || valid != 4 {
+		t.Errorf("without -exclusioncomments: covered/valid = %d/%d, want 2/4", covered, valid)
+	}
+	if covered, valid := parse(true); covered != 2 || valid != 2 {
+		t.Errorf("with -exclusioncomments: covered/valid = %d/%d, want 2/2", covered, valid)
+	}
+}
+
+// TestPipeline_OverlappingReportsShowTheSameLineCoverage merges two reports of the same
+// file that both report line 4, and expects TextSummary, Lcov and Clover to count each
+// line once and to show the same line coverage quota.
+func TestPipeline_OverlappingReportsShowTheSameLineCoverage(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "src")
+	if err := os.MkdirAll(srcDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	source := "class Cart\n{\n    int A() => 1;\n    int B() => 2;\n    int C() => 3;\n}\n"
+	if err := os.WriteFile(filepath.Join(srcDir, "Cart.cs"), []byte(source), 0o644); err != nil {
+		t.Fatalf("failed to write the source: %v", err)
+	}
+
+	// The first report covers line 3 of lines 3 and 4, the second line 4 of lines 4 and 5.
+	var reportFiles []string
+	for i, lines := range []string{
+		`<line number="3" hits="1" branch="false" /><line number="4" hits="0" branch="false" />`,
+		`<line number="4" hits="2" branch="false" /><line number="5" hits="0" branch="false" />`,
+	} {
+		report := `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.5" branch-rate="0" timestamp="1700000000" version="1.9">
+  <sources><source>` + srcDir + `</source></sources>
+  <packages><package name="Shop"><classes>
+    <class name="Shop.Cart" filename="Cart.cs" line-rate="0.5" branch-rate="0">
+      <methods />
+      <lines>` + lines + `</lines>
+    </class>
+  </classes></package></packages>
+</coverage>
+`
+		path := filepath.Join(dir, fmt.Sprintf("coverage%d.xml", i))
+		if err := os.WriteFile(path, []byte(report), 0o644); err != nil {
+			t.Fatalf("failed to write the report: %v", err)
+		}
+		reportFiles = append(reportFiles, path)
+	}
+
+	outputDir := filepath.Join(dir, "out")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg, err := reportconfig.NewReportConfiguration(reportFiles, outputDir,
+		reportconfig.WithLogger(logger),
+		reportconfig.WithLanguageProcessorFactory(newLanguageProcessorFactory()),
+		reportconfig.WithSourceDirectories([]string{srcDir}),
+		reportconfig.WithReportTypes([]string{"TextSummary", "Lcov", "Clover"}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	summary, err := parseAndMergeReports(logger, cfg, newParserFactory(filereader.NewDefaultReader()), nil)
+	if err != nil {
+		t.Fatalf("parseAndMergeReports returned error: %v", err)
+	}
+	if err := generateReports(reporter.NewBuilderContext(cfg, cfg.Settings(), logger), summary, nil, nil); err != nil {
+		t.Fatalf("generateReports returned error: %v", err)
+	}
+
+	read := func(file string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(outputDir, file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		return string(content)
+	}
+	// lines sums the coverable and the covered lines matched by the two groups of pattern.
+	lines := func(file string, pattern *regexp.Regexp) (valid, covered int) {
+		t.Helper()
+		content := read(file)
+		matches := pattern.FindAllStringSubmatch(content, -1)
+		if matches == nil {
+			t.Fatalf("%s does not match %s:\n%s", file, pattern, content)
+		}
+		for _, match := range matches {
+			v, _ := strconv.Atoi(match[1])
+			c, _ := strconv.Atoi(match[2])
+			valid, covered = valid+v, covered+c
+		}
+		return valid, covered
+	}
+	lcovValid, lcovCovered := lines("lcov.info", regexp.MustCompile(`(?m)^LF:(\d+)\nLH:(\d+)$`))
+	cloverValid, cloverCovered := lines("clover.xml", regexp.MustCompile(`<project[^>]*>\s*<metrics statements="(\d+)" coveredstatements="(\d+)"`))
+	text := regexp.MustCompile(`(?m)^  Line coverage: (.+)$`).FindStringSubmatch(read("Summary.txt"))
+	if text == nil {
+		t.Fatalf("Summary.txt has no line coverage")
+	}
+
+	quota := func(covered, valid int) string {
+		return utils.FormatPercentageWithMode(utils.CalculatePercentageWithMode(covered, valid, 1, utils.RoundingTruncate), 0, utils.RoundingTruncate)
+	}
+	if lcovCovered != 2 || lcovValid != 3 {
+		t.Errorf("Lcov lines %d/%d, want 2/3", lcovCovered, lcovValid)
+	}
+	if cloverCovered != 2 || cloverValid != 3 {
+		t.Errorf("Clover statements %d/%d, want 2/3", cloverCovered, cloverValid)
+	}
+	if text[1] != quota(2, 3) || quota(lcovCovered, lcovValid) != text[1] || quota(cloverCovered, cloverValid) != text[1] {
+		t.Errorf("line coverage differs: TextSummary %q, Lcov %q, Clover %q, want %q",
+			text[1], quota(lcovCovered, lcovValid), quota(cloverCovered, cloverValid), quota(2, 3))
+	}
+}
+
+// TestPipeline_MethodCoverageFromMergedFragments merges two reports of a method that each
+// cover another branch of its line 4 and expects the metrics table of the class page to
+// show the branch coverage and the CrapScore of the merged lines.
+func TestPipeline_MethodCoverageFromMergedFragments(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "src")
+	if err := os.MkdirAll(srcDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	source := "class Cart\n{\n    int Check(bool x)\n    { return x ? 1 : 2; }\n}\n"
+	if err := os.WriteFile(filepath.Join(srcDir, "Cart.cs"), []byte(source), 0o644); err != nil {
+		t.Fatalf("failed to write the source: %v", err)
+	}
+
+	var reportFiles []string
+	for i, coverage := range [][2]string{{"100%", "0%"}, {"0%", "100%"}} {
+		report := `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="1" branch-rate="0.5" timestamp="1700000000" version="1.9">
+  <sources><source>` + srcDir + `</source></sources>
+  <packages><package name="Shop"><classes>
+    <class name="Shop.Cart" filename="Cart.cs" line-rate="1" branch-rate="0.5">
+      <methods><method name="Check" signature="(System.Boolean)" line-rate="1" branch-rate="0.5" complexity="2">
+        <lines>
+          <line number="3" hits="1" branch="false" />
+          <line number="4" hits="1" branch="true" condition-coverage="50% (1/2)">
+            <conditions><condition number="0" type="jump" coverage="` + coverage[0] + `" /><condition number="1" type="jump" coverage="` + coverage[1] + `" /></conditions>
+          </line>
+        </lines>
+      </method></methods>
+      <lines>
+        <line number="3" hits="1" branch="false" />
+        <line number="4" hits="1" branch="true" condition-coverage="50% (1/2)">
+          <conditions><condition number="0" type="jump" coverage="` + coverage[0] + `" /><condition number="1" type="jump" coverage="` + coverage[1] + `" /></conditions>
+        </line>
+      </lines>
+    </class>
+  </classes></package></packages>
+</coverage>
+`
+		path := filepath.Join(dir, fmt.Sprintf("coverage%d.xml", i))
+		if err := os.WriteFile(path, []byte(report), 0o644); err != nil {
+			t.Fatalf("failed to write the report: %v", err)
+		}
+		reportFiles = append(reportFiles, path)
+	}
+
+	outputDir := filepath.Join(dir, "out")
+	runPipelineForTest(t, reportFiles, srcDir, outputDir)
+
+	page, err := os.ReadFile(filepath.Join(outputDir, "ShopCart.html"))
+	if err != nil {
+		t.Fatalf("failed to read the class page: %v", err)
+	}
+	// Columns: Branch coverage, CrapScore, Cyclomatic complexity, Line coverage
+	row := regexp.MustCompile(`Check\(\.\.\.\)</a></td>\s*<td>([^<]*)</td>\s*<td[^>]*>([^<]*)</td>`).FindSubmatch(page)
+	if row == nil {
+		t.Fatalf("the metrics table has no row of Check")
+	}
+	if got := string(row[1]); got != "100%" {
+		t.Errorf("branch coverage of Check = %s, want 100%%", got)
+	}
+	// Each report alone covers half of the branches: 2² · 0.5³ + 2 = 2.5
+	if got := string(row[2]); got != "2.00" {
+		t.Errorf("CrapScore of Check = %s, want 2.00", got)
+	}
+}
@@ -0,0 +1,275 @@
+// Package e2e runs the reportgenerator binary against the fixture projects in testdata
+// and checks the written reports. The tests build the binary once and are skipped with
+// -short.
+package e2e
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// binaryPath is the reportgenerator binary built by TestMain. It is empty with -short.
+var binaryPath string
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if testing.Short() {
+		os.Exit(m.Run())
+	}
+
+	dir, err := os.MkdirTemp("", "reportgenerator-e2e")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to create build directory:", err)
+		os.Exit(1)
+	}
+	binaryPath = filepath.Join(dir, "reportgenerator")
+	if runtime.GOOS == "windows" {
+		binaryPath += ".exe"
+	}
+	build := exec.Command("go", "build", "-o", binaryPath, "../cmd")
+	if output, err := build.CombinedOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to build reportgenerator: %v\n%s", err, output)
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// cliResult is the outcome of one run of the binary.
+type cliResult struct {
+	exitCode  int
+	output    string // Combined stdout and stderr
+	outputDir string
+}
+
+// runCLI runs the binary with the arguments and an -output directory in t.TempDir().
+// Relative paths in the arguments are relative to testdata.
+func runCLI(t *testing.T, args ...string) cliResult {
+	t.Helper()
+	if binaryPath == "" {
+		t.Skip("end-to-end tests are skipped with -short")
+	}
+
+	outputDir := filepath.Join(t.TempDir(), "report")
+	cmd := exec.Command(binaryPath, append(args, "-output="+outputDir)...)
+	cmd.Dir = "testdata"
+	// A fixed locale keeps the Html report strings in English.
+	cmd.Env = append(os.Environ(), "LANG=C")
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	result := cliResult{output: output.String(), outputDir: outputDir}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		result.exitCode = exitErr.ExitCode()
+	case err != nil:
+		t.Fatalf("failed to run reportgenerator: %v", err)
+	}
+	return result
+}
+
+// mustSucceed fails the test if the run did not exit with code 0.
+func (r cliResult) mustSucceed(t *testing.T) cliResult {
+	t.Helper()
+	if r.exitCode != 0 {
+		t.Fatalf("reportgenerator exited with code %d, output:\n%s", r.exitCode, r.output)
+	}
+	return r
+}
+
+// readReport returns the content of a file in the output directory.
+func (r cliResult) readReport(t *testing.T, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(r.outputDir, name))
+	if err != nil {
+		t.Fatalf("failed to read report file: %v", err)
+	}
+	return string(content)
+}
+
+func (r cliResult) reportExists(name string) bool {
+	_, err := os.Stat(filepath.Join(r.outputDir, name))
+	return err == nil
+}
+
+func assertContains(t *testing.T, name, content string, expected ...string) {
+	t.Helper()
+	for _, e := range expected {
+		if !strings.Contains(content, e) {
+			t.Errorf("expected %s to contain %q", name, e)
+		}
+	}
+}
+
+func assertNotContains(t *testing.T, name, content string, unexpected ...string) {
+	t.Helper()
+	for _, u := range unexpected {
+		if strings.Contains(content, u) {
+			t.Errorf("expected %s not to contain %q", name, u)
+		}
+	}
+}
+
+func TestCLI_Cobertura(t *testing.T) {
+	result := runCLI(t, "-report=cobertura/coverage.xml", "-sourcedirs=cobertura/src").mustSucceed(t)
+
+	assertContains(t, "Summary.txt", result.readReport(t, "Summary.txt"),
+		"Parser: Cobertura",
+		"Assemblies: 2",
+		"Classes: 3",
+		"Line coverage: 63%",
+		"Covered lines: 7",
+		"Coverable lines: 11",
+		"Branch coverage: 50% (1 of 2)",
+		"Method coverage: 50% (2 of 4)",
+		"Shop.Web.HomeController    0%",
+	)
+	assertContains(t, "index.html", result.readReport(t, "index.html"), "Shop.Core.Cart", "Shop.Web.HomeController")
+	assertContains(t, "Shop.CoreCart.html", result.readReport(t, "Shop.CoreCart.html"),
+		"<title>Shop.Core.Cart - Coverage Report</title>",
+		"80%",
+		"count += quantity;",
+	)
+}
+
+func TestCLI_GoCoverProfile(t *testing.T) {
+	result := runCLI(t, "-report=gocover/cover.out", "-sourcedirs=gocover/src").mustSucceed(t)
+
+	assertContains(t, "Summary.txt", result.readReport(t, "Summary.txt"),
+		"Parser: GoCover",
+		"Line coverage: 60%",
+		"Covered lines: 3",
+		"Coverable lines: 5",
+		"Method coverage: 66% (2 of 3)",
+		"example.com/calc    60%",
+		"  mathx             75%",
+	)
+	assertContains(t, "index.html", result.readReport(t, "index.html"), "example.com/calc")
+	assertContains(t, "calcmathx.html", result.readReport(t, "calcmathx.html"), "75%", "func Abs(v int) int {")
+}
+
+func TestCLI_MixedReports(t *testing.T) {
+	result := runCLI(t, "-report=cobertura/coverage.xml;gocover/cover.out", "-sourcedirs=cobertura/src,gocover/src").mustSucceed(t)
+
+	assertContains(t, "Summary.txt", result.readReport(t, "Summary.txt"),
+		"Assemblies: 3",
+		"Classes: 5",
+		"Covered lines: 10",
+		"Coverable lines: 16",
+		"Line coverage: 62%",
+		"Shop.Core",
+		"example.com/calc",
+	)
+	for _, page := range []string{"index.html", "Shop.CorePrice.html", "calcformat.html"} {
+		if !result.reportExists(page) {
+			t.Errorf("expected %s to be written", page)
+		}
+	}
+}
+
+func TestCLI_FiltersRemoveContent(t *testing.T) {
+	result := runCLI(t,
+		"-report=cobertura/coverage.xml;gocover/cover.out",
+		"-sourcedirs=cobertura/src,gocover/src",
+		"-assemblyfilters=-Shop.Web",
+		"-classfilters=-Shop.Core.Price",
+	).mustSucceed(t)
+
+	summary := result.readReport(t, "Summary.txt")
+	assertContains(t, "Summary.txt", summary, "Shop.Core.Cart", "example.com/calc", "Coverable lines: 10")
+	assertNotContains(t, "Summary.txt", summary, "Shop.Web", "Shop.Core.Price")
+	assertNotContains(t, "index.html", result.readReport(t, "index.html"), "HomeController", "Shop.Core.Price")
+	for _, page := range []string{"Shop.WebHomeController.html", "Shop.CorePrice.html"} {
+		if result.reportExists(page) {
+			t.Errorf("expected %s not to be written", page)
+		}
+	}
+}
+
+func TestCLI_ReportTypesAndTextSummaryFile(t *testing.T) {
+	result := runCLI(t, "-report=gocover/cover.out", "-sourcedirs=gocover/src", "-reporttypes=TextSummary", "-textsummaryfile=coverage.txt").mustSucceed(t)
+
+	assertContains(t, "coverage.txt", result.readReport(t, "coverage.txt"), "Line coverage: 60%")
+	if result.reportExists("index.html") {
+		t.Error("expected no Html report without Html in -reporttypes")
+	}
+}
+
+func TestCLI_CorruptReportIsSkipped(t *testing.T) {
+	args := []string{"-report=cobertura/coverage.xml;corrupt/coverage.xml", "-sourcedirs=cobertura/src"}
+
+	result := runCLI(t, args...).mustSucceed(t)
+	summary := result.readReport(t, "Summary.txt")
+	assertContains(t, "Summary.txt", summary,
+		"Covered lines: 7",
+		"WARNING: 1 report file(s) could not be parsed. Their coverage is not included.",
+		"corrupt/coverage.xml (Cobertura): ",
+	)
+	assertContains(t, "index.html", result.readReport(t, "index.html"),
+		"Skipped report files (1)",
+		`corrupt/coverage.xml</td><td>Cobertura</td><td>`,
+		"XML syntax error on line 9: unexpected EOF",
+	)
+
+	strict := runCLI(t, append(args, "-failonparseerror")...)
+	if strict.exitCode != 1 {
+		t.Fatalf("expected exit code 1 with -failonparseerror, got %d, output:\n%s", strict.exitCode, strict.output)
+	}
+	assertContains(t, "the output", strict.output, "1 report file(s) could not be parsed (-failonparseerror)", "corrupt/coverage.xml")
+	if strict.reportExists("Summary.txt") || strict.reportExists("index.html") {
+		t.Error("expected no reports to be written with -failonparseerror")
+	}
+}
+
+func TestCLI_Failures(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		expectedOutput string
+	}{
+		{
+			name:           "MissingReport",
+			args:           []string{"-report=missing.xml"},
+			expectedOutput: "no valid report files found",
+		},
+		{
+			name:           "UnknownReportType",
+			args:           []string{"-report=gocover/cover.out", "-reporttypes=Nope"},
+			expectedOutput: "Nope",
+		},
+		{
+			name:           "MissingSourcesWithFailOnMissingSources",
+			args:           []string{"-report=cobertura/coverage.xml", "-sourcedirs=gocover/src", "-failonmissingsources"},
+			expectedOutput: "-failonmissingsources",
+		},
+		{
+			name:           "OnlyCorruptReports",
+			args:           []string{"-report=corrupt/coverage.xml"},
+			expectedOutput: "no coverage reports could be parsed successfully",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runCLI(t, tt.args...)
+
+			if result.exitCode != 1 {
+				t.Fatalf("expected exit code 1, got %d, output:\n%s", result.exitCode, result.output)
+			}
+			assertContains(t, "the output", result.output, tt.expectedOutput)
+		})
+	}
+}
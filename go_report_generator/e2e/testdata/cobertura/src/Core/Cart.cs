@@ -0,0 +1,20 @@
+namespace Shop.Core
+{
+    public class Cart
+    {
+        private int count;
+
+        public void Add(int quantity)
+        {
+            if (quantity > 0)
+            {
+                count += quantity;
+            }
+        }
+
+        public int Count()
+        {
+            return count;
+        }
+    }
+}
@@ -0,0 +1,10 @@
+namespace Shop.Core
+{
+    public static class Price
+    {
+        public static decimal Net(decimal gross)
+        {
+            return gross / 1.19m;
+        }
+    }
+}
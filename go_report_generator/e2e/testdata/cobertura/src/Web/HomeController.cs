@@ -0,0 +1,10 @@
+namespace Shop.Web
+{
+    public class HomeController
+    {
+        public string Index()
+        {
+            return "Home";
+        }
+    }
+}
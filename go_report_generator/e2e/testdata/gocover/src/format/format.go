@@ -0,0 +1,8 @@
+package format
+
+import "strconv"
+
+// Percent formats a ratio as a percentage.
+func Percent(ratio float64) string {
+	return strconv.FormatFloat(ratio*100, 'f', 1, 64) + "%"
+}
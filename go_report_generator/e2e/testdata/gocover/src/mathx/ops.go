@@ -0,0 +1,14 @@
+package mathx
+
+// Add returns the sum of a and b.
+func Add(a, b int) int {
+	return a + b
+}
+
+// Abs returns the absolute value of v.
+func Abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
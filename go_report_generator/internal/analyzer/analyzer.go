@@ -6,19 +6,24 @@ package analyzer
 import (
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"sort"
 	"time"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
 // MergerConfig defines the necessary configuration for the merging process.
-// It provides access to source directories, filters, and a logger.
+// It provides access to source directories, filters, the settings and a logger.
 type MergerConfig interface {
 	SourceDirectories() []string
 	AssemblyFilters() filtering.IFilter
+	Settings() *settings.Settings
 	Logger() *slog.Logger
 }
 
@@ -39,18 +44,42 @@ func MergeParserResults(results []*parsers.ParserResult, config MergerConfig) (*
 
 	sourceDirs := unionSourceDirs(results)
 
-	mergedAssembliesMap := mergeAssemblies(results, logger)
+	mergeMode, err := utils.ParseMergeMode(config.Settings().MergeMode)
+	if err != nil {
+		return nil, err
+	}
+
+	pathCase, err := utils.ParsePathCaseMode(config.Settings().PathCase)
+	if err != nil {
+		return nil, err
+	}
+	keyOf := utils.PathKeyFunc(pathCase, false)
+
+	mergedAssembliesMap := mergeAssemblies(results, mergeMode, config.Settings().Metrics, NewFullMethodCoverage(config.Settings()), keyOf, logger)
 	logger.Info("Assemblies merged", "count", len(mergedAssembliesMap))
 
 	finalAssemblies := make([]model.Assembly, 0, len(mergedAssembliesMap))
 	for _, asm := range mergedAssembliesMap {
+		sortAssemblyContents(asm)
 		finalAssemblies = append(finalAssemblies, *asm)
 	}
 	sort.Slice(finalAssemblies, func(i, j int) bool {
 		return finalAssemblies[i].Name < finalAssemblies[j].Name
 	})
 
-	linesCovered, linesValid, totalLines, branchesCovered, branchesValid, hasBranchData := computeGlobalStats(mergedAssembliesMap)
+	duplicates := duplicateFileMerger{
+		keyOf:           utils.PathKeyFunc(pathCase, config.Settings().ResolveSymlinks),
+		vendorHeuristic: config.Settings().MergeVendoredFiles,
+		mode:            mergeMode,
+		registry:        config.Settings().Metrics,
+		fullCoverage:    NewFullMethodCoverage(config.Settings()),
+		logger:          logger,
+	}
+	if merged := duplicates.merge(finalAssemblies); merged > 0 {
+		logger.Info("Merged source files reached through several paths (symbolic links or vendor directories)", "count", merged)
+	}
+
+	linesCovered, linesValid, totalLines, branchesCovered, branchesValid, hasBranchData := computeGlobalStats(finalAssemblies, keyOf)
 	logger.Debug("Computed global stats", "linesCovered", linesCovered, "linesValid", linesValid, "hasBranchData", hasBranchData)
 
 	finalSummary := &model.SummaryResult{
@@ -60,6 +89,10 @@ func MergeParserResults(results []*parsers.ParserResult, config MergerConfig) (*
 		LinesCovered: linesCovered,
 		LinesValid:   linesValid,
 		TotalLines:   totalLines,
+
+		MethodCoverageAvailable: anyMethodCoverageAvailable(results),
+		MissingSourceFiles:      unionMissingSourceFiles(results),
+		ExternalFiles:           model.CountExternalFiles(finalAssemblies, keyOf),
 	}
 
 	if minTimestamp != nil {
@@ -125,12 +158,59 @@ func unionSourceDirs(results []*parsers.ParserResult) []string {
 	return sourceDirs
 }
 
+// anyMethodCoverageAvailable reports whether any parser result provides methods.
+func anyMethodCoverageAvailable(results []*parsers.ParserResult) bool {
+	for _, res := range results {
+		if res.MethodCoverageAvailable {
+			return true
+		}
+	}
+	return false
+}
+
+// unionMissingSourceFiles collects the unresolved source files of all parser results,
+// de-duplicated by path and referencing class, and sorted for stable output.
+func unionMissingSourceFiles(results []*parsers.ParserResult) []model.MissingSourceFile {
+	seen := make(map[model.MissingSourceFile]struct{})
+	var missing []model.MissingSourceFile
+	for _, res := range results {
+		for _, m := range res.MissingSourceFiles {
+			if _, ok := seen[m]; ok {
+				continue
+			}
+			seen[m] = struct{}{}
+			missing = append(missing, m)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Path != missing[j].Path {
+			return missing[i].Path < missing[j].Path
+		}
+		if missing[i].Assembly != missing[j].Assembly {
+			return missing[i].Assembly < missing[j].Assembly
+		}
+		return missing[i].Class < missing[j].Class
+	})
+	return missing
+}
+
 // combines assemblies from all parser results into a single map using a deep merge strategy.
 // If an assembly is found in multiple results, its statistics are summed.
-// Its classes are also merged by name, summing their individual statistics and creating a union of their file lists.
-func mergeAssemblies(results []*parsers.ParserResult, logger *slog.Logger) map[string]*model.Assembly {
+// Its classes are also merged by name, summing their individual statistics and creating a union of their file lists,
+// whose paths are compared by keyOf.
+// The lines of a file found in several copies of a class are merged with mergeMode and the
+// totals of the file, the class and the assembly are recounted from them; the class
+// methods are the distinct methods of all copies, counted with fullCoverage and
+// aggregated into the class metrics with registry.
+func mergeAssemblies(results []*parsers.ParserResult, mergeMode utils.MergeMode, registry model.MetricRegistry, fullCoverage FullMethodCoverage, keyOf func(string) string, logger *slog.Logger) map[string]*model.Assembly {
 	// Pre-allocate map capacity, guessing an average of 2 assemblies per result.
 	mergedAssembliesMap := make(map[string]*model.Assembly, len(results)*2)
+	// mergedNames records the assemblies found in more than one result, whose
+	// total lines have to be recalculated from the merged file lists.
+	mergedNames := make(map[string]struct{})
+	// mergedFileClasses records the classes per assembly with a file found in more than
+	// one result, whose totals have to be recounted from the merged lines.
+	mergedFileClasses := make(map[string]map[string]struct{})
 
 	for _, res := range results {
 		for _, asmFromParser := range res.Assemblies {
@@ -139,6 +219,7 @@ func mergeAssemblies(results []*parsers.ParserResult, logger *slog.Logger) map[s
 
 			if existingAsm, ok := mergedAssembliesMap[asmCopy.Name]; ok {
 				logger.Debug("Merging existing assembly", "name", asmCopy.Name)
+				mergedNames[asmCopy.Name] = struct{}{}
 
 				// Merge top-level assembly statistics
 				existingAsm.LinesCovered += asmCopy.LinesCovered
@@ -175,19 +256,43 @@ func mergeAssemblies(results []*parsers.ParserResult, logger *slog.Logger) map[s
 						// Class exists: merge its statistics and files
 						existingClass.LinesCovered += classFromParser.LinesCovered
 						existingClass.LinesValid += classFromParser.LinesValid
+						methods := utils.DistinctBy(slices.Concat(existingClass.Methods, classFromParser.Methods), model.Method.RawKey)
+						existingClass.Metrics = mergeClassMetrics(registry, methods, existingClass, &classFromParser)
+						existingClass.Methods = methods
+						existingClass.TotalMethods = len(methods)
+						existingClass.CoveredMethods, existingClass.FullyCoveredMethods = fullCoverage.CountMethodCoverage(methods)
 
 						// Merge the file list to avoid duplicates
-						// Create a set of existing file paths for quick lookups.
-						filePaths := make(map[string]struct{}, len(existingClass.Files))
-						for _, f := range existingClass.Files {
-							filePaths[f.Path] = struct{}{}
+						// Map the existing file paths to their index for quick lookups.
+						// The files may still be shared with the parser result, which must
+						// not be changed.
+						existingClass.Files = slices.Clone(existingClass.Files)
+						filePaths := make(map[string]int, len(existingClass.Files))
+						for i, f := range existingClass.Files {
+							filePaths[keyOf(f.Path)] = i
 						}
 
-						// Append only the files that have not been seen before in this class.
+						// Append the files that have not been seen before in this class and
+						// merge the lines of the others.
 						for _, fileFromParser := range classFromParser.Files {
-							if _, fileExists := filePaths[fileFromParser.Path]; !fileExists {
+							if i, fileExists := filePaths[keyOf(fileFromParser.Path)]; fileExists {
+								file := &existingClass.Files[i]
+								file.InSourceDirs = file.InSourceDirs || fileFromParser.InSourceDirs
+								if len(file.Lines) == 0 || len(fileFromParser.Lines) == 0 {
+									// Without the lines of both reports the totals cannot be recounted.
+									file.Lines = mergeFileLines(file.Lines, fileFromParser.Lines, mergeMode)
+									continue
+								}
+								file.Lines = mergeFileLines(file.Lines, fileFromParser.Lines, mergeMode)
+								file.CoveredLines, file.CoverableLines = model.CountLines(file.Lines)
+								file.TotalLines = max(file.TotalLines, fileFromParser.TotalLines)
+								if mergedFileClasses[asmCopy.Name] == nil {
+									mergedFileClasses[asmCopy.Name] = make(map[string]struct{})
+								}
+								mergedFileClasses[asmCopy.Name][existingClass.Name] = struct{}{}
+							} else {
 								existingClass.Files = append(existingClass.Files, fileFromParser)
-								filePaths[fileFromParser.Path] = struct{}{}
+								filePaths[keyOf(fileFromParser.Path)] = len(existingClass.Files) - 1
 							}
 						}
 					} else {
@@ -197,28 +302,186 @@ func mergeAssemblies(results []*parsers.ParserResult, logger *slog.Logger) map[s
 					}
 				}
 			} else {
-				// Assembly is new, so add a copy of it to the map.
+				// Assembly is new, so add a copy of it to the map. Its classes are
+				// copied too, as merging changes them.
 				logger.Debug("Adding new assembly", "name", asmCopy.Name)
+				asmCopy.Classes = slices.Clone(asmCopy.Classes)
 				mergedAssembliesMap[asmCopy.Name] = &asmCopy
 			}
 		}
 	}
+
+	for name := range mergedNames {
+		asm := mergedAssembliesMap[name]
+		for i := range asm.Classes {
+			asm.Classes[i].TotalLines = uniqueFileTotalLines(asm.Classes[i:i+1], keyOf)
+		}
+		asm.TotalLines = uniqueFileTotalLines(asm.Classes, keyOf)
+	}
+	// The line and branch totals of both results were added up, but the lines of a file
+	// reported twice are counted once.
+	for name, classNames := range mergedFileClasses {
+		asm := mergedAssembliesMap[name]
+		for i := range asm.Classes {
+			if _, ok := classNames[asm.Classes[i].Name]; ok {
+				sumClassTotals(&asm.Classes[i], keyOf)
+			}
+		}
+		sumAssemblyTotals(asm, keyOf)
+	}
 	return mergedAssembliesMap
 }
 
+// mergeFileLines merges the lines of two reports of a file by line number. The hits and
+// the visits of branches with the same identifier are combined with mode; a line is as
+// covered as in the report that covers it best, or better if the reports visited
+// different branches of it. The covered and coverable line counts of the file are left to
+// the caller.
+func mergeFileLines(existing, incoming []model.Line, mode utils.MergeMode) []model.Line {
+	if len(incoming) == 0 {
+		return existing
+	}
+	merged := slices.Clone(existing)
+	indexes := make(map[int]int, len(merged))
+	for i, line := range merged {
+		indexes[line.Number] = i
+	}
+	for _, line := range incoming {
+		if i, ok := indexes[line.Number]; ok {
+			merged[i] = mergeLine(merged[i], line, mode)
+		} else {
+			indexes[line.Number] = len(merged)
+			merged = append(merged, line)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Number < merged[j].Number })
+	return merged
+}
+
+// mergeLine combines two reports of a line, see mergeFileLines. The hits of a test found in
+// both reports are combined with mode as well.
+func mergeLine(a, b model.Line, mode utils.MergeMode) model.Line {
+	if a.LineVisitStatus == model.NotCoverable {
+		return b
+	}
+	if b.LineVisitStatus == model.NotCoverable {
+		return a
+	}
+
+	merged := a
+	merged.Hits = mode.Combine(max(a.Hits, 0), max(b.Hits, 0))
+	merged.LineVisitStatus = max(a.LineVisitStatus, b.LineVisitStatus)
+	merged.IsBranchPoint = a.IsBranchPoint || b.IsBranchPoint
+	merged.CoveredBranches = max(a.CoveredBranches, b.CoveredBranches)
+	merged.TotalBranches = max(a.TotalBranches, b.TotalBranches)
+
+	if len(b.LineCoverageByTestMethod) > 0 {
+		merged.LineCoverageByTestMethod = maps.Clone(a.LineCoverageByTestMethod)
+		if merged.LineCoverageByTestMethod == nil {
+			merged.LineCoverageByTestMethod = make(map[string]int, len(b.LineCoverageByTestMethod))
+		}
+		for test, hits := range b.LineCoverageByTestMethod {
+			if existing, ok := merged.LineCoverageByTestMethod[test]; ok {
+				hits = mode.Combine(existing, hits)
+			}
+			merged.LineCoverageByTestMethod[test] = hits
+		}
+	}
+
+	if len(b.Branch) > 0 {
+		merged.Branch = slices.Clone(a.Branch)
+		indexes := make(map[string]int, len(merged.Branch))
+		for i, branch := range merged.Branch {
+			indexes[branch.Identifier] = i
+		}
+		for _, branch := range b.Branch {
+			if i, ok := indexes[branch.Identifier]; ok {
+				merged.Branch[i].Visits = mode.Combine(merged.Branch[i].Visits, branch.Visits)
+			} else {
+				indexes[branch.Identifier] = len(merged.Branch)
+				merged.Branch = append(merged.Branch, branch)
+			}
+		}
+		// Branches covered by different reports add up.
+		visited := 0
+		for _, branch := range merged.Branch {
+			if branch.Visits > 0 {
+				visited++
+			}
+		}
+		merged.CoveredBranches = min(max(merged.CoveredBranches, visited), merged.TotalBranches)
+		merged.LineVisitStatus = merged.ComputeVisitStatus()
+	}
+	return merged
+}
+
+// mergeClassMetrics aggregates the metrics of a class with the registry over methods, e.g. the distinct
+// methods of the copies of a class found in several reports, so that a method reported
+// twice is not counted twice. Metrics without method values, e.g. a complexity declared
+// for the class, keep the value of the first of classes.
+func mergeClassMetrics(registry model.MetricRegistry, methods []model.Method, classes ...*model.Class) map[string]float64 {
+	merged := registry.AggregateMethodMetrics(methods)
+	for _, class := range classes {
+		for name, value := range class.Metrics {
+			if _, ok := merged[name]; !ok {
+				merged[name] = value
+			}
+		}
+	}
+	return merged
+}
+
+// sortAssemblyContents sorts the classes of the assembly by display name and the files of
+// each class by path, so that reports do not depend on the order of the input reports or
+// on map iteration in the parsers.
+func sortAssemblyContents(asm *model.Assembly) {
+	// The slices may still be shared with the parser results, which must not be reordered.
+	asm.Classes = slices.Clone(asm.Classes)
+	sort.SliceStable(asm.Classes, func(i, j int) bool {
+		if asm.Classes[i].DisplayName != asm.Classes[j].DisplayName {
+			return asm.Classes[i].DisplayName < asm.Classes[j].DisplayName
+		}
+		return asm.Classes[i].Name < asm.Classes[j].Name
+	})
+	for i := range asm.Classes {
+		files := slices.Clone(asm.Classes[i].Files)
+		asm.Classes[i].Files = files
+		sort.SliceStable(files, func(a, b int) bool { return files[a].Path < files[b].Path })
+	}
+}
+
+// uniqueFileTotalLines sums the total lines of the distinct files of the classes, whose
+// paths are compared by keyOf. A file shared by several classes (e.g. partial classes)
+// is counted once, so a class counts each of its files and an assembly counts each of
+// its files once.
+func uniqueFileTotalLines(classes []model.Class, keyOf func(string) string) int {
+	totalLines := 0
+	seenFiles := make(map[string]struct{})
+	for _, cls := range classes {
+		for _, f := range cls.Files {
+			if _, seen := seenFiles[keyOf(f.Path)]; !seen {
+				seenFiles[keyOf(f.Path)] = struct{}{}
+				totalLines += f.TotalLines
+			}
+		}
+	}
+	return totalLines
+}
+
 // computeGlobalStats iterates through the merged assemblies and calculates the final summary statistics in a single pass.
-func computeGlobalStats(mergedAssemblies map[string]*model.Assembly) (linesCovered, linesValid, totalLines, branchesCovered, branchesValid int, hasBranchData bool) {
+// The total lines are counted once per file, whose paths are compared by keyOf.
+func computeGlobalStats(assemblies []model.Assembly, keyOf func(string) string) (linesCovered, linesValid, totalLines, branchesCovered, branchesValid int, hasBranchData bool) {
 	uniqueFilesForGrandTotal := make(map[string]int)
 
-	for _, asm := range mergedAssemblies {
+	for _, asm := range assemblies {
 		linesCovered += asm.LinesCovered
 		linesValid += asm.LinesValid
 
 		// Calculate total lines from unique files across all assemblies.
 		for _, cls := range asm.Classes {
 			for _, f := range cls.Files {
-				if _, exists := uniqueFilesForGrandTotal[f.Path]; !exists && f.TotalLines > 0 {
-					uniqueFilesForGrandTotal[f.Path] = f.TotalLines
+				if _, exists := uniqueFilesForGrandTotal[keyOf(f.Path)]; !exists && f.TotalLines > 0 {
+					uniqueFilesForGrandTotal[keyOf(f.Path)] = f.TotalLines
 					totalLines += f.TotalLines
 				}
 			}
@@ -11,6 +11,8 @@ import (
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,12 +21,19 @@ import (
 type mockMergerConfig struct {
 	sourceDirs      []string
 	assemblyFilters filtering.IFilter
+	settings        *settings.Settings
 	logger          *slog.Logger
 }
 
 func (m *mockMergerConfig) SourceDirectories() []string        { return m.sourceDirs }
 func (m *mockMergerConfig) AssemblyFilters() filtering.IFilter { return m.assemblyFilters }
 func (m *mockMergerConfig) Logger() *slog.Logger               { return m.logger }
+func (m *mockMergerConfig) Settings() *settings.Settings {
+	if m.settings == nil {
+		return settings.NewSettings()
+	}
+	return m.settings
+}
 
 // =============================================================================
 // ERROR HANDLING TESTS
@@ -134,6 +143,66 @@ func TestMergeParserResults_WhenSingleResultWithoutBranches_ShouldNotIncludeBran
 	assert.Nil(t, summary.BranchesValid, "Expected no branch validity data when source has none")
 }
 
+func TestMergeParserResults_SameClassInSeveralReports_MergesMetricsWithoutDoubleCounting(t *testing.T) {
+	method := func(name string, lineCoverage, complexity, crapScore float64) model.Method {
+		return model.Method{Name: name, Signature: "()", FirstLine: 1, LastLine: 1, MethodMetrics: []model.MethodMetric{{
+			Name: name, Line: 1, Metrics: []model.Metric{
+				{Name: "Line coverage", Value: lineCoverage},
+				{Name: "Cyclomatic complexity", Value: complexity},
+				{Name: "CrapScore", Value: crapScore},
+			},
+		}}}
+	}
+	result := func(methods ...model.Method) *parsers.ParserResult {
+		class := model.Class{Name: "Shop.Cart", Methods: methods, Metrics: model.DefaultMetricRegistry().AggregateMethodMetrics(methods)}
+		return &parsers.ParserResult{ParserName: "Test", Assemblies: []model.Assembly{{Name: "Shop", Classes: []model.Class{class}}}}
+	}
+	add, remove := method("Add", 100, 2, 2), method("Remove", 0, 3, 12)
+	config := &mockMergerConfig{logger: slog.Default()}
+
+	summary, err := analyzer.MergeParserResults([]*parsers.ParserResult{result(add), result(add, remove)}, config)
+
+	require.NoError(t, err)
+	require.Len(t, summary.Assemblies, 1)
+	require.Len(t, summary.Assemblies[0].Classes, 1)
+	assert.Equal(t, map[string]float64{"Line coverage": 50, "Cyclomatic complexity": 5, "CrapScore": 12}, summary.Assemblies[0].Classes[0].Metrics)
+}
+
+func TestMergeParserResults_PartialClassInSeveralReports_KeepsAndCountsTheMethodsOfAllReports(t *testing.T) {
+	add := model.Method{Name: "Add", Signature: "()", FirstLine: 1, LastLine: 3, LineRate: 1}
+	remove := model.Method{Name: "Remove", Signature: "()", FirstLine: 5, LastLine: 9, LineRate: 0.5}
+	result := func(methods ...model.Method) *parsers.ParserResult {
+		class := model.Class{Name: "Shop.Cart", Methods: methods, TotalMethods: len(methods)}
+		return &parsers.ParserResult{ParserName: "Test", Assemblies: []model.Assembly{{Name: "Shop", Classes: []model.Class{class}}}}
+	}
+	config := &mockMergerConfig{logger: slog.Default()}
+
+	summary, err := analyzer.MergeParserResults([]*parsers.ParserResult{result(add), result(add, remove)}, config)
+
+	require.NoError(t, err)
+	require.Len(t, summary.Assemblies, 1)
+	require.Len(t, summary.Assemblies[0].Classes, 1)
+	class := summary.Assemblies[0].Classes[0]
+	require.Len(t, class.Methods, 2)
+	assert.Equal(t, "Add", class.Methods[0].Name)
+	assert.Equal(t, "Remove", class.Methods[1].Name)
+	assert.Equal(t, 2, class.TotalMethods)
+	assert.Equal(t, 2, class.CoveredMethods)
+	assert.Equal(t, 1, class.FullyCoveredMethods)
+}
+
+func TestMergeParserResults_MethodCoverageAvailable_IfAnyResultProvidesMethods(t *testing.T) {
+	config := &mockMergerConfig{logger: slog.Default()}
+
+	summary, err := analyzer.MergeParserResults([]*parsers.ParserResult{{ParserName: "Cobertura"}, {ParserName: "Cobertura"}}, config)
+	require.NoError(t, err)
+	assert.False(t, summary.MethodCoverageAvailable)
+
+	summary, err = analyzer.MergeParserResults([]*parsers.ParserResult{{ParserName: "Cobertura"}, {ParserName: "GoCover", MethodCoverageAvailable: true}}, config)
+	require.NoError(t, err)
+	assert.True(t, summary.MethodCoverageAvailable)
+}
+
 // =============================================================================
 // MULTIPLE RESULTS TESTS
 // =============================================================================
@@ -523,6 +592,46 @@ func TestMergeParserResults_WhenCalculatingTotalLines_ShouldCountUniqueFilesOnly
 	assert.Equal(t, 450, summary.TotalLines)
 }
 
+func TestMergeParserResults_WhenPathsDifferInCase_ShouldCountFilesOnceIfCaseInsensitive(t *testing.T) {
+	// Arrange: the same class and file as written by two Windows test runs.
+	newResults := func() []*parsers.ParserResult {
+		result := func(path string) *parsers.ParserResult {
+			return &parsers.ParserResult{
+				ParserName: "Cobertura",
+				Assemblies: []model.Assembly{{
+					Name:    "Shop",
+					Classes: []model.Class{{Name: "Shop.Cart", Files: []model.CodeFile{{Path: path, TotalLines: 40}}}},
+				}},
+			}
+		}
+		return []*parsers.ParserResult{result(`c:\Work\src\Cart.cs`), result(`C:\work\src\cart.cs`)}
+	}
+	tests := []struct {
+		mode          utils.PathCaseMode
+		expectedFiles int
+		expectedLines int
+	}{
+		{utils.PathCaseInsensitive, 1, 40},
+		{utils.PathCaseSensitive, 2, 80},
+	}
+	for _, tt := range tests {
+		appSettings := settings.NewSettings()
+		appSettings.PathCase = tt.mode.String()
+		config := &mockMergerConfig{settings: appSettings, logger: slog.Default()}
+
+		// Act
+		summary, err := analyzer.MergeParserResults(newResults(), config)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, summary.Assemblies, 1)
+		require.Len(t, summary.Assemblies[0].Classes, 1)
+		assert.Len(t, summary.Assemblies[0].Classes[0].Files, tt.expectedFiles)
+		assert.Equal(t, tt.expectedLines, summary.Assemblies[0].TotalLines)
+		assert.Equal(t, tt.expectedLines, summary.TotalLines)
+	}
+}
+
 func TestMergeParserResults_WhenFilesHaveZeroLines_ShouldIgnoreZeroLineFiles(t *testing.T) {
 	// Arrange
 	results := []*parsers.ParserResult{
@@ -1086,3 +1195,231 @@ func TestMergeParserResults_WhenClassHasNoFiles_ShouldMergeCorrectly(t *testing.
 	assert.NotNil(t, mergedClass.Files, "Files slice should not be nil even if empty")
 	assert.Empty(t, mergedClass.Files, "Files slice should be empty after merging")
 }
+
+// =============================================================================
+// MISSING SOURCE FILES TESTS
+// =============================================================================
+
+func TestMergeParserResults_MissingSourceFiles_AreDeduplicatedAndSorted(t *testing.T) {
+	// Arrange
+	missingB := model.MissingSourceFile{Path: "src/b.cs", Assembly: "AssemblyA", Class: "ClassB"}
+	missingA := model.MissingSourceFile{Path: "src/a.cs", Assembly: "AssemblyA", Class: "ClassA"}
+	result1 := &parsers.ParserResult{
+		Assemblies:         []model.Assembly{{Name: "AssemblyA"}},
+		MissingSourceFiles: []model.MissingSourceFile{missingB, missingA},
+	}
+	result2 := &parsers.ParserResult{
+		Assemblies:         []model.Assembly{{Name: "AssemblyA"}},
+		MissingSourceFiles: []model.MissingSourceFile{missingA},
+	}
+	config := &mockMergerConfig{logger: slog.Default()}
+
+	// Act
+	summary, err := analyzer.MergeParserResults([]*parsers.ParserResult{result1, result2}, config)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []model.MissingSourceFile{missingA, missingB}, summary.MissingSourceFiles)
+}
+
+func TestMergeParserResults_ExternalFiles_AreCountedOnce(t *testing.T) {
+	// Arrange
+	internalFile := model.CodeFile{Path: "/src/app/a.go", InSourceDirs: true}
+	externalFile := model.CodeFile{Path: "/usr/lib/go/src/fmt/print.go"}
+	sharedFile := model.CodeFile{Path: "/src/app/shared.go"}
+	result1 := &parsers.ParserResult{
+		Assemblies: []model.Assembly{{Name: "AssemblyA", Classes: []model.Class{
+			{Name: "ClassA", Files: []model.CodeFile{internalFile, externalFile}},
+			{Name: "ClassB", Files: []model.CodeFile{externalFile, sharedFile}},
+		}}},
+	}
+	sharedFile.InSourceDirs = true
+	result2 := &parsers.ParserResult{
+		Assemblies: []model.Assembly{{Name: "AssemblyA", Classes: []model.Class{
+			{Name: "ClassB", Files: []model.CodeFile{sharedFile}},
+		}}},
+	}
+	config := &mockMergerConfig{logger: slog.Default()}
+
+	// Act
+	summary, err := analyzer.MergeParserResults([]*parsers.ParserResult{result1, result2}, config)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.ExternalFiles, "a file inside the source directories of any report is not external")
+	classB := summary.Assemblies[0].Classes[1]
+	require.Equal(t, "ClassB", classB.Name)
+	for _, f := range classB.Files {
+		assert.Equal(t, f.Path == sharedFile.Path, f.InSourceDirs, f.Path)
+	}
+}
+
+func TestMergeParserResults_WhenFileSharedByClasses_ShouldCountFileOncePerClassAndAssembly(t *testing.T) {
+	// Arrange
+	shared := model.CodeFile{Path: "/app/Shared.cs", TotalLines: 100}
+	results := []*parsers.ParserResult{
+		{
+			ParserName: "Test",
+			Assemblies: []model.Assembly{{
+				Name:       "App",
+				TotalLines: 100,
+				Classes: []model.Class{
+					{Name: "Foo", TotalLines: 100, Files: []model.CodeFile{shared}},
+				},
+			}},
+		},
+		{
+			ParserName: "Test",
+			Assemblies: []model.Assembly{{
+				Name:       "App",
+				TotalLines: 140,
+				Classes: []model.Class{
+					{Name: "Foo", TotalLines: 40, Files: []model.CodeFile{{Path: "/app/Foo.Part.cs", TotalLines: 40}}},
+					{Name: "Bar", TotalLines: 100, Files: []model.CodeFile{shared}},
+				},
+			}},
+		},
+	}
+	config := &mockMergerConfig{logger: slog.Default()}
+
+	// Act
+	summary, err := analyzer.MergeParserResults(results, config)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, summary.Assemblies, 1)
+	asm := summary.Assemblies[0]
+	classTotals := make(map[string]int)
+	for _, cls := range asm.Classes {
+		classTotals[cls.Name] = cls.TotalLines
+	}
+	assert.Equal(t, map[string]int{"Foo": 140, "Bar": 100}, classTotals, "each class counts all of its files")
+	assert.Equal(t, 140, asm.TotalLines, "the assembly counts the shared file once")
+	assert.Equal(t, 140, summary.TotalLines)
+}
+
+// overlappingResults returns two reports of the same class and file: line 1 is covered by
+// both, line 2 only by the second, and line 3 has branches with the same identifiers.
+func overlappingResults() []*parsers.ParserResult {
+	report := func(hits1, hits2, visits int) *parsers.ParserResult {
+		lines := []model.Line{
+			{Number: 1, Hits: hits1, LineVisitStatus: model.Covered},
+			{Number: 2, Hits: hits2, LineVisitStatus: model.NotCovered},
+			{Number: 3, Hits: 1, LineVisitStatus: model.PartiallyCovered, IsBranchPoint: true, CoveredBranches: 1, TotalBranches: 2,
+				Branch: []model.BranchCoverageDetail{{Identifier: "0", Visits: visits}, {Identifier: "1", Visits: 0}}},
+		}
+		if hits2 > 0 {
+			lines[1].LineVisitStatus = model.Covered
+		}
+		return &parsers.ParserResult{
+			ParserName: "Test",
+			Assemblies: []model.Assembly{{
+				Name:         "App",
+				LinesCovered: 2,
+				LinesValid:   3,
+				Classes: []model.Class{{
+					Name:         "Cart",
+					LinesCovered: 2,
+					LinesValid:   3,
+					Files:        []model.CodeFile{{Path: "/app/Cart.cs", Lines: lines, CoveredLines: 2, CoverableLines: 3}},
+				}},
+			}},
+		}
+	}
+	return []*parsers.ParserResult{report(4, 0, 3), report(6, 2, 5)}
+}
+
+func TestMergeParserResults_MergeModeCombinesHitsOfOverlappingReports(t *testing.T) {
+	testCases := []struct {
+		mode   string
+		hits   []int
+		visits []int
+	}{
+		{mode: "sum", hits: []int{10, 2, 2}, visits: []int{8, 0}},
+		{mode: "max", hits: []int{6, 2, 1}, visits: []int{5, 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			// Arrange
+			appSettings := settings.NewSettings()
+			appSettings.MergeMode = tc.mode
+			config := &mockMergerConfig{logger: slog.Default(), settings: appSettings}
+
+			// Act
+			summary, err := analyzer.MergeParserResults(overlappingResults(), config)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, summary.Assemblies, 1)
+			require.Len(t, summary.Assemblies[0].Classes, 1)
+			files := summary.Assemblies[0].Classes[0].Files
+			require.Len(t, files, 1, "the file is merged, not listed twice")
+			require.Len(t, files[0].Lines, 3)
+
+			var hits []int
+			for _, line := range files[0].Lines {
+				hits = append(hits, line.Hits)
+			}
+			assert.Equal(t, tc.hits, hits)
+			assert.Equal(t, model.Covered, files[0].Lines[1].LineVisitStatus, "a line covered by one report is covered")
+			branches := files[0].Lines[2].Branch
+			require.Len(t, branches, 2)
+			assert.Equal(t, tc.visits, []int{branches[0].Visits, branches[1].Visits})
+
+			assert.Equal(t, 3, files[0].CoveredLines, "the counts of the file are recounted from the merged lines")
+			assert.Equal(t, 3, files[0].CoverableLines)
+			class := summary.Assemblies[0].Classes[0]
+			assert.Equal(t, 3, class.LinesCovered, "the totals do not depend on the merge mode")
+			assert.Equal(t, 3, class.LinesValid)
+			require.NotNil(t, class.BranchesValid)
+			assert.Equal(t, 1, *class.BranchesCovered)
+			assert.Equal(t, 2, *class.BranchesValid)
+			assert.Equal(t, 3, summary.Assemblies[0].LinesValid)
+			assert.Equal(t, 3, summary.LinesCovered)
+			assert.Equal(t, 3, summary.LinesValid)
+		})
+	}
+}
+
+func TestMergeParserResults_MergeModeDoesNotChangeTheParserResults(t *testing.T) {
+	// Arrange
+	results := overlappingResults()
+	config := &mockMergerConfig{logger: slog.Default()}
+
+	// Act
+	_, err := analyzer.MergeParserResults(results, config)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 4, results[0].Assemblies[0].Classes[0].Files[0].Lines[0].Hits)
+	assert.Equal(t, 3, results[0].Assemblies[0].Classes[0].Files[0].Lines[2].Branch[0].Visits)
+}
+
+func TestMergeParserResults_MergesTheCoverageOfTheLinesByTestMethod(t *testing.T) {
+	// Arrange
+	results := overlappingResults()
+	results[0].Assemblies[0].Classes[0].Files[0].Lines[0].LineCoverageByTestMethod = map[string]int{"AddTest": 3, "RemoveTest": 1}
+	results[1].Assemblies[0].Classes[0].Files[0].Lines[0].LineCoverageByTestMethod = map[string]int{"AddTest": 2, "ClearTest": 4}
+	config := &mockMergerConfig{logger: slog.Default()}
+
+	// Act
+	summary, err := analyzer.MergeParserResults(results, config)
+
+	// Assert
+	require.NoError(t, err)
+	line := summary.Assemblies[0].Classes[0].Files[0].Lines[0]
+	assert.Equal(t, map[string]int{"AddTest": 5, "RemoveTest": 1, "ClearTest": 4}, line.LineCoverageByTestMethod)
+	assert.Equal(t, map[string]int{"AddTest": 3, "RemoveTest": 1}, results[0].Assemblies[0].Classes[0].Files[0].Lines[0].LineCoverageByTestMethod,
+		"the parser results are not changed")
+}
+
+func TestMergeParserResults_InvalidMergeMode(t *testing.T) {
+	appSettings := settings.NewSettings()
+	appSettings.MergeMode = "average"
+	config := &mockMergerConfig{logger: slog.Default(), settings: appSettings}
+
+	_, err := analyzer.MergeParserResults(overlappingResults(), config)
+
+	assert.ErrorContains(t, err, "invalid merge mode")
+}
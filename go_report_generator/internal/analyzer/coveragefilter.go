@@ -0,0 +1,74 @@
+package analyzer
+
+import (
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// CoverageRange decides which line coverage quotas (0 to 100) a coverage filter keeps.
+type CoverageRange interface {
+	Contains(coverage float64) bool
+}
+
+// ApplyClassCoverageFilter removes the classes whose line coverage lies outside the
+// range, and the assemblies left without classes. Classes without coverable lines count
+// as fully covered.
+//
+// With recomputeAggregates the assembly and overall statistics are recalculated over
+// the remaining classes. Otherwise they keep describing all classes, so the summary
+// still shows the coverage of the whole code base. The number of removed classes is
+// added to summary.HiddenClasses and returned. keyOf returns the key by which file paths
+// are compared, see utils.PathKeyFunc.
+func ApplyClassCoverageFilter(summary *model.SummaryResult, coverageRange CoverageRange, recomputeAggregates bool, keyOf func(string) string) int {
+	if summary == nil || coverageRange == nil {
+		return 0
+	}
+
+	hidden := 0
+	remaining := make([]model.Assembly, 0, len(summary.Assemblies))
+	for _, assembly := range summary.Assemblies {
+		classes := make([]model.Class, 0, len(assembly.Classes))
+		for _, class := range assembly.Classes {
+			if coverageRange.Contains(classLineCoverage(class)) {
+				classes = append(classes, class)
+			} else {
+				hidden++
+			}
+		}
+		if len(classes) == 0 {
+			continue
+		}
+		filtered := len(classes) != len(assembly.Classes)
+		assembly.Classes = classes
+		if recomputeAggregates && filtered {
+			recalculateAssemblyStats(&assembly, keyOf)
+		}
+		remaining = append(remaining, assembly)
+	}
+
+	summary.Assemblies = remaining
+	summary.HiddenClasses += hidden
+	if hidden == 0 || !recomputeAggregates {
+		return hidden
+	}
+
+	linesCovered, linesValid, totalLines, branchesCovered, branchesValid, hasBranchData := computeGlobalStats(remaining, keyOf)
+	summary.LinesCovered = linesCovered
+	summary.LinesValid = linesValid
+	summary.TotalLines = totalLines
+	summary.BranchesCovered = nil
+	summary.BranchesValid = nil
+	if hasBranchData {
+		summary.BranchesCovered = &branchesCovered
+		summary.BranchesValid = &branchesValid
+	}
+	return hidden
+}
+
+// classLineCoverage returns the line coverage quota of a class in percent. A class
+// without coverable lines has nothing left to cover and counts as fully covered.
+func classLineCoverage(class model.Class) float64 {
+	if class.LinesValid == 0 {
+		return 100
+	}
+	return 100 * float64(class.LinesCovered) / float64(class.LinesValid)
+}
@@ -0,0 +1,138 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCoverageFilterSummary() *model.SummaryResult {
+	return &model.SummaryResult{
+		Assemblies: []model.Assembly{
+			{
+				Name: "Core", LinesCovered: 14, LinesValid: 20, TotalLines: 100,
+				BranchesCovered: intPtr(3), BranchesValid: intPtr(6),
+				Classes: []model.Class{
+					{Name: "Core.Full", DisplayName: "Core.Full", LinesCovered: 10, LinesValid: 10,
+						BranchesCovered: intPtr(2), BranchesValid: intPtr(2),
+						Files: []model.CodeFile{{Path: "Full.cs", TotalLines: 60}}},
+					{Name: "Core.Half", DisplayName: "Core.Half", LinesCovered: 4, LinesValid: 8,
+						BranchesCovered: intPtr(1), BranchesValid: intPtr(4),
+						Files: []model.CodeFile{{Path: "Half.cs", TotalLines: 30}}},
+					{Name: "Core.None", DisplayName: "Core.None", LinesCovered: 0, LinesValid: 2,
+						Files: []model.CodeFile{{Path: "None.cs", TotalLines: 10}}},
+				},
+			},
+			{
+				Name: "Contracts", TotalLines: 5,
+				Classes: []model.Class{
+					{Name: "Contracts.IService", DisplayName: "Contracts.IService",
+						Files: []model.CodeFile{{Path: "IService.cs", TotalLines: 5}}},
+				},
+			},
+		},
+		LinesCovered: 14, LinesValid: 20, TotalLines: 105,
+		BranchesCovered: intPtr(3), BranchesValid: intPtr(6),
+	}
+}
+
+func classNames(summary *model.SummaryResult) []string {
+	var names []string
+	for _, asm := range summary.Assemblies {
+		for _, cls := range asm.Classes {
+			names = append(names, cls.DisplayName)
+		}
+	}
+	return names
+}
+
+func mustParseCoverageRange(t *testing.T, value string) *reportconfig.CoverageRange {
+	t.Helper()
+	coverageRange, err := reportconfig.ParseCoverageRange(value)
+	require.NoError(t, err)
+	return coverageRange
+}
+
+func TestApplyClassCoverageFilter_KeepsOriginalAggregates(t *testing.T) {
+	// Arrange
+	summary := newCoverageFilterSummary()
+
+	// Act
+	hidden := analyzer.ApplyClassCoverageFilter(summary, mustParseCoverageRange(t, "<100"), false, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, 2, hidden)
+	assert.Equal(t, 2, summary.HiddenClasses)
+	assert.Equal(t, []string{"Core.Half", "Core.None"}, classNames(summary))
+
+	require.Len(t, summary.Assemblies, 1, "an assembly without remaining classes is removed")
+	core := summary.Assemblies[0]
+	assert.Equal(t, 14, core.LinesCovered)
+	assert.Equal(t, 20, core.LinesValid)
+	assert.Equal(t, 100, core.TotalLines)
+	assert.Equal(t, 3, *core.BranchesCovered)
+
+	assert.Equal(t, 14, summary.LinesCovered)
+	assert.Equal(t, 20, summary.LinesValid)
+	assert.Equal(t, 105, summary.TotalLines)
+	assert.Equal(t, 6, *summary.BranchesValid)
+}
+
+func TestApplyClassCoverageFilter_RecomputesAggregates(t *testing.T) {
+	// Arrange
+	summary := newCoverageFilterSummary()
+
+	// Act
+	hidden := analyzer.ApplyClassCoverageFilter(summary, mustParseCoverageRange(t, "<100"), true, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, 2, hidden)
+	require.Len(t, summary.Assemblies, 1)
+	core := summary.Assemblies[0]
+	assert.Equal(t, 4, core.LinesCovered)
+	assert.Equal(t, 10, core.LinesValid)
+	assert.Equal(t, 40, core.TotalLines)
+	require.NotNil(t, core.BranchesCovered)
+	assert.Equal(t, 1, *core.BranchesCovered)
+	assert.Equal(t, 4, *core.BranchesValid)
+
+	assert.Equal(t, 4, summary.LinesCovered)
+	assert.Equal(t, 10, summary.LinesValid)
+	assert.Equal(t, 40, summary.TotalLines)
+	require.NotNil(t, summary.BranchesCovered)
+	assert.Equal(t, 1, *summary.BranchesCovered)
+	assert.Equal(t, 4, *summary.BranchesValid)
+}
+
+func TestApplyClassCoverageFilter_ClassesWithoutCoverableLinesCountAsFullyCovered(t *testing.T) {
+	// Arrange
+	summary := newCoverageFilterSummary()
+
+	// Act
+	hidden := analyzer.ApplyClassCoverageFilter(summary, mustParseCoverageRange(t, ">=100"), true, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, 2, hidden)
+	assert.Equal(t, []string{"Core.Full", "Contracts.IService"}, classNames(summary))
+	assert.Equal(t, 10, summary.LinesCovered)
+	assert.Equal(t, 10, summary.LinesValid)
+	assert.Equal(t, 65, summary.TotalLines)
+}
+
+func TestApplyClassCoverageFilter_NothingHidden_LeavesSummaryUnchanged(t *testing.T) {
+	// Arrange
+	summary := newCoverageFilterSummary()
+	expected := newCoverageFilterSummary()
+
+	// Act
+	hidden := analyzer.ApplyClassCoverageFilter(summary, mustParseCoverageRange(t, ">=0"), true, utils.PathKey)
+
+	// Assert
+	assert.Zero(t, hidden)
+	assert.Equal(t, expected, summary)
+}
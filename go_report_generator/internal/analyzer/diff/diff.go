@@ -0,0 +1,245 @@
+// Package diff compares two analyzed coverage runs, e.g. the coverage of a pull
+// request against the coverage of its target branch.
+package diff
+
+import (
+	"math"
+	"sort"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// Presence tells on which side of the comparison an assembly or class exists.
+type Presence int
+
+const (
+	// InBoth means the element exists in the baseline and in the current run.
+	InBoth Presence = iota
+	// OnlyInBaseline means the element was removed since the baseline.
+	OnlyInBaseline
+	// OnlyInCurrent means the element was added since the baseline.
+	OnlyInCurrent
+)
+
+// String returns a short label for the presence, as used in the delta reports.
+func (p Presence) String() string {
+	switch p {
+	case OnlyInBaseline:
+		return "removed"
+	case OnlyInCurrent:
+		return "new"
+	default:
+		return ""
+	}
+}
+
+// Coverage holds the line counts of one side of the comparison.
+type Coverage struct {
+	LinesCovered int
+	LinesValid   int
+}
+
+// LineCoverage returns the line coverage in percent, or NaN if there are no coverable lines.
+func (c Coverage) LineCoverage() float64 {
+	return utils.CalculatePercentage(c.LinesCovered, c.LinesValid, 1)
+}
+
+// ClassDelta is the change of a single class between the two runs.
+type ClassDelta struct {
+	Name        string
+	DisplayName string
+	Presence    Presence
+	Baseline    Coverage
+	Current     Coverage
+
+	// NewlyUncoveredLines counts the lines that are coverable but not covered in the
+	// current run and were either covered or not coverable in the baseline.
+	NewlyUncoveredLines int
+}
+
+// LineCoverageChange returns the change of the line coverage in percentage points.
+// It is NaN if one of the sides has no coverable lines (e.g. for new classes).
+func (d ClassDelta) LineCoverageChange() float64 {
+	return coverageChange(d.Baseline, d.Current)
+}
+
+// AssemblyDelta is the change of an assembly and its classes between the two runs.
+type AssemblyDelta struct {
+	Name                string
+	Presence            Presence
+	Baseline            Coverage
+	Current             Coverage
+	NewlyUncoveredLines int
+	Classes             []ClassDelta
+}
+
+// LineCoverageChange returns the change of the line coverage in percentage points.
+func (d AssemblyDelta) LineCoverageChange() float64 {
+	return coverageChange(d.Baseline, d.Current)
+}
+
+// Result is the comparison of two coverage runs.
+type Result struct {
+	Baseline            Coverage
+	Current             Coverage
+	NewlyUncoveredLines int
+	Assemblies          []AssemblyDelta
+}
+
+// LineCoverageChange returns the change of the overall line coverage in percentage points.
+func (r *Result) LineCoverageChange() float64 {
+	return coverageChange(r.Baseline, r.Current)
+}
+
+// Compare computes the per-assembly and per-class delta between a baseline and the
+// current run. Assemblies are matched by name and classes by assembly and class
+// name; a class that moved to another assembly shows up as removed and new.
+// Assemblies and classes are sorted by name.
+func Compare(baseline, current *model.SummaryResult) *Result {
+	if baseline == nil {
+		baseline = &model.SummaryResult{}
+	}
+	if current == nil {
+		current = &model.SummaryResult{}
+	}
+
+	result := &Result{
+		Baseline: Coverage{LinesCovered: baseline.LinesCovered, LinesValid: baseline.LinesValid},
+		Current:  Coverage{LinesCovered: current.LinesCovered, LinesValid: current.LinesValid},
+	}
+
+	baselineAssemblies := indexAssemblies(baseline.Assemblies)
+	currentAssemblies := indexAssemblies(current.Assemblies)
+
+	for _, name := range unionKeys(baselineAssemblies, currentAssemblies) {
+		delta := compareAssembly(name, baselineAssemblies[name], currentAssemblies[name])
+		result.NewlyUncoveredLines += delta.NewlyUncoveredLines
+		result.Assemblies = append(result.Assemblies, delta)
+	}
+	return result
+}
+
+func compareAssembly(name string, baseline, current *model.Assembly) AssemblyDelta {
+	delta := AssemblyDelta{Name: name, Presence: presenceOf(baseline != nil, current != nil)}
+
+	var baselineClasses, currentClasses map[string]*model.Class
+	if baseline != nil {
+		delta.Baseline = Coverage{LinesCovered: baseline.LinesCovered, LinesValid: baseline.LinesValid}
+		baselineClasses = indexClasses(baseline.Classes)
+	}
+	if current != nil {
+		delta.Current = Coverage{LinesCovered: current.LinesCovered, LinesValid: current.LinesValid}
+		currentClasses = indexClasses(current.Classes)
+	}
+
+	for _, className := range unionKeys(baselineClasses, currentClasses) {
+		classDelta := compareClass(className, baselineClasses[className], currentClasses[className])
+		delta.NewlyUncoveredLines += classDelta.NewlyUncoveredLines
+		delta.Classes = append(delta.Classes, classDelta)
+	}
+	return delta
+}
+
+func compareClass(name string, baseline, current *model.Class) ClassDelta {
+	delta := ClassDelta{Name: name, DisplayName: name, Presence: presenceOf(baseline != nil, current != nil)}
+	if baseline != nil {
+		delta.Baseline = Coverage{LinesCovered: baseline.LinesCovered, LinesValid: baseline.LinesValid}
+		if baseline.DisplayName != "" {
+			delta.DisplayName = baseline.DisplayName
+		}
+	}
+	if current != nil {
+		delta.Current = Coverage{LinesCovered: current.LinesCovered, LinesValid: current.LinesValid}
+		if current.DisplayName != "" {
+			delta.DisplayName = current.DisplayName
+		}
+		delta.NewlyUncoveredLines = countNewlyUncoveredLines(baseline, current)
+	}
+	return delta
+}
+
+type lineKey struct {
+	path   string
+	number int
+}
+
+// countNewlyUncoveredLines counts the uncovered lines of the current class that were
+// not already uncovered in the baseline. Without a baseline every uncovered line is new.
+func countNewlyUncoveredLines(baseline, current *model.Class) int {
+	uncoveredBefore := make(map[lineKey]struct{})
+	if baseline != nil {
+		for _, file := range baseline.Files {
+			for _, line := range file.Lines {
+				if line.LineVisitStatus == model.NotCovered {
+					uncoveredBefore[lineKey{path: file.Path, number: line.Number}] = struct{}{}
+				}
+			}
+		}
+	}
+
+	count := 0
+	for _, file := range current.Files {
+		for _, line := range file.Lines {
+			if line.LineVisitStatus != model.NotCovered {
+				continue
+			}
+			if _, ok := uncoveredBefore[lineKey{path: file.Path, number: line.Number}]; !ok {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func coverageChange(baseline, current Coverage) float64 {
+	before, after := baseline.LineCoverage(), current.LineCoverage()
+	if math.IsNaN(before) || math.IsNaN(after) {
+		return math.NaN()
+	}
+	return math.Round((after-before)*10) / 10
+}
+
+func presenceOf(inBaseline, inCurrent bool) Presence {
+	switch {
+	case inBaseline && !inCurrent:
+		return OnlyInBaseline
+	case !inBaseline && inCurrent:
+		return OnlyInCurrent
+	default:
+		return InBoth
+	}
+}
+
+func indexAssemblies(assemblies []model.Assembly) map[string]*model.Assembly {
+	index := make(map[string]*model.Assembly, len(assemblies))
+	for i := range assemblies {
+		index[assemblies[i].Name] = &assemblies[i]
+	}
+	return index
+}
+
+func indexClasses(classes []model.Class) map[string]*model.Class {
+	index := make(map[string]*model.Class, len(classes))
+	for i := range classes {
+		index[classes[i].Name] = &classes[i]
+	}
+	return index
+}
+
+// unionKeys returns the sorted keys present in either map.
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		seen[k] = struct{}{}
+	}
+	for k := range b {
+		seen[k] = struct{}{}
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,118 @@
+package diff_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer/diff"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func line(number int, status model.LineVisitStatus) model.Line {
+	hits := 0
+	if status == model.Covered {
+		hits = 1
+	}
+	return model.Line{Number: number, Hits: hits, LineVisitStatus: status}
+}
+
+func class(name string, covered, valid int, lines ...model.Line) model.Class {
+	return model.Class{
+		Name:         name,
+		DisplayName:  name,
+		LinesCovered: covered,
+		LinesValid:   valid,
+		Files:        []model.CodeFile{{Path: name + ".cs", Lines: lines}},
+	}
+}
+
+func TestCompare_ClassChanges(t *testing.T) {
+	// Arrange
+	baseline := &model.SummaryResult{
+		LinesCovered: 3, LinesValid: 5,
+		Assemblies: []model.Assembly{{
+			Name: "App", LinesCovered: 3, LinesValid: 5,
+			Classes: []model.Class{
+				class("Calc", 2, 3, line(1, model.Covered), line(2, model.Covered), line(3, model.NotCovered)),
+				class("Legacy", 1, 2, line(1, model.Covered), line(2, model.NotCovered)),
+			},
+		}},
+	}
+	current := &model.SummaryResult{
+		LinesCovered: 2, LinesValid: 6,
+		Assemblies: []model.Assembly{{
+			Name: "App", LinesCovered: 2, LinesValid: 6,
+			Classes: []model.Class{
+				// Line 2 lost its coverage, line 3 stays uncovered, line 4 is new and uncovered.
+				class("Calc", 1, 4, line(1, model.Covered), line(2, model.NotCovered), line(3, model.NotCovered), line(4, model.NotCovered)),
+				class("Parser", 1, 2, line(1, model.Covered), line(2, model.NotCovered)),
+			},
+		}},
+	}
+
+	// Act
+	result := diff.Compare(baseline, current)
+
+	// Assert
+	require.Len(t, result.Assemblies, 1)
+	assembly := result.Assemblies[0]
+	assert.Equal(t, diff.InBoth, assembly.Presence)
+	require.Len(t, assembly.Classes, 3)
+
+	calc, legacy, parser := assembly.Classes[0], assembly.Classes[1], assembly.Classes[2]
+	assert.Equal(t, "Calc", calc.Name)
+	assert.Equal(t, diff.InBoth, calc.Presence)
+	assert.Equal(t, 2, calc.NewlyUncoveredLines)
+	assert.InDelta(t, -41.6, calc.LineCoverageChange(), 0.001) // 66.6% -> 25.0%
+
+	assert.Equal(t, "Legacy", legacy.Name)
+	assert.Equal(t, diff.OnlyInBaseline, legacy.Presence)
+	assert.Equal(t, 0, legacy.NewlyUncoveredLines)
+	assert.True(t, math.IsNaN(legacy.LineCoverageChange()))
+
+	assert.Equal(t, "Parser", parser.Name)
+	assert.Equal(t, diff.OnlyInCurrent, parser.Presence)
+	assert.Equal(t, 1, parser.NewlyUncoveredLines)
+	assert.True(t, math.IsNaN(parser.LineCoverageChange()))
+
+	assert.Equal(t, 3, assembly.NewlyUncoveredLines)
+	assert.Equal(t, 3, result.NewlyUncoveredLines)
+	assert.InDelta(t, -26.7, result.LineCoverageChange(), 0.001) // 60.0% -> 33.3%
+}
+
+func TestCompare_AssembliesOnOneSide(t *testing.T) {
+	// Arrange
+	baseline := &model.SummaryResult{
+		Assemblies: []model.Assembly{{Name: "Old", Classes: []model.Class{class("Utils", 1, 1, line(1, model.Covered))}}},
+	}
+	current := &model.SummaryResult{
+		// The same class moved to another assembly; renames are not detected.
+		Assemblies: []model.Assembly{{Name: "New", Classes: []model.Class{class("Utils", 0, 1, line(1, model.NotCovered))}}},
+	}
+
+	// Act
+	result := diff.Compare(baseline, current)
+
+	// Assert
+	require.Len(t, result.Assemblies, 2)
+	assert.Equal(t, "New", result.Assemblies[0].Name)
+	assert.Equal(t, diff.OnlyInCurrent, result.Assemblies[0].Presence)
+	assert.Equal(t, diff.OnlyInCurrent, result.Assemblies[0].Classes[0].Presence)
+	assert.Equal(t, 1, result.Assemblies[0].NewlyUncoveredLines)
+
+	assert.Equal(t, "Old", result.Assemblies[1].Name)
+	assert.Equal(t, diff.OnlyInBaseline, result.Assemblies[1].Presence)
+	assert.Equal(t, diff.OnlyInBaseline, result.Assemblies[1].Classes[0].Presence)
+}
+
+func TestCompare_NilSummaries(t *testing.T) {
+	// Act
+	result := diff.Compare(nil, nil)
+
+	// Assert
+	require.NotNil(t, result)
+	assert.Empty(t, result.Assemblies)
+	assert.True(t, math.IsNaN(result.LineCoverageChange()))
+}
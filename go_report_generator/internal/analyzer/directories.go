@@ -0,0 +1,27 @@
+package analyzer
+
+import (
+	"path/filepath"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer/tree"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// BuildDirectoryTree aggregates the coverage of all code files by directory and stores the
+// tree in summary.Directories. Files are placed relative to the source directory containing
+// them, or relative to the common directory of the remaining files. Relative source
+// directories are resolved against the working directory, like the file paths of the parsers.
+// keyOf returns the key by which paths are compared, see tree.Build.
+func BuildDirectoryTree(summary *model.SummaryResult, sourceDirs []string, keyOf func(string) string) {
+	if summary == nil {
+		return
+	}
+	absDirs := make([]string, 0, len(sourceDirs))
+	for _, dir := range sourceDirs {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		absDirs = append(absDirs, dir)
+	}
+	summary.Directories = tree.Build(summary.Assemblies, absDirs, keyOf)
+}
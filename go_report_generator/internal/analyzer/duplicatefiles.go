@@ -0,0 +1,248 @@
+package analyzer
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// duplicateFileMerger merges the files of an assembly that are the same physical source
+// file reached through different paths, so that its lines are counted and shown once.
+type duplicateFileMerger struct {
+	// keyOf returns the key by which paths are compared, see utils.PathKeyFunc.
+	keyOf func(string) string
+	// vendorHeuristic, if set, makes a vendored file (".../vendor/<suffix>") a copy of the
+	// file of the assembly whose path ends with the same suffix, or of other vendored
+	// copies of it, even if they belong to other classes, e.g. the packages of a Go
+	// module and of its vendored copy.
+	vendorHeuristic bool
+	mode            utils.MergeMode
+	registry        model.MetricRegistry
+	fullCoverage    FullMethodCoverage
+	logger          *slog.Logger
+}
+
+// fileRef locates a file in the classes of an assembly.
+type fileRef struct {
+	class, file int
+}
+
+// merge merges the copies of the files in each of the assemblies and returns the number
+// of files merged into another file. Files with the same key are merged within a class
+// only, as a file may be shared by several classes (e.g. partial classes); vendored
+// copies are merged across the classes of the assembly. The lines of the copies are
+// merged like those of a file found in several reports, with mode. A class whose files
+// were all merged into other classes is removed and its methods are added to the class
+// of its first file. The totals of the changed classes and assemblies are recalculated.
+func (m duplicateFileMerger) merge(assemblies []model.Assembly) int {
+	merged := 0
+	for i := range assemblies {
+		merged += m.mergeAssembly(&assemblies[i])
+	}
+	return merged
+}
+
+func (m duplicateFileMerger) mergeAssembly(assembly *model.Assembly) int {
+	var refs []fileRef
+	var paths []string
+	for c, class := range assembly.Classes {
+		for f, file := range class.Files {
+			refs = append(refs, fileRef{class: c, file: f})
+			paths = append(paths, file.Path)
+		}
+	}
+	if len(refs) < 2 {
+		return 0
+	}
+
+	keys, vendored := m.duplicateFileKeys(paths)
+	targets := duplicateFileTargets(refs, keys, vendored)
+
+	// The files may still be shared with the parser results, so merged files are copies.
+	files := make([]model.CodeFile, len(refs))
+	for i, ref := range refs {
+		files[i] = assembly.Classes[ref.class].Files[ref.file]
+	}
+	merged := 0
+	for i, target := range targets {
+		if target < 0 {
+			continue
+		}
+		files[target] = mergeCodeFiles(files[target], files[i], m.mode)
+		m.logger.Debug("Merged duplicate source file", "assembly", assembly.Name,
+			"class", assembly.Classes[refs[target].class].Name, "file", files[target].Path,
+			"duplicateClass", assembly.Classes[refs[i].class].Name, "duplicate", files[i].Path)
+		merged++
+	}
+	if merged == 0 {
+		return 0
+	}
+
+	changed := make([]bool, len(assembly.Classes))
+	removed := make([]bool, len(assembly.Classes))
+	classFiles := make([][]model.CodeFile, len(assembly.Classes))
+	receivers := make([]int, len(assembly.Classes))
+	for c := range receivers {
+		receivers[c] = -1
+	}
+	for i, ref := range refs {
+		if target := targets[i]; target >= 0 {
+			changed[ref.class], changed[refs[target].class] = true, true
+			if receivers[ref.class] < 0 {
+				receivers[ref.class] = refs[target].class
+			}
+			continue
+		}
+		classFiles[ref.class] = append(classFiles[ref.class], files[i])
+	}
+	for c := range assembly.Classes {
+		if !changed[c] {
+			continue
+		}
+		class := &assembly.Classes[c]
+		class.Files = classFiles[c]
+		if len(class.Files) == 0 {
+			m.addMethods(&assembly.Classes[receivers[c]], class)
+			removed[c] = true
+		}
+	}
+	for c := range assembly.Classes {
+		if changed[c] && !removed[c] {
+			sumClassTotals(&assembly.Classes[c], m.keyOf)
+		}
+	}
+	classes := make([]model.Class, 0, len(assembly.Classes))
+	for c, class := range assembly.Classes {
+		if !removed[c] {
+			classes = append(classes, class)
+		}
+	}
+	assembly.Classes = classes
+	sumAssemblyTotals(assembly, m.keyOf)
+	return merged
+}
+
+// addMethods adds the distinct methods of the class from, whose files were merged into
+// the class to, to those of to and recounts them.
+func (m duplicateFileMerger) addMethods(to, from *model.Class) {
+	methods := utils.DistinctBy(slices.Concat(to.Methods, from.Methods), model.Method.RawKey)
+	to.Metrics = mergeClassMetrics(m.registry, methods, to, from)
+	to.Methods = methods
+	to.TotalMethods = len(methods)
+	to.CoveredMethods, to.FullyCoveredMethods = m.fullCoverage.CountMethodCoverage(methods)
+}
+
+// duplicateFileKeys returns the key of each path under which its copies are grouped, and
+// whether it is a vendored copy. The key is that of keyOf or, for a vendored copy, the
+// key of the first path that is not vendored and ends with the path after "vendor/", or
+// "vendor/<suffix>" if there is none.
+func (m duplicateFileMerger) duplicateFileKeys(paths []string) ([]string, []bool) {
+	keys := make([]string, len(paths))
+	vendored := make([]bool, len(paths))
+	suffixes := make([]string, len(paths))
+	for i, p := range paths {
+		keys[i] = m.keyOf(p)
+		if m.vendorHeuristic {
+			suffixes[i], vendored[i] = utils.VendoredPathSuffix(keys[i])
+		}
+	}
+	if !m.vendorHeuristic {
+		return keys, vendored
+	}
+
+	// originals maps every trailing part of the keys of the files that are not vendored,
+	// starting after a slash, to the first of these keys.
+	originals := make(map[string]string)
+	for i, key := range keys {
+		if vendored[i] {
+			continue
+		}
+		for part := key; ; {
+			if _, ok := originals[part]; !ok {
+				originals[part] = key
+			}
+			slash := strings.Index(part, "/")
+			if slash < 0 {
+				break
+			}
+			part = part[slash+1:]
+		}
+	}
+	for i := range keys {
+		if !vendored[i] {
+			continue
+		}
+		if original, ok := originals[suffixes[i]]; ok {
+			keys[i] = original
+		} else {
+			// Vendored copies without a checked out original are grouped by their suffix.
+			keys[i] = "vendor/" + suffixes[i]
+		}
+	}
+	return keys, vendored
+}
+
+// duplicateFileTargets returns for each file the index of the file it is merged into, or
+// -1 if it is kept. A file is merged into the first file of its class with the same key.
+// A vendored copy is merged into the first file with the same key that is not vendored,
+// preferably of the same class, or else into the first vendored copy of the assembly.
+// Files that are merged into are always kept.
+func duplicateFileTargets(refs []fileRef, keys []string, vendored []bool) []int {
+	type classKey struct {
+		class int
+		key   string
+	}
+	firstInClass := make(map[classKey]int)
+	firstOriginal := make(map[string]int)
+	firstCopy := make(map[string]int)
+	for i, ref := range refs {
+		if vendored[i] {
+			if _, ok := firstCopy[keys[i]]; !ok {
+				firstCopy[keys[i]] = i
+			}
+			continue
+		}
+		if _, ok := firstInClass[classKey{ref.class, keys[i]}]; !ok {
+			firstInClass[classKey{ref.class, keys[i]}] = i
+		}
+		if _, ok := firstOriginal[keys[i]]; !ok {
+			firstOriginal[keys[i]] = i
+		}
+	}
+
+	targets := make([]int, len(refs))
+	for i, ref := range refs {
+		target, ok := firstInClass[classKey{ref.class, keys[i]}]
+		if vendored[i] && !ok {
+			if target, ok = firstOriginal[keys[i]]; !ok {
+				target = firstCopy[keys[i]]
+			}
+		}
+		if target == i {
+			target = -1
+		}
+		targets[i] = target
+	}
+	return targets
+}
+
+// mergeCodeFiles merges the lines of duplicate into those of kept with mode and recounts
+// the covered and coverable lines. The methods and code elements of kept are used unless
+// it has none.
+func mergeCodeFiles(kept, duplicate model.CodeFile, mode utils.MergeMode) model.CodeFile {
+	kept.Lines = mergeFileLines(kept.Lines, duplicate.Lines, mode)
+	kept.CoveredLines, kept.CoverableLines = model.CountLines(kept.Lines)
+	kept.TotalLines = max(kept.TotalLines, duplicate.TotalLines)
+	if len(kept.MethodMetrics) == 0 {
+		kept.MethodMetrics = duplicate.MethodMetrics
+	}
+	if len(kept.CodeElements) == 0 {
+		kept.CodeElements = duplicate.CodeElements
+	}
+	kept.ApproximateBranchCoverage = kept.ApproximateBranchCoverage || duplicate.ApproximateBranchCoverage
+	kept.InSourceDirs = kept.InSourceDirs || duplicate.InSourceDirs
+	return kept
+}
@@ -0,0 +1,188 @@
+package analyzer_test
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// duplicateFilesResult returns a result with a class that has a file per path, each with
+// two coverable lines of which the given line is covered once.
+func duplicateFilesResult(paths map[string]int) *parsers.ParserResult {
+	class := model.Class{Name: "pkg.Errors"}
+	for path, coveredLine := range paths {
+		lines := []model.Line{
+			{Number: 1, Hits: 0, LineVisitStatus: model.NotCovered},
+			{Number: 2, Hits: 0, LineVisitStatus: model.NotCovered},
+		}
+		lines[coveredLine-1].Hits, lines[coveredLine-1].LineVisitStatus = 1, model.Covered
+		file := model.NewCodeFile(path, lines)
+		file.TotalLines = 10
+		class.Files = append(class.Files, file)
+		class.LinesCovered += file.CoveredLines
+		class.LinesValid += file.CoverableLines
+	}
+	class.TotalLines = 10 * len(paths)
+	return &parsers.ParserResult{
+		ParserName: "Test",
+		Assemblies: []model.Assembly{{
+			Name: "App", Classes: []model.Class{class},
+			LinesCovered: class.LinesCovered, LinesValid: class.LinesValid, TotalLines: class.TotalLines,
+		}},
+	}
+}
+
+func mergeDuplicateFilesResult(t *testing.T, result *parsers.ParserResult, mergeVendored bool) *model.SummaryResult {
+	t.Helper()
+	appSettings := settings.NewSettings()
+	appSettings.MergeVendoredFiles = mergeVendored
+	return mergeWithSettings(t, result, appSettings)
+}
+
+func mergeWithSettings(t *testing.T, result *parsers.ParserResult, appSettings *settings.Settings) *model.SummaryResult {
+	t.Helper()
+	summary, err := analyzer.MergeParserResults([]*parsers.ParserResult{result}, &mockMergerConfig{logger: slog.Default(), settings: appSettings})
+	require.NoError(t, err)
+	return summary
+}
+
+func TestMergeParserResults_VendoredCopy_IsMergedIntoOriginal(t *testing.T) {
+	result := duplicateFilesResult(map[string]int{
+		"/src/app/vendor/github.com/pkg/errors/errors.go": 1,
+		"/go/src/github.com/pkg/errors/errors.go":         2,
+	})
+
+	summary := mergeDuplicateFilesResult(t, result, true)
+
+	class := summary.Assemblies[0].Classes[0]
+	require.Len(t, class.Files, 1)
+	assert.Equal(t, "/go/src/github.com/pkg/errors/errors.go", class.Files[0].Path, "the file outside the vendor directory is kept")
+	assert.Equal(t, 2, class.Files[0].CoveredLines)
+	assert.Equal(t, 2, class.LinesCovered)
+	assert.Equal(t, 2, class.LinesValid)
+	assert.Equal(t, 10, class.TotalLines)
+	assert.Equal(t, 2, summary.LinesValid)
+	assert.Equal(t, 10, summary.TotalLines)
+}
+
+func TestMergeParserResults_VendoredCopies_AreMergedBySuffix(t *testing.T) {
+	result := duplicateFilesResult(map[string]int{
+		"/src/a/vendor/github.com/pkg/errors/errors.go": 1,
+		"/src/b/vendor/github.com/pkg/errors/errors.go": 1,
+		"/src/b/vendor/github.com/pkg/errors/stack.go":  1,
+	})
+
+	summary := mergeDuplicateFilesResult(t, result, true)
+
+	class := summary.Assemblies[0].Classes[0]
+	require.Len(t, class.Files, 2)
+	assert.Equal(t, 2, class.Files[0].Lines[0].Hits, "the hits of the copies are summed")
+	assert.Equal(t, 4, class.LinesValid)
+}
+
+func TestMergeParserResults_VendorHeuristicDisabled_KeepsCopies(t *testing.T) {
+	result := duplicateFilesResult(map[string]int{
+		"/src/app/vendor/github.com/pkg/errors/errors.go": 1,
+		"/go/src/github.com/pkg/errors/errors.go":         2,
+	})
+
+	summary := mergeDuplicateFilesResult(t, result, false)
+
+	assert.Len(t, summary.Assemblies[0].Classes[0].Files, 2)
+	assert.Equal(t, 4, summary.LinesValid)
+}
+
+func TestMergeParserResults_SymlinkedFile_IsMergedWhenLinksAreResolved(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symbolic links needs extra privileges on Windows")
+	}
+	dir := t.TempDir()
+	target := filepath.Join(dir, "src", "errors.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(target), 0o755))
+	require.NoError(t, os.WriteFile(target, []byte("package errors\n"), 0o644))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "src"), filepath.Join(dir, "bazel-out")))
+	link := filepath.Join(dir, "bazel-out", "errors.go")
+
+	result := duplicateFilesResult(map[string]int{target: 1, link: 2})
+
+	kept := mergeWithSettings(t, result, settings.NewSettings())
+	assert.Len(t, kept.Assemblies[0].Classes[0].Files, 2, "links are not resolved by default")
+
+	appSettings := settings.NewSettings()
+	appSettings.ResolveSymlinks = true
+	summary := mergeWithSettings(t, result, appSettings)
+
+	class := summary.Assemblies[0].Classes[0]
+	require.Len(t, class.Files, 1)
+	assert.Equal(t, 2, class.LinesCovered)
+	assert.Equal(t, 10, summary.TotalLines)
+}
+
+func TestMergeParserResults_VendoredPackage_IsMergedIntoItsClass(t *testing.T) {
+	original := duplicateFilesResult(map[string]int{"/go/src/github.com/pkg/errors/errors.go": 2})
+	vendoredCopy := duplicateFilesResult(map[string]int{"/src/app/vendor/github.com/pkg/errors/errors.go": 1})
+	original.Assemblies[0].Classes[0].Name = "github.com/pkg/errors"
+	original.Assemblies[0].Classes[0].Methods = []model.Method{{Name: "New", Lines: []model.Line{model.NewLine(2, 1)}}}
+	vendoredClass := &vendoredCopy.Assemblies[0].Classes[0]
+	vendoredClass.Name = "example.com/app/vendor/github.com/pkg/errors"
+	vendoredClass.Methods = []model.Method{{Name: "Wrap", Lines: []model.Line{model.NewLine(1, 1)}}}
+	vendoredClass.Files = append(vendoredClass.Files, model.NewCodeFile("/src/app/vendor/github.com/pkg/errors/stack.go", []model.Line{model.NewLine(1, 0)}))
+	otherClass := model.Class{Name: "example.com/app", Files: []model.CodeFile{model.NewCodeFile("/src/app/main.go", []model.Line{model.NewLine(1, 1)})}, LinesCovered: 1, LinesValid: 1}
+	vendoredCopy.Assemblies[0].Classes = append(vendoredCopy.Assemblies[0].Classes, otherClass)
+	result := &parsers.ParserResult{ParserName: "Test", Assemblies: []model.Assembly{{
+		Name:    "App",
+		Classes: slices.Concat(original.Assemblies[0].Classes, vendoredCopy.Assemblies[0].Classes),
+	}}}
+
+	summary := mergeDuplicateFilesResult(t, result, true)
+
+	classes := summary.Assemblies[0].Classes
+	require.Len(t, classes, 3, "the vendored package keeps the file without an original")
+	errorsClass := classes[2]
+	assert.Equal(t, "github.com/pkg/errors", errorsClass.Name)
+	require.Len(t, errorsClass.Files, 1)
+	assert.Equal(t, 2, errorsClass.Files[0].CoveredLines, "the hits of the vendored copy are merged into the original")
+	assert.Equal(t, 2, errorsClass.LinesCovered)
+	assert.Len(t, errorsClass.Methods, 1, "the vendored package keeps its methods while it has files")
+	vendored := classes[1]
+	require.Len(t, vendored.Files, 1)
+	assert.Equal(t, "/src/app/vendor/github.com/pkg/errors/stack.go", vendored.Files[0].Path)
+	assert.Equal(t, 0, vendored.LinesCovered)
+	assert.Equal(t, 3, summary.Assemblies[0].LinesCovered)
+	assert.Equal(t, 4, summary.Assemblies[0].LinesValid)
+}
+
+func TestMergeParserResults_VendoredPackageWithoutOwnFiles_IsRemoved(t *testing.T) {
+	original := model.Class{
+		Name:    "github.com/pkg/errors",
+		Files:   []model.CodeFile{model.NewCodeFile("/go/src/github.com/pkg/errors/errors.go", []model.Line{model.NewLine(1, 0), model.NewLine(2, 1)})},
+		Methods: []model.Method{{Name: "New", Lines: []model.Line{model.NewLine(2, 1)}}},
+	}
+	vendored := model.Class{
+		Name:    "example.com/app/vendor/github.com/pkg/errors",
+		Files:   []model.CodeFile{model.NewCodeFile("/src/app/vendor/github.com/pkg/errors/errors.go", []model.Line{model.NewLine(1, 1), model.NewLine(2, 0)})},
+		Methods: []model.Method{{Name: "Wrap", Lines: []model.Line{model.NewLine(1, 1)}}},
+	}
+	result := &parsers.ParserResult{ParserName: "Test", Assemblies: []model.Assembly{{Name: "App", Classes: []model.Class{vendored, original}}}}
+
+	summary := mergeDuplicateFilesResult(t, result, true)
+
+	classes := summary.Assemblies[0].Classes
+	require.Len(t, classes, 1)
+	assert.Equal(t, "github.com/pkg/errors", classes[0].Name)
+	assert.Equal(t, "/go/src/github.com/pkg/errors/errors.go", classes[0].Files[0].Path)
+	assert.Equal(t, 2, classes[0].LinesCovered)
+	assert.Equal(t, 2, classes[0].TotalMethods, "the methods of the vendored package are added")
+	assert.Equal(t, 2, summary.LinesCovered)
+	assert.Equal(t, 2, summary.LinesValid)
+}
@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+)
+
+// CoverageFingerprint hashes the coverage data of a parser result: the assemblies, classes
+// and the hits and branch visits of every line. Paths of the source files and timestamps are left out, so
+// two reports with the same fingerprint describe the same test run even if they were
+// copied to other locations or produced in another checkout. Merging both would count
+// the coverage twice.
+func CoverageFingerprint(result *parsers.ParserResult) string {
+	h := sha256.New()
+
+	assemblies := make([]model.Assembly, len(result.Assemblies))
+	copy(assemblies, result.Assemblies)
+	sort.Slice(assemblies, func(i, j int) bool { return assemblies[i].Name < assemblies[j].Name })
+
+	for _, assembly := range assemblies {
+		fmt.Fprintf(h, "A%s\n", assembly.Name)
+
+		classes := make([]model.Class, len(assembly.Classes))
+		copy(classes, assembly.Classes)
+		sort.Slice(classes, func(i, j int) bool { return classes[i].Name < classes[j].Name })
+
+		for _, class := range classes {
+			fmt.Fprintf(h, "C%s\n", class.Name)
+			writeFilesFingerprint(h, class.Files)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeFilesFingerprint adds the lines of the files in an order that does not depend on
+// their paths.
+func writeFilesFingerprint(h hash.Hash, files []model.CodeFile) {
+	fileHashes := make([]string, 0, len(files))
+	for _, file := range files {
+		fh := sha256.New()
+		for _, line := range file.Lines {
+			if line.Hits < 0 {
+				continue
+			}
+			fmt.Fprintf(fh, "%d:%d:%d/%d", line.Number, line.Hits, line.CoveredBranches, line.TotalBranches)
+			// Reports that visit different branches of a line are not duplicates.
+			for _, branch := range line.Branch {
+				fmt.Fprintf(fh, " %s=%d", branch.Identifier, branch.Visits)
+			}
+			fmt.Fprintln(fh)
+		}
+		fileHashes = append(fileHashes, hex.EncodeToString(fh.Sum(nil)))
+	}
+	sort.Strings(fileHashes)
+	for _, fileHash := range fileHashes {
+		fmt.Fprintf(h, "F%s\n", fileHash)
+	}
+}
@@ -0,0 +1,71 @@
+package analyzer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/stretchr/testify/assert"
+)
+
+func fingerprintTestResult(sourceDir string, hits int) *parsers.ParserResult {
+	timestamp := time.Now()
+	return &parsers.ParserResult{
+		MinimumTimeStamp:  &timestamp,
+		SourceDirectories: []string{sourceDir},
+		Assemblies: []model.Assembly{{
+			Name: "App",
+			Classes: []model.Class{
+				{Name: "App.B", Files: []model.CodeFile{{Path: sourceDir + "/b.cs", Lines: []model.Line{{Number: 1, Hits: -1}, {Number: 2, Hits: 0}}}}},
+				{Name: "App.A", Files: []model.CodeFile{{Path: sourceDir + "/a.cs", Lines: []model.Line{{Number: 3, Hits: hits}}}}},
+			},
+		}},
+	}
+}
+
+func TestCoverageFingerprint_WhenOnlyPathsAndTimestampsDiffer_ShouldBeEqual(t *testing.T) {
+	// Arrange
+	first := fingerprintTestResult("/agent1/src", 4)
+	second := fingerprintTestResult(`C:\agent2\src`, 4)
+	second.Assemblies[0].Classes[0], second.Assemblies[0].Classes[1] = second.Assemblies[0].Classes[1], second.Assemblies[0].Classes[0]
+
+	// Act
+	firstFingerprint := analyzer.CoverageFingerprint(first)
+	secondFingerprint := analyzer.CoverageFingerprint(second)
+
+	// Assert
+	assert.Equal(t, firstFingerprint, secondFingerprint)
+}
+
+func TestCoverageFingerprint_WhenHitsDiffer_ShouldDiffer(t *testing.T) {
+	// Arrange
+	first := fingerprintTestResult("/src", 4)
+	second := fingerprintTestResult("/src", 5)
+
+	// Act
+	firstFingerprint := analyzer.CoverageFingerprint(first)
+	secondFingerprint := analyzer.CoverageFingerprint(second)
+
+	// Assert
+	assert.NotEqual(t, firstFingerprint, secondFingerprint)
+}
+
+func TestCoverageFingerprint_WhenVisitedBranchesDiffer_ShouldDiffer(t *testing.T) {
+	// Arrange: both reports cover one of the two branches of line 3, but not the same one
+	first, second := fingerprintTestResult("/src", 4), fingerprintTestResult("/src", 4)
+	for i, result := range []*parsers.ParserResult{first, second} {
+		line := &result.Assemblies[0].Classes[1].Files[0].Lines[0]
+		line.IsBranchPoint, line.CoveredBranches, line.TotalBranches = true, 1, 2
+		line.Branch = []model.BranchCoverageDetail{{Identifier: "0"}, {Identifier: "1"}}
+		line.Branch[i].Visits = 1
+	}
+
+	// Act
+	firstFingerprint := analyzer.CoverageFingerprint(first)
+	secondFingerprint := analyzer.CoverageFingerprint(second)
+
+	// Assert
+	assert.NotEqual(t, firstFingerprint, secondFingerprint)
+}
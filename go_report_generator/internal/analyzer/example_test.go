@@ -0,0 +1,87 @@
+package analyzer_test
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/htmlreport"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/textsummary"
+)
+
+// This example builds the coverage of two classes by hand, as a custom coverage
+// collector would, and writes the TextSummary and Html reports without a coverage file.
+func ExampleNormalizeSummary() {
+	summary := &model.SummaryResult{
+		ParserName: "MyCollector",
+		Assemblies: []model.Assembly{{
+			Name: "Shop",
+			Classes: []model.Class{
+				{Name: "Shop.Cart", Files: []model.CodeFile{
+					model.NewCodeFile("src/Cart.cs", []model.Line{
+						model.NewLine(12, 0),
+						model.NewLine(10, 3),
+						model.NewBranchLine(11, 3, 1, 2),
+						model.NewLine(9, -1), // Not coverable
+					}),
+				}},
+				{Name: "Shop.Price", Files: []model.CodeFile{
+					model.NewCodeFile("src/Price.cs", []model.Line{model.NewLine(4, 1)}),
+				}},
+			},
+		}},
+	}
+	outputDir, err := os.MkdirTemp("", "coverage-report")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(outputDir)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg, err := reportconfig.NewReportConfiguration(nil, outputDir, reportconfig.WithLogger(logger))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := analyzer.NormalizeSummary(summary, cfg.Settings()); err != nil {
+		fmt.Println(err)
+		return
+	}
+	now := func() time.Time { return time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC) }
+	ctx := reporter.NewBuilderContext(cfg, cfg.Settings(), logger, reporter.WithClock(now))
+
+	if err := textsummary.NewTextReportBuilder(outputDir, logger, textsummary.WithClock(now)).CreateReport(summary); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := htmlreport.NewHtmlReportBuilder(outputDir, ctx).CreateReport(summary); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	text, _ := os.ReadFile(filepath.Join(outputDir, "Summary.txt"))
+	for _, line := range strings.Split(string(text), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Line coverage:") || strings.HasPrefix(line, "Branch coverage:") || strings.HasPrefix(line, "Shop") {
+			fmt.Println(line)
+		}
+	}
+	_, err = os.Stat(filepath.Join(outputDir, "index.html"))
+	fmt.Println("index.html written:", err == nil)
+	// Output:
+	// Line coverage: 75%
+	// Branch coverage: 50% (1 of 2)
+	// Shop            75%  Methods: N/A
+	// Shop.Cart     66%
+	// Shop.Price    100%
+	// index.html written: true
+}
@@ -0,0 +1,214 @@
+package analyzer
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+// ApplyExclusionComments marks the lines excluded by coverage-exclusion comments in the
+// source files as not coverable (see settings.ExclusionMarkers) and recomputes the totals
+// of the changed files, classes and assemblies and of the summary, and the coverage quotas
+// of the code elements with excluded lines. The coverage of methods is left to
+// FullMethodCoverage.RecomputeMethodCoverage.
+//
+// markersFor returns the markers of a file. Sources stored in the model are scanned as
+// they are, others are read with readLines; files that cannot be read keep their coverage.
+// Start markers without an end, and end markers without a start, are logged as warnings
+// and ignored. The number of excluded coverable lines of the unique files is returned;
+// keyOf returns the key by which file paths are compared, see utils.PathKeyFunc.
+func ApplyExclusionComments(summary *model.SummaryResult, markersFor func(path string) settings.ExclusionMarkers, readLines func(path string) ([]string, error), keyOf func(string) string, logger *slog.Logger) int {
+	if summary == nil {
+		return 0
+	}
+
+	excludedByFile := make(map[string]map[int]bool)
+	excludedLinesOf := func(file *model.CodeFile) map[int]bool {
+		key := keyOf(file.Path)
+		if excluded, ok := excludedByFile[key]; ok {
+			return excluded
+		}
+		source, ok := file.SourceLines()
+		if !ok {
+			var err error
+			if source, err = readLines(file.Path); err != nil {
+				logger.Debug("Could not read source file to look for coverage exclusion comments", "file", file.Path, "error", err)
+				excludedByFile[key] = nil
+				return nil
+			}
+		}
+		excluded, unbalanced := findExcludedLines(source, markersFor(file.Path))
+		for _, marker := range unbalanced {
+			logger.Warn("Ignoring unbalanced coverage exclusion marker", "file", file.Path, "line", marker.line, "marker", marker.marker)
+		}
+		excludedByFile[key] = excluded
+		return excluded
+	}
+
+	excludedLines := 0
+	counted := make(map[string]bool)
+	summaryChanged := false
+	for i := range summary.Assemblies {
+		assembly := &summary.Assemblies[i]
+		assemblyChanged := false
+		for j := range assembly.Classes {
+			class := &assembly.Classes[j]
+			classChanged := false
+			for k := range class.Files {
+				file := &class.Files[k]
+				if len(file.Lines) == 0 {
+					continue
+				}
+				excluded := excludedLinesOf(file)
+				if len(excluded) == 0 {
+					continue
+				}
+				// Files of several classes may share their lines, so a file whose lines were
+				// already excluded through another class still needs its counts updated.
+				covered, coverable := file.CoveredLines, file.CoverableLines
+				n := excludeLines(file, excluded)
+				if key := keyOf(file.Path); n > 0 && !counted[key] {
+					counted[key] = true
+					excludedLines += n
+				}
+				if n > 0 || file.CoveredLines != covered || file.CoverableLines != coverable {
+					classChanged = true
+				}
+			}
+			if classChanged {
+				class.BranchesCovered, class.BranchesValid = nil, nil
+				sumClassTotals(class, keyOf)
+				assemblyChanged = true
+			}
+		}
+		if assemblyChanged {
+			sumAssemblyTotals(assembly, keyOf)
+			summaryChanged = true
+		}
+	}
+
+	if summaryChanged {
+		sumSummaryTotals(summary, keyOf)
+	}
+	return excludedLines
+}
+
+// excludeLines makes the excluded coverable lines of the file not coverable and recounts
+// its covered and coverable lines and the coverage quotas of its code elements with
+// excluded lines. It returns the number of lines it changed.
+func excludeLines(file *model.CodeFile, excluded map[int]bool) int {
+	changed := 0
+	for i := range file.Lines {
+		line := &file.Lines[i]
+		if !excluded[line.Number] || (line.Hits < 0 && !line.IsBranchPoint) {
+			continue
+		}
+		*line = model.Line{Number: line.Number, Hits: -1, Content: line.Content, LineVisitStatus: model.NotCoverable}
+		changed++
+	}
+	file.CoveredLines, file.CoverableLines = model.CountLines(file.Lines)
+	for i := range file.CodeElements {
+		if element := &file.CodeElements[i]; element.CoverageQuota != nil && containsExcludedLine(element, excluded) {
+			element.CoverageQuota = coverageQuotaOfLines(linesInRange(file.Lines, element.FirstLine, element.LastLine))
+		}
+	}
+	return changed
+}
+
+// containsExcludedLine reports whether an excluded line lies within the lines of the
+// element.
+func containsExcludedLine(element *model.CodeElement, excluded map[int]bool) bool {
+	for number := range excluded {
+		if number >= element.FirstLine && number <= element.LastLine {
+			return true
+		}
+	}
+	return false
+}
+
+// coverageQuotaOfLines returns the covered percentage of the coverable lines, or nil if
+// none of them is coverable.
+func coverageQuotaOfLines(lines []model.Line) *float64 {
+	covered, coverable := model.CountLines(lines)
+	if coverable == 0 {
+		return nil
+	}
+	quota := float64(covered) / float64(coverable) * 100
+	return &quota
+}
+
+// unbalancedMarker is a start marker without an end, or an end marker without a start.
+type unbalancedMarker struct {
+	line   int
+	marker string
+}
+
+// findExcludedLines returns the numbers of the source lines excluded by the markers, and
+// the region markers that were ignored because they are unbalanced. Regions may be
+// nested; an end marker closes the innermost open region.
+func findExcludedLines(source []string, markers settings.ExclusionMarkers) (map[int]bool, []unbalancedMarker) {
+	excluded := make(map[int]bool)
+	var unbalanced []unbalancedMarker
+	var openRegions []int // Line numbers of the start markers of the open regions
+	excludeNext := false
+
+	for i, text := range source {
+		number := i + 1
+		if excludeNext && strings.TrimSpace(text) != "" {
+			excluded[number] = true
+			excludeNext = false
+		}
+		if containsMarker(text, markers.Line) {
+			excluded[number] = true
+		}
+		if containsMarker(text, markers.Next) {
+			excludeNext = true
+		}
+		if containsMarker(text, markers.Start) {
+			openRegions = append(openRegions, number)
+		}
+		if containsMarker(text, markers.End) {
+			if len(openRegions) == 0 {
+				unbalanced = append(unbalanced, unbalancedMarker{line: number, marker: markers.End})
+				continue
+			}
+			start := openRegions[len(openRegions)-1]
+			openRegions = openRegions[:len(openRegions)-1]
+			for n := start; n <= number; n++ {
+				excluded[n] = true
+			}
+		}
+	}
+	for _, start := range openRegions {
+		unbalanced = append(unbalanced, unbalancedMarker{line: start, marker: markers.Start})
+	}
+	return excluded, unbalanced
+}
+
+// containsMarker reports whether the marker occurs in the text as a whole word, i.e. not
+// directly preceded or followed by a letter, digit, '_' or '-' where the marker itself
+// starts or ends with one. "coverage:ignore" is thus not found in "coverage:ignore-start".
+func containsMarker(text, marker string) bool {
+	if marker == "" {
+		return false
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], marker)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(marker)
+		joinedBefore := start > 0 && isMarkerWordChar(text[start-1]) && isMarkerWordChar(marker[0])
+		joinedAfter := end < len(text) && isMarkerWordChar(text[end]) && isMarkerWordChar(marker[len(marker)-1])
+		if !joinedBefore && !joinedAfter {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isMarkerWordChar(c byte) bool {
+	return c == '_' || c == '-' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
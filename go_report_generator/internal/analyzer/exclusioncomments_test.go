@@ -0,0 +1,199 @@
+package analyzer_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var goExclusionMarkers = settings.DefaultExclusionMarkers()["Go"]
+
+// coveredFile returns a file whose lines 1 to n are all covered once.
+func coveredFile(path string, n int) model.CodeFile {
+	lines := make([]model.Line, n)
+	for i := range lines {
+		lines[i] = model.NewLine(i+1, 1)
+	}
+	return model.NewCodeFile(path, lines)
+}
+
+// excludedLineNumbers applies the Go markers to a file with a covered line per source line
+// and returns the numbers of the lines that became not coverable and the logged warnings.
+func excludedLineNumbers(t *testing.T, source string) ([]int, string) {
+	t.Helper()
+	lines := strings.Split(source, "\n")
+	summary := &model.SummaryResult{Assemblies: []model.Assembly{{
+		Name:    "App",
+		Classes: []model.Class{{Name: "App.Class", Files: []model.CodeFile{coveredFile("/src/app.go", len(lines))}}},
+	}}}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	analyzer.ApplyExclusionComments(summary,
+		func(string) settings.ExclusionMarkers { return goExclusionMarkers },
+		func(string) ([]string, error) { return lines, nil },
+		utils.PathKey,
+		logger)
+
+	var excluded []int
+	for _, line := range summary.Assemblies[0].Classes[0].Files[0].Lines {
+		if line.Hits < 0 {
+			excluded = append(excluded, line.Number)
+		}
+	}
+	return excluded, logs.String()
+}
+
+func TestApplyExclusionComments_Markers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		excluded []int
+		warning  string
+	}{
+		{
+			name:     "LineMarker",
+			source:   "a()\nb() // coverage:ignore\nc()",
+			excluded: []int{2},
+		},
+		{
+			name:     "MarkerIsAWholeWord",
+			source:   "a() // coverage:ignored\nb() // nocoverage:ignore\nc() // coverage:ignore.",
+			excluded: []int{3},
+		},
+		{
+			name:     "NextMarkerSkipsBlankLines",
+			source:   "// coverage:ignore-next\n\n   \nb()\nc()",
+			excluded: []int{4},
+		},
+		{
+			name:     "Region",
+			source:   "a()\n// coverage:ignore-start\nb()\nc()\n// coverage:ignore-end\nd()",
+			excluded: []int{2, 3, 4, 5},
+		},
+		{
+			name:     "NestedRegions",
+			source:   "// coverage:ignore-start\na()\n// coverage:ignore-start\nb()\n// coverage:ignore-end\nc()\n// coverage:ignore-end\nd()",
+			excluded: []int{1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			name:     "UnclosedRegionIsNotAppliedToTheEnd",
+			source:   "a()\n// coverage:ignore-start\nb()\nc()",
+			excluded: nil,
+			warning:  "line=2",
+		},
+		{
+			name:     "UnclosedOuterRegionKeepsTheClosedInnerRegion",
+			source:   "// coverage:ignore-start\na()\n// coverage:ignore-start\nb()\n// coverage:ignore-end\nc()",
+			excluded: []int{3, 4, 5},
+			warning:  "line=1",
+		},
+		{
+			name:     "EndWithoutStart",
+			source:   "a()\n// coverage:ignore-end\nb() // coverage:ignore",
+			excluded: []int{3},
+			warning:  "line=2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			excluded, logs := excludedLineNumbers(t, tc.source)
+
+			assert.Equal(t, tc.excluded, excluded)
+			if tc.warning == "" {
+				assert.NotContains(t, logs, "level=WARN")
+			} else {
+				assert.Contains(t, logs, "Ignoring unbalanced coverage exclusion marker")
+				assert.Contains(t, logs, tc.warning)
+			}
+		})
+	}
+}
+
+func TestApplyExclusionComments_RecomputesTotals(t *testing.T) {
+	source := []string{"if x {", "  a() // coverage:ignore", "}", "b()"}
+	branchy := coveredFile("/src/a.go", 4)
+	branchy.Lines[0] = model.NewBranchLine(1, 1, 1, 2)
+	branchy.Lines[1].LineCoverageByTestMethod = map[string]int{"TestA": 1}
+	branchy.Lines[3] = model.NewLine(4, 0)
+	branchy.CoveredLines, branchy.CoverableLines = model.CountLines(branchy.Lines)
+	unreadable := coveredFile("/src/missing.go", 2)
+
+	summary := &model.SummaryResult{Assemblies: []model.Assembly{{
+		Name: "App",
+		Classes: []model.Class{
+			{Name: "App.A", Files: []model.CodeFile{branchy}},
+			{Name: "App.Shared", Files: []model.CodeFile{branchy, unreadable}},
+		},
+	}}}
+	require.NoError(t, analyzer.NormalizeSummary(summary, settings.NewSettings()))
+	require.Equal(t, 10, summary.LinesValid)
+
+	readLines := func(path string) ([]string, error) {
+		if path == "/src/a.go" {
+			return source, nil
+		}
+		return nil, errors.New("not found")
+	}
+	excluded := analyzer.ApplyExclusionComments(summary, func(string) settings.ExclusionMarkers { return goExclusionMarkers }, readLines, utils.PathKey, slog.Default())
+
+	assert.Equal(t, 1, excluded, "the file shared by two classes is counted once")
+	classA := summary.Assemblies[0].Classes[0]
+	line := classA.Files[0].Lines[1]
+	assert.Equal(t, -1, line.Hits)
+	assert.Equal(t, model.NotCoverable, line.LineVisitStatus)
+	assert.Nil(t, line.LineCoverageByTestMethod)
+	assert.Equal(t, 3, classA.Files[0].CoverableLines)
+	assert.Equal(t, 2, classA.LinesCovered)
+	assert.Equal(t, 3, classA.LinesValid)
+	require.NotNil(t, classA.BranchesValid)
+	assert.Equal(t, 2, *classA.BranchesValid, "the branches of lines that are not excluded are kept")
+
+	shared := summary.Assemblies[0].Classes[1]
+	assert.Equal(t, 4, shared.LinesCovered, "the unreadable file keeps its coverage")
+	assert.Equal(t, 5, shared.LinesValid)
+	assert.Equal(t, 6, summary.Assemblies[0].LinesCovered)
+	assert.Equal(t, 8, summary.Assemblies[0].LinesValid)
+	assert.Equal(t, 6, summary.LinesCovered)
+	assert.Equal(t, 8, summary.LinesValid)
+}
+
+func TestApplyExclusionComments_RecomputesCoverageQuotasOfCodeElements(t *testing.T) {
+	quota := func(value float64) *float64 { return &value }
+	source := []string{"func run() {", "  a()", "  b() // coverage:ignore", "}", "func skip() { c() } // coverage:ignore", "func other() { d() }"}
+	file := coveredFile("/src/a.go", 6)
+	file.Lines[2] = model.NewLine(3, 0)
+	file.Lines[4] = model.NewLine(5, 0)
+	file.CodeElements = []model.CodeElement{
+		{Name: "run", FirstLine: 1, LastLine: 4, CoverageQuota: quota(75)},
+		{Name: "skip", FirstLine: 5, LastLine: 5, CoverageQuota: quota(0)},
+		{Name: "other", FirstLine: 6, LastLine: 6, CoverageQuota: quota(50)},
+	}
+	summary := &model.SummaryResult{Assemblies: []model.Assembly{{
+		Name:    "App",
+		Classes: []model.Class{{Name: "App.A", Files: []model.CodeFile{file}}},
+	}}}
+
+	analyzer.ApplyExclusionComments(summary,
+		func(string) settings.ExclusionMarkers { return goExclusionMarkers },
+		func(string) ([]string, error) { return source, nil },
+		utils.PathKey,
+		slog.Default())
+
+	elements := summary.Assemblies[0].Classes[0].Files[0].CodeElements
+	require.NotNil(t, elements[0].CoverageQuota)
+	assert.Equal(t, 100.0, *elements[0].CoverageQuota, "the uncovered line is excluded")
+	assert.Nil(t, elements[1].CoverageQuota, "no coverable line is left")
+	require.NotNil(t, elements[2].CoverageQuota)
+	assert.Equal(t, 50.0, *elements[2].CoverageQuota, "elements without excluded lines keep their quota")
+}
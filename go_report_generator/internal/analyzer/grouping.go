@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// ApplyAssemblyGrouping splits every assembly of the summary into pseudo-assemblies
+// named "<Assembly> - <Namespace>", where the namespace consists of at most
+// groupingLevel leading namespace segments of the class display name. Classes
+// without a namespace stay in the original assembly. A grouping level of zero (or
+// less) keeps the grouping by assembly only.
+//
+// Namespaces are taken from dotted names for .NET-style classes and from
+// slash-separated package paths for Go-style classes. The grouped prefix is removed
+// from the class display names, and the assembly statistics are recalculated. keyOf
+// returns the key by which file paths are compared, see utils.PathKeyFunc.
+func ApplyAssemblyGrouping(summary *model.SummaryResult, groupingLevel int, keyOf func(string) string) {
+	if summary == nil || groupingLevel <= 0 {
+		return
+	}
+
+	var grouped []model.Assembly
+	for _, assembly := range summary.Assemblies {
+		groups := make(map[string]*model.Assembly)
+		for _, class := range assembly.Classes {
+			namespace, shortName := splitNamespace(class.DisplayName, groupingLevel)
+
+			groupName := assembly.Name
+			if namespace != "" {
+				groupName = assembly.Name + " - " + namespace
+				class.DisplayName = shortName
+			}
+
+			group, ok := groups[groupName]
+			if !ok {
+				group = &model.Assembly{Name: groupName, Classes: []model.Class{}}
+				groups[groupName] = group
+			}
+			group.Classes = append(group.Classes, class)
+		}
+
+		for _, group := range groups {
+			recalculateAssemblyStats(group, keyOf)
+			grouped = append(grouped, *group)
+		}
+	}
+
+	sort.Slice(grouped, func(i, j int) bool {
+		return grouped[i].Name < grouped[j].Name
+	})
+	summary.Assemblies = grouped
+}
+
+// splitNamespace returns the first groupingLevel namespace segments of a class name and
+// the remainder of the name. The last segment always belongs to the class itself.
+func splitNamespace(displayName string, groupingLevel int) (namespace, shortName string) {
+	separator := "."
+	if strings.Contains(displayName, "/") {
+		separator = "/"
+	}
+
+	// Generic arguments (e.g. "List<System.String>") must not be split.
+	nameForSplitting := displayName
+	if i := strings.IndexAny(nameForSplitting, "<("); i != -1 {
+		nameForSplitting = nameForSplitting[:i]
+	}
+
+	segments := strings.Split(nameForSplitting, separator)
+	namespaceSegments := len(segments) - 1
+	if namespaceSegments <= 0 {
+		return "", displayName
+	}
+	if namespaceSegments > groupingLevel {
+		namespaceSegments = groupingLevel
+	}
+
+	namespace = strings.Join(segments[:namespaceSegments], separator)
+	return namespace, strings.TrimPrefix(displayName, namespace+separator)
+}
+
+// recalculateAssemblyStats sums the class statistics of a (pseudo-)assembly.
+// Total lines are counted once per unique file, whose paths are compared by keyOf.
+func recalculateAssemblyStats(assembly *model.Assembly, keyOf func(string) string) {
+	var linesCovered, linesValid, branchesCovered, branchesValid int
+	hasBranchData := false
+
+	for _, cls := range assembly.Classes {
+		linesCovered += cls.LinesCovered
+		linesValid += cls.LinesValid
+		if cls.BranchesCovered != nil && cls.BranchesValid != nil {
+			hasBranchData = true
+			branchesCovered += *cls.BranchesCovered
+			branchesValid += *cls.BranchesValid
+		}
+	}
+
+	assembly.LinesCovered = linesCovered
+	assembly.LinesValid = linesValid
+	assembly.TotalLines = uniqueFileTotalLines(assembly.Classes, keyOf)
+	assembly.BranchesCovered = nil
+	assembly.BranchesValid = nil
+	if hasBranchData {
+		assembly.BranchesCovered = &branchesCovered
+		assembly.BranchesValid = &branchesValid
+	}
+}
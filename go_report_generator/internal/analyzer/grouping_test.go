@@ -0,0 +1,118 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(i int) *int { return &i }
+
+func groupedNames(summary *model.SummaryResult) map[string][]string {
+	names := make(map[string][]string)
+	for _, asm := range summary.Assemblies {
+		for _, cls := range asm.Classes {
+			names[asm.Name] = append(names[asm.Name], cls.DisplayName)
+		}
+	}
+	return names
+}
+
+func TestApplyAssemblyGrouping_DotNetNamespaces(t *testing.T) {
+	newSummary := func() *model.SummaryResult {
+		return &model.SummaryResult{
+			Assemblies: []model.Assembly{{
+				Name: "MyApp",
+				Classes: []model.Class{
+					{Name: "MyApp.Services.Billing.Invoice", DisplayName: "MyApp.Services.Billing.Invoice", LinesCovered: 5, LinesValid: 10,
+						BranchesCovered: intPtr(1), BranchesValid: intPtr(2),
+						Files: []model.CodeFile{{Path: "Invoice.cs", TotalLines: 40}}},
+					{Name: "MyApp.Services.Mailer", DisplayName: "MyApp.Services.Mailer", LinesCovered: 2, LinesValid: 4,
+						Files: []model.CodeFile{{Path: "Mailer.cs", TotalLines: 20}}},
+					{Name: "MyApp.Program", DisplayName: "MyApp.Program", LinesCovered: 1, LinesValid: 1},
+					{Name: "Startup", DisplayName: "Startup", LinesCovered: 0, LinesValid: 3},
+					{Name: "MyApp.Cache`1", DisplayName: "MyApp.Cache<System.String>", LinesCovered: 1, LinesValid: 2},
+				},
+			}},
+		}
+	}
+
+	t.Run("LevelZero_KeepsAssemblies", func(t *testing.T) {
+		// Arrange
+		summary := newSummary()
+
+		// Act
+		analyzer.ApplyAssemblyGrouping(summary, 0, utils.PathKey)
+
+		// Assert
+		require.Len(t, summary.Assemblies, 1)
+		assert.Equal(t, "MyApp.Services.Mailer", summary.Assemblies[0].Classes[1].DisplayName)
+	})
+
+	t.Run("LevelOne_GroupsByTopLevelNamespace", func(t *testing.T) {
+		// Arrange
+		summary := newSummary()
+
+		// Act
+		analyzer.ApplyAssemblyGrouping(summary, 1, utils.PathKey)
+
+		// Assert
+		assert.Equal(t, map[string][]string{
+			"MyApp":         {"Startup"},
+			"MyApp - MyApp": {"Services.Billing.Invoice", "Services.Mailer", "Program", "Cache<System.String>"},
+		}, groupedNames(summary))
+	})
+
+	t.Run("LevelTwo_GroupsAndRecalculatesStats", func(t *testing.T) {
+		// Arrange
+		summary := newSummary()
+
+		// Act
+		analyzer.ApplyAssemblyGrouping(summary, 2, utils.PathKey)
+
+		// Assert
+		assert.Equal(t, map[string][]string{
+			"MyApp":                  {"Startup"},
+			"MyApp - MyApp":          {"Program", "Cache<System.String>"},
+			"MyApp - MyApp.Services": {"Billing.Invoice", "Mailer"},
+		}, groupedNames(summary))
+
+		require.Len(t, summary.Assemblies, 3)
+		services := summary.Assemblies[2]
+		assert.Equal(t, "MyApp - MyApp.Services", services.Name, "groups are sorted by name")
+		assert.Equal(t, 7, services.LinesCovered)
+		assert.Equal(t, 14, services.LinesValid)
+		assert.Equal(t, 60, services.TotalLines)
+		require.NotNil(t, services.BranchesCovered)
+		assert.Equal(t, 1, *services.BranchesCovered)
+		assert.Nil(t, summary.Assemblies[1].BranchesCovered)
+	})
+}
+
+func TestApplyAssemblyGrouping_GoPackagePaths(t *testing.T) {
+	// Arrange
+	summary := &model.SummaryResult{
+		Assemblies: []model.Assembly{{
+			Name: "example.com/mod",
+			Classes: []model.Class{
+				{Name: "example.com/mod/internal/parsers/cobertura", DisplayName: "internal/parsers/cobertura"},
+				{Name: "example.com/mod/internal/utils", DisplayName: "internal/utils"},
+				{Name: "example.com/mod/cmd", DisplayName: "cmd"},
+				{Name: "example.com/mod", DisplayName: "(root)"},
+			},
+		}},
+	}
+
+	// Act
+	analyzer.ApplyAssemblyGrouping(summary, 1, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, map[string][]string{
+		"example.com/mod":            {"cmd", "(root)"},
+		"example.com/mod - internal": {"parsers/cobertura", "utils"},
+	}, groupedNames(summary))
+}
@@ -0,0 +1,298 @@
+package analyzer
+
+import (
+	"math"
+	"slices"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+// FullMethodCoverage defines when a method counts as fully covered, see
+// settings.Settings.FullMethodCoverageMinimumLineRate and FullMethodCoverageRequiresBranches.
+type FullMethodCoverage struct {
+	// MinimumLineRate is the share of the coverable lines (0 to 1, exclusive of 0) that
+	// must be covered.
+	MinimumLineRate float64
+	// RequiresBranches makes a method with branches only fully covered if all of them are.
+	RequiresBranches bool
+}
+
+// DefaultFullMethodCoverage requires all coverable lines of a method to be covered.
+var DefaultFullMethodCoverage = FullMethodCoverage{MinimumLineRate: 1}
+
+// NewFullMethodCoverage returns the definition configured in the settings. A minimum line
+// rate outside of (0, 1], e.g. of settings that were not created by settings.NewSettings,
+// requires all lines to be covered.
+func NewFullMethodCoverage(s *settings.Settings) FullMethodCoverage {
+	d := FullMethodCoverage{
+		MinimumLineRate:  s.FullMethodCoverageMinimumLineRate,
+		RequiresBranches: s.FullMethodCoverageRequiresBranches,
+	}
+	if !(d.MinimumLineRate > 0 && d.MinimumLineRate <= 1) {
+		d.MinimumLineRate = DefaultFullMethodCoverage.MinimumLineRate
+	}
+	return d
+}
+
+// CountMethodCoverage returns the number of covered methods, which have a covered line, and
+// of fully covered methods. A method without coverable lines is neither, but still counts
+// towards the total number of methods, like in the reports of the C# ReportGenerator.
+func (d FullMethodCoverage) CountMethodCoverage(methods []model.Method) (covered, fullyCovered int) {
+	for i := range methods {
+		isCovered, isFullyCovered := d.classify(&methods[i])
+		if isCovered {
+			covered++
+		}
+		if isFullyCovered {
+			fullyCovered++
+		}
+	}
+	return covered, fullyCovered
+}
+
+// classify reports whether the method is covered and fully covered.
+func (d FullMethodCoverage) classify(method *model.Method) (covered, fullyCovered bool) {
+	lineRate := methodLineRate(method)
+	if lineRate <= 0 {
+		return false, false
+	}
+	if lineRate < d.MinimumLineRate {
+		return true, false
+	}
+	if d.RequiresBranches {
+		if branchRate, ok := methodBranchRate(method); ok && branchRate < 1 {
+			return true, false
+		}
+	}
+	return true, true
+}
+
+// methodLineRate returns the covered share of the coverable lines of the method. It is
+// counted from the lines of the method if it has any and taken from its LineRate
+// otherwise, e.g. for Go functions, whose lines are not kept. A method without coverable
+// lines has a rate of 0.
+func methodLineRate(method *model.Method) float64 {
+	if len(method.Lines) > 0 {
+		covered, coverable := model.CountLines(method.Lines)
+		if coverable == 0 {
+			return 0
+		}
+		return float64(covered) / float64(coverable)
+	}
+	if math.IsNaN(method.LineRate) {
+		return 0
+	}
+	return method.LineRate
+}
+
+// methodBranchRate returns the covered share of the branches of the method, counted from
+// its lines or taken from its BranchRate, and false if the method has no branches.
+func methodBranchRate(method *model.Method) (float64, bool) {
+	covered, valid := 0, 0
+	for _, line := range method.Lines {
+		covered += line.CoveredBranches
+		valid += line.TotalBranches
+	}
+	if valid > 0 {
+		return float64(covered) / float64(valid), true
+	}
+	if len(method.Lines) == 0 && method.BranchRate != nil && !math.IsNaN(*method.BranchRate) {
+		return *method.BranchRate, true
+	}
+	return 0, false
+}
+
+// RecomputeMethodCoverage derives the line and branch rates of the methods from the
+// merged lines of their file within FirstLine..LastLine, so that the methods agree with
+// the lines after fragments of a class from several reports were merged, and recounts
+// the covered and fully covered methods of the changed classes with d. The coverage
+// metrics and the CrapScore of the methods, evaluated against thresholds, the metrics of
+// their class, aggregated with registry, and the coverage quotas of their code elements
+// are updated as well. The line and branch totals of the changed classes, their
+// assemblies and the summary are recounted from the merged lines, so that a class and
+// its methods have the same coverage; keyOf returns the key by which file paths are
+// compared, see utils.PathKeyFunc.
+//
+// Methods without lines of their own, such as Go functions, whose rates are counted in
+// statements, keep the rates of the parser, as do methods whose line range or file is
+// unknown. The number of methods whose rates changed is returned.
+func (d FullMethodCoverage) RecomputeMethodCoverage(summary *model.SummaryResult, registry model.MetricRegistry, thresholds map[string]model.MetricThreshold, keyOf func(string) string) int {
+	if summary == nil {
+		return 0
+	}
+	changed := 0
+	for i := range summary.Assemblies {
+		assembly := &summary.Assemblies[i]
+		assemblyChanged := false
+		for j := range assembly.Classes {
+			class := &assembly.Classes[j]
+			n := recomputeClassMethods(class, thresholds)
+			if n > 0 {
+				class.CoveredMethods, class.FullyCoveredMethods = d.CountMethodCoverage(class.Methods)
+				class.Metrics = mergeClassMetrics(registry, class.Methods, class)
+				recountClassTotals(class, keyOf)
+				assemblyChanged = true
+			}
+			changed += n
+		}
+		if assemblyChanged {
+			sumAssemblyTotals(assembly, keyOf)
+		}
+	}
+	if changed > 0 {
+		sumSummaryTotals(summary, keyOf)
+	}
+	return changed
+}
+
+// recountClassTotals recounts the covered and coverable lines of the files of the class
+// that have line data, and sets the class totals to the sums over its files. The files
+// must have been copied, see recomputeClassMethods.
+func recountClassTotals(class *model.Class, keyOf func(string) string) {
+	for i := range class.Files {
+		if file := &class.Files[i]; len(file.Lines) > 0 {
+			file.CoveredLines, file.CoverableLines = model.CountLines(file.Lines)
+		}
+	}
+	sumClassTotals(class, keyOf)
+}
+
+// recomputeClassMethods recomputes the rates of the methods of the class, see
+// RecomputeMethodCoverage. The methods, files and code elements are copied before they
+// are changed, as they may be shared with the parser results.
+func recomputeClassMethods(class *model.Class, thresholds map[string]model.MetricThreshold) int {
+	changed := 0
+	copied := false
+	copiedElements := make(map[int]bool)
+	for i := range class.Methods {
+		method := &class.Methods[i]
+		if len(method.Lines) == 0 || method.FirstLine <= 0 || method.LastLine < method.FirstLine {
+			continue
+		}
+		fileIndex := methodFileIndex(class, method)
+		if fileIndex < 0 {
+			continue
+		}
+		lines := linesInRange(class.Files[fileIndex].Lines, method.FirstLine, method.LastLine)
+		if len(lines) == 0 {
+			continue
+		}
+		lineRate, branchRate := ratesOfLines(lines, method.BranchRate != nil)
+		if lineRate == method.LineRate && equalRates(branchRate, method.BranchRate) {
+			continue
+		}
+
+		if !copied {
+			class.Methods = slices.Clone(class.Methods)
+			class.Files = slices.Clone(class.Files)
+			method = &class.Methods[i]
+			copied = true
+		}
+		method.Lines, method.LineRate, method.BranchRate = lines, lineRate, branchRate
+		updateMethodMetrics(method, thresholds)
+		file := &class.Files[fileIndex]
+		if !copiedElements[fileIndex] {
+			file.CodeElements = slices.Clone(file.CodeElements)
+			copiedElements[fileIndex] = true
+		}
+		if element := model.FindCodeElement(file.CodeElements, method); element != nil && element.CoverageQuota != nil {
+			quota := lineRate * 100
+			element.CoverageQuota = &quota
+		}
+		changed++
+	}
+	return changed
+}
+
+// updateMethodMetrics sets the coverage metrics and the CrapScore of the method to its
+// recomputed rates. The CrapScore is calculated from the branch rate if the method has
+// one, like the parsers do. The metrics are copied, as they may be shared with the parser
+// results.
+func updateMethodMetrics(method *model.Method, thresholds map[string]model.MetricThreshold) {
+	coverage := method.LineRate
+	if method.BranchRate != nil {
+		coverage = *method.BranchRate
+	}
+	method.MethodMetrics = slices.Clone(method.MethodMetrics)
+	for i := range method.MethodMetrics {
+		metrics := slices.Clone(method.MethodMetrics[i].Metrics)
+		for j := range metrics {
+			metric := &metrics[j]
+			switch metric.Name {
+			case "Line coverage":
+				metric.Value = method.LineRate * 100
+			case "Branch coverage":
+				if method.BranchRate != nil {
+					metric.Value = *method.BranchRate * 100
+				}
+			case "CrapScore":
+				if score := model.CrapScore(coverage, method.Complexity); !math.IsNaN(score) {
+					metric.Value = score
+					metric.Status = thresholds["CrapScore"].Evaluate(score)
+				}
+			}
+		}
+		method.MethodMetrics[i].Metrics = metrics
+	}
+}
+
+// methodFileIndex returns the index of the file of the class that defines the method: the
+// file with its code element or, if the class has a single file, that one. It returns -1
+// if the file is unknown.
+func methodFileIndex(class *model.Class, method *model.Method) int {
+	for i := range class.Files {
+		if model.FindCodeElement(class.Files[i].CodeElements, method) != nil {
+			return i
+		}
+	}
+	if len(class.Files) == 1 {
+		return 0
+	}
+	return -1
+}
+
+// linesInRange returns copies of the coverable lines numbered first to last, without
+// their source code.
+func linesInRange(lines []model.Line, first, last int) []model.Line {
+	var result []model.Line
+	for _, line := range lines {
+		if line.Number >= first && line.Number <= last && line.Hits >= 0 {
+			line.Content = ""
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// ratesOfLines returns the covered share of the lines and of their branches. The branch
+// rate is nil if the method had none before and the lines have no branches, and 1 for
+// lines without branches otherwise, like the Cobertura parser rates such methods.
+func ratesOfLines(lines []model.Line, hadBranchRate bool) (float64, *float64) {
+	covered, coverable := model.CountLines(lines)
+	lineRate := 0.0
+	if coverable > 0 {
+		lineRate = float64(covered) / float64(coverable)
+	}
+	branchesCovered, branchesValid := 0, 0
+	for _, line := range lines {
+		branchesCovered += line.CoveredBranches
+		branchesValid += line.TotalBranches
+	}
+	switch {
+	case branchesValid > 0:
+		rate := float64(branchesCovered) / float64(branchesValid)
+		return lineRate, &rate
+	case hadBranchRate:
+		rate := 1.0
+		return lineRate, &rate
+	}
+	return lineRate, nil
+}
+
+func equalRates(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
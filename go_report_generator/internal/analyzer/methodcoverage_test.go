@@ -0,0 +1,192 @@
+package analyzer_test
+
+import (
+	"log/slog"
+	"math"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// methodWithLines returns a method with a line per entry of hits (-1: not coverable), the
+// first of which has the given covered and total branches.
+func methodWithLines(name string, coveredBranches, totalBranches int, hits ...int) model.Method {
+	method := model.Method{Name: name}
+	for i, h := range hits {
+		method.Lines = append(method.Lines, model.Line{Number: i + 1, Hits: h})
+	}
+	if totalBranches > 0 {
+		method.Lines[0].IsBranchPoint = true
+		method.Lines[0].CoveredBranches, method.Lines[0].TotalBranches = coveredBranches, totalBranches
+	}
+	return method
+}
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestFullMethodCoverage_CountMethodCoverage(t *testing.T) {
+	methods := []model.Method{
+		methodWithLines("AllLines", 0, 0, 1, 2, 3, 4),
+		methodWithLines("ThreeOfFourLines", 0, 0, 1, 1, 1, 0),
+		methodWithLines("AllLinesHalfOfBranches", 1, 2, 1, 1),
+		methodWithLines("Uncovered", 0, 0, 0, 0),
+		methodWithLines("NoCoverableLines", 0, 0, -1, -1),
+		{Name: "NoLines"},
+		{Name: "GoFullyCovered", LineRate: 1},
+		{Name: "GoPartiallyCovered", LineRate: 0.8, BranchRate: floatPtr(0.5)},
+		{Name: "NoLineRate", LineRate: math.NaN()},
+	}
+
+	testCases := []struct {
+		name         string
+		definition   analyzer.FullMethodCoverage
+		fullyCovered int
+	}{
+		// AllLines, AllLinesHalfOfBranches and GoFullyCovered
+		{name: "Default", definition: analyzer.DefaultFullMethodCoverage, fullyCovered: 3},
+		// ... and ThreeOfFourLines and GoPartiallyCovered
+		{name: "MinimumLineRate", definition: analyzer.FullMethodCoverage{MinimumLineRate: 0.75}, fullyCovered: 5},
+		// AllLines and GoFullyCovered
+		{name: "RequiresBranches", definition: analyzer.FullMethodCoverage{MinimumLineRate: 1, RequiresBranches: true}, fullyCovered: 2},
+		// ... and ThreeOfFourLines
+		{name: "MinimumLineRateRequiresBranches", definition: analyzer.FullMethodCoverage{MinimumLineRate: 0.75, RequiresBranches: true}, fullyCovered: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			covered, fullyCovered := tc.definition.CountMethodCoverage(methods)
+
+			assert.Equal(t, 5, covered, "methods without coverable lines or line rate are not covered")
+			assert.Equal(t, tc.fullyCovered, fullyCovered)
+		})
+	}
+}
+
+func TestNewFullMethodCoverage(t *testing.T) {
+	appSettings := settings.NewSettings()
+	assert.Equal(t, analyzer.DefaultFullMethodCoverage, analyzer.NewFullMethodCoverage(appSettings))
+
+	appSettings.FullMethodCoverageMinimumLineRate = 0.95
+	appSettings.FullMethodCoverageRequiresBranches = true
+	assert.Equal(t, analyzer.FullMethodCoverage{MinimumLineRate: 0.95, RequiresBranches: true}, analyzer.NewFullMethodCoverage(appSettings))
+
+	assert.Equal(t, analyzer.DefaultFullMethodCoverage, analyzer.NewFullMethodCoverage(&settings.Settings{}), "an unset minimum line rate requires all lines")
+}
+
+// fragmentResult returns a report of the method Check (lines 3 to 4) of the class Cart,
+// in which only the branch with the identifier visited of the two on line 4 was visited.
+func fragmentResult(visited string) *parsers.ParserResult {
+	branches := []model.BranchCoverageDetail{{Identifier: "0"}, {Identifier: "1"}}
+	for i := range branches {
+		if branches[i].Identifier == visited {
+			branches[i].Visits = 1
+		}
+	}
+	lines := []model.Line{
+		{Number: 3, Hits: 1, LineVisitStatus: model.Covered},
+		{Number: 4, Hits: 1, IsBranchPoint: true, CoveredBranches: 1, TotalBranches: 2, Branch: branches, LineVisitStatus: model.PartiallyCovered},
+	}
+	method := model.Method{Name: "Check", Signature: "()", DisplayName: "Check()", FirstLine: 3, LastLine: 4, LineRate: 1, BranchRate: floatPtr(0.5), Lines: lines}
+	return &parsers.ParserResult{
+		ParserName: "Cobertura",
+		Assemblies: []model.Assembly{{
+			Name: "Shop",
+			Classes: []model.Class{{
+				Name:    "Shop.Cart",
+				Methods: []model.Method{method},
+				Files: []model.CodeFile{{
+					Path:         "/src/Cart.cs",
+					Lines:        lines,
+					CodeElements: []model.CodeElement{{FullName: "Check()", FirstLine: 3, LastLine: 4, RawKey: "Check()", CoverageQuota: floatPtr(100)}},
+				}},
+				TotalMethods:   1,
+				CoveredMethods: 1,
+			}},
+		}},
+	}
+}
+
+func TestRecomputeMethodCoverage_FragmentsCoveringDifferentBranches(t *testing.T) {
+	// Arrange
+	results := []*parsers.ParserResult{fragmentResult("0"), fragmentResult("1")}
+	summary, err := analyzer.MergeParserResults(results, &mockMergerConfig{logger: slog.Default()})
+	require.NoError(t, err)
+	definition := analyzer.FullMethodCoverage{MinimumLineRate: 1, RequiresBranches: true}
+
+	// Act
+	changed := definition.RecomputeMethodCoverage(summary, model.DefaultMetricRegistry(), nil, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, 1, changed)
+	class := summary.Assemblies[0].Classes[0]
+	line := class.Files[0].Lines[1]
+	assert.Equal(t, 2, line.CoveredBranches, "the branches covered by the two reports add up")
+	assert.Equal(t, model.Covered, line.LineVisitStatus)
+	method := class.Methods[0]
+	assert.Equal(t, 1.0, method.LineRate)
+	require.NotNil(t, method.BranchRate)
+	assert.Equal(t, 1.0, *method.BranchRate)
+	assert.Equal(t, 1, class.FullyCoveredMethods)
+	assert.Equal(t, 0.5, *results[0].Assemblies[0].Classes[0].Methods[0].BranchRate, "the parser results are not changed")
+}
+
+func TestRecomputeMethodCoverage_RecountsClassTotals(t *testing.T) {
+	// Arrange: the first report has the file without its lines, so merging cannot recount
+	// the totals of the class and sums them. Line 3 is not covered in the second report.
+	results := []*parsers.ParserResult{fragmentResult("0"), fragmentResult("1")}
+	first := &results[0].Assemblies[0].Classes[0]
+	first.LinesCovered, first.LinesValid = 2, 2
+	first.Files[0].Lines = nil
+	second := &results[1].Assemblies[0].Classes[0]
+	second.LinesCovered, second.LinesValid = 1, 2
+	second.Files[0].Lines[0].Hits, second.Files[0].Lines[0].LineVisitStatus = 0, model.NotCovered
+	summary, err := analyzer.MergeParserResults(results, &mockMergerConfig{logger: slog.Default()})
+	require.NoError(t, err)
+
+	// Act
+	changed := analyzer.DefaultFullMethodCoverage.RecomputeMethodCoverage(summary, model.DefaultMetricRegistry(), nil, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, 1, changed)
+	class := summary.Assemblies[0].Classes[0]
+	method := class.Methods[0]
+	assert.Equal(t, 0.5, method.LineRate)
+	require.Equal(t, 2, class.LinesValid)
+	assert.Equal(t, method.LineRate, float64(class.LinesCovered)/float64(class.LinesValid), "the class quota matches the method rate")
+	assert.Equal(t, 2, summary.Assemblies[0].LinesValid)
+	assert.Equal(t, 1, summary.LinesCovered)
+	assert.Equal(t, 2, summary.LinesValid)
+}
+
+func TestRecomputeMethodCoverage_KeepsParserRatesWithoutLineRange(t *testing.T) {
+	lines := []model.Line{{Number: 3, Hits: 0}, {Number: 4, Hits: 1}}
+	cases := []struct {
+		name   string
+		method model.Method
+		files  []model.CodeFile
+	}{
+		{"method without lines", model.Method{Name: "GoFunc", FirstLine: 3, LastLine: 4, LineRate: 0.75}, []model.CodeFile{{Path: "a.go", Lines: lines}}},
+		{"unknown line range", model.Method{Name: "M", LineRate: 0.75, Lines: lines[1:]}, []model.CodeFile{{Path: "a.cs", Lines: lines}}},
+		{"unknown file", model.Method{Name: "M", FirstLine: 3, LastLine: 4, LineRate: 0.75, Lines: lines[1:]}, []model.CodeFile{{Path: "a.cs", Lines: lines}, {Path: "b.cs", Lines: lines}}},
+		{"no lines in range", model.Method{Name: "M", FirstLine: 7, LastLine: 9, LineRate: 0.75, Lines: lines[1:]}, []model.CodeFile{{Path: "a.cs", Lines: lines}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := &model.SummaryResult{Assemblies: []model.Assembly{{
+				Name:    "A",
+				Classes: []model.Class{{Name: "C", Methods: []model.Method{tc.method}, Files: tc.files}},
+			}}}
+
+			changed := analyzer.DefaultFullMethodCoverage.RecomputeMethodCoverage(summary, model.DefaultMetricRegistry(), nil, utils.PathKey)
+
+			assert.Equal(t, 0, changed)
+			assert.Equal(t, 0.75, summary.Assemblies[0].Classes[0].Methods[0].LineRate)
+		})
+	}
+}
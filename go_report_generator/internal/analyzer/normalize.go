@@ -0,0 +1,208 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// NormalizeSummary prepares a summary that was built in memory instead of by a parser
+// for the reporters. It rejects inconsistent line data and fixes up what the reporters
+// rely on:
+//
+//   - Lines are sorted by number and their LineVisitStatus is derived from the hits
+//     (-1: not coverable) and branches; a line with branches is a branch point.
+//   - The covered and coverable lines of files with line data are counted; files
+//     without line data keep their counts.
+//   - Classes get their DisplayName, line, branch and method totals and the metrics
+//     aggregated from their methods with the metrics of the settings (see
+//     model.MetricRegistry.AggregateMethodMetrics) unless set. Fully covered methods
+//     are counted as configured in the settings, see NewFullMethodCoverage.
+//   - Assemblies and the summary get the totals of their classes; branch totals are
+//     only set (non-nil) if a class has branch points.
+//   - Assemblies, classes and files are sorted like MergeParserResults sorts them.
+//
+// All problems are reported in one error, each naming the assembly, class, file and
+// line it was found in.
+func NormalizeSummary(summary *model.SummaryResult, s *settings.Settings) error {
+	if summary == nil {
+		return errors.New("summary is nil")
+	}
+	pathCase, err := utils.ParsePathCaseMode(s.PathCase)
+	if err != nil {
+		return err
+	}
+	keyOf := utils.PathKeyFunc(pathCase, false)
+
+	var errs []error
+	for i := range summary.Assemblies {
+		assembly := &summary.Assemblies[i]
+		if assembly.Name == "" {
+			errs = append(errs, fmt.Errorf("assembly #%d: the name is empty", i+1))
+		}
+		for j := range assembly.Classes {
+			errs = append(errs, normalizeClass(assembly.Name, &assembly.Classes[j], s, keyOf)...)
+		}
+		if len(assembly.Classes) > 0 {
+			sumAssemblyTotals(assembly, keyOf)
+		}
+		sortAssemblyContents(assembly)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid coverage model: %w", err)
+	}
+
+	sort.SliceStable(summary.Assemblies, func(i, j int) bool {
+		return summary.Assemblies[i].Name < summary.Assemblies[j].Name
+	})
+	if len(summary.Assemblies) > 0 {
+		sumSummaryTotals(summary, keyOf)
+	}
+	for _, assembly := range summary.Assemblies {
+		for _, class := range assembly.Classes {
+			if len(class.Methods) > 0 {
+				summary.MethodCoverageAvailable = true
+			}
+		}
+	}
+	if summary.ParserName == "" {
+		summary.ParserName = "Unknown"
+	}
+	return nil
+}
+
+// normalizeClass validates and completes a class, see NormalizeSummary.
+func normalizeClass(assemblyName string, class *model.Class, s *settings.Settings, keyOf func(string) string) []error {
+	var errs []error
+	if class.Name == "" {
+		errs = append(errs, fmt.Errorf("assembly %q: a class name is empty", assemblyName))
+	}
+	if class.DisplayName == "" {
+		class.DisplayName = class.Name
+	}
+
+	for i := range class.Files {
+		file := &class.Files[i]
+		location := fmt.Sprintf("assembly %q, class %q, file %q", assemblyName, class.Name, file.Path)
+		if file.Path == "" {
+			errs = append(errs, fmt.Errorf("assembly %q, class %q: the path of file #%d is empty", assemblyName, class.Name, i+1))
+		}
+		errs = append(errs, normalizeLines(location, file)...)
+	}
+	for name, value := range class.Metrics {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			errs = append(errs, fmt.Errorf("assembly %q, class %q: metric %q is not a finite number", assemblyName, class.Name, name))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if len(class.Files) > 0 {
+		sumClassTotals(class, keyOf)
+	}
+	if class.TotalMethods == 0 && len(class.Methods) > 0 {
+		class.TotalMethods = len(class.Methods)
+		class.CoveredMethods, class.FullyCoveredMethods = NewFullMethodCoverage(s).CountMethodCoverage(class.Methods)
+	}
+	if class.Metrics == nil {
+		class.Metrics = s.Metrics.AggregateMethodMetrics(class.Methods)
+	}
+	return nil
+}
+
+// normalizeLines sorts the lines of the file, derives their visit status and counts
+// the covered and coverable lines.
+func normalizeLines(location string, file *model.CodeFile) []error {
+	if len(file.Lines) == 0 {
+		return nil
+	}
+
+	var errs []error
+	slices.SortStableFunc(file.Lines, func(a, b model.Line) int { return a.Number - b.Number })
+	for i := range file.Lines {
+		line := &file.Lines[i]
+		switch {
+		case line.Number < 1:
+			errs = append(errs, fmt.Errorf("%s: line number %d is invalid, line numbers start at 1", location, line.Number))
+			continue
+		case i > 0 && file.Lines[i-1].Number == line.Number:
+			errs = append(errs, fmt.Errorf("%s: line %d is listed more than once", location, line.Number))
+			continue
+		case line.Hits < -1:
+			errs = append(errs, fmt.Errorf("%s: line %d has %d hits, use -1 for lines that are not coverable", location, line.Number, line.Hits))
+			continue
+		case line.CoveredBranches < 0 || line.CoveredBranches > line.TotalBranches:
+			errs = append(errs, fmt.Errorf("%s: line %d has %d of %d branches covered", location, line.Number, line.CoveredBranches, line.TotalBranches))
+			continue
+		}
+		if line.TotalBranches > 0 {
+			line.IsBranchPoint = true
+		}
+		line.LineVisitStatus = line.ComputeVisitStatus()
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	file.CoveredLines, file.CoverableLines = model.CountLines(file.Lines)
+	file.TotalLines = max(file.TotalLines, file.Lines[len(file.Lines)-1].Number)
+	return nil
+}
+
+// sumClassTotals sets the line and branch totals of a class to the sums over its files,
+// whose paths are compared by keyOf.
+func sumClassTotals(class *model.Class, keyOf func(string) string) {
+	class.LinesCovered, class.LinesValid = 0, 0
+	var branchesCovered, branchesValid int
+	hasBranchData := false
+	for _, file := range class.Files {
+		class.LinesCovered += file.CoveredLines
+		class.LinesValid += file.CoverableLines
+		for _, line := range file.Lines {
+			if line.IsBranchPoint {
+				hasBranchData = true
+				branchesCovered += line.CoveredBranches
+				branchesValid += line.TotalBranches
+			}
+		}
+	}
+	if hasBranchData {
+		class.BranchesCovered, class.BranchesValid = &branchesCovered, &branchesValid
+	}
+	class.TotalLines = uniqueFileTotalLines([]model.Class{*class}, keyOf)
+}
+
+// sumAssemblyTotals sets the line and branch totals of the assembly from its classes.
+func sumAssemblyTotals(assembly *model.Assembly, keyOf func(string) string) {
+	assembly.LinesCovered, assembly.LinesValid = 0, 0
+	assembly.BranchesCovered, assembly.BranchesValid = nil, nil
+	for _, class := range assembly.Classes {
+		assembly.LinesCovered += class.LinesCovered
+		assembly.LinesValid += class.LinesValid
+		if class.BranchesCovered != nil && class.BranchesValid != nil {
+			if assembly.BranchesCovered == nil {
+				assembly.BranchesCovered, assembly.BranchesValid = new(int), new(int)
+			}
+			*assembly.BranchesCovered += *class.BranchesCovered
+			*assembly.BranchesValid += *class.BranchesValid
+		}
+	}
+	assembly.TotalLines = uniqueFileTotalLines(assembly.Classes, keyOf)
+}
+
+// sumSummaryTotals sets the line and branch totals of the summary from its assemblies.
+func sumSummaryTotals(summary *model.SummaryResult, keyOf func(string) string) {
+	linesCovered, linesValid, totalLines, branchesCovered, branchesValid, hasBranchData := computeGlobalStats(summary.Assemblies, keyOf)
+	summary.LinesCovered, summary.LinesValid, summary.TotalLines = linesCovered, linesValid, totalLines
+	summary.BranchesCovered, summary.BranchesValid = nil, nil
+	if hasBranchData {
+		summary.BranchesCovered, summary.BranchesValid = &branchesCovered, &branchesValid
+	}
+}
@@ -0,0 +1,123 @@
+package analyzer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSummary_CompletesHandBuiltModel(t *testing.T) {
+	summary := &model.SummaryResult{
+		Assemblies: []model.Assembly{
+			{Name: "Zoo", Classes: []model.Class{{Name: "Zoo.Keeper", Files: []model.CodeFile{{
+				Path: "Keeper.cs", TotalLines: 3,
+				Lines: []model.Line{{Number: 8, Hits: 0}, {Number: 2, Hits: 5}, {Number: 4, Hits: 1, TotalBranches: 4, CoveredBranches: 1}, {Number: 1, Hits: -1}},
+			}}}}},
+			{Name: "Api", Classes: []model.Class{{
+				Name:  "Api.Handler",
+				Files: []model.CodeFile{{Path: "Handler.cs", CoveredLines: 2, CoverableLines: 4, TotalLines: 30}},
+				Methods: []model.Method{
+					{Name: "Serve", Lines: []model.Line{model.NewLine(3, 1), model.NewLine(4, 1)}, MethodMetrics: []model.MethodMetric{{Metrics: []model.Metric{{Name: "Cyclomatic complexity", Value: 2.0}}}}},
+					{Name: "Close", Lines: []model.Line{model.NewLine(9, 1), model.NewLine(10, 0)}, MethodMetrics: []model.MethodMetric{{Metrics: []model.Metric{{Name: "Cyclomatic complexity", Value: 3.0}}}}},
+				},
+			}}},
+		},
+	}
+
+	require.NoError(t, analyzer.NormalizeSummary(summary, settings.NewSettings()))
+
+	require.Len(t, summary.Assemblies, 2)
+	api, zoo := summary.Assemblies[0], summary.Assemblies[1]
+	assert.Equal(t, "Api", api.Name, "assemblies are sorted by name")
+
+	keeperFile := zoo.Classes[0].Files[0]
+	var numbers []int
+	for _, line := range keeperFile.Lines {
+		numbers = append(numbers, line.Number)
+	}
+	assert.Equal(t, []int{1, 2, 4, 8}, numbers)
+	assert.Equal(t, []model.LineVisitStatus{model.NotCoverable, model.Covered, model.PartiallyCovered, model.NotCovered},
+		[]model.LineVisitStatus{keeperFile.Lines[0].LineVisitStatus, keeperFile.Lines[1].LineVisitStatus, keeperFile.Lines[2].LineVisitStatus, keeperFile.Lines[3].LineVisitStatus})
+	assert.True(t, keeperFile.Lines[2].IsBranchPoint)
+	assert.Equal(t, 2, keeperFile.CoveredLines)
+	assert.Equal(t, 3, keeperFile.CoverableLines)
+	assert.Equal(t, 8, keeperFile.TotalLines, "the total lines include the last line with data")
+
+	keeper := zoo.Classes[0]
+	assert.Equal(t, "Zoo.Keeper", keeper.DisplayName)
+	require.NotNil(t, keeper.BranchesValid)
+	assert.Equal(t, 1, *keeper.BranchesCovered)
+	assert.Equal(t, 4, *keeper.BranchesValid)
+
+	handler := api.Classes[0]
+	assert.Equal(t, 2, handler.LinesCovered, "files without line data keep their counts")
+	assert.Equal(t, 4, handler.LinesValid)
+	assert.Nil(t, handler.BranchesValid)
+	assert.Nil(t, api.BranchesValid, "an assembly without branch points has no branch totals")
+	assert.Equal(t, 2, handler.TotalMethods)
+	assert.Equal(t, 2, handler.CoveredMethods)
+	assert.Equal(t, 1, handler.FullyCoveredMethods)
+	assert.Equal(t, map[string]float64{"Cyclomatic complexity": 5}, handler.Metrics)
+
+	assert.Equal(t, 4, summary.LinesCovered)
+	assert.Equal(t, 7, summary.LinesValid)
+	assert.Equal(t, 38, summary.TotalLines)
+	require.NotNil(t, summary.BranchesValid)
+	assert.Equal(t, 4, *summary.BranchesValid)
+	assert.True(t, summary.MethodCoverageAvailable)
+	assert.Equal(t, "Unknown", summary.ParserName)
+}
+
+func TestNormalizeSummary_CountsMethodsAsConfigured(t *testing.T) {
+	summary := &model.SummaryResult{Assemblies: []model.Assembly{{Name: "Api", Classes: []model.Class{{
+		Name: "Api.Handler",
+		Methods: []model.Method{
+			{Name: "Serve", Lines: []model.Line{model.NewLine(3, 1), model.NewLine(4, 1)}},
+			{Name: "Close", Lines: []model.Line{model.NewLine(9, 1), model.NewLine(10, 0)}},
+		},
+	}}}}}
+	appSettings := settings.NewSettings()
+	appSettings.FullMethodCoverageMinimumLineRate = 0.5
+
+	require.NoError(t, analyzer.NormalizeSummary(summary, appSettings))
+
+	assert.Equal(t, 2, summary.Assemblies[0].Classes[0].FullyCoveredMethods, "half of the lines of Close are enough")
+}
+
+func TestNormalizeSummary_RejectsInconsistentModels(t *testing.T) {
+	summary := &model.SummaryResult{
+		Assemblies: []model.Assembly{
+			{Name: "Shop", Classes: []model.Class{
+				{Name: "Shop.Cart", Files: []model.CodeFile{{
+					Path:  "Cart.cs",
+					Lines: []model.Line{{Number: 0, Hits: 1}, {Number: 3, Hits: 1}, {Number: 3, Hits: 2}, {Number: 5, Hits: -2}, {Number: 6, Hits: 1, CoveredBranches: 3, TotalBranches: 2}},
+				}}},
+				{Name: "Shop.Price", Files: []model.CodeFile{{}}, Metrics: map[string]float64{"CrapScore": math.NaN()}},
+				{},
+			}},
+			{},
+		},
+	}
+
+	err := analyzer.NormalizeSummary(summary, settings.NewSettings())
+
+	require.Error(t, err)
+	for _, want := range []string{
+		`assembly "Shop", class "Shop.Cart", file "Cart.cs": line number 0 is invalid, line numbers start at 1`,
+		`assembly "Shop", class "Shop.Cart", file "Cart.cs": line 3 is listed more than once`,
+		`assembly "Shop", class "Shop.Cart", file "Cart.cs": line 5 has -2 hits, use -1 for lines that are not coverable`,
+		`assembly "Shop", class "Shop.Cart", file "Cart.cs": line 6 has 3 of 2 branches covered`,
+		`assembly "Shop", class "Shop.Price": the path of file #1 is empty`,
+		`assembly "Shop", class "Shop.Price": metric "CrapScore" is not a finite number`,
+		`assembly "Shop": a class name is empty`,
+		`assembly #2: the name is empty`,
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+	assert.Error(t, analyzer.NormalizeSummary(nil, settings.NewSettings()))
+}
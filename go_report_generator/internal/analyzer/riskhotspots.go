@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// RiskHotspotMetricNames lists the built-in method metrics that make a method a risk
+// hotspot.
+var RiskHotspotMetricNames = []string{"Cyclomatic complexity", "CrapScore", "NPath complexity"}
+
+// RiskHotspotMetricNamesOf returns the metrics of the risk hotspots in the order of
+// RiskHotspot.Metrics: RiskHotspotMetricNames, followed by the custom metrics of the
+// registry in its order.
+func RiskHotspotMetricNamesOf(registry model.MetricRegistry) []string {
+	builtIn := model.DefaultMetricRegistry()
+	var custom []string
+	for name := range registry {
+		if _, ok := builtIn[name]; !ok {
+			custom = append(custom, name)
+		}
+	}
+	registry.SortNames(custom)
+	return slices.Concat(RiskHotspotMetricNames, custom)
+}
+
+// RiskHotspotMetric is the value of one of RiskHotspotMetricNamesOf for a method and its
+// status against the threshold of the metric. Value is NaN if the method has no value.
+type RiskHotspotMetric struct {
+	Name      string
+	Value     float64
+	Status    model.MetricStatus
+	Threshold model.MetricThreshold
+}
+
+// Exceeded reports whether the value is above the warning or error limit.
+func (m RiskHotspotMetric) Exceeded() bool {
+	return m.Status != model.StatusOk
+}
+
+// RiskHotspot is a method with at least one metric above its threshold.
+type RiskHotspot struct {
+	Assembly *model.Assembly
+	Class    *model.Class
+	Method   *model.Method
+	Metrics  []RiskHotspotMetric // One entry per RiskHotspotMetricNamesOf
+}
+
+// Status returns the highest status of the metrics.
+func (h RiskHotspot) Status() model.MetricStatus {
+	status := model.StatusOk
+	for _, metric := range h.Metrics {
+		status = max(status, metric.Status)
+	}
+	return status
+}
+
+// exceededMetrics returns the number of metrics above their threshold.
+func (h RiskHotspot) exceededMetrics() int {
+	count := 0
+	for _, metric := range h.Metrics {
+		if metric.Exceeded() {
+			count++
+		}
+	}
+	return count
+}
+
+// FindRiskHotspots returns the methods of the summary with a metric above its threshold,
+// skipping the assemblies and classes excluded by the risk hotspot filters (nil: no
+// filter). The metrics of the hotspots are those of RiskHotspotMetricNamesOf(registry).
+// Hotspots with an exceeded error limit come first, then those with the most
+// exceeded metrics; ties are ordered by assembly, class and method. The hotspots point
+// into summary, which must not be modified while they are used.
+func FindRiskHotspots(summary *model.SummaryResult, registry model.MetricRegistry, thresholds map[string]model.MetricThreshold, assemblyFilter, classFilter filtering.IFilter) []RiskHotspot {
+	if summary == nil {
+		return nil
+	}
+	names := RiskHotspotMetricNamesOf(registry)
+	var hotspots []RiskHotspot
+	for i := range summary.Assemblies {
+		assembly := &summary.Assemblies[i]
+		if assemblyFilter != nil && !assemblyFilter.IsElementIncludedInReport(assembly.Name) {
+			continue
+		}
+		for j := range assembly.Classes {
+			class := &assembly.Classes[j]
+			if classFilter != nil && !classFilter.IsElementIncludedInReport(class.Name) {
+				continue
+			}
+			for k := range class.Methods {
+				hotspot := RiskHotspot{Assembly: assembly, Class: class, Method: &class.Methods[k]}
+				hotspot.Metrics = riskHotspotMetrics(hotspot.Method, names, thresholds)
+				if hotspot.Status() != model.StatusOk {
+					hotspots = append(hotspots, hotspot)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		left, right := hotspots[i], hotspots[j]
+		if left.Status() != right.Status() {
+			return left.Status() > right.Status()
+		}
+		if left.exceededMetrics() != right.exceededMetrics() {
+			return left.exceededMetrics() > right.exceededMetrics()
+		}
+		if left.Assembly.Name != right.Assembly.Name {
+			return left.Assembly.Name < right.Assembly.Name
+		}
+		if left.Class.Name != right.Class.Name {
+			return left.Class.Name < right.Class.Name
+		}
+		if left.Method.FirstLine != right.Method.FirstLine {
+			return left.Method.FirstLine < right.Method.FirstLine
+		}
+		return left.Method.RawKey() < right.Method.RawKey()
+	})
+	return hotspots
+}
+
+// riskHotspotMetrics evaluates the values of the named metrics of a method.
+func riskHotspotMetrics(method *model.Method, names []string, thresholds map[string]model.MetricThreshold) []RiskHotspotMetric {
+	metrics := make([]RiskHotspotMetric, len(names))
+	for i, name := range names {
+		metrics[i] = RiskHotspotMetric{Name: name, Value: math.NaN(), Threshold: thresholds[name]}
+		value, ok := methodMetricValue(method, name)
+		if !ok {
+			continue
+		}
+		metrics[i].Value = value
+		metrics[i].Status = metrics[i].Threshold.Evaluate(value)
+	}
+	return metrics
+}
+
+// methodMetricValue returns the finite value of the metric with the given name.
+func methodMetricValue(method *model.Method, name string) (float64, bool) {
+	for _, methodMetric := range method.MethodMetrics {
+		for _, metric := range methodMetric.Metrics {
+			if metric.Name != name {
+				continue
+			}
+			var value float64
+			switch v := metric.Value.(type) {
+			case float64:
+				value = v
+			case int:
+				value = float64(v)
+			default:
+				return 0, false
+			}
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				return 0, false
+			}
+			return value, true
+		}
+	}
+	return 0, false
+}
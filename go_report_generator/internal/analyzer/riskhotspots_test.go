@@ -0,0 +1,90 @@
+package analyzer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hotspotMethod(name string, firstLine int, metrics map[string]float64) model.Method {
+	method := model.Method{Name: name, DisplayName: name + "()", FirstLine: firstLine}
+	for metricName, value := range metrics {
+		method.MethodMetrics = append(method.MethodMetrics, model.MethodMetric{
+			Name: metricName, Line: firstLine, Metrics: []model.Metric{{Name: metricName, Value: value}},
+		})
+	}
+	return method
+}
+
+func newRiskHotspotSummary() *model.SummaryResult {
+	return &model.SummaryResult{
+		Assemblies: []model.Assembly{
+			{Name: "Core", Classes: []model.Class{
+				{Name: "Core.Parser", Methods: []model.Method{
+					hotspotMethod("Simple", 5, map[string]float64{"Cyclomatic complexity": 3, "CrapScore": 3}),
+					hotspotMethod("Branchy", 20, map[string]float64{"Cyclomatic complexity": 18, "CrapScore": 20}),
+					hotspotMethod("Untested", 60, map[string]float64{"Cyclomatic complexity": 12, "CrapScore": 156}),
+				}},
+				{Name: "Core.Generated", Methods: []model.Method{
+					hotspotMethod("Huge", 1, map[string]float64{"Cyclomatic complexity": 40, "CrapScore": 1640}),
+				}},
+			}},
+			{Name: "Api", Classes: []model.Class{
+				{Name: "Api.Handler", Methods: []model.Method{
+					hotspotMethod("Serve", 10, map[string]float64{"Cyclomatic complexity": 16, "CrapScore": 31}),
+					hotspotMethod("NoMetrics", 30, nil),
+				}},
+			}},
+		},
+	}
+}
+
+func TestFindRiskHotspots_OrderedByExceededThresholds(t *testing.T) {
+	summary := newRiskHotspotSummary()
+
+	hotspots := analyzer.FindRiskHotspots(summary, model.DefaultMetricRegistry(), settings.DefaultMetricThresholds(), nil, nil)
+
+	var names []string
+	for _, hotspot := range hotspots {
+		names = append(names, hotspot.Class.Name+"."+hotspot.Method.Name)
+	}
+	// Errors first, then warnings of two metrics before warnings of one.
+	assert.Equal(t, []string{"Core.Generated.Huge", "Core.Parser.Untested", "Api.Handler.Serve", "Core.Parser.Branchy"}, names)
+
+	untested := hotspots[1]
+	assert.Equal(t, "Core", untested.Assembly.Name)
+	assert.Same(t, &summary.Assemblies[0].Classes[0].Methods[2], untested.Method)
+	assert.Equal(t, model.StatusError, untested.Status())
+	require.Len(t, untested.Metrics, len(analyzer.RiskHotspotMetricNames))
+	assert.Equal(t, analyzer.RiskHotspotMetric{Name: "Cyclomatic complexity", Value: 12, Status: model.StatusOk, Threshold: model.MetricThreshold{Warning: 15, Error: 30}}, untested.Metrics[0])
+	assert.True(t, untested.Metrics[1].Exceeded())
+	assert.Equal(t, 80.0, untested.Metrics[1].Threshold.Error)
+	assert.True(t, math.IsNaN(untested.Metrics[2].Value), "NPath complexity is not reported")
+	assert.False(t, untested.Metrics[2].Exceeded())
+}
+
+func TestFindRiskHotspots_Filters(t *testing.T) {
+	assemblyFilter, err := filtering.NewDefaultFilter([]string{"-Api"})
+	require.NoError(t, err)
+	classFilter, err := filtering.NewDefaultFilter([]string{"-*.Generated"})
+	require.NoError(t, err)
+
+	hotspots := analyzer.FindRiskHotspots(newRiskHotspotSummary(), model.DefaultMetricRegistry(), settings.DefaultMetricThresholds(), assemblyFilter, classFilter)
+
+	require.Len(t, hotspots, 2)
+	assert.Equal(t, "Untested", hotspots[0].Method.Name)
+	assert.Equal(t, "Branchy", hotspots[1].Method.Name)
+}
+
+func TestFindRiskHotspots_None(t *testing.T) {
+	thresholds := map[string]model.MetricThreshold{"CrapScore": {Warning: 5000}}
+
+	assert.Empty(t, analyzer.FindRiskHotspots(newRiskHotspotSummary(), model.DefaultMetricRegistry(), thresholds, nil, nil))
+	assert.Empty(t, analyzer.FindRiskHotspots(nil, model.DefaultMetricRegistry(), thresholds, nil, nil))
+}
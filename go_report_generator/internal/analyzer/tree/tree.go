@@ -0,0 +1,226 @@
+// Package tree aggregates the coverage of code files into a directory tree, similar to the
+// per-directory view of `go tool cover`.
+package tree
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// fileCoverage is the coverage of one unique file, summed over all classes that contain it.
+type fileCoverage struct {
+	dir             []string // Directory segments of the file path
+	linesCovered    int
+	linesValid      int
+	branchesCovered int
+	branchesValid   int
+	hasBranchData   bool
+}
+
+// group is a set of files placed relative to the same base directory.
+type group struct {
+	label string
+	base  []string
+	files []*fileCoverage
+}
+
+// Build returns the directory tree of all code files of the assemblies.
+//
+// Files below one of the source directories are placed relative to the deepest source
+// directory containing them. Files outside all source directories, or all files if no
+// source directories are given, are placed relative to their common directory. If the
+// files end up in more than one of these groups, each group becomes a top-level node
+// named after its base directory; otherwise the root stands for the single base directory.
+// Backslashes and slashes are both treated as path separators, and paths and their
+// segments are compared by keyOf (see utils.PathKeyFunc), so the case of a path may
+// differ between classes if paths are case-insensitive.
+func Build(assemblies []model.Assembly, sourceDirs []string, keyOf func(string) string) *model.DirectoryCoverage {
+	files := collectFiles(assemblies, keyOf)
+
+	groups := make([]*group, 0, len(sourceDirs)+1)
+	for _, dir := range sourceDirs {
+		base := splitPath(dir)
+		if len(base) == 0 {
+			continue
+		}
+		groups = append(groups, &group{label: joinSegments(base), base: base})
+	}
+	outside := &group{}
+
+	for _, file := range files {
+		var best *group
+		for _, g := range groups {
+			if hasPrefix(file.dir, g.base, keyOf) && (best == nil || len(g.base) > len(best.base)) {
+				best = g
+			}
+		}
+		if best == nil {
+			best = outside
+		}
+		best.files = append(best.files, file)
+	}
+
+	if len(outside.files) > 0 {
+		outside.base = outside.files[0].dir
+		for _, file := range outside.files[1:] {
+			outside.base = commonPrefix(outside.base, file.dir, keyOf)
+		}
+		outside.label = joinSegments(outside.base)
+		groups = append(groups, outside)
+	}
+
+	var used []*group
+	for _, g := range groups {
+		if len(g.files) > 0 {
+			used = append(used, g)
+		}
+	}
+
+	root := &model.DirectoryCoverage{}
+	nodes := map[string]*model.DirectoryCoverage{"": root}
+	for _, g := range used {
+		var prefix []string
+		if len(used) == 1 {
+			root.Name = g.label
+		} else {
+			prefix = []string{g.label}
+		}
+		for _, file := range g.files {
+			segments := append(append([]string(nil), prefix...), file.dir[len(g.base):]...)
+			addFile(nodes, segments, file, keyOf)
+		}
+	}
+
+	sortChildren(root)
+	return root
+}
+
+// collectFiles sums the coverage of every unique file path over all classes, in the
+// order the files are first encountered.
+func collectFiles(assemblies []model.Assembly, keyOf func(string) string) []*fileCoverage {
+	var files []*fileCoverage
+	byPath := make(map[string]*fileCoverage)
+	for _, assembly := range assemblies {
+		for _, class := range assembly.Classes {
+			for _, codeFile := range class.Files {
+				segments := splitPath(codeFile.Path)
+				if len(segments) == 0 {
+					continue
+				}
+				key := keyOf(joinSegments(segments))
+				file, ok := byPath[key]
+				if !ok {
+					file = &fileCoverage{dir: segments[:len(segments)-1]}
+					byPath[key] = file
+					files = append(files, file)
+				}
+
+				file.linesCovered += codeFile.CoveredLines
+				file.linesValid += codeFile.CoverableLines
+				for _, line := range codeFile.Lines {
+					if line.IsBranchPoint || line.TotalBranches > 0 {
+						file.hasBranchData = true
+						file.branchesCovered += line.CoveredBranches
+						file.branchesValid += line.TotalBranches
+					}
+				}
+			}
+		}
+	}
+	return files
+}
+
+// addFile adds the coverage of a file to the root and to every directory node on the
+// path given by segments, creating the missing nodes.
+func addFile(nodes map[string]*model.DirectoryCoverage, segments []string, file *fileCoverage, keyOf func(string) string) {
+	node := nodes[""]
+	add(node, file)
+	for i, segment := range segments {
+		nodePath := strings.Join(segments[:i+1], "/")
+		child, ok := nodes[keyOf(nodePath)]
+		if !ok {
+			child = &model.DirectoryCoverage{Name: segment, Path: nodePath}
+			nodes[keyOf(nodePath)] = child
+			node.Children = append(node.Children, child)
+		}
+		add(child, file)
+		node = child
+	}
+}
+
+func add(node *model.DirectoryCoverage, file *fileCoverage) {
+	node.Files++
+	node.LinesCovered += file.linesCovered
+	node.LinesValid += file.linesValid
+	node.BranchesCovered += file.branchesCovered
+	node.BranchesValid += file.branchesValid
+	node.HasBranchData = node.HasBranchData || file.hasBranchData
+}
+
+func sortChildren(node *model.DirectoryCoverage) {
+	sort.Slice(node.Children, func(i, j int) bool {
+		return node.Children[i].Name < node.Children[j].Name
+	})
+	for _, child := range node.Children {
+		sortChildren(child)
+	}
+}
+
+// splitPath splits a path at slashes and backslashes. Empty and "." segments are dropped.
+// A rooted path starts with an empty segment, a UNC path (`\\server\share`) with "/", so
+// that joinSegments restores the root.
+func splitPath(p string) []string {
+	p = strings.ReplaceAll(p, `\`, "/")
+	var segments []string
+	switch {
+	case strings.HasPrefix(p, "//"):
+		segments = []string{"/"}
+	case strings.HasPrefix(p, "/"):
+		segments = []string{""}
+	}
+	for _, segment := range strings.Split(p, "/") {
+		if segment != "" && segment != "." {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// joinSegments is the inverse of splitPath. It returns "." for no segments.
+func joinSegments(segments []string) string {
+	switch {
+	case len(segments) == 0:
+		return "."
+	case len(segments) == 1 && segments[0] == "":
+		return "/"
+	case len(segments) == 1 && segments[0] == "/":
+		return "//"
+	}
+	return strings.Join(segments, "/")
+}
+
+func hasPrefix(segments, prefix []string, keyOf func(string) string) bool {
+	if len(prefix) > len(segments) {
+		return false
+	}
+	for i := range prefix {
+		if !sameSegment(segments[i], prefix[i], keyOf) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefix(a, b []string, keyOf func(string) string) []string {
+	n := 0
+	for n < len(a) && n < len(b) && sameSegment(a[n], b[n], keyOf) {
+		n++
+	}
+	return a[:n]
+}
+
+func sameSegment(a, b string, keyOf func(string) string) bool {
+	return a == b || keyOf(a) == keyOf(b)
+}
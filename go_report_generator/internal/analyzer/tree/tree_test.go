@@ -0,0 +1,172 @@
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer/tree"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codeFile returns a file with covered of valid lines covered.
+func codeFile(path string, covered, valid int) model.CodeFile {
+	return model.CodeFile{Path: path, CoveredLines: covered, CoverableLines: valid}
+}
+
+func assemblyWithFiles(files ...model.CodeFile) []model.Assembly {
+	classes := make([]model.Class, 0, len(files))
+	for _, f := range files {
+		classes = append(classes, model.Class{Name: f.Path, Files: []model.CodeFile{f}})
+	}
+	return []model.Assembly{{Name: "Module", Classes: classes}}
+}
+
+// flatten maps the path of every node to its file count, covered lines and coverable lines.
+func flatten(node *model.DirectoryCoverage) map[string][3]int {
+	nodes := make(map[string][3]int)
+	var walk func(n *model.DirectoryCoverage)
+	walk = func(n *model.DirectoryCoverage) {
+		nodes[n.Path] = [3]int{n.Files, n.LinesCovered, n.LinesValid}
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	walk(node)
+	return nodes
+}
+
+func childNames(node *model.DirectoryCoverage) []string {
+	var names []string
+	for _, child := range node.Children {
+		names = append(names, child.Name)
+	}
+	return names
+}
+
+func TestBuild_CommonRootWithoutSourceDirectories(t *testing.T) {
+	// Arrange
+	assemblies := assemblyWithFiles(
+		codeFile("/src/app/cmd/main.go", 5, 10),
+		codeFile("/src/app/internal/parser/parser.go", 8, 8),
+		codeFile("/src/app/internal/parser/lexer.go", 1, 4),
+		codeFile("/src/app/internal/util.go", 0, 2),
+	)
+
+	// Act
+	root := tree.Build(assemblies, nil, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, "/src/app", root.Name)
+	assert.Equal(t, []string{"cmd", "internal"}, childNames(root))
+	assert.Equal(t, map[string][3]int{
+		"":                {4, 14, 24},
+		"cmd":             {1, 5, 10},
+		"internal":        {3, 9, 14},
+		"internal/parser": {2, 9, 12},
+	}, flatten(root))
+}
+
+func TestBuild_MixedPathSeparators(t *testing.T) {
+	// Arrange
+	assemblies := assemblyWithFiles(
+		codeFile(`C:\work\app\Services\Billing.cs`, 3, 4),
+		codeFile("C:/work/app/Services/Mailer.cs", 1, 4),
+		codeFile(`C:\work\app/Program.cs`, 2, 2),
+	)
+
+	// Act
+	root := tree.Build(assemblies, []string{`C:\work\app\`}, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, "C:/work/app", root.Name)
+	assert.Equal(t, map[string][3]int{
+		"":         {3, 6, 10},
+		"Services": {2, 4, 8},
+	}, flatten(root))
+}
+
+func TestBuild_FilesOutsideSourceDirectories(t *testing.T) {
+	// Arrange
+	assemblies := assemblyWithFiles(
+		codeFile("/repo/frontend/src/app.ts", 1, 2),
+		codeFile("/repo/backend/api/handler.go", 3, 3),
+		codeFile("/repo/backend/api/internal/db/db.go", 0, 5),
+		codeFile("/usr/lib/go/src/fmt/print.go", 2, 4),
+		codeFile("/usr/lib/go/src/os/file.go", 0, 1),
+	)
+
+	// Act
+	root := tree.Build(assemblies, []string{"/repo/backend", "/repo/backend/api", "/repo/frontend"}, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, "", root.Name)
+	assert.Equal(t, []string{"/repo/backend/api", "/repo/frontend", "/usr/lib/go/src"}, childNames(root),
+		"the deepest source directory wins and files outside all of them get their own group")
+	assert.Equal(t, map[string][3]int{
+		"":                              {5, 6, 15},
+		"/repo/backend/api":             {2, 3, 8},
+		"/repo/backend/api/internal":    {1, 0, 5},
+		"/repo/backend/api/internal/db": {1, 0, 5},
+		"/repo/frontend":                {1, 1, 2},
+		"/repo/frontend/src":            {1, 1, 2},
+		"/usr/lib/go/src":               {2, 2, 5},
+		"/usr/lib/go/src/fmt":           {1, 2, 4},
+		"/usr/lib/go/src/os":            {1, 0, 1},
+	}, flatten(root))
+}
+
+func TestBuild_SourceDirectoryMatchesWholeSegments(t *testing.T) {
+	// Arrange
+	assemblies := assemblyWithFiles(
+		codeFile("/repo/app/main.go", 1, 1),
+		codeFile("/repo/application/main.go", 0, 1),
+	)
+
+	// Act
+	root := tree.Build(assemblies, []string{"/repo/app"}, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, []string{"/repo/app", "/repo/application"}, childNames(root))
+}
+
+func TestBuild_SharedFilesAndBranches(t *testing.T) {
+	// Arrange: two classes of the same file, each with its own lines.
+	first := codeFile("src/Shapes.cs", 2, 3)
+	first.Lines = []model.Line{{Number: 4, IsBranchPoint: true, CoveredBranches: 1, TotalBranches: 2}}
+	second := codeFile(`src\Shapes.cs`, 1, 1)
+	second.Lines = []model.Line{{Number: 9, IsBranchPoint: true, CoveredBranches: 2, TotalBranches: 2}}
+	assemblies := []model.Assembly{{Name: "Shapes", Classes: []model.Class{
+		{Name: "Circle", Files: []model.CodeFile{first}},
+		{Name: "Square", Files: []model.CodeFile{second}},
+	}}}
+
+	// Act
+	root := tree.Build(assemblies, nil, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, "src", root.Name)
+	assert.Empty(t, root.Children)
+	assert.Equal(t, 1, root.Files, "the shared file is counted once")
+	assert.Equal(t, 3, root.LinesCovered)
+	assert.Equal(t, 4, root.LinesValid)
+	require.True(t, root.HasBranchData)
+	assert.Equal(t, 3, root.BranchesCovered)
+	assert.Equal(t, 4, root.BranchesValid)
+}
+
+func TestBuild_UNCPathsAndNoFiles(t *testing.T) {
+	// Act
+	uncRoot := tree.Build(assemblyWithFiles(
+		codeFile(`\\build\share\app\a\one.cs`, 1, 1),
+		codeFile(`\\build\share\app\b\two.cs`, 1, 1),
+	), nil, utils.PathKey)
+	emptyRoot := tree.Build(nil, []string{"/src"}, utils.PathKey)
+
+	// Assert
+	assert.Equal(t, "//build/share/app", uncRoot.Name)
+	assert.Equal(t, []string{"a", "b"}, childNames(uncRoot))
+	assert.Zero(t, emptyRoot.Files)
+	assert.Empty(t, emptyRoot.Children)
+}
@@ -1,4 +1,5 @@
 import { ChangeDetectionStrategy, Component, Input } from "@angular/core";
+import { HistoricCoverageBand } from "./data/historic-coverage-band.class";
 import { Metric } from "./data/metric.class";
 import { ClassViewModel } from "./viewmodels/class-viewmodel.class";
 
@@ -8,6 +9,9 @@ import { ClassViewModel } from "./viewmodels/class-viewmodel.class";
 <td>
   <a [href]="clazz.reportPath" *ngIf="clazz.reportPath !== ''">{{clazz.name}}</a>
   <ng-container *ngIf="clazz.reportPath === ''">{{clazz.name}}</ng-container>
+  <span class="external" *ngIf="clazz.external" [title]="translations.ExternalFilesHint + ': ' + clazz.externalFiles">{{translations.External}}</span>
+  <div class="coverage-age" *ngIf="clazz.coverageAge !== ''">{{clazz.coverageAge}}</div>
+  <div class="uncovered-ranges" *ngIf="clazz.uncoveredLineRanges !== ''">{{translations.UncoveredLines + ': ' + clazz.uncoveredLineRanges}}</div>
 </td>
 <td class="right" *ngIf="lineCoverageAvailable">
   <ng-container *ngIf="clazz.currentHistoricCoverage !== null">
@@ -54,10 +58,10 @@ import { ClassViewModel } from "./viewmodels/class-viewmodel.class";
   </ng-container>
 </td>
 <td class="right" [title]="clazz.coverageRatioText" *ngIf="lineCoverageAvailable">
-  <div coverage-history-chart [historicCoverages]="clazz.lineCoverageHistory"
+  <div coverage-history-chart [historicCoverages]="clazz.lineCoverageHistory" [coverageBand]="clazz.lineCoverageBand"
     *ngIf="clazz.lineCoverageHistory.length > 1"
     [ngClass]="{'historiccoverageoffset': clazz.currentHistoricCoverage !== null}"
-    class="tinylinecoveragechart ct-chart" title="{{translations.history + ': ' + translations.coverage}}">
+    class="tinylinecoveragechart ct-chart" [title]="historyTitle(translations.coverage)">
   </div>
   <ng-container *ngIf="clazz.currentHistoricCoverage !== null">
     <div class="currenthistory {{getClassName(clazz.coverage, clazz.currentHistoricCoverage.lcq)}}">
@@ -94,10 +98,10 @@ import { ClassViewModel } from "./viewmodels/class-viewmodel.class";
   </ng-container>
 </td>
 <td class="right" *ngIf="branchCoverageAvailable" [title]="clazz.branchCoverageRatioText">
-  <div coverage-history-chart [historicCoverages]="clazz.branchCoverageHistory"
+  <div coverage-history-chart [historicCoverages]="clazz.branchCoverageHistory" [coverageBand]="clazz.branchCoverageBand"
     *ngIf="clazz.branchCoverageHistory.length > 1"
     [ngClass]="{'historiccoverageoffset': clazz.currentHistoricCoverage !== null}"
-    class="tinybranchcoveragechart ct-chart" title="{{translations.history + ': ' + translations.branchCoverage}}">
+    class="tinybranchcoveragechart ct-chart" [title]="historyTitle(translations.branchCoverage)">
   </div>
   <ng-container *ngIf="clazz.currentHistoricCoverage !== null">
     <div class="currenthistory {{getClassName(clazz.branchCoverage, clazz.currentHistoricCoverage.bcq)}}">
@@ -137,7 +141,7 @@ import { ClassViewModel } from "./viewmodels/class-viewmodel.class";
   <div coverage-history-chart [historicCoverages]="clazz.methodCoverageHistory"
     *ngIf="clazz.methodCoverageHistory.length > 1"
     [ngClass]="{'historiccoverageoffset': clazz.currentHistoricCoverage !== null}"
-    class="tinymethodcoveragechart ct-chart" title="{{translations.history + ': ' + translations.methodCoverage}}">
+    class="tinymethodcoveragechart ct-chart" [title]="historyTitle(translations.methodCoverage)">
   </div>
   <ng-container *ngIf="clazz.currentHistoricCoverage !== null">
     <div class="currenthistory {{getClassName(clazz.methodCoverage, clazz.currentHistoricCoverage.mcq)}}">
@@ -177,7 +181,7 @@ import { ClassViewModel } from "./viewmodels/class-viewmodel.class";
   <div coverage-history-chart [historicCoverages]="clazz.methodFullCoverageHistory"
     *ngIf="clazz.methodFullCoverageHistory.length > 1"
     [ngClass]="{'historiccoverageoffset': clazz.currentHistoricCoverage !== null}"
-    class="tinyfullmethodcoveragechart ct-chart" title="{{translations.history + ': ' + translations.fullMethodCoverage}}">
+    class="tinyfullmethodcoveragechart ct-chart" [title]="historyTitle(translations.fullMethodCoverage)">
   </div>
   <ng-container *ngIf="clazz.currentHistoricCoverage !== null">
     <div class="currenthistory {{getClassName(clazz.methodFullCoverage, clazz.currentHistoricCoverage.mfcq)}}">
@@ -220,4 +224,13 @@ export class ClassRow {
           return "lightgraybg";
       }
     }
+
+    historyTitle(coverageType: string): string {
+      let title: string = this.translations.history + ": " + coverageType;
+      let band: HistoricCoverageBand|null = this.clazz.historicCoverageBand;
+      if (band !== null) {
+          title += " (" + this.translations.olderRuns.replace("%d", band.n.toString()).replace("%s", band.from).replace("%s", band.to) + ")";
+      }
+      return title;
+    }
 }
\ No newline at end of file
@@ -7,7 +7,8 @@ import { CodeElementViewModel } from "./viewmodels/codelement-viewmodel.class";
     template: `
 <th><a href="#" (click)="element.toggleCollapse($event)">
   <i [ngClass]="{'icon-plus': element.collapsed, 'icon-minus': !element.collapsed}"></i>
-  {{element.name}}</a>
+  <ng-container *ngIf="element.reportPath === ''"> {{element.name}}</ng-container></a>
+  <a *ngIf="element.reportPath !== ''" [href]="element.reportPath"> {{element.name}}</a>
 </th>
 <th class="right" *ngIf="lineCoverageAvailable">{{element.coveredLines}}</th>
 <th class="right" *ngIf="lineCoverageAvailable">{{element.uncoveredLines}}</th>
@@ -5,6 +5,9 @@ import { Helper } from './viewmodels/helper.class';
     selector: "[coverage-history-chart]",
     template: `
   <svg width="30" height="18" class="ct-chart-line">
+    <g class="ct-series ct-series-b">
+        <path [attr.d]="bandPath" class="ct-area"></path>
+    </g>
     <g class="ct-series ct-series-a">
         <path [attr.d]="path" class="ct-line"></path>
     </g>
@@ -14,6 +17,7 @@ import { Helper } from './viewmodels/helper.class';
 })
 export class CoverageHistoryChartComponent {
     path: string|null = null;
+    bandPath: string|null = null;
 
     _historicCoverages: number[] = [];
     get historicCoverages(): number[] {
@@ -38,4 +42,19 @@ export class CoverageHistoryChartComponent {
             this.path = null;
         }
     }
+
+    /*
+    * The range [min, max] of the coverage of the older runs that are left out of the
+    * history, drawn as a band behind the line. min is -1 if they have no coverage.
+    */
+    @Input("coverageBand")
+    set coverageBand(value: number[]|null) {
+        if (value !== null && value[0] >= 0) {
+            let top: number = Helper.roundNumber(18 - (18 * value[1] / 100));
+            let bottom: number = Helper.roundNumber(18 - (18 * value[0] / 100));
+            this.bandPath = `M0,${top}L30,${top}L30,${bottom}L0,${bottom}Z`;
+        } else {
+            this.bandPath = null;
+        }
+    }
 }
\ No newline at end of file
@@ -111,12 +111,12 @@ import { Helper } from "./viewmodels/helper.class";
         </colgroup>
         <thead>
           <tr class="header">
-            <th></th>
-            <th class="center" colspan="6" *ngIf="settings.showLineCoverage">{{translations.coverage}}</th>
-            <th class="center" colspan="4" *ngIf="branchCoverageAvailable && settings.showBranchCoverage">{{translations.branchCoverage}}</th>
-            <th class="center" colspan="4" *ngIf="methodCoverageAvailable && settings.showMethodCoverage">{{translations.methodCoverage}}</th>
-            <th class="center" colspan="4" *ngIf="methodCoverageAvailable && settings.showFullMethodCoverage">{{translations.fullMethodCoverage}}</th>
-            <th class="center" [attr.colspan]="settings.visibleMetrics.length" *ngIf="settings.visibleMetrics.length > 0">{{translations.metrics}}</th>
+            <th scope="col"></th>
+            <th scope="col" class="center" colspan="6" *ngIf="settings.showLineCoverage">{{translations.coverage}}</th>
+            <th scope="col" class="center" colspan="4" *ngIf="branchCoverageAvailable && settings.showBranchCoverage">{{translations.branchCoverage}}</th>
+            <th scope="col" class="center" colspan="4" *ngIf="methodCoverageAvailable && settings.showMethodCoverage">{{translations.methodCoverage}}</th>
+            <th scope="col" class="center" colspan="4" *ngIf="methodCoverageAvailable && settings.showFullMethodCoverage">{{translations.fullMethodCoverage}}</th>
+            <th scope="col" class="center" [attr.colspan]="settings.visibleMetrics.length" *ngIf="settings.visibleMetrics.length > 0">{{translations.metrics}}</th>
           </tr>
           <tr class="filterbar">
             <td>
@@ -137,71 +137,71 @@ import { Helper } from "./viewmodels/helper.class";
             <td class="center" [attr.colspan]="settings.visibleMetrics.length" *ngIf="settings.visibleMetrics.length > 0"></td>
           </tr>
           <tr>
-            <th><a href="#" (click)="updateSorting('name', $event)"><i
+            <th scope="col"><a href="#" (click)="updateSorting('name', $event)"><i
               [ngClass]="{'icon-up-dir_active': settings.sortBy === 'name' && settings.sortOrder === 'asc',
               'icon-down-dir_active': settings.sortBy === 'name' && settings.sortOrder === 'desc',
               'icon-up-down-dir': settings.sortBy !== 'name'}"></i>{{translations.name}}</a></th>
-            <th class="right" *ngIf="settings.showLineCoverage"><a href="#" (click)="updateSorting('covered', $event)"><i
+            <th scope="col" class="right" *ngIf="settings.showLineCoverage"><a href="#" (click)="updateSorting('covered', $event)"><i
               [ngClass]="{'icon-up-dir_active': settings.sortBy === 'covered' && settings.sortOrder === 'asc',
               'icon-down-dir_active': settings.sortBy === 'covered' && settings.sortOrder === 'desc',
               'icon-up-down-dir': settings.sortBy !== 'covered'}"></i>{{translations.covered}}</a></th>
-            <th class="right" *ngIf="settings.showLineCoverage"><a href="#" (click)="updateSorting('uncovered', $event)"><i
+            <th scope="col" class="right" *ngIf="settings.showLineCoverage"><a href="#" (click)="updateSorting('uncovered', $event)"><i
               [ngClass]="{'icon-up-dir_active': settings.sortBy === 'uncovered' && settings.sortOrder === 'asc',
               'icon-down-dir_active': settings.sortBy === 'uncovered' && settings.sortOrder === 'desc',
               'icon-up-down-dir': settings.sortBy !== 'uncovered'}"></i>{{translations.uncovered}}</a></th>
-            <th class="right" *ngIf="settings.showLineCoverage"><a href="#" (click)="updateSorting('coverable', $event)"><i
+            <th scope="col" class="right" *ngIf="settings.showLineCoverage"><a href="#" (click)="updateSorting('coverable', $event)"><i
                 [ngClass]="{'icon-up-dir_active': settings.sortBy === 'coverable' && settings.sortOrder === 'asc',
                 'icon-down-dir_active': settings.sortBy === 'coverable' && settings.sortOrder === 'desc',
                 'icon-up-down-dir': settings.sortBy !== 'coverable'}"></i>{{translations.coverable}}</a></th>
-            <th class="right" *ngIf="settings.showLineCoverage"><a href="#" (click)="updateSorting('total', $event)"><i
+            <th scope="col" class="right" *ngIf="settings.showLineCoverage"><a href="#" (click)="updateSorting('total', $event)"><i
                 [ngClass]="{'icon-up-dir_active': settings.sortBy === 'total' && settings.sortOrder === 'asc',
                 'icon-down-dir_active': settings.sortBy === 'total' && settings.sortOrder === 'desc',
                 'icon-up-down-dir': settings.sortBy !== 'total'}"></i>{{translations.total}}</a></th>
-            <th class="center" colspan="2" *ngIf="settings.showLineCoverage">
+            <th scope="col" class="center" colspan="2" *ngIf="settings.showLineCoverage">
                 <a href="#" (click)="updateSorting('coverage', $event)"><i
                   [ngClass]="{'icon-up-dir_active': settings.sortBy === 'coverage' && settings.sortOrder === 'asc',
                   'icon-down-dir_active': settings.sortBy === 'coverage' && settings.sortOrder === 'desc',
                   'icon-up-down-dir': settings.sortBy !== 'coverage'}"></i>{{translations.percentage}}</a></th>
-            <th class="right" *ngIf="branchCoverageAvailable && settings.showBranchCoverage"><a href="#" (click)="updateSorting('covered_branches', $event)"><i
+            <th scope="col" class="right" *ngIf="branchCoverageAvailable && settings.showBranchCoverage"><a href="#" (click)="updateSorting('covered_branches', $event)"><i
               [ngClass]="{'icon-up-dir_active': settings.sortBy === 'covered_branches' && settings.sortOrder === 'asc',
               'icon-down-dir_active': settings.sortBy === 'covered_branches' && settings.sortOrder === 'desc',
               'icon-up-down-dir': settings.sortBy !== 'covered_branches'}"></i>{{translations.covered}}</a></th>
-            <th class="right" *ngIf="branchCoverageAvailable && settings.showBranchCoverage"><a href="#" (click)="updateSorting('total_branches', $event)"><i
+            <th scope="col" class="right" *ngIf="branchCoverageAvailable && settings.showBranchCoverage"><a href="#" (click)="updateSorting('total_branches', $event)"><i
                 [ngClass]="{'icon-up-dir_active': settings.sortBy === 'total_branches' && settings.sortOrder === 'asc',
                 'icon-down-dir_active': settings.sortBy === 'total_branches' && settings.sortOrder === 'desc',
                 'icon-up-down-dir': settings.sortBy !== 'total_branches'}"></i>{{translations.total}}</a></th>
-            <th class="center" colspan="2" *ngIf="branchCoverageAvailable && settings.showBranchCoverage">
+            <th scope="col" class="center" colspan="2" *ngIf="branchCoverageAvailable && settings.showBranchCoverage">
                 <a href="#" (click)="updateSorting('branchcoverage', $event)"><i
                   [ngClass]="{'icon-up-dir_active': settings.sortBy === 'branchcoverage' && settings.sortOrder === 'asc',
                   'icon-down-dir_active': settings.sortBy === 'branchcoverage' && settings.sortOrder === 'desc',
                   'icon-up-down-dir': settings.sortBy !== 'branchcoverage'}"></i>{{translations.percentage}}</a></th>
-            <th class="right" *ngIf="methodCoverageAvailable && settings.showMethodCoverage"><a href="#" (click)="updateSorting('covered_methods', $event)"><i
+            <th scope="col" class="right" *ngIf="methodCoverageAvailable && settings.showMethodCoverage"><a href="#" (click)="updateSorting('covered_methods', $event)"><i
               [ngClass]="{'icon-up-dir_active': settings.sortBy === 'covered_methods' && settings.sortOrder === 'asc',
               'icon-down-dir_active': settings.sortBy === 'covered_methods' && settings.sortOrder === 'desc',
               'icon-up-down-dir': settings.sortBy !== 'covered_methods'}"></i>{{translations.covered}}</a></th>
-            <th class="right" *ngIf="methodCoverageAvailable && settings.showMethodCoverage"><a href="#" (click)="updateSorting('total_methods', $event)"><i
+            <th scope="col" class="right" *ngIf="methodCoverageAvailable && settings.showMethodCoverage"><a href="#" (click)="updateSorting('total_methods', $event)"><i
                 [ngClass]="{'icon-up-dir_active': settings.sortBy === 'total_methods' && settings.sortOrder === 'asc',
                 'icon-down-dir_active': settings.sortBy === 'total_methods' && settings.sortOrder === 'desc',
                 'icon-up-down-dir': settings.sortBy !== 'total_methods'}"></i>{{translations.total}}</a></th>
-            <th class="center" colspan="2" *ngIf="methodCoverageAvailable && settings.showMethodCoverage">
+            <th scope="col" class="center" colspan="2" *ngIf="methodCoverageAvailable && settings.showMethodCoverage">
                 <a href="#" (click)="updateSorting('methodcoverage', $event)"><i
                   [ngClass]="{'icon-up-dir_active': settings.sortBy === 'methodcoverage' && settings.sortOrder === 'asc',
                   'icon-down-dir_active': settings.sortBy === 'methodcoverage' && settings.sortOrder === 'desc',
                   'icon-up-down-dir': settings.sortBy !== 'methodcoverage'}"></i>{{translations.percentage}}</a></th>
-            <th class="right" *ngIf="methodCoverageAvailable && settings.showFullMethodCoverage"><a href="#" (click)="updateSorting('fullycovered_methods', $event)"><i
+            <th scope="col" class="right" *ngIf="methodCoverageAvailable && settings.showFullMethodCoverage"><a href="#" (click)="updateSorting('fullycovered_methods', $event)"><i
               [ngClass]="{'icon-up-dir_active': settings.sortBy === 'fullycovered_methods' && settings.sortOrder === 'asc',
               'icon-down-dir_active': settings.sortBy === 'fullycovered_methods' && settings.sortOrder === 'desc',
               'icon-up-down-dir': settings.sortBy !== 'fullycovered_methods'}"></i>{{translations.covered}}</a></th>
-            <th class="right" *ngIf="methodCoverageAvailable && settings.showFullMethodCoverage"><a href="#" (click)="updateSorting('total_methods', $event)"><i
+            <th scope="col" class="right" *ngIf="methodCoverageAvailable && settings.showFullMethodCoverage"><a href="#" (click)="updateSorting('total_methods', $event)"><i
                 [ngClass]="{'icon-up-dir_active': settings.sortBy === 'total_methods' && settings.sortOrder === 'asc',
                 'icon-down-dir_active': settings.sortBy === 'total_methods' && settings.sortOrder === 'desc',
                 'icon-up-down-dir': settings.sortBy !== 'total_methods'}"></i>{{translations.total}}</a></th>
-            <th class="center" colspan="2" *ngIf="methodCoverageAvailable && settings.showFullMethodCoverage">
+            <th scope="col" class="center" colspan="2" *ngIf="methodCoverageAvailable && settings.showFullMethodCoverage">
                 <a href="#" (click)="updateSorting('methodfullcoverage', $event)"><i
                   [ngClass]="{'icon-up-dir_active': settings.sortBy === 'methodfullcoverage' && settings.sortOrder === 'asc',
                   'icon-down-dir_active': settings.sortBy === 'methodfullcoverage' && settings.sortOrder === 'desc',
                   'icon-up-down-dir': settings.sortBy !== 'methodfullcoverage'}"></i>{{translations.percentage}}</a></th>
-            <th *ngFor="let metric of settings.visibleMetrics">
+            <th scope="col" *ngFor="let metric of settings.visibleMetrics">
               <a href="#" (click)="updateSorting(metric.abbreviation, $event)"><i
                   [ngClass]="{'icon-up-dir_active': settings.sortBy === metric.abbreviation && settings.sortOrder === 'asc',
                   'icon-down-dir_active': settings.sortBy === metric.abbreviation && settings.sortOrder === 'desc',
@@ -262,6 +262,12 @@ import { Helper } from "./viewmodels/helper.class";
                 </ng-container>
               </ng-container>
             </ng-container>
+            <tr *ngIf="!element.collapsed && element.moreClasses > 0" class="more-classes">
+              <td>
+                <a *ngIf="element.moreClassesReportPath !== ''" [href]="element.moreClassesReportPath + queryString">{{moreClassesText(element.moreClasses)}}</a>
+                <ng-container *ngIf="element.moreClassesReportPath === ''">{{moreClassesText(element.moreClasses)}}</ng-container>
+              </td>
+            </tr>
           </ng-container>
         </tbody>
       </table>
@@ -331,6 +337,12 @@ export class CoverageInfoComponent {
       this.settings.groupingMaximum = groupingMaximum;
       console.log("Grouping maximum: " + groupingMaximum);
 
+      let summarySort: any = (<any>this.window).summarySort;
+      if (summarySort !== undefined && summarySort !== null) {
+        this.settings.sortBy = summarySort.sortBy;
+        this.settings.sortOrder = summarySort.sortOrder;
+      }
+
       this.settings.showBranchCoverage = this.branchCoverageAvailable;
       this.settings.showMethodCoverage = this.methodCoverageAvailable;
       this.settings.showFullMethodCoverage = this.methodCoverageAvailable;
@@ -378,12 +390,17 @@ export class CoverageInfoComponent {
     if (this.settings.grouping === 0) { // group by assembly
         for (let i: number = 0; i < assemblies.length; i++) {
             let assemblyElement: CodeElementViewModel = new CodeElementViewModel(assemblies[i].name, null);
+            assemblyElement.reportPath = assemblies[i].rp ? assemblies[i].rp + this.queryString : "";
             codeElements.push(assemblyElement);
 
             for (let j: number = 0; j < assemblies[i].classes.length; j++) {
                 assemblyElement.insertClass(new ClassViewModel(assemblies[i].classes[j], this.queryString), null);
                 numberOfClasses++;
             }
+
+            if (assemblies[i].more) {
+                assemblyElement.insertMoreClasses(assemblies[i].more!);
+            }
         }
     } else if (this.settings.grouping === -1) { // no grouping
         let assemblyElement: CodeElementViewModel = new CodeElementViewModel(this.translations.all, null);
@@ -394,16 +411,25 @@ export class CoverageInfoComponent {
                 assemblyElement.insertClass(new ClassViewModel(assemblies[i].classes[j], this.queryString), null);
                 numberOfClasses++;
             }
+
+            if (assemblies[i].more) {
+                assemblyElement.insertMoreClasses(assemblies[i].more!);
+            }
         }
     } else { // group by assembly and namespace
         for (let i: number = 0; i < assemblies.length; i++) {
           let assemblyElement: CodeElementViewModel = new CodeElementViewModel(assemblies[i].name, null);
+          assemblyElement.reportPath = assemblies[i].rp ? assemblies[i].rp + this.queryString : "";
           codeElements.push(assemblyElement);
 
             for (let j: number = 0; j < assemblies[i].classes.length; j++) {
                 assemblyElement.insertClass(new ClassViewModel(assemblies[i].classes[j], this.queryString), this.settings.grouping);
                 numberOfClasses++;
             }
+
+            if (assemblies[i].more) {
+                assemblyElement.insertMoreClasses(assemblies[i].more!);
+            }
         }
     }
 
@@ -435,6 +461,10 @@ export class CoverageInfoComponent {
     }
   }
 
+  moreClassesText(count: number): string {
+    return this.translations.andMoreClasses.replace("%d", count.toString());
+  }
+
   updateCurrentHistoricCoverage(): void {
     let start: number = new Date().getTime();
 
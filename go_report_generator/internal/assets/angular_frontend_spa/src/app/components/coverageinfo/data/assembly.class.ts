@@ -1,6 +1,9 @@
 import { Class } from "./class.class";
+import { MoreClasses } from "./more-classes.class";
 
 export class Assembly {
     name: string = "";
+    rp: string = ""; // Page of the assembly, empty if none is rendered
     classes: Class[] = [];
+    more: MoreClasses|undefined; // Classes left out of the data by summarytopn
 }
@@ -0,0 +1,16 @@
+export class ClassFile {
+    /*
+    * The anchor of the file on the class page.
+    */
+    id: string = "";
+
+    /*
+    * The path.
+    */
+    path: string = "";
+
+    /*
+    * Whether the file is outside the source directories.
+    */
+    external: boolean|undefined;
+}
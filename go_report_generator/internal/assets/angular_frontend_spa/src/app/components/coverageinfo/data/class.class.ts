@@ -1,4 +1,6 @@
+import { ClassFile } from "./class-file.class";
 import { HistoricCoverage } from "./historic-coverage.class";
+import { HistoricCoverageBand } from "./historic-coverage-band.class";
 
 export class Class {
     /*
@@ -81,8 +83,33 @@ export class Class {
     */
     hc: HistoricCoverage[] = [];
 
+    /*
+    * The history entries older than the ones in hc.
+    */
+    hcb: HistoricCoverageBand|undefined;
+
     /*
     * The metrics.
     */
     metrics: any;
+
+    /*
+    * Since when the class stays below the coverage target, e.g. "Below 80% since 2024-03-02 (5 runs)".
+    */
+    ca: string|undefined;
+
+    /*
+    * The uncovered line ranges, e.g. "12-18, 25", only for the classes with the most uncovered lines.
+    */
+    ulr: string|undefined;
+
+    /*
+    * Whether all files of the class are outside the source directories.
+    */
+    ex: boolean|undefined;
+
+    /*
+    * The files, for deep links to the class page.
+    */
+    files: ClassFile[]|undefined;
 }
\ No newline at end of file
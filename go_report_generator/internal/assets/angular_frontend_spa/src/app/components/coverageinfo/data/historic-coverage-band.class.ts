@@ -0,0 +1,13 @@
+/*
+* The history entries of a class that are older than the ones in hc, with their number,
+* dates and coverage ranges (-1 if none of them has data for a coverage type).
+*/
+export class HistoricCoverageBand {
+    n: number = 0;
+    from: string = "";
+    to: string = "";
+    lcqmin: number = -1;
+    lcqmax: number = -1;
+    bcqmin: number = -1;
+    bcqmax: number = -1;
+}
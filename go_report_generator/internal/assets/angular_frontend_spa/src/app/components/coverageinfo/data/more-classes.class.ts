@@ -0,0 +1,18 @@
+/*
+* The classes of an assembly left out of window.assemblies by the summarytopn setting,
+* with their number and totals.
+*/
+export class MoreClasses {
+    count: number = 0;
+    rp: string = ""; // Page of the assembly that lists all classes
+
+    cl: number = 0;
+    ucl: number = 0;
+    cal: number = 0;
+    tl: number = 0;
+    cb: number = 0;
+    tb: number = 0;
+    cm: number = 0;
+    fcm: number = 0;
+    tm: number = 0;
+}
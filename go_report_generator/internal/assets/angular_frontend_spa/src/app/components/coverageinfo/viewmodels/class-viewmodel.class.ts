@@ -1,6 +1,7 @@
 import { Class } from "../data/class.class";
 import { CoverageInfoSettings } from "../data/coverageinfo-settings.class";
 import { HistoricCoverage } from "../data/historic-coverage.class";
+import { HistoricCoverageBand } from "../data/historic-coverage-band.class";
 import { ElementBase } from "./elementbase.class";
 import { Helper } from "./helper.class";
 
@@ -12,6 +13,13 @@ export class ClassViewModel extends ElementBase {
     methodFullCoverageHistory: number[] = [];
     historicCoverages: HistoricCoverage[] = [];
     metrics: any;
+    coverageAge: string = "";
+    external: boolean = false;
+    externalFiles: string = "";
+    uncoveredLineRanges: string = "";
+    historicCoverageBand: HistoricCoverageBand|null = null;
+    lineCoverageBand: number[]|null = null;
+    branchCoverageBand: number[]|null = null;
 
     currentHistoricCoverage: HistoricCoverage|null = null;
 
@@ -44,6 +52,16 @@ export class ClassViewModel extends ElementBase {
             });
 
             this.metrics = clazz.metrics;
+            this.coverageAge = clazz.ca || "";
+            this.external = clazz.ex === true;
+            this.externalFiles = (clazz.files || []).filter(file => file.external).map(file => file.path).join(", ");
+            this.uncoveredLineRanges = clazz.ulr || "";
+
+            this.historicCoverageBand = clazz.hcb || null;
+            if (clazz.hcb) {
+                this.lineCoverageBand = [clazz.hcb.lcqmin, clazz.hcb.lcqmax];
+                this.branchCoverageBand = [clazz.hcb.bcqmin, clazz.hcb.bcqmax];
+            }
     }
 
     override get coverage(): number {
@@ -1,4 +1,5 @@
 import { CoverageInfoSettings } from "../data/coverageinfo-settings.class";
+import { MoreClasses } from "../data/more-classes.class";
 import { ClassViewModel } from "./class-viewmodel.class";
 import { ElementBase } from "./elementbase.class";
 import { Helper } from "./helper.class";
@@ -10,6 +11,11 @@ export class CodeElementViewModel extends ElementBase {
 
     collapsed: boolean = false;
 
+    reportPath: string = ""; // Page of the assembly, empty for namespaces
+
+    moreClasses: number = 0;
+    moreClassesReportPath: string = "";
+
     constructor(
         name: string,
         parent: CodeElementViewModel|null) {
@@ -76,6 +82,24 @@ export class CodeElementViewModel extends ElementBase {
         subNamespace.insertClass(clazz, null);
     }
 
+    insertMoreClasses(more: MoreClasses): void {
+        this.coveredLines += more.cl;
+        this.uncoveredLines += more.ucl;
+        this.coverableLines += more.cal;
+        this.totalLines += more.tl;
+
+        this.coveredBranches += more.cb;
+        this.totalBranches += more.tb;
+
+        this.coveredMethods += more.cm;
+        this.fullyCoveredMethods += more.fcm;
+        this.totalMethods += more.tm;
+
+        // Without grouping the classes of several assemblies are listed on several pages.
+        this.moreClassesReportPath = this.moreClasses === 0 ? more.rp : "";
+        this.moreClasses += more.count;
+    }
+
     collapse(): void {
         this.collapsed = true;
 
@@ -43,19 +43,19 @@ import { RiskHotspotsSettings } from "./data/riskhotspots-settings.class";
           </colgroup>
           <thead>
             <tr>
-              <th><a href="#" (click)="updateSorting('assembly', $event)"><i
+              <th scope="col"><a href="#" (click)="updateSorting('assembly', $event)"><i
                 [ngClass]="{'icon-up-dir_active': settings.sortBy === 'assembly' && settings.sortOrder === 'asc',
                 'icon-down-dir_active': settings.sortBy === 'assembly' && settings.sortOrder === 'desc',
                 'icon-up-down-dir': settings.sortBy !== 'assembly'}"></i>{{translations.assembly}}</a></th>
-              <th><a href="#" (click)="updateSorting('class', $event)"><i
+              <th scope="col"><a href="#" (click)="updateSorting('class', $event)"><i
               [ngClass]="{'icon-up-dir_active': settings.sortBy === 'class' && settings.sortOrder === 'asc',
               'icon-down-dir_active': settings.sortBy === 'class' && settings.sortOrder === 'desc',
               'icon-up-down-dir': settings.sortBy !== 'class'}"></i>{{translations.class}}</a></th>
-              <th><a href="#" (click)="updateSorting('method', $event)"><i
+              <th scope="col"><a href="#" (click)="updateSorting('method', $event)"><i
               [ngClass]="{'icon-up-dir_active': settings.sortBy === 'method' && settings.sortOrder === 'asc',
               'icon-down-dir_active': settings.sortBy === 'method' && settings.sortOrder === 'desc',
               'icon-up-down-dir': settings.sortBy !== 'method'}"></i>{{translations.method}}</a></th>
-              <th *ngFor="let riskHotspotMetric of riskHotspotMetrics; index as i">
+              <th scope="col" *ngFor="let riskHotspotMetric of riskHotspotMetrics; index as i">
                 <a href="#" (click)="updateSorting('' + i, $event)"><i
                 [ngClass]="{'icon-up-dir_active': settings.sortBy === '' + i && settings.sortOrder === 'asc',
                 'icon-down-dir_active': settings.sortBy === '' + i && settings.sortOrder === 'desc',
@@ -67,11 +67,17 @@ import { RiskHotspotsSettings } from "./data/riskhotspots-settings.class";
           <tbody>
             <tr *ngFor="let riskHotspot of riskHotspots | slice:0:settings.numberOfRiskHotspots">
               <td>{{riskHotspot.assembly}}</td>
-              <td><a [href]="riskHotspot.reportPath + queryString">{{riskHotspot.class}}</a></td>
+              <td>
+                <a [href]="riskHotspot.reportPath + queryString" *ngIf="riskHotspot.reportPath !== ''">{{riskHotspot.class}}</a>
+                <ng-container *ngIf="riskHotspot.reportPath === ''">{{riskHotspot.class}}</ng-container>
+              </td>
               <td [title]="riskHotspot.methodName">
-                <a [href]="riskHotspot.reportPath + queryString + '#file' + riskHotspot.fileIndex + '_line' + riskHotspot.line">
+                <a [href]="riskHotspot.reportPath + queryString + '#file' + riskHotspot.fileIndex + '_line' + riskHotspot.line" *ngIf="riskHotspot.reportPath !== ''">
                   {{riskHotspot.methodShortName}}
                 </a>
+                <ng-container *ngIf="riskHotspot.reportPath === ''">
+                  {{riskHotspot.methodShortName}}
+                </ng-container>
               </td>
               <td class="right" *ngFor="let metric of riskHotspot.metrics"
                 [ngClass]="{'lightred': metric.exceeded, 'lightgreen': !metric.exceeded}">{{metric.value}}</td>
@@ -12,9 +12,18 @@ for (i = 0, l = hashLinks.length; i < l; i++) {
     hashLinks[i].addEventListener('click', navigateToHash);
 }
 
+/* Visits of a line per test; null if the report has no per-test data (empty attribute) */
+var parseCoverageData = function (line) {
+    var data = line.getAttribute('data-coverage');
+    if (!data) {
+        return null;
+    }
+    return JSON.parse(data.replace(/'/g, '"'));
+};
+
 /* Switch test method */
 var switchTestMethod = function () {
-    var method = this.getAttribute("value");
+    var method = this.value; // Radio button (C# report) or <select> (test selector)
     console.log("Selected test method: " + method);
 
     var lines, i, l, coverageData, lineAnalysis, cells;
@@ -22,7 +31,10 @@ var switchTestMethod = function () {
     lines = document.querySelectorAll('.lineAnalysis tr');
 
     for (i = 1, l = lines.length; i < l; i++) {
-        coverageData = JSON.parse(lines[i].getAttribute('data-coverage').replace(/'/g, '"'));
+        coverageData = parseCoverageData(lines[i]);
+        if (coverageData === null) {
+            continue;
+        }
         lineAnalysis = coverageData[method];
         cells = lines[i].querySelectorAll('td');
         if (lineAnalysis === undefined) {
@@ -63,7 +75,10 @@ var highlightTestMethods = function () {
     }
 
     var lineAnalysis;
-    var coverageData = JSON.parse(this.getAttribute('data-coverage').replace(/'/g, '"'));
+    var coverageData = parseCoverageData(this);
+    if (coverageData === null) {
+        return;
+    }
     var testMethods = document.getElementsByClassName('testmethod');
 
     for (i = 0, l = testMethods.length; i < l; i++) {
@@ -198,4 +213,379 @@ var renderChart = function (chart) {
 var charts = document.getElementsByClassName('historychart');
 for (i = 0, l = charts.length; i < l; i++) {
     renderChart(charts[i]);
-}
\ No newline at end of file
+}
+/* Uncovered line navigation */
+var isUncoveredRow = function (row) {
+    var status = row.cells[0].getAttribute('class');
+    return status === 'red' || status === 'orange';
+};
+
+var toggleUncoveredLines = function (event) {
+    event.preventDefault();
+
+    var table = this.parentNode.nextElementSibling.querySelector('.lineAnalysis');
+    var showOnlyUncovered = this.getAttribute('data-active') !== 'true';
+    var rows = table.querySelectorAll('tbody tr');
+
+    for (var r = 0; r < rows.length; r++) {
+        rows[r].style.display = showOnlyUncovered && !isUncoveredRow(rows[r]) ? 'none' : '';
+    }
+
+    this.setAttribute('data-active', showOnlyUncovered ? 'true' : 'false');
+    this.textContent = this.getAttribute(showOnlyUncovered ? 'data-hidetext' : 'data-showtext');
+};
+
+var jumpToUncoveredLine = function (forward) {
+    var rows = document.querySelectorAll('.lineAnalysis tbody tr');
+    var offset = 10;
+    var target = null;
+
+    for (var r = 0; r < rows.length; r++) {
+        if (rows[r].style.display === 'none' || !isUncoveredRow(rows[r])) {
+            continue;
+        }
+
+        var top = rows[r].getBoundingClientRect().top;
+        if (forward && top > offset) {
+            target = rows[r];
+            break;
+        }
+        if (!forward && top < -offset) {
+            target = rows[r];
+        }
+    }
+
+    if (target === null) {
+        return;
+    }
+
+    target.scrollIntoView();
+    var anchor = target.querySelector('a[id]');
+    // In index.html the hash selects the class details, so it is kept there
+    if (anchor !== null && classDetailContainer === null && window.history !== undefined && window.history.replaceState !== undefined) {
+        window.history.replaceState(undefined, undefined, '#' + anchor.id);
+    }
+};
+
+var uncoveredToggles = document.getElementsByClassName('toggleuncovered');
+for (i = 0, l = uncoveredToggles.length; i < l; i++) {
+    uncoveredToggles[i].addEventListener('click', toggleUncoveredLines);
+}
+
+var previousUncoveredLinks = document.getElementsByClassName('previousuncovered');
+for (i = 0, l = previousUncoveredLinks.length; i < l; i++) {
+    previousUncoveredLinks[i].addEventListener('click', function (event) {
+        event.preventDefault();
+        jumpToUncoveredLine(false);
+    });
+}
+
+var nextUncoveredLinks = document.getElementsByClassName('nextuncovered');
+for (i = 0, l = nextUncoveredLinks.length; i < l; i++) {
+    nextUncoveredLinks[i].addEventListener('click', function (event) {
+        event.preventDefault();
+        jumpToUncoveredLine(true);
+    });
+}
+
+if (nextUncoveredLinks.length > 0) {
+    document.addEventListener('keydown', function (event) {
+        var tagName = event.target.tagName;
+        if (event.ctrlKey || event.altKey || event.metaKey || tagName === 'INPUT' || tagName === 'TEXTAREA' || tagName === 'SELECT') {
+            return;
+        }
+
+        if (event.key === 'n') {
+            jumpToUncoveredLine(true);
+        } else if (event.key === 'p') {
+            jumpToUncoveredLine(false);
+        }
+    });
+}
+
+/* Deep links: scroll to the file or line given by the hash (#<file> or #<file>_line<number>)
+   once the page is loaded and highlight the line */
+var showHashTarget = function () {
+    var id = window.location.hash.substring(1);
+    if (id === '') {
+        return;
+    }
+
+    var target = document.getElementById(decodeURIComponent(id));
+    var fileLine = /^file(\d+)_line(\d+)$/.exec(id);
+    if (target === null && fileLine !== null) {
+        // Links of the risk hotspots table on the summary page give the file by its index
+        var fileHeadings = document.querySelectorAll('h2[id]');
+        var fileIndex = parseInt(fileLine[1], 10);
+        if (fileIndex < fileHeadings.length) {
+            target = document.getElementById(fileHeadings[fileIndex].id + '_line' + fileLine[2]);
+        }
+    }
+    if (target === null) {
+        return;
+    }
+
+    var highlighted = document.querySelectorAll('.lineAnalysis tr.hashtarget');
+    for (var h = 0; h < highlighted.length; h++) {
+        highlighted[h].classList.remove('hashtarget');
+    }
+
+    var row = target.closest('tr');
+    if (row !== null) {
+        row.classList.add('hashtarget');
+    }
+    (row || target).scrollIntoView({ behavior: 'smooth', block: row !== null ? 'center' : 'start' });
+};
+
+if (document.querySelector('.lineAnalysis') !== null) {
+    window.addEventListener('load', showHashTarget);
+    window.addEventListener('hashchange', showHashTarget);
+}
+
+/* Sidebar links carry the line range of their method (data-range="<first>-<last>"):
+   highlight all rows of the method on click */
+var highlightMethodRange = function () {
+    var range = /^(\d+)-(\d+)$/.exec(this.getAttribute('data-range'));
+    var fileLine = /^#(.+)_line\d+$/.exec(this.getAttribute('href'));
+    if (range === null || fileLine === null) {
+        return;
+    }
+
+    var highlighted = document.querySelectorAll('.lineAnalysis tr.methodrange');
+    for (var h = 0; h < highlighted.length; h++) {
+        highlighted[h].classList.remove('methodrange');
+    }
+
+    for (var line = parseInt(range[1], 10), last = parseInt(range[2], 10); line <= last; line++) {
+        var anchor = document.getElementById(fileLine[1] + '_line' + line);
+        var row = anchor !== null ? anchor.closest('tr') : null;
+        if (row !== null) {
+            row.classList.add('methodrange');
+        }
+    }
+};
+
+var methodRangeLinks = document.querySelectorAll('a[data-range]');
+for (i = 0, l = methodRangeLinks.length; i < l; i++) {
+    methodRangeLinks[i].addEventListener('click', highlightMethodRange);
+}
+
+/* Collapsible file groups in the methods sidebar, persisted per class page */
+var sidebarStorageKey = 'collapsedSidebarFiles:' + window.location.pathname;
+
+var loadCollapsedSidebarFiles = function () {
+    try {
+        return JSON.parse(window.sessionStorage.getItem(sidebarStorageKey)) || [];
+    } catch (e) {
+        return [];
+    }
+};
+
+var saveCollapsedSidebarFiles = function (files) {
+    try {
+        window.sessionStorage.setItem(sidebarStorageKey, JSON.stringify(files));
+    } catch (e) {
+        // sessionStorage is not available, e.g. for file:// URLs in some browsers
+    }
+};
+
+var setSidebarFileCollapsed = function (group, collapsed) {
+    group.querySelector('.sidebarfileelements').style.display = collapsed ? 'none' : '';
+    group.querySelector('.togglesidebarfile i').className = collapsed ? 'icon-plus' : 'icon-minus';
+    group.setAttribute('data-collapsed', collapsed ? 'true' : 'false');
+};
+
+var toggleSidebarFile = function (event) {
+    event.preventDefault();
+
+    var group = this.parentNode.parentNode;
+    var file = group.getAttribute('data-file');
+    var collapsed = group.getAttribute('data-collapsed') !== 'true';
+    setSidebarFileCollapsed(group, collapsed);
+
+    var collapsedFiles = loadCollapsedSidebarFiles().filter(function (f) { return f !== file; });
+    if (collapsed) {
+        collapsedFiles.push(file);
+    }
+    saveCollapsedSidebarFiles(collapsedFiles);
+};
+
+var sidebarFiles = document.getElementsByClassName('sidebarfile');
+if (sidebarFiles.length > 0) {
+    var collapsedSidebarFiles = loadCollapsedSidebarFiles();
+    for (i = 0, l = sidebarFiles.length; i < l; i++) {
+        if (collapsedSidebarFiles.indexOf(sidebarFiles[i].getAttribute('data-file')) !== -1) {
+            setSidebarFileCollapsed(sidebarFiles[i], true);
+        }
+        sidebarFiles[i].querySelector('.togglesidebarfile').addEventListener('click', toggleSidebarFile);
+    }
+}
+
+/* Coverage by directory on the summary page: the rows are listed parents first, and a
+   row is hidden while any directory above it is collapsed. */
+var updateDirectoryRows = function (table) {
+    var rows = table.querySelectorAll('tr[data-path]');
+    var hideChildren = {};
+    for (var r = 0; r < rows.length; r++) {
+        var hide = hideChildren[rows[r].getAttribute('data-parent')] === true;
+        rows[r].style.display = hide ? 'none' : '';
+        hideChildren[rows[r].getAttribute('data-path')] = hide || rows[r].getAttribute('data-collapsed') === 'true';
+    }
+};
+
+var toggleDirectory = function (event) {
+    event.preventDefault();
+
+    var row = this.parentNode.parentNode;
+    var collapsed = row.getAttribute('data-collapsed') !== 'true';
+    row.setAttribute('data-collapsed', collapsed ? 'true' : 'false');
+    this.querySelector('i').className = collapsed ? 'icon-plus' : 'icon-minus';
+    updateDirectoryRows(row.parentNode);
+};
+
+var directoryToggles = document.getElementsByClassName('toggledirectory');
+for (i = 0, l = directoryToggles.length; i < l; i++) {
+    directoryToggles[i].addEventListener('click', toggleDirectory);
+}
+
+/* Risk hotspots page: sort the methods by a metric, highest values first; a second click
+   reverses the order. Methods without a value are listed last. */
+var sortRiskHotspots = function (event) {
+    event.preventDefault();
+
+    var column = parseInt(this.getAttribute('data-column'), 10) + 3;
+    var descending = this.getAttribute('data-order') !== 'desc';
+    var links = document.getElementsByClassName('sortriskhotspots');
+    for (var k = 0; k < links.length; k++) {
+        links[k].removeAttribute('data-order');
+        links[k].querySelector('i').className = 'icon-up-down-dir';
+    }
+    this.setAttribute('data-order', descending ? 'desc' : 'asc');
+    this.querySelector('i').className = descending ? 'icon-down-dir_active' : 'icon-up-dir_active';
+
+    var tbody = document.querySelector('table.riskhotspots tbody');
+    var rows = Array.prototype.slice.call(tbody.rows);
+    var value = function (row) {
+        var text = row.cells[column].getAttribute('data-value');
+        return text === '' ? null : parseFloat(text);
+    };
+    rows.sort(function (left, right) {
+        var a = value(left), b = value(right);
+        if (a === b) {
+            return 0;
+        }
+        if (a === null || b === null) {
+            return a === null ? 1 : -1;
+        }
+        return descending ? b - a : a - b;
+    });
+    for (var r = 0; r < rows.length; r++) {
+        tbody.appendChild(rows[r]);
+    }
+};
+
+var riskHotspotSortLinks = document.getElementsByClassName('sortriskhotspots');
+for (i = 0, l = riskHotspotSortLinks.length; i < l; i++) {
+    riskHotspotSortLinks[i].addEventListener('click', sortRiskHotspots);
+}
+
+/* On-demand class details (Html{classdetails=ondemand}): the links of the coverage table
+   point to #classdetails/classdetail_<n>.js and the class is shown by loading that script,
+   which calls window.loadClassDetail. Only hashes of this form are loaded, so a crafted
+   URL cannot make the page load other scripts. */
+var classDetailContainer = document.getElementById('classdetail');
+var classDetailHashPattern = /^#(classdetails\/classdetail_\d+\.js)$/;
+var loadedClassDetails = {};
+var requestedClassDetails = {};
+
+var escapeHtml = function (text) {
+    return String(text).replace(/[&<>"']/g, function (c) {
+        return { '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;' }[c];
+    });
+};
+
+/* Links to files and lines scroll to them instead of changing the hash, which selects the class */
+var scrollToClassDetailTarget = function (event) {
+    var target = document.getElementById(this.getAttribute('href').substring(1));
+    if (target !== null) {
+        event.preventDefault();
+        target.scrollIntoView();
+    }
+};
+
+/* The class details are rendered by the template of the class pages; their links and lines
+   are bound here since the handlers above only see the elements that exist on load */
+var renderClassDetail = function (detail) {
+    var container = classDetailContainer.firstChild, elements, e;
+
+    container.innerHTML = '<h1><a href="#" class="back">&lt;</a> ' + escapeHtml(detail.class.name) + '</h1>' + detail.html;
+
+    elements = container.getElementsByClassName('navigatetohash');
+    for (e = 0; e < elements.length; e++) {
+        elements[e].addEventListener('click', scrollToClassDetailTarget);
+    }
+    elements = container.getElementsByClassName('switchtestmethod');
+    for (e = 0; e < elements.length; e++) {
+        elements[e].addEventListener('change', switchTestMethod);
+    }
+    elements = container.getElementsByClassName('coverableline');
+    for (e = 0; e < elements.length; e++) {
+        elements[e].addEventListener('click', toggleLine);
+        elements[e].addEventListener('mouseenter', highlightTestMethods);
+        elements[e].addEventListener('mouseleave', unhighlightTestMethods);
+    }
+    elements = container.getElementsByClassName('toggleuncovered');
+    for (e = 0; e < elements.length; e++) {
+        elements[e].addEventListener('click', toggleUncoveredLines);
+    }
+    elements = container.querySelectorAll('.previousuncovered, .nextuncovered');
+    for (e = 0; e < elements.length; e++) {
+        elements[e].addEventListener('click', function (event) {
+            event.preventDefault();
+            jumpToUncoveredLine(this.className === 'nextuncovered');
+        });
+    }
+};
+
+var showClassDetail = function () {
+    var summary = document.querySelector('.container:not(.classdetail)');
+    var match = classDetailHashPattern.exec(window.location.hash);
+    if (match === null) {
+        classDetailContainer.style.display = 'none';
+        summary.style.display = '';
+        return;
+    }
+
+    var path = match[1];
+    if (loadedClassDetails[path] === undefined) {
+        if (!requestedClassDetails[path]) {
+            requestedClassDetails[path] = true;
+            var script = document.createElement('script');
+            script.src = path;
+            script.setAttribute('data-classdetail', path);
+            script.onerror = function () {
+                delete requestedClassDetails[path];
+                console.error('Failed to load class details from ' + path);
+            };
+            document.body.appendChild(script);
+        }
+        return;
+    }
+
+    renderClassDetail(loadedClassDetails[path]);
+    summary.style.display = 'none';
+    classDetailContainer.style.display = '';
+    window.scrollTo(0, 0);
+};
+
+if (classDetailContainer !== null) {
+    window.loadClassDetail = function (detail) {
+        var path = document.currentScript ? document.currentScript.getAttribute('data-classdetail') : null;
+        if (path !== null) {
+            loadedClassDetails[path] = detail;
+            showClassDetail();
+        }
+    };
+    window.addEventListener('hashchange', showClassDetail);
+    showClassDetail();
+}
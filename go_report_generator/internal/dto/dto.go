@@ -0,0 +1,288 @@
+// Package dto maps the internal coverage model to the documents of package api/v1. The
+// model may change freely; this mapping keeps the documents compatible.
+package dto
+
+import (
+	"math"
+	"time"
+
+	v1 "github.com/IgorBayerl/ReportGenerator/go_report_generator/api/v1"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+)
+
+// Summary returns the document of the merged coverage model.
+func Summary(summary *model.SummaryResult) v1.Summary {
+	return v1.Summary{
+		SchemaVersion:           v1.SchemaVersion,
+		ParserName:              summary.ParserName,
+		Timestamp:               summary.Timestamp,
+		SourceDirs:              summary.SourceDirs,
+		Assemblies:              Assemblies(summary.Assemblies),
+		Totals:                  totals(summary.LinesCovered, summary.LinesValid, summary.BranchesCovered, summary.BranchesValid),
+		TotalLines:              summary.TotalLines,
+		MethodCoverageAvailable: summary.MethodCoverageAvailable,
+		HiddenClasses:           summary.HiddenClasses,
+		ExternalFiles:           summary.ExternalFiles,
+		MissingSourceFiles:      missingSourceFiles(summary.MissingSourceFiles),
+		SkippedReports:          skippedReports(summary.SkippedReports),
+		Directories:             directory(summary.Directories),
+	}
+}
+
+// ParsedReport returns the document of the coverage model of a single report file.
+func ParsedReport(reportFile string, result *parsers.ParserResult) v1.ParsedReport {
+	report := v1.ParsedReport{
+		SchemaVersion:           v1.SchemaVersion,
+		ReportFile:              reportFile,
+		ParserName:              result.ParserName,
+		SourceDirs:              result.SourceDirectories,
+		SupportsBranchCoverage:  result.SupportsBranchCoverage,
+		MethodCoverageAvailable: result.MethodCoverageAvailable,
+		MinimumTimestamp:        timestamp(result.MinimumTimeStamp),
+		MaximumTimestamp:        timestamp(result.MaximumTimeStamp),
+		Assemblies:              Assemblies(result.Assemblies),
+		MissingSourceFiles:      missingSourceFiles(result.MissingSourceFiles),
+	}
+	if d := result.DeclaredTotals; d != nil {
+		report.DeclaredTotals = &v1.DeclaredTotals{
+			LinesCovered:    d.LinesCovered,
+			LinesValid:      d.LinesValid,
+			BranchesCovered: d.BranchesCovered,
+			BranchesValid:   d.BranchesValid,
+			LineRate:        d.LineRate,
+			BranchRate:      d.BranchRate,
+		}
+	}
+	return report
+}
+
+// Assemblies returns the documents of the assemblies, in the same order.
+func Assemblies(assemblies []model.Assembly) []v1.Assembly {
+	result := make([]v1.Assembly, len(assemblies))
+	for i, a := range assemblies {
+		result[i] = v1.Assembly{
+			Name:       a.Name,
+			Classes:    classes(a.Classes),
+			Totals:     totals(a.LinesCovered, a.LinesValid, a.BranchesCovered, a.BranchesValid),
+			TotalLines: a.TotalLines,
+			Complexity: a.Complexity,
+		}
+	}
+	return result
+}
+
+func classes(classes []model.Class) []v1.Class {
+	result := make([]v1.Class, len(classes))
+	for i, c := range classes {
+		class := v1.Class{
+			Name:                c.Name,
+			DisplayName:         c.DisplayName,
+			Files:               files(c.Files),
+			Methods:             methods(c.Methods),
+			Totals:              totals(c.LinesCovered, c.LinesValid, c.BranchesCovered, c.BranchesValid),
+			TotalLines:          c.TotalLines,
+			CoveredMethods:      c.CoveredMethods,
+			FullyCoveredMethods: c.FullyCoveredMethods,
+			TotalMethods:        c.TotalMethods,
+			Metrics:             c.Metrics,
+			Complexity:          c.Complexity,
+		}
+		for _, h := range c.HistoricCoverages {
+			class.HistoricCoverages = append(class.HistoricCoverages, v1.HistoricCoverage{
+				ExecutionTime:       h.ExecutionTime,
+				Tag:                 h.Tag,
+				CoveredLines:        h.CoveredLines,
+				CoverableLines:      h.CoverableLines,
+				TotalLines:          h.TotalLines,
+				CoveredBranches:     h.CoveredBranches,
+				TotalBranches:       h.TotalBranches,
+				CoveredMethods:      h.CoveredMethods,
+				FullyCoveredMethods: h.FullyCoveredMethods,
+				TotalMethods:        h.TotalMethods,
+			})
+		}
+		if a := c.CoverageAge; a != nil {
+			class.CoverageAge = &v1.CoverageAge{Threshold: a.Threshold, Runs: a.Runs, Since: a.Since}
+		}
+		result[i] = class
+	}
+	return result
+}
+
+func files(files []model.CodeFile) []v1.CodeFile {
+	result := make([]v1.CodeFile, len(files))
+	for i, f := range files {
+		file := v1.CodeFile{
+			Path:                      f.Path,
+			ReportPath:                f.ReportPath,
+			InSourceDirs:              f.InSourceDirs,
+			Lines:                     lines(f.Lines),
+			CoveredLines:              f.CoveredLines,
+			CoverableLines:            f.CoverableLines,
+			TotalLines:                f.TotalLines,
+			ApproximateBranchCoverage: f.ApproximateBranchCoverage,
+			MethodMetrics:             methodMetrics(f.MethodMetrics),
+		}
+		for _, e := range f.CodeElements {
+			elementType := v1.CodeElementMethod
+			if e.Type == model.PropertyElementType {
+				elementType = v1.CodeElementProperty
+			}
+			file.CodeElements = append(file.CodeElements, v1.CodeElement{
+				Name:          e.Name,
+				FullName:      e.FullName,
+				Type:          elementType,
+				FirstLine:     e.FirstLine,
+				LastLine:      e.LastLine,
+				CoverageQuota: e.CoverageQuota,
+				RawKey:        e.RawKey,
+			})
+		}
+		result[i] = file
+	}
+	return result
+}
+
+func methods(methods []model.Method) []v1.Method {
+	var result []v1.Method
+	for _, m := range methods {
+		method := v1.Method{
+			Name:            m.Name,
+			Signature:       m.Signature,
+			DisplayName:     m.DisplayName,
+			NestedClassName: m.NestedClassName,
+			FirstLine:       m.FirstLine,
+			LastLine:        m.LastLine,
+			LineRate:        m.LineRate,
+			BranchRate:      m.BranchRate,
+			Complexity:      m.Complexity,
+			MethodMetrics:   methodMetrics(m.MethodMetrics),
+		}
+		if len(m.Lines) > 0 {
+			method.Lines = lines(m.Lines)
+		}
+		result = append(result, method)
+	}
+	return result
+}
+
+var lineStatuses = map[model.LineVisitStatus]string{
+	model.NotCoverable:     v1.LineNotCoverable,
+	model.NotCovered:       v1.LineNotCovered,
+	model.PartiallyCovered: v1.LinePartiallyCovered,
+	model.Covered:          v1.LineCovered,
+}
+
+func lines(lines []model.Line) []v1.Line {
+	result := make([]v1.Line, len(lines))
+	for i, l := range lines {
+		line := v1.Line{
+			Number:            l.Number,
+			Hits:              l.Hits,
+			Status:            lineStatuses[l.LineVisitStatus],
+			Content:           l.Content,
+			IsBranchPoint:     l.IsBranchPoint,
+			CoveredBranches:   l.CoveredBranches,
+			TotalBranches:     l.TotalBranches,
+			ConditionCoverage: l.ConditionCoverage,
+			HitsByTest:        l.LineCoverageByTestMethod,
+		}
+		for _, b := range l.Branch {
+			line.Branches = append(line.Branches, v1.Branch{Identifier: b.Identifier, Visits: b.Visits, Type: b.Type})
+		}
+		result[i] = line
+	}
+	return result
+}
+
+var metricStatuses = map[model.MetricStatus]string{
+	model.StatusOk:      v1.MetricOk,
+	model.StatusWarning: v1.MetricWarning,
+	model.StatusError:   v1.MetricError,
+}
+
+func methodMetrics(methodMetrics []model.MethodMetric) []v1.MethodMetric {
+	var result []v1.MethodMetric
+	for _, mm := range methodMetrics {
+		metrics := make([]v1.Metric, len(mm.Metrics))
+		for i, m := range mm.Metrics {
+			metrics[i] = v1.Metric{Name: m.Name, Value: metricValue(m.Value), Status: metricStatuses[m.Status]}
+		}
+		result = append(result, v1.MethodMetric{Name: mm.Name, Line: mm.Line, Metrics: metrics})
+	}
+	return result
+}
+
+// metricValue returns the value of a metric as a number, nil if it is not a finite one.
+func metricValue(value any) *float64 {
+	var v float64
+	switch n := value.(type) {
+	case float64:
+		v = n
+	case float32:
+		v = float64(n)
+	case int:
+		v = float64(n)
+	case int64:
+		v = float64(n)
+	default:
+		return nil
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
+
+func totals(linesCovered, linesValid int, branchesCovered, branchesValid *int) v1.Totals {
+	t := v1.Totals{LinesCovered: linesCovered, LinesValid: linesValid}
+	if branchesCovered != nil && branchesValid != nil {
+		t.BranchesCovered, t.BranchesValid = branchesCovered, branchesValid
+	}
+	return t
+}
+
+func missingSourceFiles(missing []model.MissingSourceFile) []v1.MissingSourceFile {
+	var result []v1.MissingSourceFile
+	for _, m := range missing {
+		result = append(result, v1.MissingSourceFile{Path: m.Path, Assembly: m.Assembly, Class: m.Class})
+	}
+	return result
+}
+
+func skippedReports(skipped []model.SkippedReport) []v1.SkippedReport {
+	var result []v1.SkippedReport
+	for _, s := range skipped {
+		result = append(result, v1.SkippedReport{Path: s.Path, Parser: s.Parser, Error: s.Error})
+	}
+	return result
+}
+
+func directory(d *model.DirectoryCoverage) *v1.DirectoryCoverage {
+	if d == nil {
+		return nil
+	}
+	node := &v1.DirectoryCoverage{
+		Name:            d.Name,
+		Path:            d.Path,
+		Files:           d.Files,
+		LinesCovered:    d.LinesCovered,
+		LinesValid:      d.LinesValid,
+		BranchesCovered: d.BranchesCovered,
+		BranchesValid:   d.BranchesValid,
+		HasBranchData:   d.HasBranchData,
+	}
+	for _, child := range d.Children {
+		node.Children = append(node.Children, directory(child))
+	}
+	return node
+}
+
+// timestamp formats t as RFC 3339, "" if it is nil.
+func timestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	v1 "github.com/IgorBayerl/ReportGenerator/go_report_generator/api/v1"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+)
+
+func TestMetricValue(t *testing.T) {
+	for _, tc := range []struct {
+		value any
+		want  *float64
+	}{
+		{2.5, ptr(2.5)},
+		{3, ptr(3)},
+		{math.NaN(), nil},
+		{math.Inf(1), nil},
+		{"n/a", nil},
+	} {
+		got := metricValue(tc.value)
+		if (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+			t.Errorf("metricValue(%v) = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestSummary_EnumsAndBranches(t *testing.T) {
+	summary := &model.SummaryResult{
+		LinesCovered: 1, LinesValid: 2,
+		Assemblies: []model.Assembly{{Name: "App", Classes: []model.Class{{
+			Name: "App.Class",
+			Files: []model.CodeFile{{
+				Path:          "/src/app.go",
+				Lines:         []model.Line{model.NewLine(1, -1), model.NewLine(2, 0), model.NewBranchLine(3, 1, 1, 2), model.NewLine(4, 1)},
+				CodeElements:  []model.CodeElement{{Name: "Value", Type: model.PropertyElementType}},
+				MethodMetrics: []model.MethodMetric{{Name: "F", Metrics: []model.Metric{{Name: "CrapScore", Value: 40.0, Status: model.StatusWarning}}}},
+			}},
+		}}}},
+	}
+
+	got := Summary(summary)
+
+	if got.SchemaVersion != v1.SchemaVersion {
+		t.Errorf("schemaVersion = %q, want %q", got.SchemaVersion, v1.SchemaVersion)
+	}
+	if got.BranchesCovered != nil || got.BranchesValid != nil {
+		t.Error("expected no branch totals without branch data")
+	}
+	file := got.Assemblies[0].Classes[0].Files[0]
+	var statuses []string
+	for _, line := range file.Lines {
+		statuses = append(statuses, line.Status)
+	}
+	want := []string{v1.LineNotCoverable, v1.LineNotCovered, v1.LinePartiallyCovered, v1.LineCovered}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Errorf("line statuses = %v, want %v", statuses, want)
+			break
+		}
+	}
+	if file.CodeElements[0].Type != v1.CodeElementProperty {
+		t.Errorf("code element type = %q, want %q", file.CodeElements[0].Type, v1.CodeElementProperty)
+	}
+	if status := file.MethodMetrics[0].Metrics[0].Status; status != v1.MetricWarning {
+		t.Errorf("metric status = %q, want %q", status, v1.MetricWarning)
+	}
+}
+
+func TestParsedReport_Timestamps(t *testing.T) {
+	minimum := time.Date(2024, 5, 2, 8, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	got := ParsedReport("coverage.xml", &parsers.ParserResult{ParserName: "Cobertura", MinimumTimeStamp: &minimum})
+
+	if got.MinimumTimestamp != "2024-05-02T06:30:00Z" || got.MaximumTimestamp != "" {
+		t.Errorf("timestamps = %q, %q", got.MinimumTimestamp, got.MaximumTimestamp)
+	}
+	if got.ReportFile != "coverage.xml" || got.Assemblies == nil {
+		t.Errorf("unexpected report %+v", got)
+	}
+}
+
+func TestSummary_HistoryAndDiagnostics(t *testing.T) {
+	branchLine := model.NewBranchLine(1, 2, 1, 2)
+	branchLine.Branch = []model.BranchCoverageDetail{{Identifier: "0", Visits: 2, Type: "jump"}}
+	summary := &model.SummaryResult{
+		Assemblies: []model.Assembly{{Name: "App", Classes: []model.Class{{
+			Name:              "App.Class",
+			Files:             []model.CodeFile{{Path: "/src/app.go", Lines: []model.Line{branchLine}}},
+			HistoricCoverages: []model.HistoricCoverage{{ExecutionTime: 1700000000, Tag: "build-1", CoveredLines: 1, CoverableLines: 2, TotalLines: 3, CoveredBranches: 4, TotalBranches: 5, CoveredMethods: 6, FullyCoveredMethods: 7, TotalMethods: 8}},
+			CoverageAge:       &model.CoverageAge{Threshold: 80, Runs: 2, Since: 1700000000},
+		}}}},
+		MissingSourceFiles: []model.MissingSourceFile{{Path: "Gone.cs", Assembly: "App", Class: "App.Gone"}},
+		SkippedReports:     []model.SkippedReport{{Path: "broken.xml", Parser: "Cobertura", Error: "unexpected EOF"}},
+	}
+
+	got := Summary(summary)
+
+	class := got.Assemblies[0].Classes[0]
+	wantHistory := v1.HistoricCoverage{ExecutionTime: 1700000000, Tag: "build-1", CoveredLines: 1, CoverableLines: 2, TotalLines: 3, CoveredBranches: 4, TotalBranches: 5, CoveredMethods: 6, FullyCoveredMethods: 7, TotalMethods: 8}
+	if len(class.HistoricCoverages) != 1 || class.HistoricCoverages[0] != wantHistory {
+		t.Errorf("historicCoverages = %+v, want %+v", class.HistoricCoverages, wantHistory)
+	}
+	if wantAge := (v1.CoverageAge{Threshold: 80, Runs: 2, Since: 1700000000}); class.CoverageAge == nil || *class.CoverageAge != wantAge {
+		t.Errorf("coverageAge = %+v, want %+v", class.CoverageAge, wantAge)
+	}
+	if wantBranch := (v1.Branch{Identifier: "0", Visits: 2, Type: "jump"}); len(class.Files[0].Lines[0].Branches) != 1 || class.Files[0].Lines[0].Branches[0] != wantBranch {
+		t.Errorf("branches = %+v, want %+v", class.Files[0].Lines[0].Branches, wantBranch)
+	}
+	if wantMissing := (v1.MissingSourceFile{Path: "Gone.cs", Assembly: "App", Class: "App.Gone"}); len(got.MissingSourceFiles) != 1 || got.MissingSourceFiles[0] != wantMissing {
+		t.Errorf("missingSourceFiles = %+v, want %+v", got.MissingSourceFiles, wantMissing)
+	}
+	if wantSkipped := (v1.SkippedReport{Path: "broken.xml", Parser: "Cobertura", Error: "unexpected EOF"}); len(got.SkippedReports) != 1 || got.SkippedReports[0] != wantSkipped {
+		t.Errorf("skippedReports = %+v, want %+v", got.SkippedReports, wantSkipped)
+	}
+}
+
+func ptr(v float64) *float64 { return &v }
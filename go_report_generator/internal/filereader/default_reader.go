@@ -3,22 +3,58 @@ package filereader
 import (
 	"io/fs"
 	"os"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"golang.org/x/text/encoding"
 )
 
-type DefaultReader struct{}
+type DefaultReader struct {
+	// fallback decodes the source files that have no BOM and are not valid UTF-8, nil to
+	// keep their bytes as they are.
+	fallback encoding.Encoding
+}
+
+// Option configures a DefaultReader.
+type Option func(*DefaultReader)
+
+// WithFallbackEncoding sets the encoding of source files that have no BOM and are not
+// valid UTF-8 (settings.SourceFileEncoding).
+func WithFallbackEncoding(enc encoding.Encoding) Option {
+	return func(dr *DefaultReader) {
+		dr.fallback = enc
+	}
+}
+
+func NewDefaultReader(opts ...Option) Reader {
+	dr := &DefaultReader{}
+	for _, opt := range opts {
+		opt(dr)
+	}
+	return dr
+}
 
-func NewDefaultReader() Reader {
-	return &DefaultReader{}
+// NewSourceFileReader returns a DefaultReader that decodes the source files that have no
+// BOM and are not valid UTF-8 with the encoding of the given name
+// (settings.SourceFileEncoding), or keeps their bytes as they are if the name is empty.
+func NewSourceFileReader(encodingName string) (Reader, error) {
+	if encodingName == "" {
+		return NewDefaultReader(), nil
+	}
+	enc, err := LookupEncoding(encodingName)
+	if err != nil {
+		return nil, err
+	}
+	return NewDefaultReader(WithFallbackEncoding(enc)), nil
 }
 
 func (dr *DefaultReader) ReadFile(path string) ([]string, error) {
-	return ReadLinesInFile(path)
+	return ReadLinesInFile(path, dr.fallback)
 }
 
 func (dr *DefaultReader) CountLines(path string) (int, error) {
-	return CountLinesInFile(path)
+	return CountLinesInFile(path, dr.fallback)
 }
 
 func (dr *DefaultReader) Stat(name string) (fs.FileInfo, error) {
-	return os.Stat(name)
+	return os.Stat(utils.LongPath(name))
 }
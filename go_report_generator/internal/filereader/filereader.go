@@ -1,14 +1,17 @@
 package filereader
 
 import (
-	"bufio"
+	"bytes"
 	"fmt"
-	"io"
 	"io/fs"
 	"os"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
-	"golang.org/x/text/transform"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
 )
 
 type Reader interface {
@@ -17,49 +20,77 @@ type Reader interface {
 	Stat(name string) (fs.FileInfo, error)
 }
 
-func CountLinesInFile(filePath string) (int, error) {
-	file, err := os.Open(filePath)
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// LookupEncoding returns the encoding with the given name, e.g. "windows-1252" or
+// "iso-8859-1", as known from the WHATWG Encoding Standard.
+func LookupEncoding(name string) (encoding.Encoding, error) {
+	enc, err := htmlindex.Get(name)
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("unknown source file encoding %q: %w", name, err)
 	}
-	defer file.Close()
+	return enc, nil
+}
 
-	scanner := bufio.NewScanner(file)
-	lineCount := 0
-	for scanner.Scan() {
-		lineCount++
-	}
-	return lineCount, scanner.Err()
+// CountLinesInFile returns the number of lines of a source file, see ReadLinesInFile.
+func CountLinesInFile(filePath string, fallback encoding.Encoding) (int, error) {
+	lines, err := ReadLinesInFile(filePath, fallback)
+	return len(lines), err
 }
 
-func ReadLinesInFile(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
+// ReadLinesInFile returns the lines of a source file, decoded like DecodeSource does it
+// with fallback.
+func ReadLinesInFile(filePath string, fallback encoding.Encoding) ([]string, error) {
+	content, err := os.ReadFile(utils.LongPath(filePath))
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	// Attempt to detect encoding
-	detectedEncoding, err := utils.DetectEncoding(filePath)
+	text, err := DecodeSource(content, fallback)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not detect encoding for %s: %v. Assuming UTF-8.\n", filePath, err)
+		return nil, fmt.Errorf("failed to decode %s: %w", filePath, err)
 	}
+	return SplitLines(text), nil
+}
 
-	var reader io.Reader = file
-	if detectedEncoding != nil {
-		// Rewind file to beginning as DetectEncoding reads a few bytes
-		_, seekErr := file.Seek(0, io.SeekStart)
-		if seekErr != nil {
-			return nil, fmt.Errorf("failed to seek file %s after encoding detection: %w", filePath, seekErr)
-		}
-		decoder := detectedEncoding.NewDecoder()
-		reader = transform.NewReader(file, decoder)
+// DecodeSource returns the text of a source file. A UTF-8 BOM is removed and UTF-16
+// files with a BOM are decoded. Other content that is not valid UTF-8 is decoded with
+// fallback, or kept as it is if fallback is nil.
+func DecodeSource(content []byte, fallback encoding.Encoding) (string, error) {
+	var decoder *encoding.Decoder
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		return string(content[len(utf8BOM):]), nil
+	case bytes.HasPrefix(content, utf16LEBOM):
+		decoder = unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder()
+	case bytes.HasPrefix(content, utf16BEBOM):
+		decoder = unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder()
+	case utf8.Valid(content) || fallback == nil:
+		return string(content), nil
+	default:
+		decoder = fallback.NewDecoder()
+	}
+	decoded, err := decoder.Bytes(content)
+	if err != nil {
+		return "", err
 	}
+	return string(decoded), nil
+}
 
-	var lines []string
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+// SplitLines splits the text of a source file into lines. This is the one line rule of
+// ReadLinesInFile and CountLinesInFile: a line ends with "\n" or "\r\n", which is not
+// part of the line, and a terminator at the end of the text does not start another
+// line. So "a\nb" and "a\r\nb\r\n" both have two lines, and an empty text has none.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
 	}
-	return lines, scanner.Err()
+	return lines
 }
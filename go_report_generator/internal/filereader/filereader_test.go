@@ -0,0 +1,134 @@
+package filereader
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// utf16 encodes s as UTF-16 with a BOM, in little or big endian byte order.
+func utf16(s string, bigEndian bool) []byte {
+	content := []byte{0xFF, 0xFE}
+	if bigEndian {
+		content = []byte{0xFE, 0xFF}
+	}
+	for _, r := range s {
+		if bigEndian {
+			content = append(content, byte(r>>8), byte(r))
+		} else {
+			content = append(content, byte(r), byte(r>>8))
+		}
+	}
+	return content
+}
+
+func TestReadLinesInFile_Encodings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  []byte
+		fallback bool // Decode with windows-1252 if not UTF-8
+		want     []string
+	}{
+		{name: "UTF8", content: []byte("int a;\nstring b = \"ä\";\n"), want: []string{"int a;", "string b = \"ä\";"}},
+		{name: "UTF8NoTrailingNewline", content: []byte("int a;\nint b;"), want: []string{"int a;", "int b;"}},
+		{name: "UTF8BOM", content: []byte("\xEF\xBB\xBFint a;\nint b;\n"), want: []string{"int a;", "int b;"}},
+		{name: "CRLF", content: []byte("int a;\r\n\r\nint b;\r\n"), want: []string{"int a;", "", "int b;"}},
+		{name: "CRLFNoTrailingNewline", content: []byte("int a;\r\nint b;"), want: []string{"int a;", "int b;"}},
+		{name: "UTF16LEBOM", content: utf16("int ä;\r\nint b;\r\n", false), want: []string{"int ä;", "int b;"}},
+		{name: "UTF16BEBOM", content: utf16("int ä;\nint b;", true), want: []string{"int ä;", "int b;"}},
+		{name: "Windows1252Fallback", content: []byte("// Gr\xFC\xDFe\r\nint a;\r\n"), fallback: true, want: []string{"// Grüße", "int a;"}},
+		{name: "UTF8WithFallback", content: []byte("// Grüße\n"), fallback: true, want: []string{"// Grüße"}},
+		{name: "InvalidUTF8WithoutFallback", content: []byte("// Gr\xFC\xDFe\n"), want: []string{"// Gr\xFC\xDFe"}},
+		{name: "TrailingEmptyLine", content: []byte("int a;\n\n"), want: []string{"int a;", ""}},
+		{name: "OnlyNewline", content: []byte("\n"), want: []string{""}},
+		{name: "Empty", content: []byte{}, want: nil},
+		{name: "OnlyBOM", content: []byte("\xEF\xBB\xBF"), want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fallback encoding.Encoding
+			if tc.fallback {
+				fallback = charmap.Windows1252
+			}
+			path := filepath.Join(t.TempDir(), "source.cs")
+			if err := os.WriteFile(path, tc.content, 0o644); err != nil {
+				t.Fatalf("failed to write fixture: %v", err)
+			}
+
+			lines, err := ReadLinesInFile(path, fallback)
+			if err != nil {
+				t.Fatalf("ReadLinesInFile returned error: %v", err)
+			}
+			if !slices.Equal(lines, tc.want) {
+				t.Errorf("ReadLinesInFile = %q, want %q", lines, tc.want)
+			}
+
+			count, err := CountLinesInFile(path, fallback)
+			if err != nil {
+				t.Fatalf("CountLinesInFile returned error: %v", err)
+			}
+			if count != len(tc.want) {
+				t.Errorf("CountLinesInFile = %d, want %d like the lines read", count, len(tc.want))
+			}
+		})
+	}
+}
+
+func TestNewSourceFileReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "source.cs")
+	if err := os.WriteFile(path, []byte("// Gr\xFC\xDFe\n"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	for name, want := range map[string]string{"windows-1252": "// Grüße", "": "// Gr\xFC\xDFe"} {
+		reader, err := NewSourceFileReader(name)
+		if err != nil {
+			t.Fatalf("NewSourceFileReader(%q) returned error: %v", name, err)
+		}
+		lines, err := reader.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile returned error: %v", err)
+		}
+		if !slices.Equal(lines, []string{want}) {
+			t.Errorf("ReadFile with encoding %q = %q, want %q", name, lines, want)
+		}
+	}
+	if _, err := NewSourceFileReader("klingon"); err == nil {
+		t.Error("expected an error for an unknown encoding")
+	}
+}
+
+func TestReadLinesInFile_LongLines(t *testing.T) {
+	long := make([]byte, 200*1024)
+	for i := range long {
+		long[i] = 'x'
+	}
+	path := filepath.Join(t.TempDir(), "minified.js")
+	if err := os.WriteFile(path, append(long, "\nshort\n"...), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	lines, err := ReadLinesInFile(path, nil)
+	if err != nil {
+		t.Fatalf("ReadLinesInFile returned error: %v", err)
+	}
+	if len(lines) != 2 || len(lines[0]) != len(long) || lines[1] != "short" {
+		t.Errorf("expected a line of %d bytes and \"short\", got %d lines", len(long), len(lines))
+	}
+}
+
+func TestLookupEncoding(t *testing.T) {
+	for _, name := range []string{"windows-1252", "ISO-8859-1", "latin1", "shift_jis"} {
+		if _, err := LookupEncoding(name); err != nil {
+			t.Errorf("LookupEncoding(%q) returned error: %v", name, err)
+		}
+	}
+	if _, err := LookupEncoding("klingon"); err == nil {
+		t.Error("expected an error for an unknown encoding")
+	}
+}
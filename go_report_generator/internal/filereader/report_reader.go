@@ -0,0 +1,59 @@
+package filereader
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// gzipMagic is the two byte header every gzip stream starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// reportReadCloser couples the (possibly decompressing) reader with the
+// underlying file so that both are released on Close.
+type reportReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *reportReadCloser) Close() error {
+	var firstErr error
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// OpenReport opens a coverage report for reading. Gzip-compressed reports are
+// detected by their magic bytes (not by extension) and decompressed transparently,
+// so parsers always see the plain report content. Files that are still locked by the
+// process that wrote them are retried according to retry.
+func OpenReport(path string, retry utils.FileRetryPolicy) (io.ReadCloser, error) {
+	f, err := retry.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	buffered := bufio.NewReader(f)
+	header, err := buffered.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		f.Close()
+		return nil, fmt.Errorf("read header of %s: %w", path, err)
+	}
+
+	if len(header) == len(gzipMagic) && header[0] == gzipMagic[0] && header[1] == gzipMagic[1] {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("open gzip stream %s: %w", path, err)
+		}
+		return &reportReadCloser{Reader: gz, closers: []io.Closer{gz, f}}, nil
+	}
+
+	return &reportReadCloser{Reader: buffered, closers: []io.Closer{f}}, nil
+}
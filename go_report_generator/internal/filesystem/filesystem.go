@@ -7,6 +7,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
 // Platformer provides a way for filesystem implementations to indicate
@@ -73,10 +75,10 @@ type Filesystem interface {
 type DefaultFS struct{}
 
 // Stat returns a FileInfo describing the named file using os.Stat.
-func (DefaultFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+func (DefaultFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(utils.LongPath(name)) }
 
 // ReadDir reads the named directory using os.ReadDir.
-func (DefaultFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (DefaultFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(utils.LongPath(name)) }
 
 // Getwd returns the current working directory using os.Getwd.
 func (DefaultFS) Getwd() (string, error) { return os.Getwd() }
@@ -91,10 +93,10 @@ func (DefaultFS) MkdirAll(path string, perm fs.FileMode) error { return os.Mkdir
 func (DefaultFS) Create(path string) (io.WriteCloser, error) { return os.Create(path) }
 
 // Open opens the named file for reading using os.Open.
-func (DefaultFS) Open(path string) (fs.File, error) { return os.Open(path) }
+func (DefaultFS) Open(path string) (fs.File, error) { return os.Open(utils.LongPath(path)) }
 
 // ReadFile reads the named file and returns the contents using os.ReadFile.
-func (DefaultFS) ReadFile(path string) ([]byte, error) { return os.ReadFile(path) }
+func (DefaultFS) ReadFile(path string) ([]byte, error) { return os.ReadFile(utils.LongPath(path)) }
 
 // WriteFile writes data to the named file using os.WriteFile.
 func (DefaultFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
@@ -23,6 +23,13 @@
 //
 //   - `?` (Wildcard): Matches exactly one character.
 //
+//   - `/regex/` (Regular expression): A pattern wrapped in slashes is compiled as a
+//     Go regular expression instead of a wildcard pattern, e.g. `-/.*\.Generated\..*/`.
+//     The expression is not anchored implicitly; use `^` and `$` to match the whole name.
+//
+// Wildcard and regex elements can be mixed freely. Exclusion always wins over
+// inclusion, regardless of which form either filter uses.
+//
 // All pattern matching is case-insensitive by default.
 package filtering
 
@@ -39,6 +46,12 @@ type IFilter interface {
 	// AND does not match any exclude filter.
 	IsElementIncludedInReport(name string) bool
 
+	// IsAnyNameIncludedInReport decides for an element that is known by several names,
+	// e.g. a method by its own name and by the name qualified with its class. The element
+	// is included if any of the names matches an include filter and none of them matches
+	// an exclude filter.
+	IsAnyNameIncludedInReport(names ...string) bool
+
 	// HasCustomFilters returns true if the filter was created with specific
 	// user-defined rules (i.e., any '+' or '-' filters). It returns false if
 	// the filter is using the default "include all" behavior.
@@ -125,12 +138,32 @@ func (df *DefaultFilter) IsElementIncludedInReport(name string) bool {
 	return false
 }
 
+func (df *DefaultFilter) IsAnyNameIncludedInReport(names ...string) bool {
+	for _, excludeRe := range df.excludeFilters {
+		for _, name := range names {
+			if excludeRe.MatchString(name) {
+				return false
+			}
+		}
+	}
+
+	for _, includeRe := range df.includeFilters {
+		for _, name := range names {
+			if includeRe.MatchString(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (df *DefaultFilter) HasCustomFilters() bool {
 	return df.hasCustom
 }
 
 // createFilterRegex converts a filter string (e.g., "+MyNamespace.*") to a regular expression.
-// It handles escaping and wildcard conversion.
+// It handles escaping and wildcard conversion. Patterns wrapped in slashes
+// (e.g., "-/.*Tests$/") are compiled verbatim as regular expressions.
 func createFilterRegex(filter string, osIndependantPathSeparator bool) (*regexp.Regexp, error) {
 	if len(filter) == 0 {
 		return nil, fmt.Errorf("empty filter string")
@@ -138,6 +171,10 @@ func createFilterRegex(filter string, osIndependantPathSeparator bool) (*regexp.
 	// Remove '+' or '-' prefix to get the pattern.
 	pattern := filter[1:]
 
+	if isRegexPattern(pattern) {
+		return regexp.Compile("(?i)" + pattern[1:len(pattern)-1])
+	}
+
 	// Validate for balanced brackets before quoting, as QuoteMeta would escape them.
 	if strings.Count(pattern, "[") != strings.Count(pattern, "]") {
 		return nil, fmt.Errorf("unbalanced brackets in filter pattern '%s'", pattern)
@@ -164,3 +201,43 @@ func createFilterRegex(filter string, osIndependantPathSeparator bool) (*regexp.
 	// to match the entire string `^...$`.
 	return regexp.Compile("(?i)^" + pattern + "$")
 }
+
+// isRegexPattern reports whether a filter pattern uses the `/regex/` form.
+func isRegexPattern(pattern string) bool {
+	return len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/")
+}
+
+// All returns a filter that includes an element only if every filter includes it, e.g.
+// to add default excludes to the filters of the user without replacing them.
+func All(filters ...IFilter) IFilter {
+	return allFilter(filters)
+}
+
+type allFilter []IFilter
+
+func (a allFilter) IsElementIncludedInReport(name string) bool {
+	for _, f := range a {
+		if !f.IsElementIncludedInReport(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a allFilter) IsAnyNameIncludedInReport(names ...string) bool {
+	for _, f := range a {
+		if !f.IsAnyNameIncludedInReport(names...) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a allFilter) HasCustomFilters() bool {
+	for _, f := range a {
+		if f.HasCustomFilters() {
+			return true
+		}
+	}
+	return false
+}
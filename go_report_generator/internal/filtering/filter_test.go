@@ -123,6 +123,58 @@ func TestIsElementIncludedInReport(t *testing.T) {
 			elementName:        "C:\\Projects\\MyProject\\Tests\\test.cs",
 			expectedIsIncluded: false,
 		},
+
+		// --- Regex Filters ---
+		{
+			name:               "RegexExclude_ClassContainingGenerated_ReturnsFalse",
+			filters:            []string{`-/.*\.Generated\..*/`, "+*"},
+			elementName:        "MyProject.Generated.Proxy",
+			expectedIsIncluded: false,
+		},
+		{
+			name:               "RegexExclude_ClassNotMatching_ReturnsTrue",
+			filters:            []string{`-/.*\.Generated\..*/`, "+*"},
+			elementName:        "MyProject.Core.Service",
+			expectedIsIncluded: true,
+		},
+		{
+			name:               "RegexExcludeWins_OverWildcardInclude_ReturnsFalse",
+			filters:            []string{"+MyProject.*", `-/Tests$/`},
+			elementName:        "MyProject.Core.Tests",
+			expectedIsIncluded: false,
+		},
+		{
+			name:               "WildcardExcludeWins_OverRegexInclude_ReturnsFalse",
+			filters:            []string{`+/^MyProject\./`, "-*.Tests"},
+			elementName:        "MyProject.Core.Tests",
+			expectedIsIncluded: false,
+		},
+		{
+			name:               "RegexInclude_AssemblyAlternation_ReturnsTrue",
+			filters:            []string{`+/^(Core|Data)$/`, "-Legacy*"},
+			elementName:        "data",
+			expectedIsIncluded: true,
+		},
+		{
+			name:               "RegexInclude_AssemblyNotMatchingAnchors_ReturnsFalse",
+			filters:            []string{`+/^(Core|Data)$/`, "-Legacy*"},
+			elementName:        "Core.Extensions",
+			expectedIsIncluded: false,
+		},
+		{
+			name:               "RegexExclude_FileWithWindowsPath_ReturnsFalse",
+			filters:            []string{"+*", `-/[/\\]obj[/\\]/`},
+			pathSeparator:      true,
+			elementName:        "C:\\Projects\\MyProject\\obj\\Generated.cs",
+			expectedIsIncluded: false,
+		},
+		{
+			name:               "RegexExclude_FileMixedWithWildcardInclude_ReturnsTrue",
+			filters:            []string{"+*/src/*", `-/_test\.go$/`},
+			pathSeparator:      true,
+			elementName:        "/home/user/project/src/service.go",
+			expectedIsIncluded: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -144,6 +196,70 @@ func TestIsElementIncludedInReport(t *testing.T) {
 	}
 }
 
+// TestIsAnyNameIncludedInReport tests the filtering of elements known by several names.
+func TestIsAnyNameIncludedInReport(t *testing.T) {
+	testCases := []struct {
+		name               string
+		filters            []string
+		elementNames       []string
+		expectedIsIncluded bool
+	}{
+		{
+			name:               "NoFilters_ReturnsTrue",
+			elementNames:       []string{"get_Name()", "Shop.Cart.get_Name()"},
+			expectedIsIncluded: true,
+		},
+		{
+			name:               "ExcludeMatchingFirstName_ReturnsFalse",
+			filters:            []string{"-get_*"},
+			elementNames:       []string{"get_Name()", "Shop.Cart.get_Name()"},
+			expectedIsIncluded: false,
+		},
+		{
+			name:               "ExcludeMatchingSecondName_ReturnsFalse",
+			filters:            []string{"-*.Equals(*)"},
+			elementNames:       []string{"Equals(System.Object)", "Shop.Cart.Equals(System.Object)"},
+			expectedIsIncluded: false,
+		},
+		{
+			name:               "IncludeMatchingOneName_ReturnsTrue",
+			filters:            []string{"+Shop.*"},
+			elementNames:       []string{"Add()", "Shop.Cart.Add()"},
+			expectedIsIncluded: true,
+		},
+		{
+			name:               "IncludeMatchingNoName_ReturnsFalse",
+			filters:            []string{"+Billing.*"},
+			elementNames:       []string{"Add()", "Shop.Cart.Add()"},
+			expectedIsIncluded: false,
+		},
+		{
+			name:               "ExcludeWinsOverIncludeOfOtherName_ReturnsFalse",
+			filters:            []string{"+Shop.*", "-ToString()"},
+			elementNames:       []string{"ToString()", "Shop.Cart.ToString()"},
+			expectedIsIncluded: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			filter, err := NewDefaultFilter(tc.filters)
+			if err != nil {
+				t.Fatalf("Test setup failed. NewDefaultFilter returned an unexpected error: %v", err)
+			}
+
+			// Act
+			isIncluded := filter.IsAnyNameIncludedInReport(tc.elementNames...)
+
+			// Assert
+			if isIncluded != tc.expectedIsIncluded {
+				t.Errorf("Expected IsAnyNameIncludedInReport to be %v, but got %v", tc.expectedIsIncluded, isIncluded)
+			}
+		})
+	}
+}
+
 // TestNewDefaultFilter tests the constructor for validation and state initialization.
 func TestNewDefaultFilter(t *testing.T) {
 	testCases := []struct {
@@ -182,6 +298,18 @@ func TestNewDefaultFilter(t *testing.T) {
 			expectError:       true,
 			expectedHasCustom: false,
 		},
+		{
+			name:              "ValidRegexFilter_NoError_HasCustomIsTrue",
+			filters:           []string{`-/.*\.Generated\..*/`, "+*"},
+			expectError:       false,
+			expectedHasCustom: true,
+		},
+		{
+			name:              "InvalidRegexSyntax_ReturnsError",
+			filters:           []string{"-/(unclosed/"},
+			expectError:       true,
+			expectedHasCustom: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -208,3 +336,32 @@ func TestNewDefaultFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestAll_IncludesOnlyWhatEveryFilterIncludes(t *testing.T) {
+	user, _ := NewDefaultFilter([]string{"+MyProject.*"})
+	defaults, _ := NewDefaultFilter([]string{"-*.Tests"})
+	filter := All(user, defaults)
+
+	testCases := map[string]bool{
+		"MyProject.Core":    true,
+		"MyProject.Tests":   false,
+		"Other.Core":        false,
+		"MyProject.Testing": true,
+	}
+	for name, want := range testCases {
+		if got := filter.IsElementIncludedInReport(name); got != want {
+			t.Errorf("IsElementIncludedInReport(%q) = %v, want %v", name, got, want)
+		}
+		if got := filter.IsAnyNameIncludedInReport(name); got != want {
+			t.Errorf("IsAnyNameIncludedInReport(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	noFilters, _ := NewDefaultFilter(nil)
+	if All(noFilters, noFilters).HasCustomFilters() {
+		t.Error("expected no custom filters if no filter has any")
+	}
+	if !All(noFilters, defaults).HasCustomFilters() {
+		t.Error("expected custom filters if any filter has some")
+	}
+}
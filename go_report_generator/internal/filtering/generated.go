@@ -0,0 +1,100 @@
+package filtering
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// generatedFileNameSuffixes are the (lower-case) file name endings of common code generators.
+var generatedFileNameSuffixes = []string{
+	".pb.go",       // protoc-gen-go
+	".pb.gw.go",    // grpc-gateway
+	"_mock.go",     // mockgen, mockery
+	".g.cs",        // Roslyn source generators, ANTLR
+	".g.i.cs",      // WPF
+	".designer.cs", // WinForms, resources
+}
+
+// generatedCodeHeaderRegex matches the Go convention for generated files, see
+// https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source.
+var generatedCodeHeaderRegex = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// maxGeneratedHeaderLines bounds how far into a Go file the header comment is searched.
+const maxGeneratedHeaderLines = 30
+
+// IsGeneratedFileName reports whether the file name follows a common naming pattern of
+// generated code, e.g. "api.pb.go", "Form1.Designer.cs" or "schema.generated.ts".
+func IsGeneratedFileName(filePath string) bool {
+	name := strings.ToLower(path.Base(strings.ReplaceAll(filePath, "\\", "/")))
+	for _, suffix := range generatedFileNameSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return strings.Contains(name, ".generated.")
+}
+
+// HasGeneratedCodeHeader reports whether the lines of a Go file contain the canonical
+// "// Code generated ... DO NOT EDIT." comment before the package clause.
+func HasGeneratedCodeHeader(lines []string) bool {
+	for i, line := range lines {
+		if i >= maxGeneratedHeaderLines {
+			break
+		}
+		line = strings.TrimRight(line, "\r")
+		if generatedCodeHeaderRegex.MatchString(line) {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+	}
+	return false
+}
+
+// GeneratedCodeDetector recognizes generated source files and remembers which files it
+// excluded. Results are cached per path, as several classes may share a file.
+type GeneratedCodeDetector struct {
+	readFile func(path string) ([]string, error)
+	results  map[string]bool
+	excluded int
+}
+
+// NewGeneratedCodeDetector creates a detector that reads Go files with readFile to
+// look for the generated code header.
+func NewGeneratedCodeDetector(readFile func(path string) ([]string, error)) *GeneratedCodeDetector {
+	return &GeneratedCodeDetector{
+		readFile: readFile,
+		results:  make(map[string]bool),
+	}
+}
+
+// IsGenerated reports whether the file at reportPath is generated code. resolve is only
+// called for Go files whose name is inconclusive and returns the path of the source file
+// on disk, or "" if it cannot be found.
+func (d *GeneratedCodeDetector) IsGenerated(reportPath string, resolve func() string) bool {
+	if generated, ok := d.results[reportPath]; ok {
+		return generated
+	}
+
+	generated := IsGeneratedFileName(reportPath)
+	if !generated && strings.EqualFold(path.Ext(reportPath), ".go") {
+		if resolvedPath := resolve(); resolvedPath != "" {
+			if lines, err := d.readFile(resolvedPath); err == nil {
+				generated = HasGeneratedCodeHeader(lines)
+			}
+		}
+	}
+
+	d.results[reportPath] = generated
+	if generated {
+		d.excluded++
+	}
+	return generated
+}
+
+// ExcludedCount returns the number of distinct files recognized as generated code.
+func (d *GeneratedCodeDetector) ExcludedCount() int {
+	return d.excluded
+}
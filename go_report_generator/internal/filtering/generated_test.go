@@ -0,0 +1,111 @@
+package filtering
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsGeneratedFileName(t *testing.T) {
+	testCases := []struct {
+		filePath string
+		expected bool
+	}{
+		{"api/v1/service.pb.go", true},
+		{"api/v1/service.pb.gw.go", true},
+		{"internal/store/store_mock.go", true},
+		{`C:\src\App\Form1.Designer.cs`, true},
+		{"Parser.g.cs", true},
+		{"MainWindow.g.i.cs", true},
+		{"web/schema.generated.ts", true},
+		{"internal/service/service.go", false},
+		{"Designer/Form1.cs", false},
+		{"pb.go/handler.go", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filePath, func(t *testing.T) {
+			if got := IsGeneratedFileName(tc.filePath); got != tc.expected {
+				t.Errorf("IsGeneratedFileName(%q) = %v, want %v", tc.filePath, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHasGeneratedCodeHeader(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lines    []string
+		expected bool
+	}{
+		{
+			name:     "ProtocHeader",
+			lines:    []string{"// Code generated by protoc-gen-go. DO NOT EDIT.", "// versions:", "", "package v1"},
+			expected: true,
+		},
+		{
+			name:     "HeaderAfterBuildTag",
+			lines:    []string{"//go:build linux", "", "// Code generated by stringer -type=Kind; DO NOT EDIT.\r", "package kind"},
+			expected: true,
+		},
+		{
+			name:     "HeaderAfterPackageClause",
+			lines:    []string{"package main", "// Code generated by hand. DO NOT EDIT."},
+			expected: false,
+		},
+		{
+			name:     "SimilarComment",
+			lines:    []string{"// Code generated by hand, feel free to edit.", "package main"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := HasGeneratedCodeHeader(tc.lines); got != tc.expected {
+				t.Errorf("HasGeneratedCodeHeader() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGeneratedCodeDetector_IsGenerated(t *testing.T) {
+	files := map[string][]string{
+		"/src/api/service.go": {"// Code generated by protoc-gen-go. DO NOT EDIT.", "package api"},
+		"/src/api/handler.go": {"package api"},
+	}
+	reads := 0
+	detector := NewGeneratedCodeDetector(func(path string) ([]string, error) {
+		reads++
+		if lines, ok := files[path]; ok {
+			return lines, nil
+		}
+		return nil, errors.New("file not found")
+	})
+	resolve := func(path string) func() string { return func() string { return "/src/" + path } }
+
+	if !detector.IsGenerated("api/service.go", resolve("api/service.go")) {
+		t.Error("expected file with generated code header to be detected")
+	}
+	if !detector.IsGenerated("api/service.go", resolve("api/service.go")) {
+		t.Error("expected cached result for file with generated code header")
+	}
+	if detector.IsGenerated("api/handler.go", resolve("api/handler.go")) {
+		t.Error("expected regular file not to be detected")
+	}
+	if detector.IsGenerated("api/missing.go", resolve("api/missing.go")) {
+		t.Error("expected unreadable file not to be detected")
+	}
+	if !detector.IsGenerated("api/service.pb.go", func() string {
+		t.Error("resolve must not be called for generated file names")
+		return ""
+	}) {
+		t.Error("expected generated file name to be detected")
+	}
+
+	if reads != 3 {
+		t.Errorf("expected 3 file reads, got %d", reads)
+	}
+	if got := detector.ExcludedCount(); got != 2 {
+		t.Errorf("ExcludedCount() = %d, want 2", got)
+	}
+}
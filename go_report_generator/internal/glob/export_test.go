@@ -0,0 +1,9 @@
+package glob
+
+// The Windows path helpers are exported to the external tests, so that the mock
+// filesystem resolves Windows paths the same way on every host OS.
+var (
+	WindowsClean = windowsClean
+	WindowsDir   = windowsDir
+	WindowsJoin  = windowsJoin
+)
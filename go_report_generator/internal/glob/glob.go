@@ -12,6 +12,7 @@ package glob
 
 import (
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path"
@@ -77,14 +78,14 @@ type Glob struct {
 
 func (g *Glob) joinPath(elem1, elem2 string) string {
 	if g.platform == "windows" {
-		return filepath.Join(elem1, elem2)
+		return windowsJoin(elem1, elem2)
 	}
 	return path.Join(elem1, elem2) // always “/”
 }
 
 func (g *Glob) parentDir(p string) string {
 	if g.platform == "windows" {
-		return filepath.Dir(p)
+		return windowsDir(p)
 	}
 	return path.Dir(p)
 }
@@ -101,7 +102,7 @@ func (g *Glob) absForPlatform(p string) (string, error) {
 		return "", err
 	}
 	if g.platform == "windows" {
-		return filepath.Join(cwd, g.normalizePathForFS(p)), nil
+		return windowsJoin(cwd, g.normalizePathForFS(p)), nil
 	}
 	// unix use the slash variant only
 	return path.Clean(path.Join(g.normalizePathForPattern(cwd), p)), nil
@@ -162,17 +163,12 @@ func (g *Glob) Expand() ([]string, error) {
 }
 
 func (g *Glob) tryWindowsCaseFold(absPath string, dirOnly bool) ([]string, error) {
-	clean := filepath.Clean(absPath)
-	vol := ""
-	rest := clean
-	if len(clean) >= 2 && clean[1] == ':' {
-		vol = clean[:2]  // "C:"
-		rest = clean[2:] // everything after the drive
-	}
-	rest = strings.TrimPrefix(rest, `\`)
+	clean := windowsClean(absPath)
+	vol := windowsVolume(clean) // "C:" or `\\server\share`
+	rest := strings.TrimPrefix(clean[len(vol):], `\`)
 	parts := strings.Split(rest, `\`)
 
-	// Start at the root directory (e.g. "C:\").
+	// Start at the root directory (e.g. "C:\" or `\\server\share\`).
 	cur := vol + `\`
 
 	// Walk every segment and pick the real-cased name.
@@ -283,6 +279,17 @@ func (g *Glob) normalizePathForPattern(p string) string {
 	return strings.ReplaceAll(p, "\\", "/")
 }
 
+// splitVolume splits a slash-separated pattern into its Windows volume (e.g. "C:" or
+// "//server/share") and the rest. The volume is never matched as a pattern, so the "?"
+// of a `\\?\` long path prefix is not a wildcard. Unix patterns have no volume.
+func (g *Glob) splitVolume(normalizedPattern string) (string, string) {
+	if g.platform != "windows" {
+		return "", normalizedPattern
+	}
+	vol := windowsVolume(g.normalizePathForFS(normalizedPattern))
+	return normalizedPattern[:len(vol)], normalizedPattern[len(vol):]
+}
+
 // expandInternal is the core recursive matching function.
 // It returns a slice of absolute paths for matched files or directories.
 // `pattern` is the current glob pattern being processed.
@@ -295,8 +302,10 @@ func (g *Glob) expandInternal(pattern string, dirOnly bool) ([]string, error) {
 	// Normalize pattern for internal processing (always use forward slashes)
 	normalizedPattern := g.normalizePathForPattern(pattern)
 
+	vol, rest := g.splitVolume(normalizedPattern)
+
 	// Handle literal paths (no glob characters)
-	if !strings.ContainsAny(normalizedPattern, string(globCharacters)) {
+	if !strings.ContainsAny(rest, string(globCharacters)) {
 		absPath, err := g.absForPlatform(pattern)
 		if err != nil {
 			return nil, err
@@ -316,61 +325,70 @@ func (g *Glob) expandInternal(pattern string, dirOnly bool) ([]string, error) {
 		return []string{}, nil
 	}
 
-	// Split path into parent and child components
-	parent := path.Dir(normalizedPattern)
-	child := path.Base(normalizedPattern)
+	segments, crossesSeparator := splitSegments(rest)
+	if crossesSeparator {
+		return g.handleCrossSeparatorBrace(normalizedPattern, dirOnly)
+	}
 
-	// Handle root directory case
-	if parent == "." && !g.isAbsolutePath(normalizedPattern) {
-		cwd, err := g.FS.Getwd()
-		if err != nil {
-			return []string{}, fmt.Errorf("failed to get working directory: %w", err)
-		}
-		parent = g.normalizePathForPattern(cwd)
+	// The leading segments without wildcards are resolved as one literal path, which
+	// needs no directory listings and finds the real case of the names on Windows.
+	absolute := len(segments) > 0 && segments[0] == ""
+	literal := 0
+	for literal < len(segments) && !strings.ContainsAny(segments[literal], string(globCharacters)) {
+		literal++
+	}
+	root := strings.Join(segments[:literal], "/")
+	switch {
+	case absolute && root == "":
+		root = "/"
+	case root == "":
+		root = "."
+	}
+	roots, err := g.expandInternal(g.normalizePathForFS(vol+root), true)
+	if err != nil {
+		return nil, err
 	}
 
-	// Handle cross-separator brace expansion
-	if strings.Count(child, "}") > strings.Count(child, "{") {
-		return g.handleCrossSeparatorBrace(normalizedPattern, dirOnly)
+	compiled, err := g.compileSegments(segments[literal:])
+	if err != nil {
+		return nil, err
+	}
+	w := &walker{g: g, dirOnly: dirOnly, seen: make(map[string]bool), visited: make(map[walkState]bool)}
+	for _, dir := range roots {
+		w.walk(dir, compiled)
+	}
+	if w.matches == nil {
+		return []string{}, nil
 	}
+	return w.matches, nil
+}
 
-	// Handle recursive wildcard `**` as the final segment
-	if child == "**" {
-		parentDirs, err := g.expandInternal(g.normalizePathForFS(parent), true)
-		if err != nil {
-			return nil, err
-		}
-		var allResults []string
-		seenPaths := make(map[string]bool)
-		for _, pDir := range parentDirs {
-			// Get ALL descendants, including the parent dir itself
-			descendants, err := g.getRecursiveDirectoriesAndFiles(pDir, dirOnly)
-			if err != nil {
-				continue
-			}
-			// Include parent directory itself
-			if !dirOnly {
-				_, err := g.FS.Stat(pDir)
-				if err == nil && !seenPaths[pDir] {
-					allResults = append(allResults, pDir)
-					seenPaths[pDir] = true
-				}
-			} else if !seenPaths[pDir] {
-				allResults = append(allResults, pDir)
-				seenPaths[pDir] = true
+// splitSegments splits a slash-separated pattern into its path segments, dropping empty
+// ones except the first, which marks an absolute pattern. crossesSeparator is true if a
+// brace group contains a separator, e.g. "{a/b,c}/d.txt", so that the pattern has to be
+// ungrouped before it can be split.
+func splitSegments(pattern string) (segments []string, crossesSeparator bool) {
+	depth, start := 0, 0
+	for i, r := range pattern {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '/':
+			if depth > 0 {
+				return nil, true
 			}
-			// Add descendants
-			for _, d := range descendants {
-				if !seenPaths[d] {
-					allResults = append(allResults, d)
-					seenPaths[d] = true
-				}
+			if segment := pattern[start:i]; segment != "" || i == 0 {
+				segments = append(segments, segment)
 			}
+			start = i + 1
 		}
-		return allResults, nil
 	}
-
-	return g.processPathSegment(parent, child, dirOnly)
+	if segment := pattern[start:]; segment != "" {
+		segments = append(segments, segment)
+	}
+	return segments, false
 }
 
 // handleCrossSeparatorBrace handles patterns like "{a/b,c}/d.txt"
@@ -397,81 +415,176 @@ func (g *Glob) handleCrossSeparatorBrace(normalizedPattern string, dirOnly bool)
 	return allResults, nil
 }
 
-// processPathSegment is the main workhorse for a standard `parent/child` pattern.
-func (g *Glob) processPathSegment(parentPattern, childPattern string, dirOnly bool) ([]string, error) {
-	// Convert parent pattern back to filesystem format for expansion
-	parentForFS := g.normalizePathForFS(parentPattern)
-	expandedParentDirs, err := g.expandInternal(parentForFS, true)
-	if err != nil {
-		return nil, err
-	}
+// segmentKind tells how a path segment of a pattern is matched.
+type segmentKind int
 
-	ungroupedChildSegments, err := ungroup(childPattern)
-	if err != nil {
-		return nil, fmt.Errorf("error ungrouping child segment '%s': %w", childPattern, err)
-	}
+const (
+	segmentName      segmentKind = iota // Matches the entries of a directory by name
+	segmentRecursive                    // "**": zero or more directories
+	segmentCurrent                      // ".": the directory itself
+	segmentParent                       // "..": the parent directory
+)
+
+// patternSegment is a compiled path segment of a pattern.
+type patternSegment struct {
+	kind segmentKind
+	// alternatives are the names the segment matches, one per brace alternative.
+	alternatives []*RegexOrString
+}
+
+// compileSegments compiles the path segments of a pattern, so that malformed segments
+// are reported even if no directory reaches them. Consecutive "**" are merged.
+func (g *Glob) compileSegments(segments []string) ([]patternSegment, error) {
+	var compiled []patternSegment
+	for _, segment := range segments {
+		switch segment {
+		case "**":
+			if n := len(compiled); n == 0 || compiled[n-1].kind != segmentRecursive {
+				compiled = append(compiled, patternSegment{kind: segmentRecursive})
+			}
+			continue
+		case ".":
+			compiled = append(compiled, patternSegment{kind: segmentCurrent})
+			continue
+		case "..":
+			compiled = append(compiled, patternSegment{kind: segmentParent})
+			continue
+		}
 
-	var childRegexes []*RegexOrString
-	for _, segment := range ungroupedChildSegments {
-		ros, err := g.createRegexOrString(segment)
+		alternatives, err := ungroup(segment)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error ungrouping child segment '%s': %w", segment, err)
+		}
+		ps := patternSegment{kind: segmentName}
+		for _, alternative := range alternatives {
+			ros, err := g.createRegexOrString(alternative)
+			if err != nil {
+				return nil, err
+			}
+			ps.alternatives = append(ps.alternatives, ros)
 		}
-		childRegexes = append(childRegexes, ros)
+		compiled = append(compiled, ps)
 	}
+	return compiled, nil
+}
 
-	var allMatches []string
-	seenPaths := make(map[string]bool)
-
-	for _, parentDir := range expandedParentDirs {
-		entries, readDirErr := g.FS.ReadDir(parentDir)
-		if readDirErr != nil {
-			if os.IsNotExist(readDirErr) {
-				continue
-			}
-			slog.Warn("Error reading directory", "directory", parentDir, "error", readDirErr)
+// matchesName reports whether the segment matches the name of a directory entry. The
+// names of directories starting with a dot, such as ".git", are only matched by
+// alternatives that start with a dot themselves.
+func (ps patternSegment) matchesName(name string, isDir bool) bool {
+	hidden := isDir && strings.HasPrefix(name, ".")
+	for _, ros := range ps.alternatives {
+		if hidden && !strings.HasPrefix(ros.LiteralPattern, ".") {
 			continue
 		}
+		if ros.IsMatch(name) {
+			return true
+		}
+	}
+	return false
+}
 
-		for _, entry := range entries {
-			if !dirOnly || entry.IsDir() {
-				for _, ros := range childRegexes {
-					if ros.IsMatch(entry.Name()) {
-						absEntryPath := g.joinPath(parentDir, entry.Name())
-						if !seenPaths[absEntryPath] {
-							allMatches = append(allMatches, absEntryPath)
-							seenPaths[absEntryPath] = true
-						}
-						break
-					}
+// walkState is a directory together with the number of pattern segments left to match
+// below it. Patterns with several "**" reach the same state on different ways.
+type walkState struct {
+	dir       string
+	remaining int
+}
+
+// walker matches the compiled segments of a pattern against the filesystem, from the
+// root directory of the pattern downwards.
+type walker struct {
+	g       *Glob
+	dirOnly bool
+	matches []string
+	seen    map[string]bool
+	visited map[walkState]bool
+}
+
+func (w *walker) add(p string) {
+	if !w.seen[p] {
+		w.seen[p] = true
+		w.matches = append(w.matches, p)
+	}
+}
+
+// walk adds the paths below the directory dir that match the segments.
+func (w *walker) walk(dir string, segments []patternSegment) {
+	state := walkState{dir: dir, remaining: len(segments)}
+	if w.visited[state] {
+		return
+	}
+	w.visited[state] = true
+
+	if len(segments) == 0 {
+		w.add(dir)
+		return
+	}
+	segment, rest := segments[0], segments[1:]
+
+	switch segment.kind {
+	case segmentCurrent:
+		w.walk(dir, rest)
+		return
+	case segmentParent:
+		if parent := w.g.parentDir(dir); parent != dir { // The root is its own parent
+			w.walk(parent, rest)
+		}
+		return
+	case segmentRecursive:
+		// "**" matches zero directories here, or one more directory and then again "**".
+		// Directories starting with a dot are not entered, nor are symbolic links, which
+		// could form cycles.
+		w.walk(dir, rest)
+		for _, entry := range w.readDir(dir) {
+			entryPath := w.g.joinPath(dir, entry.Name())
+			if entry.IsDir() {
+				if !strings.HasPrefix(entry.Name(), ".") {
+					w.walk(entryPath, segments)
 				}
+			} else if len(rest) == 0 && !w.dirOnly && entry.Type()&fs.ModeSymlink == 0 {
+				w.add(entryPath) // A trailing "**" matches the files below, too
 			}
 		}
+		return
+	}
 
-		// Handle '.' and '..' matching
-		for _, ros := range childRegexes {
-			switch ros.OriginalRegexPattern {
-			case `^\.$`: // Matches "."
-				if !seenPaths[parentDir] {
-					allMatches = append(allMatches, parentDir)
-					seenPaths[parentDir] = true
-				}
-			case `^\.\.$`: // Matches ".."
-				grandParentDir := g.parentDir(parentDir)
-				if grandParentDir != parentDir { // Avoids root's parent being itself
-					if !seenPaths[grandParentDir] {
-						allMatches = append(allMatches, grandParentDir)
-						seenPaths[grandParentDir] = true
-					}
-				}
+	for _, entry := range w.readDir(dir) {
+		entryPath := w.g.joinPath(dir, entry.Name())
+		isDir := w.isDir(entry, entryPath)
+		if !segment.matchesName(entry.Name(), isDir) {
+			continue
+		}
+		switch {
+		case len(rest) > 0:
+			if isDir {
+				w.walk(entryPath, rest)
 			}
+		case !w.dirOnly || isDir:
+			w.add(entryPath)
 		}
 	}
+}
 
-	if allMatches == nil {
-		return []string{}, nil
+// readDir lists the directory, logging errors other than a missing directory.
+func (w *walker) readDir(dir string) []fs.DirEntry {
+	entries, err := w.g.FS.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		slog.Warn("Error reading directory", "directory", dir, "error", err)
 	}
-	return allMatches, nil
+	return entries
+}
+
+// isDir reports whether the entry is a directory, following symbolic links.
+func (w *walker) isDir(entry fs.DirEntry, entryPath string) bool {
+	if entry.IsDir() {
+		return true
+	}
+	if entry.Type()&fs.ModeSymlink == 0 {
+		return false
+	}
+	info, err := w.g.FS.Stat(entryPath)
+	return err == nil && info.IsDir()
 }
 
 // globToRegexPattern converts a glob pattern segment to a Go regular expression string.
@@ -607,63 +720,6 @@ func ungroup(path string) ([]string, error) {
 	return []string{path}, nil
 }
 
-// getRecursiveDirectoriesAndFiles is a helper for `**` when it's the last segment.
-// It lists all files/directories under the root directory recursively.
-// If dirOnly is true, only directories are returned.
-// Returns absolute paths.
-func (g *Glob) getRecursiveDirectoriesAndFiles(root string, dirOnly bool) ([]string, error) {
-	var paths []string
-
-	rootInfo, err := g.FS.Stat(root)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []string{}, nil
-		}
-		return nil, err
-	}
-
-	if !rootInfo.IsDir() {
-		return []string{}, nil
-	}
-
-	queue := []string{root}
-	visited := make(map[string]struct{})
-
-	for len(queue) > 0 {
-		currentPath := queue[0]
-		queue = queue[1:]
-
-		if _, ok := visited[currentPath]; ok {
-			continue
-		}
-		visited[currentPath] = struct{}{}
-
-		entries, err := g.FS.ReadDir(currentPath)
-		if err != nil {
-			slog.Warn("Error reading directory", "path", currentPath, "error", err)
-			continue
-		}
-
-		for _, entry := range entries {
-			nextPath := g.joinPath(currentPath, entry.Name())
-
-			entryInfo, err := g.FS.Stat(nextPath)
-			if err != nil {
-				slog.Warn("Could not stat entry", "path", nextPath, "error", err)
-				continue
-			}
-
-			if !dirOnly || entryInfo.IsDir() {
-				paths = append(paths, nextPath)
-			}
-			if entryInfo.IsDir() {
-				queue = append(queue, nextPath)
-			}
-		}
-	}
-	return paths, nil
-}
-
 // GetFiles is the public entry point for globbing.
 // It takes a glob pattern and returns a slice of absolute paths to matching files and directories.
 // Errors encountered during parts of the expansion (e.g., unreadable directory) are logged as warnings,
@@ -587,3 +587,41 @@ func TestRegexOrStringFunctionality(t *testing.T) {
 		})
 	}
 }
+
+// Test the Windows path helpers, which must behave the same on every host OS
+func TestWindowsPathHelpers(t *testing.T) {
+	testCases := []struct {
+		name       string
+		input      string
+		wantVolume string
+		wantClean  string
+		wantDir    string
+	}{
+		{"drive_path", `C:\Users\..\Temp\file.txt`, `C:`, `C:\Temp\file.txt`, `C:\Temp`},
+		{"drive_root", `C:\`, `C:`, `C:\`, `C:\`},
+		{"unc_path", `\\server\share\coverage\report.xml`, `\\server\share`, `\\server\share\coverage\report.xml`, `\\server\share\coverage`},
+		{"unc_share_root", `\\server\share\coverage`, `\\server\share`, `\\server\share\coverage`, `\\server\share\`},
+		{"unc_dotdot_stays_in_share", `\\server\share\..\..\x`, `\\server\share`, `\\server\share\x`, `\\server\share\`},
+		{"long_path_prefix", `\\?\C:\very\long\file.txt`, `\\?\C:`, `\\?\C:\very\long\file.txt`, `\\?\C:\very\long`},
+		{"long_unc_prefix", `\\?\UNC\server\share\dir\file.txt`, `\\?\UNC\server\share`, `\\?\UNC\server\share\dir\file.txt`, `\\?\UNC\server\share\dir`},
+		{"relative_path", `dir\..\file.txt`, ``, `file.txt`, `.`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := windowsVolume(tc.input); got != tc.wantVolume {
+				t.Errorf("windowsVolume(%q) = %q, want %q", tc.input, got, tc.wantVolume)
+			}
+			if got := windowsClean(tc.input); got != tc.wantClean {
+				t.Errorf("windowsClean(%q) = %q, want %q", tc.input, got, tc.wantClean)
+			}
+			if got := windowsDir(tc.wantClean); got != tc.wantDir {
+				t.Errorf("windowsDir(%q) = %q, want %q", tc.wantClean, got, tc.wantDir)
+			}
+		})
+	}
+
+	if got := windowsJoin(`\\server\share`, `coverage\report.xml`); got != `\\server\share\coverage\report.xml` {
+		t.Errorf("windowsJoin kept %q, want the UNC root", got)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
-	"path/filepath"
+	"path"
 	"strings"
 	"testing"
 	"time"
@@ -126,28 +126,35 @@ func (m *MockFilesystem) ReadDir(name string) ([]fs.DirEntry, error) {
 
 func (m *MockFilesystem) Getwd() (string, error) { return m.cwd, nil }
 
-func (m *MockFilesystem) Abs(path string) (string, error) {
-	path = m.normalizePath(path)
+// Abs resolves paths with the conventions of the simulated platform instead of the host,
+// e.g. `\\server\share\dir` is an absolute Windows path on Linux hosts as well.
+func (m *MockFilesystem) Abs(p string) (string, error) {
+	p = m.normalizePath(p)
 	if m.platform == "windows" {
-		if filepath.IsAbs(path) || strings.HasPrefix(path, `C:`) {
-			return path, nil
+		if strings.HasPrefix(p, `\\`) || (len(p) >= 2 && p[1] == ':') {
+			return glob.WindowsClean(p), nil
 		}
-		if strings.HasPrefix(path, `\`) {
-			return `C:` + path, nil
-		}
-		return filepath.Join(m.cwd, path), nil
+		return glob.WindowsJoin(m.cwd, p), nil
+	}
+	if path.IsAbs(p) {
+		return path.Clean(p), nil
 	}
-	if filepath.IsAbs(path) {
-		return path, nil
+	return path.Join(m.cwd, p), nil
+}
+
+// dir returns the parent directory of an absolute path of the simulated platform.
+func (m *MockFilesystem) dir(abs string) string {
+	if m.platform == "windows" {
+		return glob.WindowsDir(abs)
 	}
-	return filepath.Join(m.cwd, path), nil
+	return path.Dir(abs)
 }
 
-func (m *MockFilesystem) AddFile(path string, isDir bool) {
-	abs, _ := m.Abs(path)
+func (m *MockFilesystem) AddFile(p string, isDir bool) {
+	abs, _ := m.Abs(p)
 
 	info := MockFileInfo{
-		name:    filepath.Base(abs),
+		name:    path.Base(strings.ReplaceAll(abs, `\`, "/")),
 		size:    100,
 		mode:    0o644,
 		modTime: time.Now(),
@@ -158,7 +165,7 @@ func (m *MockFilesystem) AddFile(path string, isDir bool) {
 	}
 	m.files[abs] = info
 
-	parent := filepath.Dir(abs)
+	parent := m.dir(abs)
 	if parent != abs {
 		m.dirs[parent] = append(m.dirs[parent], MockDirEntry{
 			name: info.name, isDir: isDir, info: info,
@@ -805,3 +812,282 @@ func TestGetFilesPublicAPI(t *testing.T) {
 		t.Errorf("expected empty results for empty pattern, got %d results", len(results))
 	}
 }
+
+func setupUNCFS() *MockFilesystem {
+	fs := NewMockFilesystem("windows")
+	fs.SetCwd(`C:\work`)
+	fs.AddFile(`\\buildserver\share\`, true)
+	fs.AddFile(`\\buildserver\share\coverage`, true)
+	fs.AddFile(`\\buildserver\share\coverage\unit`, true)
+	fs.AddFile(`\\buildserver\share\coverage\unit\coverage.xml`, false)
+	fs.AddFile(`\\buildserver\share\coverage\integration`, true)
+	fs.AddFile(`\\buildserver\share\coverage\integration\coverage.xml`, false)
+	fs.AddFile(`\\buildserver\share\coverage\integration\readme.txt`, false)
+	return fs
+}
+
+func TestExpandNames_UNCPaths_KeepServerAndShare(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		pattern string
+		want    []string
+	}{
+		{
+			name:    "recursive with backslashes",
+			pattern: `\\buildserver\share\coverage\**\*.xml`,
+			want: []string{
+				`\\buildserver\share\coverage\integration\coverage.xml`,
+				`\\buildserver\share\coverage\unit\coverage.xml`,
+			},
+		},
+		{
+			name:    "forward slashes",
+			pattern: "//buildserver/share/coverage/*/coverage.xml",
+			want: []string{
+				`\\buildserver\share\coverage\integration\coverage.xml`,
+				`\\buildserver\share\coverage\unit\coverage.xml`,
+			},
+		},
+		{
+			name:    "wildcard directly below the share",
+			pattern: `\\buildserver\share\cov*`,
+			want:    []string{`\\buildserver\share\coverage`},
+		},
+		{
+			name:    "literal path",
+			pattern: `\\buildserver\share\coverage\unit\coverage.xml`,
+			want:    []string{`\\buildserver\share\coverage\unit\coverage.xml`},
+		},
+		{
+			name:    "case-insensitive literal path",
+			pattern: `\\buildserver\share\COVERAGE\Unit\Coverage.xml`,
+			want:    []string{`\\buildserver\share\coverage\unit\coverage.xml`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := glob.NewGlob(tc.pattern, setupUNCFS()).ExpandNames()
+
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			// Compared without assert.ToSlash, which would hide a lost UNC prefix.
+			assert.Equal(t, tc.want, got, assert.SortPaths)
+		})
+	}
+}
+
+func TestExpandNames_LongWindowsPaths_ReturnExpected(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: a directory path of more than 260 characters (MAX_PATH)
+	fs := NewMockFilesystem("windows")
+	fs.SetCwd(`C:\`)
+	fs.AddFile(`C:\`, true)
+	dir := `C:\build`
+	fs.AddFile(dir, true)
+	for i := 0; len(dir) <= 260; i++ {
+		dir += fmt.Sprintf(`\very_long_directory_name_%02d`, i)
+		fs.AddFile(dir, true)
+	}
+	fs.AddFile(dir+`\coverage.xml`, false)
+	prefixed := `\\?\` + dir
+	fs.AddFile(`\\?\C:\`, true)
+	fs.AddFile(prefixed, true)
+	fs.AddFile(prefixed+`\coverage.xml`, false)
+
+	cases := []struct {
+		name    string
+		pattern string
+		want    []string
+	}{
+		{"wildcard", dir + `\*.xml`, []string{dir + `\coverage.xml`}},
+		{"recursive", `C:\build\**\coverage.xml`, []string{dir + `\coverage.xml`}},
+		{"literal", dir + `\coverage.xml`, []string{dir + `\coverage.xml`}},
+		// The "?" of the long path prefix is not a wildcard.
+		{"long path prefix", prefixed + `\*.xml`, []string{prefixed + `\coverage.xml`}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			got, err := glob.NewGlob(tc.pattern, fs).ExpandNames()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			assert.Equal(t, tc.want, got, assert.SortPaths)
+		})
+	}
+}
+
+// setupRepoFS builds a checkout with build outputs and a .git directory below /repo.
+func setupRepoFS() *MockFilesystem {
+	fs := NewMockFilesystem("unix")
+	fs.SetCwd("/repo")
+	for _, p := range []string{"/", "/repo", "/repo/src", "/repo/src/app", "/repo/src/app/bin",
+		"/repo/src/app/bin/Debug", "/repo/tests", "/repo/tests/bin", "/repo/.git",
+		"/repo/.git/objects", "/repo/.github", "/repo/abs", "/repo/abs/x", "/repo/abs/x/bin"} {
+		fs.AddFile(p, true)
+	}
+	for _, p := range []string{"/repo/coverage.xml", "/repo/src/app/coverage.xml",
+		"/repo/src/app/bin/report.xml", "/repo/src/app/bin/Debug/coverage.xml",
+		"/repo/tests/bin/coverage.xml", "/repo/.git/coverage.xml", "/repo/.git/objects/coverage.xml",
+		"/repo/.github/coverage.xml", "/repo/.coverage.xml", "/repo/abs/x/bin/a.xml"} {
+		fs.AddFile(p, false)
+	}
+	return fs
+}
+
+func TestExpandNames_RecursiveSegments_ReturnExpected(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		pattern string
+		want    []string
+	}{
+		{
+			name:    "leading double asterisk",
+			pattern: "**/coverage.xml",
+			want: []string{
+				"/repo/coverage.xml",
+				"/repo/src/app/coverage.xml",
+				"/repo/src/app/bin/Debug/coverage.xml",
+				"/repo/tests/bin/coverage.xml",
+			},
+		},
+		{
+			name:    "absolute root with several double asterisks",
+			pattern: "/repo/**/bin/**/*.xml",
+			want: []string{
+				"/repo/src/app/bin/report.xml",
+				"/repo/src/app/bin/Debug/coverage.xml",
+				"/repo/tests/bin/coverage.xml",
+				"/repo/abs/x/bin/a.xml",
+			},
+		},
+		{
+			name:    "double asterisk from the filesystem root",
+			pattern: "/**/tests/bin/*.xml",
+			want:    []string{"/repo/tests/bin/coverage.xml"},
+		},
+		{
+			name:    "double asterisk matching zero directories",
+			pattern: "src/**/app/coverage.xml",
+			want:    []string{"/repo/src/app/coverage.xml"},
+		},
+		{
+			name:    "consecutive double asterisks",
+			pattern: "tests/**/**/coverage.xml",
+			want:    []string{"/repo/tests/bin/coverage.xml"},
+		},
+		{
+			name:    "explicit dot directory",
+			pattern: ".git/**/coverage.xml",
+			want: []string{
+				"/repo/.git/coverage.xml",
+				"/repo/.git/objects/coverage.xml",
+			},
+		},
+		{
+			name:    "wildcard starting with a dot",
+			pattern: ".*/coverage.xml",
+			want: []string{
+				"/repo/.git/coverage.xml",
+				"/repo/.github/coverage.xml",
+			},
+		},
+		{
+			name:    "wildcard skips dot directories",
+			pattern: "*/coverage.xml",
+			want:    []string{},
+		},
+		{
+			name:    "wildcard matches dot files",
+			pattern: "*.xml",
+			want:    []string{"/repo/coverage.xml", "/repo/.coverage.xml"},
+		},
+		{
+			name:    "no match",
+			pattern: "**/missing.xml",
+			want:    []string{},
+		},
+	}
+
+	fs := setupRepoFS()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got, err := glob.NewGlob(tc.pattern, fs).ExpandNames()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			assert.Equal(t, tc.want, got, assert.CmpPaths...)
+		})
+	}
+}
+
+// countingFS counts the directories listed below a path prefix.
+type countingFS struct {
+	*MockFilesystem
+	prefix string
+	reads  int
+}
+
+func (c *countingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if strings.HasPrefix(name, c.prefix) {
+		c.reads++
+	}
+	return c.MockFilesystem.ReadDir(name)
+}
+
+func TestExpandNames_DoubleAsterisk_DoesNotReadDotDirectories(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fs := &countingFS{MockFilesystem: setupRepoFS(), prefix: "/repo/.git"}
+
+	// Act
+	got, err := glob.NewGlob("**/coverage.xml", fs).ExpandNames()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 4 {
+		t.Errorf("got %d files, want 4: %v", len(got), got)
+	}
+	if fs.reads != 0 {
+		t.Errorf("listed %d directories below .git, want none", fs.reads)
+	}
+}
+
+func BenchmarkExpandNames_SkipsDotDirectories(b *testing.B) {
+	fs := &countingFS{MockFilesystem: setupRepoFS(), prefix: "/repo/.git"}
+	for i := 0; i < 256; i++ {
+		dir := fmt.Sprintf("/repo/.git/objects/%02x", i)
+		fs.AddFile(dir, true)
+		for j := 0; j < 16; j++ {
+			fs.AddFile(fmt.Sprintf("%s/%038x", dir, j), false)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := glob.NewGlob("**/coverage.xml", fs).ExpandNames(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(fs.reads)/float64(b.N), "gitreads/op")
+}
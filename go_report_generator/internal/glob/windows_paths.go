@@ -0,0 +1,89 @@
+package glob
+
+import (
+	"path"
+	"strings"
+)
+
+// The helpers below handle Windows paths as plain strings, so that they behave the same
+// on every host OS. path/filepath only understands the conventions of the host: on Linux
+// it neither splits `C:\dir\file` nor keeps the `\\server\share` root of UNC paths.
+
+// windowsVolume returns the volume of a backslash-separated Windows path: "C:" for drive
+// paths, `\\server\share` for UNC paths and `\\?\C:` or `\\?\UNC\server\share` for paths
+// with the long path prefix. Relative and rooted paths have no volume.
+func windowsVolume(p string) string {
+	switch {
+	case strings.HasPrefix(p, `\\?\UNC\`), strings.HasPrefix(p, `\\.\UNC\`):
+		return uncVolume(p, len(`\\?\UNC\`))
+	case strings.HasPrefix(p, `\\?\`), strings.HasPrefix(p, `\\.\`):
+		rest := p[len(`\\?\`):]
+		if len(rest) >= 2 && rest[1] == ':' {
+			return p[:len(`\\?\C:`)]
+		}
+		// A device or volume GUID path, e.g. \\?\Volume{...}\dir
+		if i := strings.IndexByte(rest, '\\'); i >= 0 {
+			return p[:len(`\\?\`)+i]
+		}
+		return p
+	case strings.HasPrefix(p, `\\`):
+		return uncVolume(p, len(`\\`))
+	case len(p) >= 2 && p[1] == ':':
+		return p[:2]
+	}
+	return ""
+}
+
+// uncVolume returns the `\\server\share` part of a UNC path whose server name starts at
+// index start, or the whole path if it has no share.
+func uncVolume(p string, start int) string {
+	server := strings.IndexByte(p[start:], '\\')
+	if server < 0 {
+		return p
+	}
+	shareStart := start + server + 1
+	share := strings.IndexByte(p[shareStart:], '\\')
+	if share < 0 {
+		return p
+	}
+	return p[:shareStart+share]
+}
+
+// windowsCleanRest cleans the part of a Windows path after its volume.
+func windowsCleanRest(rest string) string {
+	return strings.ReplaceAll(path.Clean(strings.ReplaceAll(rest, `\`, "/")), "/", `\`)
+}
+
+// windowsClean is filepath.Clean for Windows paths. The volume is kept as it is.
+func windowsClean(p string) string {
+	vol := windowsVolume(p)
+	rest := p[len(vol):]
+	if rest == "" && vol != "" {
+		return vol
+	}
+	return vol + windowsCleanRest(rest)
+}
+
+// windowsJoin is filepath.Join for Windows paths.
+func windowsJoin(elem1, elem2 string) string {
+	if elem1 == "" {
+		return windowsClean(elem2)
+	}
+	if elem2 == "" {
+		return windowsClean(elem1)
+	}
+	return windowsClean(strings.TrimSuffix(elem1, `\`) + `\` + elem2)
+}
+
+// windowsDir is filepath.Dir for Windows paths: the parent of `\\server\share\dir` is
+// `\\server\share\`, not `\server\share`.
+func windowsDir(p string) string {
+	vol := windowsVolume(p)
+	rest := p[len(vol):]
+	i := strings.LastIndexByte(rest, '\\')
+	dir := windowsCleanRest(rest[:i+1])
+	if dir == "." && len(vol) > 2 {
+		return vol
+	}
+	return vol + dir
+}
@@ -0,0 +1,394 @@
+// Package history stores the coverage of every run in a history directory and reads the
+// earlier runs back, so that the reports can show how the coverage of the classes changed.
+// The files have the layout of the C# ReportGenerator ("<date>_CoverageHistory.xml"), so
+// a history directory can be shared with it.
+package history
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+const (
+	fileNameSuffix = "_CoverageHistory.xml"
+	dateLayout     = "2006-01-02_15-04-05" // Local time, like the C# ReportGenerator
+)
+
+// historyFile is the root element of a history file.
+type historyFile struct {
+	XMLName    xml.Name          `xml:"coverage"`
+	Version    string            `xml:"version,attr"`
+	Date       string            `xml:"date,attr"`
+	Tag        string            `xml:"tag,attr,omitempty"`
+	Assemblies []historyAssembly `xml:"assembly"`
+}
+
+type historyAssembly struct {
+	Name    string         `xml:"name,attr"`
+	Classes []historyClass `xml:"class"`
+}
+
+type historyClass struct {
+	Name                    string `xml:"name,attr"`
+	CoveredLines            int    `xml:"coveredlines,attr"`
+	CoverableLines          int    `xml:"coverablelines,attr"`
+	TotalLines              int    `xml:"totallines,attr"`
+	CoveredBranches         int    `xml:"coveredbranches,attr"`
+	TotalBranches           int    `xml:"totalbranches,attr"`
+	CoveredCodeElements     int    `xml:"coveredcodeelements,attr"`
+	FullCoveredCodeElements int    `xml:"fullcoveredcodeelements,attr"`
+	TotalCodeElements       int    `xml:"totalcodeelements,attr"`
+}
+
+type classKey struct {
+	assembly string
+	class    string
+}
+
+// Snapshot is the coverage of the classes in one run.
+type Snapshot struct {
+	ExecutionTime time.Time
+	Tag           string
+	classes       map[classKey]model.HistoricCoverage
+}
+
+// NewSnapshot records the coverage of the classes of summary at executionTime.
+func NewSnapshot(summary *model.SummaryResult, executionTime time.Time, tag string) Snapshot {
+	snapshot := Snapshot{ExecutionTime: executionTime, Tag: tag, classes: make(map[classKey]model.HistoricCoverage)}
+	for _, assembly := range summary.Assemblies {
+		for _, class := range assembly.Classes {
+			coverage := model.HistoricCoverage{
+				ExecutionTime:       executionTime.Unix(),
+				Tag:                 tag,
+				CoveredLines:        class.LinesCovered,
+				CoverableLines:      class.LinesValid,
+				TotalLines:          class.TotalLines,
+				CoveredMethods:      class.CoveredMethods,
+				FullyCoveredMethods: class.FullyCoveredMethods,
+				TotalMethods:        class.TotalMethods,
+			}
+			if class.BranchesCovered != nil && class.BranchesValid != nil {
+				coverage.CoveredBranches = *class.BranchesCovered
+				coverage.TotalBranches = *class.BranchesValid
+			}
+			snapshot.classes[classKey{assembly: assembly.Name, class: class.Name}] = coverage
+		}
+	}
+	return snapshot
+}
+
+// Coverage returns the coverage of a class in the snapshot.
+func (s Snapshot) Coverage(assembly, class string) (model.HistoricCoverage, bool) {
+	coverage, ok := s.classes[classKey{assembly: assembly, class: class}]
+	return coverage, ok
+}
+
+// Apply sets the HistoricCoverages of the classes of summary from the snapshots, oldest
+// first. Classes are matched by assembly and class name.
+func Apply(summary *model.SummaryResult, snapshots []Snapshot) {
+	sorted := sortedByTime(snapshots)
+	for i := range summary.Assemblies {
+		assembly := &summary.Assemblies[i]
+		for j := range assembly.Classes {
+			class := &assembly.Classes[j]
+			class.HistoricCoverages = nil
+			for _, snapshot := range sorted {
+				if coverage, ok := snapshot.Coverage(assembly.Name, class.Name); ok {
+					class.HistoricCoverages = append(class.HistoricCoverages, coverage)
+				}
+			}
+		}
+	}
+}
+
+// ApplyCoverageAge sets the CoverageAge of the classes of summary whose line coverage is
+// below threshold (in percent) in the newest snapshot and in at least the one before it.
+// The runs are counted back from the newest snapshot until one in which the class is at
+// or above the threshold or has no coverage. Classes without coverable lines count as
+// 100% covered. A threshold of 0 or less clears the ages.
+func ApplyCoverageAge(summary *model.SummaryResult, snapshots []Snapshot, threshold float64) {
+	sorted := sortedByTime(snapshots)
+	for i := range summary.Assemblies {
+		assembly := &summary.Assemblies[i]
+		for j := range assembly.Classes {
+			class := &assembly.Classes[j]
+			class.CoverageAge = nil
+			if threshold <= 0 {
+				continue
+			}
+			age := model.CoverageAge{Threshold: threshold}
+			for k := len(sorted) - 1; k >= 0; k-- {
+				coverage, ok := sorted[k].Coverage(assembly.Name, class.Name)
+				if !ok || !belowThreshold(coverage, threshold) {
+					break
+				}
+				age.Runs++
+				age.Since = coverage.ExecutionTime
+			}
+			// A class below the threshold only in the current run has no age yet.
+			if age.Runs > 1 {
+				class.CoverageAge = &age
+			}
+		}
+	}
+}
+
+func belowThreshold(coverage model.HistoricCoverage, threshold float64) bool {
+	if coverage.CoverableLines == 0 {
+		return false
+	}
+	return 100*float64(coverage.CoveredLines)/float64(coverage.CoverableLines) < threshold
+}
+
+func sortedByTime(snapshots []Snapshot) []Snapshot {
+	sorted := append([]Snapshot(nil), snapshots...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ExecutionTime.Before(sorted[j].ExecutionTime) })
+	return sorted
+}
+
+// Store reads and writes the history files of one directory.
+type Store struct {
+	dir           string
+	prefix        string
+	maxFiles      int
+	retentionDays int
+	prune         bool
+	now           func() time.Time
+	logger        *slog.Logger
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithFileNamePrefix writes the history files as "<prefix>_<date>_CoverageHistory.xml" and
+// only reads files with that prefix, so several projects can share a history directory.
+func WithFileNamePrefix(prefix string) Option {
+	return func(s *Store) {
+		s.prefix = prefix
+	}
+}
+
+// WithMaxFiles keeps only the newest maxFiles history files (0: no limit).
+func WithMaxFiles(maxFiles int) Option {
+	return func(s *Store) {
+		s.maxFiles = maxFiles
+	}
+}
+
+// WithRetentionDays ignores history files older than the given number of days (0: no limit).
+func WithRetentionDays(days int) Option {
+	return func(s *Store) {
+		s.retentionDays = days
+	}
+}
+
+// WithPrune deletes the history files that Load ignores because of their age or count.
+func WithPrune(prune bool) Option {
+	return func(s *Store) {
+		s.prune = prune
+	}
+}
+
+// WithClock replaces time.Now for the retention period.
+func WithClock(now func() time.Time) Option {
+	return func(s *Store) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+// WithLogger sets the logger for skipped and deleted files.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Store) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// NewStore creates a Store for the history files in dir.
+func NewStore(dir string, opts ...Option) *Store {
+	s := &Store{dir: dir, now: time.Now, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// candidate is a history file found by Load, dated by its file name.
+type candidate struct {
+	path string
+	date time.Time
+}
+
+// Load reads the snapshots of the history directory, oldest first. Files older than the
+// retention period are left out first, then all but the newest maxFiles of the rest, so
+// the count never keeps a file the age limit removed. With WithPrune the files left out
+// are deleted. A missing directory has no history.
+func (s *Store) Load() ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var candidates []candidate
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		date, ok := s.dateOf(entry.Name())
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, candidate{path: filepath.Join(s.dir, entry.Name()), date: date})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].date.After(candidates[j].date) })
+
+	var kept, removed []candidate
+	oldest := time.Time{}
+	if s.retentionDays > 0 {
+		oldest = s.now().AddDate(0, 0, -s.retentionDays)
+	}
+	for _, c := range candidates {
+		if c.date.Before(oldest) || (s.maxFiles > 0 && len(kept) >= s.maxFiles) {
+			removed = append(removed, c)
+			continue
+		}
+		kept = append(kept, c)
+	}
+	if len(removed) > 0 {
+		s.logger.Info("Ignoring old history files", "count", len(removed), "prune", s.prune)
+	}
+	if s.prune {
+		for _, c := range removed {
+			if err := os.Remove(c.path); err != nil {
+				s.logger.Warn("Could not delete history file", "file", c.path, "error", err)
+			}
+		}
+	}
+
+	snapshots := make([]Snapshot, 0, len(kept))
+	for i := len(kept) - 1; i >= 0; i-- {
+		snapshot, err := readSnapshot(kept[i].path)
+		if err != nil {
+			s.logger.Warn("Skipping unreadable history file", "file", kept[i].path, "error", err)
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// dateOf returns the date in the name of a history file of this store.
+func (s *Store) dateOf(name string) (time.Time, bool) {
+	if !strings.HasSuffix(name, fileNameSuffix) {
+		return time.Time{}, false
+	}
+	stem := strings.TrimSuffix(name, fileNameSuffix)
+	if s.prefix != "" {
+		if !strings.HasPrefix(stem, s.prefix+"_") {
+			return time.Time{}, false
+		}
+		stem = strings.TrimPrefix(stem, s.prefix+"_")
+	}
+	if len(stem) < len(dateLayout) {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation(dateLayout, stem[len(stem)-len(dateLayout):], time.Local)
+	return date, err == nil
+}
+
+func readSnapshot(path string) (Snapshot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var file historyFile
+	if err := xml.Unmarshal(content, &file); err != nil {
+		return Snapshot{}, err
+	}
+	executionTime, err := time.ParseInLocation(dateLayout, file.Date, time.Local)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("invalid date %q: %w", file.Date, err)
+	}
+
+	snapshot := Snapshot{ExecutionTime: executionTime, Tag: file.Tag, classes: make(map[classKey]model.HistoricCoverage)}
+	for _, assembly := range file.Assemblies {
+		for _, class := range assembly.Classes {
+			snapshot.classes[classKey{assembly: assembly.Name, class: class.Name}] = model.HistoricCoverage{
+				ExecutionTime:       executionTime.Unix(),
+				Tag:                 file.Tag,
+				CoveredLines:        class.CoveredLines,
+				CoverableLines:      class.CoverableLines,
+				TotalLines:          class.TotalLines,
+				CoveredBranches:     class.CoveredBranches,
+				TotalBranches:       class.TotalBranches,
+				CoveredMethods:      class.CoveredCodeElements,
+				FullyCoveredMethods: class.FullCoveredCodeElements,
+				TotalMethods:        class.TotalCodeElements,
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// Save writes the snapshot as a new history file and returns its path.
+func (s *Store) Save(snapshot Snapshot) (string, error) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	file := historyFile{
+		Version: "1.0",
+		Date:    snapshot.ExecutionTime.In(time.Local).Format(dateLayout),
+		Tag:     snapshot.Tag,
+	}
+	byAssembly := make(map[string][]historyClass)
+	for key, coverage := range snapshot.classes {
+		byAssembly[key.assembly] = append(byAssembly[key.assembly], historyClass{
+			Name:                    key.class,
+			CoveredLines:            coverage.CoveredLines,
+			CoverableLines:          coverage.CoverableLines,
+			TotalLines:              coverage.TotalLines,
+			CoveredBranches:         coverage.CoveredBranches,
+			TotalBranches:           coverage.TotalBranches,
+			CoveredCodeElements:     coverage.CoveredMethods,
+			FullCoveredCodeElements: coverage.FullyCoveredMethods,
+			TotalCodeElements:       coverage.TotalMethods,
+		})
+	}
+	for name, classes := range byAssembly {
+		sort.Slice(classes, func(i, j int) bool { return classes[i].Name < classes[j].Name })
+		file.Assemblies = append(file.Assemblies, historyAssembly{Name: name, Classes: classes})
+	}
+	sort.Slice(file.Assemblies, func(i, j int) bool { return file.Assemblies[i].Name < file.Assemblies[j].Name })
+
+	content, err := xml.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal history file: %w", err)
+	}
+	name := file.Date + fileNameSuffix
+	if s.prefix != "" {
+		name = s.prefix + "_" + name
+	}
+	path := filepath.Join(s.dir, name)
+	if err := utils.WriteFileAtomic(path, append([]byte(xml.Header), content...), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write history file: %w", err)
+	}
+	return path, nil
+}
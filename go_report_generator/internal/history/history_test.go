@@ -0,0 +1,299 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+var testNow = time.Date(2024, 6, 30, 12, 0, 0, 0, time.Local)
+
+func testSummary(coveredLines int) *model.SummaryResult {
+	covered, valid := 1, 2
+	return &model.SummaryResult{Assemblies: []model.Assembly{{
+		Name: "Shop",
+		Classes: []model.Class{
+			{Name: "Shop.Cart", LinesCovered: coveredLines, LinesValid: 10, TotalLines: 40, BranchesCovered: &covered, BranchesValid: &valid, CoveredMethods: 2, FullyCoveredMethods: 1, TotalMethods: 3},
+			{Name: "Shop.Price", LinesCovered: 0, LinesValid: 5},
+		},
+	}}}
+}
+
+// saveSnapshots writes one history file per age in days, with the age as covered lines
+// of Shop.Cart, and returns the store's directory.
+func saveSnapshots(t *testing.T, ages ...int) string {
+	t.Helper()
+	dir := t.TempDir()
+	store := NewStore(dir)
+	for _, age := range ages {
+		if _, err := store.Save(NewSnapshot(testSummary(age), testNow.AddDate(0, 0, -age), "")); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+	return dir
+}
+
+// ages returns the ages in days of the loaded snapshots.
+func ages(t *testing.T, snapshots []Snapshot) []int {
+	t.Helper()
+	var result []int
+	for _, snapshot := range snapshots {
+		coverage, ok := snapshot.Coverage("Shop", "Shop.Cart")
+		if !ok {
+			t.Fatalf("snapshot of %v has no Shop.Cart", snapshot.ExecutionTime)
+		}
+		result = append(result, coverage.CoveredLines)
+	}
+	return result
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, WithFileNamePrefix("shop"))
+	executionTime := testNow.Add(-time.Hour)
+
+	path, err := store.Save(NewSnapshot(testSummary(8), executionTime, "build-7"))
+	if err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if want := "shop_2024-06-30_11-00-00_CoverageHistory.xml"; filepath.Base(path) != want {
+		t.Errorf("history file = %s, want %s", filepath.Base(path), want)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read history file: %v", err)
+	}
+	for _, want := range []string{
+		`<coverage version="1.0" date="2024-06-30_11-00-00" tag="build-7">`,
+		`<class name="Shop.Cart" coveredlines="8" coverablelines="10" totallines="40" coveredbranches="1" totalbranches="2" coveredcodeelements="2" fullcoveredcodeelements="1" totalcodeelements="3"></class>`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("history file does not contain %q:\n%s", want, content)
+		}
+	}
+
+	snapshots, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot, got %d", len(snapshots))
+	}
+	if !snapshots[0].ExecutionTime.Equal(executionTime) || snapshots[0].Tag != "build-7" {
+		t.Errorf("snapshot = %v %q, want %v %q", snapshots[0].ExecutionTime, snapshots[0].Tag, executionTime, "build-7")
+	}
+	want := model.HistoricCoverage{
+		ExecutionTime: executionTime.Unix(), Tag: "build-7",
+		CoveredLines: 8, CoverableLines: 10, TotalLines: 40, CoveredBranches: 1, TotalBranches: 2,
+		CoveredMethods: 2, FullyCoveredMethods: 1, TotalMethods: 3,
+	}
+	if got, _ := snapshots[0].Coverage("Shop", "Shop.Cart"); got != want {
+		t.Errorf("Shop.Cart = %+v, want %+v", got, want)
+	}
+
+	other, err := NewStore(dir, WithFileNamePrefix("web")).Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("expected files of another prefix to be ignored, got %d snapshots", len(other))
+	}
+}
+
+func TestStore_LoadMissingDirectory(t *testing.T) {
+	snapshots, err := NewStore(filepath.Join(t.TempDir(), "missing")).Load()
+	if err != nil || snapshots != nil {
+		t.Errorf("Load() = %v, %v, want no snapshots and no error", snapshots, err)
+	}
+}
+
+// TestStore_LoadRetention checks that the age limit is applied before the count, so that
+// the count keeps the newest files within the retention period.
+func TestStore_LoadRetention(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxFiles      int
+		retentionDays int
+		want          []int // Ages in days of the kept snapshots, oldest first
+	}{
+		{name: "NoLimits", want: []int{20, 10, 3, 2, 1}},
+		{name: "Count", maxFiles: 2, want: []int{2, 1}},
+		{name: "Age", retentionDays: 5, want: []int{3, 2, 1}},
+		{name: "AgeThenCount", maxFiles: 2, retentionDays: 5, want: []int{2, 1}},
+		{name: "CountAboveFilesWithinAge", maxFiles: 4, retentionDays: 15, want: []int{10, 3, 2, 1}},
+		{name: "AgeRemovesAll", maxFiles: 3, retentionDays: 1, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := saveSnapshots(t, 1, 2, 3, 10, 20)
+			// One more second makes the file of day 1 fall into a one-day retention period.
+			now := func() time.Time { return testNow.Add(time.Second) }
+
+			snapshots, err := NewStore(dir, WithMaxFiles(tt.maxFiles), WithRetentionDays(tt.retentionDays), WithClock(now)).Load()
+			if err != nil {
+				t.Fatalf("Load returned error: %v", err)
+			}
+			if got := ages(t, snapshots); !slices.Equal(got, tt.want) {
+				t.Errorf("kept ages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_LoadPrunesOnlyWhenRequested(t *testing.T) {
+	dir := saveSnapshots(t, 1, 2, 3, 10)
+	countFiles := func() int {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("failed to read history directory: %v", err)
+		}
+		return len(entries)
+	}
+	now := func() time.Time { return testNow }
+
+	if _, err := NewStore(dir, WithMaxFiles(2), WithClock(now)).Load(); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := countFiles(); got != 4 {
+		t.Fatalf("expected no file to be deleted without pruning, %d left", got)
+	}
+
+	if _, err := NewStore(dir, WithMaxFiles(2), WithRetentionDays(5), WithPrune(true), WithClock(now)).Load(); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := countFiles(); got != 2 {
+		t.Fatalf("expected 2 files after pruning, %d left", got)
+	}
+	snapshots, err := NewStore(dir).Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := ages(t, snapshots); !slices.Equal(got, []int{2, 1}) {
+		t.Errorf("ages after pruning = %v, want [2 1]", got)
+	}
+}
+
+func TestStore_LoadSkipsUnreadableFiles(t *testing.T) {
+	dir := saveSnapshots(t, 1)
+	if err := os.WriteFile(filepath.Join(dir, "2024-06-01_00-00-00_CoverageHistory.xml"), []byte("<coverage"), 0o644); err != nil {
+		t.Fatalf("failed to write history file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a history file"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	snapshots, err := NewStore(dir).Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := ages(t, snapshots); !slices.Equal(got, []int{1}) {
+		t.Errorf("ages = %v, want [1]", got)
+	}
+}
+
+func TestApply(t *testing.T) {
+	summary := testSummary(9)
+	older := NewSnapshot(testSummary(3), testNow.AddDate(0, 0, -2), "")
+	newer := NewSnapshot(testSummary(6), testNow.AddDate(0, 0, -1), "")
+	current := NewSnapshot(summary, testNow, "")
+	delete(older.classes, classKey{assembly: "Shop", class: "Shop.Price"})
+
+	Apply(summary, []Snapshot{newer, current, older})
+
+	var cart []int
+	for _, hc := range summary.Assemblies[0].Classes[0].HistoricCoverages {
+		cart = append(cart, hc.CoveredLines)
+	}
+	if !slices.Equal(cart, []int{3, 6, 9}) {
+		t.Errorf("Shop.Cart history = %v, want [3 6 9]", cart)
+	}
+	if got := len(summary.Assemblies[0].Classes[1].HistoricCoverages); got != 2 {
+		t.Errorf("expected 2 history entries of Shop.Price, got %d", got)
+	}
+}
+
+// ageSnapshots returns one snapshot per day, oldest first, in which Shop.Cart has the
+// given number of 10 coverable lines covered. A negative number leaves the class out.
+func ageSnapshots(coveredLines ...int) []Snapshot {
+	var snapshots []Snapshot
+	for i, covered := range coveredLines {
+		summary := testSummary(covered)
+		if covered < 0 {
+			summary.Assemblies[0].Classes = summary.Assemblies[0].Classes[1:]
+		}
+		executionTime := testNow.AddDate(0, 0, i-len(coveredLines)+1)
+		snapshots = append(snapshots, NewSnapshot(summary, executionTime, ""))
+	}
+	return snapshots
+}
+
+func TestApplyCoverageAge(t *testing.T) {
+	tests := []struct {
+		name         string
+		coveredLines []int // Of 10 lines per run, oldest first; -1 if the class is missing
+		wantRuns     int   // 0 if no age is expected
+	}{
+		{name: "BelowInAllRuns", coveredLines: []int{5, 6, 7}, wantRuns: 3},
+		{name: "CrossedBackBelow", coveredLines: []int{5, 9, 7, 7, 6}, wantRuns: 3},
+		{name: "AtThresholdEndsRun", coveredLines: []int{5, 8, 7}, wantRuns: 0},
+		{name: "AboveNow", coveredLines: []int{5, 6, 9}, wantRuns: 0},
+		{name: "BelowOnlyNow", coveredLines: []int{9, 9, 7}, wantRuns: 0},
+		{name: "MissingSnapshotEndsRun", coveredLines: []int{5, 6, -1, 7, 7}, wantRuns: 2},
+		{name: "MissingNow", coveredLines: []int{5, 6, -1}, wantRuns: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := testSummary(tc.coveredLines[len(tc.coveredLines)-1])
+			snapshots := ageSnapshots(tc.coveredLines...)
+
+			ApplyCoverageAge(summary, snapshots, 80)
+
+			age := summary.Assemblies[0].Classes[0].CoverageAge
+			if tc.wantRuns == 0 {
+				if age != nil {
+					t.Fatalf("CoverageAge = %+v, want none", *age)
+				}
+				return
+			}
+			if age == nil {
+				t.Fatalf("CoverageAge = nil, want %d runs", tc.wantRuns)
+			}
+			wantSince := testNow.AddDate(0, 0, 1-tc.wantRuns).Unix()
+			if age.Runs != tc.wantRuns || age.Since != wantSince || age.Threshold != 80 {
+				t.Errorf("CoverageAge = %+v, want %d runs since %d below 80", *age, tc.wantRuns, wantSince)
+			}
+		})
+	}
+}
+
+func TestApplyCoverageAge_ClassesWithoutCoverableLinesAndDisabled(t *testing.T) {
+	summary := testSummary(5)
+	snapshots := ageSnapshots(5, 5, 5)
+
+	ApplyCoverageAge(summary, snapshots, 80)
+	if age := summary.Assemblies[0].Classes[1].CoverageAge; age == nil || age.Runs != 3 {
+		t.Errorf("Shop.Price (0 of 5 lines covered) CoverageAge = %+v, want 3 runs", age)
+	}
+
+	summary.Assemblies[0].Classes[1].LinesValid = 0
+	for i := range snapshots {
+		coverage := snapshots[i].classes[classKey{assembly: "Shop", class: "Shop.Price"}]
+		coverage.CoverableLines = 0
+		snapshots[i].classes[classKey{assembly: "Shop", class: "Shop.Price"}] = coverage
+	}
+	ApplyCoverageAge(summary, snapshots, 80)
+	if age := summary.Assemblies[0].Classes[1].CoverageAge; age != nil {
+		t.Errorf("class without coverable lines CoverageAge = %+v, want none", *age)
+	}
+
+	ApplyCoverageAge(summary, snapshots, 0)
+	if age := summary.Assemblies[0].Classes[0].CoverageAge; age != nil {
+		t.Errorf("CoverageAge with threshold 0 = %+v, want none", *age)
+	}
+}
@@ -0,0 +1,215 @@
+// Package jsonschema generates JSON Schemas (draft 2020-12) from Go types, following the
+// rules encoding/json marshals them by. It supports the types the documents of the api
+// packages use: booleans, numbers, strings, slices, maps with string keys, pointers,
+// interfaces and structs, whose embedded structs are inlined.
+//
+// A field is required unless its json tag has omitempty. Fields that encoding/json writes
+// as null when they are nil (slices, maps, pointers) may be null unless they have omitempty.
+// A jsonschema tag adds constraints, separated by ';':
+//
+//	Status string `json:"status" jsonschema:"enum=ok|warning|error"`
+//	Version string `json:"version" jsonschema:"pattern=^1\\.[0-9]+$;format=..."`
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// schema is a JSON Schema. Properties and $defs are maps, so they are written sorted by
+// name and the output does not depend on the order of the struct fields.
+type schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"` // A name or a list of names
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	AnyOf                []*schema          `json:"anyOf,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*schema `json:"$defs,omitempty"`
+}
+
+// Generate returns the indented schema of the struct type t, with the given $id and title.
+// The named struct types t refers to are defined in $defs.
+func Generate(t reflect.Type, id, title string) ([]byte, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("jsonschema: %s is not a struct", t)
+	}
+	g := &generator{defs: make(map[string]*schema), types: make(map[string]reflect.Type)}
+	root, err := g.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	root.Schema, root.ID, root.Title = Draft, id, title
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return nil, fmt.Errorf("jsonschema: failed to marshal the schema of %s: %w", t, err)
+	}
+	return buf.Bytes(), nil
+}
+
+type generator struct {
+	defs  map[string]*schema
+	types map[string]reflect.Type // Type of each definition, to detect name clashes
+}
+
+// typeSchema returns the schema of a value of type t.
+func (g *generator) typeSchema(t reflect.Type) (*schema, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &schema{Type: "number"}, nil
+	case reflect.String:
+		return &schema{Type: "string"}, nil
+	case reflect.Interface:
+		return &schema{}, nil
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Slice, reflect.Array:
+		items, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("jsonschema: map %s has keys that are not strings", t)
+		}
+		values, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return g.structRef(t)
+	}
+	return nil, fmt.Errorf("jsonschema: unsupported type %s", t)
+}
+
+// structRef defines the named struct type t in $defs and returns a reference to it.
+func (g *generator) structRef(t reflect.Type) (*schema, error) {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+	ref := &schema{Ref: "#/$defs/" + t.Name()}
+	if defined, ok := g.types[t.Name()]; ok {
+		if defined != t {
+			return nil, fmt.Errorf("jsonschema: %s and %s have the same name", defined, t)
+		}
+		return ref, nil
+	}
+	g.types[t.Name()] = t // Before the fields, so that recursive types refer to themselves
+	def, err := g.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	g.defs[t.Name()] = def
+	return ref, nil
+}
+
+// structSchema returns the object schema of the fields of the struct type t.
+func (g *generator) structSchema(t reflect.Type) (*schema, error) {
+	s := &schema{Type: "object", Properties: make(map[string]*schema)}
+	if err := g.addFields(s, t); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// addFields adds the properties of the fields of the struct type t to s, inlining the
+// fields of embedded structs without a json name like encoding/json does.
+func (g *generator) addFields(s *schema, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := g.addFields(s, field.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := g.typeSchema(field.Type)
+		if err != nil {
+			return fmt.Errorf("%w (field %s.%s)", err, t.Name(), field.Name)
+		}
+		if err := applyConstraints(property, field.Tag.Get("jsonschema")); err != nil {
+			return fmt.Errorf("%w (field %s.%s)", err, t.Name(), field.Name)
+		}
+		omitEmpty := strings.Contains(","+options+",", ",omitempty,")
+		if !omitEmpty {
+			s.Required = append(s.Required, name)
+			property = nullable(property, field.Type)
+		}
+		s.Properties[name] = property
+	}
+	return nil
+}
+
+// nullable allows null for fields of types encoding/json writes as null when they are nil.
+func nullable(s *schema, t reflect.Type) *schema {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Pointer:
+	default:
+		return s
+	}
+	if name, ok := s.Type.(string); ok && s.Ref == "" {
+		s.Type = []string{name, "null"}
+		return s
+	}
+	return &schema{AnyOf: []*schema{s, {Type: "null"}}}
+}
+
+// applyConstraints adds the constraints of a jsonschema tag to s.
+func applyConstraints(s *schema, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	for _, constraint := range strings.Split(tag, ";") {
+		key, value, _ := strings.Cut(constraint, "=")
+		switch key {
+		case "enum":
+			s.Enum = strings.Split(value, "|")
+		case "format":
+			s.Format = value
+		case "pattern":
+			s.Pattern = value
+		default:
+			return fmt.Errorf("jsonschema: unknown constraint %q", key)
+		}
+	}
+	return nil
+}
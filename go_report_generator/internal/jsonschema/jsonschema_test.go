@@ -0,0 +1,87 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type testBase struct {
+	Version string `json:"version" jsonschema:"pattern=^1\\.[0-9]+$"`
+}
+
+type testNode struct {
+	Name     string      `json:"name"`
+	Children []*testNode `json:"children,omitempty"`
+}
+
+type testDocument struct {
+	testBase
+	Status   string             `json:"status" jsonschema:"enum=ok|error"`
+	Count    *int               `json:"count,omitempty"`
+	Ratio    float64            `json:"ratio"`
+	Tags     []string           `json:"tags"`
+	Values   map[string]float64 `json:"values,omitempty"`
+	Root     *testNode          `json:"root"`
+	Any      any                `json:"any,omitempty"`
+	Skipped  string             `json:"-"`
+	internal string
+}
+
+func TestGenerate(t *testing.T) {
+	content, err := Generate(reflect.TypeOf(testDocument{}), "https://example.com/doc.json", "Document")
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("invalid schema: %v\n%s", err, content)
+	}
+	want := map[string]any{
+		"$schema": Draft,
+		"$id":     "https://example.com/doc.json",
+		"title":   "Document",
+		"type":    "object",
+		"properties": map[string]any{
+			"version": map[string]any{"type": "string", "pattern": `^1\.[0-9]+$`},
+			"status":  map[string]any{"type": "string", "enum": []any{"ok", "error"}},
+			"count":   map[string]any{"type": "integer"},
+			"ratio":   map[string]any{"type": "number"},
+			"tags":    map[string]any{"type": []any{"array", "null"}, "items": map[string]any{"type": "string"}},
+			"values":  map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "number"}},
+			"root":    map[string]any{"anyOf": []any{map[string]any{"$ref": "#/$defs/testNode"}, map[string]any{"type": "null"}}},
+			"any":     map[string]any{},
+		},
+		"required": []any{"version", "status", "ratio", "tags", "root"},
+		"$defs": map[string]any{
+			"testNode": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":     map[string]any{"type": "string"},
+					"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/testNode"}},
+				},
+				"required": []any{"name"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Generate() =\n%s\nwant\n%v", content, want)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	type unsupported struct {
+		Channel chan int `json:"channel"`
+	}
+	type badTag struct {
+		Name string `json:"name" jsonschema:"minLength=1"`
+	}
+	for _, v := range []any{"not a struct", unsupported{}, badTag{}, struct {
+		Keys map[int]string `json:"keys"`
+	}{}} {
+		if _, err := Generate(reflect.TypeOf(v), "", ""); err == nil {
+			t.Errorf("expected an error for %T", v)
+		}
+	}
+}
@@ -1,20 +1,31 @@
 package csharp
 
 import (
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/defaultformatter"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 )
 
 // C#-specific Regexes.
 var (
-	compilerGeneratedMethodNameRegex = regexp.MustCompile(`^(?P<ClassName>.+)\+<(?P<CompilerGeneratedName>.+)>d__\d+\/MoveNext\(\)$`)
-	localFunctionMethodNameRegex     = regexp.MustCompile(`^(?:.*>g__)?(?P<NestedMethodName>[^|]+)\|`)
-	genericClassRegex                = regexp.MustCompile("^(?P<Name>.+)`(?P<Number>\\d+)$")
-	nestedTypeSeparatorRegex         = regexp.MustCompile(`[+/]`)
+	// stateMachineClassNameRegex matches the last type of a class name if it is the state
+	// machine of an async method or iterator, e.g. "<LoadAsync>d__3", "<Items>d__4`1", or
+	// "<<Run>g__Local|2_0>d" for a local function, and captures the name of the member.
+	stateMachineClassNameRegex = regexp.MustCompile(`[/+]<(?P<Member>[^/+]+)>d(?:__[^/+]*)?$`)
+	// localFunctionNameRegex matches the name of a local function, e.g. "Inner" in
+	// "<Execute>g__Inner|0_1" or "<<Execute>g__Outer|0_0>g__Inner|0_1". The innermost
+	// function is the last match.
+	localFunctionNameRegex = regexp.MustCompile(`(?:^|>g__)(?P<NestedMethodName>[^<>|]+)\|`)
+	// lambdaNameRegex matches the name of a lambda, e.g. "<Run>b__2_0" or, inside a local
+	// function, "<<Run>g__Local|2_0>b__1".
+	lambdaNameRegex          = regexp.MustCompile(`^<.*>b__[^<>]*$`)
+	genericClassRegex        = regexp.MustCompile("^(?P<Name>.+)`(?P<Number>\\d+)$")
+	nestedTypeSeparatorRegex = regexp.MustCompile(`[+/]`)
 )
 
 type CSharpProcessor struct{}
@@ -27,9 +38,19 @@ func (p *CSharpProcessor) Name() string {
 	return "C#"
 }
 
+// FileExtensions returns the extensions of the files handled by this processor.
+func (p *CSharpProcessor) FileExtensions() []string {
+	return []string{".cs", ".fs"}
+}
+
 func (p *CSharpProcessor) Detect(filePath string) bool {
 	lowerPath := strings.ToLower(filePath)
-	return strings.HasSuffix(lowerPath, ".cs") || strings.HasSuffix(lowerPath, ".fs")
+	for _, ext := range p.FileExtensions() {
+		if strings.HasSuffix(lowerPath, ext) {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *CSharpProcessor) GetLogicalClassName(rawClassName string) string {
@@ -39,6 +60,19 @@ func (p *CSharpProcessor) GetLogicalClassName(rawClassName string) string {
 	return rawClassName
 }
 
+// GetNestedClassName cuts the raw name before its first compiler-generated nested type,
+// whose names start with "<" (async state machines "<Run>d__2", closures "<>c" and
+// "<>c__DisplayClass0_0", local functions "<Run>g__Local|0_0"). A name that starts with a
+// compiler-generated type is returned unchanged, so it is still filtered out.
+func (p *CSharpProcessor) GetNestedClassName(rawClassName string) string {
+	for i := 1; i < len(rawClassName); i++ {
+		if strings.IndexByte("/$+", rawClassName[i-1]) != -1 && rawClassName[i] == '<' {
+			return rawClassName[:i-1]
+		}
+	}
+	return rawClassName
+}
+
 func (p *CSharpProcessor) FormatClassName(class *model.Class) string {
 	nameForDisplay := nestedTypeSeparatorRegex.ReplaceAllString(class.Name, ".")
 	match := genericClassRegex.FindStringSubmatch(nameForDisplay)
@@ -68,31 +102,65 @@ func (p *CSharpProcessor) FormatClassName(class *model.Class) string {
 	return baseDisplayName
 }
 
+// FormatMethodName cleans the names the C# compiler gives to methods that are not written as
+// such in the source, like ReportGenerator does:
+//
+//   - The MoveNext method of the state machine of an async method or iterator gets the name
+//     of the method, e.g. "LoadAsync()" for "Ns.Service/<LoadAsync>d__3", with the dots of
+//     explicit interface implementations restored ("<System-IDisposable-Dispose>d__1").
+//   - Local functions get their own name, e.g. "Inner()" for "<Execute>g__Inner|0_1", also
+//     if they are nested into another local function or are async.
+//
+// Other names, e.g. of explicit interface implementations and generic methods, are
+// returned with their signature as they are.
 func (p *CSharpProcessor) FormatMethodName(method *model.Method, class *model.Class) string {
-	methodNamePlusSignature := method.Name + method.Signature
-	combinedNameForContext := class.Name + "/" + methodNamePlusSignature
-
-	if strings.Contains(methodNamePlusSignature, "|") {
-		if match := localFunctionMethodNameRegex.FindStringSubmatch(methodNamePlusSignature); match != nil {
-			if nestedName := findNamedGroup(localFunctionMethodNameRegex, match, "NestedMethodName"); nestedName != "" {
+	member, generated := generatedMemberName(method, class)
+	if strings.Contains(member, "|") {
+		if matches := localFunctionNameRegex.FindAllStringSubmatch(member, -1); matches != nil {
+			last := matches[len(matches)-1]
+			if nestedName := findNamedGroup(localFunctionNameRegex, last, "NestedMethodName"); nestedName != "" {
 				return nestedName + "()"
 			}
 		}
 	}
+	if generated {
+		return strings.ReplaceAll(member, "-", ".") + "()"
+	}
+	return method.Name + method.Signature
+}
+
+// IsCompilerGeneratedMethod reports whether the method is a lambda, or the state machine of
+// an async lambda, e.g. "<Run>b__2_0" of "Ns.Service/<>c" or the MoveNext method of
+// "Ns.Service/<>c__DisplayClass2_0/<<Run>b__0>d". ReportGenerator leaves them out, their
+// lines are part of the method that declares them.
+func (p *CSharpProcessor) IsCompilerGeneratedMethod(method *model.Method, class *model.Class) bool {
+	member, _ := generatedMemberName(method, class)
+	return lambdaNameRegex.MatchString(member)
+}
 
-	if strings.HasSuffix(methodNamePlusSignature, "MoveNext()") {
-		if match := compilerGeneratedMethodNameRegex.FindStringSubmatch(combinedNameForContext); match != nil {
-			if compilerGenName := findNamedGroup(compilerGeneratedMethodNameRegex, match, "CompilerGeneratedName"); compilerGenName != "" {
-				return compilerGenName + "()"
+// generatedMemberName returns the name of the member whose code a method runs: for the
+// MoveNext method of a state machine the name in angle brackets of the state machine
+// (generated is true then), otherwise the raw name of the method.
+func generatedMemberName(method *model.Method, class *model.Class) (name string, generated bool) {
+	if method.Name == "MoveNext" && class != nil {
+		if match := stateMachineClassNameRegex.FindStringSubmatch(class.Name); match != nil {
+			if member := findNamedGroup(stateMachineClassNameRegex, match, "Member"); member != "" {
+				return member, true
 			}
 		}
 	}
-
-	return methodNamePlusSignature
+	return method.Name, false
 }
 
+// CategorizeCodeElement returns PropertyElementType for property accessors, also of
+// explicit interface implementations like "System.Collections.IEnumerator.get_Current()".
 func (p *CSharpProcessor) CategorizeCodeElement(method *model.Method) model.CodeElementType {
-	if strings.HasPrefix(method.DisplayName, "get_") || strings.HasPrefix(method.DisplayName, "set_") {
+	name := method.DisplayName
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = name[:i]
+	}
+	name = name[strings.LastIndexByte(name, '.')+1:]
+	if strings.HasPrefix(name, "get_") || strings.HasPrefix(name, "set_") {
 		return model.PropertyElementType
 	}
 	return model.MethodElementType
@@ -112,6 +180,15 @@ func (p *CSharpProcessor) CalculateCyclomaticComplexity(filePath string) ([]mode
 	return nil, language.ErrNotSupported
 }
 
+// DetectMethods finds the methods of a C# file by their header and braces. F# files,
+// whose functions end with their indentation, are not supported.
+func (p *CSharpProcessor) DetectMethods(filePath string, sourceLines []string) ([]language.DetectedMethod, error) {
+	if !strings.EqualFold(filepath.Ext(filePath), ".cs") {
+		return nil, language.ErrNotSupported
+	}
+	return defaultformatter.DetectBraceFunctions(sourceLines), nil
+}
+
 func findNamedGroup(re *regexp.Regexp, match []string, groupName string) string {
 	for i, name := range re.SubexpNames() {
 		if i > 0 && i < len(match) && name == groupName {
@@ -3,6 +3,7 @@ package csharp_test
 import (
 	"testing"
 
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/csharp"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -128,6 +129,58 @@ func TestGetLogicalClassName(t *testing.T) {
 	}
 }
 
+func TestGetNestedClassName(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rawClassName string
+		expected     string
+	}{
+		{
+			name:         "StandardClassName_ShouldReturnSame",
+			rawClassName: "MyProject.Core.MyService",
+			expected:     "MyProject.Core.MyService",
+		},
+		{
+			name:         "NestedClass_ShouldKeepNesting",
+			rawClassName: "MyProject.Core.MyService+Nested+DeeplyNested",
+			expected:     "MyProject.Core.MyService+Nested+DeeplyNested",
+		},
+		{
+			name:         "AsyncStateMachineOfNestedClass_ShouldReturnNestedClass",
+			rawClassName: "MyProject.Core.MyService+Nested/<RunAsync>d__2",
+			expected:     "MyProject.Core.MyService+Nested",
+		},
+		{
+			name:         "DisplayClass_ShouldReturnParent",
+			rawClassName: "MyProject.Core.MyService+<>c__DisplayClass0_0",
+			expected:     "MyProject.Core.MyService",
+		},
+		{
+			name:         "ClosureInsideStateMachine_ShouldReturnParent",
+			rawClassName: "MyProject.Core.MyService/<RunAsync>d__2/<>c",
+			expected:     "MyProject.Core.MyService",
+		},
+		{
+			name:         "TopLevelCompilerGeneratedClass_ShouldReturnSame",
+			rawClassName: "<>c",
+			expected:     "<>c",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			namer := csharp.NewCSharpProcessor().(language.NestedClassNamer)
+
+			// Act
+			result := namer.GetNestedClassName(tc.rawClassName)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func TestIsCompilerGeneratedClass(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -327,3 +380,106 @@ func TestCategorizeCodeElement(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectMethods(t *testing.T) {
+	// Arrange
+	detector := csharp.NewCSharpProcessor().(language.MethodDetector)
+	source := []string{
+		"public class Cart",
+		"{",
+		"    public int Count { get { return count; } }",
+		"",
+		"    public void Add(int price)",
+		"    {",
+		"        if (price > 0)",
+		"        {",
+		"            count++;",
+		"        }",
+		"    }",
+		"}",
+	}
+
+	// Act
+	methods, err := detector.DetectMethods("/src/Cart.cs", source)
+	_, fsErr := detector.DetectMethods("/src/Cart.fs", source)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []language.DetectedMethod{{Name: "Add", Signature: "(int price)", FirstLine: 5, LastLine: 11}}, methods)
+	assert.ErrorIs(t, fsErr, language.ErrNotSupported, "F# functions are not delimited by braces")
+}
+
+// TestFormatMethodName_CoverletCorpus cleans the raw class and method names of coverlet
+// Cobertura reports like ReportGenerator does. Compiler-generated methods are lambdas,
+// which are left out of the methods of the class.
+func TestFormatMethodName_CoverletCorpus(t *testing.T) {
+	testCases := []struct {
+		name      string
+		class     string
+		method    string
+		signature string
+		expected  string
+		generated bool
+	}{
+		// Plain members
+		{name: "Method", class: "Shop.Cart", method: "Add", signature: "(System.Int32,System.Decimal)", expected: "Add(System.Int32,System.Decimal)"},
+		{name: "Constructor", class: "Shop.Cart", method: ".ctor", signature: "(Shop.IPricing)", expected: ".ctor(Shop.IPricing)"},
+		{name: "StaticConstructor", class: "Shop.Cart", method: ".cctor", signature: "()", expected: ".cctor()"},
+		{name: "PropertyGetter", class: "Shop.Cart", method: "get_Total", signature: "()", expected: "get_Total()"},
+		{name: "Operator", class: "Shop.Money", method: "op_Addition", signature: "(Shop.Money,Shop.Money)", expected: "op_Addition(Shop.Money,Shop.Money)"},
+		{name: "MoveNextOfCustomIterator", class: "Shop.CartEnumerator", method: "MoveNext", signature: "()", expected: "MoveNext()"},
+
+		// Async methods and iterators
+		{name: "AsyncMethod", class: "Shop.CheckoutService/<PayAsync>d__3", method: "MoveNext", signature: "()", expected: "PayAsync()"},
+		{name: "AsyncMethodPlusSeparator", class: "Shop.CheckoutService+<PayAsync>d__3", method: "MoveNext", signature: "()", expected: "PayAsync()"},
+		{name: "AsyncMethodOfNestedClass", class: "Shop.CheckoutService/Validator/<ValidateAsync>d__1", method: "MoveNext", signature: "()", expected: "ValidateAsync()"},
+		{name: "Iterator", class: "Shop.Catalog/<GetItems>d__4", method: "MoveNext", signature: "()", expected: "GetItems()"},
+		{name: "AsyncIterator", class: "Shop.Catalog/<StreamItemsAsync>d__5", method: "MoveNext", signature: "()", expected: "StreamItemsAsync()"},
+		{name: "GenericAsyncMethod", class: "Shop.Repository`1/<FindAsync>d__2`1", method: "MoveNext", signature: "()", expected: "FindAsync()"},
+		{name: "AsyncExplicitInterfaceImplementation", class: "Shop.Connection/<System-IAsyncDisposable-DisposeAsync>d__7", method: "MoveNext", signature: "()", expected: "System.IAsyncDisposable.DisposeAsync()"},
+
+		// Local functions
+		{name: "LocalFunction", class: "Shop.Importer", method: "<Import>g__ParseLine|0_0", signature: "(System.String)", expected: "ParseLine()"},
+		{name: "StaticLocalFunction", class: "Shop.Importer", method: "<Import>g__Trim|0_1", signature: "(System.String)", expected: "Trim()"},
+		{name: "LocalFunctionWithoutParentName", class: "Shop.Importer", method: "ParseLine|1_12", signature: "(System.Int32)", expected: "ParseLine()"},
+		{name: "NestedLocalFunction", class: "Shop.Importer", method: "<<Import>g__Outer|0_0>g__Inner|0_1", signature: "()", expected: "Inner()"},
+		{name: "LocalFunctionInLocalFunction", class: "Shop.Importer", method: "<Import>g__Inner|0_1", signature: "()", expected: "Inner()"},
+		{name: "AsyncLocalFunction", class: "Shop.Importer/<<ImportAsync>g__LoadAsync|2_0>d", method: "MoveNext", signature: "()", expected: "LoadAsync()"},
+		{name: "AsyncNestedLocalFunction", class: "Shop.Importer/<<<ImportAsync>g__Outer|2_0>g__InnerAsync|2_1>d", method: "MoveNext", signature: "()", expected: "InnerAsync()"},
+		{name: "IteratorLocalFunction", class: "Shop.Importer/<<Import>g__Lines|3_0>d", method: "MoveNext", signature: "()", expected: "Lines()"},
+
+		// Lambdas
+		{name: "Lambda", class: "Shop.Cart/<>c", method: "<Total>b__5_0", signature: "(Shop.Item)", expected: "<Total>b__5_0(Shop.Item)", generated: true},
+		{name: "Closure", class: "Shop.Cart/<>c__DisplayClass6_0", method: "<Remove>b__0", signature: "(Shop.Item)", expected: "<Remove>b__0(Shop.Item)", generated: true},
+		{name: "AsyncLambda", class: "Shop.Cart/<>c/<<SaveAsync>b__7_0>d", method: "MoveNext", signature: "()", expected: "<SaveAsync>b__7_0()", generated: true},
+		{name: "AsyncLambdaInClosure", class: "Shop.Cart/<>c__DisplayClass8_0/<<SyncAsync>b__0>d", method: "MoveNext", signature: "()", expected: "<SyncAsync>b__0()", generated: true},
+		{name: "LambdaInLocalFunction", class: "Shop.Importer/<>c", method: "<<Import>g__Parse|0_0>b__0_1", signature: "(System.Char)", expected: "Parse()", generated: true},
+		{name: "AsyncLambdaInLocalFunction", class: "Shop.Importer/<>c__DisplayClass2_0/<<<ImportAsync>g__LoadAsync|2_0>b__1>d", method: "MoveNext", signature: "()", expected: "LoadAsync()", generated: true},
+
+		// Explicit interface implementations and generic methods
+		{name: "ExplicitInterfaceImplementation", class: "Shop.Connection", method: "System.IDisposable.Dispose", signature: "()", expected: "System.IDisposable.Dispose()"},
+		{name: "ExplicitGenericInterfaceImplementation", class: "Shop.Catalog", method: "System.Collections.Generic.IEnumerable<Shop.Item>.GetEnumerator", signature: "()", expected: "System.Collections.Generic.IEnumerable<Shop.Item>.GetEnumerator()"},
+		{name: "ExplicitInterfaceProperty", class: "Shop.CartEnumerator", method: "System.Collections.IEnumerator.get_Current", signature: "()", expected: "System.Collections.IEnumerator.get_Current()"},
+		{name: "GenericMethod", class: "Shop.Mapper", method: "Map", signature: "(TSource)", expected: "Map(TSource)"},
+		{name: "MethodOfGenericClass", class: "Shop.Repository`1", method: "Add", signature: "(T)", expected: "Add(T)"},
+	}
+
+	processor := csharp.NewCSharpProcessor()
+	detector := processor.(language.CompilerGeneratedMethodDetector)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := &model.Method{Name: tc.method, Signature: tc.signature}
+			class := &model.Class{Name: tc.class}
+
+			assert.Equal(t, tc.expected, processor.FormatMethodName(method, class))
+			assert.Equal(t, tc.generated, detector.IsCompilerGeneratedMethod(method, class))
+		})
+	}
+}
+
+func TestCategorizeCodeElement_ExplicitInterfaceProperty(t *testing.T) {
+	formatter := csharp.NewCSharpProcessor()
+
+	assert.Equal(t, model.PropertyElementType, formatter.CategorizeCodeElement(&model.Method{DisplayName: "System.Collections.IEnumerator.get_Current()"}))
+	assert.Equal(t, model.MethodElementType, formatter.CategorizeCodeElement(&model.Method{DisplayName: "System.IDisposable.Dispose()"}))
+}
@@ -0,0 +1,216 @@
+package defaultformatter
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
+)
+
+var (
+	pythonDefRegex   = regexp.MustCompile(`^(\s*)(?:async\s+)?def\s+([A-Za-z_]\w*)\s*\(([^)]*\))?`)
+	pythonClassRegex = regexp.MustCompile(`^(\s*)class\s+([A-Za-z_]\w*)`)
+
+	// cFunctionHeaderRegex matches the end of a C-like function header in front of its
+	// opening brace, e.g. "static int add(int a, int b)" or "void Stack::push(T v) const".
+	cFunctionHeaderRegex = regexp.MustCompile(`([A-Za-z_~][\w:~]*)\s*\(([^()]*(?:\([^()]*\)[^()]*)*)\)\s*(?:const|noexcept|override|final|\s)*(?:->\s*[\w:<>,*&\s]+)?$`)
+)
+
+// cKeywords are words that are followed by parentheses and a block but are no functions.
+var cKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "do": true,
+	"else": true, "return": true, "sizeof": true, "defined": true, "using": true,
+}
+
+// DetectMethods finds functions by their syntax: for Python files by "def" and the
+// indentation of the following lines, for all other files by a C-like function header
+// followed by a block in braces. The result is a heuristic; macros or unusual formatting
+// can hide a function.
+func (p *DefaultProcessor) DetectMethods(filePath string, sourceLines []string) ([]language.DetectedMethod, error) {
+	if strings.EqualFold(filepath.Ext(filePath), ".py") {
+		return detectPythonFunctions(sourceLines), nil
+	}
+	return DetectBraceFunctions(sourceLines), nil
+}
+
+// detectPythonFunctions returns the functions and methods of a Python file. A function
+// ends at the last non-blank line before the next line that is indented no deeper than
+// its "def". Methods are prefixed with the name of their class.
+func detectPythonFunctions(sourceLines []string) []language.DetectedMethod {
+	type scope struct {
+		indent int
+		name   string
+	}
+	var (
+		methods []language.DetectedMethod
+		classes []scope
+		current *language.DetectedMethod
+		indent  int
+	)
+
+	for i, line := range sourceLines {
+		lineNumber := i + 1
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if current != nil {
+			if lineIndent > indent {
+				current.LastLine = lineNumber
+				continue
+			}
+			methods = append(methods, *current)
+			current = nil
+		}
+		for len(classes) > 0 && lineIndent <= classes[len(classes)-1].indent {
+			classes = classes[:len(classes)-1]
+		}
+
+		if match := pythonClassRegex.FindStringSubmatch(line); match != nil {
+			classes = append(classes, scope{indent: lineIndent, name: match[2]})
+			continue
+		}
+		if match := pythonDefRegex.FindStringSubmatch(line); match != nil {
+			name := match[2]
+			if len(classes) > 0 {
+				name = classes[len(classes)-1].name + "." + name
+			}
+			current = &language.DetectedMethod{
+				Name:      name,
+				Signature: signatureOf(match[3]),
+				FirstLine: lineNumber,
+				LastLine:  lineNumber,
+			}
+			indent = lineIndent
+		}
+	}
+	if current != nil {
+		methods = append(methods, *current)
+	}
+	return methods
+}
+
+// DetectBraceFunctions returns the functions of a file with C-like syntax. A block is a
+// function if the text in front of its opening brace ends with a name and a parameter
+// list. Blocks of namespaces, classes and "extern" are searched for functions as well,
+// blocks inside a function are not.
+func DetectBraceFunctions(sourceLines []string) []language.DetectedMethod {
+	type block struct {
+		function *language.DetectedMethod
+	}
+	var (
+		methods         []language.DetectedMethod
+		blocks          []block
+		functionDepth   int // Number of enclosing function blocks
+		header          strings.Builder
+		headerFirstLine int
+		inBlockComment  bool
+	)
+
+	for i, line := range sourceLines {
+		lineNumber := i + 1
+		if !inBlockComment && strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue // Preprocessor directive
+		}
+
+		var quote byte
+		for j := 0; j < len(line); j++ {
+			c := line[j]
+			switch {
+			case inBlockComment:
+				if c == '*' && j+1 < len(line) && line[j+1] == '/' {
+					inBlockComment = false
+					j++
+				}
+				continue
+			case quote != 0:
+				if c == '\\' {
+					j++
+				} else if c == quote {
+					quote = 0
+				}
+				continue
+			case c == '/' && j+1 < len(line) && line[j+1] == '/':
+				j = len(line)
+				continue
+			case c == '/' && j+1 < len(line) && line[j+1] == '*':
+				inBlockComment = true
+				j++
+				continue
+			case c == '"' || c == '\'':
+				quote = c
+			}
+
+			switch c {
+			case '{':
+				var function *language.DetectedMethod
+				if functionDepth == 0 {
+					function = functionFromHeader(header.String(), headerFirstLine)
+				}
+				if function != nil {
+					functionDepth++
+				}
+				blocks = append(blocks, block{function: function})
+				header.Reset()
+			case '}':
+				if len(blocks) > 0 {
+					closed := blocks[len(blocks)-1]
+					blocks = blocks[:len(blocks)-1]
+					if closed.function != nil {
+						functionDepth--
+						closed.function.LastLine = lineNumber
+						methods = append(methods, *closed.function)
+					}
+				}
+				header.Reset()
+			case ';':
+				header.Reset()
+			default:
+				if header.Len() == 0 && (c == ' ' || c == '\t') {
+					continue
+				}
+				if header.Len() == 0 {
+					headerFirstLine = lineNumber
+				}
+				header.WriteByte(c)
+			}
+		}
+		if header.Len() > 0 {
+			header.WriteByte(' ')
+		}
+	}
+
+	return methods
+}
+
+// functionFromHeader returns the function introduced by the text in front of an opening
+// brace, or nil if the text is no function header. Headers with an assignment in front of
+// the parameter list, such as array initializers or lambdas, are rejected.
+func functionFromHeader(header string, firstLine int) *language.DetectedMethod {
+	header = strings.TrimSpace(header)
+	match := cFunctionHeaderRegex.FindStringSubmatchIndex(header)
+	if match == nil {
+		return nil
+	}
+	name := header[match[2]:match[3]]
+	if cKeywords[name] || strings.Contains(header[:match[0]], "=") {
+		return nil
+	}
+	return &language.DetectedMethod{
+		Name:      name,
+		Signature: signatureOf(header[match[4]:match[5]] + ")"),
+		FirstLine: firstLine,
+	}
+}
+
+// signatureOf returns the signature of a function from its parameter list including the
+// closing parenthesis, or "(...)" if the list continues on the next line.
+func signatureOf(parameters string) string {
+	if !strings.HasSuffix(parameters, ")") {
+		return "(...)"
+	}
+	return "(" + strings.Join(strings.Fields(parameters), " ")
+}
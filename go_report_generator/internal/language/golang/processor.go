@@ -1,6 +1,10 @@
 package golang
 
 import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"strings"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
@@ -18,6 +22,11 @@ func (p *GoProcessor) Name() string {
 	return "Go"
 }
 
+// FileExtensions returns the extensions of the files handled by this processor.
+func (p *GoProcessor) FileExtensions() []string {
+	return []string{".go"}
+}
+
 func (p *GoProcessor) Detect(filePath string) bool {
 	return strings.HasSuffix(strings.ToLower(filePath), ".go")
 }
@@ -63,3 +72,48 @@ func (p *GoProcessor) CalculateCyclomaticComplexity(filePath string) ([]model.Me
 
 	return metrics, nil
 }
+
+// DetectMethods lists the functions and methods declared in the file. Methods are named
+// like gocyclo names them, e.g. "(*Stack).Push", so their complexity can be matched.
+func (p *GoProcessor) DetectMethods(filePath string, sourceLines []string) ([]language.DetectedMethod, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filePath, strings.Join(sourceLines, "\n"), parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Go source: %w", err)
+	}
+
+	var methods []language.DetectedMethod
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		name := fn.Name.Name
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			if receiver := receiverTypeName(fn.Recv.List[0].Type); receiver != "" {
+				name = fmt.Sprintf("(%s).%s", receiver, name)
+			}
+		}
+		methods = append(methods, language.DetectedMethod{
+			Name:      name,
+			FirstLine: fset.Position(fn.Pos()).Line,
+			LastLine:  fset.Position(fn.End()).Line,
+		})
+	}
+	return methods, nil
+}
+
+// receiverTypeName returns the type of a method receiver without type parameters, e.g. "*Stack".
+func receiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + receiverTypeName(t.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
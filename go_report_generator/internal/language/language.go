@@ -2,6 +2,8 @@ package language
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 )
@@ -24,6 +26,8 @@ type Processor interface {
 	FormatClassName(class *model.Class) string
 
 	// FormatMethodName transforms a raw method name and signature into a display-friendly version.
+	// class has the raw name of the class the report lists the method in, which is a nested
+	// type for e.g. the MoveNext method of the state machine of an async method.
 	FormatMethodName(method *model.Method, class *model.Class) string
 
 	// CategorizeCodeElement determines if a method is a standard method, property, etc.
@@ -38,9 +42,51 @@ type Processor interface {
 	CalculateCyclomaticComplexity(filePath string) ([]model.MethodMetric, error)
 }
 
+// ExtensionProvider is implemented by processors that are detected by file extension.
+type ExtensionProvider interface {
+	// FileExtensions returns the lower-case extensions (including the dot) the processor handles.
+	FileExtensions() []string
+}
+
+// NestedClassNamer is implemented by processors of languages with nested types. Parsers
+// use it instead of GetLogicalClassName when nested classes are kept separate.
+type NestedClassNamer interface {
+	// GetNestedClassName returns the grouping key of a class that keeps its nesting but
+	// drops compiler-generated types, e.g. "Outer+Inner" for "Outer+Inner/<RunAsync>d__2".
+	GetNestedClassName(rawClassName string) string
+}
+
+// CompilerGeneratedMethodDetector is implemented by processors of languages whose compilers
+// generate methods that are not written in the source, e.g. the methods of C# lambdas.
+// Parsers leave such methods out of the methods of a class; their lines stay part of it.
+type CompilerGeneratedMethodDetector interface {
+	// IsCompilerGeneratedMethod reports whether the method is compiler-generated. class is
+	// passed like to Processor.FormatMethodName.
+	IsCompilerGeneratedMethod(method *model.Method, class *model.Class) bool
+}
+
+// MethodDetector is implemented by processors that can find the functions declared in a
+// source file. Parsers use it when a coverage report lists the covered lines of a class
+// but not its methods.
+type MethodDetector interface {
+	// DetectMethods returns the functions declared in the given lines of the file, in
+	// source order. Nested functions are part of the function that contains them.
+	DetectMethods(filePath string, sourceLines []string) ([]DetectedMethod, error)
+}
+
+// DetectedMethod is a function found in a source file by a MethodDetector.
+type DetectedMethod struct {
+	Name      string
+	Signature string
+	FirstLine int
+	LastLine  int
+}
+
 type ProcessorFactory struct {
 	processors       []Processor
 	defaultProcessor Processor
+	// forcedProcessor, when set, is returned for every file regardless of its extension.
+	forcedProcessor Processor
 }
 
 func NewProcessorFactory(processors ...Processor) *ProcessorFactory {
@@ -63,7 +109,41 @@ func NewProcessorFactory(processors ...Processor) *ProcessorFactory {
 	return factory
 }
 
+// ForceProcessor makes the factory return the processor with the given name for
+// all files instead of detecting it by file extension. Names are matched
+// case-insensitively and "sharp" may be spelled out (e.g. "csharp" selects "C#").
+// An empty name restores extension-based detection.
+func (f *ProcessorFactory) ForceProcessor(name string) error {
+	if strings.TrimSpace(name) == "" {
+		f.forcedProcessor = nil
+		return nil
+	}
+
+	wanted := normalizeProcessorName(name)
+	for _, p := range append([]Processor{f.defaultProcessor}, f.processors...) {
+		if normalizeProcessorName(p.Name()) == wanted {
+			f.forcedProcessor = p
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown language processor '%s'", name)
+}
+
+func normalizeProcessorName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "#", "sharp")
+}
+
+// Processors returns the registered processors in detection order, followed by the
+// default processor.
+func (f *ProcessorFactory) Processors() []Processor {
+	return append(append([]Processor(nil), f.processors...), f.defaultProcessor)
+}
+
 func (f *ProcessorFactory) FindProcessorForFile(filePath string) Processor {
+	if f.forcedProcessor != nil {
+		return f.forcedProcessor
+	}
+
 	for _, p := range f.processors {
 		if p.Detect(filePath) {
 			return p
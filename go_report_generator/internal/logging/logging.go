@@ -70,6 +70,7 @@ type Config struct {
 	File      string                // "" = console only
 	Format    string                // "text" (default) | "json"
 	FS        filesystem.Filesystem // if nil, uses filesystem.DefaultFS{}
+	Console   io.Writer             // if nil, uses os.Stderr
 }
 
 func Init(cfg *Config) (io.Closer, error) {
@@ -83,8 +84,12 @@ func Init(cfg *Config) (io.Closer, error) {
 	}
 
 	// Collect writers
+	console := cfg.Console
+	if console == nil {
+		console = os.Stderr
+	}
 	var writers []io.Writer
-	writers = append(writers, os.Stderr)
+	writers = append(writers, console)
 
 	var closer io.Closer
 	if cfg.File != "" {
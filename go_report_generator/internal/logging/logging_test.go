@@ -325,6 +325,29 @@ func TestInit_ConsoleOnly(t *testing.T) {
 	}
 }
 
+func TestInit_ConsoleWriter(t *testing.T) {
+	// Arrange
+	originalDefault := slog.Default()
+	defer slog.SetDefault(originalDefault)
+	var console bytes.Buffer
+	cfg := &Config{
+		Verbosity: Info,
+		Format:    "text",
+		Console:   &console,
+	}
+
+	// Act
+	if _, err := Init(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slog.Info("to the console writer")
+
+	// Assert
+	if !strings.Contains(console.String(), "to the console writer") {
+		t.Errorf("expected the record in the console writer, got %q", console.String())
+	}
+}
+
 func TestInit_FileOutput(t *testing.T) {
 	// Arrange
 	originalDefault := slog.Default()
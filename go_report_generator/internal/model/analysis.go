@@ -1,5 +1,7 @@
 package model
 
+import "slices"
+
 // SummaryResult is the top-level analyzed report, similar to C#'s SummaryResult
 type SummaryResult struct {
 	ParserName      string
@@ -11,6 +13,57 @@ type SummaryResult struct {
 	BranchesCovered *int // Overall - Pointer to indicate presence
 	BranchesValid   *int // Overall - Pointer to indicate presence
 	TotalLines      int  // Grand total physical lines from unique source files
+
+	// MethodCoverageAvailable is true if any of the reports provides methods. Otherwise
+	// the method coverage is not shown, as it would always be 0 of 0.
+	MethodCoverageAvailable bool
+
+	// MissingSourceFiles lists the files referenced by the coverage reports that
+	// could not be found on disk, sorted by path.
+	MissingSourceFiles []MissingSourceFile
+
+	// SkippedReports lists the report files that could not be parsed, in the order in
+	// which they were passed. Their coverage is not part of the summary.
+	SkippedReports []SkippedReport
+
+	// HiddenClasses is the number of classes removed by the class coverage filter.
+	HiddenClasses int
+
+	// ExternalFiles is the number of unique files that are not in the source directories,
+	// see CodeFile.InSourceDirs. Reports only mark external files if it is not 0.
+	ExternalFiles int
+
+	// Directories aggregates the coverage of all code files by directory, nil if the
+	// tree was not built.
+	Directories *DirectoryCoverage
+}
+
+// DirectoryCoverage is a node of the directory tree built from the code file paths,
+// with the coverage summed over all files below it.
+type DirectoryCoverage struct {
+	Name            string // Last path segment; for the root and top-level groups the directory they stand for
+	Path            string // Slash-separated path of the node within the tree, "" for the root
+	Files           int    // Number of unique files below the node
+	LinesCovered    int
+	LinesValid      int
+	BranchesCovered int
+	BranchesValid   int
+	HasBranchData   bool // True if any file below the node has branch points
+	Children        []*DirectoryCoverage
+}
+
+// MissingSourceFile records a source file that could not be resolved while parsing.
+type MissingSourceFile struct {
+	Path     string // Path as it appears in the coverage report
+	Assembly string
+	Class    string // Display name of the class that referenced the file
+}
+
+// SkippedReport records a report file that was left out because it could not be parsed.
+type SkippedReport struct {
+	Path   string // Path of the report file
+	Parser string // Name of the parser that failed, empty if no parser supports the file
+	Error  string
 }
 
 type Assembly struct {
@@ -18,9 +71,10 @@ type Assembly struct {
 	Classes         []Class
 	LinesCovered    int
 	LinesValid      int
-	BranchesCovered *int // Pointer
-	BranchesValid   *int // Pointer
-	TotalLines      int  // Sum of unique file TotalLines in this assembly
+	BranchesCovered *int     // Pointer
+	BranchesValid   *int     // Pointer
+	TotalLines      int      // Unique files counted once, so it can be lower than the sum of the class TotalLines
+	Complexity      *float64 // Complexity declared for the whole assembly by the report, nil if none
 }
 
 type Class struct {
@@ -32,12 +86,14 @@ type Class struct {
 	LinesValid          int
 	BranchesCovered     *int // Pointer
 	BranchesValid       *int // Pointer
-	TotalLines          int
+	TotalLines          int  // Physical lines of all files of the class, a file shared with other classes included
 	CoveredMethods      int
 	FullyCoveredMethods int
 	TotalMethods        int
-	Metrics             map[string]float64 // Aggregated metrics (e.g., sum of complexities)
+	Metrics             map[string]float64 // Method metrics aggregated per the aggregations of the default metrics
+	Complexity          *float64           // Complexity declared for the class by the report, nil if only its methods have one
 	HistoricCoverages   []HistoricCoverage // Historical coverage data for this class
+	CoverageAge         *CoverageAge       // Runs the line coverage has been below the target, nil if it was not in the run before
 }
 
 type CodeFile struct {
@@ -48,6 +104,121 @@ type CodeFile struct {
 	TotalLines     int
 	MethodMetrics  []MethodMetric // Metrics for methods within this file
 	CodeElements   []CodeElement  // Code elements (methods/properties) in this file
+
+	// ApproximateBranchCoverage is true if the branches of the lines were derived from the
+	// block structure of a Go cover profile instead of being measured.
+	ApproximateBranchCoverage bool
+	// ReportPath is the path of the file as the coverage report lists it, if the file was
+	// found under a different path, e.g. relative to a source directory. Empty otherwise.
+	ReportPath string
+
+	// InSourceDirs is true if the file belongs to the code base: its resolved path is below
+	// a source directory of the report or, for Go, the module root. Files outside of them,
+	// e.g. of obj/ or the Go standard library, are external.
+	InSourceDirs bool
+}
+
+// NewCodeFile returns a file with the lines sorted by number and the covered and
+// coverable lines counted. The total lines are those up to the last line with data.
+func NewCodeFile(path string, lines []Line) CodeFile {
+	file := CodeFile{Path: path, Lines: slices.Clone(lines)}
+	slices.SortStableFunc(file.Lines, func(a, b Line) int { return a.Number - b.Number })
+	file.CoveredLines, file.CoverableLines = CountLines(file.Lines)
+	if len(file.Lines) > 0 {
+		file.TotalLines = file.Lines[len(file.Lines)-1].Number
+	}
+	return file
+}
+
+// SourceLines returns the source code of the file from the Content of its lines. ok is
+// false if the lines do not hold the whole file, i.e. they are not numbered from 1 to at
+// least TotalLines without gaps, or none of them has content.
+func (f *CodeFile) SourceLines() (lines []string, ok bool) {
+	if len(f.Lines) == 0 || len(f.Lines) < f.TotalLines {
+		return nil, false
+	}
+	hasContent := false
+	lines = make([]string, len(f.Lines))
+	for i, line := range f.Lines {
+		if line.Number != i+1 {
+			return nil, false
+		}
+		lines[i] = line.Content
+		hasContent = hasContent || line.Content != ""
+	}
+	if !hasContent {
+		return nil, false
+	}
+	return lines, true
+}
+
+// CountLines returns the number of coverable lines with hits and of all coverable lines.
+func CountLines(lines []Line) (covered, coverable int) {
+	for _, line := range lines {
+		if line.Hits < 0 {
+			continue
+		}
+		coverable++
+		if line.Hits > 0 {
+			covered++
+		}
+	}
+	return covered, coverable
+}
+
+// HasApproximateBranchCoverage reports whether any file of the class has approximated branches.
+func (c *Class) HasApproximateBranchCoverage() bool {
+	for i := range c.Files {
+		if c.Files[i].ApproximateBranchCoverage {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExternal reports whether the class has files and all of them are outside the source
+// directories, see CodeFile.InSourceDirs.
+func (c *Class) IsExternal() bool {
+	for i := range c.Files {
+		if c.Files[i].InSourceDirs {
+			return false
+		}
+	}
+	return len(c.Files) > 0
+}
+
+// CountExternalFiles returns the number of unique files of the assemblies outside the source
+// directories. Paths are compared by key, e.g. utils.PathKey; a file is inside the source
+// directories if any class reports it there.
+func CountExternalFiles(assemblies []Assembly, key func(path string) string) int {
+	inSourceDirs := make(map[string]bool)
+	for i := range assemblies {
+		for j := range assemblies[i].Classes {
+			for _, f := range assemblies[i].Classes[j].Files {
+				k := key(f.Path)
+				inSourceDirs[k] = inSourceDirs[k] || f.InSourceDirs
+			}
+		}
+	}
+	count := 0
+	for _, inside := range inSourceDirs {
+		if !inside {
+			count++
+		}
+	}
+	return count
+}
+
+// HasApproximateBranchCoverage reports whether any file of the report has approximated branches.
+func (s *SummaryResult) HasApproximateBranchCoverage() bool {
+	for i := range s.Assemblies {
+		for j := range s.Assemblies[i].Classes {
+			if s.Assemblies[i].Classes[j].HasApproximateBranchCoverage() {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 type CodeElementType int
@@ -61,6 +232,7 @@ const (
 type BranchCoverageDetail struct {
 	Identifier string // Unique identifier for the branch, e.g., "0", "1", "true", "false"
 	Visits     int    // Number of times this specific branch was visited
+	Type       string // Kind of the condition if the report names it, e.g. "jump" or "switch"
 }
 
 type Line struct {
@@ -72,10 +244,46 @@ type Line struct {
 	Content                  string         // The actual source code content of the line
 	CoveredBranches          int            // Number of branches on this line that were covered
 	TotalBranches            int            // Total number of branches on this line
-	LineCoverageByTestMethod map[string]int // Tracks hits for this line by TestMethod.ID
+	LineCoverageByTestMethod map[string]int // Hits of this line by test name, nil if the report has no per-test data
 	LineVisitStatus          LineVisitStatus
 }
 
+// NewLine returns a line without branches. Hits is the number of visits, or -1 if the
+// line is not coverable.
+func NewLine(number, hits int) Line {
+	line := Line{Number: number, Hits: hits}
+	line.LineVisitStatus = line.ComputeVisitStatus()
+	return line
+}
+
+// NewBranchLine returns a line with coveredBranches of totalBranches branches visited.
+func NewBranchLine(number, hits, coveredBranches, totalBranches int) Line {
+	line := Line{Number: number, Hits: hits, IsBranchPoint: true, CoveredBranches: coveredBranches, TotalBranches: totalBranches}
+	line.LineVisitStatus = line.ComputeVisitStatus()
+	return line
+}
+
+// ComputeVisitStatus derives the LineVisitStatus from the hits and branches of the line.
+func (l Line) ComputeVisitStatus() LineVisitStatus {
+	switch {
+	case l.Hits < 0:
+		return NotCoverable
+	case l.IsBranchPoint && l.TotalBranches > 0:
+		switch l.CoveredBranches {
+		case l.TotalBranches:
+			return Covered
+		case 0:
+			return NotCovered
+		}
+		return PartiallyCovered
+	case l.IsBranchPoint:
+		return NotCoverable
+	case l.Hits > 0:
+		return Covered
+	}
+	return NotCovered
+}
+
 type CodeElement struct {
 	Name          string
 	FullName      string // For uniqueness, e.g., with signature
@@ -83,6 +291,7 @@ type CodeElement struct {
 	FirstLine     int
 	LastLine      int
 	CoverageQuota *float64 // Nullable (percentage 0-100)
+	RawKey        string   // Raw Name+Signature of the method the element was created from, empty if unknown
 }
 
 // GetFirstLine implements utils.SortableByLineAndName for CodeElement
@@ -103,6 +312,11 @@ type Method struct {
 	FirstLine     int
 	LastLine      int
 	MethodMetrics []MethodMetric
+
+	// NestedClassName is the raw name of the class the report lists the method in if that is
+	// a type nested into the class, e.g. the state machine "Ns.Service/<LoadAsync>d__3" of
+	// an async method whose raw name is "MoveNext". Empty otherwise.
+	NestedClassName string
 }
 
 // GetFirstLine implements utils.SortableByLineAndName for Method
@@ -111,3 +325,36 @@ func (m Method) GetFirstLine() int { return m.FirstLine }
 // GetSortableName implements utils.SortableByLineAndName for Method
 // For Method, DisplayName is the cleaned full name, suitable for consistent sorting.
 func (m Method) GetSortableName() string { return m.DisplayName }
+
+// RawKey identifies the method by its raw name and signature, prefixed with its
+// NestedClassName if set. Unlike the cleaned DisplayName it stays distinct for overloads
+// that are displayed with the same name, and for the MoveNext methods of the state
+// machines of several async methods.
+func (m Method) RawKey() string {
+	if m.NestedClassName != "" {
+		return m.NestedClassName + "/" + m.Name + m.Signature
+	}
+	return m.Name + m.Signature
+}
+
+// FindCodeElement returns the code element created for a method. Elements
+// that carry the raw name and signature of their method are matched on it, because
+// overloads can be cleaned to the same display name and start on the same line (e.g.
+// expression-bodied members). Elements without a raw key, as created by other parsers,
+// are matched on first line and display name.
+func FindCodeElement(codeElements []CodeElement, method *Method) *CodeElement {
+	rawKey := method.RawKey()
+	for i := range codeElements {
+		ce := &codeElements[i]
+		if ce.RawKey != "" {
+			if ce.RawKey == rawKey {
+				return ce
+			}
+			continue
+		}
+		if ce.FirstLine == method.FirstLine && ce.FullName == method.DisplayName {
+			return ce
+		}
+	}
+	return nil
+}
@@ -2,11 +2,22 @@ package model
 
 // HistoricCoverage represents historical code coverage data.
 type HistoricCoverage struct {
-	ExecutionTime   int64
-	Tag             string
-	CoveredLines    int
-	CoverableLines  int
-	TotalLines      int
-	CoveredBranches int
-	TotalBranches   int
+	ExecutionTime       int64
+	Tag                 string
+	CoveredLines        int
+	CoverableLines      int
+	TotalLines          int
+	CoveredBranches     int
+	TotalBranches       int
+	CoveredMethods      int
+	FullyCoveredMethods int
+	TotalMethods        int
+}
+
+// CoverageAge describes for how many consecutive runs, up to the current one, the line
+// coverage of a class has been below a threshold.
+type CoverageAge struct {
+	Threshold float64 // Line coverage in percent
+	Runs      int     // Consecutive runs below the threshold, the current one included
+	Since     int64   // Execution time (Unix seconds) of the oldest of these runs
 }
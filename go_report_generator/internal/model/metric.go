@@ -1,5 +1,12 @@
 package model
 
+import (
+	"maps"
+	"math"
+	"sort"
+	"strings"
+)
+
 // MetricStatus represents the status of a metric.
 type MetricStatus int
 
@@ -12,6 +19,26 @@ const (
 	StatusError
 )
 
+// MetricThreshold holds the limits above which a metric value is flagged as a warning
+// or an error. A limit of 0 is disabled.
+type MetricThreshold struct {
+	Warning float64
+	Error   float64
+}
+
+// Evaluate returns StatusError if value exceeds the error limit, StatusWarning if it
+// exceeds the warning limit and StatusOk otherwise.
+func (t MetricThreshold) Evaluate(value float64) MetricStatus {
+	switch {
+	case t.Error > 0 && value > t.Error:
+		return StatusError
+	case t.Warning > 0 && value > t.Warning:
+		return StatusWarning
+	default:
+		return StatusOk
+	}
+}
+
 // Metric represents a single metric with a name, value, and status.
 type Metric struct {
 	Name   string
@@ -25,3 +52,188 @@ type MethodMetric struct {
 	Line    int      // The line number where the method is defined or this metric applies
 	Metrics []Metric // A slice of Metric structs associated with this method/entry
 }
+
+// CrapScore returns the CRAP score of a method with the given complexity whose code is
+// covered to the share coverage (0 to 1): complexity² · (1 - coverage)³ + complexity. An
+// invalid coverage counts as 0; an unknown or invalid complexity gives NaN.
+func CrapScore(coverage, complexity float64) float64 {
+	if math.IsNaN(coverage) || math.IsInf(coverage, 0) || coverage < 0 || coverage > 1 {
+		coverage = 0
+	}
+	if math.IsNaN(complexity) || math.IsInf(complexity, 0) || complexity < 0 {
+		return math.NaN()
+	}
+	uncoveredRatio := 1.0 - coverage
+	return (math.Pow(complexity, 2) * math.Pow(uncoveredRatio, 3)) + complexity
+}
+
+// MetricAggregation is how the method values of a metric are combined into the value of
+// their class.
+type MetricAggregation int
+
+const (
+	// AggregateSum adds the method values, e.g. for complexities.
+	AggregateSum MetricAggregation = iota
+	// AggregateMax takes the highest method value, e.g. the CrapScore of the riskiest method.
+	AggregateMax
+	// AggregateWeightedAverage averages percentages, weighted by the coverable lines of the
+	// methods.
+	AggregateWeightedAverage
+	// AggregateNone leaves the metric out of the class metrics.
+	AggregateNone
+)
+
+// MetricFormat is how the values of a metric are shown in the reports.
+type MetricFormat int
+
+const (
+	// FormatDecimal shows the value with the configured decimal places of coverage quotas.
+	FormatDecimal MetricFormat = iota
+	// FormatInteger shows the value without decimal places, e.g. for complexities.
+	FormatInteger
+	// FormatTwoDecimals shows the value with two decimal places, e.g. for the CrapScore.
+	FormatTwoDecimals
+	// FormatPercentage shows the value as a coverage percentage.
+	FormatPercentage
+)
+
+// MetricDefinition describes a method metric: how it is linked, ordered, aggregated into
+// its class and formatted.
+type MetricDefinition struct {
+	Name           string
+	Abbreviation   string // Short name of the metric in the risk hotspot tables, e.g. "crap"
+	ExplanationURL string // Page explaining the metric, empty for no link
+	Order          int    // Position among the metrics, ascending; 0 sorts after all others
+	Aggregation    MetricAggregation
+	Format         MetricFormat
+}
+
+// MetricRegistry holds the metric definitions, keyed by metric name.
+type MetricRegistry map[string]MetricDefinition
+
+// defaultMetrics are the metrics the parsers provide. Their order is the alphabetical one.
+var defaultMetrics = MetricRegistry{
+	"Branch coverage":       {Name: "Branch coverage", ExplanationURL: "https://en.wikipedia.org/wiki/Code_coverage", Order: 10, Aggregation: AggregateWeightedAverage, Format: FormatPercentage},
+	"CrapScore":             {Name: "CrapScore", Abbreviation: "crap", ExplanationURL: "https://testing.googleblog.com/2011/02/this-code-is-crap.html", Order: 20, Aggregation: AggregateMax, Format: FormatTwoDecimals},
+	"Cyclomatic complexity": {Name: "Cyclomatic complexity", Abbreviation: "cyclomatic", ExplanationURL: "https://www.ndepend.com/docs/code-metrics#CC", Order: 30, Aggregation: AggregateSum, Format: FormatInteger},
+	"Line coverage":         {Name: "Line coverage", ExplanationURL: "https://en.wikipedia.org/wiki/Code_coverage", Order: 40, Aggregation: AggregateWeightedAverage, Format: FormatPercentage},
+	"NPath complexity":      {Name: "NPath complexity", Abbreviation: "npath", ExplanationURL: "https://modess.io/npath-complexity-cyclomatic-complexity-explained/", Order: 50, Aggregation: AggregateSum, Format: FormatInteger},
+	"Nesting depth":         {Name: "Nesting depth", Order: 60, Aggregation: AggregateMax, Format: FormatInteger},
+}
+
+// DefaultMetricRegistry returns a copy of the definitions of the metrics the parsers provide.
+func DefaultMetricRegistry() MetricRegistry {
+	return maps.Clone(defaultMetrics)
+}
+
+// Lookup returns the definition of the named metric. Metrics that are not registered get
+// a definition without link and aggregation that sorts after the registered ones.
+func (r MetricRegistry) Lookup(name string) MetricDefinition {
+	if definition, ok := r[name]; ok {
+		return definition
+	}
+	return MetricDefinition{Name: name, Aggregation: AggregateNone}
+}
+
+// Find returns the definition whose name equals name case-insensitively.
+func (r MetricRegistry) Find(name string) (MetricDefinition, bool) {
+	if definition, ok := r[name]; ok {
+		return definition, true
+	}
+	for key, definition := range r {
+		if strings.EqualFold(key, name) {
+			return definition, true
+		}
+	}
+	return MetricDefinition{}, false
+}
+
+// SortNames sorts metric names by the order of their definitions, then by name.
+func (r MetricRegistry) SortNames(names []string) {
+	sort.SliceStable(names, func(i, j int) bool {
+		a, b := r.Lookup(names[i]).Order, r.Lookup(names[j]).Order
+		if a != b {
+			return b == 0 || (a != 0 && a < b)
+		}
+		return names[i] < names[j]
+	})
+}
+
+// AggregateMethodMetrics computes the class metrics from the method metrics according to
+// the aggregations of the registry. Methods without a finite value of a metric are left
+// out of it, and metrics without any value are not part of the result.
+func (r MetricRegistry) AggregateMethodMetrics(methods []Method) map[string]float64 {
+	type accumulator struct {
+		value, weight float64 // weight: sum of the coverable lines for AggregateWeightedAverage
+	}
+	accumulators := make(map[string]*accumulator)
+	for _, method := range methods {
+		weight := float64(method.coverableLines())
+		for _, methodMetric := range method.MethodMetrics {
+			for _, metric := range methodMetric.Metrics {
+				aggregation := r.Lookup(metric.Name).Aggregation
+				value, finite := metricFloat(metric.Value)
+				if aggregation == AggregateNone || !finite {
+					continue
+				}
+				acc, seen := accumulators[metric.Name]
+				if !seen {
+					acc = &accumulator{}
+					accumulators[metric.Name] = acc
+				}
+				switch aggregation {
+				case AggregateSum:
+					acc.value += value
+				case AggregateMax:
+					if !seen || value > acc.value {
+						acc.value = value
+					}
+				case AggregateWeightedAverage:
+					acc.value += value * weight
+					acc.weight += weight
+				}
+			}
+		}
+	}
+
+	metrics := make(map[string]float64, len(accumulators))
+	for name, acc := range accumulators {
+		metrics[name] = acc.value
+		if r.Lookup(name).Aggregation == AggregateWeightedAverage {
+			metrics[name] = acc.value / acc.weight
+		}
+	}
+	return metrics
+}
+
+// coverableLines returns the number of coverable lines of the method, which weighs its
+// coverage percentages. Methods without line data count with their line span, or 1.
+func (m Method) coverableLines() int {
+	if len(m.Lines) > 0 {
+		count := 0
+		for _, line := range m.Lines {
+			if line.Hits >= 0 {
+				count++
+			}
+		}
+		return max(count, 1)
+	}
+	if m.FirstLine > 0 && m.LastLine >= m.FirstLine {
+		return m.LastLine - m.FirstLine + 1
+	}
+	return 1
+}
+
+// metricFloat returns the numeric value of a metric and whether it is finite.
+func metricFloat(value interface{}) (float64, bool) {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case int:
+		f = float64(v)
+	default:
+		return 0, false
+	}
+	return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+}
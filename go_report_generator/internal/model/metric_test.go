@@ -0,0 +1,91 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func metricsMethod(name string, coverableLines int, metrics map[string]float64) model.Method {
+	method := model.Method{Name: name, FirstLine: 1}
+	for i := 0; i < coverableLines; i++ {
+		method.Lines = append(method.Lines, model.Line{Number: i + 1, Hits: i % 2})
+	}
+	for metricName, value := range metrics {
+		method.MethodMetrics = append(method.MethodMetrics, model.MethodMetric{
+			Name: name, Line: 1, Metrics: []model.Metric{{Name: metricName, Value: value}},
+		})
+	}
+	return method
+}
+
+func TestAggregateMethodMetrics(t *testing.T) {
+	methods := []model.Method{
+		metricsMethod("Short", 2, map[string]float64{
+			"Cyclomatic complexity": 3, "NPath complexity": 4, "CrapScore": 12.5, "Line coverage": 100, "Branch coverage": 50,
+		}),
+		metricsMethod("Long", 6, map[string]float64{
+			"Cyclomatic complexity": 5, "NPath complexity": 16, "CrapScore": 5, "Line coverage": 20, "Unknown metric": 7,
+		}),
+	}
+
+	metrics := model.DefaultMetricRegistry().AggregateMethodMetrics(methods)
+
+	assert.Equal(t, map[string]float64{
+		"Cyclomatic complexity": 8,  // Sum
+		"NPath complexity":      20, // Sum
+		"CrapScore":             12.5,
+		"Line coverage":         40, // (100*2 + 20*6) / 8
+		"Branch coverage":       50, // Only Short has branches
+	}, metrics)
+}
+
+func TestAggregateMethodMetrics_UsesTheAggregationsOfTheRegistry(t *testing.T) {
+	methods := []model.Method{
+		metricsMethod("Short", 2, map[string]float64{"Cyclomatic complexity": 3, "Mutation score": 60}),
+		metricsMethod("Long", 6, map[string]float64{"Cyclomatic complexity": 5, "Mutation score": 80}),
+	}
+	registry := model.DefaultMetricRegistry()
+	registry["Mutation score"] = model.MetricDefinition{Name: "Mutation score", Aggregation: model.AggregateWeightedAverage}
+	registry["Cyclomatic complexity"] = model.MetricDefinition{Name: "Cyclomatic complexity", Aggregation: model.AggregateMax}
+
+	assert.Equal(t, map[string]float64{"Cyclomatic complexity": 5, "Mutation score": 75}, registry.AggregateMethodMetrics(methods))
+}
+
+func TestAggregateMethodMetrics_SkipsValuesThatAreNotFinite(t *testing.T) {
+	methods := []model.Method{
+		metricsMethod("Unknown", 1, map[string]float64{"Cyclomatic complexity": math.NaN(), "CrapScore": math.Inf(1)}),
+		metricsMethod("Known", 1, map[string]float64{"Cyclomatic complexity": 2}),
+	}
+
+	assert.Equal(t, map[string]float64{"Cyclomatic complexity": 2}, model.DefaultMetricRegistry().AggregateMethodMetrics(methods))
+	assert.Empty(t, model.DefaultMetricRegistry().AggregateMethodMetrics(nil))
+}
+
+func TestAggregateMethodMetrics_WeighsMethodsWithoutLinesByTheirSpan(t *testing.T) {
+	first := metricsMethod("First", 0, map[string]float64{"Line coverage": 0})
+	first.FirstLine, first.LastLine = 10, 12
+	second := metricsMethod("Second", 0, map[string]float64{"Line coverage": 100})
+	second.FirstLine, second.LastLine = 20, 20
+
+	assert.Equal(t, 25.0, model.DefaultMetricRegistry().AggregateMethodMetrics([]model.Method{first, second})["Line coverage"])
+}
+
+func TestMetricRegistry_SortNames(t *testing.T) {
+	registry := model.DefaultMetricRegistry()
+	registry["Mutation score"] = model.MetricDefinition{Name: "Mutation score", Order: 15}
+	names := []string{"Unknown", "Line coverage", "Mutation score", "CrapScore", "Branch coverage", "Another"}
+
+	registry.SortNames(names)
+
+	assert.Equal(t, []string{"Branch coverage", "Mutation score", "CrapScore", "Line coverage", "Another", "Unknown"}, names,
+		"metrics without an order sort after the others by name")
+}
+
+func TestMetricRegistry_LookupUnknownMetric(t *testing.T) {
+	definition := model.DefaultMetricRegistry().Lookup("Mutation score")
+
+	assert.Equal(t, model.MetricDefinition{Name: "Mutation score", Aggregation: model.AggregateNone}, definition)
+}
@@ -65,7 +65,10 @@ type MethodXML struct {
 	LineRate   string   `xml:"line-rate,attr"`
 	BranchRate string   `xml:"branch-rate,attr"`
 	Complexity string   `xml:"complexity,attr"`
-	Lines      LinesXML `xml:"lines"` // Lines specific to this method
+	NPath      string   `xml:"npath,attr"`   // Optional extended metric (e.g. PHPUnit)
+	Crap       string   `xml:"crap,attr"`    // Optional extended metric (e.g. PHPUnit)
+	Nesting    string   `xml:"nesting,attr"` // Optional extended metric
+	Lines      LinesXML `xml:"lines"`        // Lines specific to this method
 }
 
 // <lines>
@@ -80,6 +83,7 @@ type LineXML struct {
 	Branch            string        `xml:"branch,attr"` // "true" or "false"
 	ConditionCoverage string        `xml:"condition-coverage,attr"`
 	Conditions        ConditionsXML `xml:"conditions"`
+	Tests             TestsXML      `xml:"tests"` // Optional per-test hits (extension, not part of the Cobertura DTD)
 }
 
 // <condition>
@@ -93,3 +97,16 @@ type ConditionXML struct {
 type ConditionsXML struct {
 	Condition []ConditionXML `xml:"condition"`
 }
+
+// <tests>, an extension listing the tests that hit a line:
+//
+//	<line number="10" hits="3"><tests><test name="CalcTests.Add" hits="2"/></tests></line>
+type TestsXML struct {
+	Test []TestXML `xml:"test"`
+}
+
+// <test>
+type TestXML struct {
+	Name string `xml:"name,attr"`
+	Hits string `xml:"hits,attr"`
+}
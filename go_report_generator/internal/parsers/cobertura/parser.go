@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/fs"
 	"log/slog"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -25,15 +26,15 @@ type CoberturaParser struct {
 type DefaultFileReader struct{}
 
 func (dfr *DefaultFileReader) ReadFile(path string) ([]string, error) {
-	return filereader.ReadLinesInFile(path)
+	return filereader.ReadLinesInFile(path, nil)
 }
 
 func (dfr *DefaultFileReader) CountLines(path string) (int, error) {
-	return filereader.CountLinesInFile(path)
+	return filereader.CountLinesInFile(path, nil)
 }
 
 func (dfr *DefaultFileReader) Stat(name string) (fs.FileInfo, error) {
-	return os.Stat(name)
+	return os.Stat(utils.LongPath(name))
 }
 
 func NewCoberturaParser(fileReader filereader.Reader) parsers.IParser {
@@ -46,11 +47,17 @@ func (cp *CoberturaParser) Name() string {
 	return "Cobertura"
 }
 
-func (cp *CoberturaParser) SupportsFile(filePath string) bool {
-	if !strings.HasSuffix(strings.ToLower(filePath), ".xml") {
+// DetectionHint describes the files SupportsFile accepts.
+func (cp *CoberturaParser) DetectionHint() string {
+	return "*.xml or *.xml.gz with a <coverage> root element"
+}
+
+func (cp *CoberturaParser) SupportsFile(filePath string, config parsers.ParserConfig) bool {
+	lowerPath := strings.ToLower(filePath)
+	if !strings.HasSuffix(lowerPath, ".xml") && !strings.HasSuffix(lowerPath, ".xml.gz") {
 		return false
 	}
-	f, err := os.Open(filePath)
+	f, err := filereader.OpenReport(filePath, parsers.FileRetryPolicy(config))
 	if err != nil {
 		return false
 	}
@@ -71,64 +78,89 @@ func (cp *CoberturaParser) SupportsFile(filePath string) bool {
 	return false
 }
 
-// Parse is the main entry point for the Cobertura parsers. It unmarshals the XML
-// and delegates the complex processing logic to the processingOrchestrator, which
-// handles per-file language detection and formatting.
+// Parse is the main entry point for the Cobertura parsers. The XML is streamed one
+// <package> element at a time and each package is handed to the processingOrchestrator,
+// which handles per-file language detection and formatting. Peak memory is therefore
+// bounded by the largest package rather than by the whole report.
 func (cp *CoberturaParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
 	logger := config.Logger().With(slog.String("parser", cp.Name()), slog.String("file", filePath))
 
-	rawReport, sourceDirsFromXML, err := cp.loadAndUnmarshalCoberturaXML(filePath)
+	// The branch rates of the methods depend on whether the report has branch data at all,
+	// so this is known before the first method is processed.
+	retry := parsers.FileRetryPolicy(config)
+	hasBranchPoints, err := scanForBranchPoints(filePath, retry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load/unmarshal Cobertura XML from %s: %w", filePath, err)
 	}
 
-	effectiveSourceDirs := cp.getEffectiveSourceDirs(config, sourceDirsFromXML)
-
-	// The orchestrator is now simpler and does not take a pre-determined formatter.
-	// It will determine the formatter for each file internally.
-	orchestrator := newProcessingOrchestrator(cp.fileReader, config, effectiveSourceDirs, logger)
-
-	assemblies, detectedBranchSupport, err := orchestrator.processPackages(rawReport.Packages.Package)
+	// The orchestrator is created with the first package, because it needs the
+	// <sources> that precede the <packages> in the document.
+	var orchestrator *processingOrchestrator
+	header, err := cp.streamCoberturaXML(filePath, retry, func(pkgXML PackageXML, sourceDirsFromXML []string) {
+		if orchestrator == nil {
+			effectiveSourceDirs := cp.getEffectiveSourceDirs(config, sourceDirsFromXML)
+			orchestrator = newProcessingOrchestrator(cp.fileReader, config, effectiveSourceDirs, logger)
+			orchestrator.detectedBranchCoverage = hasBranchPoints
+		}
+		orchestrator.addPackage(pkgXML)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to process Cobertura packages: %w", err)
+		return nil, fmt.Errorf("failed to load/unmarshal Cobertura XML from %s: %w", filePath, err)
+	}
+	if orchestrator == nil {
+		orchestrator = newProcessingOrchestrator(cp.fileReader, config, cp.getEffectiveSourceDirs(config, header.sources), logger)
+	}
+
+	timestamp := cp.getReportTimestamp(header.timestamp, logger)
+	excluded := orchestrator.excludedGeneratedFiles()
+	if excluded > 0 {
+		logger.Info("Excluded generated code files", "count", excluded)
 	}
+	parsers.LogFilteredFiles(logger, orchestrator.filteredFiles)
+	parsers.LogExternalFiles(logger, orchestrator.externalFiles)
+	orchestrator.logSourceResolution()
 
-	timestamp := cp.getReportTimestamp(rawReport.Timestamp, logger)
+	result := &parsers.ParserResult{
+		Assemblies:              orchestrator.assemblies,
+		SourceDirectories:       header.sources,
+		SupportsBranchCoverage:  orchestrator.detectedBranchCoverage,
+		MethodCoverageAvailable: orchestrator.detectedMethods,
+		ParserName:              cp.Name(),
+		MinimumTimeStamp:        timestamp,
+		MaximumTimeStamp:        timestamp,
+		MissingSourceFiles:      orchestrator.missingSourceFiles,
+		DeclaredTotals:          cp.getDeclaredTotals(header, logger),
+	}
 
-	return &parsers.ParserResult{
-		Assemblies:             assemblies,
-		SourceDirectories:      sourceDirsFromXML,
-		SupportsBranchCoverage: detectedBranchSupport,
-		ParserName:             cp.Name(),
-		MinimumTimeStamp:       timestamp,
-		MaximumTimeStamp:       timestamp,
-	}, nil
+	// Filtered or excluded classes are part of the declared totals, so they can only be
+	// compared with the parsed data when the whole report was kept.
+	if excluded > 0 || config.AssemblyFilters().HasCustomFilters() || config.ClassFilters().HasCustomFilters() || config.FileFilters().HasCustomFilters() {
+		logger.Debug("Skipping the check of the declared totals, as parts of the report were filtered")
+	} else {
+		parsers.CheckDeclaredTotals(result, config.Settings().DeclaredTotalsTolerance, logger)
+	}
+	return result, nil
 }
 
 // ------ Helper Functions ------
 
-// getEffectiveSourceDirs combines source directories from the configuration (CLI)
-// and from the XML file's <sources> tag to create a comprehensive list of search paths.
+// getEffectiveSourceDirs returns the roots source files are looked up in, in the order
+// they are probed: the <source> roots of the report in document order, then the extra
+// roots of the SourceRootHints setting, then the source directories of the configuration
+// (CLI). The file of the first root that has it wins. Duplicates are dropped.
 func (cp *CoberturaParser) getEffectiveSourceDirs(config parsers.ParserConfig, sourceDirsFromXML []string) []string {
-	sourceDirsSet := make(map[string]struct{})
-
-	for _, dir := range config.SourceDirectories() {
-		if dir != "" {
-			sourceDirsSet[dir] = struct{}{}
-		}
-	}
-
-	for _, dir := range sourceDirsFromXML {
-		if dir != "" {
-			sourceDirsSet[dir] = struct{}{}
-		}
-	}
-
 	var effectiveSourceDirs []string
-	for dir := range sourceDirsSet {
-		effectiveSourceDirs = append(effectiveSourceDirs, dir)
+	seen := make(map[string]struct{})
+	for _, dirs := range [][]string{sourceDirsFromXML, config.Settings().SourceRootHints, config.SourceDirectories()} {
+		for _, dir := range dirs {
+			dir = strings.TrimSpace(dir)
+			if _, ok := seen[dir]; ok || dir == "" {
+				continue
+			}
+			seen[dir] = struct{}{}
+			effectiveSourceDirs = append(effectiveSourceDirs, dir)
+		}
 	}
-
 	return effectiveSourceDirs
 }
 
@@ -157,22 +189,145 @@ func (cp *CoberturaParser) getReportTimestamp(rawTimestamp string, logger *slog.
 	return nil
 }
 
-// loadAndUnmarshalCoberturaXML reads and unmarshals the Cobertura XML file.
-func (cp *CoberturaParser) loadAndUnmarshalCoberturaXML(path string) (*CoberturaRoot, []string, error) {
-	f, err := os.Open(path)
+// getDeclaredTotals parses the totals of the <coverage> root element. Attributes that are
+// missing or malformed are left nil; it returns nil if the root declares no totals at all.
+func (cp *CoberturaParser) getDeclaredTotals(header *coberturaHeader, logger *slog.Logger) *parsers.DeclaredTotals {
+	parseInt := func(name string) *int {
+		raw, ok := header.attributes[name]
+		if !ok || raw == "" {
+			return nil
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			logger.Warn("Failed to parse Cobertura root attribute", "attribute", name, "value", raw, "error", err)
+			return nil
+		}
+		return &value
+	}
+	parseRate := func(name string) *float64 {
+		raw, ok := header.attributes[name]
+		if !ok || raw == "" {
+			return nil
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(value) {
+			logger.Warn("Failed to parse Cobertura root attribute", "attribute", name, "value", raw, "error", err)
+			return nil
+		}
+		return &value
+	}
+
+	totals := &parsers.DeclaredTotals{
+		LinesCovered:    parseInt("lines-covered"),
+		LinesValid:      parseInt("lines-valid"),
+		BranchesCovered: parseInt("branches-covered"),
+		BranchesValid:   parseInt("branches-valid"),
+		LineRate:        parseRate("line-rate"),
+		BranchRate:      parseRate("branch-rate"),
+	}
+	if *totals == (parsers.DeclaredTotals{}) {
+		return nil
+	}
+	return totals
+}
+
+// coberturaHeader holds the report-level data found outside of the <package> elements.
+type coberturaHeader struct {
+	timestamp string
+	sources   []string
+	// attributes are all attributes of the <coverage> root element by name.
+	attributes map[string]string
+}
+
+// scanForBranchPoints reports whether any <line> of the report is a branch point. It only
+// tokenizes the XML and stops at the first branch point, which is usually found early in
+// reports with branch data. The file is opened with the retry policy.
+func scanForBranchPoints(path string, retry utils.FileRetryPolicy) (bool, error) {
+	f, err := filereader.OpenReport(path, retry)
 	if err != nil {
-		return nil, nil, fmt.Errorf("open file: %w", err)
+		return false, fmt.Errorf("open file: %w", err)
 	}
 	defer f.Close()
 
-	bytes, err := io.ReadAll(f)
+	decoder := xml.NewDecoder(f)
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("unmarshal xml: %w", err)
+		}
+		if se, ok := token.(xml.StartElement); ok && se.Name.Local == "line" {
+			for _, attr := range se.Attr {
+				if attr.Name.Local == "branch" && strings.EqualFold(attr.Value, "true") {
+					return true, nil
+				}
+			}
+		}
+	}
+}
+
+// streamCoberturaXML decodes the Cobertura XML file token by token. Every <package>
+// element is decoded on its own and passed to handlePackage together with the
+// <source> directories seen so far; the package is released once the handler returns.
+// The file is opened with the retry policy.
+func (cp *CoberturaParser) streamCoberturaXML(path string, retry utils.FileRetryPolicy, handlePackage func(pkgXML PackageXML, sourceDirsFromXML []string)) (*coberturaHeader, error) {
+	f, err := filereader.OpenReport(path, retry)
 	if err != nil {
-		return nil, nil, fmt.Errorf("read file: %w", err)
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	header := &coberturaHeader{attributes: make(map[string]string)}
+	decoder := xml.NewDecoder(f)
+	rootSeen := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal xml: %w", err)
+		}
+
+		se, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if !rootSeen {
+			if se.Name.Local != "coverage" {
+				return nil, fmt.Errorf("unmarshal xml: expected element type <coverage> but have <%s>", se.Name.Local)
+			}
+			rootSeen = true
+			for _, attr := range se.Attr {
+				header.attributes[attr.Name.Local] = attr.Value
+				if attr.Name.Local == "timestamp" {
+					header.timestamp = attr.Value
+				}
+			}
+			continue
+		}
+
+		switch se.Name.Local {
+		case "sources":
+			var sources Sources
+			if err := decoder.DecodeElement(&sources, &se); err != nil {
+				return nil, fmt.Errorf("unmarshal xml: %w", err)
+			}
+			header.sources = append(header.sources, sources.Source...)
+		case "package":
+			var pkgXML PackageXML
+			if err := decoder.DecodeElement(&pkgXML, &se); err != nil {
+				return nil, fmt.Errorf("unmarshal xml: %w", err)
+			}
+			handlePackage(pkgXML, header.sources)
+		}
 	}
 
-	var rawReport CoberturaRoot
-	if err := xml.Unmarshal(bytes, &rawReport); err != nil {
-		return nil, nil, fmt.Errorf("unmarshal xml: %w", err)
+	if !rootSeen {
+		return nil, fmt.Errorf("unmarshal xml: no <coverage> element found")
 	}
-	return &rawReport, rawReport.Sources.Source, nil
+	return header, nil
 }
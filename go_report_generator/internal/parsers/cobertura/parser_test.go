@@ -0,0 +1,779 @@
+package cobertura
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const minimalCoberturaXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="1" branch-rate="1" timestamp="1700000000" version="1.9">
+  <sources><source>/project/src</source></sources>
+  <packages>
+    <package name="MyAssembly" line-rate="1" branch-rate="1">
+      <classes>
+        <class name="MyAssembly.Calc" filename="Calc.cs" line-rate="1" branch-rate="1">
+          <methods />
+          <lines><line number="3" hits="2" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+func writeGzipFile(t *testing.T, path, content string) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+}
+
+func TestCoberturaParser_SupportsFile_Gzipped(t *testing.T) {
+	dir := t.TempDir()
+	gzPath := filepath.Join(dir, "coverage.xml.gz")
+	writeGzipFile(t, gzPath, minimalCoberturaXML)
+
+	otherRootPath := filepath.Join(dir, "other.xml.gz")
+	writeGzipFile(t, otherRootPath, `<?xml version="1.0"?><report />`)
+
+	p := NewCoberturaParser(&DefaultFileReader{})
+	config := newTestConfig(settings.NewSettings())
+
+	assert.True(t, p.SupportsFile(gzPath, config), "root element must be detected on the decompressed stream")
+	assert.False(t, p.SupportsFile(otherRootPath, config))
+}
+
+func TestCoberturaParser_LoadGzippedReport(t *testing.T) {
+	gzPath := filepath.Join(t.TempDir(), "coverage.xml.gz")
+	writeGzipFile(t, gzPath, minimalCoberturaXML)
+
+	cp := &CoberturaParser{fileReader: &DefaultFileReader{}}
+	var packageNames []string
+	header, err := cp.streamCoberturaXML(gzPath, utils.FileRetryPolicy{}, func(pkgXML PackageXML, _ []string) {
+		packageNames = append(packageNames, pkgXML.Name)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/project/src"}, header.sources)
+	assert.Equal(t, "1700000000", header.timestamp)
+	assert.Equal(t, []string{"MyAssembly"}, packageNames)
+}
+
+func TestCoberturaParser_StreamRejectsOtherRootElements(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	require.NoError(t, os.WriteFile(path, []byte(`<?xml version="1.0"?><report><package name="x" /></report>`), 0o644))
+
+	cp := &CoberturaParser{fileReader: &DefaultFileReader{}}
+	_, err := cp.streamCoberturaXML(path, utils.FileRetryPolicy{}, func(PackageXML, []string) {
+		t.Fatalf("no package must be handled for a non-Cobertura document")
+	})
+
+	assert.Error(t, err)
+}
+
+// writeSyntheticCoberturaReport writes a report with the given number of packages,
+// classes per package and lines per class, mimicking large merged gcov output.
+func writeSyntheticCoberturaReport(tb testing.TB, path string, packages, classesPerPackage, linesPerClass int) {
+	tb.Helper()
+	f, err := os.Create(path)
+	require.NoError(tb, err)
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	fmt.Fprintln(w, `<?xml version="1.0" encoding="utf-8"?>`)
+	fmt.Fprintln(w, `<coverage line-rate="0.5" branch-rate="0.5" timestamp="1700000000" version="1.9">`)
+	fmt.Fprintln(w, `  <sources><source>/nonexistent/src</source></sources>`)
+	fmt.Fprintln(w, `  <packages>`)
+	for p := 0; p < packages; p++ {
+		fmt.Fprintf(w, "    <package name=\"pkg%d\" line-rate=\"0.5\" branch-rate=\"0.5\">\n      <classes>\n", p)
+		for c := 0; c < classesPerPackage; c++ {
+			fmt.Fprintf(w, "        <class name=\"pkg%d.Class%d\" filename=\"pkg%d/class%d.cpp\" line-rate=\"0.5\" branch-rate=\"0.5\">\n          <methods />\n          <lines>\n", p, c, p, c)
+			for l := 1; l <= linesPerClass; l++ {
+				if l%10 == 0 {
+					fmt.Fprintf(w, "            <line number=\"%d\" hits=\"%d\" branch=\"true\" condition-coverage=\"50%% (1/2)\" />\n", l, l%3)
+				} else {
+					fmt.Fprintf(w, "            <line number=\"%d\" hits=\"%d\" branch=\"false\" />\n", l, l%3)
+				}
+			}
+			fmt.Fprintln(w, "          </lines>\n        </class>")
+		}
+		fmt.Fprintln(w, "      </classes>\n    </package>")
+	}
+	fmt.Fprintln(w, "  </packages>\n</coverage>")
+	require.NoError(tb, w.Flush())
+}
+
+func heapInUseMB() float64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return float64(ms.HeapInuse) / (1 << 20)
+}
+
+// BenchmarkCoberturaDecode compares the peak heap of decoding a synthetic ~60 MB report
+// as a whole document (the previous implementation) with the streaming decoder.
+//
+// On a typical development machine the results were:
+//
+//	BenchmarkCoberturaDecode/WholeDocument  peak-heap-MB ~ 356
+//	BenchmarkCoberturaDecode/Streaming      peak-heap-MB ~ 7
+//
+// i.e. the streaming decoder keeps only one package in memory, so peak memory no
+// longer grows with the size of the report.
+func BenchmarkCoberturaDecode(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "large.xml")
+	writeSyntheticCoberturaReport(b, path, 200, 50, 100)
+
+	b.Run("WholeDocument", func(b *testing.B) {
+		b.ReportAllocs()
+		peak := 0.0
+		for i := 0; i < b.N; i++ {
+			runtime.GC()
+			data, err := os.ReadFile(path)
+			require.NoError(b, err)
+			var root CoberturaRoot
+			require.NoError(b, xml.Unmarshal(data, &root))
+			peak = math.Max(peak, heapInUseMB())
+			runtime.KeepAlive(data)
+			runtime.KeepAlive(&root)
+		}
+		b.ReportMetric(peak, "peak-heap-MB")
+	})
+
+	b.Run("Streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		cp := &CoberturaParser{fileReader: &DefaultFileReader{}}
+		peak := 0.0
+		for i := 0; i < b.N; i++ {
+			runtime.GC()
+			_, err := cp.streamCoberturaXML(path, utils.FileRetryPolicy{}, func(pkgXML PackageXML, _ []string) {
+				peak = math.Max(peak, heapInUseMB())
+				runtime.KeepAlive(&pkgXML)
+			})
+			require.NoError(b, err)
+		}
+		b.ReportMetric(peak, "peak-heap-MB")
+	})
+}
+
+// BenchmarkCoberturaParser_Parse measures the full parse of a large synthetic report,
+// including the processing of every package by the orchestrator.
+func BenchmarkCoberturaParser_Parse(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "large.xml")
+	writeSyntheticCoberturaReport(b, path, 50, 20, 100)
+	config := newTestConfig(settings.NewSettings())
+	p := NewCoberturaParser(&DefaultFileReader{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := p.Parse(path, config)
+		require.NoError(b, err)
+	}
+}
+
+// inconsistentTotalsXML declares 10 of 12 covered lines and 3 of 4 branches on the root,
+// but its line data has 1 of 2 covered lines and 1 of 2 branches.
+const inconsistentTotalsXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.8333" branch-rate="0.75" lines-covered="10" lines-valid="12" branches-covered="3" branches-valid="4" timestamp="1700000000" version="1.9">
+  <packages>
+    <package name="MyAssembly" line-rate="0.5" branch-rate="0.5">
+      <classes>
+        <class name="MyAssembly.Calc" filename="Calc.cs" line-rate="0.5" branch-rate="0.5">
+          <methods />
+          <lines>
+            <line number="3" hits="2" branch="false" />
+            <line number="4" hits="0" branch="true" condition-coverage="50% (1/2)" />
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+// parseWithLogs parses the report content and returns the result and the logged warnings.
+func parseWithLogs(t *testing.T, content string, appSettings *settings.Settings) (*parsers.ParserResult, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "coverage.xml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	var logs bytes.Buffer
+	config := newTestConfig(appSettings)
+	config.logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	result, err := NewCoberturaParser(&DefaultFileReader{}).Parse(path, config)
+	require.NoError(t, err)
+	return result, logs.String()
+}
+
+func TestCoberturaParser_ReadsDeclaredTotals(t *testing.T) {
+	result, _ := parseWithLogs(t, inconsistentTotalsXML, settings.NewSettings())
+
+	require.NotNil(t, result.DeclaredTotals)
+	totals := result.DeclaredTotals
+	require.NotNil(t, totals.LinesCovered)
+	require.NotNil(t, totals.LinesValid)
+	require.NotNil(t, totals.BranchesCovered)
+	require.NotNil(t, totals.BranchesValid)
+	require.NotNil(t, totals.LineRate)
+	assert.Equal(t, 10, *totals.LinesCovered)
+	assert.Equal(t, 12, *totals.LinesValid)
+	assert.Equal(t, 3, *totals.BranchesCovered)
+	assert.Equal(t, 4, *totals.BranchesValid)
+	assert.InDelta(t, 0.8333, *totals.LineRate, 1e-9)
+
+	require.NotNil(t, result.MinimumTimeStamp)
+	assert.Equal(t, int64(1700000000), result.MinimumTimeStamp.Unix())
+}
+
+func TestCoberturaParser_WarnsAboutInconsistentDeclaredTotals(t *testing.T) {
+	_, logs := parseWithLogs(t, inconsistentTotalsXML, settings.NewSettings())
+
+	for _, want := range []string{
+		"total=lines-covered declared=10 computed=1",
+		"total=lines-valid declared=12 computed=2",
+		"total=branches-covered declared=3 computed=1",
+		"total=branches-valid declared=4 computed=2",
+	} {
+		assert.Contains(t, logs, want)
+	}
+	assert.NotContains(t, logs, "total=line-rate", "rates are only checked without counts")
+}
+
+func TestCoberturaParser_DeclaredTotalsCheck(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rootAttrs    string
+		tolerance    float64
+		wantWarnings int
+	}{
+		{name: "ConsistentCounts", rootAttrs: `lines-covered="1" lines-valid="2" branches-covered="1" branches-valid="2"`, tolerance: 0.01},
+		{name: "RateWithinTolerance", rootAttrs: `line-rate="0.505" branch-rate="0.5"`, tolerance: 0.01},
+		{name: "RateOutsideTolerance", rootAttrs: `line-rate="0.9"`, tolerance: 0.01, wantWarnings: 1},
+		{name: "CountWithinTolerance", rootAttrs: `lines-covered="1" lines-valid="3"`, tolerance: 0.5},
+		{name: "CheckDisabled", rootAttrs: `lines-covered="10" lines-valid="12"`, tolerance: -1},
+		{name: "NoDeclaredTotals", rootAttrs: ``, tolerance: 0.01},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := strings.Replace(inconsistentTotalsXML,
+				`line-rate="0.8333" branch-rate="0.75" lines-covered="10" lines-valid="12" branches-covered="3" branches-valid="4"`,
+				tc.rootAttrs, 1)
+			appSettings := settings.NewSettings()
+			appSettings.DeclaredTotalsTolerance = tc.tolerance
+
+			_, logs := parseWithLogs(t, content, appSettings)
+
+			assert.Equal(t, tc.wantWarnings, strings.Count(logs, "Declared report total differs"), logs)
+		})
+	}
+}
+
+// branchesAfterFirstMethodXML has a method without branches in its first package and the
+// first branch point of the report in its second package.
+const branchesAfterFirstMethodXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.75" branch-rate="0.5" version="1.9">
+  <packages>
+    <package name="First">
+      <classes>
+        <class name="First.Plain" filename="Plain.cs">
+          <methods>
+            <method name="Run" signature="()" complexity="1">
+              <lines><line number="3" hits="1" branch="false" /></lines>
+            </method>
+          </methods>
+          <lines><line number="3" hits="1" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+    <package name="Second">
+      <classes>
+        <class name="Second.Branchy" filename="Branchy.cs">
+          <methods>
+            <method name="Decide" signature="(System.Boolean)" complexity="2">
+              <lines><line number="5" hits="1" branch="true" condition-coverage="50% (1/2)" /></lines>
+            </method>
+          </methods>
+          <lines><line number="5" hits="1" branch="true" condition-coverage="50% (1/2)" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+func TestCoberturaParser_BranchRatesOfMethodsBeforeTheFirstBranchPoint(t *testing.T) {
+	result, _ := parseWithLogs(t, branchesAfterFirstMethodXML, settings.NewSettings())
+
+	require.True(t, result.SupportsBranchCoverage)
+	branchRates := make(map[string]float64)
+	for _, assembly := range result.Assemblies {
+		for _, class := range assembly.Classes {
+			for _, method := range class.Methods {
+				require.NotNil(t, method.BranchRate, "method %s has no branch rate", method.DisplayName)
+				branchRates[method.DisplayName] = *method.BranchRate
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"Run()": 1.0, "Decide(System.Boolean)": 0.5}, branchRates)
+}
+
+// withoutMethodsXML lists no <methods> and references a source file that does not exist,
+// so no methods can be detected either.
+const withoutMethodsXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="1" branch-rate="0" version="1.9">
+  <packages>
+    <package name="Shop">
+      <classes>
+        <class name="Shop.Cart" filename="Missing/Cart.cs">
+          <methods />
+          <lines><line number="3" hits="1" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+func TestCoberturaParser_MethodCoverageAvailable(t *testing.T) {
+	withMethods, _ := parseWithLogs(t, branchesAfterFirstMethodXML, settings.NewSettings())
+	assert.True(t, withMethods.MethodCoverageAvailable)
+
+	withoutMethods, _ := parseWithLogs(t, withoutMethodsXML, settings.NewSettings())
+	require.Len(t, withoutMethods.Assemblies, 1)
+	assert.False(t, withoutMethods.MethodCoverageAvailable)
+}
+
+func TestScanForBranchPoints(t *testing.T) {
+	dir := t.TempDir()
+	withBranches := filepath.Join(dir, "branches.xml")
+	withoutBranches := filepath.Join(dir, "lines.xml")
+	require.NoError(t, os.WriteFile(withBranches, []byte(branchesAfterFirstMethodXML), 0o644))
+	require.NoError(t, os.WriteFile(withoutBranches, []byte(strings.ReplaceAll(branchesAfterFirstMethodXML, `branch="true"`, `branch="false"`)), 0o644))
+
+	found, err := scanForBranchPoints(withBranches, utils.FileRetryPolicy{})
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = scanForBranchPoints(withoutBranches, utils.FileRetryPolicy{})
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+// scoverageComplexityXML declares the complexity like scoverage: on the packages and
+// classes, while the methods leave it empty.
+const scoverageComplexityXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.5" branch-rate="0" complexity="7" version="1.0">
+  <packages>
+    <package name="shop" line-rate="0.5" branch-rate="0" complexity="7">
+      <classes>
+        <class name="shop.Cart" filename="shop/Cart.scala" line-rate="0.5" branch-rate="0" complexity="4">
+          <methods>
+            <method name="add" signature="(I)V" line-rate="0" branch-rate="0" complexity="">
+              <lines><line number="3" hits="0" branch="false" /></lines>
+            </method>
+            <method name="total" signature="()I" line-rate="1" branch-rate="0">
+              <lines><line number="6" hits="2" branch="false" /></lines>
+            </method>
+          </methods>
+          <lines>
+            <line number="3" hits="0" branch="false" />
+            <line number="6" hits="2" branch="false" />
+          </lines>
+        </class>
+      </classes>
+    </package>
+    <package name="plain" line-rate="0" branch-rate="0">
+      <classes>
+        <class name="plain.Util" filename="plain/Util.scala" line-rate="0" branch-rate="0">
+          <methods>
+            <method name="run" signature="()V" line-rate="0" branch-rate="0">
+              <lines><line number="2" hits="0" branch="false" /></lines>
+            </method>
+          </methods>
+          <lines><line number="2" hits="0" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+func TestCoberturaParser_ClassAndPackageComplexity(t *testing.T) {
+	result, _ := parseWithLogs(t, scoverageComplexityXML, settings.NewSettings())
+
+	require.Len(t, result.Assemblies, 2)
+	shop := result.Assemblies[0]
+	require.NotNil(t, shop.Complexity)
+	assert.Equal(t, 7.0, *shop.Complexity)
+	require.Len(t, shop.Classes, 1)
+	cart := shop.Classes[0]
+	require.NotNil(t, cart.Complexity)
+	assert.Equal(t, 4.0, *cart.Complexity)
+	assert.Equal(t, 4.0, cart.Metrics["Cyclomatic complexity"], "the class value replaces the sum of unknown method complexities")
+
+	require.Len(t, cart.Methods, 2)
+	for _, method := range cart.Methods {
+		assert.True(t, math.IsNaN(method.Complexity), method.Name)
+		for _, mm := range method.MethodMetrics {
+			for _, m := range mm.Metrics {
+				assert.NotContains(t, []string{"Cyclomatic complexity", "CrapScore"}, m.Name, method.Name)
+			}
+		}
+	}
+
+	plain := result.Assemblies[1]
+	assert.Nil(t, plain.Complexity)
+	require.Len(t, plain.Classes, 1)
+	assert.Nil(t, plain.Classes[0].Complexity)
+	require.Len(t, plain.Classes[0].Methods, 1)
+	assert.Equal(t, 0.0, plain.Classes[0].Methods[0].Complexity, "without a declared complexity a missing one is still 0")
+}
+
+func TestDeclaredComplexity(t *testing.T) {
+	floatPtr := func(v float64) *float64 { return &v }
+	testCases := []struct {
+		name   string
+		values []string
+		want   *float64
+	}{
+		{name: "Empty", values: []string{""}},
+		{name: "NotANumber", values: []string{"NaN"}},
+		{name: "Negative", values: []string{"-1"}},
+		{name: "Single", values: []string{"3"}, want: floatPtr(3)},
+		{name: "SumOfDeclared", values: []string{"2", "", "1.5"}, want: floatPtr(3.5)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, declaredComplexity(tc.values...))
+		})
+	}
+}
+
+// writeSourceRoot creates a root directory with the given files relative to it.
+func writeSourceRoot(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return root
+}
+
+// multiRootXML is a report with two <source> roots and the classes of two packages.
+const multiRootXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="1" branch-rate="1" version="1.9">
+  <sources>
+    <source>%s</source>
+    <source>%s</source>
+  </sources>
+  <packages>
+    <package name="lib" line-rate="1" branch-rate="1">
+      <classes>
+        <class name="lib.Util" filename="lib/Util.cs" line-rate="1" branch-rate="1">
+          <methods />
+          <lines><line number="1" hits="1" branch="false" /></lines>
+        </class>
+        <class name="lib.Only" filename="lib/Only.cs" line-rate="1" branch-rate="1">
+          <methods />
+          <lines><line number="1" hits="1" branch="false" /></lines>
+        </class>
+        <class name="lib.Gone" filename="lib/Gone.cs" line-rate="1" branch-rate="1">
+          <methods />
+          <lines><line number="1" hits="1" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+    <package name="src/net" line-rate="1" branch-rate="1">
+      <classes>
+        <class name="net.Http" filename="Http.cs" line-rate="1" branch-rate="1">
+          <methods />
+          <lines><line number="1" hits="1" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+func TestCoberturaParser_MultipleSourceRoots(t *testing.T) {
+	first := writeSourceRoot(t, map[string]string{"lib/Util.cs": "class Util {} // first"})
+	second := writeSourceRoot(t, map[string]string{
+		"lib/Util.cs":     "class Util {} // second",
+		"lib/Only.cs":     "class Only {}",
+		"src/net/Http.cs": "class Http {}",
+	})
+	path := filepath.Join(t.TempDir(), "coverage.xml")
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(multiRootXML, first, second)), 0o644))
+
+	var logs bytes.Buffer
+	config := newTestConfig(settings.NewSettings())
+	config.logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+	result, err := NewCoberturaParser(&DefaultFileReader{}).Parse(path, config)
+	require.NoError(t, err)
+
+	files := make(map[string]model.CodeFile)
+	for _, assembly := range result.Assemblies {
+		for _, class := range assembly.Classes {
+			for _, file := range class.Files {
+				files[class.Name] = file
+			}
+		}
+	}
+	util := files["lib.Util"]
+	assert.Equal(t, filepath.Join(first, "lib", "Util.cs"), util.Path, "the first root of the report wins")
+	require.NotEmpty(t, util.Lines)
+	assert.Equal(t, "class Util {} // first", util.Lines[0].Content)
+	assert.Equal(t, filepath.Join(second, "lib", "Only.cs"), files["lib.Only"].Path)
+	assert.Equal(t, filepath.Join(second, "src", "net", "Http.cs"), files["net.Http"].Path, "the file is found below the path of its package")
+	require.Len(t, result.MissingSourceFiles, 1)
+	assert.Equal(t, "lib/Gone.cs", result.MissingSourceFiles[0].Path)
+
+	assert.Contains(t, logs.String(), fmt.Sprintf(`msg="Resolved source files" parser=Cobertura file=%s roots="%s (1), %s (2)" unresolved=1`, path, first, second))
+}
+
+func TestCoberturaParser_SourceRootOrder(t *testing.T) {
+	config := newTestConfig(settings.NewSettings())
+	config.settings.SourceRootHints = []string{"/hint", "/report/b"}
+	config.sourceDirs = []string{"/cli", "", "/hint"}
+
+	dirs := (&CoberturaParser{}).getEffectiveSourceDirs(config, []string{" /report/b ", "/report/a", "/report/b"})
+
+	assert.Equal(t, []string{"/report/b", "/report/a", "/hint", "/cli"}, dirs, "report roots in document order, then the hints, then the source directories")
+}
+
+// TestCoberturaParser_FSharpFragmentsShareLines parses an F# export whose class is split
+// into fragments listing the same lines. The methods count the merged lines of the file.
+func TestCoberturaParser_FSharpFragmentsShareLines(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "fsharp.cobertura.xml"))
+	require.NoError(t, err)
+	result, _ := parseWithLogs(t, string(content), settings.NewSettings())
+
+	require.Len(t, result.Assemblies, 1)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+	class := result.Assemblies[0].Classes[0]
+	require.Len(t, class.Files, 1)
+	assert.Equal(t, 5, class.Files[0].CoveredLines)
+	assert.Equal(t, 6, class.Files[0].CoverableLines)
+
+	lineRates := make(map[string]float64)
+	coverableLines := make(map[string]int)
+	for _, method := range class.Methods {
+		lineRates[method.DisplayName] = method.LineRate
+		_, coverableLines[method.DisplayName] = model.CountLines(method.Lines)
+	}
+	assert.InDeltaMapValues(t, map[string]float64{
+		"area(Geometry.Shapes/Shape)":        0.75,
+		"perimeter(System.Double)":           1.0,
+		"matchArea@6(Geometry.Shapes/Shape)": 2.0 / 3.0,
+	}, lineRates, 1e-9)
+	assert.Equal(t, map[string]int{
+		"area(Geometry.Shapes/Shape)":        4,
+		"perimeter(System.Double)":           2,
+		"matchArea@6(Geometry.Shapes/Shape)": 3,
+	}, coverableLines)
+
+	assert.Equal(t, 3, class.CoveredMethods)
+	assert.Equal(t, 1, class.FullyCoveredMethods)
+}
+
+// compilerGeneratedMethodsXML has the methods of a C# class as coverlet reports them: the
+// async methods in their state machine classes and a lambda in the closure class.
+const compilerGeneratedMethodsXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.75" branch-rate="1" version="1.9">
+  <packages>
+    <package name="Shop">
+      <classes>
+        <class name="Shop.Cart" filename="Cart.cs">
+          <methods>
+            <method name="&lt;Import&gt;g__ParseLine|0_0" signature="(System.String)" complexity="1">
+              <lines><line number="3" hits="1" branch="false" /></lines>
+            </method>
+          </methods>
+          <lines><line number="3" hits="1" branch="false" /></lines>
+        </class>
+        <class name="Shop.Cart/&lt;LoadAsync&gt;d__1" filename="Cart.cs">
+          <methods>
+            <method name="MoveNext" signature="()" complexity="1">
+              <lines><line number="5" hits="1" branch="false" /></lines>
+            </method>
+          </methods>
+          <lines><line number="5" hits="1" branch="false" /></lines>
+        </class>
+        <class name="Shop.Cart/&lt;SaveAsync&gt;d__2" filename="Cart.cs">
+          <methods>
+            <method name="MoveNext" signature="()" complexity="1">
+              <lines><line number="7" hits="0" branch="false" /></lines>
+            </method>
+          </methods>
+          <lines><line number="7" hits="0" branch="false" /></lines>
+        </class>
+        <class name="Shop.Cart/&lt;&gt;c" filename="Cart.cs">
+          <methods>
+            <method name="&lt;Total&gt;b__3_0" signature="(Shop.Item)" complexity="1">
+              <lines><line number="9" hits="1" branch="false" /></lines>
+            </method>
+          </methods>
+          <lines><line number="9" hits="1" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+func TestCoberturaParser_CleansCompilerGeneratedMethods(t *testing.T) {
+	result, _ := parseWithLogs(t, compilerGeneratedMethodsXML, settings.NewSettings())
+
+	require.Len(t, result.Assemblies, 1)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+	methods := make([]string, 0)
+	for _, method := range result.Assemblies[0].Classes[0].Methods {
+		methods = append(methods, method.DisplayName)
+	}
+	assert.ElementsMatch(t, []string{"ParseLine()", "LoadAsync()", "SaveAsync()"}, methods)
+}
+
+// externalFilesXML is a report with a class in its <source> root, a class whose file is
+// outside of it, e.g. a framework source, and a class whose file cannot be found.
+const externalFilesXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="1" branch-rate="1" version="1.9">
+  <sources><source>%s</source></sources>
+  <packages>
+    <package name="App">
+      <classes>
+        <class name="App.Calc" filename="App/Calc.cs">
+          <methods />
+          <lines><line number="1" hits="1" branch="false" /></lines>
+        </class>
+        <class name="App.Framework" filename="%s">
+          <methods />
+          <lines><line number="1" hits="0" branch="false" /></lines>
+        </class>
+        <class name="App.Gone" filename="App/Gone.cs">
+          <methods />
+          <lines><line number="1" hits="0" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+func TestCoberturaParser_ExternalFiles(t *testing.T) {
+	root := writeSourceRoot(t, map[string]string{"App/Calc.cs": "class Calc {}"})
+	framework := filepath.Join(writeSourceRoot(t, map[string]string{"Framework.cs": "class Framework {}"}), "Framework.cs")
+	content := fmt.Sprintf(externalFilesXML, root, framework)
+
+	testCases := []struct {
+		name     string
+		exclude  bool
+		strict   bool
+		expected map[string]bool // InSourceDirs by class
+	}{
+		{name: "Classified", expected: map[string]bool{"App.Calc": true, "App.Framework": false, "App.Gone": true}},
+		{name: "StrictClassifiesMissingFilesAsExternal", strict: true, expected: map[string]bool{"App.Calc": true, "App.Framework": false, "App.Gone": false}},
+		{name: "Excluded", exclude: true, expected: map[string]bool{"App.Calc": true, "App.Gone": true}},
+		{name: "StrictExcluded", exclude: true, strict: true, expected: map[string]bool{"App.Calc": true}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			appSettings := settings.NewSettings()
+			appSettings.ExcludeExternalFiles = tc.exclude
+			appSettings.StrictExternalFiles = tc.strict
+
+			result, _ := parseWithLogs(t, content, appSettings)
+
+			require.Len(t, result.Assemblies, 1)
+			classes := make(map[string]bool)
+			for _, class := range result.Assemblies[0].Classes {
+				require.Len(t, class.Files, 1)
+				classes[class.Name] = class.Files[0].InSourceDirs
+			}
+			assert.Equal(t, tc.expected, classes)
+			assert.Equal(t, len(tc.expected), result.Assemblies[0].LinesValid, "excluded files count towards no total")
+		})
+	}
+}
+
+// fullMethodCoverageXML has a method per case of the full method coverage definition.
+const fullMethodCoverageXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.8" branch-rate="0.5" version="1.9">
+  <packages>
+    <package name="App">
+      <classes>
+        <class name="App.Calc" filename="Calc.cs">
+          <methods>
+            <method name="Full" signature="()" complexity="1">
+              <lines><line number="1" hits="1" branch="false" /><line number="2" hits="3" branch="false" /></lines>
+            </method>
+            <method name="Mostly" signature="()" complexity="1">
+              <lines><line number="4" hits="1" branch="false" /><line number="5" hits="1" branch="false" /><line number="6" hits="1" branch="false" /><line number="7" hits="0" branch="false" /></lines>
+            </method>
+            <method name="Branchy" signature="()" complexity="2">
+              <lines><line number="9" hits="1" branch="true" condition-coverage="50% (1/2)" /><line number="10" hits="1" branch="false" /></lines>
+            </method>
+            <method name="Empty" signature="()" complexity="1">
+              <lines />
+            </method>
+          </methods>
+          <lines>
+            <line number="1" hits="1" branch="false" /><line number="2" hits="3" branch="false" />
+            <line number="4" hits="1" branch="false" /><line number="5" hits="1" branch="false" /><line number="6" hits="1" branch="false" /><line number="7" hits="0" branch="false" />
+            <line number="9" hits="1" branch="true" condition-coverage="50% (1/2)" /><line number="10" hits="1" branch="false" />
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+// TestCoberturaParser_FullMethodCoverage counts the fully covered methods of a class with
+// each definition of the settings. A method without coverable lines is never covered.
+func TestCoberturaParser_FullMethodCoverage(t *testing.T) {
+	testCases := []struct {
+		name             string
+		minimumLineRate  float64
+		requiresBranches bool
+		fullyCovered     int
+	}{
+		{name: "Default", minimumLineRate: 1, fullyCovered: 2},
+		{name: "MinimumLineRate", minimumLineRate: 0.75, fullyCovered: 3},
+		{name: "RequiresBranches", minimumLineRate: 1, requiresBranches: true, fullyCovered: 1},
+		{name: "MinimumLineRateRequiresBranches", minimumLineRate: 0.75, requiresBranches: true, fullyCovered: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			appSettings := settings.NewSettings()
+			appSettings.FullMethodCoverageMinimumLineRate = tc.minimumLineRate
+			appSettings.FullMethodCoverageRequiresBranches = tc.requiresBranches
+
+			result, _ := parseWithLogs(t, fullMethodCoverageXML, appSettings)
+
+			require.Len(t, result.Assemblies, 1)
+			require.Len(t, result.Assemblies[0].Classes, 1)
+			class := result.Assemblies[0].Classes[0]
+			assert.Equal(t, 4, class.TotalMethods)
+			assert.Equal(t, 3, class.CoveredMethods)
+			assert.Equal(t, tc.fullyCovered, class.FullyCoveredMethods)
+		})
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
@@ -30,10 +35,23 @@ type fileProcessingMetrics struct {
 type processingOrchestrator struct {
 	fileReader                        filereader.Reader
 	config                            parsers.ParserConfig
-	sourceDirs                        []string
-	uniqueFilePathsForGrandTotalLines map[string]int
-	processedAssemblyFiles            map[string]struct{}
+	pathKey                           func(string) string // Key of a file path, see utils.PathKeyFunc
+	sourceDirs                        []string            // Roots in the order they are probed, see getEffectiveSourceDirs
+	packageSourceDirs                 []string            // The roots joined with the path of the current package
+	sourceResolution                  sourceResolutionStats
+	uniqueFilePathsForGrandTotalLines map[string]int      // Keyed by pathKey
+	processedAssemblyFiles            map[string]struct{} // Keyed by pathKey
 	detectedBranchCoverage            bool
+	detectedMethods                   bool // At least one method was listed in <methods> or detected in a source file
+	currentAssemblyName               string
+	currentAssemblyComplexity         *float64
+	currentFileLines                  []model.Line // Lines of the file being processed merged from all fragments, indexed by number-1
+	assemblies                        []model.Assembly
+	missingSourceFiles                []model.MissingSourceFile
+	generatedCode                     *filtering.GeneratedCodeDetector // nil if generated code is not excluded
+	includedFiles                     map[string]bool                  // Results of the file filters by report path in the current package
+	filteredFiles                     map[string]struct{}              // Report paths excluded by the file filters
+	externalFiles                     map[string]struct{}              // Report paths excluded as outside the source directories
 	logger                            *slog.Logger
 }
 
@@ -43,29 +61,70 @@ func newProcessingOrchestrator(
 	sourceDirs []string,
 	logger *slog.Logger,
 ) *processingOrchestrator {
-	return &processingOrchestrator{
+	pathKey := utils.PathKeyFunc(parsers.PathCaseMode(config), false)
+	o := &processingOrchestrator{
 		fileReader:                        fileReader,
 		config:                            config,
+		pathKey:                           pathKey,
 		sourceDirs:                        sourceDirs,
 		uniqueFilePathsForGrandTotalLines: make(map[string]int),
+		sourceResolution:                  newSourceResolutionStats(pathKey),
+		includedFiles:                     make(map[string]bool),
+		filteredFiles:                     make(map[string]struct{}),
+		externalFiles:                     make(map[string]struct{}),
 		detectedBranchCoverage:            false,
 		logger:                            logger,
 	}
+	if config.Settings().ExcludeGeneratedCode {
+		o.generatedCode = filtering.NewGeneratedCodeDetector(fileReader.ReadFile)
+	}
+	return o
 }
 
 func (o *processingOrchestrator) processPackages(packages []PackageXML) ([]model.Assembly, bool, error) {
-	var parsedAssemblies []model.Assembly
+	if containsBranchPoints(packages) {
+		o.detectedBranchCoverage = true
+	}
 	for _, pkgXML := range packages {
-		assembly, err := o.processPackage(pkgXML)
-		if err != nil {
-			o.logger.Warn("Could not process Cobertura package, skipping.", "package", pkgXML.Name, "error", err)
-			continue
-		}
-		if assembly != nil {
-			parsedAssemblies = append(parsedAssemblies, *assembly)
+		o.addPackage(pkgXML)
+	}
+	return o.assemblies, o.detectedBranchCoverage, nil
+}
+
+// containsBranchPoints reports whether any line of the packages is a branch point. The
+// branch rates of the methods depend on it, so it has to be known before the first method
+// is processed; Parse gets it from scanForBranchPoints.
+func containsBranchPoints(packages []PackageXML) bool {
+	for _, pkgXML := range packages {
+		for _, classXML := range pkgXML.Classes.Class {
+			for _, lineXML := range classXML.Lines.Line {
+				if strings.EqualFold(lineXML.Branch, "true") {
+					return true
+				}
+			}
+			for _, methodXML := range classXML.Methods.Method {
+				for _, lineXML := range methodXML.Lines.Line {
+					if strings.EqualFold(lineXML.Branch, "true") {
+						return true
+					}
+				}
+			}
 		}
 	}
-	return parsedAssemblies, o.detectedBranchCoverage, nil
+	return false
+}
+
+// addPackage processes a single <package> and appends the resulting assembly. It is
+// used by the streaming parser, so no state other than the results may outlive the call.
+func (o *processingOrchestrator) addPackage(pkgXML PackageXML) {
+	assembly, err := o.processPackage(pkgXML)
+	if err != nil {
+		o.logger.Warn("Could not process Cobertura package, skipping.", "package", pkgXML.Name, "error", err)
+		return
+	}
+	if assembly != nil {
+		o.assemblies = append(o.assemblies, *assembly)
+	}
 }
 
 func (o *processingOrchestrator) processPackage(pkgXML PackageXML) (*model.Assembly, error) {
@@ -75,15 +134,20 @@ func (o *processingOrchestrator) processPackage(pkgXML PackageXML) (*model.Assem
 	}
 
 	assembly := &model.Assembly{
-		Name:    pkgXML.Name,
-		Classes: []model.Class{},
+		Name:       pkgXML.Name,
+		Classes:    []model.Class{},
+		Complexity: declaredComplexity(pkgXML.Complexity),
 	}
 	o.processedAssemblyFiles = make(map[string]struct{})
+	o.currentAssemblyName = pkgXML.Name
+	o.packageSourceDirs = packageSourceDirs(o.sourceDirs, pkgXML.Name)
+	o.includedFiles = make(map[string]bool)
+	o.currentAssemblyComplexity = assembly.Complexity
 
 	classesXMLGrouped := o.groupClassesByLogicalName(pkgXML.Classes.Class)
 
-	for logicalName, classXMLGroup := range classesXMLGrouped {
-		classModel, err := o.processClassGroup(logicalName, classXMLGroup)
+	for _, logicalName := range parsers.SortedKeys(classesXMLGrouped) {
+		classModel, err := o.processClassGroup(logicalName, classesXMLGrouped[logicalName])
 		if err != nil {
 			o.logger.Debug("Skipping class group.", "class", logicalName, "reason", err)
 			continue
@@ -99,9 +163,19 @@ func (o *processingOrchestrator) processPackage(pkgXML PackageXML) (*model.Assem
 
 func (o *processingOrchestrator) groupClassesByLogicalName(classes []ClassXML) map[string][]ClassXML {
 	grouped := make(map[string][]ClassXML)
+	rawMode := o.config.Settings().RawMode
+	keepNested := o.config.Settings().KeepNestedClasses
 	for _, classXML := range classes {
-		formatter := o.config.LanguageProcessorFactory().FindProcessorForFile(classXML.Filename)
-		logicalName := formatter.GetLogicalClassName(classXML.Name)
+		// In raw mode nested and compiler-generated classes keep their own entry.
+		logicalName := classXML.Name
+		if !rawMode {
+			formatter := o.config.LanguageProcessorFactory().FindProcessorForFile(classXML.Filename)
+			if namer, ok := formatter.(language.NestedClassNamer); ok && keepNested {
+				logicalName = namer.GetNestedClassName(classXML.Name)
+			} else {
+				logicalName = formatter.GetLogicalClassName(classXML.Name)
+			}
+		}
 		grouped[logicalName] = append(grouped[logicalName], classXML)
 	}
 	return grouped
@@ -123,17 +197,33 @@ func (o *processingOrchestrator) processClassGroup(logicalClassName string, clas
 		Methods: []model.Method{},
 		Metrics: make(map[string]float64),
 	}
-
-	if primaryFormatter.IsCompilerGeneratedClass(classModel) {
-		return nil, fmt.Errorf("class '%s' is a compiler-generated type and was filtered out", logicalClassName)
+	classComplexities := make([]string, 0, len(classXMLs))
+	for _, classXML := range classXMLs {
+		classComplexities = append(classComplexities, classXML.Complexity)
 	}
+	classModel.Complexity = declaredComplexity(classComplexities...)
 
-	classModel.DisplayName = primaryFormatter.FormatClassName(classModel)
+	if o.config.Settings().RawMode {
+		classModel.DisplayName = logicalClassName
+	} else {
+		if primaryFormatter.IsCompilerGeneratedClass(classModel) {
+			return nil, fmt.Errorf("class '%s' is a compiler-generated type and was filtered out", logicalClassName)
+		}
+		classModel.DisplayName = primaryFormatter.FormatClassName(classModel)
+	}
 
 	classProcessedFilePaths := make(map[string]struct{})
 	xmlFragmentsByFile := o.groupClassFragmentsByFile(classXMLs)
+	if len(xmlFragmentsByFile) == 0 && o.containsOnlyGeneratedCode(classXMLs) {
+		return nil, fmt.Errorf("class '%s' only contains generated code", logicalClassName)
+	}
+	if len(xmlFragmentsByFile) == 0 && o.containsOnlyExternalFiles(classXMLs) {
+		return nil, fmt.Errorf("class '%s' only contains files outside the source directories", logicalClassName)
+	}
 
-	for filePath, fragmentsForFile := range xmlFragmentsByFile {
+	for _, fileKey := range parsers.SortedKeys(xmlFragmentsByFile) {
+		fragmentsForFile := xmlFragmentsByFile[fileKey]
+		filePath := fragmentsForFile[0].Filename
 		fileFormatter := o.config.LanguageProcessorFactory().FindProcessorForFile(filePath)
 		codeFile, methodsInFile, err := o.processFileForClass(filePath, classModel, fragmentsForFile, fileFormatter)
 		if err != nil {
@@ -143,9 +233,12 @@ func (o *processingOrchestrator) processClassGroup(logicalClassName string, clas
 
 		classModel.Files = append(classModel.Files, *codeFile)
 		classModel.Methods = append(classModel.Methods, methodsInFile...)
+		if len(methodsInFile) > 0 {
+			o.detectedMethods = true
+		}
 
-		o.processedAssemblyFiles[codeFile.Path] = struct{}{}
-		classProcessedFilePaths[codeFile.Path] = struct{}{}
+		o.processedAssemblyFiles[o.pathKey(codeFile.Path)] = struct{}{}
+		classProcessedFilePaths[o.pathKey(codeFile.Path)] = struct{}{}
 	}
 
 	o.aggregateClassMetrics(classModel, classProcessedFilePaths)
@@ -153,9 +246,16 @@ func (o *processingOrchestrator) processClassGroup(logicalClassName string, clas
 }
 
 func (o *processingOrchestrator) processFileForClass(filePath string, classModel *model.Class, fragments []ClassXML, fileFormatter language.Processor) (*model.CodeFile, []model.Method, error) {
-	resolvedPath, err := utils.FindFileInSourceDirs(filePath, o.sourceDirs, o.fileReader)
+	resolvedPath, err := o.resolveSourceFile(filePath)
+	o.sourceResolution.record(filePath, resolvedPath, err, o.sourceDirs)
+	found := err == nil
 	if err != nil {
 		o.logger.Warn("Source file not found, line content will be missing.", "file", filePath, "class", classModel.DisplayName)
+		o.missingSourceFiles = append(o.missingSourceFiles, model.MissingSourceFile{
+			Path:     filePath,
+			Assembly: o.currentAssemblyName,
+			Class:    classModel.DisplayName,
+		})
 		resolvedPath = filePath
 	}
 
@@ -172,15 +272,37 @@ func (o *processingOrchestrator) processFileForClass(filePath string, classModel
 	sourceLines, _ := o.fileReader.ReadFile(resolvedPath)
 	totalLines := o.getTotalLines(resolvedPath, sourceLines)
 	maxLineNumInFile := getMaxLineNumber(fragments)
+	if o.config.Settings().StoreSources {
+		// Keep the lines after the last line with coverage data too, so the model holds the whole file.
+		maxLineNumInFile = max(maxLineNumInFile, len(sourceLines))
+	}
 	mergedLineHits, mergedBranches := o.mergeLineAndBranchData(fragments)
+	finalLinesForFile, fileMetrics := o.assembleLinesForFile(maxLineNumInFile, sourceLines, mergedLineHits, mergedBranches)
+	if testHits := mergeTestHits(fragments); len(testHits) > 0 {
+		for i := range finalLinesForFile {
+			finalLinesForFile[i].LineCoverageByTestMethod = testHits[finalLinesForFile[i].Number]
+		}
+	}
+
+	// The methods count the merged lines of the file, so that a line listed by several
+	// fragments has the same hits in all methods containing it.
+	o.currentFileLines = finalLinesForFile
+	defer func() { o.currentFileLines = nil }()
 
 	// Pass the complexity map down to the method processor
 	methodsInFile, codeElementsInFile, err := o.processMethodsForFile(fragments, classModel, fileFormatter, complexityMap)
 	if err != nil {
 		return nil, nil, fmt.Errorf("processing methods for file %s: %w", filePath, err)
 	}
-
-	finalLinesForFile, fileMetrics := o.assembleLinesForFile(maxLineNumInFile, sourceLines, mergedLineHits, mergedBranches)
+	if !declaresMethods(fragments) && len(sourceLines) > 0 {
+		methodsInFile, codeElementsInFile, err = o.synthesizeMethodsForFile(resolvedPath, sourceLines, fragments, classModel, fileFormatter, complexityMap)
+		if err != nil {
+			return nil, nil, fmt.Errorf("synthesizing methods for file %s: %w", filePath, err)
+		}
+	}
+	if len(sourceLines) > 0 {
+		o.extendMethodRanges(resolvedPath, sourceLines, methodsInFile, codeElementsInFile, fileFormatter)
+	}
 
 	codeFile := &model.CodeFile{
 		Path:           resolvedPath,
@@ -190,6 +312,10 @@ func (o *processingOrchestrator) processFileForClass(filePath string, classModel
 		TotalLines:     totalLines,
 		CodeElements:   codeElementsInFile,
 	}
+	if resolvedPath != filePath {
+		codeFile.ReportPath = filePath
+	}
+	codeFile.InSourceDirs = parsers.IsInSourceDirectories(o.config, resolvedPath, found, o.sourceDirs)
 
 	for _, method := range methodsInFile {
 		if method.MethodMetrics != nil {
@@ -204,16 +330,25 @@ func (o *processingOrchestrator) processFileForClass(filePath string, classModel
 func (o *processingOrchestrator) processMethodsForFile(fragments []ClassXML, classModel *model.Class, fileFormatter language.Processor, complexityMap map[string]model.MethodMetric) ([]model.Method, []model.CodeElement, error) {
 	var allMethods []model.Method
 
+	generatedMethods, _ := fileFormatter.(language.CompilerGeneratedMethodDetector)
 	for _, fragment := range fragments {
+		rawClass := &model.Class{Name: fragment.Name}
 		for _, methodXML := range fragment.Methods.Method {
-			methodModel := o.processMethodXML(methodXML, classModel, fileFormatter, complexityMap)
+			if generatedMethods != nil && !o.config.Settings().RawMode &&
+				generatedMethods.IsCompilerGeneratedMethod(&model.Method{Name: methodXML.Name, Signature: methodXML.Signature}, rawClass) {
+				o.logger.Debug("Skipping compiler-generated method", "class", fragment.Name, "method", methodXML.Name+methodXML.Signature)
+				continue
+			}
+			methodModel := o.processMethodXML(methodXML, fragment.Name, classModel, fileFormatter, complexityMap)
+			if !parsers.IsMethodIncluded(o.config, classModel.DisplayName, methodModel.DisplayName) {
+				o.logger.Debug("Method excluded by method filters", "class", classModel.DisplayName, "method", methodModel.DisplayName)
+				continue
+			}
 			allMethods = append(allMethods, *methodModel)
 		}
 	}
 
-	distinctMethods := utils.DistinctBy(allMethods, func(m model.Method) string {
-		return m.Name + m.Signature
-	})
+	distinctMethods := utils.DistinctBy(allMethods, model.Method.RawKey)
 
 	var allCodeElements []model.CodeElement
 	for i := range distinctMethods {
@@ -226,14 +361,135 @@ func (o *processingOrchestrator) processMethodsForFile(fragments []ClassXML, cla
 	return distinctMethods, allCodeElements, nil
 }
 
-func (o *processingOrchestrator) processMethodXML(methodXML MethodXML, classModel *model.Class, fileFormatter language.Processor, complexityMap map[string]model.MethodMetric) *model.Method {
+// declaresMethods reports whether any of the class fragments lists <methods>. Methods
+// removed by the method filters still count, so that they are not synthesized again.
+func declaresMethods(fragments []ClassXML) bool {
+	for _, fragment := range fragments {
+		if len(fragment.Methods.Method) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// synthesizeMethodsForFile creates the methods of a file whose classes list no <methods>,
+// as written by gcovr and some Python tools, from the functions the language processor
+// finds in the source. Each function gets the class lines within its line range;
+// functions without such lines are left out. Without a method detector for the language
+// the file keeps having no methods.
+func (o *processingOrchestrator) synthesizeMethodsForFile(filePath string, sourceLines []string, fragments []ClassXML, classModel *model.Class, fileFormatter language.Processor, complexityMap map[string]model.MethodMetric) ([]model.Method, []model.CodeElement, error) {
+	detector, ok := fileFormatter.(language.MethodDetector)
+	if !ok {
+		return nil, nil, nil
+	}
+	detectedMethods, err := detector.DetectMethods(filePath, sourceLines)
+	if err != nil {
+		o.logger.Debug("Could not detect methods in source file", "file", filePath, "error", err)
+		return nil, nil, nil
+	}
+
+	linesByNumber := make(map[int]LineXML)
+	for _, fragment := range fragments {
+		for _, lineXML := range fragment.Lines.Line {
+			lineNumber, err := strconv.Atoi(lineXML.Number)
+			if _, seen := linesByNumber[lineNumber]; err == nil && !seen {
+				linesByNumber[lineNumber] = lineXML
+			}
+		}
+	}
+
+	var methodXMLs []MethodXML
+	for _, detected := range detectedMethods {
+		// The complexity is unknown unless the language processor calculates it.
+		methodXML := MethodXML{Name: detected.Name, Signature: detected.Signature, Complexity: "NaN"}
+		for lineNumber := detected.FirstLine; lineNumber <= detected.LastLine; lineNumber++ {
+			if lineXML, ok := linesByNumber[lineNumber]; ok {
+				methodXML.Lines.Line = append(methodXML.Lines.Line, lineXML)
+			}
+		}
+		if len(methodXML.Lines.Line) > 0 {
+			methodXMLs = append(methodXMLs, methodXML)
+		}
+	}
+	if len(methodXMLs) == 0 {
+		return nil, nil, nil
+	}
+
+	o.logger.Debug("Synthesized methods from source", "file", filePath, "class", classModel.DisplayName, "methods", len(methodXMLs))
+	return o.processMethodsForFile([]ClassXML{{Methods: MethodsXML{Method: methodXMLs}}}, classModel, fileFormatter, complexityMap)
+}
+
+// extendMethodRanges moves the last line of the methods to the end of the method in the
+// source. Cobertura only lists the lines with coverage data, so the range of a method
+// whose last lines are not coverable, or are missing from the report, ends too early.
+// Each method is matched with the innermost function found by the language processor
+// that contains its lines; a function is used for the first of its methods only, so
+// compiler-generated methods for the lambdas in a method keep their range. Without a
+// method detector for the language the ranges are left as they are.
+func (o *processingOrchestrator) extendMethodRanges(filePath string, sourceLines []string, methods []model.Method, codeElements []model.CodeElement, fileFormatter language.Processor) {
+	detector, ok := fileFormatter.(language.MethodDetector)
+	if !ok || len(methods) == 0 {
+		return
+	}
+	detectedMethods, err := detector.DetectMethods(filePath, sourceLines)
+	if err != nil {
+		o.logger.Debug("Could not detect methods in source file", "file", filePath, "error", err)
+		return
+	}
+
+	used := make(map[int]bool)
+	for i := range methods {
+		method := &methods[i]
+		if method.FirstLine <= 0 {
+			continue
+		}
+		match := -1
+		for j, detected := range detectedMethods {
+			if detected.FirstLine > method.FirstLine || detected.LastLine < method.LastLine {
+				continue
+			}
+			if match == -1 || detected.LastLine-detected.FirstLine < detectedMethods[match].LastLine-detectedMethods[match].FirstLine {
+				match = j
+			}
+		}
+		if match == -1 || used[match] {
+			continue
+		}
+		used[match] = true
+		if detectedMethods[match].LastLine <= method.LastLine {
+			continue
+		}
+
+		for k := range codeElements {
+			if codeElements[k].RawKey == method.RawKey() && codeElements[k].FirstLine == method.FirstLine {
+				codeElements[k].LastLine = detectedMethods[match].LastLine
+			}
+		}
+		method.LastLine = detectedMethods[match].LastLine
+	}
+}
+
+// processMethodXML creates a method of the class from the <method> element of the class
+// fragment named rawClassName, which is a nested type of the class for e.g. the state
+// machines of async methods.
+func (o *processingOrchestrator) processMethodXML(methodXML MethodXML, rawClassName string, classModel *model.Class, fileFormatter language.Processor, complexityMap map[string]model.MethodMetric) *model.Method {
 	method := &model.Method{
 		Name:       methodXML.Name,
 		Signature:  methodXML.Signature,
 		Complexity: parseFloat(methodXML.Complexity),
 	}
+	if rawClassName != classModel.Name {
+		method.NestedClassName = rawClassName
+	}
 
-	method.DisplayName = fileFormatter.FormatMethodName(method, classModel)
+	method.DisplayName = fileFormatter.FormatMethodName(method, &model.Class{Name: rawClassName, DisplayName: classModel.DisplayName})
+
+	if declaredComplexity(methodXML.Complexity) == nil && (classModel.Complexity != nil || o.currentAssemblyComplexity != nil) {
+		// Producers such as scoverage only declare the complexity of classes and packages.
+		// The complexity of the method is unknown then rather than 0, so no CrapScore is
+		// calculated from it.
+		method.Complexity = math.NaN()
+	}
 
 	if metric, ok := complexityMap[method.DisplayName]; ok {
 		if len(metric.Metrics) > 0 {
@@ -244,6 +500,7 @@ func (o *processingOrchestrator) processMethodXML(methodXML MethodXML, classMode
 
 	o.processMethodLines(methodXML, method)
 	o.populateStandardMethodMetrics(method)
+	o.populateExtendedMethodMetrics(methodXML, method)
 
 	return method
 }
@@ -269,17 +526,20 @@ func (o *processingOrchestrator) createCodeElementFromMethod(method *model.Metho
 		FirstLine:     method.FirstLine,
 		LastLine:      method.LastLine,
 		CoverageQuota: coverageQuota,
+		RawKey:        method.RawKey(),
 	}
 }
 
 // The rest of this file (helper functions) remains unchanged as they are not
 // directly involved in calculating or setting the cyclomatic complexity.
 
+// processMethodLines sets the lines, the range and the rates of a method. While a file is
+// processed, the method gets the coverable lines of the file within the range of its
+// <line> elements, like ReportGenerator calculates the coverage quota of a code element.
+// F# and VB.NET split a class into fragments that list the same line with different hits,
+// so the lines of the fragment alone would not match the lines of the file.
 func (o *processingOrchestrator) processMethodLines(methodXML MethodXML, method *model.Method) {
 	minLine, maxLine := math.MaxInt32, 0
-	var methodLinesCovered, methodLinesValid int
-	var methodBranchesCovered, methodBranchesValid int
-
 	for _, lineXML := range methodXML.Lines.Line {
 		currentLineNum, _ := strconv.Atoi(lineXML.Number)
 		if currentLineNum < minLine {
@@ -288,18 +548,33 @@ func (o *processingOrchestrator) processMethodLines(methodXML MethodXML, method
 		if currentLineNum > maxLine {
 			maxLine = currentLineNum
 		}
+	}
 
-		lineModel, lineMetricsStats := o.processLineXML(lineXML)
-		method.Lines = append(method.Lines, lineModel)
+	if minLine > 0 && minLine <= maxLine && maxLine <= len(o.currentFileLines) {
+		for _, line := range o.currentFileLines[minLine-1 : maxLine] {
+			if line.Hits >= 0 {
+				line.Content = ""
+				method.Lines = append(method.Lines, line)
+			}
+		}
+	} else {
+		for _, lineXML := range methodXML.Lines.Line {
+			lineModel, _ := o.processLineXML(lineXML)
+			method.Lines = append(method.Lines, lineModel)
+		}
+	}
 
-		if lineModel.Hits >= 0 {
+	var methodLinesCovered, methodLinesValid int
+	var methodBranchesCovered, methodBranchesValid int
+	for _, line := range method.Lines {
+		if line.Hits >= 0 {
 			methodLinesValid++
-			if lineModel.Hits > 0 {
+			if line.Hits > 0 {
 				methodLinesCovered++
 			}
 		}
-		methodBranchesCovered += lineMetricsStats.branchesCovered
-		methodBranchesValid += lineMetricsStats.branchesValid
+		methodBranchesCovered += line.CoveredBranches
+		methodBranchesValid += line.TotalBranches
 	}
 
 	method.FirstLine = 0
@@ -342,38 +617,38 @@ func (o *processingOrchestrator) processLineXML(lineXML LineXML) (model.Line, fi
 	}
 
 	if line.IsBranchPoint {
-		matches := conditionCoverageRegexCobertura.FindStringSubmatch(lineXML.ConditionCoverage)
-		if len(matches) > 0 {
-			coveredStr := findNamedGroup(conditionCoverageRegexCobertura, matches, "NumberOfCoveredBranches")
-			totalStr := findNamedGroup(conditionCoverageRegexCobertura, matches, "NumberOfTotalBranches")
-
-			if coveredStr != "" && totalStr != "" {
-				numberOfCoveredBranches, _ := strconv.Atoi(coveredStr)
-				numberOfTotalBranches, _ := strconv.Atoi(totalStr)
-
-				if numberOfTotalBranches > 0 {
-					line.CoveredBranches = numberOfCoveredBranches
-					line.TotalBranches = numberOfTotalBranches
-					for i := 0; i < line.TotalBranches; i++ {
-						visits := 0
-						if i < line.CoveredBranches {
-							visits = 1
-						}
-						line.Branch = append(line.Branch, model.BranchCoverageDetail{Identifier: fmt.Sprintf("%d_%d", lineNumber, i), Visits: visits})
-					}
-				}
-			}
-		} else if len(lineXML.Conditions.Condition) > 0 {
+		covered, total, hasConditionCoverage := parseConditionCoverage(lineXML.ConditionCoverage)
+		switch {
+		case len(lineXML.Conditions.Condition) > 0:
+			// Explicit <condition> elements carry real identifiers, so they win over the
+			// attribute. Some converters (e.g. Istanbul) emit both with different granularity.
 			for _, condition := range lineXML.Conditions.Condition {
 				visits := 0
 				if strings.HasPrefix(condition.Coverage, "100") {
 					visits = 1
 					line.CoveredBranches++
 				}
-				line.Branch = append(line.Branch, model.BranchCoverageDetail{Identifier: condition.Number, Visits: visits})
+				line.Branch = append(line.Branch, model.BranchCoverageDetail{Identifier: condition.Number, Visits: visits, Type: condition.Type})
 				line.TotalBranches++
 			}
-		} else {
+			if hasConditionCoverage && (covered != line.CoveredBranches || total != line.TotalBranches) {
+				o.logger.Debug("condition-coverage attribute disagrees with <conditions>, using <conditions>",
+					"line", lineNumber,
+					"attribute", lineXML.ConditionCoverage,
+					"conditionsCovered", line.CoveredBranches,
+					"conditionsTotal", line.TotalBranches)
+			}
+		case hasConditionCoverage && total > 0:
+			line.CoveredBranches = covered
+			line.TotalBranches = total
+			for i := 0; i < line.TotalBranches; i++ {
+				visits := 0
+				if i < line.CoveredBranches {
+					visits = 1
+				}
+				line.Branch = append(line.Branch, model.BranchCoverageDetail{Identifier: syntheticBranchIdentifier(lineNumber, i), Visits: visits})
+			}
+		case !hasConditionCoverage:
 			o.setFallbackBranchData(&line)
 		}
 	}
@@ -383,6 +658,48 @@ func (o *processingOrchestrator) processLineXML(lineXML LineXML) (model.Line, fi
 	return line, metrics
 }
 
+// parseConditionCoverage extracts the covered and total branch counts from a
+// condition-coverage attribute such as "75% (3/4)".
+func parseConditionCoverage(conditionCoverage string) (covered, total int, ok bool) {
+	matches := conditionCoverageRegexCobertura.FindStringSubmatch(conditionCoverage)
+	if len(matches) == 0 {
+		return 0, 0, false
+	}
+	covered, errCovered := strconv.Atoi(findNamedGroup(conditionCoverageRegexCobertura, matches, "NumberOfCoveredBranches"))
+	total, errTotal := strconv.Atoi(findNamedGroup(conditionCoverageRegexCobertura, matches, "NumberOfTotalBranches"))
+	if errCovered != nil || errTotal != nil {
+		return 0, 0, false
+	}
+	return covered, total, true
+}
+
+// syntheticBranchIdentifier names a branch that has no identifier in the report,
+// e.g. when only the condition-coverage attribute is present.
+func syntheticBranchIdentifier(lineNumber, index int) string {
+	return fmt.Sprintf("%d_%d", lineNumber, index)
+}
+
+// isSyntheticBranchIdentifier reports whether the identifier was created by
+// syntheticBranchIdentifier rather than taken from a <condition> number.
+func isSyntheticBranchIdentifier(identifier string) bool {
+	_, _, ok := syntheticBranchIndex(identifier)
+	return ok
+}
+
+// syntheticBranchIndex parses an identifier created by syntheticBranchIdentifier.
+func syntheticBranchIndex(identifier string) (lineNumber, index int, ok bool) {
+	lineText, indexText, found := strings.Cut(identifier, "_")
+	if !found {
+		return 0, 0, false
+	}
+	lineNumber, errLine := strconv.Atoi(lineText)
+	index, errIndex := strconv.Atoi(indexText)
+	if errLine != nil || errIndex != nil || index < 0 {
+		return 0, 0, false
+	}
+	return lineNumber, index, true
+}
+
 func (o *processingOrchestrator) setFallbackBranchData(line *model.Line) {
 	if line.Hits > 0 {
 		line.CoveredBranches = 1
@@ -391,7 +708,7 @@ func (o *processingOrchestrator) setFallbackBranchData(line *model.Line) {
 	}
 	line.TotalBranches = 1
 	line.Branch = append(line.Branch, model.BranchCoverageDetail{
-		Identifier: fmt.Sprintf("%d_0", line.Number),
+		Identifier: syntheticBranchIdentifier(line.Number, 0),
 		Visits:     line.CoveredBranches,
 	})
 }
@@ -403,7 +720,7 @@ func (o *processingOrchestrator) populateStandardMethodMetrics(method *model.Met
 	if !math.IsNaN(method.Complexity) {
 		method.MethodMetrics = append(method.MethodMetrics, model.MethodMetric{
 			Name: shortMetricName, Line: method.FirstLine,
-			Metrics: []model.Metric{{Name: "Cyclomatic complexity", Value: method.Complexity, Status: model.StatusOk}},
+			Metrics: []model.Metric{{Name: "Cyclomatic complexity", Value: method.Complexity, Status: o.metricStatus("Cyclomatic complexity", method.Complexity)}},
 		})
 	}
 
@@ -432,37 +749,172 @@ func (o *processingOrchestrator) populateStandardMethodMetrics(method *model.Met
 		coverageForCrapScore = method.LineRate
 	}
 
-	crapScoreValue := o.calculateCrapScore(coverageForCrapScore, method.Complexity)
+	crapScoreValue := model.CrapScore(coverageForCrapScore, method.Complexity)
 	if !math.IsNaN(crapScoreValue) {
 		method.MethodMetrics = append(method.MethodMetrics, model.MethodMetric{
 			Name: shortMetricName, Line: method.FirstLine,
-			Metrics: []model.Metric{{Name: "CrapScore", Value: crapScoreValue, Status: model.StatusOk}},
+			Metrics: []model.Metric{{Name: "CrapScore", Value: crapScoreValue, Status: o.metricStatus("CrapScore", crapScoreValue)}},
 		})
 	}
 }
 
-func (o *processingOrchestrator) calculateCrapScore(coverage float64, complexity float64) float64 {
-	if math.IsNaN(coverage) || math.IsInf(coverage, 0) || coverage < 0 || coverage > 1 {
-		coverage = 0
+// populateExtendedMethodMetrics adds the optional numeric metric attributes of a <method>
+// element. A CrapScore provided by the report replaces the calculated one.
+func (o *processingOrchestrator) populateExtendedMethodMetrics(methodXML MethodXML, method *model.Method) {
+	extendedMetrics := []struct {
+		name  string
+		value string
+	}{
+		{name: "NPath complexity", value: methodXML.NPath},
+		{name: "CrapScore", value: methodXML.Crap},
+		{name: "Nesting depth", value: methodXML.Nesting},
 	}
-	if math.IsNaN(complexity) || math.IsInf(complexity, 0) || complexity < 0 {
-		return math.NaN()
+
+	shortMetricName := utils.GetShortMethodName(method.DisplayName)
+	for _, em := range extendedMetrics {
+		if em.value == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(em.value, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			o.logger.Debug("Ignoring non-numeric method metric", "metric", em.name, "value", em.value, "method", method.DisplayName)
+			continue
+		}
+
+		method.MethodMetrics = slices.DeleteFunc(method.MethodMetrics, func(mm model.MethodMetric) bool {
+			return len(mm.Metrics) == 1 && mm.Metrics[0].Name == em.name
+		})
+		method.MethodMetrics = append(method.MethodMetrics, model.MethodMetric{
+			Name: shortMetricName, Line: method.FirstLine,
+			Metrics: []model.Metric{{Name: em.name, Value: value, Status: o.metricStatus(em.name, value)}},
+		})
 	}
-	uncoveredRatio := 1.0 - coverage
-	return (math.Pow(complexity, 2) * math.Pow(uncoveredRatio, 3)) + complexity
 }
 
+// metricStatus evaluates a method metric against the thresholds configured in the settings.
+func (o *processingOrchestrator) metricStatus(name string, value float64) model.MetricStatus {
+	return o.config.Settings().MetricThresholds[name].Evaluate(value)
+}
+
+// groupClassFragmentsByFile groups the class fragments by the pathKey of their file
+// name, so that spellings of the same file that differ e.g. in case are processed once.
 func (o *processingOrchestrator) groupClassFragmentsByFile(classXMLs []ClassXML) map[string][]ClassXML {
 	grouped := make(map[string][]ClassXML)
 	for _, classXML := range classXMLs {
-		if classXML.Filename == "" || !o.config.FileFilters().IsElementIncludedInReport(classXML.Filename) {
+		if classXML.Filename == "" || !o.isFileIncluded(classXML.Filename) || o.isGeneratedCode(classXML.Filename) || o.isExcludedExternalFile(classXML.Filename) {
 			continue
 		}
-		grouped[classXML.Filename] = append(grouped[classXML.Filename], classXML)
+		key := o.pathKey(classXML.Filename)
+		grouped[key] = append(grouped[key], classXML)
 	}
 	return grouped
 }
 
+// resolveSourceFile looks reportPath up below the roots in order, and then below the
+// roots joined with the path of the current package, for reports whose file names are
+// relative to their package, e.g. <package name="src/net"> with filename="http.cc".
+func (o *processingOrchestrator) resolveSourceFile(reportPath string) (string, error) {
+	resolver := o.config.SourceFileResolver()
+	resolvedPath, err := resolver.Resolve(reportPath, o.sourceDirs, o.fileReader)
+	if err == nil || len(o.packageSourceDirs) == 0 {
+		return resolvedPath, err
+	}
+	if packagePath, packageErr := resolver.Resolve(reportPath, o.packageSourceDirs, o.fileReader); packageErr == nil {
+		return packagePath, nil
+	}
+	return resolvedPath, err
+}
+
+// packageSourceDirs returns the roots joined with the path of the package: its name if it
+// is a path, otherwise the name with the dots as separators, e.g. "src.net" as "src/net".
+func packageSourceDirs(roots []string, packageName string) []string {
+	if packageName == "" || filepath.IsAbs(packageName) {
+		return nil
+	}
+	packagePath := packageName
+	if !strings.ContainsAny(packagePath, `/\`) {
+		packagePath = strings.ReplaceAll(packagePath, ".", "/")
+	}
+	dirs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		dirs = append(dirs, filepath.Join(root, filepath.FromSlash(packagePath)))
+	}
+	return dirs
+}
+
+// isFileIncluded applies the file filters to the path in the report and to the resolved
+// path of the source file, see parsers.IsFileIncluded. The result is remembered per path
+// within the package, as the resolved path depends on the package.
+func (o *processingOrchestrator) isFileIncluded(reportPath string) bool {
+	if !o.config.FileFilters().HasCustomFilters() {
+		return true
+	}
+	if included, ok := o.includedFiles[reportPath]; ok {
+		return included
+	}
+	resolvedPath, _ := o.resolveSourceFile(reportPath)
+	included := parsers.IsFileIncluded(o.config, reportPath, resolvedPath)
+	o.includedFiles[reportPath] = included
+	if !included {
+		o.filteredFiles[reportPath] = struct{}{}
+	}
+	return included
+}
+
+// isExcludedExternalFile reports whether the file is excluded because it is outside the
+// source directories, see parsers.IsInSourceDirectories.
+func (o *processingOrchestrator) isExcludedExternalFile(reportPath string) bool {
+	if !o.config.Settings().ExcludeExternalFiles {
+		return false
+	}
+	resolvedPath, err := o.resolveSourceFile(reportPath)
+	if parsers.IsInSourceDirectories(o.config, resolvedPath, err == nil, o.sourceDirs) {
+		return false
+	}
+	o.externalFiles[reportPath] = struct{}{}
+	return true
+}
+
+// isGeneratedCode reports whether the file is excluded as generated code.
+func (o *processingOrchestrator) isGeneratedCode(filePath string) bool {
+	if o.generatedCode == nil {
+		return false
+	}
+	return o.generatedCode.IsGenerated(filePath, func() string {
+		resolvedPath, _ := o.resolveSourceFile(filePath)
+		return resolvedPath
+	})
+}
+
+// containsOnlyGeneratedCode reports whether all files of the class fragments are excluded as generated code.
+func (o *processingOrchestrator) containsOnlyGeneratedCode(classXMLs []ClassXML) bool {
+	for _, classXML := range classXMLs {
+		if classXML.Filename == "" || !o.isGeneratedCode(classXML.Filename) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsOnlyExternalFiles reports whether all files of the class fragments are excluded as
+// outside the source directories.
+func (o *processingOrchestrator) containsOnlyExternalFiles(classXMLs []ClassXML) bool {
+	for _, classXML := range classXMLs {
+		if _, external := o.externalFiles[classXML.Filename]; !external {
+			return false
+		}
+	}
+	return len(classXMLs) > 0
+}
+
+// excludedGeneratedFiles returns how many files were excluded as generated code.
+func (o *processingOrchestrator) excludedGeneratedFiles() int {
+	if o.generatedCode == nil {
+		return 0
+	}
+	return o.generatedCode.ExcludedCount()
+}
+
 func (o *processingOrchestrator) aggregateAssemblyMetrics(assembly *model.Assembly) {
 	var linesCovered, linesValid, branchesCovered, branchesValid, totalLines int
 	hasBranchData := false
@@ -492,7 +944,6 @@ func (o *processingOrchestrator) aggregateAssemblyMetrics(assembly *model.Assemb
 
 func (o *processingOrchestrator) aggregateClassMetrics(class *model.Class, processedFiles map[string]struct{}) {
 	var totalClassLines, totalClassBranchesCovered, totalClassBranchesValid int
-	var coveredM, fullyCoveredM, totalM int
 	hasClassBranchData := false
 
 	for _, f := range class.Files {
@@ -518,53 +969,26 @@ func (o *processingOrchestrator) aggregateClassMetrics(class *model.Class, proce
 	}
 	class.TotalLines = totalClassLines
 
-	if len(class.Methods) > 0 {
-		totalM = len(class.Methods)
-		for _, method := range class.Methods {
-			atLeastOneLineCoveredInMethod := false
-			methodIsFullyCovered := true
-			methodHasCoverableLines := false
-			for _, line := range method.Lines {
-				if line.Hits >= 0 {
-					methodHasCoverableLines = true
-					if line.Hits > 0 {
-						atLeastOneLineCoveredInMethod = true
-					} else {
-						methodIsFullyCovered = false
-					}
-				}
-			}
-			if atLeastOneLineCoveredInMethod {
-				coveredM++
-			}
-			if methodHasCoverableLines && methodIsFullyCovered {
-				fullyCoveredM++
-			} else if !methodHasCoverableLines && len(method.Lines) == 0 {
-				fullyCoveredM++
-			}
-		}
-	}
-	class.CoveredMethods = coveredM
-	class.FullyCoveredMethods = fullyCoveredM
-	class.TotalMethods = totalM
+	class.TotalMethods = len(class.Methods)
+	class.CoveredMethods, class.FullyCoveredMethods = analyzer.NewFullMethodCoverage(o.config.Settings()).CountMethodCoverage(class.Methods)
 
-	for _, method := range class.Methods {
-		if !math.IsNaN(method.Complexity) {
-			class.Metrics["Cyclomatic complexity"] += method.Complexity
-		}
+	class.Metrics = o.config.Settings().Metrics.AggregateMethodMetrics(class.Methods)
+	if _, ok := class.Metrics["Cyclomatic complexity"]; !ok && class.Complexity != nil {
+		class.Metrics["Cyclomatic complexity"] = *class.Complexity
 	}
 }
 
 func (o *processingOrchestrator) getTotalLines(path string, sourceLines []string) int {
-	if count, ok := o.uniqueFilePathsForGrandTotalLines[path]; ok {
+	key := o.pathKey(path)
+	if count, ok := o.uniqueFilePathsForGrandTotalLines[key]; ok {
 		return count
 	}
 	if lineCount, err := o.fileReader.CountLines(path); err == nil {
-		o.uniqueFilePathsForGrandTotalLines[path] = lineCount
+		o.uniqueFilePathsForGrandTotalLines[key] = lineCount
 		return lineCount
 	}
 	if sourceLines != nil {
-		o.uniqueFilePathsForGrandTotalLines[path] = len(sourceLines)
+		o.uniqueFilePathsForGrandTotalLines[key] = len(sourceLines)
 		return len(sourceLines)
 	}
 	return 0
@@ -586,15 +1010,27 @@ func getMaxLineNumber(fragments []ClassXML) int {
 	return maxLine
 }
 
-func (o *processingOrchestrator) mergeBranches(existing, new []model.BranchCoverageDetail) []model.BranchCoverageDetail {
+// mergeBranches merges the branches of two listings of a line, combining the visits of the
+// same branch with mode.
+func mergeBranches(existing, new []model.BranchCoverageDetail, mode utils.MergeMode) []model.BranchCoverageDetail {
 	if existing == nil {
 		return new
 	}
+
+	// Synthetic identifiers only describe the position of a branch. When the fragments use
+	// different identifier schemes, e.g. condition numbers in one and synthetic identifiers
+	// in another instrumentation run, merging by identifier would count every branch twice.
+	sameScheme := (allSyntheticBranches(existing) && allSyntheticBranches(new)) ||
+		(!hasSyntheticBranch(existing) && !hasSyntheticBranch(new))
+	if !sameScheme {
+		return mergeBranchesByPosition(existing, new, mode)
+	}
+
 	for _, newBranch := range new {
 		found := false
 		for i, existingBranch := range existing {
 			if existingBranch.Identifier == newBranch.Identifier {
-				existing[i].Visits += newBranch.Visits
+				existing[i].Visits = mode.Combine(existing[i].Visits, newBranch.Visits)
 				found = true
 				break
 			}
@@ -606,39 +1042,157 @@ func (o *processingOrchestrator) mergeBranches(existing, new []model.BranchCover
 	return existing
 }
 
+// mergeBranchesByPosition merges branches of different identifier schemes by their
+// position on the line (see branchPositions) and combines their visits. A position keeps the
+// real condition number if either side has one, so the line has as many branches as the
+// fragment with the most branches instead of the union of both identifier sets.
+func mergeBranchesByPosition(a, b []model.BranchCoverageDetail, mode utils.MergeMode) []model.BranchCoverageDetail {
+	byPosition := make(map[int]model.BranchCoverageDetail)
+	for _, branches := range [][]model.BranchCoverageDetail{a, b} {
+		for i, position := range branchPositions(branches) {
+			branch, seen := byPosition[position]
+			if !seen || (isSyntheticBranchIdentifier(branch.Identifier) && !isSyntheticBranchIdentifier(branches[i].Identifier)) {
+				branch.Identifier = branches[i].Identifier
+			}
+			if branch.Type == "" {
+				branch.Type = branches[i].Type
+			}
+			branch.Visits = mode.Combine(branch.Visits, branches[i].Visits)
+			byPosition[position] = branch
+		}
+	}
+
+	merged := make([]model.BranchCoverageDetail, 0, len(byPosition))
+	for _, position := range slices.Sorted(maps.Keys(byPosition)) {
+		merged = append(merged, byPosition[position])
+	}
+	return merged
+}
+
+// branchPositions returns the position of each branch on its line. Real condition numbers
+// take the first positions in the order they appear; synthetic identifiers carry their
+// position, which is behind the real ones in a line merged before.
+func branchPositions(branches []model.BranchCoverageDetail) []int {
+	positions := make([]int, len(branches))
+	next := 0
+	for i, branch := range branches {
+		if _, index, ok := syntheticBranchIndex(branch.Identifier); ok {
+			positions[i] = index
+			continue
+		}
+		positions[i] = next
+		next++
+	}
+	return positions
+}
+
+func allSyntheticBranches(branches []model.BranchCoverageDetail) bool {
+	for _, branch := range branches {
+		if !isSyntheticBranchIdentifier(branch.Identifier) {
+			return false
+		}
+	}
+	return len(branches) > 0
+}
+
+func hasSyntheticBranch(branches []model.BranchCoverageDetail) bool {
+	return slices.ContainsFunc(branches, func(branch model.BranchCoverageDetail) bool {
+		return isSyntheticBranchIdentifier(branch.Identifier)
+	})
+}
+
+// mergeLineAndBranchData merges the hits and branches of the lines of all fragments of a
+// file. A fragment may list a line in its class <lines> and in the <lines> of a method;
+// both describe the same visits, so they are combined with MergeMax. Different fragments
+// of one report, e.g. of a partial class, add up. The -mergemode setting applies to lines
+// reported by several reports only, see analyzer.MergeParserResults.
 func (o *processingOrchestrator) mergeLineAndBranchData(fragments []ClassXML) (map[int]int, map[int][]model.BranchCoverageDetail) {
 	lineHits := make(map[int]int)
 	branchDetails := make(map[int][]model.BranchCoverageDetail)
 
 	for _, fragment := range fragments {
-		allLines := make([]LineXML, len(fragment.Lines.Line))
-		copy(allLines, fragment.Lines.Line)
-		for _, method := range fragment.Methods.Method {
-			allLines = append(allLines, method.Lines.Line...)
-		}
-
-		for _, lineXML := range allLines {
+		fragmentHits := make(map[int]int)
+		fragmentBranches := make(map[int][]model.BranchCoverageDetail)
+		for _, lineXML := range fragmentLines(fragment) {
 			lineNumber, err := strconv.Atoi(lineXML.Number)
 			if err != nil || lineNumber <= 0 {
 				continue
 			}
 
 			if hits, err := strconv.Atoi(lineXML.Hits); err == nil {
-				lineHits[lineNumber] += hits
+				if existing, ok := fragmentHits[lineNumber]; ok {
+					hits = utils.MergeMax.Combine(existing, hits)
+				}
+				fragmentHits[lineNumber] = hits
 			}
 
 			if strings.EqualFold(lineXML.Branch, "true") {
 				lineModel, _ := o.processLineXML(lineXML)
 				if lineModel.IsBranchPoint {
-					currentBranches := branchDetails[lineNumber]
-					branchDetails[lineNumber] = o.mergeBranches(currentBranches, lineModel.Branch)
+					fragmentBranches[lineNumber] = mergeBranches(fragmentBranches[lineNumber], lineModel.Branch, utils.MergeMax)
 				}
 			}
 		}
+
+		for lineNumber, hits := range fragmentHits {
+			lineHits[lineNumber] += hits
+		}
+		for lineNumber, branches := range fragmentBranches {
+			branchDetails[lineNumber] = mergeBranches(branchDetails[lineNumber], branches, utils.MergeSum)
+		}
 	}
 	return lineHits, branchDetails
 }
 
+// fragmentLines returns the class <lines> of the fragment followed by the <lines> of its
+// methods.
+func fragmentLines(fragment ClassXML) []LineXML {
+	lines := slices.Clone(fragment.Lines.Line)
+	for _, method := range fragment.Methods.Method {
+		lines = append(lines, method.Lines.Line...)
+	}
+	return lines
+}
+
+// mergeTestHits merges the optional per-test hits of all fragments by line number and
+// test name like mergeLineAndBranchData merges the hits: the listings of a line in the
+// class and in a method of a fragment are combined with MergeMax, fragments add up.
+func mergeTestHits(fragments []ClassXML) map[int]map[string]int {
+	testHits := make(map[int]map[string]int)
+	for _, fragment := range fragments {
+		fragmentHits := make(map[int]map[string]int)
+		for _, lineXML := range fragmentLines(fragment) {
+			if len(lineXML.Tests.Test) == 0 {
+				continue
+			}
+			lineNumber, err := strconv.Atoi(lineXML.Number)
+			if err != nil || lineNumber <= 0 {
+				continue
+			}
+			for _, test := range lineXML.Tests.Test {
+				hits, err := strconv.Atoi(test.Hits)
+				if test.Name == "" || err != nil || hits < 0 {
+					continue
+				}
+				if fragmentHits[lineNumber] == nil {
+					fragmentHits[lineNumber] = make(map[string]int)
+				}
+				fragmentHits[lineNumber][test.Name] = max(fragmentHits[lineNumber][test.Name], hits)
+			}
+		}
+
+		for lineNumber, hitsByTest := range fragmentHits {
+			if testHits[lineNumber] == nil {
+				testHits[lineNumber] = make(map[string]int)
+			}
+			for name, hits := range hitsByTest {
+				testHits[lineNumber][name] += hits
+			}
+		}
+	}
+	return testHits
+}
+
 func (o *processingOrchestrator) assembleLinesForFile(maxLineNum int, sourceLines []string, lineHits map[int]int, branches map[int][]model.BranchCoverageDetail) ([]model.Line, fileProcessingMetrics) {
 	var finalLines []model.Line
 	metrics := fileProcessingMetrics{}
@@ -705,6 +1259,25 @@ func parseFloat(s string) float64 {
 	return v
 }
 
+// declaredComplexity returns the sum of the complexity attributes that hold a valid
+// value, or nil if none does. An empty attribute means the element declares no complexity.
+func declaredComplexity(values ...string) *float64 {
+	var sum float64
+	declared := false
+	for _, value := range values {
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+			continue
+		}
+		sum += v
+		declared = true
+	}
+	if !declared {
+		return nil
+	}
+	return &sum
+}
+
 func determineLineVisitStatus(hits int, isBranchPoint bool, coveredBranches int, totalBranches int) model.LineVisitStatus {
 	if hits < 0 {
 		return model.NotCoverable
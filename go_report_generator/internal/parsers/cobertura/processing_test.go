@@ -0,0 +1,888 @@
+package cobertura
+
+import (
+	"encoding/xml"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/csharp"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/defaultformatter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/golang"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/progress"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockParserConfig struct {
+	settings     *settings.Settings
+	noFilter     filtering.IFilter
+	methodFilter filtering.IFilter // No filter if nil
+	langFactory  *language.ProcessorFactory
+	resolver     *utils.SourceFileResolver
+	logger       *slog.Logger // Discards the logs if nil
+	sourceDirs   []string
+}
+
+func (m *mockParserConfig) SourceDirectories() []string        { return m.sourceDirs }
+func (m *mockParserConfig) AssemblyFilters() filtering.IFilter { return m.noFilter }
+func (m *mockParserConfig) ClassFilters() filtering.IFilter    { return m.noFilter }
+func (m *mockParserConfig) FileFilters() filtering.IFilter     { return m.noFilter }
+func (m *mockParserConfig) MethodFilters() filtering.IFilter {
+	if m.methodFilter != nil {
+		return m.methodFilter
+	}
+	return m.noFilter
+}
+func (m *mockParserConfig) Settings() *settings.Settings { return m.settings }
+func (m *mockParserConfig) Logger() *slog.Logger {
+	if m.logger != nil {
+		return m.logger
+	}
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+func (m *mockParserConfig) LanguageProcessorFactory() *language.ProcessorFactory {
+	return m.langFactory
+}
+func (m *mockParserConfig) SourceFileIndex() *utils.SourceFileIndex { return nil }
+func (m *mockParserConfig) SourceFileResolver() *utils.SourceFileResolver {
+	return m.resolver
+}
+func (m *mockParserConfig) Progress() progress.Reporter { return progress.Discard }
+
+func newTestConfig(appSettings *settings.Settings) *mockParserConfig {
+	noFilter, _ := filtering.NewDefaultFilter(nil)
+	return &mockParserConfig{
+		settings: appSettings,
+		noFilter: noFilter,
+		resolver: utils.NewSourceFileResolver(nil, slog.New(slog.NewTextHandler(io.Discard, nil))),
+		langFactory: language.NewProcessorFactory(
+			defaultformatter.NewDefaultProcessor(),
+			csharp.NewCSharpProcessor(),
+			golang.NewGoProcessor(),
+		),
+	}
+}
+
+func nestedClassesPackage() PackageXML {
+	line := func(number string) LinesXML {
+		return LinesXML{Line: []LineXML{{Number: number, Hits: "1", Branch: "false"}}}
+	}
+	return PackageXML{
+		Name: "MyAssembly",
+		Classes: ClassesXML{Class: []ClassXML{
+			{Name: "MyNamespace.Foo", Filename: "Foo.cs", Lines: line("3")},
+			{Name: "MyNamespace.Foo/<Bar>d__3", Filename: "Foo.cs", Lines: line("7")},
+		}},
+	}
+}
+
+func classDisplayNames(t *testing.T, config *mockParserConfig) []string {
+	t.Helper()
+	orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+	assemblies, _, err := orchestrator.processPackages([]PackageXML{nestedClassesPackage()})
+	require.NoError(t, err)
+	require.Len(t, assemblies, 1)
+
+	var names []string
+	for _, class := range assemblies[0].Classes {
+		names = append(names, class.DisplayName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nestedTypesPackage has two meaningful nested classes of Outer and the compiler-generated
+// types of an async method, a lambda and a local function.
+func nestedTypesPackage() PackageXML {
+	line := func(number, hits string) LinesXML {
+		return LinesXML{Line: []LineXML{{Number: number, Hits: hits, Branch: "false"}}}
+	}
+	return PackageXML{
+		Name: "MyAssembly",
+		Classes: ClassesXML{Class: []ClassXML{
+			{Name: "MyNamespace.Outer", Filename: "Outer.cs", Lines: line("3", "1")},
+			{Name: "MyNamespace.Outer+Inner1", Filename: "Outer.cs", Lines: line("8", "1")},
+			{Name: "MyNamespace.Outer+Inner1/<RunAsync>d__2", Filename: "Outer.cs", Lines: line("10", "0")},
+			{Name: "MyNamespace.Outer+Inner2", Filename: "Outer.cs", Lines: line("15", "0")},
+			{Name: "MyNamespace.Outer+Inner2+<>c__DisplayClass0_0", Filename: "Outer.cs", Lines: line("17", "1")},
+			{Name: "MyNamespace.Outer/<>c", Filename: "Outer.cs", Lines: line("20", "1")},
+		}},
+	}
+}
+
+func TestProcessingOrchestrator_NestedClassGrouping(t *testing.T) {
+	process := func(t *testing.T, keepNested bool) map[string][2]int {
+		t.Helper()
+		appSettings := settings.NewSettings()
+		appSettings.KeepNestedClasses = keepNested
+		config := newTestConfig(appSettings)
+		orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+
+		assemblies, _, err := orchestrator.processPackages([]PackageXML{nestedTypesPackage()})
+		require.NoError(t, err)
+		require.Len(t, assemblies, 1)
+
+		// Display name to covered and coverable lines.
+		classes := make(map[string][2]int)
+		for _, class := range assemblies[0].Classes {
+			classes[class.DisplayName] = [2]int{class.LinesCovered, class.LinesValid}
+		}
+		return classes
+	}
+
+	t.Run("Merged", func(t *testing.T) {
+		assert.Equal(t, map[string][2]int{"MyNamespace.Outer": {4, 6}}, process(t, false))
+	})
+
+	t.Run("KeepNestedClasses", func(t *testing.T) {
+		assert.Equal(t, map[string][2]int{
+			"MyNamespace.Outer":        {2, 2},
+			"MyNamespace.Outer.Inner1": {1, 2},
+			"MyNamespace.Outer.Inner2": {1, 2},
+		}, process(t, true), "compiler-generated types are merged into the type that contains them")
+	})
+}
+
+func TestProcessingOrchestrator_MergesNestedClassesByDefault(t *testing.T) {
+	names := classDisplayNames(t, newTestConfig(settings.NewSettings()))
+
+	assert.Equal(t, []string{"MyNamespace.Foo"}, names)
+}
+
+func TestProcessingOrchestrator_RawModeKeepsCompilerGeneratedClasses(t *testing.T) {
+	appSettings := settings.NewSettings()
+	appSettings.RawMode = true
+
+	names := classDisplayNames(t, newTestConfig(appSettings))
+
+	assert.Equal(t, []string{"MyNamespace.Foo", "MyNamespace.Foo/<Bar>d__3"}, names)
+}
+
+func TestProcessingOrchestrator_ForcedLanguageProcessor(t *testing.T) {
+	config := newTestConfig(settings.NewSettings())
+	require.NoError(t, config.langFactory.ForceProcessor("default"))
+
+	// The default processor neither merges nested classes nor filters compiler-generated ones.
+	names := classDisplayNames(t, config)
+
+	assert.Equal(t, []string{"MyNamespace.Foo", "MyNamespace.Foo/<Bar>d__3"}, names)
+	assert.Equal(t, "Default", config.langFactory.FindProcessorForFile("Foo.cs").Name())
+
+	require.NoError(t, config.langFactory.ForceProcessor("CSharp"))
+	assert.Equal(t, "C#", config.langFactory.FindProcessorForFile("view.cshtml").Name())
+
+	assert.Error(t, config.langFactory.ForceProcessor("cobol"))
+}
+
+func TestProcessingOrchestrator_ExcludesGeneratedCode(t *testing.T) {
+	pkg := nestedClassesPackage()
+	pkg.Classes.Class = append(pkg.Classes.Class, ClassXML{
+		Name:     "MyNamespace.Form1",
+		Filename: "Form1.Designer.cs",
+		Lines:    LinesXML{Line: []LineXML{{Number: "5", Hits: "0", Branch: "false"}}},
+	})
+	config := newTestConfig(settings.NewSettings())
+	orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+
+	assemblies, _, err := orchestrator.processPackages([]PackageXML{pkg})
+	require.NoError(t, err)
+
+	require.Len(t, assemblies, 1)
+	require.Len(t, assemblies[0].Classes, 1)
+	assert.Equal(t, "MyNamespace.Foo", assemblies[0].Classes[0].DisplayName)
+	assert.Equal(t, 1, orchestrator.excludedGeneratedFiles())
+}
+
+func TestProcessingOrchestrator_CollectsMissingSourceFiles(t *testing.T) {
+	config := newTestConfig(settings.NewSettings())
+	orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, []string{t.TempDir()}, config.Logger())
+
+	_, _, err := orchestrator.processPackages([]PackageXML{nestedClassesPackage()})
+	require.NoError(t, err)
+
+	require.Len(t, orchestrator.missingSourceFiles, 1)
+	assert.Equal(t, "Foo.cs", orchestrator.missingSourceFiles[0].Path)
+	assert.Equal(t, "MyAssembly", orchestrator.missingSourceFiles[0].Assembly)
+	assert.Equal(t, "MyNamespace.Foo", orchestrator.missingSourceFiles[0].Class)
+}
+
+func TestProcessingOrchestrator_ExtendedMethodMetrics(t *testing.T) {
+	config := newTestConfig(settings.NewSettings())
+	orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+	classModel := &model.Class{Name: "Calc", DisplayName: "Calc"}
+
+	methodXML := MethodXML{
+		Name: "add", Signature: "()", LineRate: "1", Complexity: "2",
+		NPath: "4", Crap: "7.5", Nesting: "not-a-number",
+		Lines: LinesXML{Line: []LineXML{{Number: "3", Hits: "1", Branch: "false"}}},
+	}
+
+	method := orchestrator.processMethodXML(methodXML, classModel.Name, classModel, defaultformatter.NewDefaultProcessor(), nil)
+
+	values := make(map[string][]interface{})
+	for _, mm := range method.MethodMetrics {
+		for _, m := range mm.Metrics {
+			values[m.Name] = append(values[m.Name], m.Value)
+		}
+	}
+	assert.Equal(t, []interface{}{4.0}, values["NPath complexity"])
+	assert.Equal(t, []interface{}{7.5}, values["CrapScore"], "reported CrapScore replaces the calculated one")
+	assert.NotContains(t, values, "Nesting depth", "non-numeric values are ignored")
+	assert.Equal(t, []interface{}{2.0}, values["Cyclomatic complexity"])
+}
+
+func TestProcessingOrchestrator_MethodMetricStatus(t *testing.T) {
+	config := newTestConfig(settings.NewSettings())
+	orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+	classModel := &model.Class{Name: "Calc", DisplayName: "Calc"}
+
+	methodXML := MethodXML{
+		Name: "parse", Signature: "()", LineRate: "0", Complexity: "20",
+		Lines: LinesXML{Line: []LineXML{{Number: "3", Hits: "0", Branch: "false"}}},
+	}
+
+	method := orchestrator.processMethodXML(methodXML, classModel.Name, classModel, defaultformatter.NewDefaultProcessor(), nil)
+
+	statuses := make(map[string]model.MetricStatus)
+	for _, mm := range method.MethodMetrics {
+		for _, m := range mm.Metrics {
+			statuses[m.Name] = m.Status
+		}
+	}
+	assert.Equal(t, model.StatusError, statuses["CrapScore"], "CrapScore 420 exceeds the error limit of 80")
+	assert.Equal(t, model.StatusWarning, statuses["Cyclomatic complexity"], "complexity 20 exceeds the warning limit of 15")
+	assert.Equal(t, model.StatusOk, statuses["Line coverage"])
+}
+
+// Istanbul-to-Cobertura converters emit the condition-coverage attribute with branch
+// granularity and <conditions> with condition granularity on the same line.
+const istanbulClassFragment = `
+<class name="calc.js" filename="calc.js">
+  <methods/>
+  <lines>
+    <line number="10" hits="3" branch="true" condition-coverage="75% (3/4)">
+      <conditions>
+        <condition number="0" type="jump" coverage="100%"/>
+        <condition number="1" type="jump" coverage="50%"/>
+      </conditions>
+    </line>
+  </lines>
+</class>`
+
+// coverlet emits IL offsets as condition numbers.
+const coverletClassFragment = `
+<class name="Calc" filename="Calc.cs">
+  <methods>
+    <method name="Add" signature="(System.Boolean)" line-rate="1" branch-rate="0.5" complexity="2">
+      <lines>
+        <line number="10" hits="1" branch="True" condition-coverage="50% (1/2)">
+          <conditions>
+            <condition number="46" type="jump" coverage="50%"/>
+          </conditions>
+        </line>
+      </lines>
+    </method>
+  </methods>
+  <lines>
+    <line number="10" hits="1" branch="True" condition-coverage="50% (1/2)"/>
+  </lines>
+</class>`
+
+func unmarshalClassXML(t *testing.T, fragment string) ClassXML {
+	t.Helper()
+	var classXML ClassXML
+	require.NoError(t, xml.Unmarshal([]byte(fragment), &classXML))
+	return classXML
+}
+
+func branchIdentifiers(branches []model.BranchCoverageDetail) []string {
+	var ids []string
+	for _, b := range branches {
+		ids = append(ids, b.Identifier)
+	}
+	return ids
+}
+
+func TestProcessLineXML_ConditionsWinOverAttribute(t *testing.T) {
+	config := newTestConfig(settings.NewSettings())
+	orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+	classXML := unmarshalClassXML(t, istanbulClassFragment)
+
+	line, metrics := orchestrator.processLineXML(classXML.Lines.Line[0])
+
+	assert.Equal(t, []string{"0", "1"}, branchIdentifiers(line.Branch))
+	assert.Equal(t, "jump", line.Branch[1].Type, "the condition type is kept")
+	assert.Equal(t, 1, line.CoveredBranches)
+	assert.Equal(t, 2, line.TotalBranches)
+	assert.Equal(t, 2, metrics.branchesValid)
+}
+
+func TestMergeLineAndBranchData_SyntheticAndConditionIdentifiers(t *testing.T) {
+	countBranches := func(branches []model.BranchCoverageDetail) (covered, total int) {
+		for _, b := range branches {
+			if b.Visits > 0 {
+				covered++
+			}
+			total++
+		}
+		return covered, total
+	}
+
+	t.Run("Istanbul_ConditionsThenAttributeOnly", func(t *testing.T) {
+		config := newTestConfig(settings.NewSettings())
+		orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+		withConditions := unmarshalClassXML(t, istanbulClassFragment)
+		attributeOnly := unmarshalClassXML(t, istanbulClassFragment)
+		attributeOnly.Lines.Line[0].Conditions = ConditionsXML{}
+
+		_, branches := orchestrator.mergeLineAndBranchData([]ClassXML{withConditions, attributeOnly})
+
+		assert.Equal(t, []string{"0", "1", "10_2", "10_3"}, branchIdentifiers(branches[10]))
+		covered, total := countBranches(branches[10])
+		assert.Equal(t, 3, covered)
+		assert.Equal(t, 4, total, "branches must not be counted once per identifier scheme")
+	})
+
+	t.Run("Coverlet_AttributeOnlyThenConditions", func(t *testing.T) {
+		config := newTestConfig(settings.NewSettings())
+		orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+		classXML := unmarshalClassXML(t, coverletClassFragment)
+
+		// The class <lines> (attribute only) are merged before the method <lines> (with conditions).
+		_, branches := orchestrator.mergeLineAndBranchData([]ClassXML{classXML})
+
+		assert.Equal(t, []string{"46", "10_1"}, branchIdentifiers(branches[10]))
+		assert.Equal(t, "jump", branches[10][0].Type, "the condition type of the merged branch is kept")
+		covered, total := countBranches(branches[10])
+		assert.Equal(t, 1, covered)
+		assert.Equal(t, 2, total)
+	})
+}
+
+func TestMergeLineAndBranchData_ClassAndMethodLinesAreCountedOnce(t *testing.T) {
+	fragment := `<class name="Shop.Cart" filename="Cart.cs">
+  <methods><method name="Add" signature="()"><lines>
+    <line number="3" hits="4" branch="true" condition-coverage="50% (1/2)"/>
+  </lines></method></methods>
+  <lines>
+    <line number="3" hits="4" branch="true" condition-coverage="50% (1/2)"/>
+  </lines>
+</class>`
+	rerun := strings.ReplaceAll(fragment, `hits="4"`, `hits="6"`)
+
+	for _, mode := range []string{"sum", "max"} {
+		t.Run(mode, func(t *testing.T) {
+			appSettings := settings.NewSettings()
+			appSettings.MergeMode = mode
+			config := newTestConfig(appSettings)
+			orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+
+			hits, branches := orchestrator.mergeLineAndBranchData([]ClassXML{unmarshalClassXML(t, fragment)})
+			assert.Equal(t, 4, hits[3], "the method lists the same visits as the class")
+			require.Len(t, branches[3], 2)
+			assert.Equal(t, []int{1, 0}, []int{branches[3][0].Visits, branches[3][1].Visits})
+
+			hits, branches = orchestrator.mergeLineAndBranchData([]ClassXML{unmarshalClassXML(t, fragment), unmarshalClassXML(t, rerun)})
+			assert.Equal(t, 10, hits[3], "the fragments of one report add up in every merge mode")
+			require.Len(t, branches[3], 2)
+			assert.Equal(t, []int{2, 0}, []int{branches[3][0].Visits, branches[3][1].Visits})
+		})
+	}
+}
+
+// TestProcessPackages_MergesBranchIdentifierSchemes builds a partial class from fragments
+// of different instrumentation runs: one with condition numbers, the others with
+// condition-coverage attributes only, which get synthetic identifiers ("42_0"). The class
+// must count the branches of line 42 once, as the largest fragment has them.
+func TestProcessPackages_MergesBranchIdentifierSchemes(t *testing.T) {
+	conditions := `<line number="42" hits="1" branch="true"><conditions>
+		<condition number="0" type="jump" coverage="100%"/><condition number="1" type="jump" coverage="0%"/>
+	</conditions></line>`
+	attribute := func(coverage string) string {
+		return `<line number="42" hits="1" branch="true" condition-coverage="` + coverage + `"/>`
+	}
+	fragment := func(line string) ClassXML {
+		return unmarshalClassXML(t, `<class name="Shop.Cart" filename="Cart.cs"><methods/><lines>`+line+`</lines></class>`)
+	}
+
+	testCases := []struct {
+		name        string
+		lines       []string
+		wantIDs     []string
+		wantCovered int
+		wantValid   int
+	}{
+		{name: "ConditionsThenAttribute", lines: []string{conditions, attribute("50% (1/2)")}, wantIDs: []string{"0", "1"}, wantCovered: 1, wantValid: 2},
+		{name: "AttributeThenConditions", lines: []string{attribute("50% (1/2)"), conditions}, wantIDs: []string{"0", "1"}, wantCovered: 1, wantValid: 2},
+		{name: "AttributeWithMoreBranches", lines: []string{conditions, attribute("33% (1/3)")}, wantIDs: []string{"0", "1", "42_2"}, wantCovered: 1, wantValid: 3},
+		{
+			name:    "MixedThenConditions",
+			lines:   []string{conditions, attribute("66% (2/3)"), strings.Replace(conditions, `</conditions>`, `<condition number="2" type="jump" coverage="100%"/></conditions>`, 1)},
+			wantIDs: []string{"0", "1", "2"}, wantCovered: 3, wantValid: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newTestConfig(settings.NewSettings())
+			orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+			pkg := PackageXML{Name: "Shop"}
+			for _, line := range tc.lines {
+				pkg.Classes.Class = append(pkg.Classes.Class, fragment(line))
+			}
+
+			assemblies, _, err := orchestrator.processPackages([]PackageXML{pkg})
+
+			require.NoError(t, err)
+			require.Len(t, assemblies, 1)
+			require.Len(t, assemblies[0].Classes, 1)
+			class := assemblies[0].Classes[0]
+			require.NotNil(t, class.BranchesValid)
+			assert.Equal(t, tc.wantValid, *class.BranchesValid, "branches must not be counted once per identifier scheme")
+			assert.Equal(t, tc.wantCovered, *class.BranchesCovered)
+			require.Len(t, class.Files, 1)
+			for _, line := range class.Files[0].Lines {
+				if line.Number == 42 {
+					assert.Equal(t, tc.wantIDs, branchIdentifiers(line.Branch))
+					assert.Equal(t, tc.wantValid, line.TotalBranches)
+				}
+			}
+		})
+	}
+}
+
+// TestProcessingOrchestrator_SharedFileTotalLines pins the TotalLines semantics for a file
+// shared by two classes: each class counts the whole file, the assembly counts it once.
+func TestProcessingOrchestrator_SharedFileTotalLines(t *testing.T) {
+	sharedFile := filepath.Join(t.TempDir(), "Shared.cs")
+	require.NoError(t, os.WriteFile(sharedFile, []byte(strings.Repeat("// line\n", 10)), 0o644))
+
+	config := newTestConfig(settings.NewSettings())
+	orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+	pkg := PackageXML{
+		Name: "MyAssembly",
+		Classes: ClassesXML{Class: []ClassXML{
+			{Name: "MyNamespace.Foo", Filename: sharedFile, Lines: LinesXML{Line: []LineXML{{Number: "2", Hits: "1", Branch: "false"}}}},
+			{Name: "MyNamespace.Bar", Filename: sharedFile, Lines: LinesXML{Line: []LineXML{{Number: "7", Hits: "0", Branch: "false"}}}},
+		}},
+	}
+
+	assemblies, _, err := orchestrator.processPackages([]PackageXML{pkg})
+
+	require.NoError(t, err)
+	require.Len(t, assemblies, 1)
+	require.Len(t, assemblies[0].Classes, 2)
+	for _, class := range assemblies[0].Classes {
+		assert.Equal(t, 10, class.TotalLines, "class %s counts the shared file", class.Name)
+	}
+	assert.Equal(t, 10, assemblies[0].TotalLines, "assembly counts the shared file once")
+}
+
+func TestMergeTestHits(t *testing.T) {
+	classXML := unmarshalClassXML(t, `
+<class name="Calc" filename="Calc.cs">
+  <methods>
+    <method name="Add" signature="()" line-rate="1" branch-rate="1" complexity="1">
+      <lines>
+        <line number="3" hits="2"><tests><test name="CalcTests.Add" hits="2"/></tests></line>
+      </lines>
+    </method>
+  </methods>
+  <lines>
+    <line number="3" hits="3"><tests><test name="CalcTests.Add" hits="1"/><test name="CalcTests.All" hits="2"/></tests></line>
+    <line number="4" hits="0"/>
+  </lines>
+</class>`)
+
+	testHits := mergeTestHits([]ClassXML{classXML})
+
+	assert.Equal(t, map[int]map[string]int{3: {"CalcTests.Add": 2, "CalcTests.All": 2}}, testHits, "the method and the class list the same visits")
+	assert.Empty(t, mergeTestHits([]ClassXML{unmarshalClassXML(t, coverletClassFragment)}), "reports without <tests> have no per-test data")
+}
+
+func TestProcessMethodsForFile_CodeElementsCarryRawKey(t *testing.T) {
+	config := newTestConfig(settings.NewSettings())
+	orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, nil, config.Logger())
+	classModel := &model.Class{Name: "MyNamespace.Program", DisplayName: "MyNamespace.Program"}
+	lines := func(hits string) LinesXML {
+		return LinesXML{Line: []LineXML{{Number: "10", Hits: hits, Branch: "false"}}}
+	}
+	fragments := []ClassXML{{Methods: MethodsXML{Method: []MethodXML{
+		{Name: "<Main>g__Helper|0_0", Signature: "(System.Int32)", LineRate: "1", Lines: lines("1")},
+		{Name: "<Main>g__Helper|0_1", Signature: "(System.String)", LineRate: "0", Lines: lines("0")},
+	}}}}
+
+	methods, codeElements, err := orchestrator.processMethodsForFile(fragments, classModel, csharp.NewCSharpProcessor(), nil)
+
+	require.NoError(t, err)
+	require.Len(t, methods, 2)
+	require.Len(t, codeElements, 2)
+	assert.Equal(t, codeElements[0].FullName, codeElements[1].FullName, "both overloads are cleaned to the same name")
+	rawKeys := map[string]float64{}
+	for _, ce := range codeElements {
+		require.NotNil(t, ce.CoverageQuota)
+		rawKeys[ce.RawKey] = *ce.CoverageQuota
+	}
+	assert.Equal(t, map[string]float64{
+		"<Main>g__Helper|0_0(System.Int32)":  100,
+		"<Main>g__Helper|0_1(System.String)": 0,
+	}, rawKeys)
+}
+
+// memoryFileReader serves source files from memory. Paths are absolute with forward slashes.
+type memoryFileReader struct {
+	files    fstest.MapFS
+	foldCase bool // Looks up paths case-insensitively, like a Windows file system
+}
+
+func newMemoryFileReader(files map[string]string) *memoryFileReader {
+	r := &memoryFileReader{files: fstest.MapFS{}}
+	for path, content := range files {
+		r.files[strings.TrimPrefix(path, "/")] = &fstest.MapFile{Data: []byte(content)}
+	}
+	return r
+}
+
+func (r *memoryFileReader) name(path string) string {
+	name := strings.TrimPrefix(path, "/")
+	if r.foldCase {
+		for candidate := range r.files {
+			if strings.EqualFold(candidate, name) {
+				return candidate
+			}
+		}
+	}
+	return name
+}
+
+func (r *memoryFileReader) ReadFile(path string) ([]string, error) {
+	content, err := fs.ReadFile(r.files, r.name(path))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n"), nil
+}
+
+func (r *memoryFileReader) CountLines(path string) (int, error) {
+	lines, err := r.ReadFile(path)
+	return len(lines), err
+}
+
+func (r *memoryFileReader) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(r.files, r.name(name))
+}
+
+// gcovrClass is a class as written by gcovr: all coverage is in <lines>, <methods> is empty.
+const gcovrClass = `
+<class name="calc_c" filename="/src/calc.c" line-rate="0.6" branch-rate="0.5" complexity="0.0">
+  <methods/>
+  <lines>
+    <line number="3" hits="4" branch="false"/>
+    <line number="4" hits="4" branch="false"/>
+    <line number="7" hits="2" branch="true" condition-coverage="50% (1/2)"/>
+    <line number="8" hits="2" branch="false"/>
+    <line number="10" hits="0" branch="false"/>
+  </lines>
+</class>`
+
+const gcovrSource = `#include "calc.h"
+
+int add(int a, int b) {
+    return a + b;
+}
+
+int clamp(int value) {
+    if (value < 0) { /* { in a comment */
+        return 0;
+    }
+    return value;
+}
+
+static const int table[] = { 1, 2 };
+`
+
+func processGcovrClass(t *testing.T, files map[string]string, classXML string) model.Class {
+	t.Helper()
+	config := newTestConfig(settings.NewSettings())
+	orchestrator := newProcessingOrchestrator(newMemoryFileReader(files), config, nil, config.Logger())
+	pkg := PackageXML{Name: "calc", Classes: ClassesXML{Class: []ClassXML{unmarshalClassXML(t, classXML)}}}
+
+	assemblies, _, err := orchestrator.processPackages([]PackageXML{pkg})
+
+	require.NoError(t, err)
+	require.Len(t, assemblies, 1)
+	require.Len(t, assemblies[0].Classes, 1)
+	return assemblies[0].Classes[0]
+}
+
+func TestProcessClassGroup_SynthesizesMethodsFromSource(t *testing.T) {
+	class := processGcovrClass(t, map[string]string{"/src/calc.c": gcovrSource}, gcovrClass)
+
+	require.Len(t, class.Methods, 2)
+	add, clamp := class.Methods[0], class.Methods[1]
+	assert.Equal(t, "add(int a, int b)", add.DisplayName)
+	assert.Equal(t, 3, add.FirstLine)
+	assert.Equal(t, 5, add.LastLine, "the range ends at the closing brace")
+	assert.Equal(t, 1.0, add.LineRate)
+	assert.Equal(t, "clamp(int value)", clamp.DisplayName)
+	assert.Equal(t, 7, clamp.FirstLine)
+	assert.Equal(t, 12, clamp.LastLine)
+	assert.InDelta(t, 2.0/3.0, clamp.LineRate, 1e-9)
+	require.NotNil(t, clamp.BranchRate)
+	assert.Equal(t, 0.5, *clamp.BranchRate)
+
+	assert.Equal(t, 2, class.TotalMethods)
+	assert.Equal(t, 2, class.CoveredMethods)
+	assert.Equal(t, 1, class.FullyCoveredMethods)
+
+	require.Len(t, class.Files, 1)
+	codeElements := class.Files[0].CodeElements
+	require.Len(t, codeElements, 2)
+	assert.Equal(t, "clamp(int value)", codeElements[1].FullName)
+	assert.Equal(t, 7, codeElements[1].FirstLine)
+	require.NotNil(t, codeElements[1].CoverageQuota)
+	assert.InDelta(t, 200.0/3.0, *codeElements[1].CoverageQuota, 1e-9)
+}
+
+func TestProcessClassGroup_SynthesizesPythonAndGoMethods(t *testing.T) {
+	t.Run("Python", func(t *testing.T) {
+		source := "import os\n\nclass Greeter:\n    def greet(self, name):\n        return f\"Hi {name}\"\n\n    def unused(self):\n        pass\n\ndef main():\n    def inner():\n        return 1\n    return inner()\n"
+		classXML := `<class name="greeter.py" filename="/app/greeter.py"><methods/><lines>
+  <line number="1" hits="1"/><line number="3" hits="1"/><line number="4" hits="1"/><line number="5" hits="1"/>
+  <line number="7" hits="1"/><line number="8" hits="0"/>
+  <line number="10" hits="1"/><line number="11" hits="1"/><line number="12" hits="0"/><line number="13" hits="0"/>
+</lines></class>`
+
+		class := processGcovrClass(t, map[string]string{"/app/greeter.py": source}, classXML)
+
+		var names []string
+		for _, method := range class.Methods {
+			names = append(names, method.DisplayName)
+		}
+		assert.Equal(t, []string{"Greeter.greet(self, name)", "Greeter.unused(self)", "main()"}, names)
+		assert.Equal(t, 13, class.Methods[2].LastLine, "the nested function belongs to main")
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		source := "package stack\n\ntype Stack struct{ items []int }\n\nfunc (s *Stack) Push(v int) {\n\ts.items = append(s.items, v)\n}\n\nfunc New() *Stack {\n\treturn &Stack{}\n}\n"
+		classXML := `<class name="stack" filename="/go/stack/stack.go"><methods/><lines>
+  <line number="6" hits="3"/><line number="10" hits="0"/>
+</lines></class>`
+
+		class := processGcovrClass(t, map[string]string{"/go/stack/stack.go": source}, classXML)
+
+		require.Len(t, class.Methods, 2)
+		assert.Equal(t, "(*Stack).Push", class.Methods[0].DisplayName)
+		assert.Equal(t, 6, class.Methods[0].FirstLine, "methods start at their first coverable line")
+		assert.Equal(t, "New", class.Methods[1].DisplayName)
+		assert.Equal(t, 1, class.CoveredMethods)
+	})
+}
+
+func TestProcessClassGroup_WithoutSourceKeepsNoMethods(t *testing.T) {
+	class := processGcovrClass(t, nil, gcovrClass)
+
+	assert.Empty(t, class.Methods)
+	assert.Zero(t, class.TotalMethods)
+	require.Len(t, class.Files, 1)
+	assert.Empty(t, class.Files[0].CodeElements)
+	assert.Equal(t, 4, class.LinesCovered, "line coverage is reported as before")
+}
+
+const csharpSource = `namespace Shop
+{
+    public class Cart
+    {
+        public int Total(int[] prices)
+        {
+            var total = 0;
+            foreach (var price in prices)
+            {
+                total += price;
+            }
+            if (total > 100)
+            {
+                total -= 10;
+            }
+            return total;
+        }
+
+        public void Clear() => items.Clear();
+    }
+}
+`
+
+// csharpClass lists the lines of Total up to the "if": its last three lines, and the
+// closing brace of the "if" block, have no coverage data.
+const csharpClass = `
+<class name="Shop.Cart" filename="/src/Cart.cs">
+  <methods>
+    <method name="Total" signature="(System.Int32[])">
+      <lines>
+        <line number="6" hits="1"/><line number="7" hits="1"/><line number="8" hits="3"/>
+        <line number="10" hits="2"/><line number="12" hits="1"/>
+      </lines>
+    </method>
+    <method name="Clear" signature="()">
+      <lines><line number="19" hits="0"/></lines>
+    </method>
+  </methods>
+  <lines>
+    <line number="6" hits="1"/><line number="7" hits="1"/><line number="8" hits="3"/>
+    <line number="10" hits="2"/><line number="12" hits="1"/><line number="19" hits="0"/>
+  </lines>
+</class>`
+
+func TestProcessClassGroup_ExtendsMethodRangesToTheEndInTheSource(t *testing.T) {
+	class := processGcovrClass(t, map[string]string{"/src/Cart.cs": csharpSource}, csharpClass)
+
+	require.Len(t, class.Methods, 2)
+	total, clearMethod := class.Methods[0], class.Methods[1]
+	assert.Equal(t, "Total(System.Int32[])", total.DisplayName)
+	assert.Equal(t, 6, total.FirstLine)
+	assert.Equal(t, 17, total.LastLine, "the uncovered tail of the method is part of its range")
+	assert.Equal(t, 19, clearMethod.FirstLine)
+	assert.Equal(t, 19, clearMethod.LastLine, "expression-bodied members keep the range from the report")
+
+	require.Len(t, class.Files, 1)
+	codeElements := class.Files[0].CodeElements
+	require.Len(t, codeElements, 2)
+	assert.Equal(t, 6, codeElements[0].FirstLine)
+	assert.Equal(t, 17, codeElements[0].LastLine)
+	assert.Equal(t, 19, codeElements[1].LastLine)
+}
+
+func TestProcessClassGroup_WithoutSourceKeepsMethodRanges(t *testing.T) {
+	class := processGcovrClass(t, nil, csharpClass)
+
+	require.Len(t, class.Methods, 2)
+	assert.Equal(t, 12, class.Methods[0].LastLine)
+	assert.Equal(t, 12, class.Files[0].CodeElements[0].LastLine)
+}
+
+func TestProcessingOrchestrator_PathsDifferingInCaseAreOneFile(t *testing.T) {
+	appSettings := settings.NewSettings()
+	appSettings.PathCase = "insensitive"
+	reader := newMemoryFileReader(map[string]string{"/Work/src/Cart.cs": strings.Repeat("// line\n", 10)})
+	reader.foldCase = true
+	config := newTestConfig(appSettings)
+	orchestrator := newProcessingOrchestrator(reader, config, nil, config.Logger())
+	line := func(number, hits string) LinesXML {
+		return LinesXML{Line: []LineXML{{Number: number, Hits: hits, Branch: "false"}}}
+	}
+	pkg := PackageXML{
+		Name: "Shop",
+		Classes: ClassesXML{Class: []ClassXML{
+			{Name: "Shop.Cart", Filename: "/Work/src/Cart.cs", Lines: line("2", "1")},
+			{Name: "Shop.Cart", Filename: "/work/SRC/cart.cs", Lines: line("3", "0")},
+			{Name: "Shop.Order", Filename: "/WORK/src/cart.cs", Lines: line("7", "1")},
+		}},
+	}
+
+	assemblies, _, err := orchestrator.processPackages([]PackageXML{pkg})
+
+	require.NoError(t, err)
+	require.Len(t, assemblies, 1)
+	require.Len(t, assemblies[0].Classes, 2)
+	cart := assemblies[0].Classes[0]
+	require.Len(t, cart.Files, 1, "both spellings of the file are merged")
+	assert.Equal(t, 1, cart.LinesCovered)
+	assert.Equal(t, 2, cart.LinesValid)
+	assert.Equal(t, 10, cart.TotalLines)
+	assert.Equal(t, 10, assemblies[0].TotalLines, "the file is counted once for the assembly")
+}
+
+func TestProcessingOrchestrator_MethodFiltersExcludeMethodsButKeepTheirLines(t *testing.T) {
+	source := "class Cart\n{\n    string Name { get; set; }\n\n    public override bool Equals(object o) => false;\n\n    void Add() { }\n}\n"
+	reader := newMemoryFileReader(map[string]string{"/src/Cart.cs": source})
+	config := newTestConfig(settings.NewSettings())
+	methodFilter, err := filtering.NewDefaultFilter([]string{"-get_*", "-set_*", "-*.Equals(*)"})
+	require.NoError(t, err)
+	config.methodFilter = methodFilter
+	orchestrator := newProcessingOrchestrator(reader, config, nil, config.Logger())
+	method := func(name, signature, number, hits string) MethodXML {
+		return MethodXML{Name: name, Signature: signature, Complexity: "1",
+			Lines: LinesXML{Line: []LineXML{{Number: number, Hits: hits, Branch: "false"}}}}
+	}
+	pkg := PackageXML{
+		Name: "Shop",
+		Classes: ClassesXML{Class: []ClassXML{{
+			Name:     "Shop.Cart",
+			Filename: "/src/Cart.cs",
+			Methods: MethodsXML{Method: []MethodXML{
+				method("get_Name", "()", "3", "1"),
+				method("set_Name", "(System.String)", "3", "1"),
+				method("Equals", "(System.Object)", "5", "0"),
+				method("Add", "()", "7", "0"),
+			}},
+			Lines: LinesXML{Line: []LineXML{
+				{Number: "3", Hits: "1", Branch: "false"},
+				{Number: "5", Hits: "0", Branch: "false"},
+				{Number: "7", Hits: "0", Branch: "false"},
+			}},
+		}}},
+	}
+
+	assemblies, _, err := orchestrator.processPackages([]PackageXML{pkg})
+
+	require.NoError(t, err)
+	require.Len(t, assemblies, 1)
+	require.Len(t, assemblies[0].Classes, 1)
+	class := assemblies[0].Classes[0]
+	require.Len(t, class.Methods, 1)
+	assert.Equal(t, "Add()", class.Methods[0].DisplayName)
+	assert.Equal(t, 1, class.TotalMethods)
+	assert.Zero(t, class.CoveredMethods)
+	assert.Zero(t, class.FullyCoveredMethods)
+
+	require.Len(t, class.Files, 1)
+	file := class.Files[0]
+	require.Len(t, file.CodeElements, 1)
+	assert.Equal(t, "Add()", file.CodeElements[0].FullName)
+	for _, metric := range file.MethodMetrics {
+		assert.Equal(t, 7, metric.Line, "only the metrics of Add() remain")
+	}
+	assert.Equal(t, 1, class.LinesCovered, "the lines of filtered methods keep counting")
+	assert.Equal(t, 3, class.LinesValid)
+}
+
+func TestProcessingOrchestrator_StoreSourcesKeepsTheWholeFile(t *testing.T) {
+	sourceDir := t.TempDir()
+	source := "namespace MyNamespace\n{\n  class Foo\n  {\n    void Bar() { }\n\n    void Baz() { }\n  }\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "Foo.cs"), []byte(source), 0o644))
+
+	fooFile := func(storeSources bool) model.CodeFile {
+		appSettings := settings.NewSettings()
+		appSettings.StoreSources = storeSources
+		config := newTestConfig(appSettings)
+		orchestrator := newProcessingOrchestrator(&DefaultFileReader{}, config, []string{sourceDir}, config.Logger())
+		assemblies, _, err := orchestrator.processPackages([]PackageXML{nestedClassesPackage()})
+		require.NoError(t, err)
+		require.Len(t, assemblies, 1)
+		require.Len(t, assemblies[0].Classes[0].Files, 1)
+		return assemblies[0].Classes[0].Files[0]
+	}
+
+	withoutStoredSources := fooFile(false)
+	assert.Len(t, withoutStoredSources.Lines, 7, "lines up to the last line with coverage data")
+	_, ok := withoutStoredSources.SourceLines()
+	assert.False(t, ok)
+
+	file := fooFile(true)
+	lines, ok := file.SourceLines()
+	require.True(t, ok)
+	assert.Equal(t, strings.Split(strings.TrimSuffix(source, "\n"), "\n"), lines)
+	assert.Equal(t, 2, file.CoverableLines, "the stored lines are not coverable")
+}
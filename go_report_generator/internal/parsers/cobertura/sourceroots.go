@@ -0,0 +1,59 @@
+package cobertura
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// sourceResolutionStats counts under which root the source files of a report were found.
+type sourceResolutionStats struct {
+	byRoot     map[string]int
+	elsewhere  int // Absolute paths and files found by the source file index
+	unresolved int
+	seen       map[string]struct{} // Report paths counted, keyed by keyOf
+	keyOf      func(string) string
+}
+
+func newSourceResolutionStats(keyOf func(string) string) sourceResolutionStats {
+	return sourceResolutionStats{byRoot: make(map[string]int), seen: make(map[string]struct{}), keyOf: keyOf}
+}
+
+// record counts the resolution of reportPath once, attributing it to the first of the
+// roots that contains resolvedPath.
+func (s *sourceResolutionStats) record(reportPath, resolvedPath string, err error, roots []string) {
+	key := s.keyOf(reportPath)
+	if _, ok := s.seen[key]; ok {
+		return
+	}
+	s.seen[key] = struct{}{}
+	if err != nil {
+		s.unresolved++
+		return
+	}
+	for _, root := range roots {
+		if rel, err := filepath.Rel(filepath.Clean(root), resolvedPath); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			s.byRoot[root]++
+			return
+		}
+	}
+	s.elsewhere++
+}
+
+// logSourceResolution logs how many source files of the report were found under each
+// root, in the order the roots are probed, and how many were not found.
+func (o *processingOrchestrator) logSourceResolution() {
+	s := o.sourceResolution
+	if len(s.seen) == 0 {
+		return
+	}
+	roots := make([]string, 0, len(o.sourceDirs))
+	for _, root := range o.sourceDirs {
+		roots = append(roots, fmt.Sprintf("%s (%d)", root, s.byRoot[root]))
+	}
+	attrs := []any{"roots", strings.Join(roots, ", "), "unresolved", s.unresolved}
+	if s.elsewhere > 0 {
+		attrs = append(attrs, "outsideRoots", s.elsewhere)
+	}
+	o.logger.Info("Resolved source files", attrs...)
+}
@@ -0,0 +1,93 @@
+package parsers
+
+import (
+	"log/slog"
+	"math"
+)
+
+// DeclaredTotals holds the totals a report states about itself, e.g. the attributes of
+// the Cobertura <coverage> root element. Nil fields were not present in the report.
+type DeclaredTotals struct {
+	LinesCovered    *int
+	LinesValid      *int
+	BranchesCovered *int
+	BranchesValid   *int
+	LineRate        *float64
+	BranchRate      *float64
+}
+
+// computedTotals sums the line and branch counts of all classes of a ParserResult.
+type computedTotals struct {
+	linesCovered, linesValid       int
+	branchesCovered, branchesValid int
+	hasBranchData                  bool
+}
+
+func computeTotals(result *ParserResult) computedTotals {
+	var totals computedTotals
+	for _, assembly := range result.Assemblies {
+		for _, class := range assembly.Classes {
+			totals.linesCovered += class.LinesCovered
+			totals.linesValid += class.LinesValid
+			if class.BranchesCovered != nil && class.BranchesValid != nil {
+				totals.branchesCovered += *class.BranchesCovered
+				totals.branchesValid += *class.BranchesValid
+				totals.hasBranchData = true
+			}
+		}
+	}
+	return totals
+}
+
+// CheckDeclaredTotals compares the totals declared by a report with the totals computed
+// from its parsed classes and logs a warning with both numbers for every difference
+// above the tolerance. Counts may differ by tolerance times the declared count, rates
+// (0 to 1) by tolerance itself; a declared rate is only checked when the counts it
+// derives from are missing. A negative tolerance disables the check. It returns the
+// number of reported differences.
+func CheckDeclaredTotals(result *ParserResult, tolerance float64, logger *slog.Logger) int {
+	declared := result.DeclaredTotals
+	if declared == nil || tolerance < 0 {
+		return 0
+	}
+	computed := computeTotals(result)
+	mismatches := 0
+
+	checkCount := func(name string, declaredValue *int, computedValue int) {
+		if declaredValue == nil {
+			return
+		}
+		if math.Abs(float64(*declaredValue-computedValue)) > tolerance*float64(*declaredValue) {
+			logger.Warn("Declared report total differs from the parsed coverage data",
+				"total", name, "declared", *declaredValue, "computed", computedValue)
+			mismatches++
+		}
+	}
+	checkRate := func(name string, declaredValue *float64, covered, valid int) {
+		if declaredValue == nil || valid == 0 {
+			return
+		}
+		computedValue := float64(covered) / float64(valid)
+		if math.Abs(*declaredValue-computedValue) > tolerance {
+			logger.Warn("Declared report total differs from the parsed coverage data",
+				"total", name, "declared", *declaredValue, "computed", computedValue)
+			mismatches++
+		}
+	}
+
+	checkCount("lines-covered", declared.LinesCovered, computed.linesCovered)
+	checkCount("lines-valid", declared.LinesValid, computed.linesValid)
+	if declared.LinesCovered == nil || declared.LinesValid == nil {
+		checkRate("line-rate", declared.LineRate, computed.linesCovered, computed.linesValid)
+	}
+
+	// Reports without branch data often declare zero branches, which is consistent.
+	if computed.hasBranchData || (declared.BranchesValid != nil && *declared.BranchesValid > 0) {
+		checkCount("branches-covered", declared.BranchesCovered, computed.branchesCovered)
+		checkCount("branches-valid", declared.BranchesValid, computed.branchesValid)
+		if declared.BranchesCovered == nil || declared.BranchesValid == nil {
+			checkRate("branch-rate", declared.BranchRate, computed.branchesCovered, computed.branchesValid)
+		}
+	}
+	return mismatches
+}
@@ -12,9 +12,20 @@ func NewParserFactory(parsers ...IParser) *ParserFactory {
 	}
 }
 
-func (f *ParserFactory) FindParserForFile(filePath string) (IParser, error) {
+// DetectionHinter is implemented by parsers that can describe the files they accept,
+// e.g. for listing the capabilities of a build.
+type DetectionHinter interface {
+	DetectionHint() string
+}
+
+// Parsers returns the registered parsers in detection order.
+func (f *ParserFactory) Parsers() []IParser {
+	return append([]IParser(nil), f.parsers...)
+}
+
+func (f *ParserFactory) FindParserForFile(filePath string, config ParserConfig) (IParser, error) {
 	for _, p := range f.parsers {
-		if p.SupportsFile(filePath) {
+		if p.SupportsFile(filePath, config) {
 			return p, nil
 		}
 	}
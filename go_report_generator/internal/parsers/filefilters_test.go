@@ -0,0 +1,134 @@
+package parsers_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/defaultformatter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/golang"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers/cobertura"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers/gocover"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goSource = "package util\n\nfunc Upper(s string) string {\n\treturn s\n}\n"
+
+// writeFileFilterFixtures writes a Go module with two files and a Cobertura report with
+// paths relative to the module as well as a Go profile with import paths, both covering
+// the two files.
+func writeFileFilterFixtures(t *testing.T) (moduleDir, coberturaPath, profilePath string) {
+	t.Helper()
+	moduleDir = t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod":                   "module example.com/mod\n",
+		"internal/util/strings.go": goSource,
+		"internal/legacy/old.go":   goSource,
+	} {
+		fullPath := filepath.Join(moduleDir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+
+	reportDir := t.TempDir()
+	coberturaPath = filepath.Join(reportDir, "coverage.xml")
+	require.NoError(t, os.WriteFile(coberturaPath, []byte(`<?xml version="1.0"?>
+<coverage line-rate="0.5" branch-rate="0" timestamp="1700000000" version="1.9">
+  <sources><source>`+moduleDir+`</source></sources>
+  <packages>
+    <package name="example.com/mod" line-rate="0.5" branch-rate="0">
+      <classes>
+        <class name="example.com/mod/internal/util" filename="internal/util/strings.go" line-rate="1" branch-rate="0">
+          <methods />
+          <lines><line number="4" hits="1" branch="false" /></lines>
+        </class>
+        <class name="example.com/mod/internal/legacy" filename="internal/legacy/old.go" line-rate="0" branch-rate="0">
+          <methods />
+          <lines><line number="4" hits="0" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`), 0o644))
+
+	profilePath = filepath.Join(reportDir, "coverage.out")
+	require.NoError(t, os.WriteFile(profilePath, []byte("mode: set\n"+
+		"example.com/mod/internal/util/strings.go:3.29,5.2 1 1\n"+
+		"example.com/mod/internal/legacy/old.go:3.29,5.2 1 0\n"), 0o644))
+	return moduleDir, coberturaPath, profilePath
+}
+
+func parsedFiles(t *testing.T, parser parsers.IParser, reportPath, moduleDir string, fileFilters []string, logs *bytes.Buffer) []string {
+	t.Helper()
+	config, err := reportconfig.NewReportConfiguration(nil, t.TempDir(),
+		reportconfig.WithSourceDirectories([]string{moduleDir}),
+		reportconfig.WithFilters(nil, nil, fileFilters, nil, nil),
+		reportconfig.WithLogger(slog.New(slog.NewTextHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		reportconfig.WithLanguageProcessorFactory(language.NewProcessorFactory(defaultformatter.NewDefaultProcessor(), golang.NewGoProcessor())),
+	)
+	require.NoError(t, err)
+
+	result, err := parser.Parse(reportPath, config)
+	require.NoError(t, err)
+
+	var files []string
+	for _, assembly := range result.Assemblies {
+		for _, class := range assembly.Classes {
+			for _, file := range class.Files {
+				rel, err := filepath.Rel(moduleDir, file.Path)
+				require.NoError(t, err)
+				files = append(files, filepath.ToSlash(rel))
+			}
+		}
+	}
+	return files
+}
+
+// TestFileFilters_SameFilterForCoberturaAndGoCover expects a file filter to exclude the
+// same file from a Cobertura report with module relative paths and from a Go profile
+// with import paths.
+func TestFileFilters_SameFilterForCoberturaAndGoCover(t *testing.T) {
+	moduleDir, coberturaPath, profilePath := writeFileFilterFixtures(t)
+	fileReader := filereader.NewDefaultReader()
+	reports := map[string]struct {
+		parser parsers.IParser
+		path   string
+	}{
+		"Cobertura": {cobertura.NewCoberturaParser(fileReader), coberturaPath},
+		"GoCover":   {gocover.NewGoCoverParser(fileReader), profilePath},
+	}
+
+	for _, filter := range []string{
+		"-internal/legacy/*", // Report path (Cobertura) and module path (Go)
+		"-*/legacy/old.go",   // Any of the paths
+		"-" + filepath.Join(moduleDir, "internal", "legacy", "*"), // Resolved path
+		"+internal/util/*",
+	} {
+		for name, report := range reports {
+			t.Run(name+filter, func(t *testing.T) {
+				var logs bytes.Buffer
+
+				files := parsedFiles(t, report.parser, report.path, moduleDir, []string{filter}, &logs)
+
+				assert.Equal(t, []string{"internal/util/strings.go"}, files)
+				assert.Contains(t, logs.String(), `msg="Excluded files by file filters" parser=`+name)
+				assert.Contains(t, logs.String(), "count=1")
+				assert.Contains(t, logs.String(), "old.go")
+			})
+		}
+	}
+
+	for name, report := range reports {
+		var logs bytes.Buffer
+		files := parsedFiles(t, report.parser, report.path, moduleDir, nil, &logs)
+		assert.Len(t, files, 2, name)
+		assert.NotContains(t, logs.String(), "Excluded files by file filters", name)
+	}
+}
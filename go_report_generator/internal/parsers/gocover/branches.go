@@ -0,0 +1,187 @@
+package gocover
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+)
+
+// lineBranches holds the approximated branches of the decisions on one source line.
+type lineBranches struct {
+	covered int
+	total   int
+}
+
+// blockPosition is a line/column position as used by the cover profile.
+type blockPosition struct {
+	line, col int
+}
+
+func (p blockPosition) before(other blockPosition) bool {
+	return p.line < other.line || (p.line == other.line && p.col < other.col)
+}
+
+// decisionArm is a part of a decision statement of which at most one runs per execution.
+type decisionArm struct {
+	start, end blockPosition
+}
+
+// branchApproximator derives pseudo branch points from the if, switch and select statements
+// of a Go file. Cover profiles only record how often each block ran, so every arm of a
+// decision counts as one branch, covered if a block inside it ran. An if without else and
+// a switch without default get an implicit arm, which counts as covered if the decision ran
+// more often than its explicit arms. In "set" mode the hit counts are 0 or 1, so implicit
+// arms are only recognized when no explicit arm ran.
+type branchApproximator struct {
+	fset   *token.FileSet
+	blocks []GoCoverProfileBlock // Sorted by start position
+}
+
+func newBranchApproximator(fset *token.FileSet, blocks []GoCoverProfileBlock) *branchApproximator {
+	sorted := make([]GoCoverProfileBlock, len(blocks))
+	copy(sorted, blocks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return blockStart(sorted[i]).before(blockStart(sorted[j]))
+	})
+	return &branchApproximator{fset: fset, blocks: sorted}
+}
+
+// approximateBranches returns the branches per line number of the decisions in file.
+func (a *branchApproximator) approximateBranches(file *ast.File) map[int]lineBranches {
+	result := make(map[int]lineBranches)
+	ast.Inspect(file, func(n ast.Node) bool {
+		var arms []decisionArm
+		hasImplicitArm := false
+
+		switch stmt := n.(type) {
+		case *ast.IfStmt:
+			arms = append(arms, a.arm(stmt.Body.Lbrace, stmt.Body.Rbrace))
+			if stmt.Else != nil {
+				arms = append(arms, a.arm(stmt.Else.Pos(), stmt.Else.End()))
+			} else {
+				hasImplicitArm = true
+			}
+		case *ast.SwitchStmt:
+			arms, hasImplicitArm = a.clauseArms(stmt.Body, true)
+		case *ast.TypeSwitchStmt:
+			arms, hasImplicitArm = a.clauseArms(stmt.Body, true)
+		case *ast.SelectStmt:
+			arms, _ = a.clauseArms(stmt.Body, false)
+		default:
+			return true
+		}
+
+		decisionHits, ok := a.hitsOfEnclosingBlock(a.position(n.Pos()))
+		if !ok {
+			return true
+		}
+
+		covered, total, explicitHits := 0, 0, 0
+		for _, arm := range arms {
+			hits, ran, found := a.armHits(arm)
+			if !found {
+				continue // Empty arms have no block, their coverage is unknown
+			}
+			total++
+			explicitHits += hits
+			if ran {
+				covered++
+			}
+		}
+		if hasImplicitArm {
+			total++
+			if decisionHits > explicitHits {
+				covered++
+			}
+		}
+		if total < 2 {
+			return true
+		}
+
+		line := a.position(n.Pos()).line
+		branches := result[line]
+		branches.covered += covered
+		branches.total += total
+		result[line] = branches
+		return true
+	})
+	return result
+}
+
+// clauseArms returns one arm per case clause. hasImplicitArm is true if a switch has no
+// default clause.
+func (a *branchApproximator) clauseArms(body *ast.BlockStmt, implicitDefault bool) (arms []decisionArm, hasImplicitArm bool) {
+	hasDefault := false
+	for _, stmt := range body.List {
+		switch clause := stmt.(type) {
+		case *ast.CaseClause:
+			if clause.List == nil {
+				hasDefault = true
+			}
+			arms = append(arms, a.arm(clause.Colon, clause.End()))
+		case *ast.CommClause:
+			arms = append(arms, a.arm(clause.Colon, clause.End()))
+		}
+	}
+	return arms, implicitDefault && !hasDefault
+}
+
+func (a *branchApproximator) arm(start, end token.Pos) decisionArm {
+	return decisionArm{start: a.position(start), end: a.position(end)}
+}
+
+func (a *branchApproximator) position(pos token.Pos) blockPosition {
+	p := a.fset.Position(pos)
+	return blockPosition{line: p.Line, col: p.Column}
+}
+
+// armHits returns the hits of the first block of the arm and whether any block inside the
+// arm ran. found is false if the arm contains no block.
+func (a *branchApproximator) armHits(arm decisionArm) (hits int, ran, found bool) {
+	for _, block := range a.blocks {
+		start := blockStart(block)
+		if start.before(arm.start) {
+			continue
+		}
+		if arm.end.before(start) {
+			break
+		}
+		if !found {
+			hits = block.HitCount
+			found = true
+		}
+		if block.HitCount > 0 {
+			ran = true
+		}
+	}
+	return hits, ran, found
+}
+
+// hitsOfEnclosingBlock returns the hits of the smallest block containing pos.
+func (a *branchApproximator) hitsOfEnclosingBlock(pos blockPosition) (int, bool) {
+	var enclosing *GoCoverProfileBlock
+	for i := range a.blocks {
+		block := &a.blocks[i]
+		if pos.before(blockStart(*block)) {
+			break
+		}
+		if blockEnd(*block).before(pos) {
+			continue
+		}
+		if enclosing == nil || !blockStart(*block).before(blockStart(*enclosing)) {
+			enclosing = block
+		}
+	}
+	if enclosing == nil {
+		return 0, false
+	}
+	return enclosing.HitCount, true
+}
+
+func blockStart(b GoCoverProfileBlock) blockPosition {
+	return blockPosition{line: b.StartLine, col: b.StartCol}
+}
+
+func blockEnd(b GoCoverProfileBlock) blockPosition {
+	return blockPosition{line: b.EndLine, col: b.EndCol}
+}
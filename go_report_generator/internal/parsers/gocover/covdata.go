@@ -0,0 +1,56 @@
+package gocover
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// covmetaPrefix starts the names of the meta-data files that binaries built with
+// "go build -cover" write to GOCOVERDIR, next to their covcounters.* files.
+const covmetaPrefix = "covmeta."
+
+// IsCoverageDirectory reports whether path is a GOCOVERDIR directory, i.e. a directory
+// holding the binary coverage data files of Go 1.20+ binaries built with -cover.
+func IsCoverageDirectory(path string) bool {
+	entries, err := os.ReadDir(utils.LongPath(path))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), covmetaPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertCoverageDirectory converts the binary coverage data in dir to a text profile in
+// tempDir with "go tool covdata textfmt" and returns the path of the profile.
+func (p *GoCoverParser) convertCoverageDirectory(dir, tempDir string, logger *slog.Logger) (string, error) {
+	goTool, err := p.lookPath("go")
+	if err != nil {
+		return "", fmt.Errorf("cannot convert the binary coverage data in %s: the go toolchain was not found in PATH. "+
+			"Install Go or convert the data yourself with \"go tool covdata textfmt -i=%s -o=coverage.out\" and pass the profile with -reports", dir, dir)
+	}
+
+	profilePath := filepath.Join(tempDir, "coverage.out")
+	var stderr bytes.Buffer
+	cmd := exec.Command(goTool, "tool", "covdata", "textfmt", "-i="+dir, "-o="+profilePath)
+	cmd.Stderr = &stderr
+
+	logger.Debug("Converting binary coverage data", "go", goTool, "output", profilePath)
+	if err := cmd.Run(); err != nil {
+		if text := strings.TrimSpace(stderr.String()); text != "" {
+			return "", fmt.Errorf("go tool covdata failed to convert %s: %w\n%s", dir, err, text)
+		}
+		return "", fmt.Errorf("go tool covdata failed to convert %s: %w", dir, err)
+	}
+	return profilePath, nil
+}
@@ -7,42 +7,50 @@ import (
 	"io/fs"
 	"log/slog"
 	"os"
+	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
 var (
 	// Regex to parse a Go coverage line, e.g., "file.go:1.2,3.4 5 6"
 	goCoverLineRegex = regexp.MustCompile(`^(.+):(\d+)\.(\d+),(\d+)\.(\d+)\s(\d+)\s(\d+)$`)
+
+	// Version suffix of a module in an import path, e.g. "@v1.2.3" or "@v0.0.0-20240102-abcdef".
+	moduleVersionRegex = regexp.MustCompile(`@v\d[^/]*`)
 )
 
 // GoCoverParser implements the parsers.IParserinterface for Go coverage reports.
 type GoCoverParser struct {
 	fileReader filereader.Reader // Injected dependency
+	// lookPath finds the go toolchain for GOCOVERDIR directories, exec.LookPath outside of tests.
+	lookPath func(file string) (string, error)
 }
 
 type DefaultFileReader struct{}
 
 func (dfr *DefaultFileReader) ReadFile(path string) ([]string, error) {
-	return filereader.ReadLinesInFile(path)
+	return filereader.ReadLinesInFile(path, nil)
 }
 
 func (dfr *DefaultFileReader) CountLines(path string) (int, error) {
-	return filereader.CountLinesInFile(path)
+	return filereader.CountLinesInFile(path, nil)
 }
 
 func (dfr *DefaultFileReader) Stat(name string) (fs.FileInfo, error) {
-	return os.Stat(name)
+	return os.Stat(utils.LongPath(name))
 }
 
 // NewGoCoverParser creates a new parser instance.
 func NewGoCoverParser(fileReader filereader.Reader) parsers.IParser {
 	return &GoCoverParser{
 		fileReader: fileReader,
+		lookPath:   exec.LookPath,
 	}
 }
 
@@ -51,9 +59,17 @@ func (p *GoCoverParser) Name() string {
 	return "GoCover"
 }
 
+// DetectionHint describes the files SupportsFile accepts.
+func (p *GoCoverParser) DetectionHint() string {
+	return "text profile (optionally gzipped) starting with \"mode:\", or a GOCOVERDIR directory converted with go tool covdata"
+}
+
 // SupportsFile performs a fast check to see if this parser can handle the file.
-func (p *GoCoverParser) SupportsFile(filePath string) bool {
-	f, err := os.Open(filePath)
+func (p *GoCoverParser) SupportsFile(filePath string, config parsers.ParserConfig) bool {
+	if IsCoverageDirectory(filePath) {
+		return true
+	}
+	f, err := filereader.OpenReport(filePath, parsers.FileRetryPolicy(config))
 	if err != nil {
 		return false
 	}
@@ -73,7 +89,19 @@ func (p *GoCoverParser) SupportsFile(filePath string) bool {
 func (p *GoCoverParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
 	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
 
-	profileBlocks, err := p.loadAndParseGoCoverFile(filePath)
+	profilePath := filePath
+	if IsCoverageDirectory(filePath) {
+		tempDir, err := os.MkdirTemp("", "reportgenerator-covdata-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create a directory for the converted profile: %w", err)
+		}
+		defer os.RemoveAll(tempDir)
+		if profilePath, err = p.convertCoverageDirectory(filePath, tempDir, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	profileBlocks, err := p.loadAndParseGoCoverFile(profilePath, parsers.FileRetryPolicy(config))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load/parse Go coverage file from %s: %w", filePath, err)
 	}
@@ -84,61 +112,103 @@ func (p *GoCoverParser) Parse(filePath string, config parsers.ParserConfig) (*pa
 	if err != nil {
 		return nil, fmt.Errorf("failed to process Go coverage blocks: %w", err)
 	}
+	if excluded := orchestrator.excludedGeneratedFiles(); excluded > 0 {
+		logger.Info("Excluded generated code files", "count", excluded)
+	}
+	parsers.LogFilteredFiles(logger, orchestrator.filteredFiles)
+	parsers.LogExternalFiles(logger, orchestrator.externalFiles)
 
 	return &parsers.ParserResult{
-		Assemblies:             assemblies,
-		SourceDirectories:      []string{}, // Go cover files don't list source directories
-		SupportsBranchCoverage: false,
-		ParserName:             p.Name(),
-		MinimumTimeStamp:       nil,
-		MaximumTimeStamp:       nil,
+		Assemblies:              assemblies,
+		SourceDirectories:       []string{}, // Go cover files don't list source directories
+		SupportsBranchCoverage:  config.Settings().GoApproximateBranchCoverage,
+		MethodCoverageAvailable: true, // Functions are detected in the Go sources
+		ParserName:              p.Name(),
+		MinimumTimeStamp:        nil,
+		MaximumTimeStamp:        nil,
+		MissingSourceFiles:      orchestrator.missingSourceFiles,
 	}, nil
 }
 
 // loadAndParseGoCoverFile reads the specified file line-by-line and parses each
-// valid coverage data line into a GoCoverProfileBlock.
-func (p *GoCoverParser) loadAndParseGoCoverFile(path string) ([]GoCoverProfileBlock, error) {
-	file, err := os.Open(path)
+// valid coverage data line into a GoCoverProfileBlock. Profiles that were concatenated,
+// e.g. those of unit and integration tests, repeat the "mode:" line; the modes must
+// agree, and the blocks listed several times are merged like "go tool cover" does. The
+// file is opened with the retry policy.
+func (p *GoCoverParser) loadAndParseGoCoverFile(path string, retry utils.FileRetryPolicy) ([]GoCoverProfileBlock, error) {
+	file, err := filereader.OpenReport(path, retry)
 	if err != nil {
 		return nil, fmt.Errorf("open file: %w", err)
 	}
 	defer file.Close()
 
 	var blocks []GoCoverProfileBlock
+	blockIndexes := make(map[GoCoverProfileBlock]int) // Keyed by the block without its counts
+	mode := ""
 	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if value, ok := strings.CutPrefix(line, "mode:"); ok {
+			value = strings.TrimSpace(value)
+			if mode != "" && value != mode {
+				return nil, fmt.Errorf("line %d: mode %q does not match the mode %q of the profile", lineNumber, value, mode)
+			}
+			mode = value
+			continue
+		}
 
-	// Skip the first line ("mode: ...")
-	if !scanner.Scan() {
-		return nil, fmt.Errorf("file is empty or could not be read")
-	}
-
-	for scanner.Scan() {
-		line := scanner.Text()
 		match := goCoverLineRegex.FindStringSubmatch(line)
-
-		if len(match) == 8 {
-			startLine, _ := strconv.Atoi(match[2])
-			startCol, _ := strconv.Atoi(match[3])
-			endLine, _ := strconv.Atoi(match[4])
-			endCol, _ := strconv.Atoi(match[5])
-			numStatements, _ := strconv.Atoi(match[6])
-			hitCount, _ := strconv.Atoi(match[7])
-
-			blocks = append(blocks, GoCoverProfileBlock{
-				FileName:      match[1],
-				StartLine:     startLine,
-				StartCol:      startCol,
-				EndLine:       endLine,
-				EndCol:        endCol,
-				NumStatements: numStatements,
-				HitCount:      hitCount,
-			})
+		if len(match) != 8 {
+			continue
+		}
+		startLine, _ := strconv.Atoi(match[2])
+		startCol, _ := strconv.Atoi(match[3])
+		endLine, _ := strconv.Atoi(match[4])
+		endCol, _ := strconv.Atoi(match[5])
+		numStatements, _ := strconv.Atoi(match[6])
+		hitCount, _ := strconv.Atoi(match[7])
+
+		key := GoCoverProfileBlock{
+			FileName:  stripModuleVersions(match[1]),
+			StartLine: startLine,
+			StartCol:  startCol,
+			EndLine:   endLine,
+			EndCol:    endCol,
 		}
+		if i, ok := blockIndexes[key]; ok {
+			if mode == "set" {
+				blocks[i].HitCount = max(blocks[i].HitCount, hitCount)
+			} else {
+				blocks[i].HitCount += hitCount
+			}
+			continue
+		}
+		block := key
+		block.NumStatements = numStatements
+		block.HitCount = hitCount
+		blockIndexes[key] = len(blocks)
+		blocks = append(blocks, block)
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
+	if mode == "" {
+		return nil, fmt.Errorf("file is empty or has no \"mode:\" line")
+	}
 
 	return blocks, nil
 }
+
+// stripModuleVersions removes the version suffixes of the module paths in an import
+// path, e.g. "example.com/lib@v1.2.3/util/util.go" becomes "example.com/lib/util/util.go",
+// so that the files of a module are grouped into the same packages with or without them.
+func stripModuleVersions(importPath string) string {
+	if !strings.Contains(importPath, "@") {
+		return importPath
+	}
+	return moduleVersionRegex.ReplaceAllString(importPath, "")
+}
@@ -1,11 +1,14 @@
 package gocover
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"io/fs"
 	"log/slog"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -15,7 +18,10 @@ import (
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/defaultformatter"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/golang"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/progress"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -108,20 +114,28 @@ type mockParserConfig struct {
 	assemblyFilter filtering.IFilter
 	classFilter    filtering.IFilter
 	fileFilter     filtering.IFilter
+	methodFilter   filtering.IFilter
 	settings       *settings.Settings
 	logger         *slog.Logger
 	langFactory    *language.ProcessorFactory
+	resolver       *utils.SourceFileResolver
 }
 
 func (m *mockParserConfig) SourceDirectories() []string        { return m.srcDirs }
 func (m *mockParserConfig) AssemblyFilters() filtering.IFilter { return m.assemblyFilter }
 func (m *mockParserConfig) ClassFilters() filtering.IFilter    { return m.classFilter }
 func (m *mockParserConfig) FileFilters() filtering.IFilter     { return m.fileFilter }
+func (m *mockParserConfig) MethodFilters() filtering.IFilter   { return m.methodFilter }
 func (m *mockParserConfig) Settings() *settings.Settings       { return m.settings }
 func (m *mockParserConfig) Logger() *slog.Logger               { return m.logger }
 func (m *mockParserConfig) LanguageProcessorFactory() *language.ProcessorFactory {
 	return m.langFactory
 }
+func (m *mockParserConfig) SourceFileIndex() *utils.SourceFileIndex { return nil }
+func (m *mockParserConfig) SourceFileResolver() *utils.SourceFileResolver {
+	return m.resolver
+}
+func (m *mockParserConfig) Progress() progress.Reporter { return progress.Discard }
 
 func newTestConfig() *mockParserConfig {
 	noFilter, _ := filtering.NewDefaultFilter(nil)
@@ -132,14 +146,17 @@ func newTestConfig() *mockParserConfig {
 		golang.NewGoProcessor(),
 	)
 
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	return &mockParserConfig{
 		srcDirs:        []string{"/project/src"},
 		assemblyFilter: noFilter,
 		classFilter:    noFilter,
 		fileFilter:     noFilter,
+		methodFilter:   noFilter,
 		settings:       settings.NewSettings(),
-		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		logger:         logger,
 		langFactory:    langFactory,
+		resolver:       utils.NewSourceFileResolver(nil, logger),
 	}
 }
 
@@ -218,11 +235,12 @@ func TestProcessingOrchestrator_findModuleNameFromGoMod(t *testing.T) {
 	config := newTestConfig()
 	orchestrator := newProcessingOrchestrator(mockFileReader, config, slog.Default())
 
-	result, err := orchestrator.findModuleNameFromGoMod("/project/src/pkg/math/add.go")
+	result, root, err := orchestrator.findModuleNameFromGoMod("/project/src/pkg/math/add.go")
 	assert.NoError(t, err)
 	assert.Equal(t, "github.com/example/myproject", result)
+	assert.Equal(t, "/project/src", filepath.ToSlash(root))
 
-	_, err = orchestrator.findModuleNameFromGoMod("/other/project/main.go")
+	_, _, err = orchestrator.findModuleNameFromGoMod("/other/project/main.go")
 	assert.Error(t, err)
 }
 
@@ -245,3 +263,644 @@ func TestDefaultFileReader_Integration(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 3, count)
 }
+
+func TestGoCoverParser_GzippedReport(t *testing.T) {
+	coverProfileContent := "mode: set\ncalculator/calculator.go:4.2,4.13 1 1\n"
+
+	reportPath := filepath.Join(t.TempDir(), "cover.out.gz")
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(coverProfileContent))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(reportPath, buf.Bytes(), 0o644))
+
+	mockFileReader := NewMockFileReader()
+	mockFileReader.AddFile("/project/src/calculator/calculator.go", "package calculator\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n")
+	mockFileReader.AddFile("/project/src/go.mod", "module example.com/calculator")
+
+	p := NewGoCoverParser(mockFileReader)
+	require.True(t, p.SupportsFile(reportPath, newTestConfig()), "mode: prefix must be detected on the decompressed stream")
+
+	result, err := p.Parse(reportPath, newTestConfig())
+	require.NoError(t, err)
+	require.Len(t, result.Assemblies, 1)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+	assert.Equal(t, 1, result.Assemblies[0].Classes[0].LinesCovered)
+}
+
+func TestGoCoverParser_ExcludesGeneratedCode(t *testing.T) {
+	coverProfileContent := `mode: set
+calculator/calculator.go:4.2,4.13 1 1
+calculator/kind_string.go:6.2,6.13 1 0
+calculator/api/service.pb.go:5.2,5.13 1 0`
+
+	reportPath := filepath.Join(t.TempDir(), "cover.out")
+	require.NoError(t, os.WriteFile(reportPath, []byte(coverProfileContent), 0o644))
+
+	newFileReader := func() *MockFileReader {
+		mockFileReader := NewMockFileReader()
+		mockFileReader.AddFile("/project/src/go.mod", "module example.com/calculator")
+		mockFileReader.AddFile("/project/src/calculator/calculator.go", "package calculator\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n")
+		mockFileReader.AddFile("/project/src/calculator/kind_string.go", "// Code generated by \"stringer -type=Kind\"; DO NOT EDIT.\n\npackage calculator\n\nfunc (i Kind) String() string {\n\treturn \"\"\n}\n")
+		mockFileReader.AddFile("/project/src/calculator/api/service.pb.go", "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n\nfunc (x *Request) Reset() {\n\t*x = Request{}\n}\n")
+		return mockFileReader
+	}
+
+	t.Run("Excluded", func(t *testing.T) {
+		result, err := NewGoCoverParser(newFileReader()).Parse(reportPath, newTestConfig())
+		require.NoError(t, err)
+
+		require.Len(t, result.Assemblies, 1)
+		require.Len(t, result.Assemblies[0].Classes, 1, "the api package only contains generated code")
+		class := result.Assemblies[0].Classes[0]
+		require.Len(t, class.Files, 1)
+		assert.Equal(t, "/project/src/calculator/calculator.go", filepath.ToSlash(class.Files[0].Path))
+		assert.Equal(t, 1, class.LinesCovered)
+		assert.Equal(t, 1, class.LinesValid)
+		assert.Equal(t, 5, result.Assemblies[0].TotalLines)
+	})
+
+	t.Run("Included", func(t *testing.T) {
+		config := newTestConfig()
+		config.settings.ExcludeGeneratedCode = false
+
+		result, err := NewGoCoverParser(newFileReader()).Parse(reportPath, config)
+		require.NoError(t, err)
+
+		require.Len(t, result.Assemblies, 1)
+		assert.Len(t, result.Assemblies[0].Classes, 2)
+		assert.Equal(t, 3, result.Assemblies[0].LinesValid)
+	})
+}
+
+// TestGoCoverParser_ExternalFiles expects the files outside the source directories and the
+// module root, like those of the standard library, to be marked as external, or left out
+// with ExcludeExternalFiles.
+func TestGoCoverParser_ExternalFiles(t *testing.T) {
+	coverProfileContent := `mode: set
+calculator/calculator.go:4.2,4.13 1 1
+/usr/lib/go/src/fmt/print.go:4.2,4.13 1 0`
+
+	reportPath := filepath.Join(t.TempDir(), "cover.out")
+	require.NoError(t, os.WriteFile(reportPath, []byte(coverProfileContent), 0o644))
+	newFileReader := func() *MockFileReader {
+		mockFileReader := NewMockFileReader()
+		mockFileReader.AddFile("/project/src/go.mod", "module example.com/calculator")
+		mockFileReader.AddFile("/project/src/calculator/calculator.go", "package calculator\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n")
+		mockFileReader.AddFile("/usr/lib/go/src/fmt/print.go", "package fmt\n\nfunc Print() {\n\tprint()\n}\n")
+		return mockFileReader
+	}
+
+	t.Run("Classified", func(t *testing.T) {
+		result, err := NewGoCoverParser(newFileReader()).Parse(reportPath, newTestConfig())
+		require.NoError(t, err)
+
+		files := make(map[string]bool)
+		for _, class := range result.Assemblies[0].Classes {
+			for _, file := range class.Files {
+				files[filepath.ToSlash(file.Path)] = file.InSourceDirs
+			}
+		}
+		assert.Equal(t, map[string]bool{"/project/src/calculator/calculator.go": true, "/usr/lib/go/src/fmt/print.go": false}, files)
+		assert.Equal(t, 2, result.Assemblies[0].LinesValid)
+	})
+
+	t.Run("Excluded", func(t *testing.T) {
+		config := newTestConfig()
+		config.settings.ExcludeExternalFiles = true
+
+		result, err := NewGoCoverParser(newFileReader()).Parse(reportPath, config)
+		require.NoError(t, err)
+
+		require.Len(t, result.Assemblies, 1)
+		require.Len(t, result.Assemblies[0].Classes, 1)
+		assert.Equal(t, "/project/src/calculator/calculator.go", filepath.ToSlash(result.Assemblies[0].Classes[0].Files[0].Path))
+		assert.Equal(t, 1, result.Assemblies[0].LinesValid)
+	})
+
+	t.Run("StandardLibraryFirst", func(t *testing.T) {
+		// The go.mod of GOROOT/src must not become the module of the profile.
+		stdFirstPath := filepath.Join(t.TempDir(), "cover.out")
+		require.NoError(t, os.WriteFile(stdFirstPath, []byte(`mode: set
+/usr/lib/go/src/fmt/print.go:4.2,4.13 1 0
+calculator/calculator.go:4.2,4.13 1 1`), 0o644))
+		mockFileReader := newFileReader()
+		mockFileReader.AddFile("/usr/lib/go/src/go.mod", "module std")
+
+		result, err := NewGoCoverParser(mockFileReader).Parse(stdFirstPath, newTestConfig())
+		require.NoError(t, err)
+
+		require.Len(t, result.Assemblies, 1)
+		assert.Equal(t, "example.com/calculator", result.Assemblies[0].Name)
+		files := make(map[string]bool)
+		for _, class := range result.Assemblies[0].Classes {
+			for _, file := range class.Files {
+				files[filepath.ToSlash(file.Path)] = file.InSourceDirs
+			}
+		}
+		assert.Equal(t, map[string]bool{"/project/src/calculator/calculator.go": true, "/usr/lib/go/src/fmt/print.go": false}, files)
+	})
+}
+
+func TestGoCoverParser_ApproximateBranchCoverage(t *testing.T) {
+	coverProfileContent := `mode: count
+br/br.go:4.2,4.11 1 2
+br/br.go:5.3,6.1 1 2
+br/br.go:6.9,6.18 1 0
+br/br.go:7.3,8.1 1 0
+br/br.go:9.3,10.1 1 0
+br/br.go:14.2,14.11 1 1
+br/br.go:15.3,16.1 1 0
+br/br.go:17.2,17.10 1 1
+br/br.go:21.2,21.9 1 2
+br/br.go:23.3,23.16 1 1
+br/br.go:25.3,25.15 1 0
+br/br.go:27.2,27.16 1 1`
+
+	brGoContent := `package br
+
+func Sign(x int) int {
+	if x > 0 { // Line 4
+		return 1
+	} else if x < 0 { // Line 6
+		return -1
+	} else {
+		return 0
+	}
+}
+
+func Abs(x int) int {
+	if x < 0 { // Line 14
+		x = -x
+	}
+	return x
+}
+
+func Kind(x int) string {
+	switch { // Line 21
+	case x == 0:
+		return "zero"
+	case x > 100:
+		return "big"
+	}
+	return "other"
+}
+`
+
+	reportPath := filepath.Join(t.TempDir(), "cover.out")
+	require.NoError(t, os.WriteFile(reportPath, []byte(coverProfileContent), 0o644))
+
+	mockFileReader := NewMockFileReader()
+	mockFileReader.AddFile("/project/src/br/br.go", brGoContent)
+	mockFileReader.AddFile("/project/src/go.mod", "module example.com/br")
+
+	t.Run("Enabled", func(t *testing.T) {
+		config := newTestConfig()
+		config.settings.GoApproximateBranchCoverage = true
+
+		p := NewGoCoverParser(mockFileReader)
+		result, err := p.Parse(reportPath, config)
+		require.NoError(t, err)
+		assert.True(t, result.SupportsBranchCoverage)
+
+		require.Len(t, result.Assemblies, 1)
+		require.Len(t, result.Assemblies[0].Classes, 1)
+		class := result.Assemblies[0].Classes[0]
+		require.Len(t, class.Files, 1)
+		assert.True(t, class.Files[0].ApproximateBranchCoverage)
+
+		lines := make(map[int]model.Line)
+		for _, line := range class.Files[0].Lines {
+			lines[line.Number] = line
+		}
+
+		expected := []struct {
+			line, covered, total int
+			status               model.LineVisitStatus
+		}{
+			{4, 1, 2, model.PartiallyCovered},  // if ran, the else-if arm did not
+			{6, 0, 2, model.PartiallyCovered},  // else-if never evaluated, but the closing brace of the if body ran
+			{14, 1, 2, model.PartiallyCovered}, // only the implicit else arm ran
+			{21, 2, 3, model.PartiallyCovered}, // first case and the implicit default ran
+		}
+		for _, e := range expected {
+			line := lines[e.line]
+			assert.True(t, line.IsBranchPoint, "line %d", e.line)
+			assert.Equal(t, e.covered, line.CoveredBranches, "line %d", e.line)
+			assert.Equal(t, e.total, line.TotalBranches, "line %d", e.line)
+			assert.Equal(t, e.status, line.LineVisitStatus, "line %d", e.line)
+		}
+		assert.False(t, lines[17].IsBranchPoint)
+
+		require.NotNil(t, class.BranchesCovered)
+		require.NotNil(t, class.BranchesValid)
+		assert.Equal(t, 4, *class.BranchesCovered)
+		assert.Equal(t, 9, *class.BranchesValid)
+		require.NotNil(t, result.Assemblies[0].BranchesValid)
+		assert.Equal(t, 9, *result.Assemblies[0].BranchesValid)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		p := NewGoCoverParser(mockFileReader)
+		result, err := p.Parse(reportPath, newTestConfig())
+		require.NoError(t, err)
+		assert.False(t, result.SupportsBranchCoverage)
+
+		class := result.Assemblies[0].Classes[0]
+		assert.Nil(t, class.BranchesValid)
+		for _, line := range class.Files[0].Lines {
+			assert.False(t, line.IsBranchPoint, "line %d", line.Number)
+		}
+	})
+}
+
+func TestGoCoverParser_MethodFilters(t *testing.T) {
+	coverProfileContent := `mode: set
+calculator/stack.go:6.2,6.21 1 1
+calculator/stack.go:10.2,10.18 1 0
+calculator/stack.go:14.2,14.24 1 1`
+
+	reportPath := filepath.Join(t.TempDir(), "cover.out")
+	require.NoError(t, os.WriteFile(reportPath, []byte(coverProfileContent), 0o644))
+
+	mockFileReader := NewMockFileReader()
+	mockFileReader.AddFile("/project/src/go.mod", "module example.com/calculator")
+	mockFileReader.AddFile("/project/src/calculator/stack.go", "package calculator\n\ntype Stack struct{ items []int }\n\nfunc (s *Stack) Push(v int) {\n\ts.items = append(s.items, v)\n}\n\nfunc (s *Stack) Len() int {\n\treturn len(s.items)\n}\n\nfunc (s *Stack) String() string {\n\treturn fmt.Sprint(s.items)\n}\n")
+
+	config := newTestConfig()
+	methodFilter, err := filtering.NewDefaultFilter([]string{"-*.String", "-*).Len"})
+	require.NoError(t, err)
+	config.methodFilter = methodFilter
+
+	result, err := NewGoCoverParser(mockFileReader).Parse(reportPath, config)
+	require.NoError(t, err)
+
+	require.Len(t, result.Assemblies, 1)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+	class := result.Assemblies[0].Classes[0]
+	require.Len(t, class.Methods, 1)
+	assert.Contains(t, class.Methods[0].DisplayName, "Push")
+	assert.Equal(t, 1, class.TotalMethods)
+	assert.Equal(t, 1, class.CoveredMethods)
+	require.Len(t, class.Files, 1)
+	require.Len(t, class.Files[0].CodeElements, 1)
+	assert.Equal(t, class.Methods[0].DisplayName, class.Files[0].CodeElements[0].FullName)
+	assert.Equal(t, 2, class.LinesCovered, "the lines of filtered methods keep counting")
+	assert.Equal(t, 3, class.LinesValid)
+}
+
+// countingFilter counts how often the file filters are asked about a file.
+type countingFilter struct {
+	filtering.IFilter
+	calls int
+}
+
+func (f *countingFilter) IsAnyNameIncludedInReport(names ...string) bool {
+	f.calls++
+	return f.IFilter.IsAnyNameIncludedInReport(names...)
+}
+
+func TestGoCoverParser_FileFiltersAreAppliedOncePerFile(t *testing.T) {
+	coverProfileContent := `mode: set
+calculator/stack.go:6.2,6.21 1 1
+calculator/stack.go:10.2,10.18 1 0
+calculator/stack_gen.go:3.2,3.10 1 1
+calculator/stack.go:14.2,14.24 1 1
+calculator/stack_gen.go:5.2,5.10 1 0`
+
+	reportPath := filepath.Join(t.TempDir(), "cover.out")
+	require.NoError(t, os.WriteFile(reportPath, []byte(coverProfileContent), 0o644))
+
+	mockFileReader := NewMockFileReader()
+	mockFileReader.AddFile("/project/src/go.mod", "module example.com/calculator")
+	mockFileReader.AddFile("/project/src/calculator/stack.go", "package calculator\n\ntype Stack struct{ items []int }\n\nfunc (s *Stack) Push(v int) {\n\ts.items = append(s.items, v)\n}\n\nfunc (s *Stack) Len() int {\n\treturn len(s.items)\n}\n\nfunc (s *Stack) String() string {\n\treturn fmt.Sprint(s.items)\n}\n")
+
+	config := newTestConfig()
+	fileFilter, err := filtering.NewDefaultFilter([]string{"-*_gen.go"}, true)
+	require.NoError(t, err)
+	counter := &countingFilter{IFilter: fileFilter}
+	config.fileFilter = counter
+
+	result, err := NewGoCoverParser(mockFileReader).Parse(reportPath, config)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, counter.calls, "each file is matched once, not once per block")
+	require.Len(t, result.Assemblies, 1)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+	require.Len(t, result.Assemblies[0].Classes[0].Files, 1)
+	assert.Equal(t, 3, result.Assemblies[0].Classes[0].LinesValid)
+}
+
+// parseSingleFile parses the profile of a single file in example.com/app/server.
+func parseSingleFile(t *testing.T, coverProfileContent, source string) model.Class {
+	t.Helper()
+	reportPath := filepath.Join(t.TempDir(), "cover.out")
+	require.NoError(t, os.WriteFile(reportPath, []byte(coverProfileContent), 0o644))
+
+	mockFileReader := NewMockFileReader()
+	mockFileReader.AddFile("/project/src/go.mod", "module example.com/app")
+	mockFileReader.AddFile("/project/src/server/server.go", source)
+
+	result, err := NewGoCoverParser(mockFileReader).Parse(reportPath, newTestConfig())
+	require.NoError(t, err)
+	require.Len(t, result.Assemblies, 1)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+	return result.Assemblies[0].Classes[0]
+}
+
+// TestGoCoverParser_FullMethodCoverage counts the fully covered functions with each
+// definition of the settings. A function without statements is never covered, and
+// functions have no branch data that could keep them from being fully covered.
+func TestGoCoverParser_FullMethodCoverage(t *testing.T) {
+	source := `package server
+
+func Full() int {
+	return 1 // Line 4
+}
+
+func Mostly(x int) int {
+	a := x
+	b := a
+	c := b
+	return c // Line 11
+}
+
+func Empty() {
+}`
+	coverProfileContent := `mode: set
+server/server.go:4.2,4.10 1 1
+server/server.go:8.2,10.8 3 1
+server/server.go:11.2,11.10 1 0
+server/server.go:14.14,15.2 0 1`
+	reportPath := filepath.Join(t.TempDir(), "cover.out")
+	require.NoError(t, os.WriteFile(reportPath, []byte(coverProfileContent), 0o644))
+
+	testCases := []struct {
+		name             string
+		minimumLineRate  float64
+		requiresBranches bool
+		fullyCovered     int
+	}{
+		{name: "Default", minimumLineRate: 1, fullyCovered: 1},
+		{name: "MinimumLineRate", minimumLineRate: 0.75, fullyCovered: 2},
+		{name: "RequiresBranches", minimumLineRate: 0.75, requiresBranches: true, fullyCovered: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFileReader := NewMockFileReader()
+			mockFileReader.AddFile("/project/src/go.mod", "module example.com/app")
+			mockFileReader.AddFile("/project/src/server/server.go", source)
+			config := newTestConfig()
+			config.settings.FullMethodCoverageMinimumLineRate = tc.minimumLineRate
+			config.settings.FullMethodCoverageRequiresBranches = tc.requiresBranches
+
+			result, err := NewGoCoverParser(mockFileReader).Parse(reportPath, config)
+
+			require.NoError(t, err)
+			require.Len(t, result.Assemblies, 1)
+			require.Len(t, result.Assemblies[0].Classes, 1)
+			class := result.Assemblies[0].Classes[0]
+			assert.Equal(t, 3, class.TotalMethods)
+			assert.Equal(t, 2, class.CoveredMethods)
+			assert.Equal(t, tc.fullyCovered, class.FullyCoveredMethods)
+		})
+	}
+}
+
+func TestGoCoverParser_Closures(t *testing.T) {
+	source := `package server
+
+var handler = func() string {
+	return "ok" // Line 4
+}
+
+func Run(jobs []func()) {
+	for _, job := range jobs { // Line 8
+		go func() {
+			job() // Line 10
+			defer func() { recover() }() // Line 11
+		}()
+	}
+	func() { _ = jobs }() // Line 14
+}`
+	coverProfileContent := `mode: set
+server/server.go:4.2,4.13 1 1
+server/server.go:8.2,8.28 1 1
+server/server.go:8.28,9.14 1 1
+server/server.go:9.14,10.9 1 1
+server/server.go:11.4,11.19 1 1
+server/server.go:11.19,11.30 1 0
+server/server.go:14.2,14.8 1 1
+server/server.go:14.10,14.20 1 0`
+
+	class := parseSingleFile(t, coverProfileContent, source)
+
+	type method struct {
+		first, last int
+		lineRate    float64
+	}
+	got := make(map[string]method)
+	var names []string
+	for _, m := range class.Methods {
+		names = append(names, m.DisplayName)
+		got[m.DisplayName] = method{m.FirstLine, m.LastLine, m.LineRate}
+	}
+	assert.Equal(t, []string{"init.func1", "Run", "Run.func1", "Run.func1.1", "Run.func2"}, names)
+	assert.Equal(t, method{3, 5, 1}, got["init.func1"])
+	assert.Equal(t, method{7, 15, 1}, got["Run"], "the statements of the closures are not counted for Run")
+	assert.Equal(t, method{9, 12, 1}, got["Run.func1"])
+	assert.Equal(t, method{11, 11, 0}, got["Run.func1.1"])
+	assert.Equal(t, method{14, 14, 0}, got["Run.func2"], "a closure called where it is declared starts on the line of its parent's block")
+	assert.Equal(t, 5, class.TotalMethods)
+	assert.Equal(t, 3, class.CoveredMethods)
+
+	require.Len(t, class.Files, 1)
+	require.Len(t, class.Files[0].CodeElements, 5)
+	assert.Equal(t, "Run.func1.1", class.Files[0].CodeElements[3].FullName)
+}
+
+func TestGoCoverParser_Generics(t *testing.T) {
+	source := `package server
+
+func Map[T, U any](items []T, f func(T) U) []U {
+	out := make([]U, 0, len(items)) // Line 4
+	for _, item := range items {
+		out = append(out, f(item)) // Line 6
+	}
+	return out // Line 8
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func (p *Pair[K, V]) Swap(key K) K {
+	if key == p.Key { // Line 17
+		return key // Line 18
+	}
+	old := p.Key // Line 20
+	p.Key = key
+	return old
+}`
+	coverProfileContent := `mode: set
+server/server.go:4.2,5.30 2 1
+server/server.go:5.30,7.3 1 1
+server/server.go:8.2,8.12 1 1
+server/server.go:17.2,17.17 1 1
+server/server.go:17.17,19.3 1 0
+server/server.go:20.2,22.12 3 1`
+
+	class := parseSingleFile(t, coverProfileContent, source)
+
+	require.Len(t, class.Methods, 2)
+	assert.Equal(t, "Map[T, U any]", class.Methods[0].DisplayName)
+	assert.Equal(t, "Map", class.Methods[0].Name)
+	assert.InDelta(t, 1.0, class.Methods[0].LineRate, 0.001)
+
+	assert.Equal(t, "(*Pair[K, V]).Swap", class.Methods[1].DisplayName)
+	assert.Equal(t, "Swap", class.Methods[1].Name)
+	assert.InDelta(t, 0.8, class.Methods[1].LineRate, 0.001)
+	fset, astFile, err := parseGoSource("server.go", strings.Split(source, "\n"))
+	require.NoError(t, err)
+	functions := findGoFunctions(fset, astFile)
+	require.Len(t, functions, 2)
+	assert.Equal(t, "Map", functions[0].ComplexityName, "the complexity is matched without the type parameters")
+	assert.Equal(t, "(*Pair).Swap", functions[1].ComplexityName)
+}
+
+// TestGoCoverParser_ConcatenatedProfiles parses two concatenated profiles, one of them
+// with the version of the module in its paths. The blocks of both are merged.
+func TestGoCoverParser_ConcatenatedProfiles(t *testing.T) {
+	mockFileReader := NewMockFileReader()
+	mockFileReader.AddFile("/project/src/go.mod", "module example.com/calculator")
+	mockFileReader.AddFile("/project/src/calc/calc.go", `package calc
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func Sub(a, b int) int {
+	return a - b
+}
+
+func Neg(a int) int {
+	return -a
+}`)
+
+	result, err := NewGoCoverParser(mockFileReader).Parse(filepath.Join("testdata", "concatenated.out"), newTestConfig())
+	require.NoError(t, err)
+	require.Len(t, result.Assemblies, 1)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+	class := result.Assemblies[0].Classes[0]
+	assert.Equal(t, "calc", class.DisplayName)
+	require.Len(t, class.Files, 1)
+	assert.Equal(t, 2, class.Files[0].CoveredLines)
+	assert.Equal(t, 3, class.Files[0].CoverableLines)
+
+	hits := make(map[int]int)
+	for _, line := range class.Files[0].Lines {
+		if line.Hits >= 0 {
+			hits[line.Number] = line.Hits
+		}
+	}
+	assert.Equal(t, map[int]int{4: 3, 8: 3, 12: 0}, hits)
+}
+
+func TestGoCoverParser_ConcatenatedProfilesWithDifferentModes(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "cover.out")
+	content := "mode: set\ncalc/calc.go:4.2,4.14 1 1\nmode: atomic\ncalc/calc.go:8.2,8.14 1 3\n"
+	require.NoError(t, os.WriteFile(reportPath, []byte(content), 0o644))
+
+	_, err := NewGoCoverParser(NewMockFileReader()).Parse(reportPath, newTestConfig())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `line 3: mode "atomic" does not match the mode "set"`)
+}
+
+func TestStripModuleVersions(t *testing.T) {
+	testCases := map[string]string{
+		"example.com/lib/util/util.go":                             "example.com/lib/util/util.go",
+		"example.com/lib@v1.2.3/util/util.go":                      "example.com/lib/util/util.go",
+		"example.com/lib/v2@v2.0.1+incompatible/util.go":           "example.com/lib/v2/util.go",
+		"golang.org/x/tools@v0.0.0-20240102150405-abcdef/cmd/a.go": "golang.org/x/tools/cmd/a.go",
+		"example.com/user@home/a.go":                               "example.com/user@home/a.go",
+	}
+	for input, want := range testCases {
+		assert.Equal(t, want, stripModuleVersions(input), input)
+	}
+}
+
+func TestGoCoverParser_CoverageDirectoryWithoutGoToolchain(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "covmeta.0123abcd"), []byte{0, 1}, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "covcounters.0123abcd.42.1700000000"), []byte{0, 1}, 0o644))
+
+	p := &GoCoverParser{
+		fileReader: NewMockFileReader(),
+		lookPath:   func(string) (string, error) { return "", exec.ErrNotFound },
+	}
+	assert.True(t, p.SupportsFile(dir, newTestConfig()))
+	assert.False(t, p.SupportsFile(t.TempDir(), newTestConfig()), "a directory without coverage data")
+
+	_, err := p.Parse(dir, newTestConfig())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the go toolchain was not found in PATH")
+	assert.Contains(t, err.Error(), "go tool covdata textfmt -i="+dir)
+}
+
+// TestGoCoverParser_CoverageDirectory builds a program with -cover, runs it with GOCOVERDIR
+// and parses the directory. It needs the go toolchain.
+func TestGoCoverParser_CoverageDirectory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a program")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found in PATH")
+	}
+
+	moduleDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module example.com/covdemo\n\ngo 1.21\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "main.go"), []byte(`package main
+
+func main() {
+	println(double(2))
+}
+
+func double(x int) int {
+	return x * 2
+}
+
+func unused() int {
+	return 0
+}
+`), 0o644))
+
+	binary := filepath.Join(t.TempDir(), "covdemo")
+	build := exec.Command(goTool, "build", "-cover", "-o", binary, ".")
+	build.Dir = moduleDir
+	output, err := build.CombinedOutput()
+	require.NoError(t, err, "%s", output)
+
+	coverDir := t.TempDir()
+	run := exec.Command(binary)
+	run.Env = append(os.Environ(), "GOCOVERDIR="+coverDir)
+	output, err = run.CombinedOutput()
+	require.NoError(t, err, "%s", output)
+	require.True(t, IsCoverageDirectory(coverDir))
+
+	config := newTestConfig()
+	config.srcDirs = []string{moduleDir}
+	result, err := NewGoCoverParser(&DefaultFileReader{}).Parse(coverDir, config)
+	require.NoError(t, err)
+	require.Len(t, result.Assemblies, 1)
+	assert.Equal(t, "example.com/covdemo", result.Assemblies[0].Name)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+
+	lineRates := make(map[string]float64)
+	for _, method := range result.Assemblies[0].Classes[0].Methods {
+		lineRates[method.Name] = method.LineRate
+	}
+	assert.Equal(t, map[string]float64{"main": 1, "double": 1, "unused": 0}, lineRates)
+}
@@ -6,12 +6,16 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"log/slog"
 	"math"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
@@ -22,24 +26,42 @@ import (
 type processingOrchestrator struct {
 	fileReader   filereader.Reader
 	config       parsers.ParserConfig
+	pathKey      func(string) string // Key of a file path, see utils.PathKeyFunc
 	assemblyName string
-	logger       *slog.Logger
+	moduleRoot   string // Directory of the go.mod of the module, "" if it was not found
+	// missingSourceFiles collects the profile paths that could not be resolved.
+	missingSourceFiles []model.MissingSourceFile
+	generatedCode      *filtering.GeneratedCodeDetector // nil if generated code is not excluded
+	includedFiles      map[string]bool                  // Results of the file filters by profile path
+	filteredFiles      map[string]struct{}              // Profile paths excluded by the file filters
+	externalFiles      map[string]struct{}              // Profile paths excluded as outside the source directories
+	logger             *slog.Logger
 }
 
 // parsedMethod is a temporary struct to hold data from AST (Abstract System Tree) parsing.
 type parsedMethod struct {
-	DisplayName string
-	FuncName    string
-	StartLine   int
-	EndLine     int
+	DisplayName    string
+	FuncName       string
+	ComplexityName string // Name in the cyclomatic complexity metrics, empty for function literals
+	StartLine      int
+	EndLine        int
+	body           [2]token.Position // Braces of the body, zero if the function has none
 }
 
 func newProcessingOrchestrator(fileReader filereader.Reader, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
-	return &processingOrchestrator{
-		fileReader: fileReader,
-		config:     config,
-		logger:     logger,
-	}
+	o := &processingOrchestrator{
+		fileReader:    fileReader,
+		config:        config,
+		pathKey:       utils.PathKeyFunc(parsers.PathCaseMode(config), false),
+		includedFiles: make(map[string]bool),
+		filteredFiles: make(map[string]struct{}),
+		externalFiles: make(map[string]struct{}),
+		logger:        logger,
+	}
+	if config.Settings().ExcludeGeneratedCode {
+		o.generatedCode = filtering.NewGeneratedCodeDetector(fileReader.ReadFile)
+	}
+	return o
 }
 
 func (o *processingOrchestrator) processBlocks(blocks []GoCoverProfileBlock) ([]model.Assembly, error) {
@@ -48,20 +70,13 @@ func (o *processingOrchestrator) processBlocks(blocks []GoCoverProfileBlock) ([]
 	}
 
 	var foundAssemblyName string
-	if len(blocks) > 0 {
-		startPath := blocks[0].FileName
-		resolvedStartPath, err := utils.FindFileInSourceDirs(startPath, o.config.SourceDirectories(), o.fileReader)
-		if err == nil {
-			startPath = resolvedStartPath
-		}
-
-		modName, err := o.findModuleNameFromGoMod(startPath)
-		if err == nil {
-			foundAssemblyName = modName
-			o.logger.Info("Discovered Go module name for assembly", "name", foundAssemblyName)
-		} else {
-			o.logger.Warn("Could not discover Go module name, falling back to default.", "error", err)
-		}
+	modName, modRoot, err := o.discoverModule(blocks)
+	if err == nil {
+		foundAssemblyName = modName
+		o.moduleRoot = modRoot
+		o.logger.Info("Discovered Go module name for assembly", "name", foundAssemblyName)
+	} else {
+		o.logger.Warn("Could not discover Go module name, falling back to default.", "error", err)
 	}
 	if foundAssemblyName != "" {
 		o.assemblyName = foundAssemblyName
@@ -79,8 +94,8 @@ func (o *processingOrchestrator) processBlocks(blocks []GoCoverProfileBlock) ([]
 		Classes: []model.Class{},
 	}
 
-	for pkgPath, fileBlocks := range filesByPackage {
-		class := o.processPackage(pkgPath, fileBlocks)
+	for _, pkgPath := range parsers.SortedKeys(filesByPackage) {
+		class := o.processPackage(pkgPath, filesByPackage[pkgPath])
 		if class != nil {
 			assembly.Classes = append(assembly.Classes, *class)
 		}
@@ -90,7 +105,41 @@ func (o *processingOrchestrator) processBlocks(blocks []GoCoverProfileBlock) ([]
 	return []model.Assembly{*assembly}, nil
 }
 
-func (o *processingOrchestrator) findModuleNameFromGoMod(startPath string) (string, error) {
+// discoverModule returns the module name and root of the profile from the go.mod of its
+// first file outside GOROOT. Profiles that cover the standard library list its files
+// too, and the go.mod of GOROOT/src (module std, or cmd for the tools) is not the module
+// under test, so those files are skipped wherever they come in the profile.
+func (o *processingOrchestrator) discoverModule(blocks []GoCoverProfileBlock) (string, string, error) {
+	err := errors.New("the profile has no files")
+	checkedDirs := make(map[string]struct{})
+	for _, block := range blocks {
+		dir := filepath.Dir(block.FileName)
+		if _, checked := checkedDirs[dir]; checked {
+			continue
+		}
+		checkedDirs[dir] = struct{}{}
+
+		startPath := block.FileName
+		if resolvedPath, resolveErr := o.config.SourceFileResolver().Resolve(startPath, o.config.SourceDirectories(), o.fileReader); resolveErr == nil {
+			startPath = resolvedPath
+		}
+		var modName, modRoot string
+		modName, modRoot, err = o.findModuleNameFromGoMod(startPath)
+		if err != nil {
+			continue
+		}
+		if modName == "std" || modName == "cmd" {
+			err = fmt.Errorf("only the GOROOT module %q was found", modName)
+			continue
+		}
+		return modName, modRoot, nil
+	}
+	return "", "", err
+}
+
+// findModuleNameFromGoMod returns the module name of the go.mod in the directory of
+// startPath or its closest parent, and the directory of the go.mod.
+func (o *processingOrchestrator) findModuleNameFromGoMod(startPath string) (string, string, error) {
 	dir := filepath.Dir(startPath)
 	var goModPath string
 
@@ -103,29 +152,29 @@ func (o *processingOrchestrator) findModuleNameFromGoMod(startPath string) (stri
 
 		parentDir := filepath.Dir(dir)
 		if parentDir == dir {
-			return "", fmt.Errorf("go.mod not found in parent directories of %s", startPath)
+			return "", "", fmt.Errorf("go.mod not found in parent directories of %s", startPath)
 		}
 		dir = parentDir
 	}
 
 	lines, err := o.fileReader.ReadFile(goModPath)
 	if err != nil || len(lines) == 0 {
-		return "", fmt.Errorf("could not read or empty go.mod at %s: %w", goModPath, err)
+		return "", "", fmt.Errorf("could not read or empty go.mod at %s: %w", goModPath, err)
 	}
 
 	for _, line := range lines {
 		if strings.HasPrefix(line, "module ") {
-			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), dir, nil
 		}
 	}
 
-	return "", fmt.Errorf("'module' directive not found in %s", goModPath)
+	return "", "", fmt.Errorf("'module' directive not found in %s", goModPath)
 }
 
 func (o *processingOrchestrator) groupFilesByPackage(blocks []GoCoverProfileBlock) map[string]map[string][]GoCoverProfileBlock {
 	filesByPackage := make(map[string]map[string][]GoCoverProfileBlock)
 	for _, block := range blocks {
-		if !o.config.FileFilters().IsElementIncludedInReport(block.FileName) {
+		if !o.isFileIncluded(block.FileName) || o.isGeneratedCode(block.FileName) || o.isExcludedExternalFile(block.FileName) {
 			continue
 		}
 		pkgPath := filepath.ToSlash(filepath.Dir(block.FileName))
@@ -140,6 +189,69 @@ func (o *processingOrchestrator) groupFilesByPackage(blocks []GoCoverProfileBloc
 	return filesByPackage
 }
 
+// isFileIncluded applies the file filters to the path in the profile, the resolved path of
+// the source file and the path relative to the module, see parsers.IsFileIncluded. The
+// result is remembered per path, as the profile lists a block per statement range.
+func (o *processingOrchestrator) isFileIncluded(profilePath string) bool {
+	if !o.config.FileFilters().HasCustomFilters() {
+		return true
+	}
+	if included, ok := o.includedFiles[profilePath]; ok {
+		return included
+	}
+	resolvedPath, _ := o.config.SourceFileResolver().Resolve(profilePath, o.config.SourceDirectories(), o.fileReader)
+	modulePath, _ := strings.CutPrefix(profilePath, o.assemblyName+"/")
+	included := parsers.IsFileIncluded(o.config, profilePath, resolvedPath, modulePath)
+	o.includedFiles[profilePath] = included
+	if !included {
+		o.filteredFiles[profilePath] = struct{}{}
+	}
+	return included
+}
+
+// sourceDirectories returns the directories the files of the profile belong to: the source
+// directories and the module root.
+func (o *processingOrchestrator) sourceDirectories() []string {
+	dirs := slices.Clone(o.config.SourceDirectories())
+	if o.moduleRoot != "" {
+		dirs = append(dirs, o.moduleRoot)
+	}
+	return dirs
+}
+
+// isExcludedExternalFile reports whether the file is excluded because it is outside the
+// source directories and the module root, see parsers.IsInSourceDirectories.
+func (o *processingOrchestrator) isExcludedExternalFile(profilePath string) bool {
+	if !o.config.Settings().ExcludeExternalFiles {
+		return false
+	}
+	resolvedPath, err := o.config.SourceFileResolver().Resolve(profilePath, o.config.SourceDirectories(), o.fileReader)
+	if parsers.IsInSourceDirectories(o.config, resolvedPath, err == nil, o.sourceDirectories()) {
+		return false
+	}
+	o.externalFiles[profilePath] = struct{}{}
+	return true
+}
+
+// isGeneratedCode reports whether the file is excluded as generated code.
+func (o *processingOrchestrator) isGeneratedCode(filePath string) bool {
+	if o.generatedCode == nil {
+		return false
+	}
+	return o.generatedCode.IsGenerated(filePath, func() string {
+		resolvedPath, _ := o.config.SourceFileResolver().Resolve(filePath, o.config.SourceDirectories(), o.fileReader)
+		return resolvedPath
+	})
+}
+
+// excludedGeneratedFiles returns how many files were excluded as generated code.
+func (o *processingOrchestrator) excludedGeneratedFiles() int {
+	if o.generatedCode == nil {
+		return 0
+	}
+	return o.generatedCode.ExcludedCount()
+}
+
 func (o *processingOrchestrator) processPackage(pkgPath string, fileBlocks map[string][]GoCoverProfileBlock) *model.Class {
 	if !o.config.ClassFilters().IsElementIncludedInReport(pkgPath) {
 		return nil
@@ -160,8 +272,8 @@ func (o *processingOrchestrator) processPackage(pkgPath string, fileBlocks map[s
 		Metrics:     make(map[string]float64),
 	}
 
-	for filePath, blocksForFile := range fileBlocks {
-		codeFile, methods := o.processFile(filePath, blocksForFile)
+	for _, filePath := range parsers.SortedKeys(fileBlocks) {
+		codeFile, methods := o.processFile(filePath, packageClass.DisplayName, fileBlocks[filePath])
 		if codeFile == nil {
 			continue
 		}
@@ -176,10 +288,16 @@ func (o *processingOrchestrator) processPackage(pkgPath string, fileBlocks map[s
 	return nil
 }
 
-func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverProfileBlock) (*model.CodeFile, []model.Method) {
-	resolvedPath, err := utils.FindFileInSourceDirs(filePath, o.config.SourceDirectories(), o.fileReader)
+func (o *processingOrchestrator) processFile(filePath, className string, blocks []GoCoverProfileBlock) (*model.CodeFile, []model.Method) {
+	resolvedPath, err := o.config.SourceFileResolver().Resolve(filePath, o.config.SourceDirectories(), o.fileReader)
+	found := err == nil
 	if err != nil {
 		o.logger.Warn("Source file not found, line content will be missing.", "file", filePath, "error", err)
+		o.missingSourceFiles = append(o.missingSourceFiles, model.MissingSourceFile{
+			Path:     filePath,
+			Assembly: o.assemblyName,
+			Class:    className,
+		})
 		resolvedPath = filePath
 	}
 
@@ -190,9 +308,16 @@ func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverPr
 		return nil, nil
 	}
 
-	parsedMethods, err := parseGoSourceForFunctions(resolvedPath, sourceLines)
+	var parsedMethods []parsedMethod
+	var branchesByLine map[int]lineBranches
+	fset, astFile, err := parseGoSource(resolvedPath, sourceLines)
 	if err != nil {
 		o.logger.Warn("Failed to parse Go source for functions, method metrics will be unavailable.", "file", resolvedPath, "error", err)
+	} else {
+		parsedMethods = findGoFunctions(fset, astFile)
+		if o.config.Settings().GoApproximateBranchCoverage {
+			branchesByLine = newBranchApproximator(fset, blocks).approximateBranches(astFile)
+		}
 	}
 
 	langProcessor := o.config.LanguageProcessorFactory().FindProcessorForFile(filePath)
@@ -206,19 +331,27 @@ func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverPr
 		complexityMap[m.Name] = m
 	}
 
+	// Function literals follow the function containing them, so the last match is the innermost.
 	blocksByMethod := make(map[string][]GoCoverProfileBlock)
 	for _, block := range blocks {
+		owner := ""
 		for _, pMethod := range parsedMethods {
-			if block.StartLine >= pMethod.StartLine && block.StartLine <= pMethod.EndLine {
-				blocksByMethod[pMethod.DisplayName] = append(blocksByMethod[pMethod.DisplayName], block)
-				break
+			if pMethod.containsBlock(block) {
+				owner = pMethod.DisplayName
 			}
 		}
+		if owner != "" {
+			blocksByMethod[owner] = append(blocksByMethod[owner], block)
+		}
 	}
 
 	var methods []model.Method
 	var codeElements []model.CodeElement
 	for _, pMethod := range parsedMethods {
+		if !parsers.IsMethodIncluded(o.config, className, pMethod.DisplayName) {
+			o.logger.Debug("Method excluded by method filters", "class", className, "method", pMethod.DisplayName)
+			continue
+		}
 		methodBlocks := blocksByMethod[pMethod.DisplayName]
 		totalStatements := 0
 		coveredStatements := 0
@@ -244,7 +377,7 @@ func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverPr
 			Complexity:  math.NaN(),
 		}
 
-		if metric, ok := complexityMap[method.DisplayName]; ok {
+		if metric, ok := complexityMap[pMethod.ComplexityName]; ok && pMethod.ComplexityName != "" {
 			if len(metric.Metrics) > 0 {
 				method.Complexity = metric.Metrics[0].Value.(float64)
 			}
@@ -303,6 +436,15 @@ func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverPr
 			line.Hits = -1
 		}
 
+		if branches, ok := branchesByLine[lineNumber]; ok && line.Hits >= 0 {
+			line.IsBranchPoint = true
+			line.CoveredBranches = branches.covered
+			line.TotalBranches = branches.total
+			if line.Hits > 0 && branches.covered < branches.total {
+				line.LineVisitStatus = model.PartiallyCovered
+			}
+		}
+
 		finalLines = append(finalLines, line)
 	}
 
@@ -321,7 +463,13 @@ func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverPr
 		TotalLines:     totalLines,
 		CodeElements:   codeElements,
 		MethodMetrics:  methodMetricsForFile,
+
+		ApproximateBranchCoverage: o.config.Settings().GoApproximateBranchCoverage,
+	}
+	if resolvedPath != filePath {
+		codeFile.ReportPath = filePath
 	}
+	codeFile.InSourceDirs = parsers.IsInSourceDirectories(o.config, resolvedPath, found, o.sourceDirectories())
 
 	return codeFile, methods
 }
@@ -333,7 +481,7 @@ func (o *processingOrchestrator) populateStandardGoMethodMetrics(method *model.M
 	if !math.IsNaN(method.Complexity) {
 		method.MethodMetrics = append(method.MethodMetrics, model.MethodMetric{
 			Name: shortMetricName, Line: method.FirstLine,
-			Metrics: []model.Metric{{Name: "Cyclomatic complexity", Value: method.Complexity, Status: model.StatusOk}},
+			Metrics: []model.Metric{{Name: "Cyclomatic complexity", Value: method.Complexity, Status: o.metricStatus("Cyclomatic complexity", method.Complexity)}},
 		})
 	}
 	lineCoveragePercentage := method.LineRate * 100.0
@@ -344,25 +492,19 @@ func (o *processingOrchestrator) populateStandardGoMethodMetrics(method *model.M
 		})
 	}
 	if !math.IsNaN(method.Complexity) {
-		crapScoreValue := o.calculateCrapScore(method.LineRate, method.Complexity)
+		crapScoreValue := model.CrapScore(method.LineRate, method.Complexity)
 		if !math.IsNaN(crapScoreValue) {
 			method.MethodMetrics = append(method.MethodMetrics, model.MethodMetric{
 				Name: shortMetricName, Line: method.FirstLine,
-				Metrics: []model.Metric{{Name: "CrapScore", Value: crapScoreValue, Status: model.StatusOk}},
+				Metrics: []model.Metric{{Name: "CrapScore", Value: crapScoreValue, Status: o.metricStatus("CrapScore", crapScoreValue)}},
 			})
 		}
 	}
 }
 
-func (o *processingOrchestrator) calculateCrapScore(coverage float64, complexity float64) float64 {
-	if math.IsNaN(coverage) || math.IsInf(coverage, 0) || coverage < 0 || coverage > 1 {
-		coverage = 0
-	}
-	if math.IsNaN(complexity) || math.IsInf(complexity, 0) || complexity < 0 {
-		return math.NaN()
-	}
-	uncoveredRatio := 1.0 - coverage
-	return (math.Pow(complexity, 2) * math.Pow(uncoveredRatio, 3)) + complexity
+// metricStatus evaluates a method metric against the thresholds configured in the settings.
+func (o *processingOrchestrator) metricStatus(name string, value float64) model.MetricStatus {
+	return o.config.Settings().MetricThresholds[name].Evaluate(value)
 }
 
 func (o *processingOrchestrator) aggregateClassMetrics(class *model.Class) {
@@ -371,75 +513,187 @@ func (o *processingOrchestrator) aggregateClassMetrics(class *model.Class) {
 		class.LinesValid += f.CoverableLines
 		class.TotalLines += f.TotalLines
 	}
-	class.TotalMethods = len(class.Methods)
-	for _, method := range class.Methods {
-		if !math.IsNaN(method.LineRate) {
-			if method.LineRate > 0 {
-				class.CoveredMethods++
+	if o.config.Settings().GoApproximateBranchCoverage {
+		covered, valid := 0, 0
+		for _, f := range class.Files {
+			for _, line := range f.Lines {
+				covered += line.CoveredBranches
+				valid += line.TotalBranches
 			}
-			if method.LineRate >= 1.0 {
-				class.FullyCoveredMethods++
-			}
-		}
-		if !math.IsNaN(method.Complexity) {
-			class.Metrics["Cyclomatic complexity"] += method.Complexity
 		}
+		class.BranchesCovered = &covered
+		class.BranchesValid = &valid
 	}
+	class.TotalMethods = len(class.Methods)
+	class.CoveredMethods, class.FullyCoveredMethods = analyzer.NewFullMethodCoverage(o.config.Settings()).CountMethodCoverage(class.Methods)
+	class.Metrics = o.config.Settings().Metrics.AggregateMethodMetrics(class.Methods)
 }
 
+// aggregateAssemblyMetrics sums the class statistics. Total lines are counted once
+// per file, as in the Cobertura parser.
 func (o *processingOrchestrator) aggregateAssemblyMetrics(assembly *model.Assembly) {
+	seenFiles := make(map[string]struct{})
 	for _, cls := range assembly.Classes {
 		assembly.LinesCovered += cls.LinesCovered
 		assembly.LinesValid += cls.LinesValid
-		assembly.TotalLines += cls.TotalLines
+		if cls.BranchesCovered != nil && cls.BranchesValid != nil {
+			if assembly.BranchesCovered == nil {
+				assembly.BranchesCovered, assembly.BranchesValid = new(int), new(int)
+			}
+			*assembly.BranchesCovered += *cls.BranchesCovered
+			*assembly.BranchesValid += *cls.BranchesValid
+		}
+		for _, f := range cls.Files {
+			if _, seen := seenFiles[o.pathKey(f.Path)]; !seen {
+				seenFiles[o.pathKey(f.Path)] = struct{}{}
+				assembly.TotalLines += f.TotalLines
+			}
+		}
 	}
 }
 
-func parseGoSourceForFunctions(filePath string, sourceLines []string) ([]parsedMethod, error) {
+// parseGoSource parses the lines of a Go file into its syntax tree.
+func parseGoSource(filePath string, sourceLines []string) (*token.FileSet, *ast.File, error) {
 	fset := token.NewFileSet()
 	f, err := parser.ParseFile(fset, filePath, strings.Join(sourceLines, "\n"), 0)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Go source: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse Go source: %w", err)
 	}
+	return fset, f, nil
+}
 
+// findGoFunctions lists the functions and methods declared in the file, followed by the
+// function literals inside them. Function literals are named like the Go runtime names
+// them: "Parent.func1" for the first closure in Parent, "Parent.func1.1" for a closure
+// inside it, and "init.func1" for closures in package-level declarations.
+func findGoFunctions(fset *token.FileSet, f *ast.File) []parsedMethod {
 	var methods []parsedMethod
-	ast.Inspect(f, func(n ast.Node) bool {
-		if fn, ok := n.(*ast.FuncDecl); ok {
-			funcName := fn.Name.Name
-			displayName := funcName
-
-			if fn.Recv != nil && len(fn.Recv.List) > 0 {
-				typeExpr := fn.Recv.List[0].Type
-				var receiverTypeNameBuilder strings.Builder
-				var extractTypeName func(ast.Expr)
-				extractTypeName = func(e ast.Expr) {
-					switch t := e.(type) {
-					case *ast.StarExpr:
-						receiverTypeNameBuilder.WriteString("*")
-						extractTypeName(t.X)
-					case *ast.Ident:
-						receiverTypeNameBuilder.WriteString(t.Name)
-					}
+	packageClosures := 0
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			ast.Inspect(decl, func(n ast.Node) bool {
+				lit, ok := n.(*ast.FuncLit)
+				if !ok {
+					return true
 				}
-				extractTypeName(typeExpr)
+				packageClosures++
+				methods = appendFuncLit(fset, methods, lit, fmt.Sprintf("init.func%d", packageClosures))
+				return false
+			})
+			continue
+		}
 
-				receiverTypeName := receiverTypeNameBuilder.String()
-				if receiverTypeName != "" {
-					displayName = fmt.Sprintf("(%s).%s", receiverTypeName, funcName)
-				}
+		funcName := fn.Name.Name
+		displayName := funcName + typeParamList(fn.Type.TypeParams)
+		complexityName := funcName
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			typeExpr := fn.Recv.List[0].Type
+			if receiver := receiverTypeName(typeExpr, true); receiver != "" {
+				displayName = fmt.Sprintf("(%s).%s", receiver, funcName)
+				complexityName = fmt.Sprintf("(%s).%s", receiverTypeName(typeExpr, false), funcName)
 			}
+		}
 
-			startPosition := fset.Position(fn.Pos())
-			endPosition := fset.Position(fn.End())
-			methods = append(methods, parsedMethod{
-				DisplayName: displayName,
-				FuncName:    funcName,
-				StartLine:   startPosition.Line,
-				EndLine:     endPosition.Line,
-			})
+		methods = append(methods, newParsedMethod(fset, fn, fn.Body, displayName, funcName, complexityName))
+		if fn.Body != nil {
+			methods = appendFuncLits(fset, methods, fn.Body, displayName+".func")
 		}
-		return true
+	}
+	return methods
+}
+
+// appendFuncLits appends the function literals directly inside node, numbered in source
+// order with the given name prefix, and recursively the literals inside them.
+func appendFuncLits(fset *token.FileSet, methods []parsedMethod, node ast.Node, prefix string) []parsedMethod {
+	count := 0
+	ast.Inspect(node, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		count++
+		methods = appendFuncLit(fset, methods, lit, fmt.Sprintf("%s%d", prefix, count))
+		return false
 	})
+	return methods
+}
+
+// appendFuncLit appends the function literal and the literals nested in it.
+func appendFuncLit(fset *token.FileSet, methods []parsedMethod, lit *ast.FuncLit, displayName string) []parsedMethod {
+	funcName := displayName[strings.LastIndex(displayName, ".")+1:]
+	methods = append(methods, newParsedMethod(fset, lit, lit.Body, displayName, funcName, ""))
+	return appendFuncLits(fset, methods, lit.Body, displayName+".")
+}
+
+// newParsedMethod creates the parsedMethod of a function spanning node with the given body.
+func newParsedMethod(fset *token.FileSet, node ast.Node, body *ast.BlockStmt, displayName, funcName, complexityName string) parsedMethod {
+	method := parsedMethod{
+		DisplayName:    displayName,
+		FuncName:       funcName,
+		ComplexityName: complexityName,
+		StartLine:      fset.Position(node.Pos()).Line,
+		EndLine:        fset.Position(node.End()).Line,
+	}
+	if body != nil {
+		method.body = [2]token.Position{fset.Position(body.Lbrace), fset.Position(body.Rbrace)}
+	}
+	return method
+}
+
+// containsBlock reports whether the profile block starts inside the body of the function.
+// The body is compared by line and column, as a closure can start on a line of its parent.
+func (m parsedMethod) containsBlock(block GoCoverProfileBlock) bool {
+	start, end := m.body[0], m.body[1]
+	if start.Line == 0 {
+		return false
+	}
+	if block.StartLine < start.Line || (block.StartLine == start.Line && block.StartCol <= start.Column) {
+		return false
+	}
+	return block.StartLine < end.Line || (block.StartLine == end.Line && block.StartCol <= end.Column)
+}
+
+// receiverTypeName returns the type of a method receiver, e.g. "*Stack" or "*Stack[T]"
+// if withTypeParams is set.
+func receiverTypeName(expr ast.Expr, withTypeParams bool) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + receiverTypeName(t.X, withTypeParams)
+	case *ast.IndexExpr:
+		return receiverTypeName(t.X, withTypeParams) + typeArgList(withTypeParams, t.Index)
+	case *ast.IndexListExpr:
+		return receiverTypeName(t.X, withTypeParams) + typeArgList(withTypeParams, t.Indices...)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
 
-	return methods, nil
+// typeArgList renders the type parameters of a generic receiver, e.g. "[K, V]".
+func typeArgList(withTypeParams bool, args ...ast.Expr) string {
+	if !withTypeParams {
+		return ""
+	}
+	names := make([]string, 0, len(args))
+	for _, arg := range args {
+		names = append(names, types.ExprString(arg))
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// typeParamList renders the type parameters of a generic function, e.g. "[K comparable, V any]".
+func typeParamList(params *ast.FieldList) string {
+	if params == nil || len(params.List) == 0 {
+		return ""
+	}
+	fields := make([]string, 0, len(params.List))
+	for _, field := range params.List {
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		fields = append(fields, strings.Join(names, ", ")+" "+types.ExprString(field.Type))
+	}
+	return "[" + strings.Join(fields, ", ") + "]"
 }
@@ -2,12 +2,17 @@ package parsers
 
 import (
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/progress"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
 // holds the processed data from a single coverage report.
@@ -15,9 +20,16 @@ type ParserResult struct {
 	Assemblies             []model.Assembly
 	SourceDirectories      []string
 	SupportsBranchCoverage bool
-	ParserName             string
-	MinimumTimeStamp       *time.Time
-	MaximumTimeStamp       *time.Time
+	// MethodCoverageAvailable is true if the report provides methods, so that the method
+	// coverage is meaningful.
+	MethodCoverageAvailable bool
+	ParserName              string
+	MinimumTimeStamp        *time.Time
+	MaximumTimeStamp        *time.Time
+	// MissingSourceFiles lists the referenced source files that could not be found.
+	MissingSourceFiles []model.MissingSourceFile
+	// DeclaredTotals are the totals stated by the report itself, nil if it has none.
+	DeclaredTotals *DeclaredTotals
 }
 
 type ParserConfig interface {
@@ -25,13 +37,105 @@ type ParserConfig interface {
 	AssemblyFilters() filtering.IFilter
 	ClassFilters() filtering.IFilter
 	FileFilters() filtering.IFilter
+	// MethodFilters decide which methods count towards the method coverage, see
+	// IsMethodIncluded.
+	MethodFilters() filtering.IFilter
 	Settings() *settings.Settings
 	Logger() *slog.Logger
 	LanguageProcessorFactory() *language.ProcessorFactory
+	// SourceFileIndex returns the shared auto-discovery index, or nil when
+	// source auto-discovery is disabled.
+	SourceFileIndex() *utils.SourceFileIndex
+	// SourceFileResolver returns the resolver shared by all parsers of a run, which
+	// caches where the files referenced by the reports were found.
+	SourceFileResolver() *utils.SourceFileResolver
+	// Progress returns the reporter for the progress of long-running steps.
+	Progress() progress.Reporter
 }
 
 type IParser interface {
 	Name() string
-	SupportsFile(filePath string) bool
+	// SupportsFile reports whether the parser can handle the file. It is opened with the
+	// FileRetryPolicy of config.
+	SupportsFile(filePath string, config ParserConfig) bool
 	Parse(filePath string, config ParserConfig) (*ParserResult, error)
 }
+
+// IsMethodIncluded applies the method filters to a method by its display name
+// ("get_Name()") and by the display name qualified with its class
+// ("Shop.Cart.get_Name()"), so that both "-get_*" and "-*.Equals(*)" exclude methods.
+// Excluded methods are left out of the methods, code elements and method metrics of a
+// class; their lines still count towards the line and branch coverage.
+func IsMethodIncluded(config ParserConfig, className, methodDisplayName string) bool {
+	return config.MethodFilters().IsAnyNameIncludedInReport(methodDisplayName, className+"."+methodDisplayName)
+}
+
+// IsFileIncluded applies the file filters to a file by all paths it is known by: the path
+// in the report, the resolved path of the source file and, for Go profiles, the path
+// relative to the module ("internal/utils/paths.go" for "example.com/mod/internal/utils/paths.go").
+// Empty paths are ignored. Like IsMethodIncluded, the file is included if any path matches
+// an include filter and none matches an exclude filter, so "-internal/generated/*" or
+// "-*/generated/*" excludes the same file in a Cobertura report with relative paths and in
+// a Go profile.
+func IsFileIncluded(config ParserConfig, paths ...string) bool {
+	names := slices.DeleteFunc(slices.Clone(paths), func(path string) bool { return path == "" })
+	return config.FileFilters().IsAnyNameIncludedInReport(names...)
+}
+
+// IsInSourceDirectories classifies a source file for model.CodeFile.InSourceDirs: it is
+// inside if its resolved path is below one of dirs, see utils.IsPathInDirectories, or if
+// there are no dirs to compare it with. A file whose source was not found is only known
+// by the path of the report, so it counts as inside unless Settings.StrictExternalFiles
+// is set.
+func IsInSourceDirectories(config ParserConfig, resolvedPath string, found bool, dirs []string) bool {
+	if !found {
+		return !config.Settings().StrictExternalFiles
+	}
+	if !slices.ContainsFunc(dirs, func(dir string) bool { return strings.TrimSpace(dir) != "" }) {
+		return true
+	}
+	return utils.IsPathInDirectories(resolvedPath, dirs, PathCaseMode(config))
+}
+
+// PathCaseMode returns how the case of file paths is treated, see Settings.PathCase.
+func PathCaseMode(config ParserConfig) utils.PathCaseMode {
+	// The mode was validated when the configuration was created.
+	mode, _ := utils.ParsePathCaseMode(config.Settings().PathCase)
+	return mode
+}
+
+// FileRetryPolicy returns how report files that are locked by another process are
+// retried, see Settings.FileRetries.
+func FileRetryPolicy(config ParserConfig) utils.FileRetryPolicy {
+	s := config.Settings()
+	return utils.FileRetryPolicy{
+		Retries: s.FileRetries,
+		Delay:   time.Duration(s.FileRetryDelayInMilliseconds) * time.Millisecond,
+		Timeout: time.Duration(s.FileRetryTimeoutInSeconds) * time.Second,
+	}
+}
+
+// SortedKeys returns the keys of a map of classes, packages or files in ascending order.
+// The parsers process them in this order, as map order is random: sorting keeps the
+// classes, logs and missing files stable between runs.
+func SortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// LogExternalFiles logs the files of a report that were left out because they are outside
+// the source directories, see Settings.ExcludeExternalFiles.
+func LogExternalFiles(logger *slog.Logger, files map[string]struct{}) {
+	if len(files) == 0 {
+		return
+	}
+	logger.Info("Excluded files outside the source directories", "count", len(files))
+	logger.Debug("Excluded external files", "files", slices.Sorted(maps.Keys(files)))
+}
+
+// LogFilteredFiles logs the files of a report that the file filters excluded.
+func LogFilteredFiles(logger *slog.Logger, files map[string]struct{}) {
+	if len(files) == 0 {
+		return
+	}
+	logger.Debug("Excluded files by file filters", "count", len(files), "files", slices.Sorted(maps.Keys(files)))
+}
@@ -0,0 +1,89 @@
+package vscoverage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// knownConverters are the tools looked up in PATH if no converter is configured, in this
+// order. Both convert .coverage files with the same merge command, see runConverter.
+var knownConverters = []string{"dotnet-coverage", "Microsoft.CodeCoverage.Console"}
+
+// waitDelay is how long runConverter waits for the output of a converter that was stopped
+// after the timeout, e.g. because a child process still holds its stderr.
+const waitDelay = 2 * time.Second
+
+// maxStderrLength limits the converter output that is included in an error.
+const maxStderrLength = 4000
+
+// findConverter returns the path of the configured converter (-coverageconverter), or of
+// the first known converter found in PATH.
+func (p *VsCoverageParser) findConverter(configured string) (string, error) {
+	if configured != "" {
+		path, err := p.lookPath(configured)
+		if err != nil {
+			return "", fmt.Errorf("coverage converter %q (-coverageconverter) cannot be run: %w", configured, err)
+		}
+		return path, nil
+	}
+	for _, name := range knownConverters {
+		if path, err := p.lookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("cannot convert Visual Studio .coverage files: neither %s was found in PATH. "+
+		"Install dotnet-coverage (dotnet tool install --global dotnet-coverage) or pass the path of a converter with -coverageconverter",
+		strings.Join(knownConverters, " nor "))
+}
+
+// runConverter converts the .coverage file input to the Cobertura report output with
+// "merge --output-format cobertura --output <output> <input>". The converter is stopped
+// after timeoutSeconds (0: no limit). Its stderr is part of the returned error.
+func runConverter(converter, input, output string, timeoutSeconds int, logger *slog.Logger) error {
+	ctx := context.Background()
+	var timeout time.Duration
+	if timeoutSeconds > 0 {
+		timeout = time.Duration(timeoutSeconds) * time.Second
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, converter, "merge", "--output-format", "cobertura", "--output", output, input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
+
+	logger.Debug("Converting .coverage file", "converter", converter, "output", output)
+	start := time.Now()
+	err := cmd.Run()
+	logger.Debug("Converter finished", "duration", time.Since(start), "stdout", strings.TrimSpace(stdout.String()))
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s did not convert %s within %s (-coverageconvertertimeout)%s", converter, input, timeout, formatStderr(&stderr))
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed to convert %s: %w%s", converter, input, err, formatStderr(&stderr))
+	}
+	return nil
+}
+
+// formatStderr returns the converter output for an error message, shortened to its end,
+// where the cause is usually printed.
+func formatStderr(stderr *bytes.Buffer) string {
+	text := strings.TrimSpace(stderr.String())
+	if text == "" {
+		return ""
+	}
+	if len(text) > maxStderrLength {
+		text = "..." + text[len(text)-maxStderrLength:]
+	}
+	return "\n" + text
+}
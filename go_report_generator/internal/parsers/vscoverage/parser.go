@@ -0,0 +1,100 @@
+package vscoverage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/parsers/cobertura"
+)
+
+// headerSize is the number of bytes SupportsFile inspects.
+const headerSize = 512
+
+// VsCoverageParser implements the parsers.IParser interface for the binary .coverage files
+// of Visual Studio and dotnet-coverage. The binary format is not documented, so the files
+// are converted to Cobertura by an external converter (see converter.go) and the result is
+// parsed by the Cobertura parser.
+type VsCoverageParser struct {
+	cobertura parsers.IParser
+	// lookPath finds the converter executables, exec.LookPath outside of tests.
+	lookPath func(file string) (string, error)
+}
+
+// NewVsCoverageParser creates a new parser instance.
+func NewVsCoverageParser(fileReader filereader.Reader) parsers.IParser {
+	return &VsCoverageParser{
+		cobertura: cobertura.NewCoberturaParser(fileReader),
+		lookPath:  exec.LookPath,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *VsCoverageParser) Name() string {
+	return "VisualStudioCoverage"
+}
+
+// DetectionHint describes the files SupportsFile accepts.
+func (p *VsCoverageParser) DetectionHint() string {
+	return "binary *.coverage file, converted with " + strings.Join(knownConverters, " or ")
+}
+
+// SupportsFile accepts files with the .coverage extension whose header is binary. Text
+// formats never contain NUL bytes, so e.g. a Cobertura or XML export that was saved with
+// the .coverage extension is left to the other parsers.
+func (p *VsCoverageParser) SupportsFile(filePath string, config parsers.ParserConfig) bool {
+	if !strings.EqualFold(filepath.Ext(filePath), ".coverage") {
+		return false
+	}
+	f, err := parsers.FileRetryPolicy(config).Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header := make([]byte, headerSize)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return false
+	}
+	return bytes.IndexByte(header[:n], 0) >= 0
+}
+
+// Parse converts the .coverage file to a Cobertura report in a temporary directory and
+// parses that report. The temporary directory is removed afterwards.
+func (p *VsCoverageParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	converter, err := p.findConverter(config.Settings().CoverageConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir, err := os.MkdirTemp("", "reportgenerator-coverage-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a directory for the converted report: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	coberturaPath := filepath.Join(tempDir, "coverage.cobertura.xml")
+	if err := runConverter(converter, filePath, coberturaPath, config.Settings().CoverageConverterTimeoutInSeconds, logger); err != nil {
+		return nil, err
+	}
+	if !p.cobertura.SupportsFile(coberturaPath, config) {
+		return nil, fmt.Errorf("%s did not convert %s to a Cobertura report", converter, filePath)
+	}
+
+	result, err := p.cobertura.Parse(coberturaPath, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the converted report of %s: %w", filePath, err)
+	}
+	result.ParserName = p.Name()
+	return result, nil
+}
@@ -0,0 +1,177 @@
+package vscoverage
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language/defaultformatter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const convertedCoberturaXML = `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.5" branch-rate="1" timestamp="1700000000" version="1.9">
+  <packages>
+    <package name="MyAssembly" line-rate="0.5" branch-rate="1">
+      <classes>
+        <class name="MyAssembly.Calc" filename="Calc.cs" line-rate="0.5" branch-rate="1">
+          <methods />
+          <lines><line number="3" hits="2" branch="false" /><line number="4" hits="0" branch="false" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+// writeCoverageFile writes a file that passes SupportsFile.
+func writeCoverageFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "run.coverage")
+	require.NoError(t, os.WriteFile(path, []byte("MSCC\x00\x01\x02binary coverage data"), 0o644))
+	return path
+}
+
+// writeFakeConverter writes a shell script that stands in for dotnet-coverage. It records
+// its arguments next to itself and runs body, in which $out is the --output argument.
+func writeFakeConverter(t *testing.T, name, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake converter is a shell script")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > \"" + filepath.Join(dir, "args.txt") + "\"\nout=\"$5\"\n" + body + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path
+}
+
+func newTestConfig(t *testing.T, converter string, timeoutSeconds int) *reportconfig.ReportConfiguration {
+	t.Helper()
+	appSettings := settings.NewSettings()
+	appSettings.CoverageConverter = converter
+	appSettings.CoverageConverterTimeoutInSeconds = timeoutSeconds
+	config, err := reportconfig.NewReportConfiguration(nil, t.TempDir(),
+		reportconfig.WithSettings(appSettings),
+		reportconfig.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		reportconfig.WithLanguageProcessorFactory(language.NewProcessorFactory(defaultformatter.NewDefaultProcessor())),
+	)
+	require.NoError(t, err)
+	return config
+}
+
+func TestVsCoverageParser_SupportsFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	parser := NewVsCoverageParser(filereader.NewDefaultReader())
+	config := newTestConfig(t, "", 10)
+
+	assert.True(t, parser.SupportsFile(writeCoverageFile(t), config))
+	assert.True(t, parser.SupportsFile(write("RUN.COVERAGE", "\x00"), config))
+	assert.False(t, parser.SupportsFile(write("export.coverage", convertedCoberturaXML), config), "text files are left to the other parsers")
+	assert.False(t, parser.SupportsFile(write("binary.xml", "\x00\x01"), config))
+	assert.False(t, parser.SupportsFile(filepath.Join(dir, "missing.coverage"), config))
+}
+
+func TestVsCoverageParser_ParsesConvertedReport(t *testing.T) {
+	converter := writeFakeConverter(t, "dotnet-coverage", "cat > \"$out\" <<'XML'\n"+convertedCoberturaXML+"\nXML")
+	input := writeCoverageFile(t)
+
+	result, err := NewVsCoverageParser(filereader.NewDefaultReader()).Parse(input, newTestConfig(t, converter, 10))
+
+	require.NoError(t, err)
+	assert.Equal(t, "VisualStudioCoverage", result.ParserName)
+	require.Len(t, result.Assemblies, 1)
+	require.Len(t, result.Assemblies[0].Classes, 1)
+	assert.Equal(t, "MyAssembly.Calc", result.Assemblies[0].Classes[0].Name)
+	assert.Equal(t, 1, result.Assemblies[0].Classes[0].LinesCovered)
+	assert.Equal(t, 2, result.Assemblies[0].Classes[0].LinesValid)
+
+	args, err := os.ReadFile(filepath.Join(filepath.Dir(converter), "args.txt"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(args)), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, []string{"merge", "--output-format", "cobertura", "--output"}, lines[:4])
+	assert.Equal(t, input, lines[5])
+	assert.NoFileExists(t, lines[4], "the converted report is removed")
+}
+
+func TestVsCoverageParser_ConverterFromPath(t *testing.T) {
+	converter := writeFakeConverter(t, "Microsoft.CodeCoverage.Console", "cat > \"$out\" <<'XML'\n"+convertedCoberturaXML+"\nXML")
+	t.Setenv("PATH", filepath.Dir(converter)+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	result, err := NewVsCoverageParser(filereader.NewDefaultReader()).Parse(writeCoverageFile(t), newTestConfig(t, "", 10))
+
+	require.NoError(t, err)
+	assert.Len(t, result.Assemblies, 1)
+}
+
+func TestVsCoverageParser_MissingConverter(t *testing.T) {
+	parser := &VsCoverageParser{lookPath: func(file string) (string, error) { return "", exec.ErrNotFound }}
+
+	_, err := parser.Parse(writeCoverageFile(t), newTestConfig(t, "", 10))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "neither dotnet-coverage nor Microsoft.CodeCoverage.Console was found in PATH")
+	assert.Contains(t, err.Error(), "-coverageconverter")
+
+	_, err = parser.Parse(writeCoverageFile(t), newTestConfig(t, "/opt/tools/dotnet-coverage", 10))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `coverage converter "/opt/tools/dotnet-coverage" (-coverageconverter) cannot be run`)
+	assert.True(t, errors.Is(err, exec.ErrNotFound))
+}
+
+func TestVsCoverageParser_ConverterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		timeout int
+		want    []string
+	}{
+		{
+			name: "FailureWithStderr",
+			body: "echo 'Error: the file is not a coverage file' >&2\nexit 3",
+			want: []string{"failed to convert", "exit status 3", "Error: the file is not a coverage file"},
+		},
+		{
+			name:    "Timeout",
+			body:    "echo 'Merging...' >&2\nexec sleep 30",
+			timeout: 1,
+			want:    []string{"did not convert", "within 1s (-coverageconvertertimeout)", "Merging..."},
+		},
+		{
+			name: "NotCobertura",
+			body: "echo '<results><modules /></results>' > \"$out\"",
+			want: []string{"did not convert", "to a Cobertura report"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := writeFakeConverter(t, "dotnet-coverage", tt.body)
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 10
+			}
+
+			_, err := NewVsCoverageParser(filereader.NewDefaultReader()).Parse(writeCoverageFile(t), newTestConfig(t, converter, timeout))
+
+			require.Error(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Package progress reports how far long-running steps of a run are, e.g. parsing many
+// report files or rendering many class pages. Parsers and reporters count their units
+// of work on a Task; whether the progress is logged or shown on a terminal line is
+// decided by the Reporter the command creates.
+package progress
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+)
+
+// defaultEvery is the interval of steps without a total and without Step.Every.
+const defaultEvery = 100
+
+// Reporter creates a Task for each long-running step.
+type Reporter interface {
+	Start(step Step) Task
+}
+
+// Task counts the units of work of one step. Done must be called when the step ends,
+// also if fewer units than Step.Total were done.
+type Task interface {
+	Increment()
+	Done()
+}
+
+// Step describes a long-running step.
+type Step struct {
+	Action string // Past tense verb of the message, e.g. "Parsed"
+	Unit   string // Plural name of the units of work, e.g. "report files"
+	Total  int    // Number of units, 0 if unknown
+	Every  int    // Report after this many units; 0 reports every 10 percent of Total
+}
+
+// interval returns after how many units the progress of the step is reported.
+func (s Step) interval() int {
+	switch {
+	case s.Every > 0:
+		return s.Every
+	case s.Total > 0:
+		return max(1, (s.Total+9)/10)
+	}
+	return defaultEvery
+}
+
+// message formats the progress, e.g. "Parsed 120/400 report files".
+func (s Step) message(done int) string {
+	if s.Total > 0 {
+		return fmt.Sprintf("%s %d/%d %s", s.Action, done, s.Total, s.Unit)
+	}
+	return fmt.Sprintf("%s %d %s", s.Action, done, s.Unit)
+}
+
+// counter counts the units of a step and decides when they are reported.
+type counter struct {
+	step     Step
+	done     int
+	reported int
+}
+
+// increment counts one unit and returns true if the progress should be reported: after
+// every interval of units and when the last unit is done.
+func (c *counter) increment() bool {
+	c.done++
+	if c.done == c.step.Total || c.done-c.reported >= c.step.interval() {
+		c.reported = c.done
+		return true
+	}
+	return false
+}
+
+// finish returns true if units were done since the progress was last reported.
+func (c *counter) finish() bool {
+	if c.done == c.reported {
+		return false
+	}
+	c.reported = c.done
+	return true
+}
+
+// Discard ignores all progress.
+var Discard Reporter = discard{}
+
+type discard struct{}
+
+func (discard) Start(Step) Task { return discard{} }
+func (discard) Increment()      {}
+func (discard) Done()           {}
+
+// NewLogReporter returns a Reporter that logs the progress of each step at Info level,
+// e.g. "Parsed 120/400 report files".
+func NewLogReporter(logger *slog.Logger) Reporter {
+	return &logReporter{logger: logger}
+}
+
+type logReporter struct {
+	logger *slog.Logger
+}
+
+func (r *logReporter) Start(step Step) Task {
+	return &logTask{logger: r.logger, counter: counter{step: step}}
+}
+
+type logTask struct {
+	logger  *slog.Logger
+	counter counter
+}
+
+func (t *logTask) Increment() {
+	if t.counter.increment() {
+		t.logger.Info(t.counter.step.message(t.counter.done))
+	}
+}
+
+func (t *logTask) Done() {
+	if t.counter.finish() {
+		t.logger.Info(t.counter.step.message(t.counter.done))
+	}
+}
+
+// Multi returns a Reporter that passes the progress to all reporters.
+func Multi(reporters ...Reporter) Reporter {
+	return multiReporter(reporters)
+}
+
+type multiReporter []Reporter
+
+func (m multiReporter) Start(step Step) Task {
+	tasks := make(multiTask, len(m))
+	for i, r := range m {
+		tasks[i] = r.Start(step)
+	}
+	return tasks
+}
+
+type multiTask []Task
+
+func (m multiTask) Increment() {
+	for _, t := range m {
+		t.Increment()
+	}
+}
+
+func (m multiTask) Done() {
+	for _, t := range m {
+		t.Done()
+	}
+}
+
+// Stater is implemented by *os.File. Tests stub it to decide whether a file is a
+// terminal.
+type Stater interface {
+	Stat() (fs.FileInfo, error)
+}
+
+// IsTerminal reports whether f is a terminal (a character device), as opposed to a file
+// or a pipe the output is redirected to.
+func IsTerminal(f Stater) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
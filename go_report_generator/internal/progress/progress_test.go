@@ -0,0 +1,183 @@
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCounter_ReportsEveryIntervalAndTheLastUnit(t *testing.T) {
+	testCases := []struct {
+		name string
+		step Step
+		want []int
+	}{
+		{name: "Every", step: Step{Total: 25, Every: 10}, want: []int{10, 20, 25}},
+		{name: "TenPercent", step: Step{Total: 20}, want: []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20}},
+		{name: "FewUnits", step: Step{Total: 3}, want: []int{1, 2, 3}},
+		{name: "UnknownTotal", step: Step{Every: 4}, want: []int{4, 8}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := counter{step: tc.step}
+			units := tc.step.Total
+			if units == 0 {
+				units = 10
+			}
+			var reported []int
+			for i := 0; i < units; i++ {
+				if c.increment() {
+					reported = append(reported, c.done)
+				}
+			}
+			if !slices.Equal(reported, tc.want) {
+				t.Errorf("reported after %v units, want %v", reported, tc.want)
+			}
+		})
+	}
+}
+
+func TestCounter_FinishReportsUnreportedUnits(t *testing.T) {
+	c := counter{step: Step{Total: 100, Every: 10}}
+	for i := 0; i < 10; i++ {
+		c.increment()
+	}
+	if c.finish() {
+		t.Error("expected no report when the last unit was reported")
+	}
+	c.increment()
+	if !c.finish() {
+		t.Error("expected a report for the unit done since the last report")
+	}
+}
+
+func TestLogReporter_LogsProgress(t *testing.T) {
+	var logs bytes.Buffer
+	reporter := NewLogReporter(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	task := reporter.Start(Step{Action: "Parsed", Unit: "report files", Total: 25, Every: 10})
+	for i := 0; i < 24; i++ {
+		task.Increment()
+	}
+	task.Done()
+
+	for _, want := range []string{`msg="Parsed 10/25 report files"`, `msg="Parsed 20/25 report files"`, `msg="Parsed 24/25 report files"`} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected %s in the logs, got:\n%s", want, logs.String())
+		}
+	}
+	if got := strings.Count(logs.String(), "\n"); got != 3 {
+		t.Errorf("expected 3 records, got %d:\n%s", got, logs.String())
+	}
+}
+
+func TestMulti_PassesProgressToAllReporters(t *testing.T) {
+	var first, second bytes.Buffer
+	reporter := Multi(NewLogReporter(slog.New(slog.NewTextHandler(&first, nil))), NewLogReporter(slog.New(slog.NewTextHandler(&second, nil))))
+
+	task := reporter.Start(Step{Action: "Rendered", Unit: "class pages", Total: 1})
+	task.Increment()
+	task.Done()
+
+	for _, logs := range []string{first.String(), second.String()} {
+		if !strings.Contains(logs, "Rendered 1/1 class pages") {
+			t.Errorf("expected the progress in both logs, got:\n%s", logs)
+		}
+	}
+}
+
+// stepClock returns a clock that advances by step on every call.
+func stepClock(step time.Duration) func() time.Time {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestTerminal_RedrawsOneLine(t *testing.T) {
+	var out bytes.Buffer
+	terminal := NewTerminal(&out, WithClock(stepClock(60*time.Millisecond)))
+
+	task := terminal.Start(Step{Action: "Parsed", Unit: "report files", Total: 3})
+	task.Increment() // 60ms after the first draw: not redrawn
+	task.Increment() // 120ms: redrawn
+	task.Increment() // Last unit: redrawn
+
+	want := "\rParsed 0/3 report files\rParsed 2/3 report files\rParsed 3/3 report files"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	task.Done()
+	if want := "\r" + strings.Repeat(" ", len("Parsed 3/3 report files")) + "\r"; out.String() != want {
+		t.Errorf("expected Done to clear the line, got %q", out.String())
+	}
+}
+
+func TestTerminal_WritesLogsAboveTheLine(t *testing.T) {
+	var out bytes.Buffer
+	terminal := NewTerminal(&out)
+	terminal.Start(Step{Action: "Rendered", Unit: "class pages", Total: 10})
+	out.Reset()
+
+	if _, err := terminal.Write([]byte("level=INFO msg=record\n")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	clear := "\r" + strings.Repeat(" ", len("Rendered 0/10 class pages")) + "\r"
+	want := clear + "level=INFO msg=record\n" + "\rRendered 0/10 class pages"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestTerminal_PadsShorterLines(t *testing.T) {
+	var out bytes.Buffer
+	terminal := NewTerminal(&out)
+	terminal.Start(Step{Action: "Parsed", Unit: "report files", Total: 10})
+	out.Reset()
+
+	terminal.Start(Step{Action: "Generated", Unit: "types", Total: 1})
+
+	if want := "\rGenerated 0/1 types" + strings.Repeat(" ", len("Parsed 0/10 report files")-len("Generated 0/1 types")); out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+type fileInfo struct {
+	fs.FileInfo
+	mode fs.FileMode
+}
+
+func (f fileInfo) Mode() fs.FileMode { return f.mode }
+
+type stubStater struct {
+	info fs.FileInfo
+	err  error
+}
+
+func (s stubStater) Stat() (fs.FileInfo, error) { return s.info, s.err }
+
+func TestIsTerminal(t *testing.T) {
+	if !IsTerminal(stubStater{info: fileInfo{mode: os.ModeDevice | os.ModeCharDevice}}) {
+		t.Error("expected a character device to be a terminal")
+	}
+	if IsTerminal(stubStater{info: fileInfo{mode: os.ModeNamedPipe}}) {
+		t.Error("expected a pipe not to be a terminal")
+	}
+	if IsTerminal(stubStater{info: fileInfo{}}) {
+		t.Error("expected a regular file not to be a terminal")
+	}
+	if IsTerminal(stubStater{err: errors.New("closed")}) {
+		t.Error("expected no terminal if the file cannot be inspected")
+	}
+}
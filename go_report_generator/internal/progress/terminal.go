@@ -0,0 +1,110 @@
+package progress
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redrawInterval limits how often the progress line is redrawn.
+const redrawInterval = 100 * time.Millisecond
+
+// Terminal shows the progress of the current step as a single line that is redrawn in
+// place with a carriage return. It is also an io.Writer for the log output to the same
+// terminal: the line is cleared before a log record is written and redrawn afterwards,
+// so that records and the line do not mix.
+type Terminal struct {
+	mu       sync.Mutex
+	out      io.Writer
+	now      func() time.Time
+	line     string // The line shown, empty if none
+	lastDraw time.Time
+}
+
+// TerminalOption configures a Terminal.
+type TerminalOption func(*Terminal)
+
+// WithClock replaces time.Now, which limits how often the line is redrawn.
+func WithClock(now func() time.Time) TerminalOption {
+	return func(t *Terminal) {
+		if now != nil {
+			t.now = now
+		}
+	}
+}
+
+// NewTerminal creates a Terminal that draws on out, usually os.Stderr.
+func NewTerminal(out io.Writer, opts ...TerminalOption) *Terminal {
+	t := &Terminal{out: out, now: time.Now}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Start shows the progress of step, replacing the line of an earlier step.
+func (t *Terminal) Start(step Step) Task {
+	task := &terminalTask{terminal: t, counter: counter{step: step}}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.draw(step.message(0))
+	return task
+}
+
+// Write writes p, usually a log record, above the progress line.
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	line := t.line
+	t.clear()
+	n, err := t.out.Write(p)
+	if line != "" {
+		t.draw(line)
+	}
+	return n, err
+}
+
+// draw replaces the shown line. Must be called with t.mu held.
+func (t *Terminal) draw(line string) {
+	padding := ""
+	if len(t.line) > len(line) {
+		padding = strings.Repeat(" ", len(t.line)-len(line))
+	}
+	_, _ = io.WriteString(t.out, "\r"+line+padding)
+	t.line = line
+	t.lastDraw = t.now()
+}
+
+// clear removes the shown line. Must be called with t.mu held.
+func (t *Terminal) clear() {
+	if t.line == "" {
+		return
+	}
+	_, _ = io.WriteString(t.out, "\r"+strings.Repeat(" ", len(t.line))+"\r")
+	t.line = ""
+}
+
+type terminalTask struct {
+	terminal *Terminal
+	counter  counter
+}
+
+// Increment redraws the line at most every redrawInterval, and always for the last unit.
+func (task *terminalTask) Increment() {
+	t := task.terminal
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	task.counter.done++
+	if task.counter.done == task.counter.step.Total || t.now().Sub(t.lastDraw) >= redrawInterval {
+		t.draw(task.counter.step.message(task.counter.done))
+	}
+}
+
+// Done removes the line.
+func (task *terminalTask) Done() {
+	t := task.terminal
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.clear()
+}
@@ -0,0 +1,302 @@
+package reportconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileNames are the configuration files looked for in the working directory when
+// no -config is given, in this order.
+var ConfigFileNames = []string{"reportgenerator.yaml", "reportgenerator.yml", "reportgenerator.json"}
+
+// FileConfig is the content of a configuration file. Its keys are the names of the command
+// line flags. List fields are joined with the separator of their flag (the sep tag), and
+// fields that are not set (nil) leave the flag alone.
+type FileConfig struct {
+	Report                      []string          `yaml:"report,omitempty" json:"report,omitempty" sep:";"`
+	Output                      *string           `yaml:"output,omitempty" json:"output,omitempty"`
+	OutputSubdirs               *bool             `yaml:"outputsubdirs,omitempty" json:"outputsubdirs,omitempty"`
+	TextSummaryFile             *string           `yaml:"textsummaryfile,omitempty" json:"textsummaryfile,omitempty"`
+	ReportTypes                 []string          `yaml:"reporttypes,omitempty" json:"reporttypes,omitempty" sep:","`
+	CompareWith                 []string          `yaml:"comparewith,omitempty" json:"comparewith,omitempty" sep:";"`
+	SourceDirs                  []string          `yaml:"sourcedirs,omitempty" json:"sourcedirs,omitempty" sep:","`
+	AutoDiscoverSources         *bool             `yaml:"autodiscoversources,omitempty" json:"autodiscoversources,omitempty"`
+	StoreSources                *bool             `yaml:"storesources,omitempty" json:"storesources,omitempty"`
+	SourceEncoding              *string           `yaml:"sourceencoding,omitempty" json:"sourceencoding,omitempty"`
+	CoverageConverter           *string           `yaml:"coverageconverter,omitempty" json:"coverageconverter,omitempty"`
+	CoverageConverterTimeout    *int              `yaml:"coverageconvertertimeout,omitempty" json:"coverageconvertertimeout,omitempty"`
+	RawMode                     *bool             `yaml:"rawmode,omitempty" json:"rawmode,omitempty"`
+	KeepNestedClasses           *bool             `yaml:"keepnestedclasses,omitempty" json:"keepnestedclasses,omitempty"`
+	ExcludeGeneratedCode        *bool             `yaml:"excludegeneratedcode,omitempty" json:"excludegeneratedcode,omitempty"`
+	ExcludeTests                *bool             `yaml:"excludetests,omitempty" json:"excludetests,omitempty"`
+	GoApproximateBranchCoverage *bool             `yaml:"goapproximatebranchcoverage,omitempty" json:"goapproximatebranchcoverage,omitempty"`
+	FullMethodMinLineRate       *float64          `yaml:"fullmethodcoverage-minlinerate,omitempty" json:"fullmethodcoverage-minlinerate,omitempty"`
+	FullMethodBranches          *bool             `yaml:"fullmethodcoverage-branches,omitempty" json:"fullmethodcoverage-branches,omitempty"`
+	ExclusionComments           *bool             `yaml:"exclusioncomments,omitempty" json:"exclusioncomments,omitempty"`
+	ExclusionMarkers            map[string]string `yaml:"exclusionmarkers,omitempty" json:"exclusionmarkers,omitempty"` // Language -> "line|start|end|next"
+	LanguageFormatter           *string           `yaml:"languageformatter,omitempty" json:"languageformatter,omitempty"`
+	AssemblyGrouping            *int              `yaml:"assemblygrouping,omitempty" json:"assemblygrouping,omitempty"`
+	UncoveredLines              *int              `yaml:"uncoveredlines,omitempty" json:"uncoveredlines,omitempty"`
+	MetricThresholds            map[string]string `yaml:"metricthresholds,omitempty" json:"metricthresholds,omitempty"` // Metric name -> "warning[:error]"
+	Metrics                     map[string]string `yaml:"metrics,omitempty" json:"metrics,omitempty"`                   // Metric name -> "[url][,order]"
+	CoverageQuotaRounding       *string           `yaml:"coveragequotarounding,omitempty" json:"coveragequotarounding,omitempty"`
+	FailOnMissingSources        *bool             `yaml:"failonmissingsources,omitempty" json:"failonmissingsources,omitempty"`
+	MergeMode                   *string           `yaml:"mergemode,omitempty" json:"mergemode,omitempty"`
+	SourceRootHints             []string          `yaml:"sourceroot-hint,omitempty" json:"sourceroot-hint,omitempty" sep:","`
+	FailOnDuplicateReports      *bool             `yaml:"failonduplicatereports,omitempty" json:"failonduplicatereports,omitempty"`
+	FailOnParseError            *bool             `yaml:"failonparseerror,omitempty" json:"failonparseerror,omitempty"`
+	DeclaredTotalsTolerance     *float64          `yaml:"declaredtotalstolerance,omitempty" json:"declaredtotalstolerance,omitempty"`
+	Tag                         *string           `yaml:"tag,omitempty" json:"tag,omitempty"`
+	TagLink                     *string           `yaml:"taglink,omitempty" json:"taglink,omitempty"`
+	Title                       *string           `yaml:"title,omitempty" json:"title,omitempty"`
+	AssemblyFilters             []string          `yaml:"assemblyfilters,omitempty" json:"assemblyfilters,omitempty" sep:";"`
+	ClassFilters                []string          `yaml:"classfilters,omitempty" json:"classfilters,omitempty" sep:";"`
+	FileFilters                 []string          `yaml:"filefilters,omitempty" json:"filefilters,omitempty" sep:";"`
+	MethodFilters               []string          `yaml:"methodfilters,omitempty" json:"methodfilters,omitempty" sep:";"`
+	RiskHotspotAssemblyFilters  []string          `yaml:"riskhotspotassemblyfilters,omitempty" json:"riskhotspotassemblyfilters,omitempty" sep:";"`
+	RiskHotspotClassFilters     []string          `yaml:"riskhotspotclassfilters,omitempty" json:"riskhotspotclassfilters,omitempty" sep:";"`
+	ClassCoverageFilter         *string           `yaml:"classcoveragefilter,omitempty" json:"classcoveragefilter,omitempty"`
+	RecomputeAggregates         *bool             `yaml:"recomputeaggregates,omitempty" json:"recomputeaggregates,omitempty"`
+	Language                    *string           `yaml:"language,omitempty" json:"language,omitempty"`
+	TranslationsFile            *string           `yaml:"translationsfile,omitempty" json:"translationsfile,omitempty"`
+	SyntaxHighlight             *bool             `yaml:"syntaxhighlight,omitempty" json:"syntaxhighlight,omitempty"`
+	MaxLineLength               *int              `yaml:"maxlinelength,omitempty" json:"maxlinelength,omitempty"`
+	MinifiedLineLength          *int              `yaml:"minifiedlinelength,omitempty" json:"minifiedlinelength,omitempty"`
+	SummarySort                 *string           `yaml:"summarysort,omitempty" json:"summarysort,omitempty"`
+	SummaryTopN                 *int              `yaml:"summarytopn,omitempty" json:"summarytopn,omitempty"`
+	Incremental                 *bool             `yaml:"incremental,omitempty" json:"incremental,omitempty"`
+	Serve                       *string           `yaml:"serve,omitempty" json:"serve,omitempty"`
+	LongPaths                   *bool             `yaml:"longpaths,omitempty" json:"longpaths,omitempty"`
+	FileRetries                 *int              `yaml:"fileretries,omitempty" json:"fileretries,omitempty"`
+	FileRetryDelay              *int              `yaml:"fileretrydelay,omitempty" json:"fileretrydelay,omitempty"`
+	FileRetryTimeout            *int              `yaml:"fileretrytimeout,omitempty" json:"fileretrytimeout,omitempty"`
+	PathCase                    *string           `yaml:"pathcase,omitempty" json:"pathcase,omitempty"`
+	ResolveSymlinks             *bool             `yaml:"resolvesymlinks,omitempty" json:"resolvesymlinks,omitempty"`
+	MergeVendored               *bool             `yaml:"mergevendored,omitempty" json:"mergevendored,omitempty"`
+	ExcludeExternalFiles        *bool             `yaml:"excludeexternalfiles,omitempty" json:"excludeexternalfiles,omitempty"`
+	Strict                      *bool             `yaml:"strict,omitempty" json:"strict,omitempty"`
+	DumpModel                   *string           `yaml:"dumpmodel,omitempty" json:"dumpmodel,omitempty"`
+	DumpModelIncludeSource      *bool             `yaml:"dumpmodel-include-source,omitempty" json:"dumpmodel-include-source,omitempty"`
+	HistoryDir                  *string           `yaml:"historydir,omitempty" json:"historydir,omitempty"`
+	MaxHistoryFiles             *int              `yaml:"maxhistoryfiles,omitempty" json:"maxhistoryfiles,omitempty"`
+	HistoryRetentionDays        *int              `yaml:"historyretentiondays,omitempty" json:"historyretentiondays,omitempty"`
+	PruneHistory                *bool             `yaml:"prunehistory,omitempty" json:"prunehistory,omitempty"`
+	MaxHistoryPoints            *int              `yaml:"maxhistorypoints,omitempty" json:"maxhistorypoints,omitempty"`
+	CoverageAgeThreshold        *float64          `yaml:"coverageagethreshold,omitempty" json:"coverageagethreshold,omitempty"`
+	Verbose                     *bool             `yaml:"verbose,omitempty" json:"verbose,omitempty"`
+	Verbosity                   *string           `yaml:"verbosity,omitempty" json:"verbosity,omitempty"`
+	Quiet                       *bool             `yaml:"quiet,omitempty" json:"quiet,omitempty"`
+	StatsJSON                   *bool             `yaml:"statsjson,omitempty" json:"statsjson,omitempty"`
+	LogFile                     *string           `yaml:"logfile,omitempty" json:"logfile,omitempty"`
+	LogFormat                   *string           `yaml:"logformat,omitempty" json:"logformat,omitempty"`
+}
+
+// ConfigKeys returns the keys of a configuration file, i.e. the names of the flags it can set.
+func ConfigKeys() []string {
+	t := reflect.TypeOf(FileConfig{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		keys = append(keys, configKey(t.Field(i)))
+	}
+	return keys
+}
+
+func configKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	return name
+}
+
+// FindConfigFile returns explicitPath if it is set, otherwise the first of ConfigFileNames
+// that exists in dir, or "" if there is none.
+func FindConfigFile(explicitPath, dir string) (string, error) {
+	if explicitPath != "" {
+		return explicitPath, nil
+	}
+	for _, name := range ConfigFileNames {
+		path := filepath.Join(dir, name)
+		_, err := os.Stat(path)
+		if err == nil {
+			return path, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to check configuration file: %w", err)
+		}
+	}
+	return "", nil
+}
+
+// LoadConfigFile reads a YAML (.yaml, .yml) or JSON (.json) configuration file. It returns
+// the keys that are no configuration keys, sorted, so that the caller can warn about them.
+// Errors of malformed files name the offending line.
+func LoadConfigFile(path string) (*FileConfig, []string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var keys map[string]any
+	cfg := &FileConfig{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = decodeJSONConfig(content, &keys, cfg)
+	} else {
+		err = decodeYAMLConfig(content, &keys, cfg)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+
+	known := make(map[string]bool)
+	for _, key := range ConfigKeys() {
+		known[key] = true
+	}
+	var unknown []string
+	for key := range keys {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return cfg, unknown, nil
+}
+
+func decodeYAMLConfig(content []byte, keys *map[string]any, cfg *FileConfig) error {
+	// yaml.v3 errors already name the line, e.g. "yaml: line 3: did not find expected key".
+	if err := yaml.Unmarshal(content, keys); err != nil {
+		return err
+	}
+	return yaml.Unmarshal(content, cfg)
+}
+
+func decodeJSONConfig(content []byte, keys *map[string]any, cfg *FileConfig) error {
+	err := json.Unmarshal(content, keys)
+	if err == nil {
+		err = json.Unmarshal(content, cfg)
+	}
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+	line := 1 + bytes.Count(content[:min(int(offset), len(content))], []byte("\n"))
+	return fmt.Errorf("line %d: %w", line, err)
+}
+
+// Values returns the settings of the file in command line syntax, keyed by flag name.
+func (c *FileConfig) Values() map[string]string {
+	values := make(map[string]string)
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		switch value.Kind() {
+		case reflect.Pointer:
+			if !value.IsNil() {
+				values[configKey(field)] = fmt.Sprint(value.Elem().Interface())
+			}
+		case reflect.Slice:
+			if !value.IsNil() {
+				values[configKey(field)] = strings.Join(value.Interface().([]string), field.Tag.Get("sep"))
+			}
+		case reflect.Map:
+			if !value.IsNil() {
+				entries := value.Interface().(map[string]string)
+				pairs := make([]string, 0, len(entries))
+				for name, threshold := range entries {
+					pairs = append(pairs, name+"="+threshold)
+				}
+				sort.Strings(pairs)
+				values[configKey(field)] = strings.Join(pairs, ";")
+			}
+		}
+	}
+	return values
+}
+
+// ConfigFromValues is the inverse of Values: it returns the configuration of settings in
+// command line syntax, e.g. to print the effective configuration as a configuration file.
+// Keys that are no configuration keys are ignored.
+func ConfigFromValues(values map[string]string) (*FileConfig, error) {
+	cfg := &FileConfig{}
+	v := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		text, ok := values[configKey(field)]
+		if !ok {
+			continue
+		}
+		switch value.Kind() {
+		case reflect.Pointer:
+			parsed := reflect.New(field.Type.Elem())
+			var err error
+			switch field.Type.Elem().Kind() {
+			case reflect.String:
+				parsed.Elem().SetString(text)
+			case reflect.Bool:
+				var b bool
+				b, err = strconv.ParseBool(text)
+				parsed.Elem().SetBool(b)
+			case reflect.Int:
+				var n int64
+				n, err = strconv.ParseInt(text, 10, 0)
+				parsed.Elem().SetInt(n)
+			case reflect.Float64:
+				var f float64
+				f, err = strconv.ParseFloat(text, 64)
+				parsed.Elem().SetFloat(f)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q of %s: %w", text, configKey(field), err)
+			}
+			value.Set(parsed)
+		case reflect.Slice:
+			var items []string
+			for _, item := range strings.Split(text, field.Tag.Get("sep")) {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			value.Set(reflect.ValueOf(items))
+		case reflect.Map:
+			entries := make(map[string]string)
+			for _, pair := range strings.Split(text, ";") {
+				name, threshold, found := strings.Cut(pair, "=")
+				if found && strings.TrimSpace(name) != "" {
+					entries[strings.TrimSpace(name)] = strings.TrimSpace(threshold)
+				}
+			}
+			if len(entries) > 0 {
+				value.Set(reflect.ValueOf(entries))
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// MergeConfigValues merges settings in command line syntax. A later layer takes precedence
+// over the earlier ones, so the layers are passed from the lowest to the highest
+// precedence: defaults, configuration file, command line.
+func MergeConfigValues(layers ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, layer := range layers {
+		for key, value := range layer {
+			merged[key] = value
+		}
+	}
+	return merged
+}
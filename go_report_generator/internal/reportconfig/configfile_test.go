@@ -0,0 +1,173 @@
+package reportconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write configuration file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFile_ValuesInCommandLineSyntax(t *testing.T) {
+	yamlConfig := `report:
+  - coverage/a.xml
+  - coverage/**/b.xml
+reporttypes: ["Html{title=Backend}", TextSummary]
+sourcedirs: [src, lib]
+classfilters: ["+Shop.*", "-Shop.Tests.*"]
+metricthresholds:
+  CrapScore: "20:60"
+  Cyclomatic complexity: 10
+title: Backend
+rawmode: true
+uncoveredlines: 5
+declaredtotalstolerance: 0.5
+`
+	jsonConfig := `{
+  "report": ["coverage/a.xml", "coverage/**/b.xml"],
+  "reporttypes": ["Html{title=Backend}", "TextSummary"],
+  "sourcedirs": ["src", "lib"],
+  "classfilters": ["+Shop.*", "-Shop.Tests.*"],
+  "metricthresholds": {"CrapScore": "20:60", "Cyclomatic complexity": "10"},
+  "title": "Backend",
+  "rawmode": true,
+  "uncoveredlines": 5,
+  "declaredtotalstolerance": 0.5
+}`
+	want := map[string]string{
+		"report":                  "coverage/a.xml;coverage/**/b.xml",
+		"reporttypes":             "Html{title=Backend},TextSummary",
+		"sourcedirs":              "src,lib",
+		"classfilters":            "+Shop.*;-Shop.Tests.*",
+		"metricthresholds":        "CrapScore=20:60;Cyclomatic complexity=10",
+		"title":                   "Backend",
+		"rawmode":                 "true",
+		"uncoveredlines":          "5",
+		"declaredtotalstolerance": "0.5",
+	}
+
+	for name, content := range map[string]string{"reportgenerator.yaml": yamlConfig, "reportgenerator.json": jsonConfig} {
+		t.Run(name, func(t *testing.T) {
+			cfg, unknown, err := LoadConfigFile(writeConfigFile(t, name, content))
+			if err != nil {
+				t.Fatalf("LoadConfigFile returned error: %v", err)
+			}
+			if len(unknown) != 0 {
+				t.Errorf("unexpected unknown keys %v", unknown)
+			}
+			if got := cfg.Values(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Values() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile_UnknownKeys(t *testing.T) {
+	path := writeConfigFile(t, "reportgenerator.yml", "title: Backend\nrawMode: true\nthreshold: 3\n")
+
+	cfg, unknown, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile returned error: %v", err)
+	}
+	if want := []string{"rawMode", "threshold"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("unknown keys = %v, want %v", unknown, want)
+	}
+	if cfg.Title == nil || *cfg.Title != "Backend" {
+		t.Errorf("expected the known keys to be read, got title %v", cfg.Title)
+	}
+}
+
+func TestLoadConfigFile_MalformedFileNamesTheLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantLine string
+	}{
+		{name: "reportgenerator.yaml", content: "title: Backend\nsourcedirs:\n\t- src\n", wantLine: "line 3"},
+		{name: "reportgenerator.yaml", content: "title: Backend\n\nrawmode: maybe\n", wantLine: "line 3"},
+		{name: "reportgenerator.json", content: "{\n  \"title\": \"Backend\",\n  \"rawmode\": true,,\n}", wantLine: "line 3"},
+		{name: "reportgenerator.json", content: "{\n  \"title\": \"Backend\",\n  \"uncoveredlines\": \"five\"\n}", wantLine: "line 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.wantLine, func(t *testing.T) {
+			path := writeConfigFile(t, tt.name, tt.content)
+
+			_, _, err := LoadConfigFile(path)
+			if err == nil {
+				t.Fatal("expected an error for a malformed configuration file")
+			}
+			if !strings.Contains(err.Error(), tt.wantLine) || !strings.Contains(err.Error(), path) {
+				t.Errorf("error %q does not name %s and %s", err, path, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestFindConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	if path, err := FindConfigFile("", dir); err != nil || path != "" {
+		t.Errorf("FindConfigFile() = %q, %v, want no file", path, err)
+	}
+
+	for _, name := range []string{"reportgenerator.json", "reportgenerator.yml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o644); err != nil {
+			t.Fatalf("failed to write configuration file: %v", err)
+		}
+	}
+	if path, _ := FindConfigFile("", dir); filepath.Base(path) != "reportgenerator.yml" {
+		t.Errorf("FindConfigFile() = %q, want the .yml file before the .json file", path)
+	}
+	if path, _ := FindConfigFile("custom.yaml", dir); path != "custom.yaml" {
+		t.Errorf("FindConfigFile() = %q, want the explicit file", path)
+	}
+}
+
+func TestMergeConfigValues_LaterLayersTakePrecedence(t *testing.T) {
+	defaults := map[string]string{"output": "coverage-report", "title": "", "verbosity": "Error"}
+	file := map[string]string{"title": "From file", "verbosity": "Info"}
+	commandLine := map[string]string{"verbosity": "Verbose"}
+
+	got := MergeConfigValues(defaults, file, commandLine)
+
+	want := map[string]string{"output": "coverage-report", "title": "From file", "verbosity": "Verbose"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeConfigValues() = %v, want %v", got, want)
+	}
+}
+
+func TestConfigFromValues_RoundTrip(t *testing.T) {
+	values := map[string]string{
+		"report":           "a.xml;b.xml",
+		"sourcedirs":       "src",
+		"metricthresholds": "CrapScore=20:60",
+		"title":            "Backend",
+		"rawmode":          "true",
+		"maxhistoryfiles":  "10",
+		"assemblyfilters":  "",
+	}
+
+	cfg, err := ConfigFromValues(values)
+	if err != nil {
+		t.Fatalf("ConfigFromValues returned error: %v", err)
+	}
+	if cfg.AssemblyFilters != nil {
+		t.Errorf("expected an empty list to be left out, got %v", cfg.AssemblyFilters)
+	}
+	delete(values, "assemblyfilters")
+	if got := cfg.Values(); !reflect.DeepEqual(got, values) {
+		t.Errorf("Values() = %v, want %v", got, values)
+	}
+
+	if _, err := ConfigFromValues(map[string]string{"rawmode": "maybe"}); err == nil {
+		t.Error("expected an error for an invalid boolean")
+	}
+}
@@ -0,0 +1,93 @@
+package reportconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// coverageBoundRegex matches one comparison of a coverage range, e.g. ">=80".
+var coverageBoundRegex = regexp.MustCompile(`^\s*(<=|>=|<|>)\s*(\d+(?:\.\d+)?)\s*`)
+
+// CoverageRange is a range of coverage quotas (0 to 100) given as one or two comparisons,
+// e.g. "<100" (not fully covered) or ">=0<80" (below 80%).
+type CoverageRange struct {
+	text                           string
+	lower, upper                   float64
+	hasLower, hasUpper             bool
+	lowerInclusive, upperInclusive bool
+}
+
+// ParseCoverageRange parses a coverage range such as "<100", ">50" or ">=0<80". At most
+// one lower (> or >=) and one upper (< or <=) bound may be given.
+func ParseCoverageRange(value string) (*CoverageRange, error) {
+	r := &CoverageRange{text: strings.TrimSpace(value)}
+	if r.text == "" {
+		return nil, fmt.Errorf("empty coverage range")
+	}
+
+	rest := r.text
+	for rest != "" {
+		match := coverageBoundRegex.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, fmt.Errorf("invalid coverage range %q: expected a comparison like <80 or >=50 at %q", value, rest)
+		}
+		rest = rest[len(match[0]):]
+
+		bound, err := strconv.ParseFloat(match[2], 64)
+		if err != nil || bound > 100 {
+			return nil, fmt.Errorf("invalid coverage range %q: %s is not a percentage between 0 and 100", value, match[2])
+		}
+		switch operator := match[1]; operator {
+		case ">", ">=":
+			if r.hasLower {
+				return nil, fmt.Errorf("invalid coverage range %q: more than one lower bound", value)
+			}
+			r.lower, r.hasLower, r.lowerInclusive = bound, true, operator == ">="
+		case "<", "<=":
+			if r.hasUpper {
+				return nil, fmt.Errorf("invalid coverage range %q: more than one upper bound", value)
+			}
+			r.upper, r.hasUpper, r.upperInclusive = bound, true, operator == "<="
+		}
+	}
+
+	if r.hasLower && r.hasUpper && (r.lower > r.upper || (r.lower == r.upper && !(r.lowerInclusive && r.upperInclusive))) {
+		return nil, fmt.Errorf("invalid coverage range %q: no coverage is inside the range", value)
+	}
+	return r, nil
+}
+
+// Contains reports whether a coverage quota (0 to 100) lies inside the range.
+func (r *CoverageRange) Contains(coverage float64) bool {
+	if r.hasLower && (coverage < r.lower || (coverage == r.lower && !r.lowerInclusive)) {
+		return false
+	}
+	if r.hasUpper && (coverage > r.upper || (coverage == r.upper && !r.upperInclusive)) {
+		return false
+	}
+	return true
+}
+
+// String returns the range as it was given.
+func (r *CoverageRange) String() string {
+	return r.text
+}
+
+// WithClassCoverageFilter keeps only the classes whose line coverage lies in the range,
+// e.g. "<100" to hide fully covered classes. An empty value disables the filter.
+func WithClassCoverageFilter(value string) Option {
+	return func(c *ReportConfiguration) error {
+		if strings.TrimSpace(value) == "" {
+			c.ClassCoverageRange = nil
+			return nil
+		}
+		coverageRange, err := ParseCoverageRange(value)
+		if err != nil {
+			return fmt.Errorf("invalid class coverage filter: %w", err)
+		}
+		c.ClassCoverageRange = coverageRange
+		return nil
+	}
+}
@@ -4,18 +4,42 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/language"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/logging"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/progress"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
 var supportedReportTypes = map[string]bool{
-	"TextSummary": true,
-	"Html":        true,
-	"Lcov":        true,
+	"TextSummary":       true,
+	"Html":              true,
+	"HtmlSummary":       true,
+	"Lcov":              true,
+	"DeltaSummary":      true,
+	"XmlSummary":        true,
+	"Clover":            true,
+	"BadgesPerAssembly": true,
+	"CoverageGutters":   true,
+}
+
+// reportTypeSubdirectories names the subdirectory of the target directory each
+// report type is written to when CreateSubdirectoryForAllReportTypes is enabled.
+var reportTypeSubdirectories = map[string]string{
+	"TextSummary":       "text",
+	"Html":              "html",
+	"HtmlSummary":       "htmlsummary",
+	"Lcov":              "lcov",
+	"DeltaSummary":      "delta",
+	"XmlSummary":        "xml",
+	"Clover":            "clover",
+	"BadgesPerAssembly": "badges",
+	"CoverageGutters":   "coveragegutters",
 }
 
 // ReportConfiguration struct remains the same.
@@ -25,21 +49,28 @@ type ReportConfiguration struct {
 	SDirectories                  []string
 	HDirectory                    string
 	RTypes                        []string
+	RTypeParams                   map[string]map[string]string
 	PluginsList                   []string
 	AssemblyFilterInstance        filtering.IFilter
 	ClassFilterInstance           filtering.IFilter
 	FileFilterInstance            filtering.IFilter
+	MethodFilterInstance          filtering.IFilter
 	RiskHotspotAssemblyFilterInst filtering.IFilter
 	RiskHotspotClassFilterInst    filtering.IFilter
+	ClassCoverageRange            *CoverageRange
 	VLevel                        logging.VerbosityLevel
 	CfgTag                        string
 	CfgTitle                      string
+	CfgTagLink                    string
 	CfgLicense                    string
 	InvalidPatterns               []string
 	VLevelValid                   bool
 	App                           *settings.Settings
 	logr                          *slog.Logger
 	LangFactory                   *language.ProcessorFactory
+	SrcIndex                      *utils.SourceFileIndex
+	SrcResolver                   *utils.SourceFileResolver
+	ProgressReporter              progress.Reporter
 }
 
 // All accessor methods remain the same.
@@ -52,15 +83,22 @@ func (rc *ReportConfiguration) Plugins() []string                  { return rc.P
 func (rc *ReportConfiguration) AssemblyFilters() filtering.IFilter { return rc.AssemblyFilterInstance }
 func (rc *ReportConfiguration) ClassFilters() filtering.IFilter    { return rc.ClassFilterInstance }
 func (rc *ReportConfiguration) FileFilters() filtering.IFilter     { return rc.FileFilterInstance }
+func (rc *ReportConfiguration) MethodFilters() filtering.IFilter   { return rc.MethodFilterInstance }
 func (rc *ReportConfiguration) RiskHotspotAssemblyFilters() filtering.IFilter {
 	return rc.RiskHotspotAssemblyFilterInst
 }
 func (rc *ReportConfiguration) RiskHotspotClassFilters() filtering.IFilter {
 	return rc.RiskHotspotClassFilterInst
 }
+
+// ClassCoverageFilter returns the line coverage range of the classes to keep in the
+// reports, or nil if all classes are kept.
+func (rc *ReportConfiguration) ClassCoverageFilter() *CoverageRange { return rc.ClassCoverageRange }
+
 func (rc *ReportConfiguration) VerbosityLevel() logging.VerbosityLevel { return rc.VLevel }
 func (rc *ReportConfiguration) Tag() string                            { return rc.CfgTag }
 func (rc *ReportConfiguration) Title() string                          { return rc.CfgTitle }
+func (rc *ReportConfiguration) TagLinkTemplate() string                { return rc.CfgTagLink }
 func (rc *ReportConfiguration) License() string                        { return rc.CfgLicense }
 func (rc *ReportConfiguration) InvalidReportFilePatterns() []string    { return rc.InvalidPatterns }
 func (rc *ReportConfiguration) IsVerbosityLevelValid() bool            { return rc.VLevelValid }
@@ -70,6 +108,33 @@ func (rc *ReportConfiguration) Logger() *slog.Logger { return rc.logr }
 func (rc *ReportConfiguration) LanguageProcessorFactory() *language.ProcessorFactory {
 	return rc.LangFactory
 }
+func (rc *ReportConfiguration) SourceFileIndex() *utils.SourceFileIndex { return rc.SrcIndex }
+func (rc *ReportConfiguration) SourceFileResolver() *utils.SourceFileResolver {
+	return rc.SrcResolver
+}
+
+// Progress returns the reporter for the progress of long-running steps, which
+// discards the progress unless WithProgress was given.
+func (rc *ReportConfiguration) Progress() progress.Reporter {
+	if rc.ProgressReporter == nil {
+		return progress.Discard
+	}
+	return rc.ProgressReporter
+}
+
+// TargetDirectoryForReportType returns the directory a report builder of the given
+// type writes to. It is the target directory itself unless subdirectories per
+// report type are enabled in the settings.
+func (rc *ReportConfiguration) TargetDirectoryForReportType(reportType string) string {
+	if rc.App == nil || !rc.App.CreateSubdirectoryForAllReportTypes {
+		return rc.TDirectory
+	}
+	subdir, ok := reportTypeSubdirectories[reportType]
+	if !ok {
+		subdir = strings.ToLower(reportType)
+	}
+	return filepath.Join(rc.TDirectory, subdir)
+}
 
 // --- Functional Options Pattern Implementation ---
 
@@ -97,6 +162,7 @@ func NewReportConfiguration(
 		AssemblyFilterInstance:        defaultAssemblyFilter,
 		ClassFilterInstance:           defaultClassFilter,
 		FileFilterInstance:            defaultFileFilter,
+		MethodFilterInstance:          defaultClassFilter,
 		RiskHotspotAssemblyFilterInst: defaultAssemblyFilter,
 		RiskHotspotClassFilterInst:    defaultClassFilter,
 		PluginsList:                   []string{},
@@ -110,6 +176,23 @@ func NewReportConfiguration(
 		}
 	}
 
+	if err := cfg.checkLcovFileConflict(); err != nil {
+		return nil, err
+	}
+
+	if cfg.App.LanguageProcessor != "" && cfg.LangFactory != nil {
+		if err := cfg.LangFactory.ForceProcessor(cfg.App.LanguageProcessor); err != nil {
+			return nil, fmt.Errorf("invalid language formatter setting: %w", err)
+		}
+	}
+
+	if cfg.App.AutoDiscoverSourceFiles {
+		// Roots are resolved lazily because parsers may still contribute source
+		// directories before the first unresolved file triggers indexing.
+		cfg.SrcIndex = utils.NewSourceFileIndex(cfg.SourceDirectories, cfg.logr)
+	}
+	cfg.SrcResolver = utils.NewSourceFileResolver(cfg.SrcIndex, cfg.logr)
+
 	return cfg, nil
 }
 
@@ -136,29 +219,17 @@ func WithHistoryDirectory(dir string) Option {
 	}
 }
 
+// WithReportTypes configures the report types by name, without parameters. See
+// WithReportTypeSpecs for the validation rules.
 func WithReportTypes(types []string) Option {
 	return func(c *ReportConfiguration) error {
-		if len(types) == 0 {
-			return nil
-		}
-
-		var validatedTypes []string
+		var specs []ReportTypeSpec
 		for _, t := range types {
-			trimmedType := strings.TrimSpace(t)
-			if trimmedType == "" {
-				continue
+			if trimmedType := strings.TrimSpace(t); trimmedType != "" {
+				specs = append(specs, ReportTypeSpec{Name: trimmedType})
 			}
-			if !supportedReportTypes[trimmedType] {
-				return fmt.Errorf("unsupported report type: %s", trimmedType)
-			}
-			validatedTypes = append(validatedTypes, trimmedType)
-		}
-
-		if len(validatedTypes) > 0 {
-			c.RTypes = validatedTypes
 		}
-
-		return nil
+		return c.applyReportTypeSpecs(specs)
 	}
 }
 
@@ -169,6 +240,23 @@ func WithTag(tag string) Option {
 	}
 }
 
+// WithTagLink sets a URL template for the tag, e.g. "https://ci.example.com/builds/{tag}".
+func WithTagLink(template string) Option {
+	return func(c *ReportConfiguration) error {
+		c.CfgTagLink = strings.TrimSpace(template)
+		return nil
+	}
+}
+
+// TagLink returns the tag link template with {tag} replaced by the escaped tag,
+// or "" when no tag or no template is configured.
+func (rc *ReportConfiguration) TagLink() string {
+	if rc.CfgTag == "" || rc.CfgTagLink == "" {
+		return ""
+	}
+	return strings.ReplaceAll(rc.CfgTagLink, "{tag}", url.PathEscape(rc.CfgTag))
+}
+
 func WithTitle(title string) Option {
 	return func(c *ReportConfiguration) error {
 		if title != "" {
@@ -239,6 +327,27 @@ func WithFilters(
 	}
 }
 
+// WithMethodFilters sets the filters for the methods that count towards the method
+// coverage. Excluded methods keep their lines in the line coverage.
+func WithMethodFilters(methodFilters []string) Option {
+	return func(c *ReportConfiguration) error {
+		var err error
+		c.MethodFilterInstance, err = filtering.NewDefaultFilter(methodFilters)
+		if err != nil {
+			return fmt.Errorf("failed to create method filter: %w", err)
+		}
+		return nil
+	}
+}
+
+// WithProgress sets the reporter that parsers and report builders pass their progress to.
+func WithProgress(reporter progress.Reporter) Option {
+	return func(c *ReportConfiguration) error {
+		c.ProgressReporter = reporter
+		return nil
+	}
+}
+
 func WithLanguageProcessorFactory(factory *language.ProcessorFactory) Option {
 	return func(c *ReportConfiguration) error {
 		if factory != nil {
@@ -0,0 +1,396 @@
+package reportconfig
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+func TestTargetDirectoryForReportType(t *testing.T) {
+	withSubdirs := settings.NewSettings()
+	withSubdirs.CreateSubdirectoryForAllReportTypes = true
+
+	testCases := []struct {
+		name       string
+		settings   *settings.Settings
+		reportType string
+		want       string
+	}{
+		{
+			name:       "SubdirsDisabled_ReturnsTargetDirectory",
+			settings:   settings.NewSettings(),
+			reportType: "Html",
+			want:       "out",
+		},
+		{
+			name:       "SubdirsEnabled_Html_ReturnsHtmlSubdirectory",
+			settings:   withSubdirs,
+			reportType: "Html",
+			want:       filepath.Join("out", "html"),
+		},
+		{
+			name:       "SubdirsEnabled_TextSummary_ReturnsTextSubdirectory",
+			settings:   withSubdirs,
+			reportType: "TextSummary",
+			want:       filepath.Join("out", "text"),
+		},
+		{
+			name:       "SubdirsEnabled_UnknownType_ReturnsLowercasedSubdirectory",
+			settings:   withSubdirs,
+			reportType: "SomeType",
+			want:       filepath.Join("out", "sometype"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			cfg, err := NewReportConfiguration([]string{"coverage.xml"}, "out", WithSettings(tc.settings))
+			if err != nil {
+				t.Fatalf("NewReportConfiguration returned an unexpected error: %v", err)
+			}
+
+			// Act
+			got := cfg.TargetDirectoryForReportType(tc.reportType)
+
+			// Assert
+			if got != tc.want {
+				t.Errorf("TargetDirectoryForReportType(%q) = %q, want %q", tc.reportType, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseReportTypeSpecs(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		want    []ReportTypeSpec
+		wantErr string
+	}{
+		{
+			name:  "PlainTypes",
+			value: "TextSummary, Html",
+			want:  []ReportTypeSpec{{Name: "TextSummary"}, {Name: "Html"}},
+		},
+		{
+			name:  "ParametersWithCommaInsideBraces",
+			value: "Html{title=Frontend, Web;Foo = bar},TextSummary",
+			want: []ReportTypeSpec{
+				{Name: "Html", Parameters: map[string]string{"title": "Frontend, Web", "foo": "bar"}},
+				{Name: "TextSummary"},
+			},
+		},
+		{name: "MissingClosingBrace", value: "Html{title=x,TextSummary", wantErr: "missing closing '}'"},
+		{name: "UnexpectedClosingBrace", value: "Html},TextSummary", wantErr: "unexpected '}'"},
+		{name: "TextAfterBrace", value: "Html{title=x}y", wantErr: "unexpected text after '}'"},
+		{name: "MissingName", value: "{title=x}", wantErr: "missing report type name"},
+		{name: "ParameterWithoutValue", value: "Html{title}", wantErr: "expected key=value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			got, err := ParseReportTypeSpecs(tc.value)
+
+			// Assert
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ParseReportTypeSpecs(%q) error = %v, want error containing %q", tc.value, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseReportTypeSpecs(%q) returned an unexpected error: %v", tc.value, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParseReportTypeSpecs(%q) = %+v, want %+v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithReportTypeSpecs_TitlesAndUnknownParameters(t *testing.T) {
+	// Arrange
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	// Act
+	cfg, err := NewReportConfiguration([]string{"coverage.xml"}, "out",
+		WithLogger(logger),
+		WithTitle("Global"),
+		WithReportTypeSpecs("Html{title=Frontend Coverage;color=red},TextSummary"),
+	)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("NewReportConfiguration returned an unexpected error: %v", err)
+	}
+	if got := cfg.ReportTypes(); !reflect.DeepEqual(got, []string{"Html", "TextSummary"}) {
+		t.Errorf("ReportTypes() = %v", got)
+	}
+	if got := cfg.TitleForReportType("Html"); got != "Frontend Coverage" {
+		t.Errorf("TitleForReportType(Html) = %q, want %q", got, "Frontend Coverage")
+	}
+	if got := cfg.TitleForReportType("TextSummary"); got != "Global" {
+		t.Errorf("TitleForReportType(TextSummary) = %q, want %q", got, "Global")
+	}
+	if !strings.Contains(logs.String(), "parameter=color") {
+		t.Errorf("expected a warning for the unknown parameter, got logs: %s", logs.String())
+	}
+
+	if _, err := NewReportConfiguration(nil, "out", WithReportTypeSpecs("Pdf{title=x}")); err == nil {
+		t.Errorf("expected an error for an unsupported report type")
+	}
+}
+
+func TestWithReportTypeSpecs_NormalizesNames(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "MixedCase", value: "html, textSUMMARY ,LCOV", want: []string{"Html", "TextSummary", "Lcov"}},
+		{name: "Duplicates", value: "Html,TextSummary,html{title=Again}", want: []string{"Html", "TextSummary"}},
+		{name: "EmptyEntries", value: " ,Html,, ", want: []string{"Html"}},
+		{name: "HtmlSummary", value: "htmlsummary,TextSummary", want: []string{"HtmlSummary", "TextSummary"}},
+		{name: "HtmlWinsOverHtmlSummary", value: "HtmlSummary,TextSummary,Html", want: []string{"TextSummary", "Html"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			cfg, err := NewReportConfiguration(nil, "out", WithReportTypeSpecs(tc.value))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("NewReportConfiguration returned an unexpected error: %v", err)
+			}
+			if got := cfg.ReportTypes(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ReportTypes() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	cfg, err := NewReportConfiguration(nil, "out", WithReportTypeSpecs("HTML{title=Frontend}"))
+	if err != nil {
+		t.Fatalf("NewReportConfiguration returned an unexpected error: %v", err)
+	}
+	if got := cfg.TitleForReportType("Html"); got != "Frontend" {
+		t.Errorf("TitleForReportType(Html) = %q, want %q", got, "Frontend")
+	}
+}
+
+func TestWithReportTypeSpecs_UnsupportedTypes(t *testing.T) {
+	// Act
+	_, err := NewReportConfiguration(nil, "out", WithReportTypeSpecs("Html, TextSumary,Pdf"))
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected an error for unsupported report types")
+	}
+	for _, want := range []string{"TextSumary, Pdf", "supported: BadgesPerAssembly, Clover, CoverageGutters, DeltaSummary, Html, HtmlSummary, Lcov, TextSummary, XmlSummary"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestNewReportConfiguration_LcovAndCoverageGuttersFileConflict(t *testing.T) {
+	withSubdirs := settings.NewSettings()
+	withSubdirs.CreateSubdirectoryForAllReportTypes = true
+
+	testCases := []struct {
+		name        string
+		reportTypes string
+		settings    *settings.Settings
+		wantErr     bool
+	}{
+		{name: "SameDefaultFile", reportTypes: "Lcov,CoverageGutters", settings: settings.NewSettings(), wantErr: true},
+		{name: "SameFileGivenExplicitly", reportTypes: "Lcov,CoverageGutters{file=LCOV.info}", settings: settings.NewSettings(), wantErr: true},
+		{name: "OtherFileName", reportTypes: "Lcov,CoverageGutters{file=gutters.info}", settings: settings.NewSettings()},
+		{name: "Subdirectories", reportTypes: "Lcov,CoverageGutters", settings: withSubdirs},
+		{name: "CoverageGuttersOnly", reportTypes: "CoverageGutters", settings: settings.NewSettings()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReportConfiguration(nil, "out", WithReportTypeSpecs(tc.reportTypes), WithSettings(tc.settings))
+
+			if tc.wantErr && (err == nil || !strings.Contains(err.Error(), "both write lcov.info")) {
+				t.Errorf("expected an error for the conflicting lcov.info files, got %v", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("NewReportConfiguration returned an unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWithReportTypes_NormalizesNames(t *testing.T) {
+	cfg, err := NewReportConfiguration(nil, "out", WithReportTypes([]string{" lcov", "Lcov", "textsummary"}))
+	if err != nil {
+		t.Fatalf("NewReportConfiguration returned an unexpected error: %v", err)
+	}
+	if got, want := cfg.ReportTypes(), []string{"Lcov", "TextSummary"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReportTypes() = %v, want %v", got, want)
+	}
+
+	if _, err := NewReportConfiguration(nil, "out", WithReportTypes([]string{"Htm"})); err == nil {
+		t.Errorf("expected an error for an unsupported report type")
+	}
+}
+
+func TestTagLink(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tag      string
+		template string
+		want     string
+	}{
+		{name: "NoTemplate", tag: "123", want: ""},
+		{name: "NoTag", template: "https://ci/{tag}", want: ""},
+		{name: "Substituted", tag: "build 42", template: "https://ci.example.com/builds/{tag}", want: "https://ci.example.com/builds/build%2042"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			cfg, err := NewReportConfiguration(nil, "out", WithTag(tc.tag), WithTagLink(tc.template))
+			if err != nil {
+				t.Fatalf("NewReportConfiguration returned an unexpected error: %v", err)
+			}
+
+			// Act
+			got := cfg.TagLink()
+
+			// Assert
+			if got != tc.want {
+				t.Errorf("TagLink() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseCoverageRange(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		inside  []float64
+		outside []float64
+		wantErr string
+	}{
+		{name: "BelowFull", value: "<100", inside: []float64{0, 99.9}, outside: []float64{100}},
+		{name: "LowerAndUpper", value: ">=0<80", inside: []float64{0, 79.99}, outside: []float64{80, 100}},
+		{name: "SpacesAndInclusiveUpper", value: " > 50 <= 75 ", inside: []float64{50.1, 75}, outside: []float64{50, 75.1}},
+		{name: "UpperBeforeLower", value: "<=20>=10", inside: []float64{10, 20}, outside: []float64{9.9, 20.1}},
+		{name: "Empty", value: " ", wantErr: "empty coverage range"},
+		{name: "MissingOperator", value: "80", wantErr: "expected a comparison"},
+		{name: "TrailingText", value: "<80%", wantErr: "expected a comparison"},
+		{name: "AbovePercentage", value: "<101", wantErr: "between 0 and 100"},
+		{name: "TwoLowerBounds", value: ">10>=20", wantErr: "more than one lower bound"},
+		{name: "TwoUpperBounds", value: "<10<=20", wantErr: "more than one upper bound"},
+		{name: "EmptyRange", value: ">80<80", wantErr: "no coverage is inside the range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			got, err := ParseCoverageRange(tc.value)
+
+			// Assert
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ParseCoverageRange(%q) error = %v, want error containing %q", tc.value, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCoverageRange(%q) returned an unexpected error: %v", tc.value, err)
+			}
+			for _, coverage := range tc.inside {
+				if !got.Contains(coverage) {
+					t.Errorf("range %q should contain %v", tc.value, coverage)
+				}
+			}
+			for _, coverage := range tc.outside {
+				if got.Contains(coverage) {
+					t.Errorf("range %q should not contain %v", tc.value, coverage)
+				}
+			}
+		})
+	}
+}
+
+func TestWithClassCoverageFilter(t *testing.T) {
+	// Act
+	config, err := NewReportConfiguration(nil, t.TempDir(), WithClassCoverageFilter("<100"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("NewReportConfiguration returned an unexpected error: %v", err)
+	}
+	if got := config.ClassCoverageFilter(); got == nil || got.String() != "<100" {
+		t.Errorf("ClassCoverageFilter() = %v, want <100", got)
+	}
+
+	if _, err := NewReportConfiguration(nil, t.TempDir(), WithClassCoverageFilter("80")); err == nil || !strings.Contains(err.Error(), "invalid class coverage filter") {
+		t.Errorf("NewReportConfiguration with an invalid filter: error = %v, want invalid class coverage filter", err)
+	}
+}
+
+func TestValidateTargetDirectory_CreatesMissingDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "coverage", "report")
+
+	if err := ValidateTargetDirectory(dir); err != nil {
+		t.Fatalf("ValidateTargetDirectory returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("expected the directory to be created: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected the probe file to be deleted, got %v", entries)
+	}
+}
+
+func TestValidateTargetDirectory_ExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report")
+	if err := os.WriteFile(path, []byte("not a directory"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := ValidateTargetDirectory(path)
+
+	if err == nil || !strings.Contains(err.Error(), "is an existing file") {
+		t.Errorf("expected an error about the existing file, got %v", err)
+	}
+}
+
+func TestValidateTargetDirectory_ReadOnlyDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("directory permissions do not prevent writing on Windows")
+	}
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+	dir := filepath.Join(t.TempDir(), "readonly")
+	if err := os.Mkdir(dir, 0o500); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(dir, 0o755) })
+
+	err := ValidateTargetDirectory(dir)
+
+	if err == nil || !strings.Contains(err.Error(), "is not writable") || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("expected an error including the OS error, got %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package reportconfig
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// knownReportTypeParameters lists the parameters each report type understands in
+// the extended -reporttypes syntax. Other parameters are accepted with a warning.
+var knownReportTypeParameters = map[string]map[string]bool{
+	"TextSummary":       {"title": true, "directories": true, "coverageage": true},
+	"Html":              {"title": true, "classdetails": true},
+	"HtmlSummary":       {"title": true},
+	"Lcov":              {},
+	"DeltaSummary":      {},
+	"XmlSummary":        {},
+	"Clover":            {"title": true, "packages": true, "timestamp": true},
+	"BadgesPerAssembly": {},
+	"CoverageGutters":   {"workspace": true, "workspaceonly": true, "file": true},
+}
+
+// SupportedReportTypes returns the names of all report types this build can generate, sorted.
+func SupportedReportTypes() []string {
+	types := make([]string, 0, len(supportedReportTypes))
+	for name := range supportedReportTypes {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ReportTypeSpec is a single entry of the -reporttypes value, e.g. `Html{title=Frontend}`.
+type ReportTypeSpec struct {
+	Name       string
+	Parameters map[string]string
+}
+
+// ParseReportTypeSpecs parses a comma-separated list of report types. Each type may
+// carry parameters in braces, separated by semicolons:
+//
+//	Html{title=Frontend Coverage},TextSummary{title=Frontend;foo=bar}
+//
+// Commas inside braces do not separate report types. Only the syntax is checked
+// here; whether a type is supported is validated by WithReportTypeSpecs.
+func ParseReportTypeSpecs(value string) ([]ReportTypeSpec, error) {
+	entries, err := splitOutsideBraces(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []ReportTypeSpec
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		spec, err := parseReportTypeSpec(entry)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// splitOutsideBraces splits value at commas that are not enclosed in braces.
+func splitOutsideBraces(value string) ([]string, error) {
+	var entries []string
+	depth, start := 0, 0
+	for i, r := range value {
+		switch r {
+		case '{':
+			if depth > 0 {
+				return nil, fmt.Errorf("invalid report type '%s': nested '{' is not allowed", strings.TrimSpace(value[start:]))
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				return nil, fmt.Errorf("invalid report type '%s': unexpected '}'", strings.TrimSpace(value[start:i+1]))
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				entries = append(entries, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid report type '%s': missing closing '}'", strings.TrimSpace(value[start:]))
+	}
+	return append(entries, value[start:]), nil
+}
+
+func parseReportTypeSpec(entry string) (ReportTypeSpec, error) {
+	open := strings.IndexByte(entry, '{')
+	if open == -1 {
+		return ReportTypeSpec{Name: entry}, nil
+	}
+
+	spec := ReportTypeSpec{
+		Name:       strings.TrimSpace(entry[:open]),
+		Parameters: make(map[string]string),
+	}
+	if spec.Name == "" {
+		return ReportTypeSpec{}, fmt.Errorf("invalid report type '%s': missing report type name before '{'", entry)
+	}
+	if !strings.HasSuffix(entry, "}") {
+		return ReportTypeSpec{}, fmt.Errorf("invalid report type '%s': unexpected text after '}'", entry)
+	}
+
+	for _, param := range strings.Split(entry[open+1:len(entry)-1], ";") {
+		if strings.TrimSpace(param) == "" {
+			continue
+		}
+		key, val, found := strings.Cut(param, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !found || key == "" {
+			return ReportTypeSpec{}, fmt.Errorf("invalid parameter '%s' for report type '%s': expected key=value", strings.TrimSpace(param), spec.Name)
+		}
+		spec.Parameters[key] = strings.TrimSpace(val)
+	}
+	return spec, nil
+}
+
+// WithReportTypeSpecs configures the report types from the extended -reporttypes
+// syntax (see ParseReportTypeSpecs). Report types are matched case-insensitively and
+// stored in their canonical spelling; repeated types are only generated once.
+// Unsupported report types are an error, unknown parameters of a supported type are
+// logged as a warning.
+func WithReportTypeSpecs(value string) Option {
+	return func(c *ReportConfiguration) error {
+		specs, err := ParseReportTypeSpecs(value)
+		if err != nil {
+			return err
+		}
+		return c.applyReportTypeSpecs(specs)
+	}
+}
+
+// applyReportTypeSpecs validates and normalizes the specs and stores them in the
+// configuration. All unsupported report types are reported in a single error.
+func (c *ReportConfiguration) applyReportTypeSpecs(specs []ReportTypeSpec) error {
+	var types, unsupported []string
+	params := make(map[string]map[string]string)
+	for _, spec := range specs {
+		name, ok := canonicalReportType(spec.Name)
+		if !ok {
+			unsupported = append(unsupported, spec.Name)
+			continue
+		}
+		if slices.Contains(types, name) {
+			c.logr.Warn("Ignoring duplicate report type", "reportType", name)
+		} else {
+			types = append(types, name)
+		}
+		for key, val := range spec.Parameters {
+			if !knownReportTypeParameters[name][key] {
+				c.logr.Warn("Ignoring unknown report type parameter", "reportType", name, "parameter", key)
+				continue
+			}
+			if params[name] == nil {
+				params[name] = make(map[string]string)
+			}
+			params[name][key] = val
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("unsupported report type(s): %s (supported: %s)",
+			strings.Join(unsupported, ", "), strings.Join(SupportedReportTypes(), ", "))
+	}
+	// The Html report contains the summary, so HtmlSummary would only write it again,
+	// or with subdirectories a second copy without the class pages.
+	if slices.Contains(types, "Html") && slices.Contains(types, "HtmlSummary") {
+		c.logr.Info("Ignoring report type HtmlSummary, the Html report contains the summary")
+		types = slices.DeleteFunc(types, func(name string) bool { return name == "HtmlSummary" })
+	}
+	if len(types) > 0 {
+		c.RTypes = types
+		c.RTypeParams = params
+	}
+	return nil
+}
+
+// defaultLcovFileName is the file the Lcov and CoverageGutters reports write unless
+// CoverageGutters{file=...} is given.
+const defaultLcovFileName = "lcov.info"
+
+// checkLcovFileConflict returns an error if the Lcov and CoverageGutters reports would
+// write the same file, as both write lcov.info by default. The paths of the reports
+// differ, so one would silently overwrite the other.
+func (c *ReportConfiguration) checkLcovFileConflict() error {
+	if !slices.Contains(c.RTypes, "Lcov") || !slices.Contains(c.RTypes, "CoverageGutters") {
+		return nil
+	}
+	guttersFile := defaultLcovFileName
+	if name := c.ReportTypeParameter("CoverageGutters", "file"); name != "" {
+		guttersFile = filepath.Base(name)
+	}
+	if c.TargetDirectoryForReportType("Lcov") != c.TargetDirectoryForReportType("CoverageGutters") ||
+		!strings.EqualFold(guttersFile, defaultLcovFileName) {
+		return nil
+	}
+	return fmt.Errorf("report types Lcov and CoverageGutters both write %s into %s; set another file name with CoverageGutters{file=...} or use -outputsubdirs",
+		defaultLcovFileName, c.TargetDirectoryForReportType("Lcov"))
+}
+
+// canonicalReportType returns the canonical spelling of a supported report type,
+// matching name case-insensitively and ignoring surrounding whitespace.
+func canonicalReportType(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for supported := range supportedReportTypes {
+		if strings.EqualFold(supported, name) {
+			return supported, true
+		}
+	}
+	return "", false
+}
+
+// ReportTypeParameter returns the value of a parameter given for the report type
+// in the -reporttypes value, or "" if it was not set.
+func (rc *ReportConfiguration) ReportTypeParameter(reportType, key string) string {
+	return rc.RTypeParams[reportType][strings.ToLower(key)]
+}
+
+// TitleForReportType returns the title configured for the report type, falling
+// back to the global title.
+func (rc *ReportConfiguration) TitleForReportType(reportType string) string {
+	if title := rc.ReportTypeParameter(reportType, "title"); title != "" {
+		return title
+	}
+	return rc.CfgTitle
+}
@@ -0,0 +1,28 @@
+package reportconfig
+
+import (
+	"fmt"
+	"os"
+)
+
+// ValidateTargetDirectory checks before any parsing work that the reports can be written
+// to dir: it must not be an existing file, it is created if missing, and a probe file is
+// written to and deleted from it.
+func ValidateTargetDirectory(dir string) error {
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		return fmt.Errorf("output directory %s is an existing file", dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", dir, err)
+	}
+	probe, err := os.CreateTemp(dir, ".reportgenerator-probe-*")
+	if err != nil {
+		return fmt.Errorf("output directory %s is not writable: %w", dir, err)
+	}
+	probeName := probe.Name()
+	probe.Close()
+	if err := os.Remove(probeName); err != nil {
+		return fmt.Errorf("failed to delete probe file in output directory %s: %w", dir, err)
+	}
+	return nil
+}
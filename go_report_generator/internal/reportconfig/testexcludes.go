@@ -0,0 +1,69 @@
+package reportconfig
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filtering"
+)
+
+// TestExcludes are the filters -excludetests (settings.ExcludeTestProjects) adds to the
+// filters of the user for the reports of one parser.
+type TestExcludes struct {
+	AssemblyFilters []string
+	FileFilters     []string
+}
+
+var (
+	dotNetTestExcludes = TestExcludes{AssemblyFilters: []string{"-*.Tests", "-*.Test", "-*Tests"}}
+	jsTestExcludes     = TestExcludes{FileFilters: []string{"-**/*.spec.*", "-**/*.test.*", "-**/__tests__/**"}}
+)
+
+// testExcludes maps parser names to their test excludes. LCov and Clover have no parser
+// yet; their excludes apply once parsers with these names are registered.
+var testExcludes = map[string]TestExcludes{
+	"Cobertura":            dotNetTestExcludes,
+	"VisualStudioCoverage": dotNetTestExcludes,
+	"GoCover":              {FileFilters: []string{"-**/*_test.go", "-**/testdata/**"}},
+	"LCov":                 jsTestExcludes,
+	"Clover":               jsTestExcludes,
+}
+
+// TestExcludesForParser returns the test excludes of the parser with the given name.
+func TestExcludesForParser(parserName string) TestExcludes {
+	return testExcludes[parserName]
+}
+
+// TestExcludeParsers returns the names of the parsers that have test excludes, sorted.
+func TestExcludeParsers() []string {
+	return slices.Sorted(maps.Keys(testExcludes))
+}
+
+// ForParser returns the configuration for the reports of the parser with the given name.
+// If settings.ExcludeTestProjects is set, the test excludes of the parser are added to
+// the assembly and file filters; otherwise, and for parsers without test excludes, it
+// returns rc itself.
+func (rc *ReportConfiguration) ForParser(parserName string) (*ReportConfiguration, error) {
+	excludes, ok := testExcludes[parserName]
+	if !ok || rc.App == nil || !rc.App.ExcludeTestProjects {
+		return rc, nil
+	}
+
+	cfg := *rc
+	if len(excludes.AssemblyFilters) > 0 {
+		filter, err := filtering.NewDefaultFilter(excludes.AssemblyFilters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create test assembly filter: %w", err)
+		}
+		cfg.AssemblyFilterInstance = filtering.All(rc.AssemblyFilterInstance, filter)
+	}
+	if len(excludes.FileFilters) > 0 {
+		filter, err := filtering.NewDefaultFilter(excludes.FileFilters, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create test file filter: %w", err)
+		}
+		cfg.FileFilterInstance = filtering.All(rc.FileFilterInstance, filter)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,92 @@
+package reportconfig
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+func TestForParser_AddsTestExcludesToUserFilters(t *testing.T) {
+	appSettings := settings.NewSettings()
+	appSettings.ExcludeTestProjects = true
+	cfg, err := NewReportConfiguration(nil, t.TempDir(),
+		WithSettings(appSettings),
+		WithFilters([]string{"-Legacy"}, nil, []string{"-**/generated/**"}, nil, nil),
+	)
+	if err != nil {
+		t.Fatalf("NewReportConfiguration returned error: %v", err)
+	}
+
+	cobertura, err := cfg.ForParser("Cobertura")
+	if err != nil {
+		t.Fatalf("ForParser returned error: %v", err)
+	}
+	assemblies := map[string]bool{
+		"Shop.Tests":   false,
+		"Shop.Test":    false,
+		"ShopTests":    false,
+		"Legacy":       false, // The filter of the user still applies
+		"Shop":         true,
+		"Shop.Testing": true,
+		"Testing":      true,
+	}
+	for name, want := range assemblies {
+		if got := cobertura.AssemblyFilters().IsElementIncludedInReport(name); got != want {
+			t.Errorf("Cobertura assembly %q included = %v, want %v", name, got, want)
+		}
+	}
+
+	gocover, err := cfg.ForParser("GoCover")
+	if err != nil {
+		t.Fatalf("ForParser returned error: %v", err)
+	}
+	files := map[string]bool{
+		"/src/tool/parser_test.go":        false,
+		"/src/tool/testdata/fixture.go":   false,
+		"/src/tool/generated/types.go":    false,
+		"/src/tool/parser.go":             true,
+		"/src/tool/testing/helpers.go":    true,
+		`C:\src\tool\testdata\fixture.go`: false,
+	}
+	for path, want := range files {
+		if got := gocover.FileFilters().IsElementIncludedInReport(path); got != want {
+			t.Errorf("GoCover file %q included = %v, want %v", path, got, want)
+		}
+	}
+	if !gocover.AssemblyFilters().IsElementIncludedInReport("Shop.Tests") {
+		t.Error("expected the Cobertura assembly excludes not to apply to Go modules")
+	}
+	if !cfg.AssemblyFilters().IsElementIncludedInReport("Shop.Tests") {
+		t.Error("expected the filters of the configuration itself to be unchanged")
+	}
+}
+
+func TestForParser_WithoutExcludeTests(t *testing.T) {
+	cfg, err := NewReportConfiguration(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewReportConfiguration returned error: %v", err)
+	}
+	for _, parser := range []string{"Cobertura", "GoCover", "Unknown"} {
+		got, err := cfg.ForParser(parser)
+		if err != nil {
+			t.Fatalf("ForParser returned error: %v", err)
+		}
+		if got != cfg {
+			t.Errorf("expected the configuration itself for %s", parser)
+		}
+	}
+}
+
+func TestTestExcludes_AreValidFilters(t *testing.T) {
+	appSettings := settings.NewSettings()
+	appSettings.ExcludeTestProjects = true
+	cfg, err := NewReportConfiguration(nil, t.TempDir(), WithSettings(appSettings))
+	if err != nil {
+		t.Fatalf("NewReportConfiguration returned error: %v", err)
+	}
+	for _, parser := range TestExcludeParsers() {
+		if _, err := cfg.ForParser(parser); err != nil {
+			t.Errorf("invalid test excludes of %s: %v", parser, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package reporter
+
+import (
+	"math"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// Totals are the coverage totals and quotas of the whole report, an assembly or a class.
+type Totals struct {
+	LinesCovered        int
+	LinesValid          int
+	TotalLines          int
+	BranchesCovered     *int // nil if the reports have no branch coverage
+	BranchesValid       *int
+	CoveredMethods      int
+	FullyCoveredMethods int
+	TotalMethods        int
+	Classes             int
+	Files               int // Distinct source files
+
+	// The quotas are percentages reduced to the decimal places and with the rounding
+	// mode of the settings, NaN if there is nothing coverable.
+	LineQuota       float64
+	BranchQuota     float64
+	MethodQuota     float64
+	FullMethodQuota float64
+}
+
+// AssemblyAggregates are the totals of an assembly and of its classes.
+type AssemblyAggregates struct {
+	Totals
+	ClassTotals []Totals // In the order of model.Assembly.Classes
+}
+
+// Aggregates are the totals and quotas of a report, computed once per report so that all
+// report types show the same numbers instead of each reimplementing the math.
+type Aggregates struct {
+	Overall    Totals
+	Assemblies []AssemblyAggregates // In the order of model.SummaryResult.Assemblies
+
+	decimalPlaces int
+	roundingMode  utils.RoundingMode
+}
+
+// NewAggregates computes the aggregates of summary. The line and branch totals are the
+// ones of the model; the method, class and file totals are summed up over the classes.
+// Files are counted once per key of their path, see utils.PathKeyFunc.
+func NewAggregates(summary *model.SummaryResult, decimalPlaces int, roundingMode utils.RoundingMode, keyOf func(string) string) *Aggregates {
+	a := &Aggregates{decimalPlaces: decimalPlaces, roundingMode: roundingMode}
+	allFiles := make(map[string]bool)
+	for _, assembly := range summary.Assemblies {
+		assemblyFiles := make(map[string]bool)
+		assemblyAggregates := AssemblyAggregates{ClassTotals: make([]Totals, 0, len(assembly.Classes))}
+		for _, class := range assembly.Classes {
+			classFiles := make(map[string]bool)
+			for _, file := range class.Files {
+				key := keyOf(file.Path)
+				classFiles[key], assemblyFiles[key], allFiles[key] = true, true, true
+			}
+			classTotals := Totals{
+				LinesCovered: class.LinesCovered, LinesValid: class.LinesValid, TotalLines: class.TotalLines,
+				BranchesCovered: class.BranchesCovered, BranchesValid: class.BranchesValid,
+				CoveredMethods: class.CoveredMethods, FullyCoveredMethods: class.FullyCoveredMethods, TotalMethods: class.TotalMethods,
+				Classes: 1, Files: len(classFiles),
+			}
+			a.computeQuotas(&classTotals)
+			assemblyAggregates.ClassTotals = append(assemblyAggregates.ClassTotals, classTotals)
+			assemblyAggregates.addMethods(classTotals)
+		}
+		assemblyAggregates.LinesCovered, assemblyAggregates.LinesValid, assemblyAggregates.TotalLines = assembly.LinesCovered, assembly.LinesValid, assembly.TotalLines
+		assemblyAggregates.BranchesCovered, assemblyAggregates.BranchesValid = assembly.BranchesCovered, assembly.BranchesValid
+		assemblyAggregates.Classes = len(assembly.Classes)
+		assemblyAggregates.Files = len(assemblyFiles)
+		a.computeQuotas(&assemblyAggregates.Totals)
+		a.Assemblies = append(a.Assemblies, assemblyAggregates)
+		a.Overall.addMethods(assemblyAggregates.Totals)
+		a.Overall.Classes += len(assembly.Classes)
+	}
+	a.Overall.LinesCovered, a.Overall.LinesValid, a.Overall.TotalLines = summary.LinesCovered, summary.LinesValid, summary.TotalLines
+	a.Overall.BranchesCovered, a.Overall.BranchesValid = summary.BranchesCovered, summary.BranchesValid
+	a.Overall.Files = len(allFiles)
+	a.computeQuotas(&a.Overall)
+	return a
+}
+
+// Quota returns the percentage of covered of total with the decimal places and the
+// rounding mode of the aggregates, NaN if total is 0.
+func (a *Aggregates) Quota(covered, total int) float64 {
+	return utils.CalculatePercentageWithMode(covered, total, a.decimalPlaces, a.roundingMode)
+}
+
+func (a *Aggregates) computeQuotas(t *Totals) {
+	t.LineQuota = a.Quota(t.LinesCovered, t.LinesValid)
+	t.BranchQuota = math.NaN()
+	if t.BranchesCovered != nil && t.BranchesValid != nil {
+		t.BranchQuota = a.Quota(*t.BranchesCovered, *t.BranchesValid)
+	}
+	t.MethodQuota = a.Quota(t.CoveredMethods, t.TotalMethods)
+	t.FullMethodQuota = a.Quota(t.FullyCoveredMethods, t.TotalMethods)
+}
+
+func (t *Totals) addMethods(other Totals) {
+	t.CoveredMethods += other.CoveredMethods
+	t.FullyCoveredMethods += other.FullyCoveredMethods
+	t.TotalMethods += other.TotalMethods
+}
@@ -0,0 +1,127 @@
+package reporter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestNewAggregates_Totals(t *testing.T) {
+	summary := &model.SummaryResult{
+		LinesCovered: 2, LinesValid: 3, TotalLines: 40,
+		BranchesCovered: intPtr(1), BranchesValid: intPtr(4),
+		Assemblies: []model.Assembly{
+			{Name: "A1", LinesCovered: 2, LinesValid: 3, TotalLines: 40, Classes: []model.Class{
+				{Name: "C1", LinesCovered: 2, LinesValid: 2, CoveredMethods: 2, FullyCoveredMethods: 1, TotalMethods: 3,
+					Files: []model.CodeFile{{Path: "file1.cs"}, {Path: "file2.cs"}}},
+				{Name: "C2", LinesCovered: 0, LinesValid: 1, TotalMethods: 1,
+					Files: []model.CodeFile{{Path: "file1.cs"}}},
+			}},
+			{Name: "A2"},
+		},
+	}
+
+	a := NewAggregates(summary, 1, utils.RoundingTruncate, utils.PathKey)
+
+	overall := a.Overall
+	if overall.Classes != 2 || overall.Files != 2 {
+		t.Errorf("overall classes/files = %d/%d, want 2/2", overall.Classes, overall.Files)
+	}
+	if overall.CoveredMethods != 2 || overall.FullyCoveredMethods != 1 || overall.TotalMethods != 4 {
+		t.Errorf("overall methods = %d/%d/%d, want 2/1/4", overall.CoveredMethods, overall.FullyCoveredMethods, overall.TotalMethods)
+	}
+	for _, quota := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"line", overall.LineQuota, 66.6},
+		{"branch", overall.BranchQuota, 25},
+		{"method", overall.MethodQuota, 50},
+		{"full method", overall.FullMethodQuota, 25},
+	} {
+		if quota.got != quota.want {
+			t.Errorf("overall %s quota = %v, want %v", quota.name, quota.got, quota.want)
+		}
+	}
+
+	if len(a.Assemblies) != 2 || len(a.Assemblies[0].ClassTotals) != 2 {
+		t.Fatalf("expected the assemblies and classes in the order of the summary, got %+v", a.Assemblies)
+	}
+	if got := a.Assemblies[0]; got.Classes != 2 || got.Files != 2 || got.TotalMethods != 4 {
+		t.Errorf("assembly classes/files/methods = %d/%d/%d, want 2/2/4", got.Classes, got.Files, got.TotalMethods)
+	}
+	if got := a.Assemblies[0].ClassTotals[1]; got.LineQuota != 0 || got.Files != 1 || !math.IsNaN(got.BranchQuota) {
+		t.Errorf("class C2 = %+v, want line quota 0, one file and no branch quota", got)
+	}
+	if got := a.Assemblies[1]; !math.IsNaN(got.LineQuota) || !math.IsNaN(got.MethodQuota) {
+		t.Errorf("expected NaN quotas for an assembly without coverable lines, got %+v", got.Totals)
+	}
+}
+
+func TestNewAggregates_CountsDistinctFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		assemblies []model.Assembly
+		want       int
+	}{
+		{"no assemblies", []model.Assembly{}, 0},
+		{
+			"duplicate files across classes/assemblies",
+			[]model.Assembly{
+				{Classes: []model.Class{
+					{Files: []model.CodeFile{{Path: "file1.cs"}}},
+				}},
+				{Classes: []model.Class{
+					{Files: []model.CodeFile{{Path: "file1.cs"}}}, // Duplicate
+					{Files: []model.CodeFile{{Path: "file2.cs"}}},
+				}},
+			},
+			2,
+		},
+		{
+			"paths differing in case and separators",
+			[]model.Assembly{{
+				Classes: []model.Class{
+					{Files: []model.CodeFile{{Path: `c:\Work\src\Foo.cs`}}},
+					{Files: []model.CodeFile{{Path: `C:/work/src/foo.cs`}}},
+				},
+			}},
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAggregates(&model.SummaryResult{Assemblies: tt.assemblies}, 1, utils.RoundingTruncate, utils.PathKeyFunc(utils.PathCaseInsensitive, false))
+			if a.Overall.Files != tt.want {
+				t.Errorf("files = %d, want %d", a.Overall.Files, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilderContext_AggregatesComputedOncePerSummary(t *testing.T) {
+	s := settings.NewSettings()
+	s.MaximumDecimalPlacesForCoverageQuotas = 2
+	s.CoverageQuotaRoundingMode = "round"
+	ctx := NewBuilderContext(nil, s, nil)
+	summary := &model.SummaryResult{LinesCovered: 2, LinesValid: 3}
+
+	first := ctx.Aggregates(summary)
+	if first != ctx.Aggregates(summary) {
+		t.Error("expected the aggregates of the same summary to be computed once")
+	}
+	if first.Overall.LineQuota != 66.67 {
+		t.Errorf("line quota = %v, want 66.67 with the decimal places and rounding of the settings", first.Overall.LineQuota)
+	}
+	if other := ctx.Aggregates(&model.SummaryResult{LinesCovered: 1, LinesValid: 3}); other == first || other.Overall.LineQuota != 33.33 {
+		t.Errorf("expected new aggregates for another summary, got line quota %v", other.Overall.LineQuota)
+	}
+	if ctx.Aggregates(summary) != first {
+		t.Error("expected the aggregates of the first summary to be kept")
+	}
+}
@@ -0,0 +1,180 @@
+package badges
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// markdownFileName is the file listing the badges of all assemblies.
+const markdownFileName = "badges.md"
+
+// Badge colors by line coverage, as used by shields.io.
+const (
+	colorGood   = "#4c1"    // 80% and more
+	colorMedium = "#dfb317" // 60% and more
+	colorBad    = "#e05d44"
+	colorNoData = "#9f9f9f" // Assemblies without coverable lines
+)
+
+const (
+	labelText    = "line coverage"
+	noDataText   = "no data"
+	charWidth    = 7 // Approximate width of a character of the 11px font
+	textPaddingX = 10
+)
+
+var badgeTemplate = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Value}}">
+<title>{{.Label}}: {{.Value}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="{{.LabelWidth}}" height="20" fill="#555"/><rect x="{{.LabelWidth}}" width="{{.ValueWidth}}" height="20" fill="{{.Color}}"/><rect width="{{.Width}}" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11"><text x="{{.LabelX}}" y="14">{{.Label}}</text><text x="{{.ValueX}}" y="14">{{.Value}}</text></g>
+</svg>
+`))
+
+// BadgesPerAssemblyReportBuilder writes a line coverage badge per assembly, e.g. to embed
+// in the README of each module of a monorepo, and badges.md with the image references.
+type BadgesPerAssemblyReportBuilder struct {
+	outputDir string
+	logger    *slog.Logger
+
+	decimalPlaces int
+	roundingMode  utils.RoundingMode
+	aggregates    *reporter.Aggregates
+}
+
+// Option configures a BadgesPerAssemblyReportBuilder.
+type Option func(*BadgesPerAssemblyReportBuilder)
+
+// WithDecimalPlaces sets the decimal places of the percentages on the badges, see
+// settings.Settings.MaximumDecimalPlacesForCoverageQuotas.
+func WithDecimalPlaces(decimalPlaces int) Option {
+	return func(b *BadgesPerAssemblyReportBuilder) {
+		b.decimalPlaces = decimalPlaces
+	}
+}
+
+// WithCoverageQuotaRounding sets how coverage quotas are rounded. The default truncates like ReportGenerator.
+func WithCoverageQuotaRounding(mode utils.RoundingMode) Option {
+	return func(b *BadgesPerAssemblyReportBuilder) {
+		b.roundingMode = mode
+	}
+}
+
+// WithAggregates sets the totals and quotas of the report shared with the other report
+// types. Without it, they are computed from the report with the decimal places set by
+// WithDecimalPlaces.
+func WithAggregates(aggregates *reporter.Aggregates) Option {
+	return func(b *BadgesPerAssemblyReportBuilder) {
+		b.aggregates = aggregates
+	}
+}
+
+// NewBadgesPerAssemblyReportBuilder creates a new BadgesPerAssemblyReportBuilder.
+func NewBadgesPerAssemblyReportBuilder(outputDir string, logger *slog.Logger, opts ...Option) reporter.ReportBuilder {
+	b := &BadgesPerAssemblyReportBuilder{
+		outputDir:     outputDir,
+		logger:        logger,
+		decimalPlaces: 1,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// ReportType returns the type of report this builder generates.
+func (b *BadgesPerAssemblyReportBuilder) ReportType() string {
+	return "BadgesPerAssembly"
+}
+
+// CreateReport writes badge_<assembly>_linecoverage.svg for every assembly and badges.md.
+// The assembly names are sanitized like the names of the Html class pages; names that
+// sanitize to the same string get a number suffix.
+func (b *BadgesPerAssemblyReportBuilder) CreateReport(summary *model.SummaryResult) error {
+	if err := os.MkdirAll(b.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create target directory: %w", err)
+	}
+	aggregates := b.aggregates
+	if aggregates == nil {
+		aggregates = reporter.NewAggregates(summary, b.decimalPlaces, b.roundingMode, utils.PathKey)
+	}
+
+	var markdown strings.Builder
+	markdown.WriteString("# Line coverage by assembly\n\n| Assembly | Badge | Line coverage |\n|:---|:---:|---:|\n")
+
+	existingNames := make(map[string]struct{})
+	for i, assembly := range summary.Assemblies {
+		totals := aggregates.Assemblies[i].Totals
+		value, color := noDataText, colorNoData
+		if totals.LinesValid > 0 && !math.IsNaN(totals.LineQuota) {
+			value = utils.FormatPercentageWithMode(totals.LineQuota, b.decimalPlaces, b.roundingMode)
+			color = badgeColor(totals.LineQuota)
+		}
+
+		fileName := "badge_" + utils.UniqueSanitizedFilename(assembly.Name, "", existingNames) + "_linecoverage.svg"
+		if err := b.writeBadge(fileName, value, color); err != nil {
+			return err
+		}
+
+		name := markdownEscaper.Replace(assembly.Name)
+		fmt.Fprintf(&markdown, "| %s | ![%s line coverage](%s) | %s |\n", name, name, fileName, value)
+	}
+
+	outputPath := filepath.Join(b.outputDir, markdownFileName)
+	if err := os.WriteFile(outputPath, []byte(markdown.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+	b.logger.Info("Badges per assembly written", "directory", b.outputDir, "assemblies", len(summary.Assemblies))
+	return nil
+}
+
+// markdownEscaper escapes the characters of assembly names that break a table cell or an image.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "[", `\[`, "]", `\]`)
+
+func (b *BadgesPerAssemblyReportBuilder) writeBadge(fileName, value, color string) error {
+	labelWidth := utf8.RuneCountInString(labelText)*charWidth + textPaddingX
+	valueWidth := utf8.RuneCountInString(value)*charWidth + textPaddingX
+	var svg bytes.Buffer
+	err := badgeTemplate.Execute(&svg, map[string]any{
+		"Label":      labelText,
+		"Value":      value,
+		"Color":      color,
+		"Width":      labelWidth + valueWidth,
+		"LabelWidth": labelWidth,
+		"ValueWidth": valueWidth,
+		"LabelX":     float64(labelWidth) / 2,
+		"ValueX":     float64(labelWidth) + float64(valueWidth)/2,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render badge %s: %w", fileName, err)
+	}
+	outputPath := filepath.Join(b.outputDir, fileName)
+	if err := os.WriteFile(outputPath, svg.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+// badgeColor returns the color of a badge showing the line coverage quota.
+func badgeColor(quota float64) string {
+	switch {
+	case quota >= 80:
+		return colorGood
+	case quota >= 60:
+		return colorMedium
+	default:
+		return colorBad
+	}
+}
@@ -0,0 +1,97 @@
+package badges
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+func assembly(name string, covered, valid int) model.Assembly {
+	return model.Assembly{
+		Name:         name,
+		LinesCovered: covered,
+		LinesValid:   valid,
+		Classes:      []model.Class{{Name: name + ".Class", LinesCovered: covered, LinesValid: valid}},
+	}
+}
+
+func createReport(t *testing.T, assemblies ...model.Assembly) string {
+	t.Helper()
+	dir := t.TempDir()
+	builder := NewBadgesPerAssemblyReportBuilder(dir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := builder.CreateReport(&model.SummaryResult{Assemblies: assemblies}); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+	return dir
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	return string(content)
+}
+
+func TestCreateReport_BadgePerAssembly(t *testing.T) {
+	dir := createReport(t, assembly("Shop.Core", 9, 10), assembly("Shop.Web", 13, 20), assembly("Shop.Api", 1, 4), assembly("Shop.Contracts", 0, 0))
+
+	testCases := []struct {
+		file, value, color string
+	}{
+		{"badge_Shop.Core_linecoverage.svg", "90.0%", colorGood},
+		{"badge_Shop.Web_linecoverage.svg", "65.0%", colorMedium},
+		{"badge_Shop.Api_linecoverage.svg", "25.0%", colorBad},
+		{"badge_Shop.Contracts_linecoverage.svg", "no data", colorNoData},
+	}
+	for _, tc := range testCases {
+		svg := readFile(t, filepath.Join(dir, tc.file))
+		if !strings.Contains(svg, `aria-label="line coverage: `+tc.value+`"`) || !strings.Contains(svg, `fill="`+tc.color+`"`) {
+			t.Errorf("expected %s to show %s in %s, got:\n%s", tc.file, tc.value, tc.color, svg)
+		}
+	}
+
+	markdown := readFile(t, filepath.Join(dir, "badges.md"))
+	for _, want := range []string{
+		"| Shop.Core | ![Shop.Core line coverage](badge_Shop.Core_linecoverage.svg) | 90.0% |",
+		"| Shop.Contracts | ![Shop.Contracts line coverage](badge_Shop.Contracts_linecoverage.svg) | no data |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected %q in badges.md, got:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestCreateReport_NamesSanitizedToTheSameString(t *testing.T) {
+	dir := createReport(t, assembly("shop/core", 1, 2), assembly("Shop:Core", 2, 2))
+
+	if svg := readFile(t, filepath.Join(dir, "badge_shop_core_linecoverage.svg")); !strings.Contains(svg, "50.0%") {
+		t.Errorf("expected the badge of the first assembly to show 50.0%%, got:\n%s", svg)
+	}
+	if svg := readFile(t, filepath.Join(dir, "badge_Shop_Core2_linecoverage.svg")); !strings.Contains(svg, "100.0%") {
+		t.Errorf("expected the badge of the second assembly to show 100.0%%, got:\n%s", svg)
+	}
+	markdown := readFile(t, filepath.Join(dir, "badges.md"))
+	if !strings.Contains(markdown, "| Shop:Core | ![Shop:Core line coverage](badge_Shop_Core2_linecoverage.svg) | 100.0% |") {
+		t.Errorf("expected badges.md to reference the renamed badge, got:\n%s", markdown)
+	}
+}
+
+func TestCreateReport_DecimalPlacesOfTheQuotas(t *testing.T) {
+	dir := t.TempDir()
+	builder := NewBadgesPerAssemblyReportBuilder(dir, slog.New(slog.NewTextHandler(io.Discard, nil)), WithDecimalPlaces(2))
+	if err := builder.CreateReport(&model.SummaryResult{Assemblies: []model.Assembly{assembly("Shop.Core", 2, 3)}}); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+
+	svg := readFile(t, filepath.Join(dir, "badge_Shop.Core_linecoverage.svg"))
+	if !strings.Contains(svg, `aria-label="line coverage: 66.66%"`) {
+		t.Errorf("expected the quota with two decimal places, got:\n%s", svg)
+	}
+}
@@ -0,0 +1,470 @@
+package clover
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+const fileName = "clover.xml"
+
+// cloverVersion is written to the "clover" attribute of the root element. Consumers
+// only check that it is present.
+const cloverVersion = "4.4.1"
+
+// Values of the "packages" parameter of the Clover report type, e.g.
+// Clover{packages=directory}.
+const (
+	PackagesByAssembly  = "assembly"  // One package per assembly, named like the assembly
+	PackagesByDirectory = "directory" // One package per directory of the source files
+)
+
+// Values of the "timestamp" parameter of the Clover report type, e.g.
+// Clover{timestamp=coverage}.
+const (
+	TimestampGenerated = "generated" // The time the report is written
+	TimestampCoverage  = "coverage"  // The timestamp of the coverage reports
+)
+
+// CloverReportBuilder writes clover.xml for tools that consume Clover coverage, e.g.
+// Bamboo, Jenkins or Codecov.
+type CloverReportBuilder struct {
+	outputDir string
+	logger    *slog.Logger
+
+	projectName string
+	packages    string
+	timestamp   string
+	sourceDirs  []string
+	pathCase    utils.PathCaseMode
+	now         func() time.Time
+}
+
+// Option configures a CloverReportBuilder.
+type Option func(*CloverReportBuilder)
+
+// WithProjectName sets the name of the project element, usually the report title.
+func WithProjectName(name string) Option {
+	return func(b *CloverReportBuilder) {
+		b.projectName = name
+	}
+}
+
+// WithPackages sets how classes are grouped into packages, PackagesByAssembly (default)
+// or PackagesByDirectory. Other values are ignored with a warning.
+func WithPackages(mode string) Option {
+	return func(b *CloverReportBuilder) {
+		if mode != "" {
+			b.packages = strings.ToLower(mode)
+		}
+	}
+}
+
+// WithTimestamp sets the time written to the project element, TimestampGenerated
+// (default) or TimestampCoverage. Other values are ignored with a warning.
+func WithTimestamp(mode string) Option {
+	return func(b *CloverReportBuilder) {
+		if mode != "" {
+			b.timestamp = strings.ToLower(mode)
+		}
+	}
+}
+
+// WithSourceDirectories sets the directories the package names of PackagesByDirectory
+// are relative to.
+func WithSourceDirectories(dirs []string) Option {
+	return func(b *CloverReportBuilder) {
+		b.sourceDirs = dirs
+	}
+}
+
+// WithPathCase sets whether file paths that differ only in case are the same file when
+// the files of several classes are merged. The default is utils.PathCaseAuto.
+func WithPathCase(mode utils.PathCaseMode) Option {
+	return func(b *CloverReportBuilder) {
+		b.pathCase = mode
+	}
+}
+
+// WithClock replaces time.Now for the "generated" attribute.
+func WithClock(now func() time.Time) Option {
+	return func(b *CloverReportBuilder) {
+		if now != nil {
+			b.now = now
+		}
+	}
+}
+
+// NewCloverReportBuilder creates a new CloverReportBuilder.
+func NewCloverReportBuilder(outputDir string, logger *slog.Logger, opts ...Option) reporter.ReportBuilder {
+	b := &CloverReportBuilder{
+		outputDir: outputDir,
+		logger:    logger,
+		packages:  PackagesByAssembly,
+		timestamp: TimestampGenerated,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.packages != PackagesByAssembly && b.packages != PackagesByDirectory {
+		b.logger.Warn("Unknown packages parameter of the Clover report, using one package per assembly", "value", b.packages)
+		b.packages = PackagesByAssembly
+	}
+	if b.timestamp != TimestampGenerated && b.timestamp != TimestampCoverage {
+		b.logger.Warn("Unknown timestamp parameter of the Clover report, using the generation time", "value", b.timestamp)
+		b.timestamp = TimestampGenerated
+	}
+	return b
+}
+
+// ReportType returns the type of report this builder generates.
+func (b *CloverReportBuilder) ReportType() string {
+	return "Clover"
+}
+
+type coverageElement struct {
+	XMLName   xml.Name       `xml:"coverage"`
+	Generated int64          `xml:"generated,attr"`
+	Clover    string         `xml:"clover,attr"`
+	Project   projectElement `xml:"project"`
+}
+
+type projectElement struct {
+	Timestamp int64            `xml:"timestamp,attr"`
+	Name      string           `xml:"name,attr,omitempty"`
+	Metrics   metricsElement   `xml:"metrics"`
+	Packages  []packageElement `xml:"package"`
+}
+
+type packageElement struct {
+	Name    string         `xml:"name,attr"`
+	Metrics metricsElement `xml:"metrics"`
+	Files   []fileElement  `xml:"file"`
+}
+
+type fileElement struct {
+	Name    string         `xml:"name,attr"`
+	Path    string         `xml:"path,attr"`
+	Metrics metricsElement `xml:"metrics"`
+	Classes []classElement `xml:"class"`
+	Lines   []lineElement  `xml:"line"`
+}
+
+type classElement struct {
+	Name    string         `xml:"name,attr"`
+	Metrics metricsElement `xml:"metrics"`
+}
+
+// metricsElement holds the counts of a level in the order Clover writes them. The
+// counts of the contained levels are only written for the levels that have them.
+type metricsElement struct {
+	Statements          int  `xml:"statements,attr"`
+	CoveredStatements   int  `xml:"coveredstatements,attr"`
+	Conditionals        int  `xml:"conditionals,attr"`
+	CoveredConditionals int  `xml:"coveredconditionals,attr"`
+	Methods             int  `xml:"methods,attr"`
+	CoveredMethods      int  `xml:"coveredmethods,attr"`
+	Elements            int  `xml:"elements,attr"`
+	CoveredElements     int  `xml:"coveredelements,attr"`
+	LOC                 *int `xml:"loc,attr,omitempty"`
+	Packages            *int `xml:"packages,attr,omitempty"`
+	Files               *int `xml:"files,attr,omitempty"`
+	Classes             *int `xml:"classes,attr,omitempty"`
+}
+
+type lineElement struct {
+	Num        int    `xml:"num,attr"`
+	Type       string `xml:"type,attr"`
+	Signature  string `xml:"signature,attr,omitempty"`
+	Count      int    `xml:"count,attr"`
+	TrueCount  *int   `xml:"truecount,attr,omitempty"`
+	FalseCount *int   `xml:"falsecount,attr,omitempty"`
+}
+
+// CreateReport writes clover.xml for the analyzed model.SummaryResult.
+func (b *CloverReportBuilder) CreateReport(summary *model.SummaryResult) error {
+	if err := os.MkdirAll(b.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	outputPath := filepath.Join(b.outputDir, fileName)
+	b.logger.Info("Writing Clover report to file", "path", outputPath)
+
+	content, err := xml.MarshalIndent(b.buildReport(summary), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal Clover report: %w", err)
+	}
+	content = append([]byte(xml.Header), content...)
+	if err := os.WriteFile(outputPath, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write Clover report '%s': %w", outputPath, err)
+	}
+	return nil
+}
+
+func (b *CloverReportBuilder) buildReport(summary *model.SummaryResult) coverageElement {
+	generated := b.now().UnixMilli()
+	timestamp := generated
+	if b.timestamp == TimestampCoverage {
+		if utils.IsValidUnixSeconds(summary.Timestamp) {
+			timestamp = summary.Timestamp * 1000
+		} else {
+			b.logger.Warn("The coverage reports have no timestamp, using the generation time in the Clover report")
+		}
+	}
+
+	var packages []packageElement
+	if b.packages == PackagesByDirectory {
+		packages = b.packagesByDirectory(summary)
+	} else {
+		packages = b.packagesByAssembly(summary)
+	}
+
+	// The project counts are the sums of the package counts, so that they match the
+	// counts of the files in the report.
+	var metrics metricsElement
+	fileCount := 0
+	for _, pkg := range packages {
+		metrics.add(pkg.Metrics)
+		fileCount += len(pkg.Files)
+	}
+	packageCount, classCount := len(packages), len(reporter.AllClasses(summary.Assemblies))
+	metrics.Packages, metrics.Files, metrics.Classes = &packageCount, &fileCount, &classCount
+
+	return coverageElement{
+		Generated: generated,
+		Clover:    cloverVersion,
+		Project: projectElement{
+			Timestamp: timestamp,
+			Name:      b.projectName,
+			Metrics:   metrics,
+			Packages:  packages,
+		},
+	}
+}
+
+// packagesByAssembly creates one package per assembly. The metrics are the sums of the
+// file metrics.
+func (b *CloverReportBuilder) packagesByAssembly(summary *model.SummaryResult) []packageElement {
+	packages := make([]packageElement, 0, len(summary.Assemblies))
+	for i := range summary.Assemblies {
+		assembly := &summary.Assemblies[i]
+		classes := reporter.AllClasses([]model.Assembly{*assembly})
+		files := buildFiles(reporter.MergeFiles(classes, utils.PathKeyFunc(b.pathCase, false)))
+
+		var metrics metricsElement
+		for _, file := range files {
+			metrics.add(file.Metrics)
+		}
+		fileCount, classCount := len(files), len(classes)
+		metrics.Files, metrics.Classes = &fileCount, &classCount
+		packages = append(packages, packageElement{Name: assembly.Name, Metrics: metrics, Files: files})
+	}
+	return packages
+}
+
+// packagesByDirectory creates one package per directory of the source files, named by
+// the slash-separated directory relative to the source directory that contains it. The
+// metrics are the sums of the file metrics.
+func (b *CloverReportBuilder) packagesByDirectory(summary *model.SummaryResult) []packageElement {
+	byName := make(map[string]*packageElement)
+	classesByName := make(map[string]map[*model.Class]bool)
+	for _, sourceFile := range reporter.MergeFiles(reporter.AllClasses(summary.Assemblies), utils.PathKeyFunc(b.pathCase, false)) {
+		name := b.packageName(sourceFile.Path)
+		pkg, ok := byName[name]
+		if !ok {
+			pkg = &packageElement{Name: name}
+			byName[name] = pkg
+			classesByName[name] = make(map[*model.Class]bool)
+		}
+		file := buildFile(sourceFile)
+		pkg.Files = append(pkg.Files, file)
+		pkg.Metrics.add(file.Metrics)
+		for _, classFile := range sourceFile.Classes {
+			classesByName[name][classFile.Class] = true
+		}
+	}
+
+	packages := make([]packageElement, 0, len(byName))
+	for name, pkg := range byName {
+		fileCount, classCount := len(pkg.Files), len(classesByName[name])
+		pkg.Metrics.Files, pkg.Metrics.Classes = &fileCount, &classCount
+		packages = append(packages, *pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Name < packages[j].Name })
+	return packages
+}
+
+func (b *CloverReportBuilder) packageName(filePath string) string {
+	dir := filepath.Dir(filePath)
+	for _, sourceDir := range b.sourceDirs {
+		if rel, err := filepath.Rel(sourceDir, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return path.Clean(filepath.ToSlash(dir))
+}
+
+func buildFiles(sourceFiles []*reporter.SourceFile) []fileElement {
+	files := make([]fileElement, 0, len(sourceFiles))
+	for _, sourceFile := range sourceFiles {
+		files = append(files, buildFile(sourceFile))
+	}
+	return files
+}
+
+// buildFile creates the file element with one class element per class that has lines
+// in the file. The file metrics and lines come from the lines merged over all classes,
+// the class metrics from the lines of the class in this file.
+func buildFile(sourceFile *reporter.SourceFile) fileElement {
+	file := fileElement{
+		Name:    path.Base(filepath.ToSlash(sourceFile.Path)),
+		Path:    sourceFile.Path,
+		Metrics: countLines(sourceFile.Lines, sourceFile.Elements),
+	}
+	totalLines := 0
+	for _, classFile := range sourceFile.Classes {
+		totalLines = max(totalLines, classFile.File.TotalLines)
+		lines := make(map[int]model.Line, len(classFile.File.Lines))
+		for _, line := range classFile.File.Lines {
+			lines[line.Number] = line
+		}
+		file.Classes = append(file.Classes, classElement{
+			Name:    classFile.Class.DisplayName,
+			Metrics: countLines(lines, classFile.File.CodeElements),
+		})
+	}
+	classCount := len(file.Classes)
+	file.Metrics.LOC, file.Metrics.Classes = &totalLines, &classCount
+
+	elementsByLine := make(map[int][]model.CodeElement)
+	for _, element := range sourceFile.Elements {
+		elementsByLine[element.FirstLine] = append(elementsByLine[element.FirstLine], element)
+	}
+	numbers := sourceFile.SortedLineNumbers()
+	for number := range elementsByLine {
+		if _, ok := sourceFile.Lines[number]; !ok {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		for _, element := range elementsByLine[number] {
+			file.Lines = append(file.Lines, lineElement{
+				Num:       number,
+				Type:      "method",
+				Signature: element.FullName,
+				Count:     elementCount(element, sourceFile.Lines),
+			})
+		}
+		line, ok := sourceFile.Lines[number]
+		if !ok || line.Hits < 0 {
+			continue
+		}
+		element := lineElement{Num: number, Type: "stmt", Count: line.Hits}
+		if covered, total := branchCounts(line); total > 0 {
+			// Clover treats a condition as covered if it was both true and false.
+			trueCount, falseCount := conditionCounts(line, covered)
+			element.Type = "cond"
+			element.TrueCount, element.FalseCount = &trueCount, &falseCount
+		}
+		file.Lines = append(file.Lines, element)
+	}
+	return file
+}
+
+// countLines creates the metrics of a file or class from its lines and code elements.
+// Like the other reports, every coverable line is a statement, branch points included.
+func countLines(lines map[int]model.Line, elements []model.CodeElement) metricsElement {
+	var metrics metricsElement
+	for _, line := range lines {
+		if line.Hits < 0 {
+			continue
+		}
+		metrics.Statements++
+		if line.Hits > 0 {
+			metrics.CoveredStatements++
+		}
+		covered, total := branchCounts(line)
+		metrics.Conditionals += total
+		metrics.CoveredConditionals += covered
+	}
+	metrics.Methods = len(elements)
+	for _, element := range elements {
+		if reporter.IsElementHit(element, lines) {
+			metrics.CoveredMethods++
+		}
+	}
+	metrics.sumElements()
+	return metrics
+}
+
+func (m *metricsElement) sumElements() {
+	m.Elements = m.Statements + m.Conditionals + m.Methods
+	m.CoveredElements = m.CoveredStatements + m.CoveredConditionals + m.CoveredMethods
+}
+
+func (m *metricsElement) add(other metricsElement) {
+	m.Statements += other.Statements
+	m.CoveredStatements += other.CoveredStatements
+	m.Conditionals += other.Conditionals
+	m.CoveredConditionals += other.CoveredConditionals
+	m.Methods += other.Methods
+	m.CoveredMethods += other.CoveredMethods
+	m.sumElements()
+	if other.LOC != nil {
+		loc := *other.LOC
+		if m.LOC != nil {
+			loc += *m.LOC
+		}
+		m.LOC = &loc
+	}
+}
+
+// branchCounts returns the covered and total branches of a branch point, counted like
+// the branch totals of the model.
+func branchCounts(line model.Line) (covered, total int) {
+	if !line.IsBranchPoint {
+		return 0, 0
+	}
+	return line.CoveredBranches, line.TotalBranches
+}
+
+// conditionCounts returns the truecount and falsecount of a branch point: the visits of
+// the first branch and of the other branches if they are known, otherwise one per
+// covered branch.
+func conditionCounts(line model.Line, covered int) (trueCount, falseCount int) {
+	if len(line.Branch) > 0 {
+		trueCount = line.Branch[0].Visits
+		for _, branch := range line.Branch[1:] {
+			falseCount += branch.Visits
+		}
+		return trueCount, falseCount
+	}
+	return min(covered, 1), max(covered-1, 0)
+}
+
+// elementCount returns how often a code element was executed: the hits of its first
+// coverable line, at least 1 if the element was hit.
+func elementCount(element model.CodeElement, lines map[int]model.Line) int {
+	if !reporter.IsElementHit(element, lines) {
+		return 0
+	}
+	for number := element.FirstLine; number <= element.LastLine; number++ {
+		if line, ok := lines[number]; ok && line.Hits >= 0 {
+			return max(line.Hits, 1)
+		}
+	}
+	return 1
+}
@@ -0,0 +1,201 @@
+package clover
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+var fixedTime = time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+
+// summaryReport has a C# assembly with two classes that share a file and a Go module
+// with approximated branches, normalized like a report built from parsed data.
+func summaryReport(t *testing.T) *model.SummaryResult {
+	t.Helper()
+	quota := 50.0
+	branchLine := model.Line{Number: 7, Hits: 4, IsBranchPoint: true, CoveredBranches: 1, TotalBranches: 2,
+		Branch: []model.BranchCoverageDetail{{Identifier: "0", Visits: 4}, {Identifier: "1", Visits: 0}}}
+	summary := &model.SummaryResult{
+		ParserName: "MultiReportParser (1x Cobertura, 1x GoCover)",
+		Timestamp:  1700000000,
+		Assemblies: []model.Assembly{
+			{Name: "Demo", Classes: []model.Class{
+				{
+					Name: "Demo.Calc",
+					Files: []model.CodeFile{{
+						Path:  "/src/Demo/Calc.cs",
+						Lines: []model.Line{model.NewLine(5, 4), model.NewLine(6, 4), branchLine, model.NewLine(8, 0), model.NewLine(9, -1)},
+						CodeElements: []model.CodeElement{
+							{Name: "Add", FullName: "Add(int, int)", Type: model.MethodElementType, FirstLine: 5, LastLine: 9, CoverageQuota: &quota},
+						},
+					}},
+					Methods: []model.Method{{Name: "Add", Lines: []model.Line{model.NewLine(5, 4), model.NewLine(8, 0)}}},
+				},
+				{
+					Name: "Demo.Calc<T>",
+					Files: []model.CodeFile{{
+						Path:  "/src/Demo/Calc.cs",
+						Lines: []model.Line{model.NewLine(14, 0), model.NewLine(15, 0)},
+						CodeElements: []model.CodeElement{
+							{Name: "Reset", FullName: "Reset()", Type: model.MethodElementType, FirstLine: 13, LastLine: 16},
+						},
+					}},
+					Methods: []model.Method{{Name: "Reset", Lines: []model.Line{model.NewLine(14, 0), model.NewLine(15, 0)}}},
+				},
+			}},
+			{Name: "example.com/tool", Classes: []model.Class{{
+				Name: "example.com/tool/parser",
+				Files: []model.CodeFile{
+					{
+						Path:                      "/src/tool/parser/parser.go",
+						ApproximateBranchCoverage: true,
+						Lines:                     []model.Line{model.NewLine(3, 1), model.NewBranchLine(4, 1, 1, 2), model.NewLine(5, 1)},
+						CodeElements: []model.CodeElement{
+							{Name: "Parse", FullName: "Parse(string)", Type: model.MethodElementType, FirstLine: 3, LastLine: 5},
+						},
+					},
+					{
+						Path:  "/src/tool/parser/lexer.go",
+						Lines: []model.Line{model.NewLine(10, 0), model.NewLine(11, 0)},
+					},
+				},
+			}}},
+		},
+	}
+	if err := analyzer.NormalizeSummary(summary, settings.NewSettings()); err != nil {
+		t.Fatalf("NormalizeSummary returned error: %v", err)
+	}
+	return summary
+}
+
+func createReport(t *testing.T, summary *model.SummaryResult, opts ...Option) []byte {
+	t.Helper()
+	outputDir := t.TempDir()
+	opts = append([]Option{WithClock(func() time.Time { return fixedTime })}, opts...)
+	builder := NewCloverReportBuilder(outputDir, slog.New(slog.NewTextHandler(io.Discard, nil)), opts...)
+
+	if err := builder.CreateReport(summary); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "clover.xml"))
+	if err != nil {
+		t.Fatalf("failed to read generated report: %v", err)
+	}
+	return content
+}
+
+// TestCreateReport_Golden compares clover.xml with the golden files in testdata. Run
+// `go test ./internal/reporter/clover -update` after intended changes.
+func TestCreateReport_Golden(t *testing.T) {
+	testCases := []struct {
+		name   string
+		golden string
+		opts   []Option
+	}{
+		{name: "Assemblies", golden: "clover.xml.golden", opts: []Option{WithProjectName("Demo Coverage")}},
+		{name: "Directories", golden: "clover_directories.xml.golden", opts: []Option{
+			WithPackages("Directory"), WithTimestamp(TimestampCoverage), WithSourceDirectories([]string{"/src"}),
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := createReport(t, summaryReport(t), tc.opts...)
+
+			goldenPath := filepath.Join("testdata", tc.golden)
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("clover.xml differs from %s; run with -update and review the diff\n--- got ---\n%s", goldenPath, got)
+			}
+		})
+	}
+}
+
+// TestCreateReport_TotalsMatchSummary expects the project metrics, the sums of the
+// package metrics and the sums of the class metrics to match the totals of the model,
+// which the TextSummary shows as coverable and covered lines and branches, and the
+// project methods to be the sums of the package methods.
+func TestCreateReport_TotalsMatchSummary(t *testing.T) {
+	for _, packages := range []string{PackagesByAssembly, PackagesByDirectory} {
+		t.Run(packages, func(t *testing.T) {
+			summary := summaryReport(t)
+			var report coverageElement
+			if err := xml.Unmarshal(createReport(t, summary, WithPackages(packages)), &report); err != nil {
+				t.Fatalf("failed to parse clover.xml: %v", err)
+			}
+
+			var packageTotals, classTotals metricsElement
+			for _, pkg := range report.Project.Packages {
+				packageTotals.add(pkg.Metrics)
+				for _, file := range pkg.Files {
+					for _, class := range file.Classes {
+						classTotals.add(class.Metrics)
+					}
+				}
+			}
+
+			for name, metrics := range map[string]metricsElement{"project": report.Project.Metrics, "packages": packageTotals, "classes": classTotals} {
+				if metrics.Statements != summary.LinesValid || metrics.CoveredStatements != summary.LinesCovered {
+					t.Errorf("%s: statements %d/%d, want the summary lines %d/%d",
+						name, metrics.CoveredStatements, metrics.Statements, summary.LinesCovered, summary.LinesValid)
+				}
+				if metrics.Conditionals != *summary.BranchesValid || metrics.CoveredConditionals != *summary.BranchesCovered {
+					t.Errorf("%s: conditionals %d/%d, want the summary branches %d/%d",
+						name, metrics.CoveredConditionals, metrics.Conditionals, *summary.BranchesCovered, *summary.BranchesValid)
+				}
+			}
+			if got := report.Project.Metrics; got.Methods != packageTotals.Methods || got.CoveredMethods != packageTotals.CoveredMethods {
+				t.Errorf("project methods %d/%d, want the package methods %d/%d",
+					got.CoveredMethods, got.Methods, packageTotals.CoveredMethods, packageTotals.Methods)
+			}
+		})
+	}
+}
+
+func TestCreateReport_Timestamp(t *testing.T) {
+	generated := `<project timestamp="1714638600000"`
+	testCases := []struct {
+		name      string
+		timestamp string
+		coverage  int64
+		want      string
+	}{
+		{name: "Generated", timestamp: TimestampGenerated, coverage: 1700000000, want: generated},
+		{name: "Coverage", timestamp: TimestampCoverage, coverage: 1700000000, want: `<project timestamp="1700000000000"`},
+		{name: "NoCoverageTimestamp", timestamp: TimestampCoverage, want: generated},
+		{name: "Unknown", timestamp: "yesterday", coverage: 1700000000, want: generated},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := createReport(t, &model.SummaryResult{Timestamp: tc.coverage}, WithTimestamp(tc.timestamp))
+
+			if !bytes.Contains(got, []byte(`<coverage generated="1714638600000"`)) {
+				t.Errorf("expected the generation time in the coverage element, got:\n%s", got)
+			}
+			if !bytes.Contains(got, []byte(tc.want)) {
+				t.Errorf("expected %s in report, got:\n%s", tc.want, got)
+			}
+		})
+	}
+}
@@ -4,21 +4,47 @@ package reporter
 import (
 	"io"
 	"log/slog"
+	"sync"
+	"time"
 
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/progress"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/version"
 )
 
 type IBuilderContext interface {
 	ReportConfiguration() *reportconfig.ReportConfiguration
 	Settings() *settings.Settings
 	Logger() *slog.Logger
+	// Now returns the time stamped into generated reports.
+	Now() time.Time
+	// AppVersion returns the version shown in generated reports.
+	AppVersion() string
+	// CommandLine returns the sanitized arguments the reports were generated with, empty
+	// if they are not known, e.g. in tests.
+	CommandLine() string
+	// Progress returns the reporter for the progress of long-running steps, e.g.
+	// rendering the class pages.
+	Progress() progress.Reporter
+	// Aggregates returns the totals and quotas of summary, which are computed once and
+	// shared by all report types generated from the same summary.
+	Aggregates(summary *model.SummaryResult) *Aggregates
 }
 
 type BuilderContext struct {
-	Cfg   *reportconfig.ReportConfiguration
-	Stngs *settings.Settings
-	L     *slog.Logger
+	Cfg     *reportconfig.ReportConfiguration
+	Stngs   *settings.Settings
+	L       *slog.Logger
+	Clock   func() time.Time
+	Version string
+	Args    string
+
+	aggregatesOnce    sync.Once
+	aggregatesSummary *model.SummaryResult
+	aggregates        *Aggregates
 }
 
 func (bc *BuilderContext) ReportConfiguration() *reportconfig.ReportConfiguration { return bc.Cfg }
@@ -27,15 +53,102 @@ func (bc *BuilderContext) Settings() *settings.Settings { return bc.Stngs }
 
 func (bc *BuilderContext) Logger() *slog.Logger { return bc.L }
 
-func NewBuilderContext(config *reportconfig.ReportConfiguration, settings *settings.Settings, logger *slog.Logger) *BuilderContext {
+func (bc *BuilderContext) Now() time.Time { return bc.Clock() }
+
+func (bc *BuilderContext) AppVersion() string { return bc.Version }
+
+func (bc *BuilderContext) CommandLine() string { return bc.Args }
+
+func (bc *BuilderContext) Progress() progress.Reporter {
+	if bc.Cfg == nil {
+		return progress.Discard
+	}
+	return bc.Cfg.Progress()
+}
+
+// Aggregates computes the aggregates of summary with the decimal places and the rounding
+// mode of the settings. They are computed once for the first summary, as a run generates
+// all report types from the same summary; the aggregates of another summary (e.g. of a
+// report served again after its files changed) are computed on every call.
+func (bc *BuilderContext) Aggregates(summary *model.SummaryResult) *Aggregates {
+	bc.aggregatesOnce.Do(func() {
+		bc.aggregatesSummary = summary
+		bc.aggregates = bc.newAggregates(summary)
+	})
+	if summary != bc.aggregatesSummary {
+		return bc.newAggregates(summary)
+	}
+	return bc.aggregates
+}
+
+func (bc *BuilderContext) newAggregates(summary *model.SummaryResult) *Aggregates {
+	s := bc.Stngs
+	if s == nil {
+		s = settings.NewSettings()
+	}
+	// The mode was validated when the configuration was created.
+	roundingMode, _ := utils.ParseRoundingMode(s.CoverageQuotaRoundingMode)
+	return NewAggregates(summary, s.MaximumDecimalPlacesForCoverageQuotas, roundingMode, PathKeyFunc(s))
+}
+
+// PathKeyFunc returns the key by which the reports compare file paths, with the path case
+// mode of the settings, see settings.Settings.PathCase.
+func PathKeyFunc(s *settings.Settings) func(string) string {
+	return utils.PathKeyFunc(PathCaseMode(s), false)
+}
+
+// PathCaseMode returns the path case mode of the settings, see settings.Settings.PathCase.
+func PathCaseMode(s *settings.Settings) utils.PathCaseMode {
+	if s == nil {
+		return utils.PathCaseAuto
+	}
+	// The mode was validated when the configuration was created.
+	mode, _ := utils.ParsePathCaseMode(s.PathCase)
+	return mode
+}
+
+// ContextOption configures a BuilderContext.
+type ContextOption func(*BuilderContext)
+
+// WithClock replaces time.Now, e.g. to make generated reports reproducible in tests.
+func WithClock(now func() time.Time) ContextOption {
+	return func(bc *BuilderContext) {
+		if now != nil {
+			bc.Clock = now
+		}
+	}
+}
+
+// WithAppVersion overrides the version read from the build information.
+func WithAppVersion(v string) ContextOption {
+	return func(bc *BuilderContext) {
+		bc.Version = v
+	}
+}
+
+// WithCommandLine records the arguments the reports are generated with. They are written
+// into the reports as they are, so secrets must have been removed from them.
+func WithCommandLine(commandLine string) ContextOption {
+	return func(bc *BuilderContext) {
+		bc.Args = commandLine
+	}
+}
+
+func NewBuilderContext(config *reportconfig.ReportConfiguration, settings *settings.Settings, logger *slog.Logger, opts ...ContextOption) *BuilderContext {
 	if logger == nil {
 		// Default to a discarded logger if none is provided to prevent nil pointer panics.
 		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 
-	return &BuilderContext{
-		Cfg:   config,
-		Stngs: settings,
-		L:     logger,
+	bc := &BuilderContext{
+		Cfg:     config,
+		Stngs:   settings,
+		L:       logger,
+		Clock:   time.Now,
+		Version: version.Version(),
+	}
+	for _, opt := range opts {
+		opt(bc)
 	}
+	return bc
 }
@@ -0,0 +1,59 @@
+package reporter
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// StaleClass is a class whose line coverage has stayed below the coverage age threshold.
+type StaleClass struct {
+	Assembly string
+	Class    *model.Class
+}
+
+// FormatCoverageAgeThreshold formats the threshold of a coverage age, e.g. "80%".
+func FormatCoverageAgeThreshold(age *model.CoverageAge) string {
+	return strconv.FormatFloat(age.Threshold, 'f', -1, 64) + "%"
+}
+
+// FormatCoverageAgeSince formats the date of the first run of a coverage age in local
+// time, e.g. "2024-03-02".
+func FormatCoverageAgeSince(age *model.CoverageAge) string {
+	return time.Unix(age.Since, 0).Format("2006-01-02")
+}
+
+// LongestStaleClasses returns up to limit classes with a coverage age, those below the
+// threshold for the most runs first, ties ordered by the first run and the class name.
+func LongestStaleClasses(summary *model.SummaryResult, limit int) []StaleClass {
+	if limit <= 0 {
+		return nil
+	}
+
+	var classes []StaleClass
+	for ai := range summary.Assemblies {
+		assembly := &summary.Assemblies[ai]
+		for ci := range assembly.Classes {
+			if class := &assembly.Classes[ci]; class.CoverageAge != nil {
+				classes = append(classes, StaleClass{Assembly: assembly.Name, Class: class})
+			}
+		}
+	}
+
+	sort.SliceStable(classes, func(i, j int) bool {
+		a, b := classes[i].Class.CoverageAge, classes[j].Class.CoverageAge
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		if a.Since != b.Since {
+			return a.Since < b.Since
+		}
+		return classes[i].Class.DisplayName < classes[j].Class.DisplayName
+	})
+	if len(classes) > limit {
+		classes = classes[:limit]
+	}
+	return classes
+}
@@ -0,0 +1,198 @@
+package deltasummary
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer/diff"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+const (
+	textFileName     = "DeltaSummary.txt"
+	markdownFileName = "DeltaSummary.md"
+)
+
+// DeltaReportBuilder writes the coverage change between a baseline and the current
+// run as a text and a markdown file. Only assemblies and classes that changed are listed.
+type DeltaReportBuilder struct {
+	outputDir string
+	baseline  *model.SummaryResult
+	logger    *slog.Logger
+}
+
+// NewDeltaReportBuilder creates a builder that compares reports against the baseline.
+func NewDeltaReportBuilder(outputDir string, baseline *model.SummaryResult, logger *slog.Logger) reporter.ReportBuilder {
+	return &DeltaReportBuilder{
+		outputDir: outputDir,
+		baseline:  baseline,
+		logger:    logger,
+	}
+}
+
+// ReportType returns the type of report this builder generates.
+func (b *DeltaReportBuilder) ReportType() string {
+	return "DeltaSummary"
+}
+
+// CreateReport compares the summary with the baseline and writes both delta files.
+func (b *DeltaReportBuilder) CreateReport(summary *model.SummaryResult) error {
+	if b.baseline == nil {
+		return fmt.Errorf("no baseline to compare with")
+	}
+	if err := os.MkdirAll(b.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	result := diff.Compare(b.baseline, summary)
+
+	writers := []struct {
+		fileName string
+		write    func(io.Writer, *diff.Result)
+	}{
+		{textFileName, writeText},
+		{markdownFileName, writeMarkdown},
+	}
+	for _, w := range writers {
+		var buf bytes.Buffer
+		w.write(&buf, result)
+
+		outputPath := filepath.Join(b.outputDir, w.fileName)
+		if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write delta report '%s': %w", outputPath, err)
+		}
+		b.logger.Info("Wrote delta summary", "path", outputPath)
+	}
+	return nil
+}
+
+func writeText(w io.Writer, result *diff.Result) {
+	newClasses, removedClasses := countClassChanges(result)
+
+	fmt.Fprintln(w, "Coverage delta")
+	fmt.Fprintf(w, "  Line coverage: %s -> %s (%s)\n",
+		formatCoverage(result.Baseline), formatCoverage(result.Current), formatChange(result.LineCoverageChange()))
+	fmt.Fprintf(w, "  Newly uncovered lines: %d\n", result.NewlyUncoveredLines)
+	fmt.Fprintf(w, "  New classes: %d\n", newClasses)
+	fmt.Fprintf(w, "  Removed classes: %d\n", removedClasses)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+	for _, assembly := range result.Assemblies {
+		classes := changedClasses(assembly)
+		if len(classes) == 0 && assembly.Presence == diff.InBoth {
+			continue
+		}
+
+		fmt.Fprintln(tw)
+		fmt.Fprintf(tw, "%s%s\t  %s -> %s\t  %s\t  %d newly uncovered\n",
+			assembly.Name, presenceSuffix(assembly.Presence),
+			formatCoverage(assembly.Baseline), formatCoverage(assembly.Current),
+			formatChange(assembly.LineCoverageChange()), assembly.NewlyUncoveredLines)
+		for _, class := range classes {
+			fmt.Fprintf(tw, "  %s%s\t  %s -> %s\t  %s\t  %d newly uncovered\n",
+				class.DisplayName, presenceSuffix(class.Presence),
+				formatCoverage(class.Baseline), formatCoverage(class.Current),
+				formatChange(class.LineCoverageChange()), class.NewlyUncoveredLines)
+		}
+	}
+}
+
+func writeMarkdown(w io.Writer, result *diff.Result) {
+	newClasses, removedClasses := countClassChanges(result)
+
+	fmt.Fprintln(w, "# Coverage delta")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| | Baseline | Current | Change |")
+	fmt.Fprintln(w, "| :--- | ---: | ---: | ---: |")
+	fmt.Fprintf(w, "| Line coverage | %s | %s | %s |\n",
+		formatCoverage(result.Baseline), formatCoverage(result.Current), formatChange(result.LineCoverageChange()))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Newly uncovered lines: **%d** · New classes: **%d** · Removed classes: **%d**\n",
+		result.NewlyUncoveredLines, newClasses, removedClasses)
+
+	for _, assembly := range result.Assemblies {
+		classes := changedClasses(assembly)
+		if len(classes) == 0 && assembly.Presence == diff.InBoth {
+			continue
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "## %s%s\n", escapeMarkdown(assembly.Name), presenceSuffix(assembly.Presence))
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "| Class | Baseline | Current | Change | Newly uncovered lines |")
+		fmt.Fprintln(w, "| :--- | ---: | ---: | ---: | ---: |")
+		for _, class := range classes {
+			fmt.Fprintf(w, "| %s%s | %s | %s | %s | %d |\n",
+				escapeMarkdown(class.DisplayName), presenceSuffix(class.Presence),
+				formatCoverage(class.Baseline), formatCoverage(class.Current),
+				formatChange(class.LineCoverageChange()), class.NewlyUncoveredLines)
+		}
+	}
+}
+
+// changedClasses returns the classes that were added, removed, changed their
+// coverage or gained uncovered lines.
+func changedClasses(assembly diff.AssemblyDelta) []diff.ClassDelta {
+	var changed []diff.ClassDelta
+	for _, class := range assembly.Classes {
+		if class.Presence != diff.InBoth || class.NewlyUncoveredLines > 0 || class.Baseline != class.Current {
+			changed = append(changed, class)
+		}
+	}
+	return changed
+}
+
+func countClassChanges(result *diff.Result) (added, removed int) {
+	for _, assembly := range result.Assemblies {
+		for _, class := range assembly.Classes {
+			switch class.Presence {
+			case diff.OnlyInCurrent:
+				added++
+			case diff.OnlyInBaseline:
+				removed++
+			}
+		}
+	}
+	return added, removed
+}
+
+func presenceSuffix(p diff.Presence) string {
+	if p == diff.InBoth {
+		return ""
+	}
+	return " [" + p.String() + "]"
+}
+
+func formatCoverage(c diff.Coverage) string {
+	if c.LinesValid == 0 {
+		return "-"
+	}
+	return utils.FormatPercentage(c.LineCoverage(), 1)
+}
+
+// formatChange formats a change in percentage points with an explicit sign.
+func formatChange(change float64) string {
+	if math.IsNaN(change) {
+		return "-"
+	}
+	if change == 0 {
+		return "0.0 pp"
+	}
+	return fmt.Sprintf("%+.1f pp", change)
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "*", "\\*", "<", "&lt;", ">", "&gt;")
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,160 @@
+package deltasummary
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// class returns a class of a single file whose lines are covered ('+') or not ('-').
+func class(name string, lines string) model.Class {
+	c := model.Class{Name: name, DisplayName: name, Files: []model.CodeFile{{Path: name + ".cs"}}}
+	for i, status := range lines {
+		line := model.Line{Number: i + 1, LineVisitStatus: model.NotCovered}
+		if status == '+' {
+			line.Hits, line.LineVisitStatus = 1, model.Covered
+			c.LinesCovered++
+		}
+		c.LinesValid++
+		c.Files[0].Lines = append(c.Files[0].Lines, line)
+	}
+	return c
+}
+
+// summary returns a summary of the assemblies with the totals of their classes.
+func summary(assemblies ...model.Assembly) *model.SummaryResult {
+	result := &model.SummaryResult{}
+	for i := range assemblies {
+		for _, c := range assemblies[i].Classes {
+			assemblies[i].LinesCovered += c.LinesCovered
+			assemblies[i].LinesValid += c.LinesValid
+		}
+		result.LinesCovered += assemblies[i].LinesCovered
+		result.LinesValid += assemblies[i].LinesValid
+	}
+	result.Assemblies = assemblies
+	return result
+}
+
+// createReport compares current with baseline and returns the text and markdown files.
+func createReport(t *testing.T, baseline, current *model.SummaryResult) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	builder := NewDeltaReportBuilder(dir, baseline, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := builder.CreateReport(current); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+	files := make([]string, 0, 2)
+	for _, name := range []string{textFileName, markdownFileName} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		files = append(files, string(content))
+	}
+	return files[0], files[1]
+}
+
+// deltaReports returns a baseline and a current run in which, in assembly App, Calc lost
+// the coverage of line 2 and gained the uncovered line 4, Legacy was removed and
+// Parser<T> was added. Assembly Old was removed and Tools did not change.
+func deltaReports() (baseline, current *model.SummaryResult) {
+	baseline = summary(
+		model.Assembly{Name: "App", Classes: []model.Class{class("Calc", "++-"), class("Legacy", "+-")}},
+		model.Assembly{Name: "Old", Classes: []model.Class{class("Old.Job", "+")}},
+		model.Assembly{Name: "Tools", Classes: []model.Class{class("Stable", "+-")}},
+	)
+	parser := class("Parser", "+-")
+	parser.DisplayName = "Parser<T>"
+	current = summary(
+		model.Assembly{Name: "App", Classes: []model.Class{class("Calc", "+---"), parser}},
+		model.Assembly{Name: "Tools", Classes: []model.Class{class("Stable", "+-")}},
+	)
+	return baseline, current
+}
+
+func TestCreateReport_Text(t *testing.T) {
+	baseline, current := deltaReports()
+	text, _ := createReport(t, baseline, current)
+
+	want := strings.Join([]string{
+		"Coverage delta",
+		"  Line coverage: 62.5% -> 37.5% (-25.0 pp)",
+		"  Newly uncovered lines: 3",
+		"  New classes: 1",
+		"  Removed classes: 2",
+		"",
+		"App                   60.0% -> 33.3%    -26.7 pp    3 newly uncovered",
+		"  Calc                66.6% -> 25.0%    -41.6 pp    2 newly uncovered",
+		"  Legacy [removed]    50.0% -> -        -           0 newly uncovered",
+		"  Parser<T> [new]     - -> 50.0%        -           1 newly uncovered",
+		"",
+		"Old [removed]          100.0% -> -    -    0 newly uncovered",
+		"  Old.Job [removed]    100.0% -> -    -    0 newly uncovered",
+		"",
+	}, "\n")
+	if text != want {
+		t.Errorf("unexpected %s:\n%s\nwant:\n%s", textFileName, text, want)
+	}
+}
+
+func TestCreateReport_Markdown(t *testing.T) {
+	baseline, current := deltaReports()
+	_, markdown := createReport(t, baseline, current)
+
+	want := strings.Join([]string{
+		"# Coverage delta",
+		"",
+		"| | Baseline | Current | Change |",
+		"| :--- | ---: | ---: | ---: |",
+		"| Line coverage | 62.5% | 37.5% | -25.0 pp |",
+		"",
+		"Newly uncovered lines: **3** · New classes: **1** · Removed classes: **2**",
+		"",
+		"## App",
+		"",
+		"| Class | Baseline | Current | Change | Newly uncovered lines |",
+		"| :--- | ---: | ---: | ---: | ---: |",
+		"| Calc | 66.6% | 25.0% | -41.6 pp | 2 |",
+		"| Legacy [removed] | 50.0% | - | - | 0 |",
+		"| Parser&lt;T&gt; [new] | - | 50.0% | - | 1 |",
+		"",
+		"## Old [removed]",
+		"",
+		"| Class | Baseline | Current | Change | Newly uncovered lines |",
+		"| :--- | ---: | ---: | ---: | ---: |",
+		"| Old.Job [removed] | 100.0% | - | - | 0 |",
+		"",
+	}, "\n")
+	if markdown != want {
+		t.Errorf("unexpected %s:\n%s\nwant:\n%s", markdownFileName, markdown, want)
+	}
+}
+
+func TestCreateReport_UnchangedRun(t *testing.T) {
+	baseline, _ := deltaReports()
+	current, _ := deltaReports()
+
+	text, markdown := createReport(t, baseline, current)
+
+	if !strings.HasSuffix(text, "  Removed classes: 0\n") {
+		t.Errorf("expected no assemblies to be listed, got:\n%s", text)
+	}
+	if strings.Contains(markdown, "##") {
+		t.Errorf("expected no assemblies to be listed, got:\n%s", markdown)
+	}
+}
+
+func TestCreateReport_WithoutBaseline(t *testing.T) {
+	_, current := deltaReports()
+	builder := NewDeltaReportBuilder(t.TempDir(), nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := builder.CreateReport(current); err == nil {
+		t.Error("expected an error without a baseline")
+	}
+}
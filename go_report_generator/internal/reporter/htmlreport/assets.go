@@ -5,7 +5,6 @@ import (
 	"io"
 	"io/fs"
 	"log/slog"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -22,7 +21,7 @@ func (b *HtmlReportBuilder) initializeAssets() error {
 		return fmt.Errorf("failed to copy static assets: %w", err)
 	}
 
-	if err := b.copyAngularAssets(b.OutputDir); err != nil {
+	if err := b.copyAngularAssets(); err != nil {
 		return fmt.Errorf("failed to copy angular assets: %w", err)
 	}
 
@@ -74,12 +73,10 @@ func (b *HtmlReportBuilder) initializeAssets() error {
 	jsBuilder.Write(mainContent)
 	jsBuilder.WriteString(";\n")
 
-	// Write the combined JavaScript to a file in the output directory on the real disk.
+	// Write the combined JavaScript to a file in the output directory.
 	b.combinedAngularJsFile = "reportgenerator.combined.js"
-	combinedJsPath := filepath.Join(b.OutputDir, b.combinedAngularJsFile)
-	err = os.WriteFile(combinedJsPath, []byte(jsBuilder.String()), 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write combined Angular JS file %s: %w", combinedJsPath, err)
+	if err := b.writeOutputFile(b.combinedAngularJsFile, []byte(jsBuilder.String())); err != nil {
+		return fmt.Errorf("failed to write combined Angular JS file: %w", err)
 	}
 
 	// Clear out the individual JS file names as they are no longer needed for the template.
@@ -112,8 +109,6 @@ func (b *HtmlReportBuilder) copyStaticAssets() error {
 	}
 
 	for _, fileName := range filesToCopy {
-		destinationPath := filepath.Join(b.OutputDir, fileName)
-
 		// Read the file content from the embedded filesystem.
 		content, err := fs.ReadFile(angularComplementsFS, fileName)
 		if err != nil {
@@ -122,9 +117,8 @@ func (b *HtmlReportBuilder) copyStaticAssets() error {
 			continue
 		}
 
-		// Write the content to the destination file on the real disk.
-		if err := os.WriteFile(destinationPath, content, 0644); err != nil {
-			return fmt.Errorf("failed to write asset %s to output directory: %w", destinationPath, err)
+		if err := b.writeOutputFile(fileName, content); err != nil {
+			return fmt.Errorf("failed to write asset %s to output directory: %w", fileName, err)
 		}
 	}
 
@@ -145,8 +139,7 @@ func (b *HtmlReportBuilder) copyStaticAssets() error {
 	combinedCSSBuilder.Write(customDarkCSSBytes)
 
 	if combinedCSSBuilder.Len() > 0 {
-		err = os.WriteFile(filepath.Join(b.OutputDir, "report.css"), []byte(combinedCSSBuilder.String()), 0644)
-		if err != nil {
+		if err := b.writeOutputFile("report.css", []byte(combinedCSSBuilder.String())); err != nil {
 			return fmt.Errorf("failed to write combined report.css: %w", err)
 		}
 	} else {
@@ -156,45 +149,31 @@ func (b *HtmlReportBuilder) copyStaticAssets() error {
 	return nil
 }
 
-// copyAngularAssets recursively copies all files from the embedded Angular app's dist filesystem
-// to the report's output directory on the real disk, preserving the directory structure.
-func (b *HtmlReportBuilder) copyAngularAssets(outputDir string) error {
+// copyAngularAssets copies all files from the embedded Angular app's dist filesystem
+// to the report's output, preserving the directory structure.
+func (b *HtmlReportBuilder) copyAngularAssets() error {
 	// Get the embedded filesystem containing the compiled Angular application.
 	angularDistFS, err := assets.AngularDist()
 	if err != nil {
 		return fmt.Errorf("could not get embedded angular assets: %w", err)
 	}
-	// Walk the embedded filesystem and copy each file and directory.
-	// The root "." refers to the root of the embedded filesystem.
+	// Walk the embedded filesystem and copy each file. Directories are created
+	// by copyOutputFile. The root "." refers to the root of the embedded filesystem.
 	return fs.WalkDir(angularDistFS, ".", func(path string, directoryEntry fs.DirEntry, walkError error) error {
 		if walkError != nil {
 			return fmt.Errorf("error accessing path %s during walk: %w", path, walkError)
 		}
-
-		destinationPath := filepath.Join(outputDir, path)
-
 		if directoryEntry.IsDir() {
-			// If it's a directory, create it on the real disk.
-			if err := os.MkdirAll(destinationPath, 0755); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", destinationPath, err)
-			}
-		} else {
-			// If it's a file, read it from the embedded FS and write it to the real disk.
-			sourceFile, err := angularDistFS.Open(path)
-			if err != nil {
-				return fmt.Errorf("failed to open embedded file %s: %w", path, err)
-			}
-			defer sourceFile.Close()
-
-			destinationFile, err := os.Create(destinationPath)
-			if err != nil {
-				return fmt.Errorf("failed to create destination file %s: %w", destinationPath, err)
-			}
-			defer destinationFile.Close()
+			return nil
+		}
 
-			if _, err := io.Copy(destinationFile, sourceFile); err != nil {
-				return fmt.Errorf("failed to copy file content to %s: %w", destinationPath, err)
-			}
+		file, err := angularDistFS.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded file %s: %w", path, err)
+		}
+		defer file.Close()
+		if err := b.copyOutputFile(path, file); err != nil {
+			return fmt.Errorf("failed to copy embedded file %s: %w", path, err)
 		}
 		return nil
 	})
@@ -1,18 +1,37 @@
 package htmlreport
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt" // fmt is still needed for fmt.Errorf
 	"html/template"
-	"os"
+	"io"
+	"log/slog"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/progress"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// Values of the "classdetails" parameter of the Html report type, e.g.
+// Html{classdetails=ondemand}.
+const (
+	classDetailsModePages    = "pages"
+	classDetailsModeOnDemand = "ondemand"
 )
 
 type HtmlReportBuilder struct {
 	OutputDir     string
 	ReportContext reporter.IBuilderContext
+	// FS receives all files of the report. NewHtmlReportBuilder sets it to OSOutputFS.
+	FS OutputFS
 
 	// Cached data for reuse across page generations
 	angularCssFile                     string
@@ -31,29 +50,112 @@ type HtmlReportBuilder struct {
 	methodCoverageAvailable                  bool
 	maximumDecimalPlacesForCoverageQuotas    int
 	maximumDecimalPlacesForPercentageDisplay int
+	coverageQuotaRoundingMode                utils.RoundingMode
 	parserName                               string
 	reportTimestamp                          int64
 	reportTitle                              string
 	tag                                      string
+	tagLink                                  string
 	translations                             map[string]string
-	onlySummary                              bool
-
-	classReportFilenames       map[string]string
+	onlySummary                              bool // HtmlSummary report type, see NewHtmlSummaryReportBuilder
+	classDetailsOnDemand                     bool // Html{classdetails=ondemand}, see renderClassDetailData
+	uncoveredLinesClassLimit                 int
+	syntaxHighlight                          bool
+	maximumLineLength                        int // 0: no limit, see displayedSourceLines
+	minifiedAverageLineLength                int // 0: no file counts as minified, see isMinified
+	maximumHistoricCoveragesPerClass         int // 0: no limit
+	summarySort                              SummarySort
+	summaryTopN                              int // Classes per assembly in index.html, 0: no limit
+	appVersion                               string
+	commandLine                              string    // Sanitized arguments of the run shown in the footer
+	generatedAt                              time.Time // Stamped into all pages of one report
+	incremental                              bool      // See startIncremental
+	markExternalFiles                        bool      // The report has files outside the source directories, see model.CodeFile.InSourceDirs
+
+	// fullMethodCoverage is the definition of fully covered methods described on the
+	// method coverage card.
+	fullMethodCoverage analyzer.FullMethodCoverage
+
+	// classReportFilenames holds the detail page filename reserved for each class. It is
+	// filled once by reserveClassReportFilenames and only read afterwards.
+	classReportFilenames       map[classReportKey]string
 	tempExistingLowerFilenames map[string]struct{}
+	// assemblyReportFilenames holds the page of each assembly by assembly name, reserved
+	// after the class pages so that their filenames do not change.
+	assemblyReportFilenames map[string]string
 
 	combinedAngularJsFile string // To store "reportgenerator.combined.js"
+
+	// incrementalState holds the class page hashes of the last and the current run while
+	// the class pages of an incremental report are rendered.
+	incrementalState *incrementalState
+
+	// riskHotspotTooltips holds the tooltip of the risk hotspot badge of every hotspot
+	// method, see findRiskHotspots.
+	riskHotspotTooltips map[*model.Method]string
+
+	// sourceLines holds the source files of the class being rendered, see readSourceLines.
+	sourceLines map[string][]string
+	// fileReader reads the source files whose content the coverage data does not hold.
+	fileReader filereader.Reader
 }
 
 func NewHtmlReportBuilder(outputDir string, reportCtx reporter.IBuilderContext) *HtmlReportBuilder {
 	return &HtmlReportBuilder{
 		OutputDir:                  outputDir,
 		ReportContext:              reportCtx,
-		classReportFilenames:       make(map[string]string),
+		FS:                         OSOutputFS{},
+		classReportFilenames:       make(map[classReportKey]string),
 		tempExistingLowerFilenames: make(map[string]struct{}),
+		assemblyReportFilenames:    make(map[string]string),
+		fileReader:                 sourceFileReader(reportCtx),
+	}
+}
+
+// sourceFileReader returns a reader that decodes the source files with the encoding of
+// settings.SourceFileEncoding, which the configuration has validated, or one that keeps
+// their bytes if there are no settings.
+func sourceFileReader(reportCtx reporter.IBuilderContext) filereader.Reader {
+	if reportCtx != nil && reportCtx.Settings() != nil {
+		if reader, err := filereader.NewSourceFileReader(reportCtx.Settings().SourceFileEncoding); err == nil {
+			return reader
+		}
+	}
+	return filereader.NewDefaultReader()
+}
+
+// NewHtmlSummaryReportBuilder creates a builder for the HtmlSummary report type. It writes
+// index.html with its assets and the risk hotspots page, but no class or assembly pages,
+// which take most of the time and space for large solutions. The classes in the summary
+// table are not linked then.
+func NewHtmlSummaryReportBuilder(outputDir string, reportCtx reporter.IBuilderContext) *HtmlReportBuilder {
+	b := NewHtmlReportBuilder(outputDir, reportCtx)
+	b.onlySummary = true
+	return b
+}
+
+// logger returns the context logger, falling back to the default logger when
+// the builder is used without a context (e.g. in tests).
+func (b *HtmlReportBuilder) logger() *slog.Logger {
+	if b.ReportContext != nil && b.ReportContext.Logger() != nil {
+		return b.ReportContext.Logger()
+	}
+	return slog.Default()
+}
+
+// pathKey returns the key by which file paths are compared, see reporter.PathKeyFunc,
+// falling back to utils.PathKey when the builder is used without a context.
+func (b *HtmlReportBuilder) pathKey() func(string) string {
+	if b.ReportContext != nil {
+		return reporter.PathKeyFunc(b.ReportContext.Settings())
 	}
+	return utils.PathKey
 }
 
 func (b *HtmlReportBuilder) ReportType() string {
+	if b.onlySummary {
+		return "HtmlSummary"
+	}
 	return "Html"
 }
 
@@ -64,11 +166,36 @@ func (b *HtmlReportBuilder) CreateReport(report *model.SummaryResult) error {
 	if err := b.prepareOutputDirectory(); err != nil {
 		return err
 	}
+	return b.generate(report)
+}
+
+// CreateReportInMemory renders the report like CreateReport, but returns the files keyed
+// by their slash-separated path relative to the report root instead of writing them to
+// OutputDir. It is used to serve the report without touching the disk.
+func (b *HtmlReportBuilder) CreateReportInMemory(report *model.SummaryResult) (map[string][]byte, error) {
+	if err := b.validateContext(); err != nil {
+		return nil, err
+	}
+	memoryFS := NewMemoryFS()
+	previousFS := b.FS
+	b.FS = memoryFS
+	defer func() { b.FS = previousFS }()
+
+	if err := b.generate(report); err != nil {
+		return nil, err
+	}
+	return memoryFS.filesBelow(b.OutputDir), nil
+}
+
+// generate builds the view models and renders all files of the report.
+func (b *HtmlReportBuilder) generate(report *model.SummaryResult) error {
 	if err := b.initializeAssets(); err != nil { // Copies static assets and parses Angular index.html
 		return err
 	}
 
-	b.initializeBuilderProperties(report)                   // Sets up common properties like title, translations etc.
+	if err := b.initializeBuilderProperties(report); err != nil { // Sets up common properties like title, translations etc.
+		return err
+	}
 	if err := b.prepareGlobalJSONData(report); err != nil { // Prepares metricsJSON, riskHotspotMetricsJSON etc.
 		return err
 	}
@@ -83,7 +210,8 @@ func (b *HtmlReportBuilder) CreateReport(report *model.SummaryResult) error {
 		return fmt.Errorf("failed to build angular assembly view models for summary: %w", err)
 	}
 
-	var angularRiskHotspots []AngularRiskHotspotViewModel              // Placeholder
+	riskHotspots := b.findRiskHotspots(report)
+	angularRiskHotspots := b.buildAngularRiskHotspotViewModels(riskHotspots)
 	if err := b.setRiskHotspotsJSON(angularRiskHotspots); err != nil { // Prepares b.riskHotspotsJSON
 		return err
 	}
@@ -96,8 +224,14 @@ func (b *HtmlReportBuilder) CreateReport(report *model.SummaryResult) error {
 	if err := b.renderSummaryPage(summaryData); err != nil {
 		return fmt.Errorf("failed to render summary page: %w", err)
 	}
+	if err := b.renderRiskHotspotsPage(riskHotspots); err != nil {
+		return fmt.Errorf("failed to render risk hotspots page: %w", err)
+	}
 
 	if !b.onlySummary {
+		if err := b.renderAssemblyPages(report, summaryData, angularAssembliesForSummary); err != nil {
+			return fmt.Errorf("failed to render assembly pages: %w", err)
+		}
 		// renderClassDetailPages uses b.classReportFilenames, so it doesn't need angularAssembliesForSummary
 		if err := b.renderClassDetailPages(report); err != nil {
 			return fmt.Errorf("failed to render class detail pages: %w", err)
@@ -116,35 +250,132 @@ func (b *HtmlReportBuilder) validateContext() error {
 }
 
 func (b *HtmlReportBuilder) prepareOutputDirectory() error {
-	return os.MkdirAll(b.OutputDir, 0755)
+	return b.outputFS().MkdirAll(b.OutputDir, 0755)
+}
+
+// outputFS returns the filesystem the report is written to, falling back to the disk
+// when the builder is used without NewHtmlReportBuilder.
+func (b *HtmlReportBuilder) outputFS() OutputFS {
+	if b.FS == nil {
+		return OSOutputFS{}
+	}
+	return b.FS
 }
 
-func (b *HtmlReportBuilder) initializeBuilderProperties(report *model.SummaryResult) {
+// outputPath returns the path of a file of the report, given by its slash-separated path
+// relative to the report root, and creates its directory.
+func (b *HtmlReportBuilder) outputPath(name string) (string, error) {
+	outputPath := filepath.Join(b.OutputDir, filepath.FromSlash(name))
+	if err := b.outputFS().MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", outputPath, err)
+	}
+	return outputPath, nil
+}
+
+// writeOutputFile writes a file of the report, given by its slash-separated path relative
+// to the report root.
+func (b *HtmlReportBuilder) writeOutputFile(name string, content []byte) error {
+	outputPath, err := b.outputPath(name)
+	if err != nil {
+		return err
+	}
+	if err := b.outputFS().WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+// copyOutputFile streams the content of src to a file of the report, given like in writeOutputFile.
+func (b *HtmlReportBuilder) copyOutputFile(name string, src io.Reader) error {
+	outputPath, err := b.outputPath(name)
+	if err != nil {
+		return err
+	}
+	file, err := b.outputFS().CreateFile(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+	if _, err := io.Copy(file, src); err != nil {
+		if discarder, ok := file.(interface{ Discard() }); ok {
+			discarder.Discard()
+		} else {
+			file.Close()
+		}
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+func (b *HtmlReportBuilder) initializeBuilderProperties(report *model.SummaryResult) error {
 	reportConfig := b.ReportContext.ReportConfiguration()
 	settings := b.ReportContext.Settings()
 
-	b.reportTitle = reportConfig.Title()
+	b.reportTitle = reportConfig.TitleForReportType(b.ReportType())
 	if b.reportTitle == "" {
 		b.reportTitle = "Summary" // Default for summary page
 	}
 	b.parserName = report.ParserName
 	b.reportTimestamp = report.Timestamp
 	b.tag = reportConfig.Tag()
+	b.tagLink = reportConfig.TagLink()
 	b.branchCoverageAvailable = report.BranchesValid != nil && *report.BranchesValid > 0
-	b.methodCoverageAvailable = true
+	b.methodCoverageAvailable = report.MethodCoverageAvailable
+	b.markExternalFiles = report.ExternalFiles > 0
 	b.maximumDecimalPlacesForCoverageQuotas = settings.MaximumDecimalPlacesForCoverageQuotas
+	b.fullMethodCoverage = analyzer.NewFullMethodCoverage(settings)
 	b.maximumDecimalPlacesForPercentageDisplay = settings.MaximumDecimalPlacesForPercentageDisplay
-	b.translations = GetTranslations()
+	if mode, err := utils.ParseRoundingMode(settings.CoverageQuotaRoundingMode); err == nil {
+		b.coverageQuotaRoundingMode = mode
+	} else {
+		b.logger().Warn("Invalid coverage quota rounding mode, truncating quotas", "error", err)
+	}
+	b.uncoveredLinesClassLimit = settings.UncoveredLinesClassLimit
+	b.syntaxHighlight = settings.SyntaxHighlight
+	b.maximumLineLength = settings.MaximumLineLength
+	b.minifiedAverageLineLength = settings.MinifiedAverageLineLength
+	b.incremental = settings.Incremental
+	b.maximumHistoricCoveragesPerClass = settings.MaximumHistoricCoveragesPerClass
+	if summarySort, err := ParseSummarySort(settings.SummarySort); err == nil {
+		b.summarySort = summarySort
+	} else {
+		b.logger().Warn("Invalid summary sort, keeping the order of the report", "error", err)
+	}
+	b.summaryTopN = settings.SummaryTopN
+	switch mode := strings.ToLower(reportConfig.ReportTypeParameter(b.ReportType(), "classdetails")); mode {
+	case "", classDetailsModePages:
+		b.classDetailsOnDemand = false
+	case classDetailsModeOnDemand:
+		b.classDetailsOnDemand = true
+	default:
+		b.logger().Warn("Unknown classdetails parameter of the Html report, rendering class pages", "value", mode)
+	}
+	language, ok := ResolveLanguage(settings.Language)
+	if !ok {
+		if settings.Language != "" {
+			b.logger().Debug("No translations for language, using English", "language", settings.Language)
+		}
+		language = DefaultLanguage
+	}
+	translations, err := LoadTranslations(language, settings.TranslationsFile, b.logger())
+	if err != nil {
+		return err
+	}
+	b.translations = translations
+	b.appVersion = b.ReportContext.AppVersion()
+	b.commandLine = b.ReportContext.CommandLine()
+	b.generatedAt = b.ReportContext.Now()
+	return nil
 }
 
 func (b *HtmlReportBuilder) renderSummaryPage(data SummaryPageData) error {
-	outputIndexPath := filepath.Join(b.OutputDir, "index.html")
-	summaryFile, err := os.Create(outputIndexPath)
-	if err != nil {
-		return fmt.Errorf("failed to create index.html: %w", err)
+	var page bytes.Buffer
+	if err := summaryPageTpl.Execute(&page, data); err != nil {
+		return err
 	}
-	defer summaryFile.Close()
-	return summaryPageTpl.Execute(summaryFile, data)
+	return b.writeOutputFile("index.html", page.Bytes())
 }
 
 func (b *HtmlReportBuilder) renderClassDetailPages(report *model.SummaryResult) error { // Removed angularAssembliesForSummary
@@ -152,68 +383,182 @@ func (b *HtmlReportBuilder) renderClassDetailPages(report *model.SummaryResult)
 		return nil
 	}
 
+	step := progress.Step{Action: "Rendered", Unit: "class pages"}
+	if b.classDetailsOnDemand {
+		step.Unit = "class detail files"
+	}
 	for _, assemblyModel := range report.Assemblies {
-		for _, classModel := range assemblyModel.Classes {
-			classKey := assemblyModel.Name + "_" + classModel.Name
+		step.Total += len(assemblyModel.Classes)
+	}
+	pageProgress := b.ReportContext.Progress().Start(step)
+	defer pageProgress.Done()
 
-			classReportFilename, ok := b.classReportFilenames[classKey]
+	if err := b.startIncremental(); err != nil {
+		return err
+	}
 
+	for _, assemblyModel := range report.Assemblies {
+		for _, classModel := range assemblyModel.Classes {
+			classReportFilename, ok := b.classReportFilenames[classReportKey{assembly: assemblyModel.Name, class: classModel.Name}]
 			if !ok || classReportFilename == "" {
-				b.ReportContext.Logger().Error(
+				b.logger().Error(
 					"Class report filename not found, skipping detail page generation",
 					"class", classModel.DisplayName,
 					"assembly", assemblyModel.Name,
 				)
+				pageProgress.Increment()
 				continue
 			}
 
-			err := b.generateClassDetailHTML(&classModel, classReportFilename, b.tag)
+			var err error
+			if b.classDetailsOnDemand {
+				err = b.renderClassDetailData(&classModel, classReportFilename, b.tag)
+			} else {
+				err = b.generateClassDetailHTML(&classModel, classReportFilename, b.tag)
+			}
 			if err != nil {
-				b.ReportContext.Logger().Error(
+				b.forgetClassPage(classReportFilename)
+				b.logger().Error(
 					"Failed to generate detail page for class",
 					"class", classModel.DisplayName,
 					"file", classReportFilename,
 					"error", err,
 				)
 			}
+			pageProgress.Increment()
 		}
 	}
-	return nil
+	return b.finishIncremental()
 }
 
-// determineClassReportFilename gets or generates a unique HTML filename for a class report.
-// It uses and updates the builder's internal maps for filename tracking.
-// assemblyName is the full assembly name, className is the model's raw/unique name.
-func (b *HtmlReportBuilder) determineClassReportFilename(assemblyName string, className string, assemblyShortNameForFile string) string {
-	// Create a unique key for the class within its assembly.
-	// Using the full assembly name and raw class name for the key ensures uniqueness.
-	classKey := assemblyName + "_" + className
+// classReportKey identifies a class within its assembly for filename reservation.
+type classReportKey struct {
+	assembly string
+	class    string
+}
+
+// reserveClassReportFilenames reserves a unique detail page filename for every class
+// of the report. Assemblies and classes are processed in sorted order, so the
+// suffixes added on collisions (e.g. "LibUtils2.html") do not depend on the order of
+// the input. Filenames that are already reserved are kept.
+// With on-demand class details the classes are numbered in the same order instead, and
+// the reserved name is the data file loaded by index.html.
+// The pages of the assemblies are reserved after those of the classes. A summary-only
+// report reserves no filenames, so that its classes are not linked.
+func (b *HtmlReportBuilder) reserveClassReportFilenames(report *model.SummaryResult) {
+	if b.onlySummary {
+		return
+	}
+
+	assemblies := make([]*model.Assembly, 0, len(report.Assemblies))
+	for i := range report.Assemblies {
+		assemblies = append(assemblies, &report.Assemblies[i])
+	}
+	sort.SliceStable(assemblies, func(i, j int) bool { return assemblies[i].Name < assemblies[j].Name })
+
+	for _, assembly := range assemblies {
+		classNames := make([]string, 0, len(assembly.Classes))
+		for _, class := range assembly.Classes {
+			classNames = append(classNames, class.Name)
+		}
+		sort.Strings(classNames)
+
+		assemblyShortNameForFile := assembly.Name
+		if lastSlash := strings.LastIndexAny(assembly.Name, "/\\"); lastSlash != -1 {
+			assemblyShortNameForFile = assembly.Name[lastSlash+1:]
+		}
 
-	if filename, ok := b.classReportFilenames[classKey]; ok {
-		return filename // Return already generated filename
+		for _, className := range classNames {
+			key := classReportKey{assembly: assembly.Name, class: className}
+			if _, ok := b.classReportFilenames[key]; ok {
+				continue
+			}
+			if b.classDetailsOnDemand {
+				b.classReportFilenames[key] = fmt.Sprintf("classdetails/classdetail_%d.js", len(b.classReportFilenames)+1)
+				continue
+			}
+			b.classReportFilenames[key] = generateUniqueFilename(assemblyShortNameForFile, className, b.tempExistingLowerFilenames)
+		}
 	}
 
-	// Filename not yet generated for this class. Generate a new one.
-	// generateUniqueFilename expects a map of existing *lowercase* filenames.
-	// b.tempExistingLowerFilenames serves this purpose.
-	// assemblyShortNameForFile is used for constructing the base of the filename.
-	newFilename := generateUniqueFilename(assemblyShortNameForFile, className, b.tempExistingLowerFilenames)
+	// With on-demand class details the report stays a single page.
+	if b.classDetailsOnDemand {
+		return
+	}
+	for _, assembly := range assemblies {
+		if _, ok := b.assemblyReportFilenames[assembly.Name]; !ok {
+			b.assemblyReportFilenames[assembly.Name] = utils.UniqueSanitizedFilename("assembly_"+assembly.Name, ".html", b.tempExistingLowerFilenames)
+		}
+	}
+}
 
-	// Store the actual generated filename (preserving case) in classReportFilenames.
-	b.classReportFilenames[classKey] = newFilename
-	// Also, add its lowercase version to tempExistingLowerFilenames for future uniqueness checks by generateUniqueFilename.
-	// Note: generateUniqueFilename itself adds to the map it's passed, so this is already handled if it modifies its input map.
-	// The current generateUniqueFilename modifies the map passed to it.
+// renderAssemblyPages renders a page per assembly with the summary cards and the class
+// table of the assembly only, which stays responsive for solutions with thousands of
+// classes. The pages reuse the layout of index.html; the sections that describe the
+// whole report, such as risk hotspots and history, are left out.
+func (b *HtmlReportBuilder) renderAssemblyPages(report *model.SummaryResult, summaryData SummaryPageData, angularAssemblies []AngularAssemblyViewModel) error {
+	aggregates := b.ReportContext.Aggregates(report)
+	for i := range report.Assemblies {
+		assembly := &report.Assemblies[i]
+		filename := b.assemblyReportFilenames[assembly.Name]
+		if filename == "" {
+			continue
+		}
 
-	return newFilename
+		// The page of the assembly does not link to itself.
+		pageAssembly := angularAssemblies[i]
+		pageAssembly.ReportPath = ""
+		assemblyJSON, err := json.Marshal([]AngularAssemblyViewModel{pageAssembly})
+		if err != nil {
+			return fmt.Errorf("failed to marshal assembly %q: %w", assembly.Name, err)
+		}
+		data := summaryData
+		data.AssemblyName = assembly.Name
+		data.AssembliesJSON = template.JS(assemblyJSON)
+		data.HasAssemblies = true
+		data.SummaryCards = b.buildSummaryCardsFor(report, report.Assemblies[i:i+1], aggregates.Assemblies[i].Totals)
+		data.RiskHotspotsJSON = template.JS("[]")
+		data.HasRiskHotspots = false
+		data.OverallHistoryChartData = HistoryChartDataViewModel{}
+		data.SkippedReports, data.MissingSourceFiles = nil, nil
+		data.DirectoryTreeRoot, data.DirectoryRows = "", nil
+		data.AssemblyRows = nil
+
+		var page bytes.Buffer
+		if err := summaryPageTpl.Execute(&page, data); err != nil {
+			return fmt.Errorf("failed to render assembly page %s: %w", filename, err)
+		}
+		if err := b.writeOutputFile(filename, page.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// classReportPath returns the link of a class in window.assemblies: the detail page, or
+// with on-demand class details the hash route that makes index.html load the data file.
+func (b *HtmlReportBuilder) classReportPath(classReportFilename string) string {
+	if b.classDetailsOnDemand && classReportFilename != "" {
+		return "#" + classReportFilename
+	}
+	return classReportFilename
 }
 
 func (b *HtmlReportBuilder) renderClassDetailPage(data ClassDetailData, classReportFilename string) error {
-	outputFilePath := filepath.Join(b.OutputDir, classReportFilename)
-	fileWriter, err := os.Create(outputFilePath)
-	if err != nil {
-		return fmt.Errorf("failed to create class report file %s: %w", outputFilePath, err)
+	var page bytes.Buffer
+	if err := classDetailTpl.Execute(&page, data); err != nil {
+		return fmt.Errorf("failed to render class report file %s: %w", classReportFilename, err)
 	}
-	defer fileWriter.Close()
-	return classDetailTpl.Execute(fileWriter, data)
+	return b.writeOutputFile(classReportFilename, page.Bytes())
+}
+
+// calculatePercentage calculates a coverage quota with the configured rounding mode.
+func (b *HtmlReportBuilder) calculatePercentage(value, total, decimalPlaces int) float64 {
+	return utils.CalculatePercentageWithMode(value, total, decimalPlaces, b.coverageQuotaRoundingMode)
+}
+
+// formatPercentage formats a coverage quota with the configured rounding mode. Percentage
+// bars keep using math.Round, as they are visual only.
+func (b *HtmlReportBuilder) formatPercentage(percentage float64, decimalPlaces int) string {
+	return utils.FormatPercentageWithMode(percentage, decimalPlaces, b.coverageQuotaRoundingMode)
 }
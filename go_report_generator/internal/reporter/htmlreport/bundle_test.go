@@ -0,0 +1,75 @@
+package htmlreport
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/assets"
+)
+
+// TestAngularBundle_ContainsSourceChanges guards against changes of the Angular sources
+// that never reach the embedded bundle: the report only ships angular_frontend_spa/dist,
+// so each feature of the sources has to show up in the compiled main.js.
+func TestAngularBundle_ContainsSourceChanges(t *testing.T) {
+	dist, err := assets.AngularDist()
+	if err != nil {
+		t.Fatalf("failed to open the embedded Angular dist: %v", err)
+	}
+	mainJS, err := fs.ReadFile(dist, "main.js")
+	if err != nil {
+		t.Fatalf("failed to read main.js: %v", err)
+	}
+	bundle := string(mainJS)
+
+	tests := []struct {
+		feature  string
+		snippets []string
+	}{
+		{"summary sort and class limit", []string{
+			"this.window.summarySort",
+			"insertMoreClasses(n){",
+			".more&&c.insertMoreClasses(",
+			`[1,"more-classes"]`,
+			`this.translations.andMoreClasses.replace("%d"`,
+		}},
+		{"links to the assembly pages", []string{
+			`c.reportPath=i[l].rp?i[l].rp+this.queryString:""`,
+			`g("href",t.element.reportPath,ni)`,
+		}},
+		{"coverage age of the classes", []string{
+			`this.coverageAge=n.ca||""`,
+			`O(t.clazz.coverageAge)`,
+		}},
+		{"external badge of the classes", []string{
+			`this.external=!0===n.ex`,
+			`filter(i=>i.external)`,
+			`O(t.translations.External)`,
+		}},
+		{"uncovered line ranges of the classes", []string{
+			`this.uncoveredLineRanges=n.ulr||""`,
+			`O(t.translations.UncoveredLines+": "+t.clazz.uncoveredLineRanges)`,
+		}},
+		{"header cells of the tables", []string{
+			`["scope","col",1,"right"]`,
+			`(24,"th",33)`,
+		}},
+		{"risk hotspots without class pages", []string{
+			`L(4,JUa,2,2,"a",34)(5,JUb,2,1,"ng-container",0)`,
+			`g("ngIf",""===t.reportPath)`,
+		}},
+		{"band of the older history entries", []string{
+			`this.historicCoverageBand=n.hcb||null`,
+			`set coverageBand(t){`,
+			`("coverageBand",t.clazz.lineCoverageBand)`,
+			`this.translations.olderRuns.replace("%d"`,
+		}},
+	}
+	for _, tt := range tests {
+		for _, snippet := range tt.snippets {
+			if !strings.Contains(bundle, snippet) {
+				t.Errorf("%s: main.js does not contain %q, rebuild angular_frontend_spa/dist", tt.feature, snippet)
+			}
+		}
+	}
+}
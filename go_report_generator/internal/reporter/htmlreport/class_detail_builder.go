@@ -1,22 +1,26 @@
 package htmlreport
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"math"
 	"os"
-	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
-	"time"
+	"unicode/utf8"
 
-	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/htmlreport/highlight"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
 func (b *HtmlReportBuilder) generateClassDetailHTML(classModel *model.Class, classReportFilename string, tag string) error {
+	b.sourceLines = make(map[string][]string, len(classModel.Files))
+	defer func() { b.sourceLines = nil }()
+
 	// 1. Build the main ClassViewModelForDetail (server-side rendering focus)
 	classVM := b.buildClassViewModelForDetailServer(classModel, tag)
 
@@ -27,8 +31,16 @@ func (b *HtmlReportBuilder) generateClassDetailHTML(classModel *model.Class, cla
 	}
 	classDetailJSONBytes, err := json.Marshal(angularClassDetailForJS)
 	if err != nil {
+		b.logger().Error("Failed to marshal class detail data", "class", classModel.DisplayName, "error", err)
 		return fmt.Errorf("failed to marshal Angular class detail JSON for %s: %w", classModel.DisplayName, err)
 	}
+	testCoverageJSON, err := classTestCoverage(&classVM)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the coverage by test of %s: %w", classModel.DisplayName, err)
+	}
+	if b.classPageUnchanged(classModel.Name, classReportFilename, classDetailJSONBytes, testCoverageJSON) {
+		return nil
+	}
 
 	// 3. Prepare overall data for the template
 	templateData := b.buildClassDetailPageData(classVM, tag, template.JS(classDetailJSONBytes))
@@ -38,6 +50,84 @@ func (b *HtmlReportBuilder) generateClassDetailHTML(classModel *model.Class, cla
 
 }
 
+// renderClassDetailData writes the class details as a script that hands them to the
+// class view of index.html (Html{classdetails=ondemand}). A script is used instead of
+// plain JSON because browsers block fetching files from file:// pages, while script
+// tags keep working there. The file only contains the class itself, not the assets and
+// window.* data every detail page repeats. Its HTML is rendered by the template of the
+// class pages, so both show the same details.
+func (b *HtmlReportBuilder) renderClassDetailData(classModel *model.Class, classDetailFilename string, tag string) error {
+	b.sourceLines = make(map[string][]string, len(classModel.Files))
+	defer func() { b.sourceLines = nil }()
+
+	classVM := b.buildClassViewModelForDetailServer(classModel, tag)
+	angularClassDetail, err := b.buildAngularClassDetailForJS(classModel, &classVM)
+	if err != nil {
+		return fmt.Errorf("failed to build Angular class detail JSON for %s: %w", classModel.DisplayName, err)
+	}
+	classDetail := ClassDetailDataViewModel{Class: angularClassDetail.Class}
+	classDetail.Class.ReportPath = b.classReportPath(classDetailFilename)
+
+	var content strings.Builder
+	if err := classDetailTpl.ExecuteTemplate(&content, "classDetailContent", b.buildClassDetailPageData(classVM, tag, "")); err != nil {
+		return fmt.Errorf("failed to render class details of %s: %w", classModel.DisplayName, err)
+	}
+	classDetail.HTML = compactHTML(content.String())
+
+	// The file is loaded as a script of its own, so the HTML needs no escaping for <script>
+	var classDetailJSON bytes.Buffer
+	encoder := json.NewEncoder(&classDetailJSON)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(classDetail); err != nil {
+		return fmt.Errorf("failed to marshal class detail JSON for %s: %w", classModel.DisplayName, err)
+	}
+	classDetailJSONBytes := bytes.TrimSuffix(classDetailJSON.Bytes(), []byte("\n"))
+	if b.classPageUnchanged(classModel.Name, classDetailFilename, classDetailJSONBytes) {
+		return nil
+	}
+	content.Reset()
+	content.WriteString("window.loadClassDetail(")
+	content.Write(classDetailJSONBytes)
+	content.WriteString(");\n")
+	return b.writeOutputFile(classDetailFilename, []byte(content.String()))
+}
+
+// compactHTML removes the indentation and the empty lines of the template output, which
+// take about half the size of the class details. Source code is not affected since its
+// whitespace is written as &nbsp;, see sanitizeSourceLine.
+func compactHTML(content string) string {
+	var compact strings.Builder
+	compact.Grow(len(content) / 2)
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimLeft(line, " \t"); line != "" {
+			compact.WriteString(line)
+			compact.WriteByte('\n')
+		}
+	}
+	return compact.String()
+}
+
+// readSourceLines returns the lines of a source file: from the coverage data if it holds
+// the whole file (see model.CodeFile.SourceLines), otherwise from disk. While a class is
+// rendered they are kept in b.sourceLines, so the page and its window.classDetails data
+// read the file once.
+func (b *HtmlReportBuilder) readSourceLines(file *model.CodeFile) ([]string, error) {
+	if lines, ok := b.sourceLines[file.Path]; ok {
+		return lines, nil
+	}
+	lines, ok := file.SourceLines()
+	if !ok {
+		var err error
+		if lines, err = b.fileReader.ReadFile(file.Path); err != nil {
+			return nil, err
+		}
+	}
+	if b.sourceLines != nil {
+		b.sourceLines[file.Path] = lines
+	}
+	return lines, nil
+}
+
 func (b *HtmlReportBuilder) buildClassViewModelForDetailServer(classModel *model.Class, tag string) ClassViewModelForDetail {
 	cvm := ClassViewModelForDetail{
 		Name:         classModel.DisplayName,
@@ -61,15 +151,22 @@ func (b *HtmlReportBuilder) buildClassViewModelForDetailServer(classModel *model
 
 	var allMethodMetricsForClass []*model.MethodMetric
 
+	cvm.TestMethods = buildTestMethodViewModels(classModel)
+	cvm.ApproximateBranchCoverage = classModel.HasApproximateBranchCoverage()
+	testIDs := make(map[string]string, len(cvm.TestMethods))
+	for _, tm := range cvm.TestMethods {
+		testIDs[tm.Name] = tm.ID
+	}
+
 	sortedFiles := make([]model.CodeFile, len(classModel.Files))
 	copy(sortedFiles, classModel.Files)
 	sort.Slice(sortedFiles, func(i, j int) bool {
 		return sortedFiles[i].Path < sortedFiles[j].Path
 	})
 
-	for fileIdx, fileInClassValue := range sortedFiles {
+	for _, fileInClassValue := range sortedFiles {
 		fileInClass := fileInClassValue
-		fileVM, _, err := b.buildFileViewModelForServerRender(&fileInClass)
+		fileVM, _, err := b.buildFileViewModelForServerRender(&fileInClass, testIDs)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: could not build file view model for %s: %v\n", fileInClass.Path, err)
 			continue
@@ -80,10 +177,8 @@ func (b *HtmlReportBuilder) buildClassViewModelForDetailServer(classModel *model
 			allMethodMetricsForClass = append(allMethodMetricsForClass, &fileInClass.MethodMetrics[i])
 		}
 
-		for i := range fileInClass.CodeElements {
-			codeElem := &fileInClass.CodeElements[i]
-			sidebarElem := b.buildSidebarElementViewModel(codeElem, fileVM.ShortPath, fileIdx+1, len(sortedFiles) > 1)
-			cvm.SidebarElements = append(cvm.SidebarElements, sidebarElem)
+		if sidebarFile := b.buildSidebarFileViewModel(&fileInClass, fileVM.ShortPath); len(sidebarFile.Elements) > 0 {
+			cvm.SidebarFiles = append(cvm.SidebarFiles, sidebarFile)
 		}
 	}
 
@@ -96,8 +191,9 @@ func (b *HtmlReportBuilder) buildClassViewModelForDetailServer(classModel *model
 }
 
 func (b *HtmlReportBuilder) populateLineCoverageMetricsForClassVM(cvm *ClassViewModelForDetail, classModel *model.Class) {
-	lineCoverage := utils.CalculatePercentage(cvm.CoveredLines, cvm.CoverableLines, b.maximumDecimalPlacesForCoverageQuotas)
-	cvm.CoveragePercentageForDisplay = utils.FormatPercentage(lineCoverage, b.maximumDecimalPlacesForPercentageDisplay)
+	lineCoverage := b.calculatePercentage(cvm.CoveredLines, cvm.CoverableLines, b.maximumDecimalPlacesForCoverageQuotas)
+	cvm.CoveragePercentageForDisplay = b.formatPercentage(lineCoverage, b.maximumDecimalPlacesForPercentageDisplay)
+	cvm.CoverageQuota = progressBarValueNow(lineCoverage)
 
 	if !math.IsNaN(lineCoverage) {
 
@@ -113,8 +209,9 @@ func (b *HtmlReportBuilder) populateBranchCoverageMetricsForClassVM(cvm *ClassVi
 	if b.branchCoverageAvailable && classModel.BranchesValid != nil && *classModel.BranchesValid > 0 && classModel.BranchesCovered != nil {
 		cvm.CoveredBranches = *classModel.BranchesCovered
 		cvm.TotalBranches = *classModel.BranchesValid
-		branchCoverage := utils.CalculatePercentage(*classModel.BranchesCovered, *classModel.BranchesValid, b.maximumDecimalPlacesForCoverageQuotas)
-		cvm.BranchCoveragePercentageForDisplay = utils.FormatPercentage(branchCoverage, b.maximumDecimalPlacesForPercentageDisplay)
+		branchCoverage := b.calculatePercentage(*classModel.BranchesCovered, *classModel.BranchesValid, b.maximumDecimalPlacesForCoverageQuotas)
+		cvm.BranchCoveragePercentageForDisplay = b.formatPercentage(branchCoverage, b.maximumDecimalPlacesForPercentageDisplay)
+		cvm.BranchCoverageQuota = progressBarValueNow(branchCoverage)
 
 		if !math.IsNaN(branchCoverage) {
 
@@ -138,12 +235,13 @@ func (b *HtmlReportBuilder) populateMethodCoverageMetricsForClassVM(cvm *ClassVi
 
 	if cvm.TotalMethods > 0 {
 		// Calculate with configured precision
-		methodCovVal := utils.CalculatePercentage(cvm.CoveredMethods, cvm.TotalMethods, b.maximumDecimalPlacesForCoverageQuotas)
-		fullMethodCovVal := utils.CalculatePercentage(cvm.FullyCoveredMethods, cvm.TotalMethods, b.maximumDecimalPlacesForCoverageQuotas)
+		methodCovVal := b.calculatePercentage(cvm.CoveredMethods, cvm.TotalMethods, b.maximumDecimalPlacesForCoverageQuotas)
+		fullMethodCovVal := b.calculatePercentage(cvm.FullyCoveredMethods, cvm.TotalMethods, b.maximumDecimalPlacesForCoverageQuotas)
 
 		// Format for display with 0 decimal places
-		cvm.MethodCoveragePercentageForDisplay = utils.FormatPercentage(methodCovVal, b.maximumDecimalPlacesForPercentageDisplay)
-		cvm.FullMethodCoveragePercentageForDisplay = utils.FormatPercentage(fullMethodCovVal, b.maximumDecimalPlacesForPercentageDisplay)
+		cvm.MethodCoveragePercentageForDisplay = b.formatPercentage(methodCovVal, b.maximumDecimalPlacesForPercentageDisplay)
+		cvm.FullMethodCoveragePercentageForDisplay = b.formatPercentage(fullMethodCovVal, b.maximumDecimalPlacesForPercentageDisplay)
+		cvm.MethodCoverageQuota = progressBarValueNow(methodCovVal)
 
 		cvm.MethodCoveragePercentageBarValue = 100 - int(math.Round(methodCovVal)) // Bar value should use the calculated value
 		cvm.MethodCoverageRatioTextForDisplay = fmt.Sprintf("%d of %d", cvm.CoveredMethods, cvm.TotalMethods)
@@ -161,7 +259,9 @@ func (b *HtmlReportBuilder) populateHistoricCoveragesForClassVM(cvm *ClassViewMo
 	if classModel.HistoricCoverages == nil {
 		return
 	}
-	for _, hist := range classModel.HistoricCoverages {
+	historicCoverages, band := b.limitHistoricCoverages(classModel.HistoricCoverages)
+	cvm.HistoricCoverageBand = band
+	for _, hist := range historicCoverages {
 		angularHist := b.buildAngularHistoricCoverageViewModel(&hist)
 		cvm.HistoricCoverages = append(cvm.HistoricCoverages, angularHist)
 		if angularHist.LineCoverageQuota >= 0 {
@@ -174,44 +274,149 @@ func (b *HtmlReportBuilder) populateHistoricCoveragesForClassVM(cvm *ClassViewMo
 }
 
 func (b *HtmlReportBuilder) populateAggregatedMetricsForClassVM(cvm *ClassViewModelForDetail, classModel *model.Class) {
-	cvm.Metrics = make(map[string]float64)
-	for name, val := range classModel.Metrics {
-		cvm.Metrics[name] = val
+	cvm.Metrics = finiteMetrics(classModel.Metrics)
+	cvm.ComplexityForDisplay = "-"
+	if complexity, ok := cvm.Metrics["Cyclomatic complexity"]; ok {
+		cvm.ComplexityForDisplay = b.formatMetricValue(model.Metric{Name: "Cyclomatic complexity", Value: complexity})
 	}
 }
 
-func (b *HtmlReportBuilder) buildFileViewModelForServerRender(fileInClass *model.CodeFile) (FileViewModelForDetail, []string, error) {
+// buildTestMethodViewModels returns the union of the tests that hit any line of the class,
+// sorted by name. The IDs key the per-test entries of the lines' data-coverage attribute.
+func buildTestMethodViewModels(classModel *model.Class) []TestMethodViewModel {
+	names := make(map[string]struct{})
+	for _, file := range classModel.Files {
+		for _, line := range file.Lines {
+			for name := range line.LineCoverageByTestMethod {
+				names[name] = struct{}{}
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	testMethods := make([]TestMethodViewModel, len(sortedNames))
+	for i, name := range sortedNames {
+		testMethods[i] = TestMethodViewModel{ID: fmt.Sprintf("M%d", i), Name: name, ShortName: testShortName(name)}
+	}
+	return testMethods
+}
+
+// testShortName strips the namespace and class from a test name, e.g.
+// "MyTests.CalcTests.Add(1, 2)" becomes "Add(...)".
+func testShortName(name string) string {
+	short := utils.GetShortMethodName(name)
+	end := strings.Index(short, "(")
+	if end < 0 {
+		end = len(short)
+	}
+	if dot := strings.LastIndex(short[:end], "."); dot >= 0 && dot < len(short)-1 {
+		return short[dot+1:]
+	}
+	return short
+}
+
+// buildFileViewModelForServerRender builds the source view of a file. testIDs maps test
+// names to the IDs used in the data-coverage attribute and may be nil.
+func (b *HtmlReportBuilder) buildFileViewModelForServerRender(fileInClass *model.CodeFile, testIDs map[string]string) (FileViewModelForDetail, []string, error) {
 	fileVM := FileViewModelForDetail{
 		Path:      fileInClass.Path,
-		ShortPath: utils.ReplaceInvalidPathChars(filepath.Base(fileInClass.Path)),
+		ShortPath: fileAnchorID(fileInClass.Path),
+		External:  b.markExternalFiles && !fileInClass.InSourceDirs,
 	}
-	sourceLines, err := filereader.ReadLinesInFile(fileInClass.Path)
+	sourceLines, err := b.readSourceLines(fileInClass)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: could not read source file %s: %v\n", fileInClass.Path, err)
 		sourceLines = []string{}
 	}
 
-	coverageLinesMap := make(map[int]*model.Line)
+	coverageLinesMap := make(map[int]*model.Line, len(fileInClass.Lines))
 	for i := range fileInClass.Lines {
 		covLine := &fileInClass.Lines[i]
 		coverageLinesMap[covLine.Number] = covLine
 	}
 
+	// Minified files are rendered as line numbers and visits only; their code would make
+	// the page too large to be opened.
+	fileVM.Minified = b.isMinified(sourceLines)
+
+	// Files of languages without a lexer are rendered plain, as without -syntaxhighlight.
+	var highlightedLines [][]highlight.Token
+	if lexer := highlight.ForFile(fileInClass.Path); b.syntaxHighlight && lexer != nil && !fileVM.Minified {
+		highlightedLines = lexer.Tokenize(sourceLines)
+	}
+
+	fileVM.Lines = make([]LineViewModelForDetail, 0, len(sourceLines))
 	for lineNumIdx, lineContent := range sourceLines {
 		actualLineNumber := lineNumIdx + 1
 		modelCovLine, hasCoverageData := coverageLinesMap[actualLineNumber]
-		lineVM := b.buildLineViewModelForServerRender(lineContent, actualLineNumber, modelCovLine, hasCoverageData)
+		content, length := "", 0
+		if !fileVM.Minified {
+			content, length = b.truncateSourceLine(lineContent)
+		}
+		lineVM := b.buildLineViewModelForServerRender(content, actualLineNumber, modelCovLine, hasCoverageData, fileInClass.ApproximateBranchCoverage, testIDs)
+		if length > 0 {
+			// Truncated lines are rendered plain; the tokens cover the whole line.
+			lineVM.TruncatedTitle = fmt.Sprintf(b.translations["LineTruncated"], b.maximumLineLength, length)
+		} else if highlightedLines != nil {
+			lineVM.HighlightedContent = highlightSourceLine(highlightedLines[lineNumIdx])
+		}
+		if lineVM.LineVisitStatus == "red" || lineVM.LineVisitStatus == "orange" {
+			fileVM.UncoveredLineCount++
+		}
 		fileVM.Lines = append(fileVM.Lines, lineVM)
 	}
 	return fileVM, sourceLines, nil
 }
 
-func (b *HtmlReportBuilder) buildLineViewModelForServerRender(lineContent string, actualLineNumber int, modelCovLine *model.Line, hasCoverageData bool) LineViewModelForDetail {
+// isMinified reports whether the lines of a file are too long on average to be shown, as
+// in minified JavaScript or CSS, see Settings.MinifiedAverageLineLength. It is false when
+// the line length is not limited.
+func (b *HtmlReportBuilder) isMinified(lines []string) bool {
+	if b.maximumLineLength <= 0 || b.minifiedAverageLineLength <= 0 || len(lines) == 0 {
+		return false
+	}
+	characters := 0
+	for _, line := range lines {
+		characters += utf8.RuneCountInString(line)
+	}
+	return characters/len(lines) > b.minifiedAverageLineLength
+}
+
+// truncateSourceLine shortens a line to the maximum line length. It returns the line to
+// show and, if it was shortened, the number of characters of the whole line.
+func (b *HtmlReportBuilder) truncateSourceLine(line string) (string, int) {
+	// A line has at most as many characters as bytes.
+	if b.maximumLineLength <= 0 || len(line) <= b.maximumLineLength {
+		return line, 0
+	}
+	length := utf8.RuneCountInString(line)
+	if length <= b.maximumLineLength {
+		return line, 0
+	}
+	characters := 0
+	for i := range line {
+		if characters == b.maximumLineLength {
+			return line[:i], length
+		}
+		characters++
+	}
+	return line, 0
+}
+
+func (b *HtmlReportBuilder) buildLineViewModelForServerRender(lineContent string, actualLineNumber int, modelCovLine *model.Line, hasCoverageData, approximateBranches bool, testIDs map[string]string) LineViewModelForDetail {
 	lineVM := LineViewModelForDetail{LineNumber: actualLineNumber, LineContent: lineContent}
-	dataCoverageMap := map[string]map[string]string{"AllTestMethods": {"VC": "", "LVS": "gray"}}
+	var testEntries map[string]map[string]string
 
 	if hasCoverageData {
-		lineVM.Hits = fmt.Sprintf("%d", modelCovLine.Hits)
+		lineVM.Hits = strconv.Itoa(modelCovLine.Hits)
 		status := determineLineVisitStatus(modelCovLine.Hits, modelCovLine.IsBranchPoint, modelCovLine.CoveredBranches, modelCovLine.TotalBranches)
 		lineVM.LineVisitStatus = lineVisitStatusToString(status)
 		if modelCovLine.IsBranchPoint && modelCovLine.TotalBranches > 0 {
@@ -219,50 +424,125 @@ func (b *HtmlReportBuilder) buildLineViewModelForServerRender(lineContent string
 			branchCoverageVal := (float64(modelCovLine.CoveredBranches) / float64(modelCovLine.TotalBranches)) * 100.0
 			lineVM.BranchBarValue = 100 - int(math.Round(branchCoverageVal))
 		}
-		dataCoverageMap["AllTestMethods"]["VC"] = fmt.Sprintf("%d", modelCovLine.Hits)
-		dataCoverageMap["AllTestMethods"]["LVS"] = lineVM.LineVisitStatus
+		if modelCovLine.Hits >= 0 {
+			// Per-test entries drive the test selector in custom.js; coverable lines
+			// without an entry are shown as not covered by the selected test.
+			for name, hits := range modelCovLine.LineCoverageByTestMethod {
+				id, ok := testIDs[name]
+				if !ok || hits <= 0 {
+					continue
+				}
+				if testEntries == nil {
+					testEntries = make(map[string]map[string]string)
+				}
+				testEntries[id] = map[string]string{"VC": fmt.Sprintf("%d", hits), "LVS": lineVisitStatusToString(model.Covered)}
+			}
+		}
 		tooltipBranchRate := ""
 		if lineVM.IsBranch {
 			tooltipBranchRate = fmt.Sprintf(", %d of %d branches are covered", modelCovLine.CoveredBranches, modelCovLine.TotalBranches)
+			lineVM.BranchLabel = fmt.Sprintf(b.translations["BranchesCovered"], modelCovLine.CoveredBranches, modelCovLine.TotalBranches)
+			if approximateBranches {
+				tooltipBranchRate += " (approximate)"
+				lineVM.BranchLabel += " (" + b.translations["Approximate"] + ")"
+			}
+			if len(modelCovLine.Branch) > 0 {
+				tooltipBranchRate += "; " + branchDetailsText(modelCovLine.Branch)
+			}
 		}
+		visits := fmt.Sprintf(b.translations["Visits"], modelCovLine.Hits)
 		switch status {
-		case lineVisitStatusCovered:
+		case model.Covered:
 			lineVM.Tooltip = fmt.Sprintf("Covered (%d visits%s)", modelCovLine.Hits, tooltipBranchRate)
-		case lineVisitStatusNotCovered:
+			lineVM.StatusText = b.translations["Covered"] + ", " + visits
+		case model.NotCovered:
 			lineVM.Tooltip = fmt.Sprintf("Not covered (%d visits%s)", modelCovLine.Hits, tooltipBranchRate)
-		case lineVisitStatusPartiallyCovered:
+			lineVM.StatusText = b.translations["NotCovered"] + ", " + visits
+		case model.PartiallyCovered:
 			lineVM.Tooltip = fmt.Sprintf("Partially covered (%d visits%s)", modelCovLine.Hits, tooltipBranchRate)
+			lineVM.StatusText = b.translations["PartiallyCovered"] + ", " + visits
 		default:
 			lineVM.Tooltip = "Not coverable"
 		}
+		if lineVM.StatusText != "" && lineVM.IsBranch {
+			lineVM.StatusText += ", " + lineVM.BranchLabel
+		}
 	} else {
-		lineVM.LineVisitStatus = lineVisitStatusToString(lineVisitStatusNotCoverable)
+		lineVM.LineVisitStatus = lineVisitStatusToString(model.NotCoverable)
 		lineVM.Hits = ""
 		lineVM.Tooltip = "Not coverable"
 	}
-	dataCoverageBytes, _ := json.Marshal(dataCoverageMap)
-	lineVM.DataCoverage = template.JS(dataCoverageBytes)
+	if len(testIDs) == 0 {
+		// Without per-test data there is no test selector, so the lines need no
+		// data-coverage for custom.js.
+		return lineVM
+	}
+	if len(testEntries) == 0 {
+		// Lines without per-test entries, by far the most common, skip json.Marshal; the
+		// status and the visits need no escaping.
+		lineVM.DataCoverage = `{"AllTestMethods":{"LVS":"` + lineVM.LineVisitStatus + `","VC":"` + lineVM.Hits + `"}}`
+		return lineVM
+	}
+	testEntries["AllTestMethods"] = map[string]string{"VC": lineVM.Hits, "LVS": lineVM.LineVisitStatus}
+	dataCoverageBytes, _ := json.Marshal(testEntries)
+	lineVM.DataCoverage = string(dataCoverageBytes)
 	return lineVM
+}
 
+// branchDetailsText lists the visits of each branch of a line for its tooltip, e.g.
+// "branch 0: 5 visits, branch 1 (jump): not taken".
+func branchDetailsText(branches []model.BranchCoverageDetail) string {
+	details := make([]string, len(branches))
+	for i, branch := range branches {
+		name := "branch " + strconv.Itoa(i)
+		if branch.Type != "" {
+			name += " (" + branch.Type + ")"
+		}
+		if branch.Visits > 0 {
+			details[i] = fmt.Sprintf("%s: %d visits", name, branch.Visits)
+		} else {
+			details[i] = name + ": not taken"
+		}
+	}
+	return strings.Join(details, ", ")
 }
 
-func (b *HtmlReportBuilder) buildSidebarElementViewModel(codeElem *model.CodeElement, fileShortPath string, fileIndexPlus1 int, isMultiFile bool) SidebarElementViewModel {
+// buildSidebarFileViewModel builds the sidebar group of a file, with its code elements
+// sorted by line number.
+func (b *HtmlReportBuilder) buildSidebarFileViewModel(file *model.CodeFile, fileShortPath string) SidebarFileViewModel {
+	sidebarFile := SidebarFileViewModel{
+		Path:      file.Path,
+		ShortPath: fileShortPath,
+	}
+
+	coverage := b.calculatePercentage(file.CoveredLines, file.CoverableLines, b.maximumDecimalPlacesForCoverageQuotas)
+	sidebarFile.CoverageBarValue = getCoverageBarValue(coverage)
+	sidebarFile.CoverageTitle = fmt.Sprintf("Line coverage: %s - %s", b.formatPercentage(coverage, b.maximumDecimalPlacesForCoverageQuotas), file.Path)
+
+	for i := range file.CodeElements {
+		sidebarFile.Elements = append(sidebarFile.Elements, b.buildSidebarElementViewModel(&file.CodeElements[i], fileShortPath))
+	}
+	sort.SliceStable(sidebarFile.Elements, func(i, j int) bool {
+		return sidebarFile.Elements[i].Line < sidebarFile.Elements[j].Line
+	})
+	return sidebarFile
+}
+
+func (b *HtmlReportBuilder) buildSidebarElementViewModel(codeElem *model.CodeElement, fileShortPath string) SidebarElementViewModel {
 	sidebarElem := SidebarElementViewModel{
 		Name:          codeElem.Name,
 		FullName:      codeElem.FullName,
 		FileShortPath: fileShortPath,
 		Line:          codeElem.FirstLine,
+		Range:         fmt.Sprintf("%d-%d", codeElem.FirstLine, max(codeElem.FirstLine, codeElem.LastLine)),
 		Icon:          "cube",
 	}
-	if isMultiFile {
-		sidebarElem.FileIndexPlus1 = fileIndexPlus1
-	}
 	if codeElem.Type == model.PropertyElementType {
 		sidebarElem.Icon = "wrench"
 	}
 
 	var coverageTitleText string
-	if codeElem.CoverageQuota != nil {
+	if finiteFloatPtr(codeElem.CoverageQuota) != nil {
 		sidebarElem.CoverageBarValue = getCoverageBarValue(*codeElem.CoverageQuota)
 		coverageTitleText = fmt.Sprintf("Line coverage: %.1f%%", *codeElem.CoverageQuota)
 	} else {
@@ -273,27 +553,56 @@ func (b *HtmlReportBuilder) buildSidebarElementViewModel(codeElem *model.CodeEle
 	return sidebarElem
 }
 
-func (b *HtmlReportBuilder) getStandardMetricHeaders() []AngularMetricDefinitionViewModel {
-	standardMetricKeys := []string{
-		"Branch coverage",
-		"CrapScore",
-		"Cyclomatic complexity",
-		"Line coverage",
-	}
-	var headers []AngularMetricDefinitionViewModel
-	for _, key := range standardMetricKeys {
-		translatedName := b.translations[key]
-		if translatedName == "" {
-			translatedName = key
+// getMetricHeadersForClass returns one header per metric that at least one method of the
+// class actually provides, sorted by metric name. Line coverage is always available, and
+// so is the cyclomatic complexity if the report declares it for the class: its methods
+// without one show "-" instead of leaving the column out.
+func (b *HtmlReportBuilder) getMetricHeadersForClass(classModel *model.Class) []AngularMetricDefinitionViewModel {
+	metricKeys := map[string]struct{}{"Line coverage": {}}
+	if classModel.Complexity != nil {
+		metricKeys["Cyclomatic complexity"] = struct{}{}
+	}
+	for _, method := range classModel.Methods {
+		if method.BranchRate != nil {
+			metricKeys["Branch coverage"] = struct{}{}
 		}
+		for _, mm := range method.MethodMetrics {
+			for _, m := range mm.Metrics {
+				metricKeys[m.Name] = struct{}{}
+			}
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(metricKeys))
+	for key := range metricKeys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	b.metrics().SortNames(sortedKeys)
+
+	headers := make([]AngularMetricDefinitionViewModel, 0, len(sortedKeys))
+	for _, key := range sortedKeys {
 		headers = append(headers, AngularMetricDefinitionViewModel{
-			Name:           translatedName,
-			ExplanationURL: b.getMetricExplanationURL(key),
+			Name:           b.metricDisplayName(key),
+			ExplanationURL: b.metrics().Lookup(key).ExplanationURL,
+			Key:            key,
 		})
 	}
 	return headers
 }
 
+// metrics returns the metric definitions of the settings.
+func (b *HtmlReportBuilder) metrics() model.MetricRegistry {
+	return b.ReportContext.Settings().Metrics
+}
+
+// metricDisplayName returns the translated name of a metric, or the name itself.
+func (b *HtmlReportBuilder) metricDisplayName(name string) string {
+	if translatedName := b.translations[name]; translatedName != "" {
+		return translatedName
+	}
+	return name
+}
+
 func (b *HtmlReportBuilder) buildSingleMetricRow(
 	method *model.Method,
 	correspondingCE *model.CodeElement,
@@ -310,7 +619,7 @@ func (b *HtmlReportBuilder) buildSingleMetricRow(
 	if correspondingCE != nil {
 		lineToLink = correspondingCE.FirstLine
 		isProperty = (correspondingCE.Type == model.PropertyElementType)
-		coverageQuota = correspondingCE.CoverageQuota
+		coverageQuota = finiteFloatPtr(correspondingCE.CoverageQuota)
 	} else {
 		lineToLink = method.FirstLine
 		isProperty = strings.HasPrefix(cleanedFullName, "get_") || strings.HasPrefix(cleanedFullName, "set_")
@@ -330,6 +639,8 @@ func (b *HtmlReportBuilder) buildSingleMetricRow(
 		IsProperty:     isProperty,
 		CoverageQuota:  coverageQuota,
 		MetricValues:   make([]string, len(headers)),
+		MetricStatuses: make([]model.MetricStatus, len(headers)),
+		RiskHotspot:    b.riskHotspotTooltips[method],
 	}
 
 	// Create a map for easy lookup of existing metrics for the method
@@ -342,35 +653,18 @@ func (b *HtmlReportBuilder) buildSingleMetricRow(
 
 	// Manually add Line Coverage and Branch Coverage from the method model
 	// to ensure they are available for formatting.
-	methodMetricsMap["Line coverage"] = model.Metric{Value: method.LineRate * 100.0}
+	methodMetricsMap["Line coverage"] = model.Metric{Name: "Line coverage", Value: method.LineRate * 100.0}
 	if method.BranchRate != nil {
-		methodMetricsMap["Branch coverage"] = model.Metric{Value: *method.BranchRate * 100.0}
+		methodMetricsMap["Branch coverage"] = model.Metric{Name: "Branch coverage", Value: *method.BranchRate * 100.0}
 	}
 	// Note: Complexity and CrapScore are already in method.MethodMetrics, so they'll be in the map.
 
 	for i, headerVM := range headers {
-		var originalMetricKey string
-
-		// This switch maps the display name of the header back to the metric's key.
-		switch headerVM.Name {
-		case b.translations["Branch coverage"]:
-			originalMetricKey = "Branch coverage"
-		case b.translations["CrapScore"]:
-			originalMetricKey = "CrapScore"
-		case b.translations["Cyclomatic complexity"]:
-			originalMetricKey = "Cyclomatic complexity"
-		case b.translations["Line coverage"]:
-			originalMetricKey = "Line coverage"
-		default:
-			originalMetricKey = headerVM.Name
-		}
-
-		if metric, ok := methodMetricsMap[originalMetricKey]; ok {
+		if metric, ok := methodMetricsMap[headerVM.Key]; ok {
 			row.MetricValues[i] = b.formatMetricValue(metric)
+			row.MetricStatuses[i] = metric.Status
 		} else {
-			// If the metric is not in the map (e.g., Branch coverage for a method where BranchRate was nil),
-			// explicitly set it to "N/A".
-			row.MetricValues[i] = "N/A"
+			row.MetricValues[i] = "-"
 		}
 	}
 	return row
@@ -381,7 +675,7 @@ func (b *HtmlReportBuilder) buildSingleMetricRow(
 // primarily by file path, then by line number, then by short method name.
 func (b *HtmlReportBuilder) buildMetricsTableForClassVM(classModel *model.Class) MetricsTableViewModel {
 	metricsTable := MetricsTableViewModel{}
-	metricsTable.Headers = b.getStandardMetricHeaders()
+	metricsTable.Headers = b.getMetricHeadersForClass(classModel)
 
 	if len(classModel.Methods) == 0 && len(classModel.Files) == 0 { // Check if there are any files to iterate
 		return metricsTable
@@ -423,20 +717,11 @@ func (b *HtmlReportBuilder) buildMetricsTableForClassVM(classModel *model.Class)
 			// This is a bit heuristic: a method might span files in partial classes,
 			// but for metrics, we usually associate it with its main definition file.
 			// The `CodeElement` for this method within `file.CodeElements` will confirm.
-			var foundInThisFile bool
-			for ceIdx := range file.CodeElements {
-				ce := &file.CodeElements[ceIdx]
-				if ce.FirstLine == method.FirstLine && ce.FullName == method.DisplayName {
-					foundInThisFile = true
-					break
-				}
-			}
-
-			if foundInThisFile {
+			if model.FindCodeElement(file.CodeElements, method) != nil {
 				allMethodsWithContext = append(allMethodsWithContext, methodWithFileContext{
 					method:         method,
 					filePath:       file.Path, // Full path of the file
-					fileShortPath:  utils.ReplaceInvalidPathChars(filepath.Base(file.Path)),
+					fileShortPath:  fileAnchorID(file.Path),
 					fileIndexPlus1: fileIdx + 1,
 				})
 			}
@@ -457,7 +742,12 @@ func (b *HtmlReportBuilder) buildMetricsTableForClassVM(classModel *model.Class)
 		if itemI.method.FirstLine != itemJ.method.FirstLine {
 			return itemI.method.FirstLine < itemJ.method.FirstLine
 		}
-		return utils.GetShortMethodName(itemI.method.DisplayName) < utils.GetShortMethodName(itemJ.method.DisplayName)
+		shortNameI, shortNameJ := utils.GetShortMethodName(itemI.method.DisplayName), utils.GetShortMethodName(itemJ.method.DisplayName)
+		if shortNameI != shortNameJ {
+			return shortNameI < shortNameJ
+		}
+		// Overloads cleaned to the same name keep a deterministic order.
+		return itemI.method.RawKey() < itemJ.method.RawKey()
 	})
 
 	// Now build the rows from the sorted list
@@ -467,13 +757,7 @@ func (b *HtmlReportBuilder) buildMetricsTableForClassVM(classModel *model.Class)
 		var correspondingCE *model.CodeElement
 		for _, f := range classModel.Files { // Iterate original files to find the CE
 			if f.Path == mCtx.filePath {
-				for ceIdx := range f.CodeElements {
-					ce := &f.CodeElements[ceIdx]
-					if ce.FirstLine == mCtx.method.FirstLine && ce.FullName == mCtx.method.DisplayName {
-						correspondingCE = ce
-						break
-					}
-				}
+				correspondingCE = model.FindCodeElement(f.CodeElements, mCtx.method)
 			}
 			if correspondingCE != nil {
 				break
@@ -486,7 +770,7 @@ func (b *HtmlReportBuilder) buildMetricsTableForClassVM(classModel *model.Class)
 			// For metrics table purposes, we primarily need FirstLine, FullName (as DisplayName), and Type (Method).
 			// The CoverageQuota for the method itself might be derived if available.
 			var methCovQuota *float64
-			if !math.IsNaN(mCtx.method.LineRate) {
+			if isFinite(mCtx.method.LineRate) {
 				lrq := mCtx.method.LineRate * 100.0
 				methCovQuota = &lrq
 			}
@@ -509,19 +793,6 @@ func (b *HtmlReportBuilder) buildMetricsTableForClassVM(classModel *model.Class)
 	return metricsTable
 }
 
-func (b *HtmlReportBuilder) getMetricExplanationURL(metricKey string) string {
-	switch metricKey {
-	case "Cyclomatic complexity", "Complexity":
-		return "https://en.wikipedia.org/wiki/Cyclomatic_complexity"
-	case "CrapScore":
-		return "https://testing.googleblog.com/2011/02/this-code-is-crap.html"
-	case "Line coverage", "Branch coverage":
-		return "https://en.wikipedia.org/wiki/Code_coverage"
-	default:
-		return ""
-	}
-}
-
 func (b *HtmlReportBuilder) formatMetricValue(metric model.Metric) string {
 	if metric.Value == nil {
 		return "-"
@@ -539,12 +810,12 @@ func (b *HtmlReportBuilder) formatMetricValue(metric model.Metric) string {
 	if math.IsInf(valFloat, 0) {
 		return "Inf"
 	}
-	switch metric.Name {
-	case "Line coverage", "Branch coverage":
-		return utils.FormatPercentage(valFloat, b.maximumDecimalPlacesForPercentageDisplay)
-	case "CrapScore":
+	switch b.metrics().Lookup(metric.Name).Format {
+	case model.FormatPercentage:
+		return b.formatPercentage(valFloat, b.maximumDecimalPlacesForPercentageDisplay)
+	case model.FormatTwoDecimals:
 		return fmt.Sprintf("%.2f", valFloat)
-	case "Cyclomatic complexity", "Complexity":
+	case model.FormatInteger:
 		return fmt.Sprintf("%.0f", valFloat)
 	default:
 		return fmt.Sprintf(fmt.Sprintf("%%.%df", b.maximumDecimalPlacesForCoverageQuotas), valFloat)
@@ -562,9 +833,10 @@ func (b *HtmlReportBuilder) buildAngularClassDetailForJS(classModel *model.Class
 		FullyCoveredMethods:   classVMServer.FullyCoveredMethods,
 		TotalMethods:          classVMServer.TotalMethods,
 		HistoricCoverages:     classVMServer.HistoricCoverages,
-		LineCoverageHistory:   classVMServer.LineCoverageHistory,
-		BranchCoverageHistory: classVMServer.BranchCoverageHistory,
-		Metrics:               classVMServer.Metrics,
+		LineCoverageHistory:   finiteFloats(classVMServer.LineCoverageHistory),
+		BranchCoverageHistory: finiteFloats(classVMServer.BranchCoverageHistory),
+		Metrics:               finiteMetrics(classVMServer.Metrics),
+		External:              b.markExternalFiles && classModel.IsExternal(),
 	}
 	if classModel.BranchesCovered != nil {
 		angularClassVMForJS.CoveredBranches = *classModel.BranchesCovered
@@ -594,22 +866,28 @@ func (b *HtmlReportBuilder) buildAngularFileViewModelForJS(fileInClass *model.Co
 		CoverableLines: fileInClass.CoverableLines,
 		TotalLines:     fileInClass.TotalLines,
 		Lines:          []AngularLineAnalysisViewModel{},
+		External:       b.markExternalFiles && !fileInClass.InSourceDirs,
 	}
-	sourceLines, err := filereader.ReadLinesInFile(fileInClass.Path)
+	sourceLines, err := b.readSourceLines(fileInClass)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: could not read source file %s for JS Angular VM: %v\n", fileInClass.Path, err)
 		return angularFile, nil
 	}
-	coverageLinesMap := make(map[int]*model.Line)
-	if fileInClass.Lines != nil {
-		for i := range fileInClass.Lines {
-			covLine := &fileInClass.Lines[i]
-			coverageLinesMap[covLine.Number] = covLine
-		}
+	coverageLinesMap := make(map[int]*model.Line, len(fileInClass.Lines))
+	for i := range fileInClass.Lines {
+		covLine := &fileInClass.Lines[i]
+		coverageLinesMap[covLine.Number] = covLine
 	}
+	minified := b.isMinified(sourceLines)
+	angularFile.Lines = make([]AngularLineAnalysisViewModel, 0, len(sourceLines))
 	for i, content := range sourceLines {
 		actualLineNumber := i + 1
 		modelCovLine, hasCoverageData := coverageLinesMap[actualLineNumber]
+		if minified {
+			content = ""
+		} else if truncated, length := b.truncateSourceLine(content); length > 0 {
+			content = truncated + "…"
+		}
 		angularLine := b.buildAngularLineViewModelForJS(content, actualLineNumber, modelCovLine, hasCoverageData)
 		angularFile.Lines = append(angularFile.Lines, angularLine)
 	}
@@ -633,18 +911,16 @@ func (b *HtmlReportBuilder) buildAngularLineViewModelForJS(content string, actua
 }
 
 func (b *HtmlReportBuilder) buildClassDetailPageData(classVM ClassViewModelForDetail, tag string, classDetailJS template.JS) ClassDetailData {
-	appVersion := "0.0.1"
-	if b.ReportContext.ReportConfiguration() != nil {
-		appVersion = "0.0.1"
-	}
 	return ClassDetailData{
 		ReportTitle:                           b.reportTitle,
-		AppVersion:                            appVersion,
-		CurrentDateTime:                       time.Now().Format("02/01/2006 - 15:04:05"),
+		AppVersion:                            b.appVersion,
+		CommandLine:                           b.commandLine,
+		CurrentDateTime:                       b.generatedAt.Format("02/01/2006 - 15:04:05"),
 		Class:                                 classVM,
 		BranchCoverageAvailable:               b.branchCoverageAvailable,
 		MethodCoverageAvailable:               b.methodCoverageAvailable,
 		Tag:                                   tag,
+		TagLink:                               b.tagLink,
 		Translations:                          b.translations,
 		MaximumDecimalPlacesForCoverageQuotas: b.maximumDecimalPlacesForCoverageQuotas,
 		AngularCssFile:                        b.angularCssFile,
@@ -652,7 +928,6 @@ func (b *HtmlReportBuilder) buildClassDetailPageData(classVM ClassViewModelForDe
 		AngularRuntimeJsFile:                  b.angularRuntimeJsFile,
 		AngularPolyfillsJsFile:                b.angularPolyfillsJsFile,
 		AngularMainJsFile:                     b.angularMainJsFile,
-		AssembliesJSON:                        b.assembliesJSON,
 		RiskHotspotsJSON:                      b.riskHotspotsJSON,
 		MetricsJSON:                           b.metricsJSON,
 		RiskHotspotMetricsJSON:                b.riskHotspotMetricsJSON,
@@ -0,0 +1,978 @@
+package htmlreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+	"golang.org/x/net/html"
+)
+
+// TestBuildFileViewModelForServerRender_CountsUncoveredLines checks that red and
+// orange lines are counted for the "uncovered only" toggle on the class page.
+func TestBuildFileViewModelForServerRender_CountsUncoveredLines(t *testing.T) {
+	sourcePath := filepath.Join(t.TempDir(), "Calc.cs")
+	source := "class Calc {\n  int A() { return 1; }\n  int B() { return 2; }\n  int C(bool x) { return x ? 1 : 2; }\n}\n"
+	if err := os.WriteFile(sourcePath, []byte(source), 0o644); err != nil {
+		t.Fatalf("failed to write source file: %v", err)
+	}
+
+	codeFile := &model.CodeFile{
+		Path: sourcePath,
+		Lines: []model.Line{
+			{Number: 2, Hits: 3},
+			{Number: 3, Hits: 0},
+			{Number: 4, Hits: 1, IsBranchPoint: true, CoveredBranches: 1, TotalBranches: 2},
+		},
+	}
+
+	b := newTestSummaryBuilder()
+	fileVM, _, err := b.buildFileViewModelForServerRender(codeFile, nil)
+	if err != nil {
+		t.Fatalf("buildFileViewModelForServerRender returned error: %v", err)
+	}
+
+	if fileVM.UncoveredLineCount != 2 {
+		t.Errorf("UncoveredLineCount = %d, want 2", fileVM.UncoveredLineCount)
+	}
+
+	data := ClassDetailData{
+		Translations: b.translations,
+		Class:        ClassViewModelForDetail{Name: "Calc", Files: []FileViewModelForDetail{fileVM}},
+	}
+	var page bytes.Buffer
+	if err := classDetailTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render class detail page: %v", err)
+	}
+	if !strings.Contains(page.String(), "Show 2 uncovered lines") {
+		t.Errorf("class detail page does not contain the uncovered lines toggle")
+	}
+	if strings.Contains(page.String(), "switchtestmethod") {
+		t.Errorf("class detail page without per-test data must not contain the test selector")
+	}
+}
+
+// TestBuildMetricsTableForClassVM_HeadersFromPresentMetrics checks that the metrics table only
+// has columns for metrics the methods provide and that missing values are shown as "-".
+func TestBuildMetricsTableForClassVM_HeadersFromPresentMetrics(t *testing.T) {
+	metric := func(line int, name string, value float64) model.MethodMetric {
+		return model.MethodMetric{Name: "m", Line: line, Metrics: []model.Metric{{Name: name, Value: value}}}
+	}
+	classModel := &model.Class{
+		Name: "Calc",
+		Methods: []model.Method{
+			{DisplayName: "A()", FirstLine: 2, LineRate: 1, MethodMetrics: []model.MethodMetric{metric(2, "NPath complexity", 4)}},
+			{DisplayName: "B()", FirstLine: 5, LineRate: 0.5, MethodMetrics: []model.MethodMetric{metric(5, "Cyclomatic complexity", 3)}},
+		},
+		Files: []model.CodeFile{{
+			Path: "Calc.cs",
+			CodeElements: []model.CodeElement{
+				{FullName: "A()", FirstLine: 2},
+				{FullName: "B()", FirstLine: 5},
+			},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	table := b.buildMetricsTableForClassVM(classModel)
+
+	var headerNames []string
+	for _, h := range table.Headers {
+		headerNames = append(headerNames, h.Name)
+	}
+	wantHeaders := []string{"Cyclomatic complexity", "Line coverage", "NPath complexity"}
+	if strings.Join(headerNames, "|") != strings.Join(wantHeaders, "|") {
+		t.Fatalf("headers = %v, want %v", headerNames, wantHeaders)
+	}
+
+	if len(table.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(table.Rows))
+	}
+	wantValues := [][]string{{"-", "100%", "4"}, {"3", "50%", "-"}}
+	for i, want := range wantValues {
+		if strings.Join(table.Rows[i].MetricValues, "|") != strings.Join(want, "|") {
+			t.Errorf("row %d values = %v, want %v", i, table.Rows[i].MetricValues, want)
+		}
+	}
+}
+
+// TestBuildMetricsTableForClassVM_DeclaredClassComplexity checks that the methods of a
+// class with a declared complexity but none of their own show "-" for it, and that the
+// information card shows the class value.
+func TestBuildMetricsTableForClassVM_DeclaredClassComplexity(t *testing.T) {
+	complexity := 4.0
+	classModel := &model.Class{
+		Name:       "shop.Cart",
+		Complexity: &complexity,
+		Metrics:    map[string]float64{"Cyclomatic complexity": complexity},
+		Methods:    []model.Method{{DisplayName: "add()", FirstLine: 3, LineRate: 0, Complexity: math.NaN()}},
+		Files:      []model.CodeFile{{Path: "Cart.scala", CodeElements: []model.CodeElement{{FullName: "add()", FirstLine: 3}}}},
+	}
+
+	b := newTestSummaryBuilder()
+	table := b.buildMetricsTableForClassVM(classModel)
+
+	if len(table.Rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(table.Rows))
+	}
+	if got, want := strings.Join(table.Rows[0].MetricValues, "|"), "-|0%"; got != want {
+		t.Errorf("row values = %q, want %q", got, want)
+	}
+
+	var cvm ClassViewModelForDetail
+	b.populateAggregatedMetricsForClassVM(&cvm, classModel)
+	if cvm.ComplexityForDisplay != "4" {
+		t.Errorf("ComplexityForDisplay = %q, want %q", cvm.ComplexityForDisplay, "4")
+	}
+	b.populateAggregatedMetricsForClassVM(&cvm, &model.Class{Name: "plain.Util"})
+	if cvm.ComplexityForDisplay != "-" {
+		t.Errorf("ComplexityForDisplay without complexity = %q, want %q", cvm.ComplexityForDisplay, "-")
+	}
+}
+
+func TestBuildMetricsTableForClassVM_MetricStatuses(t *testing.T) {
+	classModel := &model.Class{
+		Name: "Calc",
+		Methods: []model.Method{{
+			DisplayName: "Parse()", FirstLine: 2, LineRate: 0,
+			MethodMetrics: []model.MethodMetric{{Name: "Parse()", Line: 2, Metrics: []model.Metric{
+				{Name: "Cyclomatic complexity", Value: 20.0, Status: model.StatusWarning},
+				{Name: "CrapScore", Value: 420.0, Status: model.StatusError},
+			}}},
+		}},
+		Files: []model.CodeFile{{Path: "Calc.cs", CodeElements: []model.CodeElement{{FullName: "Parse()", FirstLine: 2}}}},
+	}
+
+	b := newTestSummaryBuilder()
+	table := b.buildMetricsTableForClassVM(classModel)
+
+	if len(table.Rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(table.Rows))
+	}
+	// Headers are sorted: CrapScore, Cyclomatic complexity, Line coverage.
+	want := []string{"lightred", "lightorange", ""}
+	for i, status := range table.Rows[0].MetricStatuses {
+		if got := metricStatusClass(status); got != want[i] {
+			t.Errorf("cell %d (%s) class = %q, want %q", i, table.Headers[i].Name, got, want[i])
+		}
+	}
+}
+
+// TestCreateReport_CustomMetric registers a metric that is not known to the parsers and
+// expects it to be linked, ordered and formatted in the metrics table of the class page
+// and listed in window.metrics.
+func TestCreateReport_CustomMetric(t *testing.T) {
+	report := storedSourceReport([]model.Line{{Number: 1, Hits: 3, Content: "int Add(int a, int b) => a + b;"}})
+	methodMetrics := []model.MethodMetric{{Name: "Add()", Line: 1, Metrics: []model.Metric{
+		{Name: "Cyclomatic complexity", Value: 1.0},
+		{Name: "Mutation score", Value: 87.25},
+	}}}
+	class := &report.Assemblies[0].Classes[0]
+	class.Methods = []model.Method{{Name: "Add", DisplayName: "Add()", FirstLine: 1, LastLine: 1, LineRate: 1, MethodMetrics: methodMetrics}}
+	class.Files[0].MethodMetrics = methodMetrics
+	class.Files[0].CodeElements = []model.CodeElement{{Name: "Add()", FullName: "Add()", Type: model.MethodElementType, FirstLine: 1, LastLine: 1}}
+
+	b := newInMemoryBuilder(t, "Html")
+	b.ReportContext.Settings().Metrics["Mutation score"] = model.MetricDefinition{
+		Name: "Mutation score", ExplanationURL: "https://stryker-mutator.io/", Order: 1, Aggregation: model.AggregateNone,
+	}
+	files, err := b.CreateReportInMemory(report)
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+
+	page := string(files["DemoCalc.html"])
+	header := `<th scope="col">Mutation score <a href="https://stryker-mutator.io/" target="_blank">`
+	if !strings.Contains(page, header) {
+		t.Errorf("expected the header %s in the class page", header)
+	}
+	if strings.Index(page, header) > strings.Index(page, `<th scope="col">Cyclomatic complexity`) {
+		t.Error("expected the custom metric before the cyclomatic complexity, as given by its order")
+	}
+
+	if !strings.Contains(page, "<td>87.2</td>") {
+		t.Error("expected the value of the custom metric with the decimal places of coverage quotas")
+	}
+
+	match := regexp.MustCompile(`window\.metrics = (.*); `).FindSubmatch(files["index.html"])
+	if match == nil {
+		t.Fatal("index.html does not contain window.metrics")
+	}
+	var metrics []AngularMetricViewModel
+	if err := json.Unmarshal(match[1], &metrics); err != nil {
+		t.Fatalf("window.metrics is not valid JSON: %v\n%s", err, match[1])
+	}
+	want := AngularMetricViewModel{Name: "Mutation score", Abbreviation: "Mutation score", ExplanationURL: "https://stryker-mutator.io/"}
+	if len(metrics) != 2 || metrics[0] != want || metrics[1].Name != "Cyclomatic complexity" {
+		t.Errorf("window.metrics = %+v, want the custom metric %+v first", metrics, want)
+	}
+}
+
+// TestBuildMetricsTableForClassVM_OverloadsWithSameDisplayName checks that overloads
+// cleaned to the same display name get the link target and coverage quota of their own
+// code element, also when they start on the same line.
+func TestBuildMetricsTableForClassVM_OverloadsWithSameDisplayName(t *testing.T) {
+	newMethod := func(signature string, line int, lineRate float64) model.Method {
+		return model.Method{Name: "<Run>g__Helper|0_0", Signature: signature, DisplayName: "Helper()", FirstLine: line, LastLine: line, LineRate: lineRate}
+	}
+	newElement := func(method model.Method) model.CodeElement {
+		quota := method.LineRate * 100
+		return model.CodeElement{Name: "Helper()", FullName: "Helper()", FirstLine: method.FirstLine, LastLine: method.LastLine, CoverageQuota: &quota, RawKey: method.RawKey()}
+	}
+
+	testCases := []struct {
+		name    string
+		methods []model.Method
+	}{
+		{name: "AdjacentLines", methods: []model.Method{newMethod("(System.Int32)", 11, 0.25), newMethod("(System.String)", 10, 1)}},
+		{name: "SameLine", methods: []model.Method{newMethod("(System.String)", 10, 1), newMethod("(System.Int32)", 10, 0.25)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange: the code elements are listed in the opposite order of the methods.
+			var elements []model.CodeElement
+			for i := len(tc.methods) - 1; i >= 0; i-- {
+				elements = append(elements, newElement(tc.methods[i]))
+			}
+			classModel := &model.Class{
+				Name:    "Program",
+				Methods: tc.methods,
+				Files:   []model.CodeFile{{Path: "Program.cs", CodeElements: elements}},
+			}
+
+			// Act
+			table := newTestSummaryBuilder().buildMetricsTableForClassVM(classModel)
+
+			// Assert
+			if len(table.Rows) != len(tc.methods) {
+				t.Fatalf("expected %d rows, got %d", len(tc.methods), len(table.Rows))
+			}
+			quotaByLineRate := map[string]float64{"100%": 100, "25%": 25}
+			for i, row := range table.Rows {
+				lineCoverage := row.MetricValues[len(row.MetricValues)-1]
+				wantQuota, ok := quotaByLineRate[lineCoverage]
+				if !ok {
+					t.Fatalf("row %d: unexpected line coverage %q", i, lineCoverage)
+				}
+				if row.CoverageQuota == nil {
+					t.Fatalf("row %d (line coverage %s): missing coverage quota", i, lineCoverage)
+				}
+				if *row.CoverageQuota != wantQuota {
+					t.Errorf("row %d (line coverage %s): coverage quota = %v, want %v", i, lineCoverage, *row.CoverageQuota, wantQuota)
+				}
+				for _, method := range tc.methods {
+					if method.LineRate*100 == wantQuota && row.Line != method.FirstLine {
+						t.Errorf("row %d (line coverage %s): links to line %d, want %d", i, lineCoverage, row.Line, method.FirstLine)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestBuildClassViewModel_CoverageByTest checks that per-test hits end up in the
+// data-coverage attribute and in the test selector, and that lines without per-test
+// data keep the plain "AllTestMethods" entry.
+func TestBuildClassViewModel_CoverageByTest(t *testing.T) {
+	sourcePath := filepath.Join(t.TempDir(), "Calc.cs")
+	if err := os.WriteFile(sourcePath, []byte("class Calc {\n  int A() => 1;\n  int B() => 2;\n}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write source file: %v", err)
+	}
+	classModel := &model.Class{
+		Name:        "Calc",
+		DisplayName: "Calc",
+		Files: []model.CodeFile{{
+			Path: sourcePath,
+			Lines: []model.Line{
+				{Number: 2, Hits: 3, LineCoverageByTestMethod: map[string]int{"Tests.CalcTests.AddTest": 2, "Tests.CalcTests.AllTest": 1}},
+				{Number: 3, Hits: 0},
+			},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	cvm := b.buildClassViewModelForDetailServer(classModel, "")
+
+	if len(cvm.TestMethods) != 2 {
+		t.Fatalf("expected 2 test methods, got %+v", cvm.TestMethods)
+	}
+	if cvm.TestMethods[0].ID != "M0" || cvm.TestMethods[0].ShortName != "AddTest" || cvm.TestMethods[1].Name != "Tests.CalcTests.AllTest" {
+		t.Errorf("unexpected test methods: %+v", cvm.TestMethods)
+	}
+
+	lines := cvm.Files[0].Lines
+	wantCovered := `{"AllTestMethods":{"LVS":"green","VC":"3"},"M0":{"LVS":"green","VC":"2"},"M1":{"LVS":"green","VC":"1"}}`
+	if got := string(lines[1].DataCoverage); got != wantCovered {
+		t.Errorf("line 2 data-coverage = %s, want %s", got, wantCovered)
+	}
+	wantUncovered := `{"AllTestMethods":{"LVS":"red","VC":"0"}}`
+	if got := string(lines[2].DataCoverage); got != wantUncovered {
+		t.Errorf("line 3 data-coverage = %s, want %s", got, wantUncovered)
+	}
+
+	data := ClassDetailData{Translations: b.translations, Class: cvm}
+	var page bytes.Buffer
+	if err := classDetailTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render class detail page: %v", err)
+	}
+	if !strings.Contains(page.String(), `<option id="M0" class="testmethod" value="M0" title="Tests.CalcTests.AddTest">AddTest</option>`) {
+		t.Errorf("class detail page does not contain the test selector")
+	}
+}
+
+// renderedDataCoverage renders the class page of classModel, parses it as a browser
+// would and returns the data-coverage attributes of the line rows in order.
+func renderedDataCoverage(t *testing.T, classModel *model.Class) []string {
+	t.Helper()
+	b := newTestSummaryBuilder()
+	data := ClassDetailData{Translations: b.translations, Class: b.buildClassViewModelForDetailServer(classModel, "")}
+	var page bytes.Buffer
+	if err := classDetailTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render class detail page: %v", err)
+	}
+	doc, err := html.Parse(&page)
+	if err != nil {
+		t.Fatalf("failed to parse class detail page: %v", err)
+	}
+
+	var attributes []string
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode || n.Data != "tr" {
+			continue
+		}
+		for _, attr := range n.Attr {
+			if attr.Key == "data-coverage" {
+				attributes = append(attributes, attr.Val)
+			}
+		}
+	}
+	return attributes
+}
+
+// TestClassPage_DataCoverageRoundTrips checks that the data-coverage attributes of a
+// rendered class page hold the JSON custom.js parses, and that they are left empty for
+// classes without per-test data.
+func TestClassPage_DataCoverageRoundTrips(t *testing.T) {
+	sourcePath := filepath.Join(t.TempDir(), "Calc.cs")
+	if err := os.WriteFile(sourcePath, []byte("class Calc {\n  int A() => 1;\n  int B() => 2;\n}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write source file: %v", err)
+	}
+	lines := []model.Line{
+		{Number: 2, Hits: 3, LineCoverageByTestMethod: map[string]int{`Tests.Calc("a \"quoted\" <arg>")`: 2, "Tests.Calc('b')": 1}},
+		{Number: 3, Hits: 0},
+	}
+	classModel := &model.Class{Name: "Calc", DisplayName: "Calc", Files: []model.CodeFile{{Path: sourcePath, Lines: lines}}}
+
+	attributes := renderedDataCoverage(t, classModel)
+	if len(attributes) != 4 {
+		t.Fatalf("expected a data-coverage attribute per line, got %q", attributes)
+	}
+	want := []map[string]map[string]string{
+		{"AllTestMethods": {"LVS": "gray", "VC": ""}},
+		{"AllTestMethods": {"LVS": "green", "VC": "3"}, "M0": {"LVS": "green", "VC": "2"}, "M1": {"LVS": "green", "VC": "1"}},
+		{"AllTestMethods": {"LVS": "red", "VC": "0"}},
+		{"AllTestMethods": {"LVS": "gray", "VC": ""}},
+	}
+	for i, attribute := range attributes {
+		var got map[string]map[string]string
+		if err := json.Unmarshal([]byte(attribute), &got); err != nil {
+			t.Errorf("data-coverage of line %d is no valid JSON: %q: %v", i+1, attribute, err)
+			continue
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want[i]) {
+			t.Errorf("data-coverage of line %d = %v, want %v", i+1, got, want[i])
+		}
+	}
+
+	for i := range lines {
+		lines[i].LineCoverageByTestMethod = nil
+	}
+	for i, attribute := range renderedDataCoverage(t, classModel) {
+		if attribute != "" {
+			t.Errorf("data-coverage of line %d = %q, want it empty without per-test data", i+1, attribute)
+		}
+	}
+}
+
+// TestBuildClassViewModel_SidebarGroupedByFile checks that the sidebar lists the code
+// elements per file, sorted by line, with a header per file for multi-file classes.
+func TestBuildClassViewModel_SidebarGroupedByFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"lexer.go", "parser.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("package p\n\nfunc A() {}\n\nfunc B() {}\n"), 0o644); err != nil {
+			t.Fatalf("failed to write source file: %v", err)
+		}
+	}
+	quota := 50.0
+	classModel := &model.Class{
+		Name:        "example.com/p",
+		DisplayName: "example.com/p",
+		Files: []model.CodeFile{
+			{
+				Path:           filepath.Join(dir, "parser.go"),
+				CoveredLines:   1,
+				CoverableLines: 2,
+				CodeElements: []model.CodeElement{
+					{Name: "B()", FullName: "B()", Type: model.MethodElementType, FirstLine: 5, CoverageQuota: &quota},
+					{Name: "A()", FullName: "A()", Type: model.MethodElementType, FirstLine: 3, LastLine: 4, CoverageQuota: &quota},
+				},
+			},
+			{
+				Path:           filepath.Join(dir, "lexer.go"),
+				CoveredLines:   0,
+				CoverableLines: 1,
+				CodeElements: []model.CodeElement{
+					{Name: "Next()", FullName: "Next()", Type: model.MethodElementType, FirstLine: 3},
+				},
+			},
+		},
+	}
+
+	b := newTestSummaryBuilder()
+	cvm := b.buildClassViewModelForDetailServer(classModel, "")
+
+	if len(cvm.SidebarFiles) != 2 {
+		t.Fatalf("expected 2 sidebar files, got %+v", cvm.SidebarFiles)
+	}
+	lexer, parser := cvm.SidebarFiles[0], cvm.SidebarFiles[1]
+	if lexer.Path != filepath.Join(dir, "lexer.go") || len(lexer.Elements) != 1 {
+		t.Errorf("unexpected first sidebar file: %+v", lexer)
+	}
+	if lexer.CoverageBarValue != getCoverageBarValue(0) {
+		t.Errorf("lexer.go CoverageBarValue = %d, want %d", lexer.CoverageBarValue, getCoverageBarValue(0))
+	}
+	if len(parser.Elements) != 2 || parser.Elements[0].Name != "A()" || parser.Elements[1].Name != "B()" {
+		t.Errorf("expected the elements of parser.go sorted by line, got %+v", parser.Elements)
+	}
+	if len(parser.Elements) == 2 && (parser.Elements[0].Range != "3-4" || parser.Elements[1].Range != "5-5") {
+		t.Errorf("expected the line ranges 3-4 and 5-5 (no last line), got %q and %q", parser.Elements[0].Range, parser.Elements[1].Range)
+	}
+	if parser.CoverageTitle != "Line coverage: 50.0% - "+filepath.Join(dir, "parser.go") {
+		t.Errorf("parser.go CoverageTitle = %q", parser.CoverageTitle)
+	}
+
+	data := ClassDetailData{Translations: b.translations, Class: cvm}
+	var page bytes.Buffer
+	if err := classDetailTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render class detail page: %v", err)
+	}
+	if !strings.Contains(page.String(), `data-range="3-4"`) {
+		t.Error("expected the sidebar link of A() to carry its line range")
+	}
+	if got := strings.Count(page.String(), `class="sidebarfile"`); got != 2 {
+		t.Errorf("expected 2 sidebar file groups, got %d", got)
+	}
+	if !strings.Contains(page.String(), `<a href="#`+parser.ShortPath+`" class="navigatetohash`) {
+		t.Errorf("sidebar file header does not link to the file section")
+	}
+}
+
+// syntheticReport returns a report with the given number of classes in modules of 50
+// classes, each with its own source file of linesPerClass lines, of which every other
+// line is coverable.
+func syntheticReport(tb testing.TB, classCount, linesPerClass int) *model.SummaryResult {
+	tb.Helper()
+	sourceDir := tb.TempDir()
+	var source strings.Builder
+	for i := 1; i <= linesPerClass; i++ {
+		fmt.Fprintf(&source, "    var value%d = Compute(%d); // statement %d\n", i, i, i)
+	}
+
+	report := &model.SummaryResult{ParserName: "Cobertura"}
+	assemblies := make(map[string]*model.Assembly)
+	var assemblyNames []string
+	for c := 0; c < classCount; c++ {
+		sourcePath := filepath.Join(sourceDir, fmt.Sprintf("Class%d.cs", c))
+		if err := os.WriteFile(sourcePath, []byte(source.String()), 0o644); err != nil {
+			tb.Fatalf("failed to write source file: %v", err)
+		}
+		var lines []model.Line
+		covered := 0
+		for n := 1; n <= linesPerClass; n += 2 {
+			line := model.Line{Number: n, Hits: n % 3, LineVisitStatus: model.NotCovered}
+			if line.Hits > 0 {
+				line.LineVisitStatus = model.Covered
+				covered++
+			}
+			lines = append(lines, line)
+		}
+		class := model.Class{
+			Name:         fmt.Sprintf("Demo.Module%d.Class%d", c/50, c),
+			DisplayName:  fmt.Sprintf("Demo.Module%d.Class%d", c/50, c),
+			LinesCovered: covered,
+			LinesValid:   len(lines),
+			TotalLines:   linesPerClass,
+			Files: []model.CodeFile{{
+				Path:           sourcePath,
+				Lines:          lines,
+				CoveredLines:   covered,
+				CoverableLines: len(lines),
+				TotalLines:     linesPerClass,
+				CodeElements: []model.CodeElement{
+					{Name: "Run()", FullName: "Run()", Type: model.MethodElementType, FirstLine: 1, LastLine: linesPerClass},
+				},
+			}},
+		}
+
+		assemblyName := fmt.Sprintf("Demo.Module%d", c/50)
+		assembly, ok := assemblies[assemblyName]
+		if !ok {
+			assembly = &model.Assembly{Name: assemblyName}
+			assemblies[assemblyName] = assembly
+			assemblyNames = append(assemblyNames, assemblyName)
+		}
+		assembly.Classes = append(assembly.Classes, class)
+		assembly.LinesCovered += covered
+		assembly.LinesValid += len(lines)
+		report.LinesCovered += covered
+		report.LinesValid += len(lines)
+	}
+	for _, name := range assemblyNames {
+		report.Assemblies = append(report.Assemblies, *assemblies[name])
+	}
+	return report
+}
+
+func renderInMemory(t *testing.T, reportTypes string, report *model.SummaryResult) map[string][]byte {
+	t.Helper()
+	files, err := newInMemoryBuilder(t, reportTypes).CreateReportInMemory(report)
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+	return files
+}
+
+func newInMemoryBuilder(t *testing.T, reportTypes string) *HtmlReportBuilder {
+	t.Helper()
+	cfg, err := reportconfig.NewReportConfiguration(nil, t.TempDir(), reportconfig.WithReportTypeSpecs(reportTypes))
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	ctx := reporter.NewBuilderContext(cfg, settings.NewSettings(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return NewHtmlReportBuilder("", ctx)
+}
+
+func totalSize(files map[string][]byte) int {
+	size := 0
+	for _, content := range files {
+		size += len(content)
+	}
+	return size
+}
+
+// TestCreateReport_OnDemandClassDetails renders a 500 class report with class pages and
+// with on-demand class details, and expects the latter to write one small data file per
+// class instead of a page, and to be much smaller in total.
+func TestCreateReport_OnDemandClassDetails(t *testing.T) {
+	const classCount = 500
+	report := syntheticReport(t, classCount, 40)
+
+	pages := renderInMemory(t, "Html", report)
+	onDemand := renderInMemory(t, "Html{classdetails=ondemand}", report)
+
+	var htmlFiles, dataFiles []string
+	for name := range onDemand {
+		switch {
+		case strings.HasSuffix(name, ".html"):
+			htmlFiles = append(htmlFiles, name)
+		case strings.HasPrefix(name, "classdetails/"):
+			dataFiles = append(dataFiles, name)
+		}
+	}
+	if len(htmlFiles) != 1 || htmlFiles[0] != "index.html" {
+		t.Errorf("expected index.html to be the only page, got %v", htmlFiles)
+	}
+	if len(dataFiles) != classCount {
+		t.Errorf("expected %d class detail files, got %d", classCount, len(dataFiles))
+	}
+	if !bytes.Contains(onDemand["index.html"], []byte(`"rp":"#classdetails/classdetail_1.js"`)) {
+		t.Errorf("expected window.assemblies to link the classes to their data files")
+	}
+	if !bytes.Contains(onDemand["index.html"], []byte(`id="classdetail"`)) {
+		t.Errorf("expected index.html to contain the class detail container")
+	}
+
+	pagesSize, onDemandSize := totalSize(pages), totalSize(onDemand)
+	t.Logf("%d classes: %d bytes with class pages, %d bytes with on-demand class details (%.1f%% smaller)",
+		classCount, pagesSize, onDemandSize, 100*(1-float64(onDemandSize)/float64(pagesSize)))
+	if onDemandSize*3 > pagesSize*2 {
+		t.Errorf("expected on-demand class details to be at least a third smaller, got %d vs %d bytes", onDemandSize, pagesSize)
+	}
+}
+
+// TestCreateReport_ClassPageSizeIndependentOfClassCount guards against class pages that
+// repeat data of the whole report: a class page of a report with 200 classes must not be
+// larger than the same page of a report with 2 classes.
+func TestCreateReport_ClassPageSizeIndependentOfClassCount(t *testing.T) {
+	small := renderInMemory(t, "Html", syntheticReport(t, 2, 40))
+	large := renderInMemory(t, "Html", syntheticReport(t, 200, 40))
+
+	const page = "Demo.Module0Class0.html"
+	if len(small[page]) == 0 || len(large[page]) == 0 {
+		t.Fatalf("expected %s in both reports", page)
+	}
+	if bytes.Contains(large[page], []byte("window.assemblies")) {
+		t.Errorf("expected the class page not to embed window.assemblies")
+	}
+	// Only the paths of the temporary source directories may differ.
+	if diff := len(large[page]) - len(small[page]); diff > 100 {
+		t.Errorf("class page grows with the number of classes: %d bytes with 2 classes, %d bytes with 200", len(small[page]), len(large[page]))
+	}
+}
+
+// TestCreateReport_OnDemandClassDetailData expects a class detail file to pass the class
+// to window.loadClassDetail with its details rendered like on the class pages.
+func TestCreateReport_OnDemandClassDetailData(t *testing.T) {
+	files := renderInMemory(t, "Html{classdetails=ondemand}", syntheticReport(t, 2, 40))
+
+	content := string(files["classdetails/classdetail_2.js"])
+	payload, ok := strings.CutPrefix(content, "window.loadClassDetail(")
+	if !ok || !strings.HasSuffix(payload, ");\n") {
+		t.Fatalf("unexpected class detail file: %.80q", content)
+	}
+	var detail ClassDetailDataViewModel
+	if err := json.Unmarshal([]byte(strings.TrimSuffix(payload, ");\n")), &detail); err != nil {
+		t.Fatalf("class detail file does not contain valid JSON: %v", err)
+	}
+
+	if detail.Class.Name != "Demo.Module0.Class1" || detail.Class.ReportPath != "#classdetails/classdetail_2.js" {
+		t.Errorf("unexpected class %q with report path %q", detail.Class.Name, detail.Class.ReportPath)
+	}
+	if got := strings.Count(detail.HTML, `<tr class="coverableline"`) + strings.Count(detail.HTML, `<tr class=""`); got != 40 {
+		t.Errorf("expected 40 lines in the class details, got %d", got)
+	}
+	for _, want := range []string{
+		`<div class="card-header">Line coverage</div>`,
+		`<h2 id="Class1.cs">`,
+		`<td class="lightgreen"><code>`,
+	} {
+		if !strings.Contains(detail.HTML, want) {
+			t.Errorf("expected %s in the class details", want)
+		}
+	}
+	if strings.Contains(detail.HTML, "\n ") {
+		t.Errorf("expected the class details without indentation")
+	}
+}
+
+// stubSourceReader serves the source files in files and refuses to read any other file.
+type stubSourceReader struct {
+	files map[string][]string
+	reads []string
+}
+
+func (r *stubSourceReader) ReadFile(path string) ([]string, error) {
+	r.reads = append(r.reads, path)
+	if lines, ok := r.files[path]; ok {
+		return lines, nil
+	}
+	return nil, fmt.Errorf("reading %s is not allowed", path)
+}
+
+func (r *stubSourceReader) CountLines(path string) (int, error) {
+	lines, err := r.ReadFile(path)
+	return len(lines), err
+}
+
+func (r *stubSourceReader) Stat(name string) (fs.FileInfo, error) {
+	return nil, fs.ErrNotExist
+}
+
+func storedSourceReport(lines []model.Line) *model.SummaryResult {
+	file := model.NewCodeFile("/gone/src/Calc.cs", lines)
+	return &model.SummaryResult{
+		ParserName: "Cobertura",
+		Assemblies: []model.Assembly{{
+			Name: "Demo",
+			Classes: []model.Class{{
+				Name: "Demo.Calc", DisplayName: "Demo.Calc",
+				LinesCovered: file.CoveredLines, LinesValid: file.CoverableLines, TotalLines: file.TotalLines,
+				Files: []model.CodeFile{file},
+			}},
+		}},
+	}
+}
+
+// TestCreateReport_SourceFromCoverageData renders a class whose source file is only
+// available from the line contents of the coverage data.
+func TestCreateReport_SourceFromCoverageData(t *testing.T) {
+	report := storedSourceReport([]model.Line{
+		{Number: 1, Hits: -1, Content: "class Calc {"},
+		{Number: 2, Hits: 3, Content: "  int Add(int a, int b) => a + b;"},
+		{Number: 3, Hits: -1, Content: "}"},
+	})
+	for _, reportTypes := range []string{"Html", "Html{classdetails=ondemand}"} {
+		t.Run(reportTypes, func(t *testing.T) {
+			reader := &stubSourceReader{}
+			b := newInMemoryBuilder(t, reportTypes)
+			b.fileReader = reader
+
+			files, err := b.CreateReportInMemory(report)
+			if err != nil {
+				t.Fatalf("CreateReportInMemory returned error: %v", err)
+			}
+
+			if len(reader.reads) > 0 {
+				t.Errorf("expected no source file to be read, read %v", reader.reads)
+			}
+			var content []byte
+			for name, fileContent := range files {
+				if name == "DemoCalc.html" || strings.HasPrefix(name, "classdetails/") {
+					content = fileContent
+				}
+			}
+			if !bytes.Contains(content, []byte("Add(int")) {
+				t.Error("expected the class details to contain the source code")
+			}
+		})
+	}
+}
+
+// TestCreateReport_SourceFromDiskIfCoverageDataIsIncomplete expects the source file to be
+// read once per class if the coverage data does not hold all of its lines.
+func TestCreateReport_SourceFromDiskIfCoverageDataIsIncomplete(t *testing.T) {
+	report := storedSourceReport([]model.Line{{Number: 2, Hits: 3, Content: "  int Add(int a, int b) => a + b;"}})
+	reader := &stubSourceReader{files: map[string][]string{
+		"/gone/src/Calc.cs": {"class Calc {", "  int Add(int a, int b) => a + b;", "  int Sub(int a, int b) => a - b;", "}"},
+	}}
+	b := newInMemoryBuilder(t, "Html")
+	b.fileReader = reader
+
+	files, err := b.CreateReportInMemory(report)
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+
+	if len(reader.reads) != 1 {
+		t.Errorf("expected the source file to be read once, read %v", reader.reads)
+	}
+	if !bytes.Contains(files["DemoCalc.html"], []byte("int Sub(int a, int b)")) {
+		t.Error("expected the class page to contain the source code read from disk")
+	}
+}
+
+// TestCreateReport_ExternalFileBadge expects the files outside the source directories to
+// be marked on the class page if the report has external files.
+func TestCreateReport_ExternalFileBadge(t *testing.T) {
+	for _, external := range []bool{false, true} {
+		t.Run(fmt.Sprintf("external=%v", external), func(t *testing.T) {
+			report := storedSourceReport([]model.Line{{Number: 1, Hits: 1, Content: "class Calc { }"}})
+			report.Assemblies[0].Classes[0].Files[0].InSourceDirs = !external
+			if external {
+				report.ExternalFiles = 1
+			}
+			b := newInMemoryBuilder(t, "Html")
+
+			files, err := b.CreateReportInMemory(report)
+			if err != nil {
+				t.Fatalf("CreateReportInMemory returned error: %v", err)
+			}
+
+			badge := `<span class="external"`
+			if got := bytes.Contains(files["DemoCalc.html"], []byte(badge)); got != external {
+				t.Errorf("class page contains the external badge = %v, want %v", got, external)
+			}
+		})
+	}
+}
+
+// TestCreateReport_SyntaxHighlight expects the source code of class pages to be colored
+// with -syntaxhighlight, escaped like plain lines, and plain without the flag.
+func TestCreateReport_SyntaxHighlight(t *testing.T) {
+	report := storedSourceReport([]model.Line{
+		{Number: 1, Hits: -1, Content: "class Calc {"},
+		{Number: 2, Hits: 3, Content: `	string Html() => "<script>alert('&')</script>"; // <b>`},
+		{Number: 3, Hits: -1, Content: "}"},
+	})
+
+	b := newInMemoryBuilder(t, "Html")
+	b.ReportContext.Settings().SyntaxHighlight = true
+	files, err := b.CreateReportInMemory(report)
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+	page := string(files["DemoCalc.html"])
+	want := `<td class="lightgreen"><code>&nbsp;&nbsp;&nbsp;&nbsp;<span class="hlkeyword">string</span>&nbsp;Html()&nbsp;=&gt;&nbsp;` +
+		`<span class="hlstring">&#34;&lt;script&gt;alert(&#39;&amp;&#39;)&lt;/script&gt;&#34;</span>;&nbsp;<span class="hlcomment">//&nbsp;&lt;b&gt;</span></code></td>`
+	if !strings.Contains(page, want) {
+		t.Errorf("expected the highlighted line %s in the class page", want)
+	}
+	if strings.Contains(page, "<script>alert") || strings.Contains(page, "<b>") {
+		t.Error("expected the source code to be escaped")
+	}
+
+	plain := string(renderInMemory(t, "Html", report)["DemoCalc.html"])
+	if strings.Contains(plain, "hlkeyword") {
+		t.Error("expected no syntax colors without -syntaxhighlight")
+	}
+	if !strings.Contains(plain, `<code>&nbsp;&nbsp;&nbsp;&nbsp;string&nbsp;Html()`) {
+		t.Error("expected the plain line in the class page")
+	}
+}
+
+// TestBuildFileViewModelForServerRender_UnknownLanguageIsPlain expects files without a
+// lexer to be rendered plain with -syntaxhighlight.
+func TestBuildFileViewModelForServerRender_UnknownLanguageIsPlain(t *testing.T) {
+	sourcePath := filepath.Join(t.TempDir(), "Calc.fs")
+	if err := os.WriteFile(sourcePath, []byte("let add a b = a + b // \"<x>\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write source file: %v", err)
+	}
+	b := newTestSummaryBuilder()
+	b.syntaxHighlight = true
+
+	fileVM, _, err := b.buildFileViewModelForServerRender(&model.CodeFile{Path: sourcePath}, nil)
+	if err != nil {
+		t.Fatalf("buildFileViewModelForServerRender returned error: %v", err)
+	}
+	if got := fileVM.Lines[0].HighlightedContent; got != "" {
+		t.Errorf("expected no highlighted content, got %q", got)
+	}
+}
+
+// TestCreateReport_TruncatesLongLines expects a line of 100,000 characters to be cut to
+// the maximum line length, so the class page stays small, and its coverage to be kept.
+func TestCreateReport_TruncatesLongLines(t *testing.T) {
+	longLine := "var x = [" + strings.Repeat("1, ", 33330) + "];"
+	lines := []model.Line{
+		{Number: 1, Hits: -1, Content: "class Calc {"},
+		{Number: 2, Hits: 3, Content: longLine},
+		{Number: 3, Hits: 0, Content: "  int Sub(int a, int b) => a - b;"},
+	}
+	// Enough short lines that the file does not count as minified.
+	for n := 4; n <= 250; n++ {
+		lines = append(lines, model.Line{Number: n, Hits: -1, Content: "  // comment"})
+	}
+	report := storedSourceReport(lines)
+	shortLines := append([]model.Line(nil), lines...)
+	shortLines[1].Content = "var x = [1];"
+	shortLineReport := storedSourceReport(shortLines)
+
+	page := string(renderInMemory(t, "Html", report)["DemoCalc.html"])
+
+	// The line adds its 2,000 shown characters twice, to the table and to the class data.
+	shortLinePage := renderInMemory(t, "Html", shortLineReport)["DemoCalc.html"]
+	if growth := len(page) - len(shortLinePage); growth > 5_000 {
+		t.Errorf("the long line adds %d bytes to the class page, want at most 5000", growth)
+	}
+	wantTitle := fmt.Sprintf(`<code title="Line truncated: 2000 of %d characters shown">var x = [1, 1, `, len(longLine))
+	if !strings.Contains(page, wantTitle) {
+		t.Errorf("expected the truncated line with %s", wantTitle)
+	}
+	if !strings.Contains(page, "1, 1,…</code>") {
+		t.Error("expected the truncated line to end with an ellipsis")
+	}
+	if !strings.Contains(page, "<code>&nbsp;&nbsp;int&nbsp;Sub(int&nbsp;a,&nbsp;int&nbsp;b)") {
+		t.Error("expected the short lines to be rendered as before")
+	}
+	if !strings.Contains(page, `title="Covered (3 visits)"`) {
+		t.Error("expected the coverage of the truncated line to be kept")
+	}
+
+	b := newInMemoryBuilder(t, "Html")
+	b.ReportContext.Settings().MaximumLineLength = 0
+	files, err := b.CreateReportInMemory(report)
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+	if unlimited := string(files["DemoCalc.html"]); strings.Contains(unlimited, `<code title=`) || !strings.Contains(unlimited, "1,&nbsp;1,&nbsp;];</code>") {
+		t.Error("expected the whole line without a maximum line length")
+	}
+}
+
+// TestCreateReport_MinifiedFile expects the lines of a minified file to be rendered
+// without their code, with a notice, and with their coverage.
+func TestCreateReport_MinifiedFile(t *testing.T) {
+	chunk := strings.Repeat("function a(b){return b+1};", 40)
+	report := storedSourceReport([]model.Line{
+		{Number: 1, Hits: 2, Content: chunk},
+		{Number: 2, Hits: 0, Content: chunk},
+		{Number: 3, Hits: -1, Content: ""},
+	})
+
+	for _, reportTypes := range []string{"Html", "Html{classdetails=ondemand}"} {
+		t.Run(reportTypes, func(t *testing.T) {
+			files := renderInMemory(t, reportTypes, report)
+
+			var page, details []byte
+			for name, content := range files {
+				switch {
+				case name == "DemoCalc.html":
+					page = content
+				case strings.HasPrefix(name, "classdetails/"):
+					details = content
+				}
+			}
+			if bytes.Contains(page, []byte("function a(b)")) || bytes.Contains(details, []byte("function a(b)")) {
+				t.Error("expected the code of the minified file to be left out")
+			}
+			if reportTypes == "Html" {
+				if !bytes.Contains(page, []byte(`<p class="minifiedfile">The lines of this file are too long to be shown`)) {
+					t.Error("expected a notice that the code of the file is not shown")
+				}
+				for _, title := range []string{`title="Covered (2 visits)"`, `title="Not covered (0 visits)"`} {
+					if !bytes.Contains(page, []byte(title)) {
+						t.Errorf("expected the line with %s", title)
+					}
+				}
+			}
+		})
+	}
+
+	b := newInMemoryBuilder(t, "Html")
+	b.ReportContext.Settings().MinifiedAverageLineLength = 2000
+	files, err := b.CreateReportInMemory(report)
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+	if !bytes.Contains(files["DemoCalc.html"], []byte("function&nbsp;a(b)")) {
+		t.Error("expected the code to be shown below the configured minified line length")
+	}
+}
+
+// TestCreateReport_ClassPageAccessibility expects the status of covered, uncovered and
+// partially covered lines to be readable without colors, and the percentage bars and
+// tables of the class page to carry their ARIA attributes.
+func TestCreateReport_ClassPageAccessibility(t *testing.T) {
+	report := storedSourceReport([]model.Line{
+		{Number: 1, Hits: 3, Content: "covered();"},
+		{Number: 2, Hits: 0, Content: "uncovered();"},
+		{Number: 3, Hits: 2, Content: "if (partial) {", IsBranchPoint: true, CoveredBranches: 1, TotalBranches: 2},
+		{Number: 4, Hits: -1, Content: "}"},
+	})
+	quota := 66.6
+	file := &report.Assemblies[0].Classes[0].Files[0]
+	file.CodeElements = []model.CodeElement{{Name: "Run()", FullName: "Run()", Type: model.MethodElementType, FirstLine: 1, LastLine: 4, CoverageQuota: &quota}}
+
+	page := string(renderInMemory(t, "Html", report)["DemoCalc.html"])
+
+	for _, want := range []string{
+		`<td class="green"><span class="sr-only">Covered, 3 visits</span></td>`,
+		`<td class="red"><span class="sr-only">Not covered, 0 visits</span></td>`,
+		`<td class="orange"><span class="sr-only">Partially covered, 2 visits, 1 of 2 branches covered</span></td>`,
+		`<td class="gray"> </td>`,
+		`<td class="percentagebar percentagebar50" aria-label="1 of 2 branches covered"><i class="icon-fork" aria-hidden="true"></i></td>`,
+		`role="progressbar" aria-valuemin="0" aria-valuemax="100" aria-valuenow="66.6" aria-label="Line coverage"`,
+		`<caption class="sr-only">Line coverage: /gone/src/Calc.cs</caption>`,
+		`<th scope="col">#</th>`,
+		`<th scope="row">Covered lines:</th>`,
+		`aria-label="Line coverage: 66.6% - Run()"><i class="icon-cube" aria-hidden="true"></i>Run()</a>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected %s in the class page", want)
+		}
+	}
+}
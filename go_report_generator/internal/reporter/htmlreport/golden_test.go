@@ -0,0 +1,208 @@
+package htmlreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// goldenReport is a small model with one fully covered and one partially covered method.
+func goldenReport() *model.SummaryResult {
+	branchRate := 0.5
+	branchesCovered, branchesValid := 1, 2
+	lines := []model.Line{
+		{Number: 7, Hits: 4, LineVisitStatus: model.Covered},
+		{Number: 12, Hits: 2, IsBranchPoint: true, CoveredBranches: 1, TotalBranches: 2, LineVisitStatus: model.PartiallyCovered,
+			Branch: []model.BranchCoverageDetail{{Identifier: "0", Visits: 2, Type: "jump"}, {Identifier: "1", Visits: 0, Type: "jump"}}},
+		{Number: 13, Hits: 0, LineVisitStatus: model.NotCovered},
+	}
+	class := model.Class{
+		Name:            "Demo.Calc",
+		DisplayName:     "Demo.Calc",
+		LinesCovered:    2,
+		LinesValid:      3,
+		BranchesCovered: &branchesCovered,
+		BranchesValid:   &branchesValid,
+		TotalLines:      16,
+		Files: []model.CodeFile{{
+			Path:           "testdata/Calc.cs",
+			Lines:          lines,
+			CoveredLines:   2,
+			CoverableLines: 3,
+			TotalLines:     16,
+			CodeElements: []model.CodeElement{
+				{Name: "Add(int, int)", FullName: "Add(int, int)", Type: model.MethodElementType, FirstLine: 5, LastLine: 8},
+				{Name: "Div(int, int)", FullName: "Div(int, int)", Type: model.MethodElementType, FirstLine: 10, LastLine: 14},
+			},
+		}},
+		Methods: []model.Method{
+			{Name: "Add", Signature: "(int, int)", DisplayName: "Add(int, int)", LineRate: 1, FirstLine: 5, LastLine: 8, Lines: lines[:1]},
+			{Name: "Div", Signature: "(int, int)", DisplayName: "Div(int, int)", LineRate: 0.5, BranchRate: &branchRate, FirstLine: 10, LastLine: 14, Lines: lines[1:]},
+		},
+	}
+	return &model.SummaryResult{
+		ParserName:      "Cobertura",
+		Timestamp:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Unix(),
+		LinesCovered:    2,
+		LinesValid:      3,
+		BranchesCovered: &branchesCovered,
+		BranchesValid:   &branchesValid,
+		TotalLines:      16,
+
+		MethodCoverageAvailable: true,
+		Assemblies: []model.Assembly{{
+			Name:            "Demo",
+			Classes:         []model.Class{class},
+			LinesCovered:    2,
+			LinesValid:      3,
+			BranchesCovered: &branchesCovered,
+			BranchesValid:   &branchesValid,
+			TotalLines:      16,
+		}},
+	}
+}
+
+// TestCreateReport_Golden renders the report with a fixed clock and version and compares
+// the summary page, the class page and the assembly page with the files in testdata. Run
+// `go test ./internal/reporter/htmlreport -update` after intended template changes.
+func TestCreateReport_Golden(t *testing.T) {
+	// The coverage date is rendered in local time.
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.UTC
+
+	outputDir := t.TempDir()
+	cfg, err := reportconfig.NewReportConfiguration(nil, outputDir)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	fixedTime := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	ctx := reporter.NewBuilderContext(cfg, settings.NewSettings(), nil,
+		reporter.WithClock(func() time.Time { return fixedTime }),
+		reporter.WithAppVersion("1.0.0-test"),
+		reporter.WithCommandLine("ReportGenerator -reports=coverage.xml -reporttypes=Html"),
+	)
+
+	if err := NewHtmlReportBuilder(outputDir, ctx).CreateReport(goldenReport()); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+
+	for _, page := range []struct{ output, golden string }{
+		{"index.html", "index.html.golden"},
+		{"DemoCalc.html", "class.html.golden"},
+		{"assembly_Demo.html", "assembly.html.golden"},
+	} {
+		t.Run(page.output, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join(outputDir, page.output))
+			if err != nil {
+				t.Fatalf("failed to read generated page: %v", err)
+			}
+			got = normalizeEmbeddedJSON(t, got)
+
+			goldenPath := filepath.Join("testdata", page.golden)
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s differs from %s; run with -update and review the diff", page.output, goldenPath)
+			}
+		})
+	}
+}
+
+// embeddedJSONRegex matches the data assigned to window.* variables in the pages.
+var embeddedJSONRegex = regexp.MustCompile(`(?m)^(\s*window\.\w+\s*=\s*)(.*)$`)
+
+// normalizeEmbeddedJSON re-encodes the embedded window.* JSON with sorted object keys,
+// so that the golden files do not depend on the field order of the view models.
+func normalizeEmbeddedJSON(t *testing.T, page []byte) []byte {
+	t.Helper()
+	return embeddedJSONRegex.ReplaceAllFunc(page, func(match []byte) []byte {
+		parts := embeddedJSONRegex.FindSubmatch(match)
+		value := bytes.TrimRight(parts[2], " ;\r")
+		prefix, suffix := "", ""
+		if inner, ok := bytes.CutPrefix(value, []byte("JSON.parse(")); ok && bytes.HasSuffix(inner, []byte(")")) {
+			value, prefix, suffix = inner[:len(inner)-1], "JSON.parse(", ")"
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return match // Not JSON, e.g. a function call
+		}
+		normalized, err := json.Marshal(decoded) // Maps are encoded with sorted keys
+		if err != nil {
+			t.Fatalf("failed to re-encode embedded JSON: %v", err)
+		}
+
+		var out bytes.Buffer
+		out.Write(parts[1])
+		out.WriteString(prefix)
+		out.Write(normalized)
+		out.WriteString(suffix + ";")
+		return out.Bytes()
+	})
+}
+
+// TestCreateReportInMemory_MatchesCreateReport expects the in-memory rendering to return
+// exactly the files CreateReport writes to the output directory.
+func TestCreateReportInMemory_MatchesCreateReport(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg, err := reportconfig.NewReportConfiguration(nil, outputDir)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	fixedTime := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	ctx := reporter.NewBuilderContext(cfg, settings.NewSettings(), nil,
+		reporter.WithClock(func() time.Time { return fixedTime }),
+	)
+
+	if err := NewHtmlReportBuilder(outputDir, ctx).CreateReport(goldenReport()); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+	files, err := NewHtmlReportBuilder(outputDir, ctx).CreateReportInMemory(goldenReport())
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+
+	written := 0
+	err = filepath.WalkDir(outputDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		written++
+		name, _ := filepath.Rel(outputDir, path)
+		want, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if got, ok := files[filepath.ToSlash(name)]; !ok {
+			t.Errorf("%s is missing from the in-memory report", name)
+		} else if !bytes.Equal(got, want) {
+			t.Errorf("%s differs between the in-memory and the written report", name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk output directory: %v", err)
+	}
+	if written != len(files) {
+		t.Errorf("expected %d in-memory files, got %d", written, len(files))
+	}
+}
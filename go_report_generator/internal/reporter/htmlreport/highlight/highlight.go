@@ -0,0 +1,211 @@
+// Package highlight splits source lines into keyword, string, comment and number tokens
+// for the syntax colors of the Html report. The lexers are deliberately small: they know
+// the keywords, comments and string literals of a language, which is enough to make
+// long files easier to scan, but they do not parse the code.
+package highlight
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind is the kind of a token.
+type Kind int
+
+const (
+	Text Kind = iota
+	Keyword
+	String
+	Comment
+	Number
+)
+
+// Class returns the CSS class of the token kind, "" for Text.
+func (k Kind) Class() string {
+	switch k {
+	case Keyword:
+		return "hlkeyword"
+	case String:
+		return "hlstring"
+	case Comment:
+		return "hlcomment"
+	case Number:
+		return "hlnumber"
+	}
+	return ""
+}
+
+// Token is a piece of a source line. The texts of the tokens of a line add up to the line.
+type Token struct {
+	Kind Kind
+	Text string
+}
+
+// stringLiteral describes how a string or character literal starts and ends.
+type stringLiteral struct {
+	open, close string
+	backslash   bool // A backslash escapes the next character
+	doubled     bool // A doubled closing delimiter stands for itself, e.g. "" in C# @"..."
+	multiline   bool // The literal may span lines, e.g. Go `raw strings`
+}
+
+// Lexer tokenizes the lines of the files of one language.
+type Lexer struct {
+	keywords     map[string]struct{}
+	lineComment  string
+	blockComment [2]string // Empty if the language has none
+	strings      []stringLiteral
+}
+
+// ForFile returns the lexer for the extension of path, or nil if the language is not
+// known, in which case the lines are rendered without colors.
+func ForFile(path string) *Lexer {
+	return lexersByExtension[strings.ToLower(filepath.Ext(path))]
+}
+
+// state is carried from one line to the next by comments and strings that span lines.
+type state struct {
+	inComment bool
+	literal   *stringLiteral
+}
+
+// Tokenize splits the lines of a file into tokens.
+func (l *Lexer) Tokenize(lines []string) [][]Token {
+	result := make([][]Token, len(lines))
+	var s state
+	for i, line := range lines {
+		result[i], s = l.tokenizeLine(line, s)
+	}
+	return result
+}
+
+func (l *Lexer) tokenizeLine(line string, s state) ([]Token, state) {
+	var tokens []Token
+	// Consecutive pieces of the same kind are collected into one token.
+	var pending strings.Builder
+	pendingKind := Text
+	flush := func() {
+		if pending.Len() > 0 {
+			tokens = append(tokens, Token{Kind: pendingKind, Text: pending.String()})
+			pending.Reset()
+		}
+	}
+	emit := func(kind Kind, text string) {
+		if text == "" {
+			return
+		}
+		if kind != pendingKind {
+			flush()
+			pendingKind = kind
+		}
+		pending.WriteString(text)
+	}
+
+	pos := 0
+scan:
+	for pos < len(line) {
+		rest := line[pos:]
+		switch {
+		case s.inComment:
+			end := strings.Index(rest, l.blockComment[1])
+			if end < 0 {
+				emit(Comment, rest)
+				break scan
+			}
+			end += len(l.blockComment[1])
+			emit(Comment, rest[:end])
+			pos += end
+			s.inComment = false
+		case s.literal != nil:
+			end, closed := s.literal.end(rest)
+			emit(String, rest[:end])
+			pos += end
+			if !closed {
+				if !s.literal.multiline {
+					s.literal = nil
+				}
+				break scan
+			}
+			s.literal = nil
+		case l.lineComment != "" && strings.HasPrefix(rest, l.lineComment):
+			emit(Comment, rest)
+			break scan
+		case l.blockComment[0] != "" && strings.HasPrefix(rest, l.blockComment[0]):
+			emit(Comment, l.blockComment[0])
+			pos += len(l.blockComment[0])
+			s.inComment = true
+		default:
+			if literal := l.stringStart(rest); literal != nil {
+				emit(String, literal.open)
+				pos += len(literal.open)
+				s.literal = literal
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(rest)
+			switch {
+			case r == '_' || unicode.IsLetter(r):
+				end := strings.IndexFunc(rest, func(r rune) bool { return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+				if end < 0 {
+					end = len(rest)
+				}
+				word := rest[:end]
+				if _, ok := l.keywords[word]; ok {
+					emit(Keyword, word)
+				} else {
+					emit(Text, word)
+				}
+				pos += end
+			case r >= '0' && r <= '9':
+				end := strings.IndexFunc(rest, func(r rune) bool { return r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+				if end < 0 {
+					end = len(rest)
+				}
+				emit(Number, rest[:end])
+				pos += end
+			default:
+				emit(Text, rest[:size])
+				pos += size
+			}
+		}
+	}
+	if s.literal != nil && !s.literal.multiline {
+		s.literal = nil
+	}
+	flush()
+	return tokens, s
+}
+
+// stringStart returns the literal starting at the beginning of text, preferring the
+// longest opening delimiter, or nil.
+func (l *Lexer) stringStart(text string) *stringLiteral {
+	var found *stringLiteral
+	for i := range l.strings {
+		literal := &l.strings[i]
+		if strings.HasPrefix(text, literal.open) && (found == nil || len(literal.open) > len(found.open)) {
+			found = literal
+		}
+	}
+	return found
+}
+
+// end returns the length of the literal at the start of text, including the closing
+// delimiter, and whether the literal is closed on this line.
+func (lit *stringLiteral) end(text string) (int, bool) {
+	for i := 0; i < len(text); i++ {
+		if lit.backslash && text[i] == '\\' {
+			i++
+			continue
+		}
+		if !strings.HasPrefix(text[i:], lit.close) {
+			continue
+		}
+		if lit.doubled && strings.HasPrefix(text[i+len(lit.close):], lit.close) {
+			i += 2*len(lit.close) - 1
+			continue
+		}
+		return i + len(lit.close), true
+	}
+	return len(text), false
+}
@@ -0,0 +1,95 @@
+package highlight
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestForFile(t *testing.T) {
+	if ForFile("/src/main.go") != goLexer || ForFile(`C:\src\Program.CS`) != csharpLexer || ForFile("app.ts") != genericLexer {
+		t.Error("expected the lexers to be selected by the extension, ignoring its case")
+	}
+	for _, path := range []string{"README.md", "Program.fs", "Makefile"} {
+		if ForFile(path) != nil {
+			t.Errorf("expected no lexer for %s", path)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name  string
+		lexer *Lexer
+		lines []string
+		want  [][]Token
+	}{
+		{
+			name:  "Go",
+			lexer: goLexer,
+			lines: []string{`	if n > 10 { return "a\"b" } // done`},
+			want: [][]Token{{
+				{Text, "\t"}, {Keyword, "if"}, {Text, " n > "}, {Number, "10"}, {Text, " { "}, {Keyword, "return"},
+				{Text, " "}, {String, `"a\"b"`}, {Text, " } "}, {Comment, "// done"},
+			}},
+		},
+		{
+			name:  "IdentifiersContainingKeywords",
+			lexer: goLexer,
+			lines: []string{"format(x1, iffy)"},
+			want:  [][]Token{{{Text, "format(x1, iffy)"}}},
+		},
+		{
+			name:  "GoRawStringSpanningLines",
+			lexer: goLexer,
+			lines: []string{"s := `first", `"second"`, "third` + x"},
+			want: [][]Token{
+				{{Text, "s := "}, {String, "`first"}},
+				{{String, `"second"`}},
+				{{String, "third`"}, {Text, " + x"}},
+			},
+		},
+		{
+			name:  "BlockCommentSpanningLines",
+			lexer: csharpLexer,
+			lines: []string{"int a; /* start", "middle */ return a;"},
+			want: [][]Token{
+				{{Keyword, "int"}, {Text, " a; "}, {Comment, "/* start"}},
+				{{Comment, "middle */"}, {Text, " "}, {Keyword, "return"}, {Text, " a;"}},
+			},
+		},
+		{
+			name:  "CSharpVerbatimString",
+			lexer: csharpLexer,
+			lines: []string{`var p = @"C:\dir\""quoted""";`},
+			want:  [][]Token{{{Keyword, "var"}, {Text, " p = "}, {String, `@"C:\dir\""quoted"""`}, {Text, ";"}}},
+		},
+		{
+			name:  "UnterminatedStringEndsWithTheLine",
+			lexer: genericLexer,
+			lines: []string{`s = "open`, "return s;"},
+			want: [][]Token{
+				{{Text, "s = "}, {String, `"open`}},
+				{{Keyword, "return"}, {Text, " s;"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.lexer.Tokenize(tc.lines)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			for i, tokens := range got {
+				var text strings.Builder
+				for _, token := range tokens {
+					text.WriteString(token.Text)
+				}
+				if text.String() != tc.lines[i] {
+					t.Errorf("tokens of line %d add up to %q, want %q", i+1, text.String(), tc.lines[i])
+				}
+			}
+		})
+	}
+}
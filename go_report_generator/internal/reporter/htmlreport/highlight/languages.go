@@ -0,0 +1,74 @@
+package highlight
+
+import "strings"
+
+// newLexer creates a lexer with the C style comments and the given keywords and literals.
+func newLexer(keywords string, literals ...stringLiteral) *Lexer {
+	l := &Lexer{
+		keywords:     make(map[string]struct{}),
+		lineComment:  "//",
+		blockComment: [2]string{"/*", "*/"},
+		strings:      literals,
+	}
+	for _, keyword := range strings.Fields(keywords) {
+		l.keywords[keyword] = struct{}{}
+	}
+	return l
+}
+
+var (
+	quoted    = stringLiteral{open: `"`, close: `"`, backslash: true}
+	character = stringLiteral{open: "'", close: "'", backslash: true}
+
+	goLexer = newLexer(`break case chan const continue default defer else fallthrough for func go goto if
+		import interface map package range return select struct switch type var
+		any bool byte complex64 complex128 error float32 float64 int int8 int16 int32 int64 rune string
+		uint uint8 uint16 uint32 uint64 uintptr true false nil iota`,
+		quoted, character,
+		stringLiteral{open: "`", close: "`", multiline: true},
+	)
+
+	csharpLexer = newLexer(`abstract as base bool break byte case catch char checked class const continue decimal
+		default delegate do double else enum event explicit extern false finally fixed float for foreach goto
+		if implicit in int interface internal is lock long namespace new null object operator out override
+		params private protected public readonly ref return sbyte sealed short sizeof stackalloc static string
+		struct switch this throw true try typeof uint ulong unchecked unsafe ushort using virtual void volatile
+		while async await var dynamic get set init value yield record when where nameof`,
+		quoted, character,
+		stringLiteral{open: `@"`, close: `"`, doubled: true, multiline: true},
+		stringLiteral{open: `$@"`, close: `"`, doubled: true, multiline: true},
+		stringLiteral{open: `@$"`, close: `"`, doubled: true, multiline: true},
+		stringLiteral{open: `"""`, close: `"""`, multiline: true},
+	)
+
+	// genericLexer covers the other languages with C style comments and strings, with the
+	// keywords most of them share.
+	genericLexer = newLexer(`abstract break case catch char class const continue default delete do double else enum
+		export extends extern false final finally float for function if implements import in instanceof int
+		interface let long namespace new null package private protected public return short signed static struct
+		super switch template this throw true try typedef typeof union unsigned var void volatile while`,
+		quoted, character,
+		stringLiteral{open: "`", close: "`", backslash: true, multiline: true},
+	)
+
+	lexersByExtension = map[string]*Lexer{
+		".go":    goLexer,
+		".cs":    csharpLexer,
+		".c":     genericLexer,
+		".h":     genericLexer,
+		".cc":    genericLexer,
+		".cpp":   genericLexer,
+		".cxx":   genericLexer,
+		".hpp":   genericLexer,
+		".java":  genericLexer,
+		".js":    genericLexer,
+		".jsx":   genericLexer,
+		".mjs":   genericLexer,
+		".ts":    genericLexer,
+		".tsx":   genericLexer,
+		".kt":    genericLexer,
+		".scala": genericLexer,
+		".swift": genericLexer,
+		".dart":  genericLexer,
+	}
+)
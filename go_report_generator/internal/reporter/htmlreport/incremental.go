@@ -0,0 +1,276 @@
+package htmlreport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// manifestFilename is the file in the output directory that holds the content hashes of
+// the class pages written by the last incremental run.
+const manifestFilename = "reportgenerator-manifest.json"
+
+// manifestVersion is increased whenever the hashed inputs change in a way the shared hash
+// does not cover, so that manifests of older versions rebuild all pages.
+const manifestVersion = 1
+
+// pageManifest records the class pages of an incremental report.
+type pageManifest struct {
+	Version int `json:"version"`
+	// SharedHash is the hash of the data that every class page embeds, see sharedPageHash.
+	SharedHash string `json:"sharedHash"`
+	// Pages holds the pages keyed by their slash-separated path relative to the report root.
+	Pages map[string]pageManifestEntry `json:"pages"`
+}
+
+// pageManifestEntry is a class page of the manifest.
+type pageManifestEntry struct {
+	Class string `json:"class"`
+	// Hash covers the class detail JSON of the page and the shared hash.
+	Hash string `json:"hash"`
+}
+
+// PersistentOutputFS is an OutputFS that keeps the files of earlier runs, which the
+// incremental mode needs to find the unchanged pages and delete stale ones. OSOutputFS
+// implements it; with other filesystems all pages are written.
+type PersistentOutputFS interface {
+	OutputFS
+
+	// ReadFile returns the content of the named file.
+	ReadFile(path string) ([]byte, error)
+
+	// Exists reports whether the named file exists.
+	Exists(path string) bool
+
+	// Remove deletes the named file.
+	Remove(path string) error
+}
+
+// ReadFile reads the named file using os.ReadFile.
+func (OSOutputFS) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(utils.LongPath(path))
+}
+
+// Exists reports whether the named file exists using os.Stat.
+func (OSOutputFS) Exists(path string) bool {
+	_, err := os.Stat(utils.LongPath(path))
+	return err == nil
+}
+
+// Remove deletes the named file using os.Remove.
+func (OSOutputFS) Remove(path string) error {
+	return os.Remove(utils.LongPath(path))
+}
+
+// incrementalState tracks the class pages of one incremental run.
+type incrementalState struct {
+	fs         PersistentOutputFS
+	sharedHash string
+	previous   map[string]pageManifestEntry // Pages of the last run, nil if it rebuilt nothing
+	current    map[string]pageManifestEntry
+	skipped    int
+}
+
+// startIncremental loads the manifest of the last run if the incremental mode is enabled
+// and the report is written to a PersistentOutputFS. A missing or unreadable manifest
+// renders all pages.
+func (b *HtmlReportBuilder) startIncremental() error {
+	b.incrementalState = nil
+	if !b.incremental {
+		return nil
+	}
+	persistentFS, ok := b.outputFS().(PersistentOutputFS)
+	if !ok {
+		b.logger().Debug("Output filesystem keeps no files of earlier runs, rendering all class pages")
+		return nil
+	}
+	sharedHash, err := b.sharedPageHash()
+	if err != nil {
+		return err
+	}
+
+	state := &incrementalState{
+		fs:         persistentFS,
+		sharedHash: sharedHash,
+		current:    make(map[string]pageManifestEntry),
+	}
+	content, err := persistentFS.ReadFile(filepath.Join(b.OutputDir, manifestFilename))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		b.logger().Warn("Failed to read the manifest of the last run, rendering all class pages", "error", err)
+	default:
+		var manifest pageManifest
+		if err := json.Unmarshal(content, &manifest); err != nil {
+			b.logger().Warn("Invalid manifest of the last run, rendering all class pages", "error", err)
+		} else if manifest.Version == manifestVersion {
+			state.previous = manifest.Pages
+		}
+	}
+	b.incrementalState = state
+	return nil
+}
+
+// sharedPageHash hashes the data, besides the class itself, that affects the content of the
+// class pages: the settings, translations, tag and the window.* data every page embeds.
+// The date of the report is left out, so unchanged pages keep the date of their last run.
+func (b *HtmlReportBuilder) sharedPageHash() (string, error) {
+	inputs := struct {
+		ReportType                         string
+		AppVersion                         string
+		CommandLine                        string
+		ReportTitle                        string
+		Tag                                string
+		TagLink                            string
+		BranchCoverageAvailable            bool
+		MethodCoverageAvailable            bool
+		DecimalPlacesForCoverageQuotas     int
+		DecimalPlacesForPercentageDisplay  int
+		CoverageQuotaRoundingMode          string
+		UncoveredLinesClassLimit           int
+		SyntaxHighlight                    bool
+		MaximumLineLength                  int
+		MinifiedAverageLineLength          int
+		MaximumHistoricCoveragesPerClass   int
+		ClassDetailsOnDemand               bool
+		Translations                       map[string]string
+		AngularFiles                       []string
+		RiskHotspotsJSON                   string
+		MetricsJSON                        string
+		RiskHotspotMetricsJSON             string
+		HistoricCoverageExecutionTimesJSON string
+	}{
+		ReportType:                         b.ReportType(),
+		AppVersion:                         b.appVersion,
+		CommandLine:                        b.commandLine,
+		ReportTitle:                        b.reportTitle,
+		Tag:                                b.tag,
+		TagLink:                            b.tagLink,
+		BranchCoverageAvailable:            b.branchCoverageAvailable,
+		MethodCoverageAvailable:            b.methodCoverageAvailable,
+		DecimalPlacesForCoverageQuotas:     b.maximumDecimalPlacesForCoverageQuotas,
+		DecimalPlacesForPercentageDisplay:  b.maximumDecimalPlacesForPercentageDisplay,
+		CoverageQuotaRoundingMode:          b.coverageQuotaRoundingMode.String(),
+		UncoveredLinesClassLimit:           b.uncoveredLinesClassLimit,
+		SyntaxHighlight:                    b.syntaxHighlight,
+		MaximumLineLength:                  b.maximumLineLength,
+		MinifiedAverageLineLength:          b.minifiedAverageLineLength,
+		MaximumHistoricCoveragesPerClass:   b.maximumHistoricCoveragesPerClass,
+		ClassDetailsOnDemand:               b.classDetailsOnDemand,
+		Translations:                       b.translations,
+		AngularFiles:                       []string{b.angularCssFile, b.combinedAngularJsFile, b.angularRuntimeJsFile, b.angularPolyfillsJsFile, b.angularMainJsFile},
+		RiskHotspotsJSON:                   string(b.riskHotspotsJSON),
+		MetricsJSON:                        string(b.metricsJSON),
+		RiskHotspotMetricsJSON:             string(b.riskHotspotMetricsJSON),
+		HistoricCoverageExecutionTimesJSON: string(b.historicCoverageExecutionTimesJSON),
+	}
+	content, err := json.Marshal(inputs)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash the shared page data: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// classPageUnchanged records the hash of a class page for the manifest and reports whether
+// the page written by the last run has the same hash, so that rendering it can be skipped.
+// pageData is the data the page is rendered from: the window.classDetails data, which
+// holds the lines, coverage and metrics of the class, and for the server-rendered pages
+// the coverage by test, see classTestCoverage.
+func (b *HtmlReportBuilder) classPageUnchanged(class, classReportFilename string, pageData ...[]byte) bool {
+	state := b.incrementalState
+	if state == nil {
+		return false
+	}
+	hash := sha256.New()
+	hash.Write([]byte(state.sharedHash))
+	for _, data := range pageData {
+		hash.Write([]byte{0})
+		hash.Write(data)
+	}
+	entry := pageManifestEntry{Class: class, Hash: hex.EncodeToString(hash.Sum(nil))}
+	state.current[classReportFilename] = entry
+
+	previous, ok := state.previous[classReportFilename]
+	if !ok || previous != entry {
+		return false
+	}
+	if !state.fs.Exists(filepath.Join(b.OutputDir, filepath.FromSlash(classReportFilename))) {
+		return false
+	}
+	state.skipped++
+	return true
+}
+
+// classTestCoverage returns the coverage by test of a server-rendered class page, which
+// the window.classDetails data does not hold: the tests of the selector and the
+// data-coverage attribute of each line.
+func classTestCoverage(classVM *ClassViewModelForDetail) ([]byte, error) {
+	if len(classVM.TestMethods) == 0 {
+		return nil, nil
+	}
+	var lines []string
+	for _, file := range classVM.Files {
+		for _, line := range file.Lines {
+			lines = append(lines, line.DataCoverage)
+		}
+	}
+	return json.Marshal(struct {
+		TestMethods []TestMethodViewModel
+		Lines       []string
+	}{classVM.TestMethods, lines})
+}
+
+// forgetClassPage removes a page whose rendering failed from the manifest, so that the
+// next run renders it again.
+func (b *HtmlReportBuilder) forgetClassPage(classReportFilename string) {
+	if b.incrementalState != nil {
+		delete(b.incrementalState.current, classReportFilename)
+	}
+}
+
+// finishIncremental deletes the pages of the last run that this run did not write, e.g.
+// of classes that disappeared, and saves the manifest of this run.
+func (b *HtmlReportBuilder) finishIncremental() error {
+	state := b.incrementalState
+	if state == nil {
+		return nil
+	}
+	b.incrementalState = nil
+
+	stale := make([]string, 0)
+	for name := range state.previous {
+		if _, ok := state.current[name]; !ok {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	for _, name := range stale {
+		// The manifest is read from the output directory, so never follow it outside of it.
+		if !filepath.IsLocal(filepath.FromSlash(name)) || name == manifestFilename {
+			b.logger().Warn("Ignoring invalid page of the manifest", "file", name)
+			continue
+		}
+		err := state.fs.Remove(filepath.Join(b.OutputDir, filepath.FromSlash(name)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			b.logger().Warn("Failed to delete stale class page", "file", name, "error", err)
+			continue
+		}
+		b.logger().Debug("Deleted stale class page", "file", name)
+	}
+	b.logger().Info("Incremental Html report", "skippedClassPages", state.skipped, "renderedClassPages", len(state.current)-state.skipped, "deletedClassPages", len(stale))
+
+	content, err := json.MarshalIndent(pageManifest{Version: manifestVersion, SharedHash: state.sharedHash, Pages: state.current}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the manifest: %w", err)
+	}
+	return b.writeOutputFile(manifestFilename, content)
+}
@@ -0,0 +1,196 @@
+package htmlreport
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+// incrementalReport returns a report with a class per entry of hits, whose single
+// coverable line has the given number of visits.
+func incrementalReport(hits map[string]int) *model.SummaryResult {
+	assembly := model.Assembly{Name: "Demo"}
+	for _, name := range []string{"Alpha", "Beta", "Gamma"} {
+		visits, ok := hits[name]
+		if !ok {
+			continue
+		}
+		file := model.NewCodeFile("/gone/src/"+name+".cs", []model.Line{
+			{Number: 1, Hits: -1, Content: "class " + name + " {"},
+			{Number: 2, Hits: visits, Content: "  void Run() { }"},
+			{Number: 3, Hits: -1, Content: "}"},
+		})
+		assembly.Classes = append(assembly.Classes, model.Class{
+			Name: "Demo." + name, DisplayName: "Demo." + name,
+			LinesCovered: file.CoveredLines, LinesValid: file.CoverableLines, TotalLines: file.TotalLines,
+			Files: []model.CodeFile{file},
+		})
+	}
+	return &model.SummaryResult{ParserName: "Cobertura", Assemblies: []model.Assembly{assembly}}
+}
+
+// buildIncremental writes an incremental Html report into dir and returns the class pages of
+// its manifest keyed by class name.
+func buildIncremental(t *testing.T, dir, tag string, report *model.SummaryResult) map[string]string {
+	t.Helper()
+	cfg, err := reportconfig.NewReportConfiguration(nil, dir, reportconfig.WithReportTypeSpecs("Html"), reportconfig.WithTag(tag))
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	appSettings := settings.NewSettings()
+	appSettings.Incremental = true
+	ctx := reporter.NewBuilderContext(cfg, appSettings, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := NewHtmlReportBuilder(dir, ctx).CreateReport(report); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, manifestFilename))
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	var manifest pageManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	pages := make(map[string]string, len(manifest.Pages))
+	for file, entry := range manifest.Pages {
+		pages[entry.Class] = file
+	}
+	return pages
+}
+
+// backdate sets the modification time of the pages to a fixed time in the past, so that
+// rewritten pages can be told apart by their modification time.
+func backdate(t *testing.T, dir string, pages map[string]string) time.Time {
+	t.Helper()
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, file := range pages {
+		if err := os.Chtimes(filepath.Join(dir, file), past, past); err != nil {
+			t.Fatalf("failed to backdate %s: %v", file, err)
+		}
+	}
+	return past
+}
+
+func modTime(t *testing.T, path string) time.Time {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat %s: %v", path, err)
+	}
+	return info.ModTime()
+}
+
+// TestCreateReport_IncrementalRewritesChangedPagesOnly builds a report twice with one class
+// changed in between and expects only the page of that class to be rewritten.
+func TestCreateReport_IncrementalRewritesChangedPagesOnly(t *testing.T) {
+	dir := t.TempDir()
+	pages := buildIncremental(t, dir, "", incrementalReport(map[string]int{"Alpha": 1, "Beta": 0, "Gamma": 2}))
+	if len(pages) != 3 {
+		t.Fatalf("manifest pages = %v, want 3 classes", pages)
+	}
+	past := backdate(t, dir, pages)
+	before := make(map[string][]byte, len(pages))
+	for class, file := range pages {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		before[class] = content
+	}
+
+	buildIncremental(t, dir, "", incrementalReport(map[string]int{"Alpha": 1, "Beta": 5, "Gamma": 2}))
+
+	for class, file := range pages {
+		path := filepath.Join(dir, file)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		changed := string(content) != string(before[class])
+		rewritten := !modTime(t, path).Equal(past)
+		want := class == "Demo.Beta"
+		if changed != want || rewritten != want {
+			t.Errorf("%s: content changed = %v, rewritten = %v, want %v", class, changed, rewritten, want)
+		}
+	}
+	if modTime(t, filepath.Join(dir, "index.html")).Equal(past) {
+		t.Error("index.html was not rewritten")
+	}
+}
+
+// TestCreateReport_IncrementalDeletesStalePages expects the page of a class that disappeared
+// to be deleted and removed from the manifest.
+func TestCreateReport_IncrementalDeletesStalePages(t *testing.T) {
+	dir := t.TempDir()
+	pages := buildIncremental(t, dir, "", incrementalReport(map[string]int{"Alpha": 1, "Beta": 0, "Gamma": 2}))
+
+	after := buildIncremental(t, dir, "", incrementalReport(map[string]int{"Alpha": 1, "Beta": 0}))
+
+	if _, err := os.Stat(filepath.Join(dir, pages["Demo.Gamma"])); !os.IsNotExist(err) {
+		t.Errorf("stale page %s still exists (err = %v)", pages["Demo.Gamma"], err)
+	}
+	if _, ok := after["Demo.Gamma"]; ok || len(after) != 2 {
+		t.Errorf("manifest pages = %v, want Demo.Alpha and Demo.Beta", after)
+	}
+	for _, class := range []string{"Demo.Alpha", "Demo.Beta"} {
+		if _, err := os.Stat(filepath.Join(dir, after[class])); err != nil {
+			t.Errorf("page of %s: %v", class, err)
+		}
+	}
+}
+
+// TestCreateReport_IncrementalSettingsChangeRewritesAllPages expects a change of the tag,
+// which every page shows, to rewrite all pages.
+func TestCreateReport_IncrementalSettingsChangeRewritesAllPages(t *testing.T) {
+	dir := t.TempDir()
+	report := incrementalReport(map[string]int{"Alpha": 1, "Beta": 0})
+	pages := buildIncremental(t, dir, "v1", report)
+	past := backdate(t, dir, pages)
+
+	buildIncremental(t, dir, "v1", report)
+	for class, file := range pages {
+		if !modTime(t, filepath.Join(dir, file)).Equal(past) {
+			t.Errorf("%s was rewritten without changes", class)
+		}
+	}
+
+	buildIncremental(t, dir, "v2", report)
+	for class, file := range pages {
+		if modTime(t, filepath.Join(dir, file)).Equal(past) {
+			t.Errorf("%s was not rewritten after the tag changed", class)
+		}
+	}
+}
+
+// TestCreateReport_IncrementalCoverageByTestChangeRewritesPage expects a page to be
+// rewritten if only the coverage by test of its lines changed, which the server-rendered
+// page shows in the test selector and the data-coverage of the lines.
+func TestCreateReport_IncrementalCoverageByTestChangeRewritesPage(t *testing.T) {
+	withTests := func(tests map[string]int) *model.SummaryResult {
+		report := incrementalReport(map[string]int{"Alpha": 1, "Beta": 2})
+		report.Assemblies[0].Classes[1].Files[0].Lines[1].LineCoverageByTestMethod = tests
+		return report
+	}
+	dir := t.TempDir()
+	pages := buildIncremental(t, dir, "", withTests(map[string]int{"Tests.First": 2}))
+	past := backdate(t, dir, pages)
+
+	buildIncremental(t, dir, "", withTests(map[string]int{"Tests.First": 1, "Tests.Second": 1}))
+
+	for class, file := range pages {
+		rewritten := !modTime(t, filepath.Join(dir, file)).Equal(past)
+		if want := class == "Demo.Beta"; rewritten != want {
+			t.Errorf("%s: rewritten = %v, want %v", class, rewritten, want)
+		}
+	}
+}
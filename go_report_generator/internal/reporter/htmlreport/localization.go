@@ -0,0 +1,156 @@
+package htmlreport
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is the language of GetTranslations. Other languages fall back to it.
+const DefaultLanguage = "en"
+
+// translationFiles holds the built-in translations besides English, one <language>.json
+// file per language with the keys of GetTranslations.
+//
+//go:embed translations/*.json
+var translationFiles embed.FS
+
+// reportedMissingTranslations remembers for which translation sets the missing keys have
+// been logged, so that regenerating a report (e.g. with -serve) logs them only once.
+var reportedMissingTranslations sync.Map
+
+// SupportedLanguages returns the codes of the languages with built-in translations, sorted.
+func SupportedLanguages() []string {
+	languages := []string{DefaultLanguage}
+	entries, _ := translationFiles.ReadDir("translations")
+	for _, entry := range entries {
+		languages = append(languages, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// ResolveLanguage maps a language code ("de", "pt-BR") or a locale as found in the LANG
+// environment variable ("pt_BR.UTF-8") to a supported language. Without an exact match the
+// primary language decides, e.g. "de-AT" selects "de" and "pt" selects "pt-BR". ok is false
+// if no supported language matches.
+func ResolveLanguage(value string) (language string, ok bool) {
+	value = strings.TrimSpace(value)
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	value = strings.ReplaceAll(value, "_", "-")
+	if value == "" {
+		return "", false
+	}
+
+	supported := SupportedLanguages()
+	for _, candidate := range supported {
+		if strings.EqualFold(candidate, value) {
+			return candidate, true
+		}
+	}
+	primary, _, _ := strings.Cut(value, "-")
+	for _, candidate := range supported {
+		candidatePrimary, _, _ := strings.Cut(candidate, "-")
+		if strings.EqualFold(candidatePrimary, primary) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// LoadTranslations returns the report strings of a supported language (see
+// ResolveLanguage), with the keys of the JSON object in overridesFile applied on top if
+// the file name is not empty. An empty language selects English. Keys that are missing
+// or empty keep their English text; for other languages they are logged once per language
+// and overrides file at debug level. The English texts with format verbs (e.g. %d) are
+// formatted with printf, so overrides of them with other verbs are ignored with a warning.
+func LoadTranslations(language, overridesFile string, logger *slog.Logger) (map[string]string, error) {
+	if language == "" {
+		language = DefaultLanguage
+	}
+	english := GetTranslations()
+	translations := make(map[string]string, len(english))
+
+	localized := map[string]string{}
+	if language != DefaultLanguage {
+		content, err := translationFiles.ReadFile(path.Join("translations", language+".json"))
+		if err != nil {
+			return nil, fmt.Errorf("unsupported language %q (supported: %s)", language, strings.Join(SupportedLanguages(), ", "))
+		}
+		if err := json.Unmarshal(content, &localized); err != nil {
+			return nil, fmt.Errorf("invalid built-in translations for %q: %w", language, err)
+		}
+	}
+
+	if overridesFile != "" {
+		content, err := os.ReadFile(overridesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read translations file: %w", err)
+		}
+		overrides := map[string]string{}
+		if err := json.Unmarshal(content, &overrides); err != nil {
+			return nil, fmt.Errorf("invalid translations file %s, expected a JSON object of strings: %w", overridesFile, err)
+		}
+		for key, value := range overrides {
+			if _, known := english[key]; !known {
+				logger.Warn("Ignoring unknown key in translations file", "file", overridesFile, "key", key)
+				continue
+			}
+			if verbs := formatVerbs(english[key]); verbs != nil && !slices.Equal(formatVerbs(value), verbs) {
+				logger.Warn("Ignoring translation whose format verbs differ from the English text", "file", overridesFile, "key", key, "english", english[key])
+				continue
+			}
+			localized[key] = value
+		}
+	}
+
+	var missing []string
+	for key, value := range english {
+		if localizedValue := localized[key]; localizedValue != "" {
+			value = localizedValue
+		} else {
+			missing = append(missing, key)
+		}
+		translations[key] = value
+	}
+
+	if len(missing) > 0 && language != DefaultLanguage {
+		if _, logged := reportedMissingTranslations.LoadOrStore(language+"|"+overridesFile, true); !logged {
+			sort.Strings(missing)
+			logger.Debug("Translations missing, using English", "language", language, "keys", missing)
+		}
+	}
+	return translations, nil
+}
+
+// formatVerbs returns the printf verbs of s in order, with their flags, width and
+// precision, e.g. ["%d", "%.1f"] for "%d lines (%.1f%%)". "%%" is not a verb.
+func formatVerbs(s string) []string {
+	var verbs []string
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			continue
+		}
+		j := i + 1
+		for j < len(s) && strings.IndexByte("+-# 0123456789.*[]", s[j]) >= 0 {
+			j++
+		}
+		if j == len(s) {
+			break
+		}
+		if s[j] != '%' {
+			verbs = append(verbs, s[i:j+1])
+		}
+		i = j
+	}
+	return verbs
+}
@@ -0,0 +1,228 @@
+package htmlreport
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestResolveLanguage(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"de", "de", true},
+		{"DE", "de", true},
+		{"de_DE.UTF-8", "de", true},
+		{"de-AT", "de", true},
+		{"pt", "pt-BR", true},
+		{"pt-br", "pt-BR", true},
+		{"pt_BR.UTF-8", "pt-BR", true},
+		{"en_US", "en", true},
+		{"en_US.UTF-8@euro", "en", true},
+		{"C", "", false},
+		{"C.UTF-8", "", false},
+		{"", "", false},
+		{"fr", "", false},
+	}
+	for _, tt := range tests {
+		language, ok := ResolveLanguage(tt.value)
+		if language != tt.expected || ok != tt.ok {
+			t.Errorf("ResolveLanguage(%q) = (%q, %v), want (%q, %v)", tt.value, language, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+// TestBuiltInTranslations_AreComplete checks that every built-in language translates
+// every key and keeps the format verbs of the English text.
+func TestBuiltInTranslations_AreComplete(t *testing.T) {
+	english := GetTranslations()
+	for _, language := range SupportedLanguages() {
+		if language == DefaultLanguage {
+			continue
+		}
+		t.Run(language, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			translations, err := LoadTranslations(language, "", logger)
+			if err != nil {
+				t.Fatalf("LoadTranslations returned error: %v", err)
+			}
+			if strings.Contains(logs.String(), "Translations missing") {
+				t.Errorf("expected no missing translations, got log:\n%s", logs.String())
+			}
+			for key, text := range english {
+				if got, want := strings.Count(translations[key], "%d"), strings.Count(text, "%d"); got != want {
+					t.Errorf("key %s has %d %%d verbs, want %d: %q", key, got, want, translations[key])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadTranslations_UnsupportedLanguage(t *testing.T) {
+	if _, err := LoadTranslations("fr", "", discardLogger()); err == nil || !strings.Contains(err.Error(), "unsupported language") {
+		t.Fatalf("expected an unsupported language error, got %v", err)
+	}
+}
+
+func TestLoadTranslations_OverridesFile(t *testing.T) {
+	overridesFile := filepath.Join(t.TempDir(), "translations.json")
+	content := `{"Summary": "Overview", "Coverage": "", "NoSuchKey": "ignored"}`
+	if err := os.WriteFile(overridesFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write translations file: %v", err)
+	}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	translations, err := LoadTranslations("de", overridesFile, logger)
+	if err != nil {
+		t.Fatalf("LoadTranslations returned error: %v", err)
+	}
+
+	if translations["Summary"] != "Overview" {
+		t.Errorf("expected the override for Summary, got %q", translations["Summary"])
+	}
+	if translations["Coverage"] != GetTranslations()["Coverage"] {
+		t.Errorf("expected an empty override to fall back to English, got %q", translations["Coverage"])
+	}
+	if translations["LineCoverage"] != "Zeilenabdeckung" {
+		t.Errorf("expected the built-in German text for keys without override, got %q", translations["LineCoverage"])
+	}
+	if _, ok := translations["NoSuchKey"]; ok {
+		t.Error("expected unknown keys to be ignored")
+	}
+	if !strings.Contains(logs.String(), "Ignoring unknown key in translations file") || !strings.Contains(logs.String(), "NoSuchKey") {
+		t.Errorf("expected a warning about the unknown key, got log:\n%s", logs.String())
+	}
+	if len(translations) != len(GetTranslations()) {
+		t.Errorf("expected %d translations, got %d", len(GetTranslations()), len(translations))
+	}
+}
+
+func TestLoadTranslations_OverridesWithOtherFormatVerbs(t *testing.T) {
+	overridesFile := filepath.Join(t.TempDir(), "translations.json")
+	content := `{"ShowUncoveredLines": "Show uncovered lines", "SkippedReportsHint": "%s reports skipped", ` +
+		`"BranchesCovered": "%d von %d Zweigen abgedeckt", "Summary": "100 % d'aperçu"}`
+	if err := os.WriteFile(overridesFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write translations file: %v", err)
+	}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	translations, err := LoadTranslations(DefaultLanguage, overridesFile, logger)
+	if err != nil {
+		t.Fatalf("LoadTranslations returned error: %v", err)
+	}
+
+	english := GetTranslations()
+	for _, key := range []string{"ShowUncoveredLines", "SkippedReportsHint"} {
+		if translations[key] != english[key] {
+			t.Errorf("expected the English text for %s, got %q", key, translations[key])
+		}
+		if !strings.Contains(logs.String(), "key="+key) {
+			t.Errorf("expected a warning about %s, got log:\n%s", key, logs.String())
+		}
+	}
+	if want := "%d von %d Zweigen abgedeckt"; translations["BranchesCovered"] != want {
+		t.Errorf("expected the override with the same verbs for BranchesCovered, got %q", translations["BranchesCovered"])
+	}
+	if want := "100 % d'aperçu"; translations["Summary"] != want {
+		t.Errorf("expected the override of a text that is not formatted, got %q", translations["Summary"])
+	}
+}
+
+func TestFormatVerbs(t *testing.T) {
+	testCases := []struct {
+		format string
+		want   []string
+	}{
+		{"Summary", nil},
+		{"Show %d uncovered lines", []string{"%d"}},
+		{"%d of %d (%.1f%%)", []string{"%d", "%d", "%.1f"}},
+		{"100%", nil},
+	}
+	for _, tc := range testCases {
+		if got := formatVerbs(tc.format); !slices.Equal(got, tc.want) {
+			t.Errorf("formatVerbs(%q) = %q, want %q", tc.format, got, tc.want)
+		}
+	}
+}
+
+func TestLoadTranslations_MissingKeysFallBackToEnglishAndAreLoggedOnce(t *testing.T) {
+	// The overrides file empties a built-in German text, so one key is missing.
+	overridesFile := filepath.Join(t.TempDir(), "translations.json")
+	if err := os.WriteFile(overridesFile, []byte(`{"Summary": ""}`), 0o644); err != nil {
+		t.Fatalf("failed to write translations file: %v", err)
+	}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	for i := 0; i < 2; i++ {
+		translations, err := LoadTranslations("de", overridesFile, logger)
+		if err != nil {
+			t.Fatalf("LoadTranslations returned error: %v", err)
+		}
+		if translations["Summary"] != GetTranslations()["Summary"] {
+			t.Errorf("expected the English text for Summary, got %q", translations["Summary"])
+		}
+	}
+
+	if count := strings.Count(logs.String(), "Translations missing, using English"); count != 1 {
+		t.Errorf("expected the missing keys to be logged once, got %d times:\n%s", count, logs.String())
+	}
+	if !strings.Contains(logs.String(), "Summary") {
+		t.Errorf("expected the log to name the missing key, got:\n%s", logs.String())
+	}
+}
+
+func TestLoadTranslations_InvalidOverridesFile(t *testing.T) {
+	dir := t.TempDir()
+	invalidFile := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidFile, []byte(`{"Summary": 1}`), 0o644); err != nil {
+		t.Fatalf("failed to write translations file: %v", err)
+	}
+
+	for _, file := range []string{invalidFile, filepath.Join(dir, "missing.json")} {
+		if _, err := LoadTranslations(DefaultLanguage, file, discardLogger()); err == nil {
+			t.Errorf("expected an error for %s", filepath.Base(file))
+		}
+	}
+}
+
+func TestCreateReportInMemory_UsesConfiguredLanguage(t *testing.T) {
+	cfg, err := reportconfig.NewReportConfiguration(nil, t.TempDir(), reportconfig.WithReportTypeSpecs("Html"))
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	appSettings := settings.NewSettings()
+	appSettings.Language = "de_DE.UTF-8"
+	ctx := reporter.NewBuilderContext(cfg, appSettings, discardLogger())
+
+	files, err := NewHtmlReportBuilder("", ctx).CreateReportInMemory(syntheticReport(t, 2, 40))
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+
+	index := string(files["index.html"])
+	if !strings.Contains(index, "Zusammenfassung") {
+		t.Error("expected the summary page to be rendered in German")
+	}
+	if !strings.Contains(index, `"LineCoverage":"Zeilenabdeckung"`) {
+		t.Error("expected window.translations to contain the German texts")
+	}
+}
@@ -0,0 +1,146 @@
+package htmlreport
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// OutputFS is the write side of the filesystem the Html report is written to. Paths are
+// OS paths below the OutputDir of the builder.
+type OutputFS interface {
+	// CreateFile creates or truncates the named file. Its content is complete once the
+	// returned writer is closed. If writing fails, the writer is discarded instead of
+	// closed if it has a Discard() method, which leaves the file as it was.
+	CreateFile(path string) (io.WriteCloser, error)
+
+	// MkdirAll creates a directory named path, along with any necessary parents.
+	MkdirAll(path string, perm fs.FileMode) error
+
+	// WriteFile writes data to the named file, replacing it if it exists.
+	WriteFile(path string, data []byte, perm fs.FileMode) error
+}
+
+// OSOutputFS writes the report to the disk. Files are replaced atomically, so an
+// interrupted run never leaves a truncated page.
+type OSOutputFS struct{}
+
+// CreateFile returns a writer to a temporary file that is renamed to the named file when
+// it is closed, see utils.CreateFileAtomic.
+func (OSOutputFS) CreateFile(path string) (io.WriteCloser, error) {
+	return utils.CreateFileAtomic(utils.LongPath(path), 0o644)
+}
+
+// MkdirAll creates a directory and any necessary parents using os.MkdirAll.
+func (OSOutputFS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(utils.LongPath(path), perm)
+}
+
+// WriteFile writes data to a temporary file and renames it to the named file.
+func (OSOutputFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return utils.WriteFileAtomic(path, data, perm)
+}
+
+// MemoryFS keeps the written files in memory. It is used to render the report without
+// touching the disk, e.g. to serve it or in tests.
+type MemoryFS struct {
+	mu    sync.Mutex
+	files map[string][]byte   // Keyed by the cleaned, slash-separated path
+	dirs  map[string]struct{} // Directories created with MkdirAll, keyed like files
+}
+
+// NewMemoryFS returns an empty MemoryFS.
+func NewMemoryFS() *MemoryFS {
+	return &MemoryFS{files: make(map[string][]byte), dirs: make(map[string]struct{})}
+}
+
+func memoryFSKey(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// CreateFile returns a writer whose content is stored as the named file when it is closed.
+func (m *MemoryFS) CreateFile(path string) (io.WriteCloser, error) {
+	return &memoryFile{fs: m, path: path}, nil
+}
+
+// MkdirAll records the directory.
+func (m *MemoryFS) MkdirAll(path string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dirs[memoryFSKey(path)] = struct{}{}
+	return nil
+}
+
+// WriteFile stores a copy of data as the named file.
+func (m *MemoryFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[memoryFSKey(path)] = bytes.Clone(data)
+	return nil
+}
+
+// ReadFile returns the content of the named file and whether it was written.
+func (m *MemoryFS) ReadFile(path string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	content, ok := m.files[memoryFSKey(path)]
+	return content, ok
+}
+
+// FileNames returns the slash-separated paths of all written files, sorted.
+func (m *MemoryFS) FileNames() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasDir reports whether the directory was created with MkdirAll.
+func (m *MemoryFS) HasDir(path string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.dirs[memoryFSKey(path)]
+	return ok
+}
+
+// filesBelow returns the files below root keyed by their slash-separated path relative to it.
+func (m *MemoryFS) filesBelow(root string) map[string][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	files := make(map[string][]byte, len(m.files))
+	for name, content := range m.files {
+		rel, err := filepath.Rel(filepath.Clean(root), filepath.FromSlash(name))
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		files[filepath.ToSlash(rel)] = content
+	}
+	return files
+}
+
+// memoryFile buffers the content written through MemoryFS.CreateFile.
+type memoryFile struct {
+	fs   *MemoryFS
+	path string
+	buf  bytes.Buffer
+}
+
+func (f *memoryFile) Write(p []byte) (int, error) { return f.buf.Write(p) }
+
+func (f *memoryFile) Close() error {
+	return f.fs.WriteFile(f.path, f.buf.Bytes(), 0o644)
+}
+
+// Discard drops the content without storing it.
+func (f *memoryFile) Discard() { f.buf.Reset() }
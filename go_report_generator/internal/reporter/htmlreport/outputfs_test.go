@@ -0,0 +1,107 @@
+package htmlreport
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCreateReport_WritesToOutputFS expects all files of the report to go through the
+// OutputFS of the builder and nothing to be written to the disk.
+func TestCreateReport_WritesToOutputFS(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "report")
+	memoryFS := NewMemoryFS()
+	b := newInMemoryBuilder(t, "Html")
+	b.OutputDir = outputDir
+	b.FS = memoryFS
+
+	if err := b.CreateReport(syntheticReport(t, 2, 10)); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+
+	if _, err := os.Stat(outputDir); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("the output directory was created on the disk: %v", err)
+	}
+	if !memoryFS.HasDir(outputDir) {
+		t.Errorf("the output directory was not created in the OutputFS")
+	}
+
+	names := make(map[string]bool)
+	root := filepath.ToSlash(outputDir) + "/"
+	for _, name := range memoryFS.FileNames() {
+		if !strings.HasPrefix(name, root) {
+			t.Errorf("%s was written outside of the output directory", name)
+			continue
+		}
+		names[strings.TrimPrefix(name, root)] = true
+	}
+	for _, want := range []string{
+		"index.html",
+		"report.css",
+		"custom.js",
+		"reportgenerator.combined.js",
+		"Demo.Module0Class0.html",
+		"Demo.Module0Class1.html",
+	} {
+		if !names[want] {
+			t.Errorf("%s was not written, got %v", want, memoryFS.FileNames())
+		}
+	}
+
+	index, ok := memoryFS.ReadFile(filepath.Join(outputDir, "index.html"))
+	if !ok {
+		t.Fatal("index.html was not written")
+	}
+	if !strings.Contains(string(index), "Demo.Module0.Class1") {
+		t.Errorf("index.html does not list the classes of the report")
+	}
+	classPage, _ := memoryFS.ReadFile(filepath.Join(outputDir, "Demo.Module0Class0.html"))
+	if !strings.Contains(string(classPage), "var value3 = Compute(3);") {
+		t.Errorf("the class page does not contain the source of the class")
+	}
+}
+
+// failingOutputFS fails all writes of files.
+type failingOutputFS struct{ *MemoryFS }
+
+func (failingOutputFS) CreateFile(path string) (io.WriteCloser, error) {
+	return nil, errors.New("disk full")
+}
+
+func (failingOutputFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return errors.New("disk full")
+}
+
+func TestCreateReport_ReturnsOutputFSErrors(t *testing.T) {
+	b := newInMemoryBuilder(t, "Html")
+	b.OutputDir = "report"
+	b.FS = failingOutputFS{NewMemoryFS()}
+
+	err := b.CreateReport(syntheticReport(t, 1, 4))
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("CreateReport error = %v, want the error of the OutputFS", err)
+	}
+}
+
+func TestMemoryFS_CreateFileStoresContentOnClose(t *testing.T) {
+	memoryFS := NewMemoryFS()
+	file, err := memoryFS.CreateFile(filepath.Join("out", "a.txt"))
+	if err != nil {
+		t.Fatalf("CreateFile returned error: %v", err)
+	}
+	io.WriteString(file, "hello ")
+	io.WriteString(file, "world")
+	if _, ok := memoryFS.ReadFile("out/a.txt"); ok {
+		t.Errorf("the file is visible before it is closed")
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if content, ok := memoryFS.ReadFile("out/./a.txt"); !ok || string(content) != "hello world" {
+		t.Errorf("ReadFile = %q, %v, want %q", content, ok, "hello world")
+	}
+}
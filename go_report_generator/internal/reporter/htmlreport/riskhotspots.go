@@ -0,0 +1,190 @@
+package htmlreport
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// riskHotspotsPageFilename is the page listing all risk hotspots. It is only written, and
+// linked from index.html, if the report has any.
+const riskHotspotsPageFilename = "risk_hotspots.html"
+
+// riskHotspotMetricHeaders returns the columns of the risk hotspot tables, in the order of
+// analyzer.RiskHotspotMetricNamesOf, including the custom metrics.
+func (b *HtmlReportBuilder) riskHotspotMetricHeaders() []AngularRiskHotspotMetricHeaderViewModel {
+	names := analyzer.RiskHotspotMetricNamesOf(b.metrics())
+	headers := make([]AngularRiskHotspotMetricHeaderViewModel, len(names))
+	for i, name := range names {
+		definition := b.metrics().Lookup(name)
+		headers[i] = AngularRiskHotspotMetricHeaderViewModel{Name: name, Abbreviation: definition.Abbreviation, ExplanationURL: definition.ExplanationURL}
+	}
+	return headers
+}
+
+// riskHotspotTarget is where a risk hotspot is shown on its class page.
+type riskHotspotTarget struct {
+	fileIndex int    // Index of the file among the files of the class page (sorted by path)
+	fileID    string // Anchor id of the file, see fileAnchorID
+	line      int
+}
+
+// findRiskHotspots runs the hotspot analysis with the thresholds and risk hotspot filters
+// of the configuration and keeps the tooltip of every hotspot method for the metrics
+// tables of the class pages.
+func (b *HtmlReportBuilder) findRiskHotspots(report *model.SummaryResult) []analyzer.RiskHotspot {
+	reportConfig := b.ReportContext.ReportConfiguration()
+	hotspots := analyzer.FindRiskHotspots(report, b.metrics(), b.ReportContext.Settings().MetricThresholds,
+		reportConfig.RiskHotspotAssemblyFilters(), reportConfig.RiskHotspotClassFilters())
+
+	b.riskHotspotTooltips = make(map[*model.Method]string, len(hotspots))
+	for _, hotspot := range hotspots {
+		b.riskHotspotTooltips[hotspot.Method] = b.riskHotspotTooltip(hotspot)
+	}
+	return hotspots
+}
+
+// riskHotspotTooltip lists the metrics of a hotspot that exceed their threshold, one per
+// line, e.g. "CrapScore 92.50 exceeds the error threshold of 80".
+func (b *HtmlReportBuilder) riskHotspotTooltip(hotspot analyzer.RiskHotspot) string {
+	var lines []string
+	for _, metric := range hotspot.Metrics {
+		format, limit := "", 0.0
+		switch metric.Status {
+		case model.StatusError:
+			format, limit = b.translations["RiskHotspotExceedsError"], metric.Threshold.Error
+		case model.StatusWarning:
+			format, limit = b.translations["RiskHotspotExceedsWarning"], metric.Threshold.Warning
+		default:
+			continue
+		}
+		value := b.formatMetricValue(model.Metric{Name: metric.Name, Value: metric.Value})
+		lines = append(lines, fmt.Sprintf(format, metric.Name, value, strconv.FormatFloat(limit, 'f', -1, 64)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// riskHotspotTargetOf returns the file and line of the method on the class page. Methods
+// without a code element are placed at their first line in the first file.
+func riskHotspotTargetOf(class *model.Class, method *model.Method) riskHotspotTarget {
+	files := make([]*model.CodeFile, len(class.Files))
+	for i := range class.Files {
+		files[i] = &class.Files[i]
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	for i, file := range files {
+		if ce := model.FindCodeElement(file.CodeElements, method); ce != nil {
+			return riskHotspotTarget{fileIndex: i, fileID: fileAnchorID(file.Path), line: ce.FirstLine}
+		}
+	}
+	target := riskHotspotTarget{line: method.FirstLine}
+	if len(files) > 0 {
+		target.fileID = fileAnchorID(files[0].Path)
+	}
+	return target
+}
+
+// riskHotspotMethodShortName returns the method name shown in the hotspot tables;
+// property accessors keep their full name like in the metrics table.
+func riskHotspotMethodShortName(method *model.Method) string {
+	if strings.HasPrefix(method.DisplayName, "get_") || strings.HasPrefix(method.DisplayName, "set_") {
+		return method.DisplayName
+	}
+	return utils.GetShortMethodName(method.DisplayName)
+}
+
+// buildAngularRiskHotspotViewModels converts the hotspots for window.riskHotspots.
+func (b *HtmlReportBuilder) buildAngularRiskHotspotViewModels(hotspots []analyzer.RiskHotspot) []AngularRiskHotspotViewModel {
+	viewModels := make([]AngularRiskHotspotViewModel, 0, len(hotspots))
+	for _, hotspot := range hotspots {
+		target := riskHotspotTargetOf(hotspot.Class, hotspot.Method)
+		vm := AngularRiskHotspotViewModel{
+			Assembly:        hotspot.Assembly.Name,
+			Class:           hotspot.Class.DisplayName,
+			ReportPath:      b.classReportPath(b.classReportFilenames[classReportKey{assembly: hotspot.Assembly.Name, class: hotspot.Class.Name}]),
+			MethodName:      hotspot.Method.DisplayName,
+			MethodShortName: riskHotspotMethodShortName(hotspot.Method),
+			FileIndex:       target.fileIndex,
+			Line:            target.line,
+			Metrics:         make([]AngularRiskHotspotStatusMetricViewModel, len(hotspot.Metrics)),
+		}
+		for i, metric := range hotspot.Metrics {
+			vm.Metrics[i] = AngularRiskHotspotStatusMetricViewModel{Value: finiteFloatPtr(&metric.Value), Exceeded: metric.Exceeded()}
+		}
+		viewModels = append(viewModels, vm)
+	}
+	return viewModels
+}
+
+// riskHotspotLinks returns the links of a hotspot to its class page and to the line of
+// its method. With on-demand class details index.html opens the class, without a line.
+func (b *HtmlReportBuilder) riskHotspotLinks(hotspot analyzer.RiskHotspot, target riskHotspotTarget) (classLink, methodLink string) {
+	filename := b.classReportFilenames[classReportKey{assembly: hotspot.Assembly.Name, class: hotspot.Class.Name}]
+	if filename == "" {
+		return "", ""
+	}
+	if b.classDetailsOnDemand {
+		classLink = "index.html" + b.classReportPath(filename)
+		return classLink, classLink
+	}
+	if target.fileID == "" {
+		return filename, filename
+	}
+	return filename, fmt.Sprintf("%s#%s_line%d", filename, target.fileID, target.line)
+}
+
+// buildRiskHotspotsPageData builds the data of risk_hotspots.html.
+func (b *HtmlReportBuilder) buildRiskHotspotsPageData(hotspots []analyzer.RiskHotspot) RiskHotspotsPageData {
+	data := RiskHotspotsPageData{
+		ReportTitle:     b.reportTitle,
+		AppVersion:      b.appVersion,
+		CommandLine:     b.commandLine,
+		CurrentDateTime: b.generatedAt.Format("02/01/2006 - 15:04:05"),
+		Translations:    b.translations,
+		Metrics:         b.riskHotspotMetricHeaders(),
+		Rows:            make([]RiskHotspotRowViewModel, 0, len(hotspots)),
+	}
+	for _, hotspot := range hotspots {
+		target := riskHotspotTargetOf(hotspot.Class, hotspot.Method)
+		classLink, methodLink := b.riskHotspotLinks(hotspot, target)
+		row := RiskHotspotRowViewModel{
+			Assembly:        hotspot.Assembly.Name,
+			Class:           hotspot.Class.DisplayName,
+			ClassLink:       classLink,
+			MethodName:      hotspot.Method.DisplayName,
+			MethodShortName: riskHotspotMethodShortName(hotspot.Method),
+			MethodLink:      methodLink,
+			Tooltip:         b.riskHotspotTooltips[hotspot.Method],
+			Metrics:         make([]RiskHotspotMetricCellViewModel, len(hotspot.Metrics)),
+		}
+		for i, metric := range hotspot.Metrics {
+			cell := RiskHotspotMetricCellViewModel{Value: "-"}
+			if isFinite(metric.Value) {
+				cell.Value = b.formatMetricValue(model.Metric{Name: metric.Name, Value: metric.Value})
+				cell.SortValue = strconv.FormatFloat(metric.Value, 'f', -1, 64)
+				cell.StatusClass = metricStatusClass(metric.Status)
+			}
+			row.Metrics[i] = cell
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	return data
+}
+
+func (b *HtmlReportBuilder) renderRiskHotspotsPage(hotspots []analyzer.RiskHotspot) error {
+	if len(hotspots) == 0 {
+		return nil
+	}
+	var page bytes.Buffer
+	if err := riskHotspotsPageTpl.Execute(&page, b.buildRiskHotspotsPageData(hotspots)); err != nil {
+		return err
+	}
+	return b.writeOutputFile(riskHotspotsPageFilename, page.Bytes())
+}
@@ -0,0 +1,124 @@
+package htmlreport
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// riskHotspotReport returns the golden report with Div(int, int) above the CrapScore
+// error limit and the Cyclomatic complexity warning limit.
+func riskHotspotReport() *model.SummaryResult {
+	report := goldenReport()
+	class := &report.Assemblies[0].Classes[0]
+	div := &class.Methods[1]
+	div.MethodMetrics = []model.MethodMetric{
+		{Name: "Cyclomatic complexity", Line: div.FirstLine, Metrics: []model.Metric{{Name: "Cyclomatic complexity", Value: 20.0, Status: model.StatusWarning}}},
+		{Name: "CrapScore", Line: div.FirstLine, Metrics: []model.Metric{{Name: "CrapScore", Value: 92.5, Status: model.StatusError}}},
+	}
+	class.Files[0].MethodMetrics = div.MethodMetrics
+	return report
+}
+
+func TestCreateReport_RiskHotspotsPage(t *testing.T) {
+	files := renderInMemory(t, "Html", riskHotspotReport())
+
+	page, ok := files[riskHotspotsPageFilename]
+	if !ok {
+		t.Fatalf("expected %s to be generated", riskHotspotsPageFilename)
+	}
+	pageHTML := string(page)
+	for _, want := range []string{
+		`<a href="DemoCalc.html">Demo.Calc</a>`,
+		`<a href="DemoCalc.html#Calc.cs_line10">Div(...)</a>`,
+		`data-value="92.5">92.50</td>`,
+		`class="right lightred"`,
+		`CrapScore 92.50 exceeds the error threshold of 80`,
+		`Cyclomatic complexity 20 exceeds the warning threshold of 15`,
+	} {
+		if !strings.Contains(pageHTML, want) {
+			t.Errorf("expected the risk hotspots page to contain %q", want)
+		}
+	}
+	if strings.Contains(pageHTML, "Add(int, int)") {
+		t.Error("expected Add(int, int) to be no risk hotspot")
+	}
+
+	if index := string(files["index.html"]); !strings.Contains(index, `href="risk_hotspots.html"`) {
+		t.Error("expected index.html to link to the risk hotspots page")
+	}
+
+	classPage := string(files["DemoCalc.html"])
+	if strings.Count(classPage, `<tr class="riskhotspot">`) != 1 {
+		t.Error("expected exactly one metrics table row to be marked as risk hotspot")
+	}
+	badge := regexp.MustCompile(`<a href="risk_hotspots.html" class="riskhotspotbadge" title="([^"]*)">`).FindStringSubmatch(classPage)
+	if badge == nil || !strings.Contains(badge[1], "CrapScore 92.50 exceeds the error threshold of 80") {
+		t.Errorf("expected a badge whose tooltip names the exceeded threshold, got %v", badge)
+	}
+}
+
+func TestCreateReport_RiskHotspotsInSummaryJSON(t *testing.T) {
+	files := renderInMemory(t, "Html", riskHotspotReport())
+
+	match := regexp.MustCompile(`window\.riskHotspots = (.*);`).FindSubmatch(files["index.html"])
+	if match == nil {
+		t.Fatal("window.riskHotspots not found in index.html")
+	}
+	var hotspots []json.RawMessage
+	if err := json.Unmarshal(match[1], &hotspots); err != nil {
+		t.Fatalf("window.riskHotspots is not valid JSON: %v", err)
+	}
+	if len(hotspots) != 1 {
+		t.Fatalf("expected 1 risk hotspot, got %d", len(hotspots))
+	}
+	want := `{"assembly":"Demo","class":"Demo.Calc","reportPath":"DemoCalc.html","methodName":"Div(int, int)","methodShortName":"Div(...)","fileIndex":0,"line":10,` +
+		`"metrics":[{"value":20,"exceeded":true},{"value":92.5,"exceeded":true},{"value":null,"exceeded":false}]}`
+	if string(hotspots[0]) != want {
+		t.Errorf("risk hotspot JSON =\n%s\nwant\n%s", hotspots[0], want)
+	}
+}
+
+func TestCreateReport_RiskHotspotsShowCustomMetrics(t *testing.T) {
+	report := riskHotspotReport()
+	div := &report.Assemblies[0].Classes[0].Methods[1]
+	div.MethodMetrics = append(div.MethodMetrics, model.MethodMetric{Name: "Mutation score", Line: div.FirstLine, Metrics: []model.Metric{{Name: "Mutation score", Value: 87.25}}})
+
+	b := newInMemoryBuilder(t, "Html")
+	b.ReportContext.Settings().Metrics["Mutation score"] = model.MetricDefinition{
+		Name: "Mutation score", ExplanationURL: "https://stryker-mutator.io/", Order: 1, Aggregation: model.AggregateNone,
+	}
+	files, err := b.CreateReportInMemory(report)
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+
+	index := string(files["index.html"])
+	header := `{"name":"Mutation score","explanationUrl":"https://stryker-mutator.io/"}]`
+	if !strings.Contains(index, header) {
+		t.Errorf("expected window.riskHotspotMetrics to end with the custom metric %s", header)
+	}
+	if !strings.Contains(index, `{"value":null,"exceeded":false},{"value":87.25,"exceeded":false}]`) {
+		t.Error("expected the value of the custom metric as the last metric of the risk hotspot")
+	}
+	if page := string(files[riskHotspotsPageFilename]); !strings.Contains(page, `data-value="87.25">87.2</td>`) {
+		t.Error("expected the custom metric in the risk hotspots page")
+	}
+}
+
+func TestCreateReport_NoRiskHotspotsPageWithoutHotspots(t *testing.T) {
+	files := renderInMemory(t, "Html", goldenReport())
+
+	if _, ok := files[riskHotspotsPageFilename]; ok {
+		t.Errorf("expected no %s without risk hotspots", riskHotspotsPageFilename)
+	}
+	if strings.Contains(string(files["index.html"]), "risk_hotspots.html") {
+		t.Error("expected index.html not to link to the risk hotspots page")
+	}
+	if strings.Contains(string(files["DemoCalc.html"]), "riskhotspot") {
+		t.Error("expected no risk hotspot badges on the class page")
+	}
+}
@@ -3,14 +3,18 @@ package htmlreport
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"html/template"
-	"log"
 	"math"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
@@ -22,23 +26,14 @@ func (b *HtmlReportBuilder) prepareGlobalJSONData(report *model.SummaryResult) e
 		b.translationsJSON = template.JS(string(translationsJSONBytes)) // Ensure it's string(bytes)
 	}
 
-	availableMetrics := []AngularMetricViewModel{
-		{Name: "NPath complexity", Abbreviation: "npath", ExplanationURL: "https://modess.io/npath-complexity-cyclomatic-complexity-explained/"},
-		{Name: "CrapScore", Abbreviation: "crap", ExplanationURL: "https://testing.googleblog.com/2011/02/this-code-is-crap.html"},
-	}
-	metricsJSONBytes, err := json.Marshal(availableMetrics)
+	metricsJSONBytes, err := json.Marshal(b.availableMetrics(report))
 	if err != nil {
 		b.metricsJSON = template.JS("([])")
 	} else {
 		b.metricsJSON = template.JS(string(metricsJSONBytes))
 	}
 
-	riskHotspotMetricHeaders := []AngularRiskHotspotMetricHeaderViewModel{
-		{Name: "Cyclomatic complexity", Abbreviation: "cyclomatic", ExplanationURL: "https://www.ndepend.com/docs/code-metrics#CC"},
-		{Name: "CrapScore", Abbreviation: "crap", ExplanationURL: "https://testing.googleblog.com/2011/02/this-code-is-crap.html"},
-		{Name: "NPath complexity", Abbreviation: "npath", ExplanationURL: "https://modess.io/npath-complexity-cyclomatic-complexity-explained/"},
-	}
-	riskHotspotMetricsJSONBytes, err := json.Marshal(riskHotspotMetricHeaders)
+	riskHotspotMetricsJSONBytes, err := json.Marshal(b.riskHotspotMetricHeaders())
 	if err != nil {
 		b.riskHotspotMetricsJSON = template.JS("([])")
 	} else {
@@ -55,6 +50,35 @@ func (b *HtmlReportBuilder) prepareGlobalJSONData(report *model.SummaryResult) e
 	return nil
 }
 
+// availableMetrics returns the method metrics of the report for window.metrics, in the
+// order of their definitions. Their abbreviation is the metric name, as the metrics of the
+// classes are keyed by name.
+func (b *HtmlReportBuilder) availableMetrics(report *model.SummaryResult) []AngularMetricViewModel {
+	seen := make(map[string]bool)
+	var names []string
+	for _, assembly := range report.Assemblies {
+		for _, class := range assembly.Classes {
+			for _, method := range class.Methods {
+				for _, methodMetric := range method.MethodMetrics {
+					for _, metric := range methodMetric.Metrics {
+						if !seen[metric.Name] {
+							seen[metric.Name] = true
+							names = append(names, metric.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	b.metrics().SortNames(names)
+
+	metrics := make([]AngularMetricViewModel, len(names))
+	for i, name := range names {
+		metrics[i] = AngularMetricViewModel{Name: b.metricDisplayName(name), Abbreviation: name, ExplanationURL: b.metrics().Lookup(name).ExplanationURL}
+	}
+	return metrics
+}
+
 func (b *HtmlReportBuilder) collectHistoricExecutionTimes(report *model.SummaryResult) []string {
 	var allHistoricCoverages []model.HistoricCoverage
 	if report.Assemblies != nil {
@@ -75,65 +99,108 @@ func (b *HtmlReportBuilder) collectHistoricExecutionTimes(report *model.SummaryR
 
 	executionTimes := make([]string, len(distinctHistoricCoverages))
 	for i, hc := range distinctHistoricCoverages {
-		executionTimes[i] = time.Unix(hc.ExecutionTime, 0).Format("2006-01-02 15:04:05")
+		executionTimes[i] = historicExecutionTimeLabel(hc.ExecutionTime)
 	}
 	sort.Strings(executionTimes) // Ensure consistent order
+	// Only the newest entries of each class are embedded, older dates would find no data.
+	if limit := b.maximumHistoricCoveragesPerClass; limit > 0 && len(executionTimes) > limit {
+		executionTimes = executionTimes[len(executionTimes)-limit:]
+	}
 	return executionTimes
 }
 
+// historicExecutionTimeLabel formats the execution time of a history entry. The Angular
+// app finds the entries of the date selected for comparison by this label.
+func historicExecutionTimeLabel(executionTime int64) string {
+	return time.Unix(executionTime, 0).Format("2006-01-02 15:04:05")
+}
+
+// limitHistoricCoverages returns the newest maximumHistoricCoveragesPerClass entries of a
+// class history, oldest first, and the band summarizing the older entries, nil if no entry
+// is left out.
+func (b *HtmlReportBuilder) limitHistoricCoverages(history []model.HistoricCoverage) ([]model.HistoricCoverage, *AngularHistoricCoverageBandViewModel) {
+	limit := b.maximumHistoricCoveragesPerClass
+	if limit <= 0 || len(history) <= limit {
+		return history, nil
+	}
+	sorted := slices.Clone(history)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ExecutionTime < sorted[j].ExecutionTime })
+	older, newest := sorted[:len(sorted)-limit], sorted[len(sorted)-limit:]
+
+	band := &AngularHistoricCoverageBandViewModel{
+		Count:                  len(older),
+		From:                   historicExecutionTimeLabel(older[0].ExecutionTime),
+		To:                     historicExecutionTimeLabel(older[len(older)-1].ExecutionTime),
+		LineCoverageQuotaMin:   -1,
+		LineCoverageQuotaMax:   -1,
+		BranchCoverageQuotaMin: -1,
+		BranchCoverageQuotaMax: -1,
+	}
+	widen := func(minimum, maximum *float64, quota float64) {
+		if quota < 0 || !isFinite(quota) {
+			return
+		}
+		if *minimum < 0 || quota < *minimum {
+			*minimum = quota
+		}
+		if quota > *maximum {
+			*maximum = quota
+		}
+	}
+	for i := range older {
+		hist := b.buildAngularHistoricCoverageViewModel(&older[i])
+		widen(&band.LineCoverageQuotaMin, &band.LineCoverageQuotaMax, hist.LineCoverageQuota)
+		widen(&band.BranchCoverageQuotaMin, &band.BranchCoverageQuotaMax, hist.BranchCoverageQuota)
+	}
+	return newest, band
+}
+
 func (b *HtmlReportBuilder) buildAngularAssemblyViewModelsForSummary(report *model.SummaryResult) ([]AngularAssemblyViewModel, error) {
-	var angularAssemblies []AngularAssemblyViewModel
+	angularAssemblies := make([]AngularAssemblyViewModel, 0, len(report.Assemblies))
 	if len(report.Assemblies) == 0 {
-		log.Println("buildAngularAssemblyViewModelsForSummary: No assemblies in the report or report.Assemblies is nil.")
 		b.assembliesJSON = template.JS("[]") // Default to a valid empty JS array literal
 		return angularAssemblies, nil
 	}
 
-	log.Printf("buildAngularAssemblyViewModelsForSummary: Processing %d assemblies.\n", len(report.Assemblies))
+	// Filenames are reserved once here; the detail pages are rendered with the same names.
+	b.reserveClassReportFilenames(report)
 
-	for _, assembly := range report.Assemblies {
-		var assemblyShortNameForFile string
-		if lastSlash := strings.LastIndexAny(assembly.Name, "/\\"); lastSlash != -1 {
-			assemblyShortNameForFile = assembly.Name[lastSlash+1:]
-		} else {
-			assemblyShortNameForFile = assembly.Name
-		}
-
-		angularAssembly := AngularAssemblyViewModel{Name: assembly.Name, Classes: []AngularClassViewModel{}}
-		log.Printf("  Processing Assembly: %s (ShortName for file: %s)\n", assembly.Name, assemblyShortNameForFile)
-
-		if len(assembly.Classes) == 0 {
-			log.Printf("    Assembly %s has no classes.\n", assembly.Name)
-		}
+	uncoveredLineRanges := make(map[classReportKey]string)
+	for _, uncovered := range reporter.TopUncoveredClasses(report, b.uncoveredLinesClassLimit) {
+		uncoveredLineRanges[classReportKey{assembly: uncovered.Assembly, class: uncovered.Class.Name}] = uncovered.FormatRanges()
+	}
 
+	for _, assembly := range report.Assemblies {
+		angularAssembly := AngularAssemblyViewModel{Name: assembly.Name, ReportPath: b.assemblyReportFilenames[assembly.Name], Classes: make([]AngularClassViewModel, 0, len(assembly.Classes))}
 		for _, class := range assembly.Classes {
-			classReportFilename := b.determineClassReportFilename(assembly.Name, class.Name, assemblyShortNameForFile)
-			log.Printf("    Processing Class: %s, ReportPath: %s\n", class.DisplayName, classReportFilename)
-
-			angularClass := b.buildAngularClassViewModelForSummary(&class, classReportFilename)
-			log.Printf("      AngularClass Built: Name=%s, RP=%s, CL=%d, CAL=%d\n", angularClass.Name, angularClass.ReportPath, angularClass.CoveredLines, angularClass.CoverableLines)
+			classReportFilename := b.classReportFilenames[classReportKey{assembly: assembly.Name, class: class.Name}]
+			angularClass := b.buildAngularClassViewModelForSummary(&class, b.classReportPath(classReportFilename))
+			angularClass.UncoveredLineRanges = uncoveredLineRanges[classReportKey{assembly: assembly.Name, class: class.Name}]
 			angularAssembly.Classes = append(angularAssembly.Classes, angularClass)
 		}
+		b.summarySort.sortClasses(angularAssembly.Classes)
 		angularAssemblies = append(angularAssemblies, angularAssembly)
 	}
 
 	if len(angularAssemblies) == 0 {
-		log.Println("buildAngularAssemblyViewModelsForSummary: angularAssemblies slice is empty after processing (this shouldn't happen if report.Assemblies was not empty).")
 		b.assembliesJSON = template.JS("[]")
 		return angularAssemblies, nil
 	}
 
-	assembliesJSONBytes, err := json.Marshal(angularAssemblies)
+	// The pages of the assemblies list all classes; only index.html is limited.
+	summaryAssemblies := limitSummaryClasses(angularAssemblies, b.summaryTopN)
+	assembliesJSONBytes, err := json.Marshal(summaryAssemblies)
 	if err != nil {
-		log.Printf("buildAngularAssemblyViewModelsForSummary: ERROR marshaling angularAssemblies to JSON: %v\n", err)
+		assemblyName, className := findUnmarshalableClass(summaryAssemblies)
+		b.logger().Error("Failed to marshal assemblies for summary page", "assembly", assemblyName, "class", className, "error", err)
 		b.assembliesJSON = template.JS("[]") // Fallback
-		return nil, fmt.Errorf("failed to marshal angular assemblies for summary: %w", err)
+		return nil, fmt.Errorf("failed to marshal angular assemblies for summary (class %q): %w", className, err)
 	}
 
 	jsonString := string(assembliesJSONBytes)
-	log.Printf("buildAngularAssemblyViewModelsForSummary: Marshaled assembliesJSON (length: %d): %s\n", len(jsonString), jsonString)
+	// Only the size is logged: the data of large reports has many megabytes.
+	b.logger().Debug("Built summary data", "assemblies", len(angularAssemblies), "bytes", len(jsonString))
 	if jsonString == "null" { // Safeguard, though Marshal on non-empty slice shouldn't give "null"
-		log.Println("buildAngularAssemblyViewModelsForSummary: Marshaled JSON is 'null', ensuring it's an empty array '[]' for JS.")
 		b.assembliesJSON = template.JS("[]")
 	} else {
 		b.assembliesJSON = template.JS(jsonString) // Key: assign the string to template.JS
@@ -141,6 +208,19 @@ func (b *HtmlReportBuilder) buildAngularAssemblyViewModelsForSummary(report *mod
 	return angularAssemblies, nil
 }
 
+// findUnmarshalableClass locates the first class whose view model cannot be
+// marshaled to JSON, so fallback paths can report which class broke the page.
+func findUnmarshalableClass(assemblies []AngularAssemblyViewModel) (string, string) {
+	for _, assembly := range assemblies {
+		for _, class := range assembly.Classes {
+			if _, err := json.Marshal(class); err != nil {
+				return assembly.Name, class.Name
+			}
+		}
+	}
+	return "", ""
+}
+
 func (b *HtmlReportBuilder) buildAngularClassViewModelForSummary(class *model.Class, reportPath string) AngularClassViewModel {
 	angularClass := AngularClassViewModel{
 		Name:                      class.DisplayName,
@@ -172,11 +252,18 @@ func (b *HtmlReportBuilder) buildAngularClassViewModelForSummary(class *model.Cl
 		angularClass.TotalBranches = 0
 	}
 
-	for _, hist := range class.HistoricCoverages {
+	if age := class.CoverageAge; age != nil {
+		angularClass.CoverageAge = fmt.Sprintf(b.translations["CoverageAge"],
+			reporter.FormatCoverageAgeThreshold(age), reporter.FormatCoverageAgeSince(age), age.Runs)
+	}
+
+	historicCoverages, band := b.limitHistoricCoverages(class.HistoricCoverages)
+	angularClass.HistoricCoverageBand = band
+	for _, hist := range historicCoverages {
 		angularHist := b.buildAngularHistoricCoverageViewModel(&hist)
 		angularClass.HistoricCoverages = append(angularClass.HistoricCoverages, angularHist)
 
-		if angularHist.LineCoverageQuota >= 0 {
+		if isFinite(angularHist.LineCoverageQuota) && angularHist.LineCoverageQuota >= 0 {
 			angularClass.LineCoverageHistory = append(angularClass.LineCoverageHistory, angularHist.LineCoverageQuota)
 		}
 		if angularHist.BranchCoverageQuota >= 0 {
@@ -190,25 +277,28 @@ func (b *HtmlReportBuilder) buildAngularClassViewModelForSummary(class *model.Cl
 		}
 	}
 
-	for name, val := range class.Metrics {
-		angularClass.Metrics[name] = val
+	angularClass.Metrics = finiteMetrics(class.Metrics)
+
+	for _, file := range class.Files {
+		angularClass.Files = append(angularClass.Files, AngularClassFileViewModel{ID: fileAnchorID(file.Path), Path: file.Path, External: b.markExternalFiles && !file.InSourceDirs})
 	}
+	angularClass.External = b.markExternalFiles && class.IsExternal()
 
 	return angularClass
 }
 
 func (b *HtmlReportBuilder) buildAngularHistoricCoverageViewModel(hist *model.HistoricCoverage) AngularHistoricCoverageViewModel {
 	angularHist := AngularHistoricCoverageViewModel{
-		ExecutionTime:   time.Unix(hist.ExecutionTime, 0).Format("2006-01-02"), // Simplified
-		CoveredLines:    hist.CoveredLines,
-		CoverableLines:  hist.CoverableLines,
-		TotalLines:      hist.TotalLines,
-		CoveredBranches: hist.CoveredBranches,
-		TotalBranches:   hist.TotalBranches,
-		// FIXME: Populate these from your model.HistoricCoverage if it has method coverage history
-		// CoveredMethods: hist.CoveredMethods,
-		// FullyCoveredMethods: hist.FullyCoveredMethods,
-		// TotalMethods: hist.TotalMethods,
+		ExecutionTime:       historicExecutionTimeLabel(hist.ExecutionTime),
+		CoveredLines:        hist.CoveredLines,
+		UncoveredLines:      hist.CoverableLines - hist.CoveredLines,
+		CoverableLines:      hist.CoverableLines,
+		TotalLines:          hist.TotalLines,
+		CoveredBranches:     hist.CoveredBranches,
+		TotalBranches:       hist.TotalBranches,
+		CoveredMethods:      hist.CoveredMethods,
+		FullyCoveredMethods: hist.FullyCoveredMethods,
+		TotalMethods:        hist.TotalMethods,
 	}
 
 	angularHist.LineCoverageQuota = -1.0
@@ -221,33 +311,28 @@ func (b *HtmlReportBuilder) buildAngularHistoricCoverageViewModel(hist *model.Hi
 		angularHist.BranchCoverageQuota = (float64(hist.CoveredBranches) / float64(hist.TotalBranches)) * 100.0
 	}
 
-	// FIXME: Update these based on fields in your model.HistoricCoverage
 	angularHist.MethodCoverageQuota = -1.0
 	angularHist.FullMethodCoverageQuota = -1.0
-	// if hist.TotalMethods > 0 {
-	// 	angularHist.MethodCoverageQuota = (float64(hist.CoveredMethods) / float64(hist.TotalMethods)) * 100.0
-	// 	angularHist.FullMethodCoverageQuota = (float64(hist.FullyCoveredMethods) / float64(hist.TotalMethods)) * 100.0
-	// }
+	if hist.TotalMethods > 0 {
+		angularHist.MethodCoverageQuota = (float64(hist.CoveredMethods) / float64(hist.TotalMethods)) * 100.0
+		angularHist.FullMethodCoverageQuota = (float64(hist.FullyCoveredMethods) / float64(hist.TotalMethods)) * 100.0
+	}
 
 	return angularHist
 }
 
 func (b *HtmlReportBuilder) setRiskHotspotsJSON(angularRiskHotspots []AngularRiskHotspotViewModel) error {
-	log.Printf("setRiskHotspotsJSON: Received %d risk hotspots to marshal.", len(angularRiskHotspots))
-
 	// json.Marshal on a nil slice results in "null" string.
 	// json.Marshal on an empty non-nil slice (e.g., make([]Type, 0)) results in "[]".
 	// Angular expects an array.
 	if angularRiskHotspots == nil { // Explicitly handle nil slice
 		b.riskHotspotsJSON = template.JS("[]")
-		log.Println("setRiskHotspotsJSON: angularRiskHotspots was nil, b.riskHotspotsJSON set to '[]'")
 		return nil
 	}
 
 	riskHotspotsJSONBytes, err := json.Marshal(angularRiskHotspots)
 	if err != nil {
 		b.riskHotspotsJSON = template.JS("[]") // Fallback
-		log.Printf("setRiskHotspotsJSON: Error marshaling risk hotspots: %v. b.riskHotspotsJSON set to '[]'", err)
 		return fmt.Errorf("failed to marshal angular risk hotspots: %w", err)
 	}
 
@@ -255,22 +340,19 @@ func (b *HtmlReportBuilder) setRiskHotspotsJSON(angularRiskHotspots []AngularRis
 	// If angularRiskHotspots was an empty (but not nil) slice, jsonString would be "[]".
 	// If it was nil, jsonString would be "null". The 'if angularRiskHotspots == nil' above handles this.
 	if jsonString == "null" {
-		log.Println("setRiskHotspotsJSON: Marshaled riskHotspotsJSON is 'null', changing to '[]' for JS.")
 		b.riskHotspotsJSON = template.JS("[]")
 	} else {
 		b.riskHotspotsJSON = template.JS(jsonString)
 	}
-	log.Printf("setRiskHotspotsJSON: b.riskHotspotsJSON set to: %s", b.riskHotspotsJSON)
 	return nil
 }
 
 func (b *HtmlReportBuilder) buildSummaryPageData(report *model.SummaryResult, angularAssembliesForSummary []AngularAssemblyViewModel, angularRiskHotspots []AngularRiskHotspotViewModel) (SummaryPageData, error) {
-	log.Printf("buildSummaryPageData: b.assembliesJSON before assigning to SummaryPageData: %s\n", b.assembliesJSON) // Log
-
 	data := SummaryPageData{
 		ReportTitle:                        b.reportTitle,
-		AppVersion:                         "0.0.1",
-		CurrentDateTime:                    time.Now().Format("02/01/2006 - 15:04:05"),
+		AppVersion:                         b.appVersion,
+		CommandLine:                        b.commandLine,
+		CurrentDateTime:                    b.generatedAt.Format("02/01/2006 - 15:04:05"),
 		Translations:                       b.translations,
 		HasRiskHotspots:                    len(angularRiskHotspots) > 0,
 		HasAssemblies:                      len(report.Assemblies) > 0,
@@ -280,6 +362,7 @@ func (b *HtmlReportBuilder) buildSummaryPageData(report *model.SummaryResult, an
 		RiskHotspotMetricsJSON:             b.riskHotspotMetricsJSON,
 		HistoricCoverageExecutionTimesJSON: b.historicCoverageExecutionTimesJSON,
 		TranslationsJSON:                   b.translationsJSON,
+		SummarySort:                        b.summarySort.angularSortViewModel(),
 		AngularCssFile:                     b.angularCssFile,
 		CombinedAngularJsFile:              b.combinedAngularJsFile,
 		AngularRuntimeJsFile:               b.angularRuntimeJsFile,
@@ -289,108 +372,338 @@ func (b *HtmlReportBuilder) buildSummaryPageData(report *model.SummaryResult, an
 		BranchCoverageAvailable:               b.branchCoverageAvailable,
 		MethodCoverageAvailable:               b.methodCoverageAvailable,
 		MaximumDecimalPlacesForCoverageQuotas: b.maximumDecimalPlacesForCoverageQuotas,
+		ClassDetailsOnDemand:                  b.classDetailsOnDemand,
 		SummaryCards:                          b.buildSummaryCards(report),
-		OverallHistoryChartData:               HistoryChartDataViewModel{Series: false},
+		OverallHistoryChartData:               b.buildOverallHistoryChartData(report),
+		SkippedReports:                        buildSkippedReportViewModels(report.SkippedReports),
+		MissingSourceFiles:                    buildMissingSourceFileViewModels(report.MissingSourceFiles),
+		MissingSourceCount:                    reporter.CountMissingSourceFiles(report.MissingSourceFiles, b.pathKey()),
+	}
+	data.AssemblyRows = b.buildAssemblyRows(report)
+	if root := report.Directories; root != nil && len(root.Children) > 0 {
+		data.DirectoryTreeRoot = root.Name
+		data.DirectoryRows = b.buildDirectoryRows(root)
+		data.DirectoryBranchCoverage = b.branchCoverageAvailable && root.HasBranchData
 	}
 	return data, nil
 }
 
+// buildAssemblyRows returns the rows of the "Assemblies" card that links to the assembly
+// pages, none if no pages are rendered.
+func (b *HtmlReportBuilder) buildAssemblyRows(report *model.SummaryResult) []AssemblyRowViewModel {
+	var rows []AssemblyRowViewModel
+	aggregates := b.ReportContext.Aggregates(report)
+	for i, assembly := range report.Assemblies {
+		reportPath := b.assemblyReportFilenames[assembly.Name]
+		if reportPath == "" {
+			continue
+		}
+		totals := aggregates.Assemblies[i].Totals
+		coverageBar := "undefined"
+		if !math.IsNaN(totals.LineQuota) {
+			coverageBar = fmt.Sprintf("%d", getCoverageBarValue(100-totals.LineQuota))
+		}
+		rows = append(rows, AssemblyRowViewModel{
+			Name:           assembly.Name,
+			ReportPath:     reportPath,
+			Classes:        totals.Classes,
+			CoveredLines:   totals.LinesCovered,
+			CoverableLines: totals.LinesValid,
+			LineCoverage:   b.formatPercentage(totals.LineQuota, b.maximumDecimalPlacesForCoverageQuotas),
+			CoverageBar:    coverageBar,
+		})
+	}
+	return rows
+}
+
+// buildDirectoryRows flattens the directory tree below root into the rows of the
+// "Coverage by directory" card, parents before their children.
+func (b *HtmlReportBuilder) buildDirectoryRows(root *model.DirectoryCoverage) []DirectoryRowViewModel {
+	var rows []DirectoryRowViewModel
+	var walk func(node *model.DirectoryCoverage, parentPath string, depth int)
+	walk = func(node *model.DirectoryCoverage, parentPath string, depth int) {
+		lineQuota := b.calculatePercentage(node.LinesCovered, node.LinesValid, b.maximumDecimalPlacesForCoverageQuotas)
+		coverageBar := "undefined"
+		if !math.IsNaN(lineQuota) {
+			coverageBar = fmt.Sprintf("%d", getCoverageBarValue(100-lineQuota))
+		}
+		branchCoverage := "-"
+		if node.HasBranchData {
+			branchQuota := b.calculatePercentage(node.BranchesCovered, node.BranchesValid, b.maximumDecimalPlacesForCoverageQuotas)
+			branchCoverage = b.formatPercentage(branchQuota, b.maximumDecimalPlacesForPercentageDisplay)
+		}
+
+		rows = append(rows, DirectoryRowViewModel{
+			Name:           node.Name,
+			Path:           node.Path,
+			ParentPath:     parentPath,
+			Indent:         5 + 20*depth,
+			HasChildren:    len(node.Children) > 0,
+			Files:          node.Files,
+			CoveredLines:   node.LinesCovered,
+			CoverableLines: node.LinesValid,
+			LineCoverage:   b.formatPercentage(lineQuota, b.maximumDecimalPlacesForPercentageDisplay),
+			CoverageBar:    coverageBar,
+			BranchCoverage: branchCoverage,
+		})
+		for _, child := range node.Children {
+			walk(child, node.Path, depth+1)
+		}
+	}
+	for _, child := range root.Children {
+		walk(child, "", 0)
+	}
+	return rows
+}
+
+func buildMissingSourceFileViewModels(missing []model.MissingSourceFile) []MissingSourceFileViewModel {
+	if len(missing) == 0 {
+		return nil
+	}
+	vms := make([]MissingSourceFileViewModel, 0, len(missing))
+	for _, m := range missing {
+		vms = append(vms, MissingSourceFileViewModel{Path: m.Path, Assembly: m.Assembly, Class: m.Class})
+	}
+	return vms
+}
+
+func buildSkippedReportViewModels(skipped []model.SkippedReport) []SkippedReportViewModel {
+	if len(skipped) == 0 {
+		return nil
+	}
+	vms := make([]SkippedReportViewModel, 0, len(skipped))
+	for _, s := range skipped {
+		vms = append(vms, SkippedReportViewModel{Path: s.Path, Parser: s.Parser, Error: s.Error})
+	}
+	return vms
+}
+
+// historySnapshot accumulates the class-level historic coverages recorded at one execution time.
+type historySnapshot struct {
+	executionTime   int64
+	tag             string
+	coveredLines    int
+	coverableLines  int
+	coveredBranches int
+	totalBranches   int
+	coveredMethods  int
+	totalMethods    int
+}
+
+// buildOverallHistoryChartData aggregates the historic coverages of all classes by
+// execution time and builds the data for the overall trend chart on the summary page.
+// Snapshots without branch or method data produce gaps rather than zero values.
+func (b *HtmlReportBuilder) buildOverallHistoryChartData(report *model.SummaryResult) HistoryChartDataViewModel {
+	snapshotsByTime := make(map[int64]*historySnapshot)
+	for _, assembly := range report.Assemblies {
+		for _, class := range assembly.Classes {
+			for _, hc := range class.HistoricCoverages {
+				snap, ok := snapshotsByTime[hc.ExecutionTime]
+				if !ok {
+					snap = &historySnapshot{executionTime: hc.ExecutionTime}
+					snapshotsByTime[hc.ExecutionTime] = snap
+				}
+				if snap.tag == "" {
+					snap.tag = hc.Tag
+				}
+				snap.coveredLines += hc.CoveredLines
+				snap.coverableLines += hc.CoverableLines
+				snap.coveredBranches += hc.CoveredBranches
+				snap.totalBranches += hc.TotalBranches
+				snap.coveredMethods += hc.CoveredMethods
+				snap.totalMethods += hc.TotalMethods
+			}
+		}
+	}
+
+	// A single snapshot is not a trend; keep the chart hidden.
+	if len(snapshotsByTime) < 2 {
+		return HistoryChartDataViewModel{Series: false}
+	}
+
+	snapshots := make([]*historySnapshot, 0, len(snapshotsByTime))
+	for _, snap := range snapshotsByTime {
+		snapshots = append(snapshots, snap)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].executionTime < snapshots[j].executionTime })
+
+	decimalPlaces := b.maximumDecimalPlacesForCoverageQuotas
+	quota := func(covered, total int) *float64 {
+		if total <= 0 {
+			return nil
+		}
+		q := b.calculatePercentage(covered, total, decimalPlaces)
+		return &q
+	}
+
+	chart := HistoryChartJSONViewModel{
+		Labels:   make([]string, 0, len(snapshots)),
+		Series:   make([][]HistoryChartPointViewModel, 3),
+		Tooltips: make([]string, 0, len(snapshots)),
+	}
+	for i, snap := range snapshots {
+		lineQuota := quota(snap.coveredLines, snap.coverableLines)
+		branchQuota := quota(snap.coveredBranches, snap.totalBranches)
+		methodQuota := quota(snap.coveredMethods, snap.totalMethods)
+
+		date := time.Unix(snap.executionTime, 0).Format("2006-01-02 15:04:05")
+		chart.Labels = append(chart.Labels, date)
+		chart.Series[0] = append(chart.Series[0], HistoryChartPointViewModel{Meta: i, Value: lineQuota})
+		chart.Series[1] = append(chart.Series[1], HistoryChartPointViewModel{Meta: i, Value: branchQuota})
+		chart.Series[2] = append(chart.Series[2], HistoryChartPointViewModel{Meta: i, Value: methodQuota})
+		chart.Tooltips = append(chart.Tooltips, b.historyChartTooltip(date, snap.tag, lineQuota, branchQuota, methodQuota))
+	}
+
+	chartJSONBytes, err := json.Marshal(chart)
+	if err != nil {
+		return HistoryChartDataViewModel{Series: false}
+	}
+	return HistoryChartDataViewModel{Series: true, JSONData: template.JS(chartJSONBytes)}
+}
+
+// historyChartTooltip renders the HTML tooltip shown when hovering a point of the history chart.
+func (b *HtmlReportBuilder) historyChartTooltip(date, tag string, lineQuota, branchQuota, methodQuota *float64) string {
+	var sb strings.Builder
+	sb.WriteString("<h3>" + html.EscapeString(date) + "</h3>")
+	if tag != "" {
+		sb.WriteString("<br /><span>" + html.EscapeString(b.translations["Tag"]) + ": " + html.EscapeString(tag) + "</span>")
+	}
+	writeRow := func(cssClass, label string, value *float64) {
+		if value == nil {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("<br /><span class=\"%s\"></span> %s: %s", cssClass, html.EscapeString(label), b.formatPercentage(*value, b.maximumDecimalPlacesForPercentageDisplay)))
+	}
+	writeRow("linecoverage", b.translations["LineCoverage"], lineQuota)
+	writeRow("branchcoverage", b.translations["BranchCoverage"], branchQuota)
+	writeRow("codeelementcoverage", b.translations["MethodCoverage"], methodQuota)
+	return sb.String()
+}
+
 func (b *HtmlReportBuilder) buildSummaryCards(report *model.SummaryResult) []CardViewModel {
+	return b.buildSummaryCardsFor(report, report.Assemblies, b.ReportContext.Aggregates(report).Overall)
+}
+
+// buildSummaryCardsFor builds the summary cards of the given assemblies of the report,
+// whose totals are given: all of them for index.html, one for an assembly page.
+func (b *HtmlReportBuilder) buildSummaryCardsFor(report *model.SummaryResult, assemblies []model.Assembly, totals reporter.Totals) []CardViewModel {
 	var cards []CardViewModel
-	decimalPlaces := b.maximumDecimalPlacesForCoverageQuotas
 	decimalPlacesForPercentageDisplay := b.maximumDecimalPlacesForPercentageDisplay
 
 	// Information Card
 	infoCardRows := []CardRowViewModel{
 		{Header: b.translations["Parser"], Text: report.ParserName},
-		{Header: b.translations["Assemblies2"], Text: fmt.Sprintf("%d", len(report.Assemblies)), Alignment: "right"},
-		{Header: b.translations["Classes"], Text: fmt.Sprintf("%d", countTotalClasses(report.Assemblies)), Alignment: "right"},
-		{Header: b.translations["Files2"], Text: fmt.Sprintf("%d", countUniqueFiles(report.Assemblies)), Alignment: "right"},
+		{Header: b.translations["Assemblies2"], Text: fmt.Sprintf("%d", len(assemblies)), Alignment: "right"},
+		{Header: b.translations["Classes"], Text: fmt.Sprintf("%d", totals.Classes), Alignment: "right"},
+		{Header: b.translations["Files2"], Text: fmt.Sprintf("%d", totals.Files), Alignment: "right"},
+	}
+	if b.markExternalFiles {
+		if external := model.CountExternalFiles(assemblies, b.pathKey()); external > 0 {
+			infoCardRows = append(infoCardRows, CardRowViewModel{Header: b.translations["ExternalFiles"], Text: fmt.Sprintf("%d", external), Tooltip: b.translations["ExternalFilesHint"], Alignment: "right"})
+		}
 	}
 	if report.Timestamp > 0 {
 		infoCardRows = append(infoCardRows, CardRowViewModel{Header: b.translations["CoverageDate"], Text: time.Unix(report.Timestamp, 0).Format("02/01/2006 - 15:04:05")})
 	}
 	if b.tag != "" {
-		infoCardRows = append(infoCardRows, CardRowViewModel{Header: b.translations["Tag"], Text: b.tag})
+		infoCardRows = append(infoCardRows, CardRowViewModel{Header: b.translations["Tag"], Text: b.tag, Href: b.tagLink})
 	}
 	cards = append(cards, CardViewModel{Title: b.translations["Information"], Rows: infoCardRows})
 
 	// Line Coverage Card
-	lineCovQuota := utils.CalculatePercentage(report.LinesCovered, report.LinesValid, decimalPlaces)
-	lineCovText := utils.FormatPercentage(lineCovQuota, decimalPlacesForPercentageDisplay)
+	lineCovText := b.formatPercentage(totals.LineQuota, decimalPlacesForPercentageDisplay)
 	lineCovTooltip := "-"
-	if !math.IsNaN(lineCovQuota) {
-		lineCovTooltip = fmt.Sprintf("%d of %d", report.LinesCovered, report.LinesValid)
-	}
-	lineCovBar := 0
-	if !math.IsNaN(lineCovQuota) {
-		lineCovBar = 100 - int(math.Round(lineCovQuota))
+	if !math.IsNaN(totals.LineQuota) {
+		lineCovTooltip = fmt.Sprintf("%d of %d", totals.LinesCovered, totals.LinesValid)
 	}
 
-	cards = append(cards, CardViewModel{Title: b.translations["LineCoverage"], SubTitle: lineCovText, SubTitlePercentageBarValue: lineCovBar, Rows: []CardRowViewModel{
-		{Header: b.translations["CoveredLines"], Text: fmt.Sprintf("%d", report.LinesCovered), Alignment: "right"},
-		{Header: b.translations["UncoveredLines"], Text: fmt.Sprintf("%d", report.LinesValid-report.LinesCovered), Alignment: "right"},
-		{Header: b.translations["CoverableLines"], Text: fmt.Sprintf("%d", report.LinesValid), Alignment: "right"},
-		{Header: b.translations["TotalLines"], Text: fmt.Sprintf("%d", report.TotalLines), Alignment: "right"},
+	cards = append(cards, CardViewModel{Title: b.translations["LineCoverage"], SubTitle: lineCovText, SubTitlePercentageBarValue: percentageBarValue(totals.LineQuota), SubTitleQuota: progressBarValueNow(totals.LineQuota), Rows: []CardRowViewModel{
+		{Header: b.translations["CoveredLines"], Text: fmt.Sprintf("%d", totals.LinesCovered), Alignment: "right"},
+		{Header: b.translations["UncoveredLines"], Text: fmt.Sprintf("%d", totals.LinesValid-totals.LinesCovered), Alignment: "right"},
+		{Header: b.translations["CoverableLines"], Text: fmt.Sprintf("%d", totals.LinesValid), Alignment: "right"},
+		{Header: b.translations["TotalLines"], Text: fmt.Sprintf("%d", totals.TotalLines), Alignment: "right"},
 		{Header: b.translations["LineCoverage"], Text: lineCovText, Tooltip: lineCovTooltip, Alignment: "right"},
 	}})
 
 	// Branch Coverage Card (Conditional)
-	if b.branchCoverageAvailable && report.BranchesCovered != nil && report.BranchesValid != nil {
-		branchCovQuota := utils.CalculatePercentage(*report.BranchesCovered, *report.BranchesValid, decimalPlaces)
-		branchCovText := utils.FormatPercentage(branchCovQuota, decimalPlacesForPercentageDisplay)
+	if b.branchCoverageAvailable && totals.BranchesCovered != nil && totals.BranchesValid != nil {
+		branchCovText := b.formatPercentage(totals.BranchQuota, decimalPlacesForPercentageDisplay)
 		branchCovTooltip := "-"
-		if !math.IsNaN(branchCovQuota) {
-			branchCovTooltip = fmt.Sprintf("%d of %d", *report.BranchesCovered, *report.BranchesValid)
-		}
-		branchCovBar := 0
-		if !math.IsNaN(branchCovQuota) {
-			branchCovBar = 100 - int(math.Round(branchCovQuota))
+		if !math.IsNaN(totals.BranchQuota) {
+			branchCovTooltip = fmt.Sprintf("%d of %d", *totals.BranchesCovered, *totals.BranchesValid)
 		}
 
-		cards = append(cards, CardViewModel{Title: b.translations["BranchCoverage"], SubTitle: branchCovText, SubTitlePercentageBarValue: branchCovBar, Rows: []CardRowViewModel{
-			{Header: b.translations["CoveredBranches2"], Text: fmt.Sprintf("%d", *report.BranchesCovered), Alignment: "right"},
-			{Header: b.translations["TotalBranches"], Text: fmt.Sprintf("%d", *report.BranchesValid), Alignment: "right"},
+		branchCard := CardViewModel{Title: b.translations["BranchCoverage"], SubTitle: branchCovText, SubTitlePercentageBarValue: percentageBarValue(totals.BranchQuota), SubTitleQuota: progressBarValueNow(totals.BranchQuota), Rows: []CardRowViewModel{
+			{Header: b.translations["CoveredBranches2"], Text: fmt.Sprintf("%d", *totals.BranchesCovered), Alignment: "right"},
+			{Header: b.translations["TotalBranches"], Text: fmt.Sprintf("%d", *totals.BranchesValid), Alignment: "right"},
 			{Header: b.translations["BranchCoverage"], Text: branchCovText, Tooltip: branchCovTooltip, Alignment: "right"},
-		}})
+		}}
+		if (&model.SummaryResult{Assemblies: assemblies}).HasApproximateBranchCoverage() {
+			branchCard.Footnote = b.translations["ApproximateBranchCoverage"]
+		}
+		cards = append(cards, branchCard)
 	}
 
 	// Method Coverage Card
-	var totalMethods, coveredMethods, fullyCoveredMethods int
-	for _, asm := range report.Assemblies {
-		for _, cls := range asm.Classes {
-			totalMethods += cls.TotalMethods
-			coveredMethods += cls.CoveredMethods
-			fullyCoveredMethods += cls.FullyCoveredMethods
-		}
+	if !b.methodCoverageAvailable {
+		return append(cards, CardViewModel{Title: b.translations["MethodCoverage"], Note: b.translations["MethodCoverageNotProvided"]})
 	}
-	methodCovQuota := utils.CalculatePercentage(coveredMethods, totalMethods, decimalPlaces)
-	methodCovText := utils.FormatPercentage(methodCovQuota, decimalPlacesForPercentageDisplay)
+	methodCovText := b.formatPercentage(totals.MethodQuota, decimalPlacesForPercentageDisplay)
 	methodCovTooltip := "-"
-	if !math.IsNaN(methodCovQuota) {
-		methodCovTooltip = fmt.Sprintf("%d of %d", coveredMethods, totalMethods)
-	}
-	methodCovBar := 0
-	if !math.IsNaN(methodCovQuota) {
-		methodCovBar = 100 - int(math.Round(methodCovQuota))
+	fullMethodCovText := b.formatPercentage(totals.FullMethodQuota, decimalPlacesForPercentageDisplay)
+	fullMethodCovDefinition := b.fullMethodCoverageDefinition()
+	fullMethodCovTooltip := fullMethodCovDefinition
+	if totals.TotalMethods > 0 {
+		methodCovTooltip = fmt.Sprintf("%d of %d", totals.CoveredMethods, totals.TotalMethods)
+		fullMethodCovTooltip = fmt.Sprintf("%d of %d - %s", totals.FullyCoveredMethods, totals.TotalMethods, fullMethodCovDefinition)
 	}
-
-	fullMethodCovQuota := utils.CalculatePercentage(fullyCoveredMethods, totalMethods, decimalPlaces)
-	fullMethodCovText := utils.FormatPercentage(fullMethodCovQuota, decimalPlacesForPercentageDisplay)
-	fullMethodCovTooltip := "-"
-	if !math.IsNaN(fullMethodCovQuota) {
-		fullMethodCovTooltip = fmt.Sprintf("%d of %d", fullyCoveredMethods, totalMethods)
-	}
-
 	cards = append(cards, CardViewModel{
-		Title: b.translations["MethodCoverage"], ProRequired: !b.methodCoverageAvailable, SubTitle: methodCovText, SubTitlePercentageBarValue: methodCovBar,
+		Title: b.translations["MethodCoverage"], SubTitle: methodCovText, SubTitlePercentageBarValue: percentageBarValue(totals.MethodQuota), SubTitleQuota: progressBarValueNow(totals.MethodQuota),
 		Rows: []CardRowViewModel{
-			{Header: b.translations["CoveredCodeElements"], Text: fmt.Sprintf("%d", coveredMethods), Alignment: "right"},
-			{Header: b.translations["FullCoveredCodeElements"], Text: fmt.Sprintf("%d", fullyCoveredMethods), Alignment: "right"},
-			{Header: b.translations["TotalCodeElements"], Text: fmt.Sprintf("%d", totalMethods), Alignment: "right"},
+			{Header: b.translations["CoveredCodeElements"], Text: fmt.Sprintf("%d", totals.CoveredMethods), Alignment: "right"},
+			{Header: b.translations["FullCoveredCodeElements"], Text: fmt.Sprintf("%d", totals.FullyCoveredMethods), Tooltip: fullMethodCovDefinition, Alignment: "right"},
+			{Header: b.translations["TotalCodeElements"], Text: fmt.Sprintf("%d", totals.TotalMethods), Alignment: "right"},
 			{Header: b.translations["CodeElementCoverageQuota2"], Text: methodCovText, Tooltip: methodCovTooltip, Alignment: "right"},
 			{Header: b.translations["FullCodeElementCoverageQuota2"], Text: fullMethodCovText, Tooltip: fullMethodCovTooltip, Alignment: "right"},
 		},
 	})
 	return cards
 }
+
+// fullMethodCoverageDefinition describes when a method counts as fully covered, see
+// analyzer.FullMethodCoverage.
+func (b *HtmlReportBuilder) fullMethodCoverageDefinition() string {
+	definition := b.fullMethodCoverage
+	if definition.MinimumLineRate == 0 {
+		definition = analyzer.DefaultFullMethodCoverage
+	}
+	if definition.MinimumLineRate >= 1 {
+		if definition.RequiresBranches {
+			return b.translations["FullMethodCoverageAllLinesAndBranches"]
+		}
+		return b.translations["FullMethodCoverageAllLines"]
+	}
+	key := "FullMethodCoverageMinimumLines"
+	if definition.RequiresBranches {
+		key = "FullMethodCoverageMinimumLinesAndBranches"
+	}
+	return fmt.Sprintf(b.translations[key], strconv.FormatFloat(definition.MinimumLineRate*100, 'f', -1, 64)+"%")
+}
+
+// percentageBarValue returns the uncovered part of a quota shown by the percentage bar of
+// a card, 0 if the quota is NaN.
+func percentageBarValue(quota float64) int {
+	if math.IsNaN(quota) {
+		return 0
+	}
+	return 100 - int(math.Round(quota))
+}
+
+// progressBarValueNow returns the aria-valuenow of a percentage bar, or an empty string
+// if the quota is not available.
+func progressBarValueNow(quota float64) string {
+	if math.IsNaN(quota) {
+		return ""
+	}
+	return strconv.FormatFloat(quota, 'f', -1, 64)
+}
@@ -0,0 +1,869 @@
+package htmlreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/analyzer"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/filereader"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+var (
+	historyChartDataRegex = regexp.MustCompile(`var historyChartDataOverall = (.*);`)
+	assembliesJSONRegex   = regexp.MustCompile(`window\.assemblies = (.*);`)
+)
+
+func newTestSummaryBuilder() *HtmlReportBuilder {
+	return &HtmlReportBuilder{
+		ReportContext:                         reporter.NewBuilderContext(nil, settings.NewSettings(), nil),
+		translations:                          GetTranslations(),
+		maximumDecimalPlacesForCoverageQuotas: 1,
+		classReportFilenames:                  make(map[classReportKey]string),
+		tempExistingLowerFilenames:            make(map[string]struct{}),
+		assemblyReportFilenames:               make(map[string]string),
+		fileReader:                            filereader.NewDefaultReader(),
+	}
+}
+
+// TestSummaryPage_EmbedsOverallHistoryChart renders the summary page for a model with
+// three historic snapshots and checks the chart data embedded for custom.js.
+func TestSummaryPage_EmbedsOverallHistoryChart(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local).Unix()
+	t2 := time.Date(2024, 1, 2, 10, 0, 0, 0, time.Local).Unix()
+	t3 := time.Date(2024, 1, 3, 10, 0, 0, 0, time.Local).Unix()
+
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{
+			Name: "MyAssembly",
+			Classes: []model.Class{
+				{
+					Name: "A",
+					HistoricCoverages: []model.HistoricCoverage{
+						{ExecutionTime: t3, CoveredLines: 8, CoverableLines: 10, CoveredBranches: 1, TotalBranches: 2, CoveredMethods: 2, TotalMethods: 2},
+						{ExecutionTime: t1, CoveredLines: 2, CoverableLines: 10, CoveredMethods: 1, TotalMethods: 2},
+						{ExecutionTime: t2, CoveredLines: 5, CoverableLines: 10, CoveredBranches: 0, TotalBranches: 2, CoveredMethods: 1, TotalMethods: 2},
+					},
+				},
+				{
+					Name: "B",
+					HistoricCoverages: []model.HistoricCoverage{
+						{ExecutionTime: t1, CoveredLines: 0, CoverableLines: 10, CoveredMethods: 0, TotalMethods: 2},
+						{ExecutionTime: t2, CoveredLines: 5, CoverableLines: 10, CoveredBranches: 2, TotalBranches: 2, CoveredMethods: 1, TotalMethods: 2},
+						{ExecutionTime: t3, CoveredLines: 10, CoverableLines: 10, CoveredBranches: 2, TotalBranches: 2, CoveredMethods: 2, TotalMethods: 2},
+					},
+				},
+			},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	data, err := b.buildSummaryPageData(report, nil, nil)
+	if err != nil {
+		t.Fatalf("buildSummaryPageData returned error: %v", err)
+	}
+
+	var page bytes.Buffer
+	if err := summaryPageTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render summary page: %v", err)
+	}
+
+	match := historyChartDataRegex.FindSubmatch(page.Bytes())
+	if match == nil {
+		t.Fatalf("summary page does not contain historyChartDataOverall")
+	}
+
+	var chart HistoryChartJSONViewModel
+	if err := json.Unmarshal(match[1], &chart); err != nil {
+		t.Fatalf("embedded chart data is not valid JSON: %v\n%s", err, match[1])
+	}
+
+	wantLabels := []string{
+		time.Unix(t1, 0).Format("2006-01-02 15:04:05"),
+		time.Unix(t2, 0).Format("2006-01-02 15:04:05"),
+		time.Unix(t3, 0).Format("2006-01-02 15:04:05"),
+	}
+	if len(chart.Labels) != 3 {
+		t.Fatalf("expected 3 labels, got %v", chart.Labels)
+	}
+	for i, want := range wantLabels {
+		if chart.Labels[i] != want {
+			t.Errorf("label %d = %q, want %q", i, chart.Labels[i], want)
+		}
+	}
+	if len(chart.Tooltips) != 3 {
+		t.Errorf("expected 3 tooltips, got %d", len(chart.Tooltips))
+	}
+	if len(chart.Series) != 3 {
+		t.Fatalf("expected line, branch and method series, got %d", len(chart.Series))
+	}
+
+	wantSeries := [][]*float64{
+		{floatPtr(10), floatPtr(50), floatPtr(90)},  // line
+		{nil, floatPtr(50), floatPtr(75)},           // branch: first snapshot has no branch data
+		{floatPtr(25), floatPtr(50), floatPtr(100)}, // method
+	}
+	for s, points := range wantSeries {
+		if len(chart.Series[s]) != len(points) {
+			t.Fatalf("series %d: expected %d points, got %d", s, len(points), len(chart.Series[s]))
+		}
+		for i, want := range points {
+			got := chart.Series[s][i]
+			if got.Meta != i {
+				t.Errorf("series %d point %d: meta = %d, want %d", s, i, got.Meta, i)
+			}
+			switch {
+			case want == nil && got.Value != nil:
+				t.Errorf("series %d point %d: expected gap, got %v", s, i, *got.Value)
+			case want != nil && (got.Value == nil || *got.Value != *want):
+				t.Errorf("series %d point %d: got %v, want %v", s, i, got.Value, *want)
+			}
+		}
+	}
+}
+
+// TestSummaryPage_HidesHistoryChartWithoutHistory ensures no chart is emitted when there is no trend to show.
+func TestSummaryPage_HidesHistoryChartWithoutHistory(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{Name: "MyAssembly", Classes: []model.Class{{Name: "A"}}}},
+	}
+
+	b := newTestSummaryBuilder()
+	data, err := b.buildSummaryPageData(report, nil, nil)
+	if err != nil {
+		t.Fatalf("buildSummaryPageData returned error: %v", err)
+	}
+	if data.OverallHistoryChartData.Series {
+		t.Errorf("expected history chart to be hidden")
+	}
+
+	var page bytes.Buffer
+	if err := summaryPageTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render summary page: %v", err)
+	}
+	if historyChartDataRegex.Match(page.Bytes()) {
+		t.Errorf("summary page unexpectedly contains history chart data")
+	}
+}
+
+// TestSummaryPage_AssembliesJSONValidWithNonFiniteValues is a regression test for NaN/Inf
+// values (zero-line classes, NaN complexity) breaking the JSON embedded in index.html.
+func TestSummaryPage_AssembliesJSONValidWithNonFiniteValues(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{
+			Name: "MyAssembly",
+			Classes: []model.Class{
+				{
+					Name:        "MyAssembly.Empty",
+					DisplayName: "MyAssembly.Empty",
+					Metrics:     map[string]float64{"Cyclomatic complexity": math.NaN()},
+				},
+				{
+					Name:         "MyAssembly.Regular",
+					DisplayName:  "MyAssembly.Regular",
+					LinesCovered: 3,
+					LinesValid:   4,
+					Metrics:      map[string]float64{"Cyclomatic complexity": 2, "CrapScore": math.Inf(1)},
+				},
+			},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	angularAssemblies, err := b.buildAngularAssemblyViewModelsForSummary(report)
+	if err != nil {
+		t.Fatalf("buildAngularAssemblyViewModelsForSummary returned error: %v", err)
+	}
+	data, err := b.buildSummaryPageData(report, angularAssemblies, nil)
+	if err != nil {
+		t.Fatalf("buildSummaryPageData returned error: %v", err)
+	}
+
+	var page bytes.Buffer
+	if err := summaryPageTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render summary page: %v", err)
+	}
+
+	match := assembliesJSONRegex.FindSubmatch(page.Bytes())
+	if match == nil {
+		t.Fatalf("summary page does not contain window.assemblies")
+	}
+
+	var assemblies []AngularAssemblyViewModel
+	if err := json.Unmarshal(match[1], &assemblies); err != nil {
+		t.Fatalf("window.assemblies is not valid JSON: %v\n%s", err, match[1])
+	}
+	if len(assemblies) != 1 || len(assemblies[0].Classes) != 2 {
+		t.Fatalf("expected 1 assembly with 2 classes, got %+v", assemblies)
+	}
+
+	empty, regular := assemblies[0].Classes[0], assemblies[0].Classes[1]
+	if _, ok := empty.Metrics["Cyclomatic complexity"]; ok {
+		t.Errorf("NaN metric should be omitted, got %v", empty.Metrics)
+	}
+	if _, ok := regular.Metrics["CrapScore"]; ok {
+		t.Errorf("Inf metric should be omitted, got %v", regular.Metrics)
+	}
+	if regular.Metrics["Cyclomatic complexity"] != 2 {
+		t.Errorf("finite metric should be kept, got %v", regular.Metrics)
+	}
+}
+
+func floatPtr(f float64) *float64 { return &f }
+
+// TestSummaryPage_ListsMissingSourceFiles checks that unresolved source files get their own
+// card, which counts a file referenced by several classes once.
+func TestSummaryPage_ListsMissingSourceFiles(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{Name: "MyAssembly", Classes: []model.Class{{Name: "A"}}}},
+		MissingSourceFiles: []model.MissingSourceFile{
+			{Path: "/build/src/A.cs", Assembly: "MyAssembly", Class: "MyAssembly.A"},
+			{Path: `\build\src\A.cs`, Assembly: "MyAssembly", Class: "MyAssembly.AExtensions"},
+			{Path: "/build/src/B.cs", Assembly: "MyAssembly", Class: "MyAssembly.B"},
+		},
+	}
+
+	b := newTestSummaryBuilder()
+	data, err := b.buildSummaryPageData(report, nil, nil)
+	if err != nil {
+		t.Fatalf("buildSummaryPageData returned error: %v", err)
+	}
+
+	var page bytes.Buffer
+	if err := summaryPageTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render summary page: %v", err)
+	}
+
+	html := page.String()
+	for _, want := range []string{"Missing source files (2)", "2 source file(s) could not be found", "/build/src/A.cs", "MyAssembly.AExtensions"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("summary page does not contain %q", want)
+		}
+	}
+}
+
+// TestSummaryPage_MethodCoverageCard checks that the method coverage card shows the
+// numbers if the reports provide methods and a note instead of 0 of 0 otherwise.
+func TestSummaryPage_MethodCoverageCard(t *testing.T) {
+	tests := []struct {
+		name      string
+		available bool
+		want      []string
+		dontWant  []string
+	}{
+		{"Available", true, []string{`<th scope="row">Covered methods/properties:</th>`}, []string{"<p>Method coverage is not available", "pro-button"}},
+		{"NotProvided", false, []string{"<p>Method coverage is not available, because the coverage reports do not provide methods.</p>"}, []string{`<th scope="row">Covered methods/properties:</th>`, "pro-button"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &model.SummaryResult{
+				Assemblies:              []model.Assembly{{Name: "MyAssembly", Classes: []model.Class{{Name: "A"}}}},
+				MethodCoverageAvailable: tt.available,
+			}
+			files := renderInMemory(t, "Html", report)
+			for _, page := range []string{"index.html", "MyAssemblyA.html"} {
+				html := string(files[page])
+				for _, want := range tt.want {
+					if !strings.Contains(html, want) {
+						t.Errorf("%s does not contain %q", page, want)
+					}
+				}
+				for _, dontWant := range tt.dontWant {
+					if strings.Contains(html, dontWant) {
+						t.Errorf("%s contains %q", page, dontWant)
+					}
+				}
+			}
+			if !regexp.MustCompile(fmt.Sprintf(`window\.methodCoverageAvailable = +%v *;`, tt.available)).Match(files["index.html"]) {
+				t.Errorf("index.html does not set window.methodCoverageAvailable to %v", tt.available)
+			}
+		})
+	}
+}
+
+// TestSummaryPage_ListsSkippedReports checks that report files that could not be parsed
+// get their own card with the parser and the error.
+func TestSummaryPage_ListsSkippedReports(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{Name: "MyAssembly", Classes: []model.Class{{Name: "A"}}}},
+		SkippedReports: []model.SkippedReport{
+			{Path: "reports/broken.xml", Parser: "Cobertura", Error: "XML syntax error on line 12: unexpected EOF"},
+			{Path: "reports/notes.txt", Error: "no parser supports the file"},
+		},
+	}
+
+	b := newTestSummaryBuilder()
+	data, err := b.buildSummaryPageData(report, nil, nil)
+	if err != nil {
+		t.Fatalf("buildSummaryPageData returned error: %v", err)
+	}
+
+	var page bytes.Buffer
+	if err := summaryPageTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render summary page: %v", err)
+	}
+
+	html := page.String()
+	for _, want := range []string{
+		"Skipped report files (2)",
+		"2 report file(s) could not be parsed",
+		`<td class="limit-width" title="reports/broken.xml">reports/broken.xml</td><td>Cobertura</td><td>XML syntax error on line 12: unexpected EOF</td>`,
+		`<td class="limit-width" title="reports/notes.txt">reports/notes.txt</td><td>-</td>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("summary page does not contain %q", want)
+		}
+	}
+}
+
+// TestSummaryPage_RendersDirectoryTree checks the "Coverage by directory" card: the rows
+// are listed parents first with their parent path for collapsing in custom.js.
+func TestSummaryPage_RendersDirectoryTree(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{Name: "MyAssembly", Classes: []model.Class{{Name: "A"}}}},
+		Directories: &model.DirectoryCoverage{
+			Name: "/src/app", Files: 3, LinesCovered: 6, LinesValid: 10, HasBranchData: true, BranchesCovered: 1, BranchesValid: 4,
+			Children: []*model.DirectoryCoverage{
+				{Name: "cmd", Path: "cmd", Files: 1, LinesCovered: 0, LinesValid: 2},
+				{Name: "internal", Path: "internal", Files: 2, LinesCovered: 6, LinesValid: 8, HasBranchData: true, BranchesCovered: 1, BranchesValid: 4,
+					Children: []*model.DirectoryCoverage{
+						{Name: "parser", Path: "internal/parser", Files: 2, LinesCovered: 6, LinesValid: 8, HasBranchData: true, BranchesCovered: 1, BranchesValid: 4},
+					}},
+			},
+		},
+	}
+
+	b := newTestSummaryBuilder()
+	b.branchCoverageAvailable = true
+	data, err := b.buildSummaryPageData(report, nil, nil)
+	if err != nil {
+		t.Fatalf("buildSummaryPageData returned error: %v", err)
+	}
+
+	var paths []string
+	for _, row := range data.DirectoryRows {
+		paths = append(paths, row.ParentPath+">"+row.Path)
+	}
+	if got, want := strings.Join(paths, " "), ">cmd >internal internal>internal/parser"; got != want {
+		t.Errorf("directory rows = %q, want %q", got, want)
+	}
+
+	var page bytes.Buffer
+	if err := summaryPageTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render summary page: %v", err)
+	}
+	html := page.String()
+	for _, want := range []string{
+		"Coverage by directory: /src/app",
+		`<tr data-path="internal/parser" data-parent="internal"><td class="limit-width" title="internal/parser" style="padding-left: 25px">parser</td>`,
+		`<td class="right percentagebar percentagebar30">75%</td><td class="right">25%</td>`,
+		`<td class="right percentagebar percentagebar100">0%</td><td class="right">-</td>`,
+		`class="toggledirectory"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("summary page does not contain %q", want)
+		}
+	}
+}
+
+// TestSummaryPage_OmitsFlatDirectoryTree checks that a tree without subdirectories gets no card.
+func TestSummaryPage_OmitsFlatDirectoryTree(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies:  []model.Assembly{{Name: "MyAssembly", Classes: []model.Class{{Name: "A"}}}},
+		Directories: &model.DirectoryCoverage{Name: "/src", Files: 1, LinesValid: 1},
+	}
+
+	data, err := newTestSummaryBuilder().buildSummaryPageData(report, nil, nil)
+	if err != nil {
+		t.Fatalf("buildSummaryPageData returned error: %v", err)
+	}
+	if data.DirectoryRows != nil {
+		t.Errorf("expected no directory rows, got %+v", data.DirectoryRows)
+	}
+}
+
+// TestSummaryPage_RendersTagLink checks that the tag in the information card links to the build.
+func TestSummaryPage_RendersTagLink(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{Name: "MyAssembly", Classes: []model.Class{{Name: "A"}}}},
+	}
+
+	b := newTestSummaryBuilder()
+	b.tag = "build-42"
+	b.tagLink = "https://ci.example.com/builds/build-42"
+	data, err := b.buildSummaryPageData(report, nil, nil)
+	if err != nil {
+		t.Fatalf("buildSummaryPageData returned error: %v", err)
+	}
+
+	var page bytes.Buffer
+	if err := summaryPageTpl.Execute(&page, data); err != nil {
+		t.Fatalf("failed to render summary page: %v", err)
+	}
+
+	want := `<a href="https://ci.example.com/builds/build-42" target="_blank">build-42</a>`
+	if !strings.Contains(page.String(), want) {
+		t.Errorf("summary page does not contain the tag link %q", want)
+	}
+}
+
+// TestClassReportFilenames_SameClassInTwoAssemblies checks that two assemblies with the same
+// short name and class name get distinct detail pages and that each summary link opens the
+// page of its own class, independent of the assembly order.
+func TestClassReportFilenames_SameClassInTwoAssemblies(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{
+			{Name: "frontend/Lib", Classes: []model.Class{{Name: "Utils", DisplayName: "FrontendUtils"}}},
+			{Name: "backend/Lib", Classes: []model.Class{{Name: "Utils", DisplayName: "BackendUtils"}}},
+		},
+	}
+
+	outputDir := t.TempDir()
+	cfg, err := reportconfig.NewReportConfiguration(nil, outputDir)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+
+	b := newTestSummaryBuilder()
+	b.OutputDir = outputDir
+	b.ReportContext = reporter.NewBuilderContext(cfg, settings.NewSettings(), nil)
+	assemblies, err := b.buildAngularAssemblyViewModelsForSummary(report)
+	if err != nil {
+		t.Fatalf("buildAngularAssemblyViewModelsForSummary returned error: %v", err)
+	}
+	if err := b.renderClassDetailPages(report); err != nil {
+		t.Fatalf("renderClassDetailPages returned error: %v", err)
+	}
+
+	wantFiles := map[string]string{
+		"frontend/Lib": "LibUtils2.html",
+		"backend/Lib":  "LibUtils.html",
+	}
+	wantClass := map[string]string{
+		"frontend/Lib": "FrontendUtils",
+		"backend/Lib":  "BackendUtils",
+	}
+	for _, assembly := range assemblies {
+		if len(assembly.Classes) != 1 {
+			t.Fatalf("assembly %s has %d classes, want 1", assembly.Name, len(assembly.Classes))
+		}
+		link := assembly.Classes[0].ReportPath
+		if link != wantFiles[assembly.Name] {
+			t.Errorf("assembly %s links to %q, want %q", assembly.Name, link, wantFiles[assembly.Name])
+		}
+
+		page, err := os.ReadFile(filepath.Join(b.OutputDir, link))
+		if err != nil {
+			t.Fatalf("failed to read class page %s: %v", link, err)
+		}
+		if !strings.Contains(string(page), wantClass[assembly.Name]) {
+			t.Errorf("class page %s does not belong to %s", link, wantClass[assembly.Name])
+		}
+	}
+}
+
+// TestSummaryCards_SharedFileCountedOnce checks the Files and Total lines rows of the summary
+// cards when two classes share a file: the file is counted once, while each class keeps the
+// full file in its own TotalLines column.
+func TestSummaryCards_SharedFileCountedOnce(t *testing.T) {
+	shared := model.CodeFile{Path: "/app/Shared.cs", TotalLines: 100}
+	report := &model.SummaryResult{
+		TotalLines: 100,
+		Assemblies: []model.Assembly{{
+			Name:       "App",
+			TotalLines: 100,
+			Classes: []model.Class{
+				{Name: "Foo", DisplayName: "Foo", TotalLines: 100, Files: []model.CodeFile{shared}},
+				{Name: "Bar", DisplayName: "Bar", TotalLines: 100, Files: []model.CodeFile{shared}},
+			},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	cards := b.buildSummaryCards(report)
+
+	rows := make(map[string]string)
+	for _, card := range cards {
+		for _, row := range card.Rows {
+			rows[row.Header] = row.Text
+		}
+	}
+	if got := rows[b.translations["Files2"]]; got != "1" {
+		t.Errorf("Files = %q, want %q", got, "1")
+	}
+	if got := rows[b.translations["TotalLines"]]; got != "100" {
+		t.Errorf("Total lines = %q, want %q", got, "100")
+	}
+
+	assemblies, err := b.buildAngularAssemblyViewModelsForSummary(report)
+	if err != nil {
+		t.Fatalf("buildAngularAssemblyViewModelsForSummary returned error: %v", err)
+	}
+	for _, class := range assemblies[0].Classes {
+		if class.TotalLines != 100 {
+			t.Errorf("class %s TotalLines = %d, want 100", class.Name, class.TotalLines)
+		}
+	}
+}
+
+// TestSummaryCards_FullMethodCoverageDefinition checks that the method coverage card
+// explains the configured definition of fully covered methods.
+func TestSummaryCards_FullMethodCoverageDefinition(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition analyzer.FullMethodCoverage
+		want       string
+	}{
+		{"Default", analyzer.FullMethodCoverage{}, "all of its coverable lines are covered"},
+		{"MinimumLineRate", analyzer.FullMethodCoverage{MinimumLineRate: 0.95}, "at least 95% of its coverable lines are covered"},
+		{"RequiresBranches", analyzer.FullMethodCoverage{MinimumLineRate: 0.95, RequiresBranches: true}, "at least 95% of its coverable lines and all of its branches"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &model.SummaryResult{
+				Assemblies: []model.Assembly{{
+					Name:    "App",
+					Classes: []model.Class{{Name: "Foo", DisplayName: "Foo", TotalMethods: 4, CoveredMethods: 3, FullyCoveredMethods: 2}},
+				}},
+			}
+
+			b := newTestSummaryBuilder()
+			b.methodCoverageAvailable = true
+			b.fullMethodCoverage = tt.definition
+			cards := b.buildSummaryCards(report)
+
+			tooltips := make(map[string]string)
+			for _, card := range cards {
+				for _, row := range card.Rows {
+					tooltips[row.Header] = row.Tooltip
+				}
+			}
+			if got := tooltips[b.translations["FullCoveredCodeElements"]]; !strings.Contains(got, tt.want) {
+				t.Errorf("fully covered tooltip = %q, want it to contain %q", got, tt.want)
+			}
+			if got := tooltips[b.translations["FullCodeElementCoverageQuota2"]]; !strings.HasPrefix(got, "2 of 4 - ") || !strings.Contains(got, tt.want) {
+				t.Errorf("full method coverage tooltip = %q, want \"2 of 4 - \" and %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBuildAngularAssemblies_UncoveredLineRanges checks that only the classes with the most
+// uncovered lines carry their uncovered line ranges in the summary data.
+func TestBuildAngularAssemblies_UncoveredLineRanges(t *testing.T) {
+	lines := func(hits ...int) []model.Line {
+		result := make([]model.Line, len(hits))
+		for i, h := range hits {
+			result[i] = model.Line{Number: i + 1, Hits: h}
+		}
+		return result
+	}
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{
+			Name: "Lib",
+			Classes: []model.Class{
+				{Name: "Small", DisplayName: "Small", LinesValid: 2, LinesCovered: 1, Files: []model.CodeFile{{Path: "Small.cs", Lines: lines(1, 0)}}},
+				{Name: "Large", DisplayName: "Large", LinesValid: 5, LinesCovered: 1, Files: []model.CodeFile{{Path: "Large.cs", Lines: lines(0, -1, 0, 1, 0, 0)}}},
+			},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	b.uncoveredLinesClassLimit = 1
+	assemblies, err := b.buildAngularAssemblyViewModelsForSummary(report)
+	if err != nil {
+		t.Fatalf("buildAngularAssemblyViewModelsForSummary returned error: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, class := range assemblies[0].Classes {
+		got[class.Name] = class.UncoveredLineRanges
+	}
+	if got["Large"] != "1-3, 5-6" {
+		t.Errorf("uncovered line ranges of Large = %q, want %q", got["Large"], "1-3, 5-6")
+	}
+	if got["Small"] != "" {
+		t.Errorf("uncovered line ranges of Small = %q, want none beyond the limit", got["Small"])
+	}
+	if !strings.Contains(string(b.assembliesJSON), `"ulr":"1-3, 5-6"`) {
+		t.Errorf("assemblies JSON does not contain the uncovered line ranges: %s", b.assembliesJSON)
+	}
+}
+
+func TestBuildAngularAssemblies_CoverageAge(t *testing.T) {
+	since := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC).Unix()
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{
+			Name: "Lib",
+			Classes: []model.Class{
+				{Name: "Stale", DisplayName: "Stale", LinesValid: 4, LinesCovered: 1, CoverageAge: &model.CoverageAge{Threshold: 75.5, Runs: 4, Since: since}},
+				{Name: "Fresh", DisplayName: "Fresh", LinesValid: 4, LinesCovered: 4},
+			},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	assemblies, err := b.buildAngularAssemblyViewModelsForSummary(report)
+	if err != nil {
+		t.Fatalf("buildAngularAssemblyViewModelsForSummary returned error: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, class := range assemblies[0].Classes {
+		got[class.Name] = class.CoverageAge
+	}
+	if want := "Below 75.5% since 2024-03-02 (4 runs)"; got["Stale"] != want {
+		t.Errorf("coverage age of Stale = %q, want %q", got["Stale"], want)
+	}
+	if got["Fresh"] != "" {
+		t.Errorf("coverage age of Fresh = %q, want none", got["Fresh"])
+	}
+	if !strings.Contains(string(b.assembliesJSON), `"ca":"Below 75.5% since 2024-03-02 (4 runs)"`) {
+		t.Errorf("assemblies JSON does not contain the coverage age: %s", b.assembliesJSON)
+	}
+}
+
+// TestCreateReport_HtmlSummary expects the HtmlSummary report type to write index.html
+// with the summary data, but no class or assembly pages and no links to them.
+func TestCreateReport_HtmlSummary(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg, err := reportconfig.NewReportConfiguration(nil, outputDir, reportconfig.WithReportTypeSpecs("HtmlSummary"))
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	ctx := reporter.NewBuilderContext(cfg, settings.NewSettings(), nil)
+	b := NewHtmlSummaryReportBuilder(outputDir, ctx)
+
+	if err := b.CreateReport(syntheticReport(t, 3, 10)); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+
+	htmlFiles, err := filepath.Glob(filepath.Join(outputDir, "*.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range htmlFiles {
+		if name := filepath.Base(file); name != "index.html" && name != "risk_hotspots.html" {
+			t.Errorf("HtmlSummary wrote the page %s", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "reportgenerator.combined.js")); err != nil {
+		t.Errorf("HtmlSummary did not write the assets: %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read index.html: %v", err)
+	}
+	match := assembliesJSONRegex.FindSubmatch(index)
+	if match == nil {
+		t.Fatalf("index.html does not contain window.assemblies")
+	}
+	var assemblies []AngularAssemblyViewModel
+	if err := json.Unmarshal(match[1], &assemblies); err != nil {
+		t.Fatalf("window.assemblies is not valid JSON: %v\n%s", err, match[1])
+	}
+	if len(assemblies) != 1 || len(assemblies[0].Classes) != 3 {
+		t.Fatalf("expected 1 assembly with 3 classes, got %+v", assemblies)
+	}
+	if assemblies[0].ReportPath != "" {
+		t.Errorf("assembly links to %q, want no link", assemblies[0].ReportPath)
+	}
+	for _, class := range assemblies[0].Classes {
+		if class.ReportPath != "" {
+			t.Errorf("class %s links to %q, want no link", class.Name, class.ReportPath)
+		}
+	}
+}
+
+// TestBuildAngularAssemblies_ClassFiles checks that the summary data lists the files of a
+// class with the anchor ids of their class page sections.
+func TestBuildAngularAssemblies_ClassFiles(t *testing.T) {
+	report := &model.SummaryResult{
+		Assemblies: []model.Assembly{{
+			Name: "Shop",
+			Classes: []model.Class{{
+				Name:        "Shop.Cart",
+				DisplayName: "Shop.Cart",
+				Files: []model.CodeFile{
+					{Path: "/src/Shop/Cart.cs"},
+					{Path: "/src/Shop/Cart Partial.cs"},
+				},
+			}},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	if _, err := b.buildAngularAssemblyViewModelsForSummary(report); err != nil {
+		t.Fatalf("buildAngularAssemblyViewModelsForSummary returned error: %v", err)
+	}
+
+	want := `"files":[{"id":"Cart.cs","path":"/src/Shop/Cart.cs"},{"id":"Cart_Partial.cs","path":"/src/Shop/Cart Partial.cs"}]`
+	if !strings.Contains(string(b.assembliesJSON), want) {
+		t.Errorf("assemblies JSON does not contain %s: %s", want, b.assembliesJSON)
+	}
+}
+
+// TestBuildAngularAssemblies_ExternalFiles checks that the files outside the source
+// directories and the classes made of them only are marked, and counted on the
+// information card, if the report has external files.
+func TestBuildAngularAssemblies_ExternalFiles(t *testing.T) {
+	report := &model.SummaryResult{
+		ExternalFiles: 1,
+		Assemblies: []model.Assembly{{
+			Name: "Shop",
+			Classes: []model.Class{
+				{Name: "Shop.Cart", DisplayName: "Shop.Cart", Files: []model.CodeFile{{Path: "/src/Shop/Cart.cs", InSourceDirs: true}}},
+				{Name: "Shop.Generated", DisplayName: "Shop.Generated", Files: []model.CodeFile{{Path: "/obj/Generated.cs"}}},
+			},
+		}},
+	}
+
+	b := newTestSummaryBuilder()
+	b.markExternalFiles = true
+	assemblies, err := b.buildAngularAssemblyViewModelsForSummary(report)
+	if err != nil {
+		t.Fatalf("buildAngularAssemblyViewModelsForSummary returned error: %v", err)
+	}
+
+	for _, class := range assemblies[0].Classes {
+		want := class.Name == "Shop.Generated"
+		if class.External != want || class.Files[0].External != want {
+			t.Errorf("class %s: External = %v, file External = %v, want %v", class.Name, class.External, class.Files[0].External, want)
+		}
+	}
+	if want := `{"id":"Generated.cs","path":"/obj/Generated.cs","external":true}`; !strings.Contains(string(b.assembliesJSON), want) {
+		t.Errorf("assemblies JSON does not contain %s: %s", want, b.assembliesJSON)
+	}
+
+	rows := make(map[string]string)
+	for _, row := range b.buildSummaryCards(report)[0].Rows {
+		rows[row.Header] = row.Text
+	}
+	if got := rows[b.translations["ExternalFiles"]]; got != "1" {
+		t.Errorf("External files = %q, want %q", got, "1")
+	}
+
+	b = newTestSummaryBuilder()
+	if _, err := b.buildAngularAssemblyViewModelsForSummary(report); err != nil {
+		t.Fatalf("buildAngularAssemblyViewModelsForSummary returned error: %v", err)
+	}
+	if strings.Contains(string(b.assembliesJSON), `"ex"`) || strings.Contains(string(b.assembliesJSON), `"external"`) {
+		t.Errorf("assemblies JSON marks external files although they are not marked: %s", b.assembliesJSON)
+	}
+}
+
+// TestBuildAngularClass_LimitsHistoricCoverages checks that only the newest history entries
+// of a class are embedded, that the older ones are summarized as a band and that the
+// execution times offered for comparison match the embedded entries.
+func TestBuildAngularClass_LimitsHistoricCoverages(t *testing.T) {
+	var history []model.HistoricCoverage
+	for day := 1; day <= 5; day++ {
+		history = append(history, model.HistoricCoverage{
+			ExecutionTime:  time.Date(2024, 1, day, 10, 0, 0, 0, time.Local).Unix(),
+			CoveredLines:   []int{4, 1, 6, 7, 9}[day-1],
+			CoverableLines: 10,
+		})
+	}
+	report := &model.SummaryResult{Assemblies: []model.Assembly{{
+		Name:    "Shop",
+		Classes: []model.Class{{Name: "Shop.Cart", DisplayName: "Shop.Cart", HistoricCoverages: history}},
+	}}}
+
+	b := newTestSummaryBuilder()
+	b.maximumHistoricCoveragesPerClass = 2
+	class := b.buildAngularClassViewModelForSummary(&report.Assemblies[0].Classes[0], "ShopCart.html")
+
+	if !slices.Equal(class.LineCoverageHistory, []float64{70, 90}) {
+		t.Errorf("line coverage history = %v, want [70 90]", class.LineCoverageHistory)
+	}
+	want := AngularHistoricCoverageBandViewModel{
+		Count: 3, From: "2024-01-01 10:00:00", To: "2024-01-03 10:00:00",
+		LineCoverageQuotaMin: 10, LineCoverageQuotaMax: 60,
+		BranchCoverageQuotaMin: -1, BranchCoverageQuotaMax: -1,
+	}
+	if class.HistoricCoverageBand == nil || *class.HistoricCoverageBand != want {
+		t.Errorf("band = %+v, want %+v", class.HistoricCoverageBand, want)
+	}
+
+	executionTimes := b.collectHistoricExecutionTimes(report)
+	var embedded []string
+	for _, hc := range class.HistoricCoverages {
+		embedded = append(embedded, hc.ExecutionTime)
+	}
+	if !slices.Equal(executionTimes, embedded) {
+		t.Errorf("execution times = %v, want the embedded entries %v", executionTimes, embedded)
+	}
+
+	b.maximumHistoricCoveragesPerClass = 0
+	if unlimited := b.buildAngularClassViewModelForSummary(&report.Assemblies[0].Classes[0], "ShopCart.html"); len(unlimited.HistoricCoverages) != 5 || unlimited.HistoricCoverageBand != nil {
+		t.Errorf("expected all 5 entries and no band without a limit, got %d entries and band %+v", len(unlimited.HistoricCoverages), unlimited.HistoricCoverageBand)
+	}
+}
+
+// TestCreateReport_AssemblyPages checks that every assembly gets a page with the summary
+// cards and the classes of that assembly only, linked from index.html, and that the
+// filenames of the class pages are the same as without assembly pages.
+func TestCreateReport_AssemblyPages(t *testing.T) {
+	report := &model.SummaryResult{
+		ParserName:   "Cobertura",
+		LinesCovered: 3,
+		LinesValid:   6,
+		Assemblies: []model.Assembly{
+			{Name: "Shop/Core", LinesCovered: 1, LinesValid: 4, Classes: []model.Class{
+				{Name: "Shop.Cart", DisplayName: "Shop.Cart", LinesCovered: 1, LinesValid: 2},
+				{Name: "Shop.Price", DisplayName: "Shop.Price", LinesCovered: 0, LinesValid: 2},
+			}},
+			{Name: "Shop.Web", LinesCovered: 2, LinesValid: 2, Classes: []model.Class{
+				{Name: "Shop.Web.Home", DisplayName: "Shop.Web.Home", LinesCovered: 2, LinesValid: 2},
+			}},
+		},
+	}
+
+	files := renderInMemory(t, "Html", report)
+
+	for _, name := range []string{"CoreCart.html", "CorePrice.html", "Shop.WebHome.html"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("expected the class page %s", name)
+		}
+	}
+	core, ok := files["assembly_Shop_Core.html"]
+	if !ok {
+		t.Fatalf("expected the page of Shop/Core with a sanitized filename, got %v", slices.Sorted(maps.Keys(files)))
+	}
+	if _, ok := files["assembly_Shop.Web.html"]; !ok {
+		t.Errorf("expected the page of Shop.Web")
+	}
+
+	index := string(files["index.html"])
+	for _, want := range []string{`<a href="assembly_Shop_Core.html">Shop/Core</a>`, `"rp":"assembly_Shop.Web.html"`} {
+		if !strings.Contains(index, want) {
+			t.Errorf("expected index.html to link the assembly page with %s", want)
+		}
+	}
+
+	page := string(core)
+	for _, want := range []string{`<h1>Assembly: Shop/Core</h1>`, `<tr><th scope="row">Classes:</th><td class="limit-width right" title="">2</td></tr>`, `"name":"Shop.Cart"`, `25%`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected the assembly page to contain %s", want)
+		}
+	}
+	for _, unwanted := range []string{"Shop.Web.Home", "<risk-hotspots>", `class="card assemblies"`} {
+		if strings.Contains(page, unwanted) {
+			t.Errorf("expected the assembly page not to contain %s", unwanted)
+		}
+	}
+}
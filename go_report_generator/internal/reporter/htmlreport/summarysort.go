@@ -0,0 +1,140 @@
+package htmlreport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Keys of the summarysort setting, e.g. "uncoveredlines:desc".
+const (
+	summarySortName           = "name"
+	summarySortLineCoverage   = "linecoverage"
+	summarySortBranchCoverage = "branchcoverage"
+	summarySortUncoveredLines = "uncoveredlines"
+)
+
+// summarySortColumns maps the keys of the summarysort setting to the sortBy values of the
+// class table of the Angular app.
+var summarySortColumns = map[string]string{
+	summarySortName:           "name",
+	summarySortLineCoverage:   "coverage",
+	summarySortBranchCoverage: "branchcoverage",
+	summarySortUncoveredLines: "uncovered",
+}
+
+// SummarySort is the order of the classes of each assembly in the summary data.
+type SummarySort struct {
+	Key        string // "" keeps the order of the report
+	Descending bool
+}
+
+// ParseSummarySort parses "<key>[:asc|desc]", where the key is name, linecoverage,
+// branchcoverage or uncoveredlines. The order defaults to asc; "" sorts nothing.
+func ParseSummarySort(value string) (SummarySort, error) {
+	key, order, hasOrder := strings.Cut(strings.ToLower(strings.TrimSpace(value)), ":")
+	if key == "" && !hasOrder {
+		return SummarySort{}, nil
+	}
+	if _, ok := summarySortColumns[key]; !ok {
+		return SummarySort{}, fmt.Errorf("invalid summary sort %q (expected name, linecoverage, branchcoverage or uncoveredlines, optionally followed by :asc or :desc)", value)
+	}
+	switch order {
+	case "", "asc":
+		return SummarySort{Key: key}, nil
+	case "desc":
+		return SummarySort{Key: key, Descending: true}, nil
+	default:
+		return SummarySort{}, fmt.Errorf("invalid order %q of summary sort %q (expected asc or desc)", order, value)
+	}
+}
+
+func (s SummarySort) String() string {
+	if s.Key == "" {
+		return ""
+	}
+	if s.Descending {
+		return s.Key + ":desc"
+	}
+	return s.Key + ":asc"
+}
+
+// angularSortViewModel returns the initial sorting of the class table, nil to keep the
+// default of the Angular app.
+func (s SummarySort) angularSortViewModel() *AngularSummarySortViewModel {
+	if s.Key == "" {
+		return nil
+	}
+	order := "asc"
+	if s.Descending {
+		order = "desc"
+	}
+	return &AngularSummarySortViewModel{SortBy: summarySortColumns[s.Key], SortOrder: order}
+}
+
+// sortClasses sorts the classes of an assembly in place. Classes without a value for
+// the key, e.g. without coverable lines for linecoverage, come last in both orders, so
+// that a limited table shows the classes that have one. Ties keep the order by name.
+func (s SummarySort) sortClasses(classes []AngularClassViewModel) {
+	if s.Key == "" {
+		return
+	}
+	value := func(c *AngularClassViewModel) (float64, bool) {
+		switch s.Key {
+		case summarySortLineCoverage:
+			return float64(c.CoveredLines) / float64(c.CoverableLines), c.CoverableLines > 0
+		case summarySortBranchCoverage:
+			return float64(c.CoveredBranches) / float64(c.TotalBranches), c.TotalBranches > 0
+		case summarySortUncoveredLines:
+			return float64(c.UncoveredLines), true
+		}
+		return 0, true
+	}
+	sort.SliceStable(classes, func(i, j int) bool {
+		left, right := &classes[i], &classes[j]
+		leftValue, leftOk := value(left)
+		rightValue, rightOk := value(right)
+		switch {
+		case leftOk != rightOk:
+			return leftOk
+		case leftValue != rightValue:
+			return (leftValue < rightValue) != s.Descending
+		case left.Name != right.Name:
+			return (left.Name < right.Name) != (s.Descending && s.Key == summarySortName)
+		}
+		return false
+	})
+}
+
+// limitSummaryClasses returns the assemblies with at most topN classes each, for the data
+// of index.html. The classes left out are replaced by a marker with their number and
+// totals, so that the rows of the assemblies still sum up all classes, and the link to
+// the page of the assembly that lists them. topN 0 keeps all classes.
+func limitSummaryClasses(assemblies []AngularAssemblyViewModel, topN int) []AngularAssemblyViewModel {
+	if topN <= 0 {
+		return assemblies
+	}
+	limited := make([]AngularAssemblyViewModel, len(assemblies))
+	for i, assembly := range assemblies {
+		limited[i] = assembly
+		if len(assembly.Classes) <= topN {
+			continue
+		}
+		more := &AngularMoreClassesViewModel{ReportPath: assembly.ReportPath}
+		for _, class := range assembly.Classes[topN:] {
+			more.Count++
+			more.CoveredLines += class.CoveredLines
+			more.UncoveredLines += class.UncoveredLines
+			more.CoverableLines += class.CoverableLines
+			more.TotalLines += class.TotalLines
+			more.CoveredBranches += class.CoveredBranches
+			more.TotalBranches += class.TotalBranches
+			more.CoveredMethods += class.CoveredMethods
+			more.FullyCoveredMethods += class.FullyCoveredMethods
+			more.TotalMethods += class.TotalMethods
+		}
+		limited[i].Classes = assembly.Classes[:topN]
+		limited[i].More = more
+	}
+	return limited
+}
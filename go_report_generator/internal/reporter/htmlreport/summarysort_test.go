@@ -0,0 +1,177 @@
+package htmlreport
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+func TestParseSummarySort(t *testing.T) {
+	tests := []struct {
+		value string
+		want  SummarySort
+	}{
+		{"", SummarySort{}},
+		{"name", SummarySort{Key: "name"}},
+		{"LineCoverage:asc", SummarySort{Key: "linecoverage"}},
+		{" uncoveredlines:desc ", SummarySort{Key: "uncoveredlines", Descending: true}},
+		{"branchcoverage:DESC", SummarySort{Key: "branchcoverage", Descending: true}},
+	}
+	for _, tt := range tests {
+		got, err := ParseSummarySort(tt.value)
+		if err != nil {
+			t.Errorf("ParseSummarySort(%q) returned error: %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSummarySort(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+		if again, _ := ParseSummarySort(got.String()); again != got {
+			t.Errorf("ParseSummarySort(%q) = %+v after String(), want %+v", got.String(), again, got)
+		}
+	}
+
+	for _, value := range []string{"coverage", "name:up", ":desc", "name:asc:desc"} {
+		if _, err := ParseSummarySort(value); err == nil {
+			t.Errorf("ParseSummarySort(%q) expected an error", value)
+		}
+	}
+}
+
+func TestSummarySort_ClassesWithoutValueComeLast(t *testing.T) {
+	classes := []AngularClassViewModel{
+		{Name: "Interface"},
+		{Name: "Half", CoveredLines: 1, CoverableLines: 2},
+		{Name: "Full", CoveredLines: 2, CoverableLines: 2},
+		{Name: "AlsoHalf", CoveredLines: 2, CoverableLines: 4},
+	}
+	for _, tt := range []struct {
+		sort SummarySort
+		want []string
+	}{
+		{SummarySort{Key: summarySortLineCoverage}, []string{"AlsoHalf", "Half", "Full", "Interface"}},
+		{SummarySort{Key: summarySortLineCoverage, Descending: true}, []string{"Full", "AlsoHalf", "Half", "Interface"}},
+		{SummarySort{Key: summarySortName, Descending: true}, []string{"Interface", "Half", "Full", "AlsoHalf"}},
+		{SummarySort{}, []string{"Interface", "Half", "Full", "AlsoHalf"}},
+	} {
+		sorted := slices.Clone(classes)
+		tt.sort.sortClasses(sorted)
+		if got := classNames(sorted); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.sort.String(), got, tt.want)
+		}
+	}
+}
+
+// TestCreateReport_SummaryTopN checks that index.html embeds the classes with the most
+// uncovered lines first, at most two per assembly, and that the marker of the others
+// keeps the totals of the assembly complete. The page of the assembly lists all classes.
+func TestCreateReport_SummaryTopN(t *testing.T) {
+	branches := func(n int) *int { return &n }
+	report := &model.SummaryResult{
+		ParserName:   "Cobertura",
+		LinesCovered: 13,
+		LinesValid:   30,
+		Assemblies: []model.Assembly{
+			{Name: "Shop", LinesCovered: 11, LinesValid: 26, BranchesCovered: branches(3), BranchesValid: branches(8), Classes: []model.Class{
+				{Name: "Shop.Cart", DisplayName: "Shop.Cart", LinesCovered: 1, LinesValid: 4, TotalLines: 10, BranchesCovered: branches(1), BranchesValid: branches(4), CoveredMethods: 1, TotalMethods: 2},
+				{Name: "Shop.Order", DisplayName: "Shop.Order", LinesCovered: 2, LinesValid: 10, TotalLines: 20, BranchesCovered: branches(2), BranchesValid: branches(4), CoveredMethods: 2, TotalMethods: 3},
+				{Name: "Shop.Price", DisplayName: "Shop.Price", LinesCovered: 3, LinesValid: 5, TotalLines: 8, CoveredMethods: 1, FullyCoveredMethods: 1, TotalMethods: 1},
+				{Name: "Shop.Tax", DisplayName: "Shop.Tax", LinesCovered: 5, LinesValid: 7, TotalLines: 9, CoveredMethods: 2, FullyCoveredMethods: 1, TotalMethods: 2},
+			}},
+			{Name: "Web", LinesCovered: 2, LinesValid: 4, Classes: []model.Class{
+				{Name: "Web.Home", DisplayName: "Web.Home", LinesCovered: 2, LinesValid: 4},
+			}},
+		},
+	}
+
+	cfg, err := reportconfig.NewReportConfiguration(nil, t.TempDir(), reportconfig.WithReportTypeSpecs("Html"))
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	appSettings := settings.NewSettings()
+	appSettings.SummarySort = "uncoveredlines:desc"
+	appSettings.SummaryTopN = 2
+	ctx := reporter.NewBuilderContext(cfg, appSettings, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	files, err := NewHtmlReportBuilder("", ctx).CreateReportInMemory(report)
+	if err != nil {
+		t.Fatalf("CreateReportInMemory returned error: %v", err)
+	}
+
+	index := string(files["index.html"])
+	assemblies := embeddedAssemblies(t, index)
+	if len(assemblies) != 2 {
+		t.Fatalf("expected 2 assemblies in index.html, got %d", len(assemblies))
+	}
+	shop := assemblies[0]
+	if got, want := classNames(shop.Classes), []string{"Shop.Order", "Shop.Cart"}; !slices.Equal(got, want) {
+		t.Errorf("classes of Shop in index.html = %v, want %v", got, want)
+	}
+	if shop.More == nil {
+		t.Fatalf("expected the marker of the classes left out of Shop")
+	}
+	if shop.More.Count != 2 || shop.More.ReportPath != "assembly_Shop.html" {
+		t.Errorf("marker = %+v, want 2 classes linking assembly_Shop.html", *shop.More)
+	}
+	if assemblies[1].More != nil {
+		t.Errorf("expected no marker for Web, which has fewer classes than the limit")
+	}
+
+	// The embedded classes and the marker add up to the totals of all classes.
+	got := *shop.More
+	for _, class := range shop.Classes {
+		got.CoveredLines += class.CoveredLines
+		got.CoverableLines += class.CoverableLines
+		got.UncoveredLines += class.UncoveredLines
+		got.TotalLines += class.TotalLines
+		got.CoveredBranches += class.CoveredBranches
+		got.TotalBranches += class.TotalBranches
+		got.CoveredMethods += class.CoveredMethods
+		got.FullyCoveredMethods += class.FullyCoveredMethods
+		got.TotalMethods += class.TotalMethods
+	}
+	want := AngularMoreClassesViewModel{Count: 2, ReportPath: "assembly_Shop.html", CoveredLines: 11, CoverableLines: 26, UncoveredLines: 15, TotalLines: 47, CoveredBranches: 3, TotalBranches: 8, CoveredMethods: 6, FullyCoveredMethods: 2, TotalMethods: 8}
+	if got != want {
+		t.Errorf("totals of Shop in index.html = %+v, want %+v", got, want)
+	}
+
+	if !strings.Contains(index, `window.summarySort = {"sortBy":"uncovered","sortOrder":"desc"};`) {
+		t.Errorf("expected index.html to start the class table sorted by the uncovered lines")
+	}
+	if !strings.Contains(index, `<a href="assembly_Shop.html">Shop</a></td><td class="right">4</td><td class="right">11</td><td class="right">26</td>`) {
+		t.Errorf("expected the assemblies card to count all classes of Shop")
+	}
+
+	page := embeddedAssemblies(t, string(files["assembly_Shop.html"]))
+	if got, want := classNames(page[0].Classes), []string{"Shop.Order", "Shop.Cart", "Shop.Price", "Shop.Tax"}; !slices.Equal(got, want) || page[0].More != nil {
+		t.Errorf("classes of the assembly page = %v (marker %v), want all of %v", got, page[0].More, want)
+	}
+}
+
+func embeddedAssemblies(t *testing.T, page string) []AngularAssemblyViewModel {
+	t.Helper()
+	match := assembliesJSONRegex.FindStringSubmatch(page)
+	if match == nil {
+		t.Fatalf("window.assemblies not found")
+	}
+	var assemblies []AngularAssemblyViewModel
+	if err := json.Unmarshal([]byte(match[1]), &assemblies); err != nil {
+		t.Fatalf("invalid window.assemblies: %v", err)
+	}
+	return assemblies
+}
+
+func classNames(classes []AngularClassViewModel) []string {
+	names := make([]string, len(classes))
+	for i, class := range classes {
+		names[i] = class.Name
+	}
+	return names
+}
@@ -3,7 +3,10 @@ package htmlreport
 import (
 	"html"
 	"html/template"
+	"strconv"
 	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/htmlreport/highlight"
 )
 
 const summaryPageLayoutTemplate = `<!DOCTYPE html>
@@ -13,7 +16,7 @@ const summaryPageLayoutTemplate = `<!DOCTYPE html>
 <meta name="viewport" content="width=device-width, initial-scale=1.0" />
 <meta http-equiv="X-UA-Compatible" content="IE=EDGE,chrome=1" />
 <link href="data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAACAAAAAgCAMAAABEpIrGAAAAn1BMVEUAAADCAAAAAAA3yDfUAAA3yDfUAAA8PDzr6+sAAAD4+Pg3yDeQkJDTAADt7e3V1dU3yDdCQkIAAADbMTHUAABBykHUAAA2yDY3yDfr6+vTAAB3diDR0dGYcHDUAAAjhiPSAAA3yDeuAADUAAA3yDf////OCALg9+BLzktBuzRelimzKgv87+/dNTVflSn1/PWz6rO126g5yDlYniy0KgwjJ0TyAAAAI3RSTlMABAj0WD6rJcsN7X1HzMqUJyYW+/X08+bltqSeaVRBOy0cE+citBEAAADBSURBVDjLlczXEoIwFIThJPYGiL0XiL3r+z+bBOJs9JDMuLffP8v+Gxfc6aIyDQVjQcnqnvRDEQwLJYtXpZT+YhDHKIjLbS+OUeT4TjkKi6OwOArq+yeKXD9uDqQQbcOjyCy0e6bTojZSftX+U6zUQ7OuittDu1k0WHqRFfdXQijgjKfF6ZwAikvmKD6OQjmKWUcDigkztm5FZN05nMON9ZcoinlBmTNnAUdBnRbUUbgdBZwWbkcBpwXcVsBtxfjb31j1QB5qeebOAAAAAElFTkSuQmCC" rel="icon" type="image/x-icon" />
-<title>{{.ReportTitle}} - {{.Translations.CoverageReport}}</title>
+<title>{{if .AssemblyName}}{{.AssemblyName}} - {{end}}{{.ReportTitle}} - {{.Translations.CoverageReport}}</title>
 <link rel="stylesheet" type="text/css" href="report.css" />
 <link rel="stylesheet" type="text/css" href="chartist.min.css"/>
 <link rel="stylesheet" type="text/css" href="{{.AngularCssFile}}">
@@ -27,6 +30,9 @@ const summaryPageLayoutTemplate = `<!DOCTYPE html>
         window.riskHotspotMetrics = {{.RiskHotspotMetricsJSON}}; 
         window.historicCoverageExecutionTimes = {{.HistoricCoverageExecutionTimesJSON}}; 
         window.translations = {{.TranslationsJSON}}; 
+        {{- if .SummarySort}}
+        window.summarySort = {{.SummarySort}};
+        {{- end}}
 
         window.branchCoverageAvailable = {{.BranchCoverageAvailable}};
         window.methodCoverageAvailable = {{.MethodCoverageAvailable}};
@@ -35,11 +41,16 @@ const summaryPageLayoutTemplate = `<!DOCTYPE html>
 
     <div class="container">
         <div class="containerleft">
+            {{if .AssemblyName}}
+            <h1><a href="index.html" class="back">&lt;</a> {{.Translations.Summary}}</h1>
+            <h1>{{.Translations.Assembly}}: {{.AssemblyName}}</h1>
+            {{else}}
             <h1>{{.ReportTitle}}
                 <!-- GitHub Buttons (from C# original) -->
                 <a class="button" href="https://github.com/danielpalme/ReportGenerator" title="{{.Translations.StarTooltip}}"><i class="icon-star"></i>{{.Translations.Star}}</a>
                 <a class="button" href="https://github.com/sponsors/danielpalme" title="{{.Translations.SponsorTooltip}}"><i class="icon-sponsor"></i>{{.Translations.Sponsor}}</a>
             </h1>
+            {{end}}
             
             <!-- Summary Cards -->
             <div class="card-group">
@@ -47,45 +58,115 @@ const summaryPageLayoutTemplate = `<!DOCTYPE html>
                 <div class="card">
                     <div class="card-header">{{.Title}}</div>
                     <div class="card-body">
-                        {{if .ProRequired}}
+                        {{if .Note}}
                         <div class="center">
-                            <p>{{$.Translations.MethodCoverageProVersion}}</p>
-                            <a class="pro-button" href="https://reportgenerator.io/pro" target="_blank">{{$.Translations.MethodCoverageProButton}}</a>
+                            <p>{{.Note}}</p>
                         </div>
                         {{else}}
                             {{if .SubTitle}}
-                            <div class="large cardpercentagebar cardpercentagebar{{.SubTitlePercentageBarValue}}">{{.SubTitle}}</div>
+                            <div class="large cardpercentagebar cardpercentagebar{{.SubTitlePercentageBarValue}}" role="progressbar" aria-valuemin="0" aria-valuemax="100"{{with .SubTitleQuota}} aria-valuenow="{{.}}"{{end}} aria-label="{{.Title}}">{{.SubTitle}}</div>
                             {{end}}
                             <div class="table">
                                 <table>
                                     {{range .Rows}}
-                                    <tr><th>{{.Header}}:</th><td class="limit-width {{if eq .Alignment "right"}}right{{end}}" title="{{.Tooltip}}">{{.Text}}</td></tr>
+                                    <tr><th scope="row">{{.Header}}:</th><td class="limit-width {{if eq .Alignment "right"}}right{{end}}" title="{{.Tooltip}}">{{if .Href}}<a href="{{.Href}}" target="_blank">{{.Text}}</a>{{else}}{{.Text}}{{end}}</td></tr>
                                     {{end}}
                                 </table>
                             </div>
+                            {{if .Footnote}}<p><small>* {{.Footnote}}</small></p>{{end}}
                         {{end}}
                     </div>
                 </div>
                 {{end}}
             </div>
 
+            <!-- Missing Source Files -->
+            {{if .MissingSourceFiles}}
+            <div class="card-group">
+                <div class="card missingsourcefiles">
+                    <details>
+                        <summary class="card-header">{{.Translations.MissingSourceFiles}} ({{.MissingSourceCount}})</summary>
+                        <p>{{printf .Translations.MissingSourceFilesHint .MissingSourceCount}}</p>
+                        <div class="table">
+                            <table>
+                                <tr><th scope="col">{{.Translations.File}}</th><th scope="col">{{.Translations.ReferencedBy}}</th></tr>
+                                {{range .MissingSourceFiles}}
+                                <tr><td class="limit-width" title="{{.Path}}">{{.Path}}</td><td title="{{.Assembly}}">{{.Class}}</td></tr>
+                                {{end}}
+                            </table>
+                        </div>
+                    </details>
+                </div>
+            </div>
+            {{end}}{{if .SkippedReports}}
+            <!-- Skipped Report Files -->
+            <div class="card-group">
+                <div class="card skippedreports">
+                    <details open>
+                        <summary class="card-header">{{.Translations.SkippedReports}} ({{len .SkippedReports}})</summary>
+                        <p>{{printf .Translations.SkippedReportsHint (len .SkippedReports)}}</p>
+                        <div class="table">
+                            <table>
+                                <tr><th scope="col">{{.Translations.ReportFile}}</th><th scope="col">{{.Translations.Parser}}</th><th scope="col">{{.Translations.Error}}</th></tr>
+                                {{range .SkippedReports}}
+                                <tr><td class="limit-width" title="{{.Path}}">{{.Path}}</td><td>{{if .Parser}}{{.Parser}}{{else}}-{{end}}</td><td>{{.Error}}</td></tr>
+                                {{end}}
+                            </table>
+                        </div>
+                    </details>
+                </div>
+            </div>
+            {{end}}{{if .DirectoryRows}}
+            <!-- Coverage by Directory -->
+            <div class="card-group">
+                <div class="card directorycoverage">
+                    <details>
+                        <summary class="card-header">{{.Translations.CoverageByDirectory}}{{if .DirectoryTreeRoot}}: {{.DirectoryTreeRoot}}{{end}}</summary>
+                        <div class="table">
+                            <table>
+                                <tr><th scope="col">{{.Translations.Directory}}</th><th scope="col" class="right">{{.Translations.Files2}}</th><th scope="col" class="right">{{.Translations.CoveredLines}}</th><th scope="col" class="right">{{.Translations.CoverableLines}}</th><th scope="col" class="right">{{.Translations.LineCoverage}}</th>{{if .DirectoryBranchCoverage}}<th scope="col" class="right">{{.Translations.BranchCoverage}}</th>{{end}}</tr>
+                                {{range .DirectoryRows}}
+                                <tr data-path="{{.Path}}" data-parent="{{.ParentPath}}"><td class="limit-width" title="{{.Path}}" style="padding-left: {{.Indent}}px">{{if .HasChildren}}<a href="#" class="toggledirectory" title="{{$.Translations.CollapseExpandDirectory}}" aria-label="{{$.Translations.CollapseExpandDirectory}}"><i class="icon-minus" aria-hidden="true"></i></a>{{end}}{{.Name}}</td><td class="right">{{.Files}}</td><td class="right">{{.CoveredLines}}</td><td class="right">{{.CoverableLines}}</td><td class="right percentagebar percentagebar{{.CoverageBar}}">{{.LineCoverage}}</td>{{if $.DirectoryBranchCoverage}}<td class="right">{{.BranchCoverage}}</td>{{end}}</tr>
+                                {{end}}
+                            </table>
+                        </div>
+                    </details>
+                </div>
+            </div>{{end}}{{if .AssemblyRows}}
+            <!-- Assemblies, linking to their pages -->
+            <div class="card-group">
+                <div class="card assemblies">
+                    <details>
+                        <summary class="card-header">{{.Translations.Assemblies2}} ({{len .AssemblyRows}})</summary>
+                        <div class="table">
+                            <table>
+                                <tr><th scope="col">{{.Translations.Assembly}}</th><th scope="col" class="right">{{.Translations.Classes}}</th><th scope="col" class="right">{{.Translations.CoveredLines}}</th><th scope="col" class="right">{{.Translations.CoverableLines}}</th><th scope="col" class="right">{{.Translations.LineCoverage}}</th></tr>
+                                {{range .AssemblyRows}}
+                                <tr><td class="limit-width" title="{{.Name}}"><a href="{{.ReportPath}}">{{.Name}}</a></td><td class="right">{{.Classes}}</td><td class="right">{{.CoveredLines}}</td><td class="right">{{.CoverableLines}}</td><td class="right percentagebar percentagebar{{.CoverageBar}}">{{.LineCoverage}}</td></tr>
+                                {{end}}
+                            </table>
+                        </div>
+                    </details>
+                </div>
+            </div>{{end}}
+
             <!-- Overall History Chart -->
             {{if .OverallHistoryChartData.Series}}
                 <h1>{{.Translations.History}}</h1>
-                <!-- The SVG is rendered directly by Go, Chartist.js might not be needed for this if SVG is static -->
                 <div class="historychart ct-chart" data-data="historyChartDataOverall">{{.OverallHistoryChartData.SVGContent | SafeHTML}}</div>
-                <!-- If custom.js or Angular needs the data for interactivity with this chart: -->
-                <!-- <script type="text/javascript">/* <![CDATA[ */ 
-                // var historyChartDataOverall = {{.OverallHistoryChartData.JSONData | SafeJS}};
-                // /* ]]> */ </script> -->
+                <script type="text/javascript">
+                    var historyChartDataOverall = {{.OverallHistoryChartData.JSONData}};
+                </script>
             {{end}}
 
+            {{if not .AssemblyName}}
             <!-- Risk Hotspots Section (Angular Component) -->
-            <h1>{{.Translations.RiskHotspots}}</h1>
+            <h1>{{.Translations.RiskHotspots}}{{if .HasRiskHotspots}} <a class="button" href="risk_hotspots.html"><i class="icon-riskhotspot"></i>{{.Translations.AllRiskHotspots}}</a>{{end}}</h1>
             <risk-hotspots></risk-hotspots> 
             {{if not .HasRiskHotspots}}
             <p>{{.Translations.NoRiskHotspots}}</p>
             {{end}}
+            {{end}}
 
             <!-- Coverage Section (Angular Component) -->
             <h1>{{.Translations.Coverage3}}</h1>
@@ -94,9 +175,11 @@ const summaryPageLayoutTemplate = `<!DOCTYPE html>
             <p>{{.Translations.NoCoveredAssemblies}}</p>
             {{end}}
 
-            <div class="footer">{{.Translations.GeneratedBy}} ReportGenerator {{.AppVersion}}<br />{{.CurrentDateTime}}<br /><a href="https://github.com/danielpalme/ReportGenerator">GitHub</a> | <a href="https://reportgenerator.io">reportgenerator.io</a></div>
+            <div class="footer">{{.Translations.GeneratedBy}} ReportGenerator {{.AppVersion}}<br />{{.CurrentDateTime}}{{if .CommandLine}}<br /><span class="commandline">{{.CommandLine}}</span>{{end}}<br /><a href="https://github.com/IgorBayerl/ReportGenerator">GitHub</a></div>
         </div> <!-- End containerleft -->
-    </div> <!-- End container -->
+    </div> <!-- End container -->{{if .ClassDetailsOnDemand}}
+    <!-- Class details, loaded by custom.js for #classdetails/... links -->
+    <div class="container classdetail" id="classdetail" style="display: none"><div class="containerleft"></div></div>{{end}}
 
     <script type="text/javascript" src="chartist.min.js"></script> <!-- For Angular components if they use Chartist -->
     <script type="text/javascript" src="custom.js"></script>
@@ -118,7 +201,6 @@ const classDetailLayoutTemplate = `<!DOCTYPE html>
 <body>
     <script>
         window.classDetails = JSON.parse({{.ClassDetailJSON}});
-        window.assemblies = JSON.parse({{.AssembliesJSON}});
         window.translations = JSON.parse({{.TranslationsJSON}});
         window.branchCoverageAvailable = {{.BranchCoverageAvailable}};
         window.methodCoverageAvailable = {{.MethodCoverageAvailable}};
@@ -133,15 +215,46 @@ const classDetailLayoutTemplate = `<!DOCTYPE html>
         <div class="containerleft">
             <h1><a href="index.html" class="back"><</a> {{.Translations.Summary}}</h1>
 
-            <div class="card-group">
+{{template "classDetailContent" .}}
+            <div class="footer">{{.Translations.GeneratedBy}} ReportGenerator {{.AppVersion}}<br />{{.CurrentDateTime}}{{if .CommandLine}}<br /><span class="commandline">{{.CommandLine}}</span>{{end}}<br /><a href="https://github.com/IgorBayerl/ReportGenerator">GitHub</a></div>
+        </div> 
+
+        {{if .Class.SidebarFiles}}
+        <div class="containerright">
+            <div class="containerrightfixed">
+                <h1>{{.Translations.MethodsProperties}}</h1>
+                {{range .Class.SidebarFiles}}
+                {{if $.Class.IsMultiFile}}
+                <div class="sidebarfile" data-file="{{.ShortPath}}">
+                <div class="sidebarfileheader"><a href="#" class="togglesidebarfile" title="{{$.Translations.CollapseExpandFile}}" aria-label="{{$.Translations.CollapseExpandFile}}"><i class="icon-minus" aria-hidden="true"></i></a><a href="#{{.ShortPath}}" class="navigatetohash percentagebar percentagebar{{.CoverageBarValue}}" title="{{.CoverageTitle}}" aria-label="{{.CoverageTitle}}">{{.Path}}</a></div>
+                <div class="sidebarfileelements">
+                {{end}}
+                {{range .Elements}}
+                <a href="#{{.FileShortPath}}_line{{.Line}}" data-range="{{.Range}}" class="navigatetohash percentagebar percentagebar{{.CoverageBarValue}}" title="{{.CoverageTitle}} - {{.Name}}" aria-label="{{.CoverageTitle}}"><i class="icon-{{.Icon}}" aria-hidden="true"></i>{{.Name}}</a><br />
+                {{end}}
+                {{if $.Class.IsMultiFile}}
+                </div>
+                </div>
+                {{end}}
+                {{end}}
+                <br/>
+            </div>
+        </div>
+        {{end}}
+    </div> 
+
+    <script type="text/javascript" src="custom.js"></script> 
+    <script type="text/javascript" src="{{.CombinedAngularJsFile}}"></script>
+</body>
+</html>{{/* Also shown by index.html for Html{classdetails=ondemand}, see renderClassDetailData */}}{{define "classDetailContent"}}            <div class="card-group">
                 <div class="card">
                     <div class="card-header">{{.Translations.Information}}</div>
                     <div class="card-body">
                         <div class="table">
                             <table>
-                                <tr><th>{{.Translations.Class}}:</th><td class="limit-width" title="{{.Class.Name}}">{{.Class.Name}}</td></tr>
-                                <tr><th>{{.Translations.Assembly}}:</th><td class="limit-width" title="{{.Class.AssemblyName}}">{{.Class.AssemblyName}}</td></tr>
-                                <tr><th>{{.Translations.Files3}}:</th><td class="overflow-wrap">
+                                <tr><th scope="row">{{.Translations.Class}}:</th><td class="limit-width" title="{{.Class.Name}}">{{.Class.Name}}</td></tr>
+                                <tr><th scope="row">{{.Translations.Assembly}}:</th><td class="limit-width" title="{{.Class.AssemblyName}}">{{.Class.AssemblyName}}</td></tr>
+                                <tr><th scope="row">{{.Translations.Files3}}:</th><td class="overflow-wrap">
                                     {{$filesLen := len .Class.Files}}
                                     {{$lastFileIdx := sub $filesLen 1}}
                                     {{range $idx, $file := .Class.Files}}
@@ -150,8 +263,9 @@ const classDetailLayoutTemplate = `<!DOCTYPE html>
                                         No files found.
                                     {{end}}
                                 </td></tr>
+                                <tr><th scope="row">{{.Translations.CyclomaticComplexity}}:</th><td class="limit-width" title="{{.Class.ComplexityForDisplay}}">{{.Class.ComplexityForDisplay}}</td></tr>
                                 {{if .Tag}}
-                                <tr><th>{{.Translations.Tag}}:</th><td class="limit-width" title="{{.Tag}}">{{.Tag}}</td></tr>
+                                <tr><th scope="row">{{.Translations.Tag}}:</th><td class="limit-width" title="{{.Tag}}">{{if .TagLink}}<a href="{{.TagLink}}" target="_blank">{{.Tag}}</a>{{else}}{{.Tag}}{{end}}</td></tr>
                                 {{end}}
                             </table>
                         </div>
@@ -163,14 +277,14 @@ const classDetailLayoutTemplate = `<!DOCTYPE html>
                 <div class="card">
                     <div class="card-header">{{.Translations.LineCoverage}}</div>
                     <div class="card-body">
-                        <div class="large cardpercentagebar cardpercentagebar{{.Class.CoveragePercentageBarValue}}">{{.Class.CoveragePercentageForDisplay}}</div>
+                        <div class="large cardpercentagebar cardpercentagebar{{.Class.CoveragePercentageBarValue}}" role="progressbar" aria-valuemin="0" aria-valuemax="100"{{with .Class.CoverageQuota}} aria-valuenow="{{.}}"{{end}} aria-label="{{.Translations.LineCoverage}}">{{.Class.CoveragePercentageForDisplay}}</div>
                         <div class="table">
                             <table>
-                                <tr><th>{{.Translations.CoveredLines}}:</th><td class="limit-width right" title="{{.Class.CoveredLines}}">{{.Class.CoveredLines}}</td></tr>
-                                <tr><th>{{.Translations.UncoveredLines}}:</th><td class="limit-width right" title="{{.Class.UncoveredLines}}">{{.Class.UncoveredLines}}</td></tr>
-                                <tr><th>{{.Translations.CoverableLines}}:</th><td class="limit-width right" title="{{.Class.CoverableLines}}">{{.Class.CoverableLines}}</td></tr>
-                                <tr><th>{{.Translations.TotalLines}}:</th><td class="limit-width right" title="{{.Class.TotalLines}}">{{.Class.TotalLines}}</td></tr>
-                                <tr><th>{{.Translations.LineCoverage}}:</th><td class="limit-width right" title="{{.Class.CoveredLines}} of {{.Class.CoverableLines}}">{{.Class.CoverageRatioTextForDisplay}}</td></tr>
+                                <tr><th scope="row">{{.Translations.CoveredLines}}:</th><td class="limit-width right" title="{{.Class.CoveredLines}}">{{.Class.CoveredLines}}</td></tr>
+                                <tr><th scope="row">{{.Translations.UncoveredLines}}:</th><td class="limit-width right" title="{{.Class.UncoveredLines}}">{{.Class.UncoveredLines}}</td></tr>
+                                <tr><th scope="row">{{.Translations.CoverableLines}}:</th><td class="limit-width right" title="{{.Class.CoverableLines}}">{{.Class.CoverableLines}}</td></tr>
+                                <tr><th scope="row">{{.Translations.TotalLines}}:</th><td class="limit-width right" title="{{.Class.TotalLines}}">{{.Class.TotalLines}}</td></tr>
+                                <tr><th scope="row">{{.Translations.LineCoverage}}:</th><td class="limit-width right" title="{{.Class.CoveredLines}} of {{.Class.CoverableLines}}">{{.Class.CoverageRatioTextForDisplay}}</td></tr>
                             </table>
                         </div>
                     </div>
@@ -179,14 +293,15 @@ const classDetailLayoutTemplate = `<!DOCTYPE html>
                 <div class="card">
                     <div class="card-header">{{.Translations.BranchCoverage}}</div>
                     <div class="card-body">
-                        <div class="large cardpercentagebar cardpercentagebar{{.Class.BranchCoveragePercentageBarValue}}">{{.Class.BranchCoveragePercentageForDisplay}}</div>
+                        <div class="large cardpercentagebar cardpercentagebar{{.Class.BranchCoveragePercentageBarValue}}" role="progressbar" aria-valuemin="0" aria-valuemax="100"{{with .Class.BranchCoverageQuota}} aria-valuenow="{{.}}"{{end}} aria-label="{{.Translations.BranchCoverage}}">{{.Class.BranchCoveragePercentageForDisplay}}</div>
                         <div class="table">
                             <table>
-                                <tr><th>{{.Translations.CoveredBranches2}}:</th><td class="limit-width right" title="{{.Class.CoveredBranches}}">{{.Class.CoveredBranches}}</td></tr>
-                                <tr><th>{{.Translations.TotalBranches}}:</th><td class="limit-width right" title="{{.Class.TotalBranches}}">{{.Class.TotalBranches}}</td></tr>
-                                <tr><th>{{.Translations.BranchCoverage}}:</th><td class="limit-width right" title="{{.Class.CoveredBranches}} of {{.Class.TotalBranches}}">{{.Class.BranchCoverageRatioTextForDisplay}}</td></tr>
+                                <tr><th scope="row">{{.Translations.CoveredBranches2}}:</th><td class="limit-width right" title="{{.Class.CoveredBranches}}">{{.Class.CoveredBranches}}</td></tr>
+                                <tr><th scope="row">{{.Translations.TotalBranches}}:</th><td class="limit-width right" title="{{.Class.TotalBranches}}">{{.Class.TotalBranches}}</td></tr>
+                                <tr><th scope="row">{{.Translations.BranchCoverage}}:</th><td class="limit-width right" title="{{.Class.CoveredBranches}} of {{.Class.TotalBranches}}">{{.Class.BranchCoverageRatioTextForDisplay}}</td></tr>
                             </table>
                         </div>
+                        {{if .Class.ApproximateBranchCoverage}}<p><small>* {{.Translations.ApproximateBranchCoverage}}</small></p>{{end}}
                     </div>
                 </div>
                 {{end}}
@@ -194,20 +309,19 @@ const classDetailLayoutTemplate = `<!DOCTYPE html>
                     <div class="card-header">{{.Translations.MethodCoverage}}</div>
                     <div class="card-body">
                         {{if .MethodCoverageAvailable}}
-                        <div class="large cardpercentagebar cardpercentagebar{{.Class.MethodCoveragePercentageBarValue}}">{{.Class.MethodCoveragePercentageForDisplay}}</div>
+                        <div class="large cardpercentagebar cardpercentagebar{{.Class.MethodCoveragePercentageBarValue}}" role="progressbar" aria-valuemin="0" aria-valuemax="100"{{with .Class.MethodCoverageQuota}} aria-valuenow="{{.}}"{{end}} aria-label="{{.Translations.MethodCoverage}}">{{.Class.MethodCoveragePercentageForDisplay}}</div>
                         <div class="table">
                             <table>
-                                <tr><th>{{.Translations.CoveredCodeElements}}:</th><td class="limit-width right" title="{{.Class.CoveredMethods}}">{{.Class.CoveredMethods}}</td></tr>
-                                <tr><th>{{.Translations.FullCoveredCodeElements}}:</th><td class="limit-width right" title="{{.Class.FullyCoveredMethods}}">{{.Class.FullyCoveredMethods}}</td></tr>
-                                <tr><th>{{.Translations.TotalCodeElements}}:</th><td class="limit-width right" title="{{.Class.TotalMethods}}">{{.Class.TotalMethods}}</td></tr>
-                                <tr><th>{{.Translations.CodeElementCoverageQuota2}}:</th><td class="limit-width right" title="{{.Class.CoveredMethods}} of {{.Class.TotalMethods}}">{{.Class.MethodCoverageRatioTextForDisplay}}</td></tr>
-                                <tr><th>{{.Translations.FullCodeElementCoverageQuota2}}:</th><td class="limit-width right" title="{{.Class.FullyCoveredMethods}} of {{.Class.TotalMethods}}">{{.Class.FullMethodCoverageRatioTextForDisplay}}</td></tr>
+                                <tr><th scope="row">{{.Translations.CoveredCodeElements}}:</th><td class="limit-width right" title="{{.Class.CoveredMethods}}">{{.Class.CoveredMethods}}</td></tr>
+                                <tr><th scope="row">{{.Translations.FullCoveredCodeElements}}:</th><td class="limit-width right" title="{{.Class.FullyCoveredMethods}}">{{.Class.FullyCoveredMethods}}</td></tr>
+                                <tr><th scope="row">{{.Translations.TotalCodeElements}}:</th><td class="limit-width right" title="{{.Class.TotalMethods}}">{{.Class.TotalMethods}}</td></tr>
+                                <tr><th scope="row">{{.Translations.CodeElementCoverageQuota2}}:</th><td class="limit-width right" title="{{.Class.CoveredMethods}} of {{.Class.TotalMethods}}">{{.Class.MethodCoverageRatioTextForDisplay}}</td></tr>
+                                <tr><th scope="row">{{.Translations.FullCodeElementCoverageQuota2}}:</th><td class="limit-width right" title="{{.Class.FullyCoveredMethods}} of {{.Class.TotalMethods}}">{{.Class.FullMethodCoverageRatioTextForDisplay}}</td></tr>
                             </table>
                         </div>
                         {{else}}
                         <div class="center">
-                            <p>{{.Translations.MethodCoverageProVersion}}</p>
-                            <a class="pro-button" href="https://reportgenerator.io/pro" target="_blank">{{.Translations.MethodCoverageProButton}}</a>
+                            <p>{{.Translations.MethodCoverageNotProvided}}</p>
                         </div>
                         {{end}}
                     </div>
@@ -224,15 +338,16 @@ const classDetailLayoutTemplate = `<!DOCTYPE html>
                         <col class="column105" />
                         {{end}}
                     </colgroup>
-                    <thead><tr><th>{{$.Translations.Methods}}</th>
+                    <caption class="sr-only">{{$.Translations.Metrics}}</caption>
+                    <thead><tr><th scope="col">{{$.Translations.Methods}}</th>
                         {{range .Class.MetricsTable.Headers}}
-                        <th>{{.Name}} {{if .ExplanationURL}}<a href="{{.ExplanationURL}}" target="_blank"><i class="icon-info-circled"></i></a>{{end}}</th>
+                        <th scope="col">{{.Name}} {{if .ExplanationURL}}<a href="{{.ExplanationURL}}" target="_blank"><i class="icon-info-circled"></i></a>{{end}}</th>
                         {{end}}
                     </tr></thead>
                     <tbody>
                         {{range .Class.MetricsTable.Rows}}
-                        <tr><td title="{{.FullName}}"><a href="#{{.FileShortPath}}_line{{.Line}}" class="navigatetohash">{{if $.Class.IsMultiFile}}File {{.FileIndexPlus1}}: {{end}}{{.Name}}</a></td>
-                            {{range .MetricValues}}<td>{{.}}</td>{{end}}
+                        <tr{{if .RiskHotspot}} class="riskhotspot"{{end}}><td title="{{.FullName}}"><a href="#{{.FileShortPath}}_line{{.Line}}" class="navigatetohash">{{if $.Class.IsMultiFile}}File {{.FileIndexPlus1}}: {{end}}{{.Name}}</a>{{if .RiskHotspot}} <a href="risk_hotspots.html" class="riskhotspotbadge" title="{{$.Translations.RiskHotspot}}: {{.RiskHotspot}}"><i class="icon-riskhotspot"></i></a>{{end}}</td>
+                            {{$row := .}}{{range $i, $value := .MetricValues}}<td{{with metricStatusClass (index $row.MetricStatuses $i)}} class="{{.}}"{{end}}>{{$value}}</td>{{end}}
                         </tr>
                         {{end}}
                     </tbody>
@@ -240,73 +355,102 @@ const classDetailLayoutTemplate = `<!DOCTYPE html>
             </div>
             {{end}}
 
+            {{if .Class.TestMethods}}
+            <h1>{{.Translations.CoverageByTest}}</h1>
+            <p>
+                <select class="switchtestmethod" aria-label="{{.Translations.CoverageByTest}}">
+                    <option id="AllTestMethods" class="testmethod" value="AllTestMethods" selected="selected">{{.Translations.AllTests}}</option>
+                    {{range .Class.TestMethods}}
+                    <option id="{{.ID}}" class="testmethod" value="{{.ID}}" title="{{.Name}}">{{.ShortName}}</option>
+                    {{end}}
+                </select>
+            </p>
+            {{end}}
+
             <h1>{{.Translations.Files3}}</h1>
             {{range $fileIdx, $file := .Class.Files}}
-            <h2 id="{{$file.ShortPath}}">{{$file.Path}}</h2>
+            <h2 id="{{$file.ShortPath}}">{{$file.Path}}{{if $file.External}} <span class="external" title="{{$.Translations.ExternalFilesHint}}">{{$.Translations.External}}</span>{{end}}</h2>
+            {{if $file.UncoveredLineCount}}
+            <div class="uncoverednavigation">
+                <a href="#" class="toggleuncovered" data-showtext="{{printf $.Translations.ShowUncoveredLines $file.UncoveredLineCount}}" data-hidetext="{{$.Translations.ShowAllLines}}">{{printf $.Translations.ShowUncoveredLines $file.UncoveredLineCount}}</a>
+                <a href="#" class="previousuncovered" title="{{$.Translations.PreviousUncoveredLine}} (p)"><i class="icon-up-dir_active"></i> {{$.Translations.PreviousUncoveredLine}}</a>
+                <a href="#" class="nextuncovered" title="{{$.Translations.NextUncoveredLine}} (n)"><i class="icon-down-dir_active"></i> {{$.Translations.NextUncoveredLine}}</a>
+            </div>
+            {{end}}
+            {{- if $file.Minified}}
+            <p class="minifiedfile">{{$.Translations.MinifiedFile}}</p>
+            {{- end}}
             <div class="table-responsive">
                 <table class="lineAnalysis">
-                    <thead><tr><th></th><th>#</th><th>{{$.Translations.Line}}</th><th></th><th>{{$.Translations.LineCoverage}}</th></tr></thead>
+                    <caption class="sr-only">{{$.Translations.LineCoverage}}: {{$file.Path}}</caption>
+                    <thead><tr><th scope="col"><span class="sr-only">{{$.Translations.Coverage}}</span></th><th scope="col">#</th><th scope="col">{{$.Translations.Line}}</th><th scope="col"><span class="sr-only">{{$.Translations.Branches}}</span></th><th scope="col">{{$.Translations.LineCoverage}}</th></tr></thead>
                     <tbody>
-                    {{range $file.Lines}}
-                        <tr class="{{if ne .LineVisitStatus "gray"}}coverableline{{end}}" title="{{.Tooltip}}" data-coverage="{{.DataCoverage}}">
-                            <td class="{{.LineVisitStatus}}"> </td>
-                            <td class="leftmargin rightmargin right">{{if ne .LineVisitStatus "gray"}}{{.Hits}}{{end}}</td>
-                            <td class="rightmargin right"><a id="{{$file.ShortPath}}_line{{.LineNumber}}"></a><code>{{.LineNumber}}</code></td>
-                            {{if .IsBranch}}
-                            <td class="percentagebar percentagebar{{.BranchBarValue}}"><i class="icon-fork"></i></td>
-                            {{else}}
-                            <td></td>
-                            {{end}}
-                            <td class="light{{.LineVisitStatus}}"><code>{{.LineContent | SanitizeSourceLine}}</code></td>
-                        </tr>
-                    {{end}}
+                    {{lineRows $file}}
                     </tbody>
                 </table>
             </div>
             {{else}}
                 <p>{{.Translations.NoFilesFound}}</p>
             {{end}}
+{{end}}`
 
-            <div class="footer">{{.Translations.GeneratedBy}} ReportGenerator {{.AppVersion}}<br />{{.CurrentDateTime}}<br /><a href="https://github.com/danielpalme/ReportGenerator">GitHub</a> | <a href="https://reportgenerator.io">reportgenerator.io</a></div>
-        </div> 
+const riskHotspotsPageLayoutTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8" />
+<meta name="viewport" content="width=device-width, initial-scale=1.0" />
+<meta http-equiv="X-UA-Compatible" content="IE=EDGE,chrome=1" />
+<title>{{.Translations.RiskHotspots}} - {{.ReportTitle}}</title>
+<link rel="stylesheet" type="text/css" href="report.css" />
+</head>
+<body>
+    <div class="container">
+        <div class="containerleft">
+            <h1><a href="index.html" class="back">&lt;</a> {{.Translations.Summary}}</h1>
 
-        {{if .Class.SidebarElements}}
-        <div class="containerright">
-            <div class="containerrightfixed">
-                <h1>{{.Translations.MethodsProperties}}</h1>
-                {{range .Class.SidebarElements}}
-                <a href="#{{.FileShortPath}}_line{{.Line}}" class="navigatetohash percentagebar percentagebar{{.CoverageBarValue}}" title="{{if $.Class.IsMultiFile}}File {{.FileIndexPlus1}}: {{end}}{{.CoverageTitle}} - {{.Name}}"><i class="icon-{{.Icon}}"></i>{{.Name}}</a><br />
-                {{end}}
-                <br/>
+            <h1>{{.Translations.RiskHotspots}}</h1>
+            <div class="table-responsive">
+                <table class="overview table-fixed stripped riskhotspots">
+                    <colgroup>
+                        <col class="column-min-200" />
+                        <col class="column-min-200" />
+                        <col class="column-min-200" />
+                        {{range .Metrics}}
+                        <col class="column105" />
+                        {{end}}
+                    </colgroup>
+                    <thead><tr><th scope="col">{{.Translations.Assembly}}</th><th scope="col">{{.Translations.Class}}</th><th scope="col">{{.Translations.Method}}</th>
+                        {{range $i, $metric := .Metrics}}
+                        <th scope="col"><a href="#" class="sortriskhotspots" data-column="{{$i}}"><i class="icon-up-down-dir"></i>{{$metric.Name}}</a> <a href="{{$metric.ExplanationURL}}" target="_blank"><i class="icon-info-circled"></i></a></th>
+                        {{end}}
+                    </tr></thead>
+                    <tbody>
+                        {{range .Rows}}
+                        <tr title="{{.Tooltip}}"><td>{{.Assembly}}</td><td>{{if .ClassLink}}<a href="{{.ClassLink}}">{{.Class}}</a>{{else}}{{.Class}}{{end}}</td><td title="{{.MethodName}}">{{if .MethodLink}}<a href="{{.MethodLink}}">{{.MethodShortName}}</a>{{else}}{{.MethodShortName}}{{end}}</td>
+                            {{range .Metrics}}<td class="right{{with .StatusClass}} {{.}}{{end}}" data-value="{{.SortValue}}">{{.Value}}</td>{{end}}
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
             </div>
+
+            <div class="footer">{{.Translations.GeneratedBy}} ReportGenerator {{.AppVersion}}<br />{{.CurrentDateTime}}{{if .CommandLine}}<br /><span class="commandline">{{.CommandLine}}</span>{{end}}<br /><a href="https://github.com/IgorBayerl/ReportGenerator">GitHub</a></div>
         </div>
-        {{end}}
-    </div> 
+    </div>
 
-    <script type="text/javascript" src="custom.js"></script> 
-    <script type="text/javascript" src="{{.CombinedAngularJsFile}}"></script>
+    <script type="text/javascript" src="custom.js"></script>
 </body>
 </html>`
 
 var (
 	// classDetailTpl for class detail pages (server-rendered structure)
 	classDetailTpl = template.Must(template.New("classDetail").Funcs(template.FuncMap{
-		"inc":      func(i int) int { return i + 1 },
-		"sub":      func(a, b int) int { return a - b },
-		"SafeHTML": func(s string) template.HTML { return template.HTML(s) },
-		"SafeJS":   func(s string) template.JS { return template.JS(s) },
-		"SanitizeSourceLine": func(line string) template.HTML {
-			// 1. HTML-escape first to get &lt;, &gt;, &amp; …
-			escaped := html.EscapeString(line)
-
-			// 2. Replace TABs with four real spaces first (so that step 3 sees them)
-			escaped = strings.ReplaceAll(escaped, "\t", "    ")
-
-			// 3. Turn every real space into &nbsp;
-			escaped = strings.ReplaceAll(escaped, " ", "&nbsp;")
-
-			return template.HTML(escaped) // mark it safe – we built the HTML ourselves
-		},
+		"inc":               func(i int) int { return i + 1 },
+		"sub":               func(a, b int) int { return a - b },
+		"metricStatusClass": metricStatusClass,
+		"SafeHTML":          func(s string) template.HTML { return template.HTML(s) },
+		"SafeJS":            func(s string) template.JS { return template.JS(s) },
+		"lineRows":          lineRows,
 	}).Parse(classDetailLayoutTemplate))
 
 	// summaryPageTpl for the main index.html (summary page)
@@ -314,4 +458,114 @@ var (
 		"SafeHTML": func(s string) template.HTML { return template.HTML(s) },
 		"SafeJS":   func(s string) template.JS { return template.JS(s) },
 	}).Parse(summaryPageLayoutTemplate))
+
+	// riskHotspotsPageTpl for risk_hotspots.html
+	riskHotspotsPageTpl = template.Must(template.New("riskHotspotsPage").Parse(riskHotspotsPageLayoutTemplate))
+)
+
+func sanitizeSourceLine(line string) template.HTML {
+	// 1. HTML-escape first to get &lt;, &gt;, &amp; …
+	escaped := html.EscapeString(line)
+
+	// 2. Replace TABs with four real spaces first (so that step 3 sees them)
+	escaped = strings.ReplaceAll(escaped, "\t", "    ")
+
+	// 3. Turn every real space into &nbsp;
+	escaped = strings.ReplaceAll(escaped, " ", "&nbsp;")
+
+	return template.HTML(escaped) // mark it safe – we built the HTML ourselves
+}
+
+// highlightSourceLine renders the tokens of a line like sanitizeSourceLine renders the
+// line, wrapping all but plain text in a span with the class of the token kind.
+func highlightSourceLine(tokens []highlight.Token) template.HTML {
+	var line strings.Builder
+	for _, token := range tokens {
+		if class := token.Kind.Class(); class != "" {
+			line.WriteString(`<span class="` + class + `">`)
+			line.WriteString(string(sanitizeSourceLine(token.Text)))
+			line.WriteString("</span>")
+		} else {
+			line.WriteString(string(sanitizeSourceLine(token.Text)))
+		}
+	}
+	return template.HTML(line.String())
+}
+
+// attributeEscaper escapes text and attribute values like html/template does.
+var attributeEscaper = strings.NewReplacer(
+	"\x00", "\uFFFD",
+	`"`, "&#34;",
+	"&", "&amp;",
+	"'", "&#39;",
+	"+", "&#43;",
+	"<", "&lt;",
+	">", "&gt;",
 )
+
+// lineRows renders the rows of the line analysis table of a file. They make up most of
+// a class page, and executing them as part of the template escapes every value of every
+// line by reflection, which took most of the time of large reports. The rows are the
+// same as a {{range $file.Lines}} block would render.
+func lineRows(file FileViewModelForDetail) template.HTML {
+	const indent = "\n                            "
+	var rows strings.Builder
+	rows.Grow(len(file.Lines) * 600)
+	for _, line := range file.Lines {
+		coverable := line.LineVisitStatus != "gray"
+		lineNumber := strconv.Itoa(line.LineNumber)
+
+		rows.WriteString("\n                        <tr class=\"")
+		if coverable {
+			rows.WriteString("coverableline")
+		}
+		rows.WriteString(`" title="`)
+		attributeEscaper.WriteString(&rows, line.Tooltip)
+		rows.WriteString(`" data-coverage="`)
+		attributeEscaper.WriteString(&rows, string(line.DataCoverage))
+		rows.WriteString(`">`)
+		rows.WriteString(indent + `<td class="`)
+		attributeEscaper.WriteString(&rows, line.LineVisitStatus)
+		if line.StatusText != "" {
+			rows.WriteString(`"><span class="sr-only">`)
+			attributeEscaper.WriteString(&rows, line.StatusText)
+			rows.WriteString(`</span></td>`)
+		} else {
+			rows.WriteString(`"> </td>`)
+		}
+		rows.WriteString(indent + `<td class="leftmargin rightmargin right">`)
+		if coverable {
+			attributeEscaper.WriteString(&rows, line.Hits)
+		}
+		rows.WriteString(`</td>`)
+		rows.WriteString(indent + `<td class="rightmargin right"><a id="`)
+		attributeEscaper.WriteString(&rows, file.ShortPath)
+		rows.WriteString("_line" + lineNumber + `"></a><code>` + lineNumber + `</code></td>` + indent)
+		if line.IsBranch {
+			rows.WriteString(indent + `<td class="percentagebar percentagebar` + strconv.Itoa(line.BranchBarValue) + `" aria-label="`)
+			attributeEscaper.WriteString(&rows, line.BranchLabel)
+			rows.WriteString(`"><i class="icon-fork" aria-hidden="true"></i></td>` + indent)
+		} else {
+			rows.WriteString(indent + `<td></td>` + indent)
+		}
+		rows.WriteString(indent + `<td class="light`)
+		attributeEscaper.WriteString(&rows, line.LineVisitStatus)
+		if line.TruncatedTitle != "" {
+			// The spaces of truncated lines are kept as they are; turning each into &nbsp;
+			// would make the page many times the size of the line.
+			rows.WriteString(`"><code title="`)
+			attributeEscaper.WriteString(&rows, line.TruncatedTitle)
+			rows.WriteString(`">`)
+			rows.WriteString(html.EscapeString(line.LineContent))
+			rows.WriteString("…")
+		} else if line.HighlightedContent != "" {
+			rows.WriteString(`"><code>`)
+			rows.WriteString(string(line.HighlightedContent))
+		} else {
+			rows.WriteString(`"><code>`)
+			rows.WriteString(string(sanitizeSourceLine(line.LineContent)))
+		}
+		rows.WriteString("</code></td>\n                        </tr>\n                    ")
+	}
+	return template.HTML(rows.String())
+}
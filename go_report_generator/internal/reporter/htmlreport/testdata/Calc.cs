@@ -0,0 +1,16 @@
+namespace Demo
+{
+    public class Calc
+    {
+	public int Add(int a, int b)
+        {
+            return a + b; // <sum> & "done"
+        }
+
+        public int Div(int a, int b)
+        {
+            if (b == 0) { return 0; }
+            return a / b;
+        }
+    }
+}
@@ -112,6 +112,7 @@ func GetTranslations() map[string]string {
 		"CoveredBranches2": "Covered branches", // C# key for 'Covered branches' count
 		"TotalBranches":    "Total branches",
 		// "BranchCoverage" already present for the last row's header
+		"ApproximateBranchCoverage": "Branches of Go code are approximated from the if/switch/select statements and the blocks of the cover profile.",
 
 		// Method Coverage Card (Title "MethodCoverage" is present)
 		"CoveredCodeElements":           "Covered methods/properties",
@@ -121,19 +122,77 @@ func GetTranslations() map[string]string {
 		"FullCodeElementCoverageQuota2": "Full method/property coverage", // C# key for full method coverage %
 		"MethodCoverageProVersion":      "This feature is only available for sponsors.",
 		"MethodCoverageProButton":       "Upgrade to PRO version",
+		"MethodCoverageNotProvided":     "Method coverage is not available, because the coverage reports do not provide methods.",
 
 		// Section Titles / Paragraphs
 		"NoRiskHotspots":      "No risk hotspots found.",
+		"AllRiskHotspots":     "All risk hotspots",
 		"Coverage3":           "Coverage", // H1 Title for the main coverage table/list section
 		"NoCoveredAssemblies": "No assemblies have been covered.",
 		"GeneratedBy":         "Generated by",
 
+		// Missing source files card on the summary page
+		"MissingSourceFiles":     "Missing source files",
+		"MissingSourceFilesHint": "%d source file(s) could not be found. Their coverage is reported, but line content is missing.", // Formatted with the number of files
+		"ReferencedBy":           "Referenced by",
+
+		// Skipped report files card on the summary page
+		"SkippedReports":     "Skipped report files",
+		"SkippedReportsHint": "%d report file(s) could not be parsed. Their coverage is not included in this report.", // Formatted with the number of files
+		"ReportFile":         "Report file",
+		"Error":              "Error",
+
+		// Coverage by directory card on the summary page
+		"CoverageByDirectory":     "Coverage by directory",
+		"Directory":               "Directory",
+		"CollapseExpandDirectory": "Collapse/expand the subdirectories",
+
 		// For Class Detail Page
-		"MethodsProperties": "Methods/Properties",
-		"Files3":            "File(s)", // Used as H1 and in info card
-		"File":              "File",    // Used like "File 0: path/to/file.cs"
-		"NoFilesFound":      "No files found.",
-		"Line":              "Line", // Header in source code table
+		"MethodsProperties":  "Methods/Properties",
+		"CollapseExpandFile": "Collapse/expand the methods of this file",
+		"Files3":             "File(s)", // Used as H1 and in info card
+		"File":               "File",    // Used like "File 0: path/to/file.cs"
+		"NoFilesFound":       "No files found.",
+		"Line":               "Line", // Header in source code table
+
+		// Uncovered line navigation on the class detail page
+		"ShowUncoveredLines":    "Show %d uncovered lines", // Formatted with the per-file uncovered line count
+		"ShowAllLines":          "Show all lines",
+		"PreviousUncoveredLine": "Previous uncovered line",
+		"NextUncoveredLine":     "Next uncovered line",
+
+		// Long lines and minified files on the class detail page
+		"LineTruncated": "Line truncated: %d of %d characters shown", // Formatted with the shown and the total length
+		"MinifiedFile":  "The lines of this file are too long to be shown (e.g. minified code). Only their coverage is listed.",
+
+		// Risk hotspots page and the risk hotspot badges of the metrics table
+		"Method":                    "Method",
+		"RiskHotspot":               "Risk hotspot",
+		"RiskHotspotExceedsWarning": "%s %s exceeds the warning threshold of %s", // Formatted with the metric, its value and the threshold
+		"RiskHotspotExceedsError":   "%s %s exceeds the error threshold of %s",
+
+		// Coverage age of the classes in the summary table
+		"CoverageAge": "Below %s since %s (%d runs)", // Formatted with the threshold, the date of the first run and the number of runs
+
+		// Tooltips of the full method coverage on the method coverage card
+		"FullMethodCoverageAllLines":                "A method is fully covered if all of its coverable lines are covered",
+		"FullMethodCoverageAllLinesAndBranches":     "A method is fully covered if all of its coverable lines and branches are covered",
+		"FullMethodCoverageMinimumLines":            "A method is fully covered if at least %s of its coverable lines are covered", // Formatted with the minimum line coverage
+		"FullMethodCoverageMinimumLinesAndBranches": "A method is fully covered if at least %s of its coverable lines and all of its branches are covered",
+
+		// Files outside the source directories, see the excludeexternalfiles setting
+		"External":          "External",
+		"ExternalFiles":     "External files",
+		"ExternalFilesHint": "Files outside the source directories, e.g. generated code or libraries",
+
+		// Line status for screen readers on the class detail page, e.g. "Covered, 3 visits, 1 of 2 branches covered"
+		"Visits":          "%d visits",                 // Formatted with the visits of the line
+		"BranchesCovered": "%d of %d branches covered", // Formatted with the covered and the total branches of the line
+		"Approximate":     "approximate",
+
+		// Coverage by test selector on the class detail page
+		"CoverageByTest": "Coverage by test",
+		"AllTests":       "All",
 
 		// == Angular-specific keys (must match Angular casing) ==
 		"collapseAll":                    "Collapse all",
@@ -171,6 +230,8 @@ func GetTranslations() map[string]string {
 		"methodCoverageProVersion": "This feature is only available for sponsors.",
 		"coverageTypes":            "Coverage types",
 		"history":                  "History",
+		"andMoreClasses":           "and %d more classes",         // Classes left out of the summary table by summarytopn
+		"olderRuns":                "%d older runs from %s to %s", // History entries of a class summarized by the band of its history chart
 	}
 
 }
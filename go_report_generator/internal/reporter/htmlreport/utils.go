@@ -1,50 +1,14 @@
 package htmlreport
 
 import (
-	"fmt"
 	"math"
+	"path/filepath"
 	"strings"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
-const (
-	lineVisitStatusNotCoverable     = 0
-	lineVisitStatusCovered          = 1
-	lineVisitStatusNotCovered       = 2
-	lineVisitStatusPartiallyCovered = 3
-)
-
-const maxFilenameLengthBase = 95
-
-func countTotalClasses(assemblies []model.Assembly) int {
-	count := 0
-	for _, asm := range assemblies {
-		count += len(asm.Classes)
-	}
-	return count
-}
-
-func countUniqueFiles(assemblies []model.Assembly) int {
-	if len(assemblies) == 0 {
-		return 0
-	}
-
-	var allFiles []model.CodeFile
-	for _, asm := range assemblies {
-		for _, cls := range asm.Classes {
-			allFiles = append(allFiles, cls.Files...)
-		}
-	}
-
-	distinctFiles := utils.DistinctBy(allFiles, func(file model.CodeFile) string {
-		return file.Path // Assuming Path is the unique key
-	})
-
-	return len(distinctFiles)
-}
-
 func determineLineVisitStatus(hits int, isBranchPoint bool, coveredBranches int, totalBranches int) model.LineVisitStatus { // Changed return type
 	if hits < 0 {
 		return model.NotCoverable
@@ -80,6 +44,25 @@ func lineVisitStatusToString(status model.LineVisitStatus) string { // Changed p
 	}
 }
 
+// metricStatusClass returns the CSS class that highlights a metric table cell, or "" for StatusOk.
+func metricStatusClass(status model.MetricStatus) string {
+	switch status {
+	case model.StatusWarning:
+		return "lightorange"
+	case model.StatusError:
+		return "lightred"
+	default:
+		return ""
+	}
+}
+
+// fileAnchorID returns the id of a file's section on its class page. The ids of the lines
+// are "<id>_line<number>". They are part of deep links such as
+// "Shop.Cart.html#Cart.cs_line42", so they only depend on the file name.
+func fileAnchorID(path string) string {
+	return utils.ReplaceInvalidPathChars(filepath.Base(path))
+}
+
 // generateUniqueFilename creates a sanitized and unique HTML filename for a class.
 // It takes assembly and class names, and a map of existing filenames to ensure uniqueness.
 // The existingFilenames map is modified by this function.
@@ -110,31 +93,7 @@ func generateUniqueFilename(
 		}
 	}
 
-	baseName := assemblyShortName + processedClassName
-	sanitizedName := utils.ReplaceInvalidPathChars(baseName) // Uses the centralized utility
-
-	if len(sanitizedName) > maxFilenameLengthBase {
-		if maxFilenameLengthBase > 50 {
-			sanitizedName = sanitizedName[:50] + sanitizedName[len(sanitizedName)-(maxFilenameLengthBase-50):]
-		} else {
-			sanitizedName = sanitizedName[:maxFilenameLengthBase]
-		}
-	}
-
-	fileName := sanitizedName + ".html"
-	counter := 1
-	normalizedFileNameToCheck := strings.ToLower(fileName)
-
-	_, exists := existingFilenames[normalizedFileNameToCheck]
-	for exists {
-		counter++
-		fileName = fmt.Sprintf("%s%d.html", sanitizedName, counter)
-		normalizedFileNameToCheck = strings.ToLower(fileName)
-		_, exists = existingFilenames[normalizedFileNameToCheck]
-	}
-
-	existingFilenames[normalizedFileNameToCheck] = struct{}{}
-	return fileName
+	return utils.UniqueSanitizedFilename(assemblyShortName+processedClassName, ".html", existingFilenames)
 }
 
 // getCoverageBarValue snaps a coverage percentage (0-100) to the nearest available CSS class value.
@@ -178,3 +137,39 @@ func getCoverageBarValue(coverage float64) int {
 
 	return 100
 }
+
+// isFinite reports whether f can be represented in JSON (i.e. is neither NaN nor ±Inf).
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// finiteFloatPtr returns p unchanged if it points to a finite value, otherwise nil,
+// so the value is emitted as JSON null instead of breaking json.Marshal.
+func finiteFloatPtr(p *float64) *float64 {
+	if p == nil || !isFinite(*p) {
+		return nil
+	}
+	return p
+}
+
+// finiteMetrics copies a metrics map, dropping entries with non-finite values.
+func finiteMetrics(metrics map[string]float64) map[string]float64 {
+	result := make(map[string]float64, len(metrics))
+	for name, val := range metrics {
+		if isFinite(val) {
+			result[name] = val
+		}
+	}
+	return result
+}
+
+// finiteFloats drops non-finite values from a history series.
+func finiteFloats(values []float64) []float64 {
+	result := make([]float64, 0, len(values))
+	for _, v := range values {
+		if isFinite(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
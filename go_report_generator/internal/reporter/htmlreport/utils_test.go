@@ -3,8 +3,6 @@ package htmlreport
 import (
 	"strings"
 	"testing"
-
-	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 )
 
 // TestGenerateUniqueFilename tests the generateUniqueFilename function.
@@ -78,76 +76,27 @@ func TestGenerateUniqueFilename(t *testing.T) {
 		})
 	}
 }
-func TestCountTotalClasses(t *testing.T) {
-	tests := []struct {
-		name       string
-		assemblies []model.Assembly
-		want       int
-	}{
-		{"no assemblies", []model.Assembly{}, 0},
-		{"one assembly no classes", []model.Assembly{{Name: "A1"}}, 0},
-		{
-			"multiple assemblies with classes",
-			[]model.Assembly{
-				{Name: "A1", Classes: []model.Class{{Name: "C1"}, {Name: "C2"}}},
-				{Name: "A2", Classes: []model.Class{{Name: "C3"}}},
-			},
-			3,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := countTotalClasses(tt.assemblies); got != tt.want {
-				t.Errorf("countTotalClasses() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
 
-func TestCountUniqueFiles(t *testing.T) {
+// TestFileAnchorID checks the anchors of the class pages, which deep links depend on.
+func TestFileAnchorID(t *testing.T) {
 	tests := []struct {
-		name       string
-		assemblies []model.Assembly
-		want       int
+		path string
+		want string
 	}{
-		{"no assemblies", []model.Assembly{}, 0},
-		{
-			"single file",
-			[]model.Assembly{{
-				Classes: []model.Class{{
-					Files: []model.CodeFile{{Path: "file1.cs"}},
-				}},
-			}},
-			1,
-		},
-		{
-			"multiple unique files",
-			[]model.Assembly{{
-				Classes: []model.Class{
-					{Files: []model.CodeFile{{Path: "file1.cs"}}},
-					{Files: []model.CodeFile{{Path: "file2.cs"}}},
-				},
-			}},
-			2,
-		},
-		{
-			"duplicate files across classes/assemblies",
-			[]model.Assembly{
-				{Classes: []model.Class{
-					{Files: []model.CodeFile{{Path: "file1.cs"}}},
-				}},
-				{Classes: []model.Class{
-					{Files: []model.CodeFile{{Path: "file1.cs"}}}, // Duplicate
-					{Files: []model.CodeFile{{Path: "file2.cs"}}},
-				}},
-			},
-			2,
-		},
+		{"Cart.cs", "Cart.cs"},
+		{"/src/Shop/Cart.cs", "Cart.cs"},
+		{"/src/My File+1.cs", "My_File_1.cs"},
+		{"src/generic_list-v2.go", "generic_list-v2.go"},
+		{"/src/Über  Größe.cs", "_ber_Gr_e.cs"},
 	}
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := countUniqueFiles(tt.assemblies); got != tt.want {
-				t.Errorf("countUniqueFiles() = %v, want %v", got, tt.want)
+		t.Run(tt.path, func(t *testing.T) {
+			got := fileAnchorID(tt.path)
+			if got != tt.want {
+				t.Errorf("fileAnchorID(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+			if again := fileAnchorID(tt.path); again != got {
+				t.Errorf("fileAnchorID(%q) is not stable: %q, then %q", tt.path, got, again)
 			}
 		})
 	}
@@ -1,32 +1,75 @@
 package htmlreport
 
-import "html/template"
+import (
+	"html/template"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
 
 // AngularAssemblyViewModel corresponds to the data structure for window.assemblies.
 type AngularAssemblyViewModel struct {
-	Name    string                  `json:"name"`
-	Classes []AngularClassViewModel `json:"classes"`
+	Name       string                  `json:"name"`
+	ReportPath string                  `json:"rp"` // Page of the assembly, empty if none is rendered
+	Classes    []AngularClassViewModel `json:"classes"`
+	// More stands for the classes left out by the summarytopn setting, nil if none are.
+	More *AngularMoreClassesViewModel `json:"more,omitempty"`
+}
+
+// AngularMoreClassesViewModel is the "and N more classes" marker of an assembly in
+// window.assemblies. Its totals are those of the classes left out.
+type AngularMoreClassesViewModel struct {
+	Count               int    `json:"count"`
+	ReportPath          string `json:"rp,omitempty"` // Page of the assembly that lists all classes
+	CoveredLines        int    `json:"cl"`
+	UncoveredLines      int    `json:"ucl"`
+	CoverableLines      int    `json:"cal"`
+	TotalLines          int    `json:"tl"`
+	CoveredBranches     int    `json:"cb"`
+	TotalBranches       int    `json:"tb"`
+	CoveredMethods      int    `json:"cm"`
+	FullyCoveredMethods int    `json:"fcm"`
+	TotalMethods        int    `json:"tm"`
+}
+
+// AngularSummarySortViewModel is the initial sorting of the class table (window.summarySort),
+// set to the order the classes are embedded in.
+type AngularSummarySortViewModel struct {
+	SortBy    string `json:"sortBy"`
+	SortOrder string `json:"sortOrder"`
 }
 
 // AngularClassViewModel corresponds to the data structure for classes within window.assemblies.
 type AngularClassViewModel struct {
-	Name                      string                             `json:"name"`
-	ReportPath                string                             `json:"rp"`
-	CoveredLines              int                                `json:"cl"`
-	UncoveredLines            int                                `json:"ucl"`
-	CoverableLines            int                                `json:"cal"`
-	TotalLines                int                                `json:"tl"`
-	CoveredBranches           int                                `json:"cb"`
-	TotalBranches             int                                `json:"tb"`
-	CoveredMethods            int                                `json:"cm"`
-	FullyCoveredMethods       int                                `json:"fcm"`
-	TotalMethods              int                                `json:"tm"`
-	LineCoverageHistory       []float64                          `json:"lch"`
-	BranchCoverageHistory     []float64                          `json:"bch"`
-	MethodCoverageHistory     []float64                          `json:"mch"`
-	FullMethodCoverageHistory []float64                          `json:"mfch"`
-	HistoricCoverages         []AngularHistoricCoverageViewModel `json:"hc"`
-	Metrics                   map[string]float64                 `json:"metrics,omitempty"`
+	Name                      string                                `json:"name"`
+	ReportPath                string                                `json:"rp"`
+	CoveredLines              int                                   `json:"cl"`
+	UncoveredLines            int                                   `json:"ucl"`
+	CoverableLines            int                                   `json:"cal"`
+	TotalLines                int                                   `json:"tl"`
+	CoveredBranches           int                                   `json:"cb"`
+	TotalBranches             int                                   `json:"tb"`
+	CoveredMethods            int                                   `json:"cm"`
+	FullyCoveredMethods       int                                   `json:"fcm"`
+	TotalMethods              int                                   `json:"tm"`
+	LineCoverageHistory       []float64                             `json:"lch"`
+	BranchCoverageHistory     []float64                             `json:"bch"`
+	MethodCoverageHistory     []float64                             `json:"mch"`
+	FullMethodCoverageHistory []float64                             `json:"mfch"`
+	HistoricCoverages         []AngularHistoricCoverageViewModel    `json:"hc"`
+	HistoricCoverageBand      *AngularHistoricCoverageBandViewModel `json:"hcb,omitempty"` // Entries older than the ones in hc
+	Metrics                   map[string]float64                    `json:"metrics,omitempty"`
+	UncoveredLineRanges       string                                `json:"ulr,omitempty"` // e.g. "12-18, 25", only for the classes with the most uncovered lines
+	CoverageAge               string                                `json:"ca,omitempty"`  // e.g. "Below 80% since 2024-03-02 (5 runs)", see model.CoverageAge
+	External                  bool                                  `json:"ex,omitempty"`  // All files of the class are outside the source directories
+	Files                     []AngularClassFileViewModel           `json:"files,omitempty"`
+}
+
+// AngularClassFileViewModel is a file of a class in window.assemblies, for deep links to
+// "<rp>#<id>" (the file) and "<rp>#<id>_line<number>" (a line).
+type AngularClassFileViewModel struct {
+	ID       string `json:"id"`                 // Anchor id of the file's section on the class page
+	Path     string `json:"path"`               // Path as displayed on the class page
+	External bool   `json:"external,omitempty"` // The file is outside the source directories
 }
 
 // AngularHistoricCoverageViewModel corresponds to individual historic coverage data points.
@@ -47,6 +90,19 @@ type AngularHistoricCoverageViewModel struct {
 	FullMethodCoverageQuota float64 `json:"mfcq"`
 }
 
+// AngularHistoricCoverageBandViewModel summarizes the history entries of a class that are
+// older than the ones embedded as "hc": their number, dates and coverage quota ranges
+// (-1 if none of them has data for a quota).
+type AngularHistoricCoverageBandViewModel struct {
+	Count                  int     `json:"n"`
+	From                   string  `json:"from"`
+	To                     string  `json:"to"`
+	LineCoverageQuotaMin   float64 `json:"lcqmin"`
+	LineCoverageQuotaMax   float64 `json:"lcqmax"`
+	BranchCoverageQuotaMin float64 `json:"bcqmin"`
+	BranchCoverageQuotaMax float64 `json:"bcqmax"`
+}
+
 // AngularMetricViewModel corresponds to the data structure for window.metrics.
 type AngularMetricViewModel struct {
 	Name           string `json:"name"`
@@ -74,6 +130,13 @@ type AngularLineAnalysisViewModel struct {
 	TotalBranches   int    `json:"tb"`
 }
 
+// ClassDetailDataViewModel is passed to window.loadClassDetail by the class detail files
+// of Html{classdetails=ondemand}. HTML holds the details as shown by the class pages.
+type ClassDetailDataViewModel struct {
+	Class AngularClassViewModel `json:"class"`
+	HTML  string                `json:"html"`
+}
+
 // AngularCodeFileViewModel represents a code file within a class for Angular.
 type AngularCodeFileViewModel struct {
 	Path                string                             `json:"p"`
@@ -84,6 +147,8 @@ type AngularCodeFileViewModel struct {
 	MetricsTableHeaders []AngularMetricDefinitionViewModel `json:"mmh"` // Headers for this file's metrics table
 	MetricsTableRows    []AngularMethodMetricsViewModel    `json:"mmr"` // Rows for this file's metrics table
 	CodeElements        []AngularCodeElementViewModel      `json:"ce"`  // For the "Methods/Properties" sidebar
+
+	External bool `json:"ex,omitempty"` // The file is outside the source directories
 }
 
 // AngularClassDetailViewModel represents the detailed data for a single class page for Angular.
@@ -110,8 +175,8 @@ type AngularRiskHotspotViewModel struct {
 
 // AngularRiskHotspotStatusMetricViewModel represents a single metric's status for a risk hotspot.
 type AngularRiskHotspotStatusMetricViewModel struct {
-	Value    float64 `json:"value"` // C# has this as decimal?, let's use float64 in Go
-	Exceeded bool    `json:"exceeded"`
+	Value    *float64 `json:"value"` // null if the method has no value for the metric
+	Exceeded bool     `json:"exceeded"`
 }
 
 // AngularRiskHotspotMetricHeaderViewModel corresponds to the data structure for window.riskHotspotMetrics (headers).
@@ -125,31 +190,36 @@ type AngularRiskHotspotMetricHeaderViewModel struct {
 type AngularMetricDefinitionViewModel struct {
 	Name           string `json:"name"`           // e.g., "Cyclomatic Complexity"
 	ExplanationURL string `json:"explanationUrl"` // URL for the info icon
+	Key            string `json:"-"`              // Untranslated metric name used to look up method values
 }
 
 // AngularMethodMetricsViewModel represents a single method's row in the metrics table
 type AngularMethodMetricsViewModel struct {
-	Name           string   `json:"name"`                     // Display name of the method/property
-	FullName       string   `json:"fullName"`                 // NEW: Full unique name (for title attributes, etc.)
-	FileIndex      int      `json:"fileIndex"`                // Index of the file (for linking)
-	FileIndexPlus1 int      `json:"fileIndexPlus1,omitempty"` // NEW: 1-based index for display
-	FileShortPath  string   `json:"fileShortPath,omitempty"`  // NEW: Sanitized file path for href ID
-	Line           int      `json:"line"`                     // First line of the method (for linking)
-	MetricValues   []string `json:"metricValues"`             // Metric values as strings, in order of headers
-	IsProperty     bool     `json:"isProperty"`               // To choose icon (wrench vs cube)
-	CoverageQuota  *float64 `json:"coverageQuota"`            // Method's own line coverage quota
+	Name           string               `json:"name"`                     // Display name of the method/property
+	FullName       string               `json:"fullName"`                 // NEW: Full unique name (for title attributes, etc.)
+	FileIndex      int                  `json:"fileIndex"`                // Index of the file (for linking)
+	FileIndexPlus1 int                  `json:"fileIndexPlus1,omitempty"` // NEW: 1-based index for display
+	FileShortPath  string               `json:"fileShortPath,omitempty"`  // NEW: Sanitized file path for href ID
+	Line           int                  `json:"line"`                     // First line of the method (for linking)
+	MetricValues   []string             `json:"metricValues"`             // Metric values as strings, in order of headers
+	MetricStatuses []model.MetricStatus `json:"metricStatuses"`           // Threshold status per metric value (0 ok, 1 warning, 2 error)
+	IsProperty     bool                 `json:"isProperty"`               // To choose icon (wrench vs cube)
+	RiskHotspot    string               `json:"riskHotspot,omitempty"`    // Tooltip of the risk hotspot badge, empty if the method is no hotspot
+	CoverageQuota  *float64             `json:"coverageQuota"`            // Method's own line coverage quota
 }
 
 // ClassDetailData is the top-level struct for the class_detail_layout.gohtml template
 type ClassDetailData struct {
 	ReportTitle     string
 	AppVersion      string // e.g., "5.4.7.0"
+	CommandLine     string // Sanitized arguments of the run, empty if unknown
 	CurrentDateTime string
 
 	Class                                 ClassViewModelForDetail // Specific view model for the class being detailed
 	BranchCoverageAvailable               bool
-	MethodCoverageAvailable               bool // To decide whether to show PRO version message
+	MethodCoverageAvailable               bool // False if the reports provide no methods; the card shows a note instead
 	Tag                                   string
+	TagLink                               string // Optional URL for the tag, e.g. the CI build
 	Translations                          map[string]string
 	MaximumDecimalPlacesForCoverageQuotas int // Needed for JS if any Angular components on page use it
 
@@ -160,9 +230,9 @@ type ClassDetailData struct {
 	AngularMainJsFile      string
 	CombinedAngularJsFile  string
 
-	// For window.* JSON objects
+	// For window.* JSON objects. window.assemblies is left out: only the summary page
+	// reads it, and repeating it made each class page as large as the whole summary.
 	ClassDetailJSON                    template.JS // This will contain AngularClassDetailViewModel
-	AssembliesJSON                     template.JS
 	RiskHotspotsJSON                   template.JS
 	MetricsJSON                        template.JS
 	RiskHotspotMetricsJSON             template.JS
@@ -178,6 +248,7 @@ type ClassViewModelForDetail struct {
 	IsMultiFile                            bool
 	CoveragePercentageForDisplay           string
 	CoveragePercentageBarValue             int
+	CoverageQuota                          string // aria-valuenow of the percentage bar, empty if N/A
 	CoveredLines                           int
 	UncoveredLines                         int
 	CoverableLines                         int
@@ -185,46 +256,59 @@ type ClassViewModelForDetail struct {
 	CoverageRatioTextForDisplay            string
 	BranchCoveragePercentageForDisplay     string
 	BranchCoveragePercentageBarValue       int
+	BranchCoverageQuota                    string
 	CoveredBranches                        int
 	TotalBranches                          int
 	BranchCoverageRatioTextForDisplay      string
 	MethodCoveragePercentageForDisplay     string
 	MethodCoveragePercentageBarValue       int
+	MethodCoverageQuota                    string
 	FullMethodCoveragePercentageForDisplay string
 	CoveredMethods                         int
 	FullyCoveredMethods                    int
 	TotalMethods                           int
 	MethodCoverageRatioTextForDisplay      string
 	FullMethodCoverageRatioTextForDisplay  string
+	ComplexityForDisplay                   string // Cyclomatic complexity of the class, "-" if unknown
 	MetricsTable                           MetricsTableViewModel
 	FilesWithMetrics                       bool
-	SidebarElements                        []SidebarElementViewModel
+	SidebarFiles                           []SidebarFileViewModel
+	TestMethods                            []TestMethodViewModel // Tests with per-line hits, empty if the report has none
+	ApproximateBranchCoverage              bool                  // Branches of some files were derived from Go cover profile blocks
 	// Fields for JS data, if needed by Angular components directly via this struct (less likely with server-side template)
-	HistoricCoverages         []AngularHistoricCoverageViewModel `json:"hc,omitempty"`
-	LineCoverageHistory       []float64                          `json:"lch,omitempty"`
-	BranchCoverageHistory     []float64                          `json:"bch,omitempty"`
-	MethodCoverageHistory     []float64                          `json:"mch,omitempty"`
-	FullMethodCoverageHistory []float64                          `json:"mfch,omitempty"`
-	Metrics                   map[string]float64                 `json:"metrics,omitempty"` // Class-level aggregated metrics
+	HistoricCoverages         []AngularHistoricCoverageViewModel    `json:"hc,omitempty"`
+	HistoricCoverageBand      *AngularHistoricCoverageBandViewModel `json:"hcb,omitempty"` // Entries older than the ones in hc
+	LineCoverageHistory       []float64                             `json:"lch,omitempty"`
+	BranchCoverageHistory     []float64                             `json:"bch,omitempty"`
+	MethodCoverageHistory     []float64                             `json:"mch,omitempty"`
+	FullMethodCoverageHistory []float64                             `json:"mfch,omitempty"`
+	Metrics                   map[string]float64                    `json:"metrics,omitempty"` // Class-level aggregated metrics
 }
 
 // FileViewModelForDetail represents a source file within a class for server-side rendering
 type FileViewModelForDetail struct {
-	Path      string
-	ShortPath string // For use in href IDs (sanitized)
-	Lines     []LineViewModelForDetail
+	Path               string
+	ShortPath          string // For use in href IDs (sanitized)
+	Lines              []LineViewModelForDetail
+	UncoveredLineCount int  // Lines rendered red or orange, shown on the "uncovered only" toggle
+	Minified           bool // The lines are too long on average to be shown; only their coverage is rendered
+	External           bool // The file is outside the source directories and marked as such
 }
 
 // LineViewModelForDetail represents a single line of code for server-side rendering
 type LineViewModelForDetail struct {
-	LineNumber      int
-	LineContent     string // Raw content, template will escape and handle spaces
-	LineVisitStatus string // CSS class: "green", "red", "orange", "gray"
-	Hits            string // Formatted hits, or empty for not coverable
-	IsBranch        bool
-	BranchBarValue  int // For percentagebar CSS class (0-100 for uncovered part)
-	Tooltip         string
-	DataCoverage    template.JS // JSON string for data-coverage attribute
+	LineNumber         int
+	LineContent        string        // Raw content, template will escape and handle spaces
+	HighlightedContent template.HTML // Content with syntax colors, empty if it is rendered plain
+	TruncatedTitle     string        // Title of the content if LineContent was shortened to the maximum line length
+	LineVisitStatus    string        // CSS class: "green", "red", "orange", "gray"
+	Hits               string        // Formatted hits, or empty for not coverable
+	IsBranch           bool
+	BranchBarValue     int    // For percentagebar CSS class (0-100 for uncovered part)
+	BranchLabel        string // aria-label of the branch cell, e.g. "1 of 2 branches covered"
+	Tooltip            string
+	StatusText         string // Status for screen readers, e.g. "covered, 3 visits"; empty for not coverable
+	DataCoverage       string // JSON of the visits per test for custom.js, escaped by lineRows; empty if the class has no per-test data
 }
 
 // MetricsTableViewModel holds data for the "Metrics" table
@@ -233,13 +317,29 @@ type MetricsTableViewModel struct {
 	Rows    []AngularMethodMetricsViewModel    // Re-use from existing viewmodels.go if it fits
 }
 
+// TestMethodViewModel holds an entry of the "coverage by test" selector
+type TestMethodViewModel struct {
+	ID        string // Key of the test's entries in the lines' data-coverage attribute, e.g. "M0"
+	Name      string // Full test name, used as title
+	ShortName string // Display name
+}
+
+// SidebarFileViewModel groups the "Methods/Properties" sidebar links of one file
+type SidebarFileViewModel struct {
+	Path             string // Full file path, shown as header
+	ShortPath        string // Sanitized file path, ID of the file's section
+	CoverageBarValue int    // For percentagebar CSS (0-100 for uncovered part)
+	CoverageTitle    string // e.g., "Line coverage: 50.0% - src/Calc.cs"
+	Elements         []SidebarElementViewModel
+}
+
 // SidebarElementViewModel holds data for the "Methods/Properties" sidebar links
 type SidebarElementViewModel struct {
 	Name             string // Display name for the link (short, e.g., Method())
 	FullName         string // Full cleaned name (e.g., Namespace.MyClass.Method(Params)) for title
 	FileShortPath    string // Sanitized file path for href ID
-	FileIndexPlus1   int    // 1-based index of the file if class is multi-file
 	Line             int    // First line of the method/property
+	Range            string // "first-last", the lines custom.js highlights on click
 	Icon             string // "cube" for method, "wrench" for property
 	CoverageBarValue int    // For percentagebar CSS (0-100 for uncovered part)
 	CoverageTitle    string // e.g., "Line coverage: 50% - Namespace.MyClass.Method(Params)"
@@ -249,6 +349,7 @@ type SidebarElementViewModel struct {
 type SummaryPageData struct {
 	ReportTitle     string
 	AppVersion      string
+	CommandLine     string
 	CurrentDateTime string
 	Translations    map[string]string // For direct use in template
 
@@ -269,12 +370,67 @@ type SummaryPageData struct {
 	RiskHotspotMetricsJSON             template.JS
 	HistoricCoverageExecutionTimesJSON template.JS
 	TranslationsJSON                   template.JS
+	SummarySort                        *AngularSummarySortViewModel // nil keeps the default sorting of the class table
 
 	BranchCoverageAvailable               bool
 	MethodCoverageAvailable               bool
 	MaximumDecimalPlacesForCoverageQuotas int
 	HasRiskHotspots                       bool
 	HasAssemblies                         bool
+	ClassDetailsOnDemand                  bool // Adds the container the class details are loaded into
+
+	SkippedReports     []SkippedReportViewModel
+	MissingSourceFiles []MissingSourceFileViewModel
+	MissingSourceCount int // Distinct files among MissingSourceFiles, which list a file per referencing class
+
+	DirectoryTreeRoot       string // Directory the rows are relative to, empty if the top-level rows name their directory themselves
+	DirectoryRows           []DirectoryRowViewModel
+	DirectoryBranchCoverage bool // Shows the branch coverage column of the directory tree
+
+	AssemblyRows []AssemblyRowViewModel // Links to the assembly pages, index.html only
+	AssemblyName string                 // Set on the page of an assembly
+}
+
+// AssemblyRowViewModel is a row of the "Assemblies" card on the summary page, which links
+// to the page of the assembly.
+type AssemblyRowViewModel struct {
+	Name           string
+	ReportPath     string
+	Classes        int
+	CoveredLines   int
+	CoverableLines int
+	LineCoverage   string
+	CoverageBar    string // Suffix of the percentagebar CSS class, e.g. "30" or "undefined"
+}
+
+// DirectoryRowViewModel is a row of the "Coverage by directory" card on the summary page.
+// Rows are listed depth-first; Path and ParentPath let custom.js collapse subdirectories.
+type DirectoryRowViewModel struct {
+	Name           string
+	Path           string
+	ParentPath     string
+	Indent         int // Left padding of the name cell in pixels
+	HasChildren    bool
+	Files          int
+	CoveredLines   int
+	CoverableLines int
+	LineCoverage   string
+	CoverageBar    string // Suffix of the percentagebar CSS class, e.g. "30" or "undefined"
+	BranchCoverage string // "-" if the directory has no branch data
+}
+
+// SkippedReportViewModel is a row of the skipped report files card on the summary page.
+type SkippedReportViewModel struct {
+	Path   string
+	Parser string
+	Error  string
+}
+
+// MissingSourceFileViewModel is a row of the "Missing source files" card on the summary page.
+type MissingSourceFileViewModel struct {
+	Path     string
+	Assembly string
+	Class    string
 }
 
 // CardViewModel represents a summary card for the Go template
@@ -282,8 +438,10 @@ type CardViewModel struct {
 	Title                      string
 	SubTitle                   string // e.g., "72%"
 	SubTitlePercentageBarValue int    // e.g., 27 for 72% coverage (100-72)
+	SubTitleQuota              string // aria-valuenow of the percentage bar, e.g. "72.5"; empty if N/A
 	Rows                       []CardRowViewModel
-	ProRequired                bool // For the "Method Coverage" card
+	Note                       string // Shown instead of the rows, e.g. if the input format does not provide the data
+	Footnote                   string
 }
 
 // CardRowViewModel represents a row in a summary card
@@ -292,6 +450,7 @@ type CardRowViewModel struct {
 	Text      string
 	Tooltip   string
 	Alignment string // "left" or "right" (or empty for default)
+	Href      string // Optional link target for Text
 }
 
 // HistoryChartDataViewModel holds data for rendering a history chart with Go templates
@@ -300,3 +459,49 @@ type HistoryChartDataViewModel struct {
 	SVGContent string      // Pre-rendered SVG string
 	JSONData   template.JS // JSON data for chart interactivity (if custom.js uses it)
 }
+
+// HistoryChartPointViewModel is a single Chartist data point. Meta is the index
+// into Tooltips; a nil Value is rendered by Chartist as a gap in the line.
+type HistoryChartPointViewModel struct {
+	Meta  int      `json:"meta"`
+	Value *float64 `json:"value"`
+}
+
+// HistoryChartJSONViewModel is the payload read by renderChart in custom.js.
+// Series are ordered line, branch, method coverage to match the chart CSS classes.
+type HistoryChartJSONViewModel struct {
+	Labels   []string                       `json:"labels"`
+	Series   [][]HistoryChartPointViewModel `json:"series"`
+	Tooltips []string                       `json:"tooltips"`
+}
+
+// RiskHotspotsPageData is the top-level struct for the risk hotspots page.
+type RiskHotspotsPageData struct {
+	ReportTitle     string
+	AppVersion      string
+	CommandLine     string
+	CurrentDateTime string
+	Translations    map[string]string
+
+	Metrics []AngularRiskHotspotMetricHeaderViewModel // Metric columns
+	Rows    []RiskHotspotRowViewModel                 // In the order of the hotspot analysis
+}
+
+// RiskHotspotRowViewModel is a method of the risk hotspots page.
+type RiskHotspotRowViewModel struct {
+	Assembly        string
+	Class           string
+	ClassLink       string // Class page, empty if the class has none
+	MethodName      string
+	MethodShortName string
+	MethodLink      string // Line of the method on the class page
+	Tooltip         string // Exceeded thresholds, one per line
+	Metrics         []RiskHotspotMetricCellViewModel
+}
+
+// RiskHotspotMetricCellViewModel is a metric value of the risk hotspots page.
+type RiskHotspotMetricCellViewModel struct {
+	Value       string // Formatted value, "-" if the method has none
+	SortValue   string // Raw value custom.js sorts by, empty if the method has none
+	StatusClass string // lightorange or lightred if the value exceeds a threshold
+}
@@ -0,0 +1,309 @@
+// Package htmlserve serves the HTML report over HTTP without writing it to disk. The
+// report is re-rendered in memory whenever the input reports change, and open pages are
+// reloaded through a server-sent events endpoint.
+package htmlserve
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter/htmlreport"
+)
+
+// EventsPath is the server-sent events endpoint that notifies pages about a new report.
+const EventsPath = "/_reportgenerator/events"
+
+// defaultPollInterval is how often the watched report files are checked for changes.
+const defaultPollInterval = time.Second
+
+// reloadScript is injected into every served page. It reloads the page when the server
+// announces a regenerated report.
+const reloadScript = `<script>
+(function () {
+  if (!window.EventSource) { return; }
+  var source = new EventSource('` + EventsPath + `');
+  source.addEventListener('reload', function () { window.location.reload(); });
+})();
+</script>
+`
+
+// LoadFunc parses and merges the input reports.
+type LoadFunc func() (*model.SummaryResult, error)
+
+// Server renders the HTML report in memory and serves it. It implements http.Handler.
+type Server struct {
+	reportCtx    reporter.IBuilderContext
+	load         LoadFunc
+	watchedFiles []string
+	pollInterval time.Duration
+
+	mu          sync.RWMutex
+	files       map[string][]byte
+	generation  uint64
+	subscribers map[chan uint64]struct{}
+
+	// loadedStates are the states of the watched files at the last Reload.
+	loadedStates map[string]fileState
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithWatchedFiles sets the files whose changes trigger a regeneration of the report,
+// usually the input coverage reports.
+func WithWatchedFiles(paths []string) Option {
+	return func(s *Server) {
+		s.watchedFiles = paths
+	}
+}
+
+// WithPollInterval sets how often the watched files are checked (default 1s).
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *Server) {
+		if interval > 0 {
+			s.pollInterval = interval
+		}
+	}
+}
+
+// NewServer creates a Server that renders the report returned by load. Call Reload
+// before serving the first request.
+func NewServer(reportCtx reporter.IBuilderContext, load LoadFunc, opts ...Option) *Server {
+	s := &Server{
+		reportCtx:    reportCtx,
+		load:         load,
+		pollInterval: defaultPollInterval,
+		subscribers:  make(map[chan uint64]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Server) logger() *slog.Logger {
+	if s.reportCtx != nil && s.reportCtx.Logger() != nil {
+		return s.reportCtx.Logger()
+	}
+	return slog.Default()
+}
+
+// Reload parses the reports, renders the report in memory and notifies all open pages.
+// On error the previously rendered report is kept.
+func (s *Server) Reload() error {
+	// Stat before loading, so that a change during loading triggers another reload.
+	states := statFiles(s.watchedFiles)
+	s.mu.Lock()
+	s.loadedStates = states
+	s.mu.Unlock()
+
+	summary, err := s.load()
+	if err != nil {
+		return fmt.Errorf("failed to load coverage reports: %w", err)
+	}
+	// A new builder per rendering, as the builder caches the class page filenames.
+	files, err := htmlreport.NewHtmlReportBuilder("", s.reportCtx).CreateReportInMemory(summary)
+	if err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+
+	s.mu.Lock()
+	s.files = files
+	s.generation++
+	generation := s.generation
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- generation:
+		default: // A reload is already pending for this page
+		}
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// ServeHTTP serves the rendered files and the events endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == EventsPath {
+		s.serveEvents(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "index.html"
+	}
+
+	s.mu.RLock()
+	content, ok := s.files[name]
+	s.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if path.Ext(name) == ".html" {
+		content = injectReloadScript(content)
+	}
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+}
+
+// injectReloadScript adds the reload script before the closing body tag of a page.
+func injectReloadScript(page []byte) []byte {
+	index := bytes.LastIndex(page, []byte("</body>"))
+	if index == -1 {
+		return append(append([]byte{}, page...), reloadScript...)
+	}
+	injected := make([]byte, 0, len(page)+len(reloadScript))
+	injected = append(injected, page[:index]...)
+	injected = append(injected, reloadScript...)
+	return append(injected, page[index:]...)
+}
+
+// serveEvents streams a "reload" event to the page whenever the report was regenerated.
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events := make(chan uint64, 1)
+	s.mu.Lock()
+	s.subscribers[events] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, events)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case generation := <-events:
+			fmt.Fprintf(w, "event: reload\ndata: %d\n\n", generation)
+			flusher.Flush()
+		}
+	}
+}
+
+// fileState is what the watcher compares to detect a changed file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+func statFiles(paths []string) map[string]fileState {
+	states := make(map[string]fileState, len(paths))
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil {
+			states[p] = fileState{modTime: info.ModTime(), size: info.Size(), exists: true}
+		} else {
+			states[p] = fileState{}
+		}
+	}
+	return states
+}
+
+// Watch regenerates the report whenever a watched file changed since the last Reload,
+// until ctx is done.
+// Files are polled with os.Stat instead of watched with file system notifications
+// (fsnotify), for these reasons:
+//   - Notifications are not delivered for files on network drives (NFS, SMB) or in
+//     folders shared with containers and VMs, where coverage is often collected.
+//   - Test runners often replace a report by renaming a new file over it. A watch on the
+//     file is removed with the old file, so the directories would have to be watched
+//     and their events filtered, which differs between the platforms.
+//   - Only a few report files are watched, and one stat per file and interval is cheap.
+//     Polling needs no dependency besides the standard library.
+//
+// A file that changes while it is being written is picked up again by the next poll.
+func (s *Server) Watch(ctx context.Context) {
+	if len(s.watchedFiles) == 0 {
+		return
+	}
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := statFiles(s.watchedFiles)
+		s.mu.RLock()
+		var changed []string
+		for _, p := range s.watchedFiles {
+			if current[p] != s.loadedStates[p] {
+				changed = append(changed, p)
+			}
+		}
+		s.mu.RUnlock()
+		if len(changed) == 0 {
+			continue
+		}
+
+		s.logger().Info("Coverage reports changed, regenerating report", "files", strings.Join(changed, ", "))
+		if err := s.Reload(); err != nil {
+			s.logger().Error("Failed to regenerate report, serving the previous one", "error", err)
+		}
+	}
+}
+
+// ListenAndServe renders the report, serves it on addr and watches the report files
+// until ctx is done.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	if err := s.Reload(); err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Addr:    addr,
+		Handler: s,
+		// Ends the event streams of open pages on shutdown.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go s.Watch(ctx)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	s.logger().Info("Serving HTML report", "address", addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve HTML report: %w", err)
+	}
+	return nil
+}
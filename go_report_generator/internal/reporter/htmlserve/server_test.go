@@ -0,0 +1,178 @@
+package htmlserve
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reportconfig"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/settings"
+)
+
+// testReport returns a report with one class, of which covered of 2 lines are covered.
+func testReport(covered int) *model.SummaryResult {
+	class := model.Class{
+		Name:         "Demo.Calc",
+		DisplayName:  "Demo.Calc",
+		LinesCovered: covered,
+		LinesValid:   2,
+		Files:        []model.CodeFile{{Path: "Calc.cs"}},
+	}
+	return &model.SummaryResult{
+		ParserName:   "Cobertura",
+		LinesCovered: covered,
+		LinesValid:   2,
+		Assemblies: []model.Assembly{{
+			Name:         "Demo",
+			Classes:      []model.Class{class},
+			LinesCovered: covered,
+			LinesValid:   2,
+		}},
+	}
+}
+
+func newTestServer(t *testing.T, outputDir string, load LoadFunc, opts ...Option) *Server {
+	t.Helper()
+	cfg, err := reportconfig.NewReportConfiguration(nil, outputDir)
+	if err != nil {
+		t.Fatalf("failed to create report configuration: %v", err)
+	}
+	return NewServer(reporter.NewBuilderContext(cfg, settings.NewSettings(), nil), load, opts...)
+}
+
+func get(t *testing.T, handler http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
+
+func TestServer_ServesReportFromMemory(t *testing.T) {
+	outputDir := t.TempDir()
+	server := newTestServer(t, outputDir, func() (*model.SummaryResult, error) { return testReport(1), nil })
+	if err := server.Reload(); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+
+	index := get(t, server, "/")
+	if index.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for /, got %d", index.Code)
+	}
+	if !strings.Contains(index.Header().Get("Content-Type"), "text/html") {
+		t.Errorf("expected an HTML content type, got %q", index.Header().Get("Content-Type"))
+	}
+	body := index.Body.String()
+	if !strings.Contains(body, EventsPath) || strings.Index(body, EventsPath) > strings.LastIndex(body, "</body>") {
+		t.Errorf("expected the reload script before </body>")
+	}
+
+	for _, path := range []string{"/DemoCalc.html", "/report.css", "/reportgenerator.combined.js"} {
+		if code := get(t, server, path).Code; code != http.StatusOK {
+			t.Errorf("expected status 200 for %s, got %d", path, code)
+		}
+	}
+	if code := get(t, server, "/missing.html").Code; code != http.StatusNotFound {
+		t.Errorf("expected status 404 for a missing page, got %d", code)
+	}
+
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected nothing to be written to disk, found %d entries", len(entries))
+	}
+}
+
+func TestServer_ReloadKeepsPreviousReportOnError(t *testing.T) {
+	fail := false
+	server := newTestServer(t, t.TempDir(), func() (*model.SummaryResult, error) {
+		if fail {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return testReport(1), nil
+	})
+	if err := server.Reload(); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+
+	fail = true
+	if err := server.Reload(); err == nil {
+		t.Fatal("expected Reload to return the load error")
+	}
+	if code := get(t, server, "/index.html").Code; code != http.StatusOK {
+		t.Errorf("expected the previous report to be served, got status %d", code)
+	}
+}
+
+// TestServer_WatchSendsReloadEvent changes a watched report and expects the regenerated
+// report to be served and announced on the events endpoint.
+func TestServer_WatchSendsReloadEvent(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "coverage.xml")
+	if err := os.WriteFile(reportPath, []byte("1"), 0o644); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	load := func() (*model.SummaryResult, error) {
+		content, err := os.ReadFile(reportPath)
+		if err != nil {
+			return nil, err
+		}
+		return testReport(len(content)), nil
+	}
+	server := newTestServer(t, t.TempDir(), load, WithWatchedFiles([]string{reportPath}), WithPollInterval(10*time.Millisecond))
+	if err := server.Reload(); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	before := get(t, server, "/index.html").Body.String()
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+EventsPath, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("failed to connect to the events endpoint: %v", err)
+	}
+	defer response.Body.Close()
+	if contentType := response.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", contentType)
+	}
+	events := bufio.NewReader(response.Body)
+	if line, err := events.ReadString('\n'); err != nil || !strings.HasPrefix(line, ": connected") {
+		t.Fatalf("expected the connected comment, got %q (err: %v)", line, err)
+	}
+
+	go server.Watch(ctx)
+	// Changes the size, so that coarse modification times do not hide the change.
+	if err := os.WriteFile(reportPath, []byte("12"), 0o644); err != nil {
+		t.Fatalf("failed to update report: %v", err)
+	}
+
+	for {
+		line, err := events.ReadString('\n')
+		if err != nil {
+			t.Fatalf("event stream ended before the reload event: %v", err)
+		}
+		if strings.HasPrefix(line, "event: reload") {
+			break
+		}
+	}
+
+	if after := get(t, server, "/index.html").Body.String(); after == before {
+		t.Errorf("expected the regenerated report to be served after the reload event")
+	}
+}
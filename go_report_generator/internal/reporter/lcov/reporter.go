@@ -3,191 +3,221 @@ package lcov
 import (
 	"bufio"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
-	"sort"
 	"strconv"
 
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
+const fileName = "lcov.info"
+
+// LcovReportBuilder writes lcov.info for tools like genhtml or the Coverage Gutters
+// extension.
 type LcovReportBuilder struct {
 	outputDir string
+	logger    *slog.Logger
+
+	reportType    string
+	fileName      string
+	workspaceRoot string // Paths are written relative to it if set, see WithWorkspaceRoot
+	workspaceOnly bool   // Files outside workspaceRoot are left out
+	pathCase      utils.PathCaseMode
 }
 
-func NewLcovReportBuilder(outputDir string) reporter.ReportBuilder {
-	return &LcovReportBuilder{
-		outputDir: outputDir,
+// Option configures a LcovReportBuilder.
+type Option func(*LcovReportBuilder)
+
+// WithWorkspaceRoot writes the paths of the files inside root relative to it, so that
+// editor extensions can match them against the open workspace. Paths outside of it are
+// written as they are.
+func WithWorkspaceRoot(root string) Option {
+	return func(b *LcovReportBuilder) {
+		b.workspaceRoot = root
 	}
 }
 
-func (b *LcovReportBuilder) ReportType() string {
-	return "Lcov"
+// WithWorkspaceOnly leaves out the files outside the workspace root, e.g. vendored
+// dependencies whose coverage would confuse the gutter display of the editor.
+func WithWorkspaceOnly(only bool) Option {
+	return func(b *LcovReportBuilder) {
+		b.workspaceOnly = only
+	}
 }
 
-func (b *LcovReportBuilder) CreateReport(summary *model.SummaryResult) error {
-	fileName := "lcov.info"
-	targetPath := filepath.Join(b.outputDir, fileName)
-
-	file, err := os.Create(targetPath)
-	if err != nil {
-		return fmt.Errorf("failed to create lcov report file '%s': %w", targetPath, err)
+// WithPathCase sets whether file paths that differ only in case are the same file when
+// files of several classes are merged and paths are compared with the workspace root.
+// The default is utils.PathCaseAuto.
+func WithPathCase(mode utils.PathCaseMode) Option {
+	return func(b *LcovReportBuilder) {
+		b.pathCase = mode
 	}
-	defer file.Close()
-
-	writer := bufio.NewWriter(file)
-	defer writer.Flush()
-
-	files := getAllFiles(summary.Assemblies)
-	sort.Slice(files, func(i, j int) bool {
-		return files[i].Path < files[j].Path
-	})
+}
 
-	for _, fileAnalysis := range files {
-		if err := writeLcovFileSection(writer, fileAnalysis); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Could not process file '%s' for LCOV report: %v\n", fileAnalysis.Path, err)
+// WithFileName sets the name of the written file (default: lcov.info). Directories of
+// the name are dropped, so the file is always written into the output directory.
+func WithFileName(name string) Option {
+	return func(b *LcovReportBuilder) {
+		if name != "" {
+			b.fileName = filepath.Base(name)
 		}
 	}
-
-	return nil
 }
 
-func writeLcovFileSection(writer *bufio.Writer, file *model.CodeFile) error {
-	// TN: Test Name (optional, often omitted in summary files)
-	if _, err := writer.WriteString(fmt.Sprintf("SF:%s\n", file.Path)); err != nil {
-		return err
+// NewLcovReportBuilder creates a new LcovReportBuilder.
+func NewLcovReportBuilder(outputDir string, logger *slog.Logger, opts ...Option) reporter.ReportBuilder {
+	b := &LcovReportBuilder{
+		outputDir:  outputDir,
+		logger:     logger,
+		reportType: "Lcov",
+		fileName:   fileName,
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
 
-	// FN/FNDA: Function and Function execution data
-	// Sort code elements by line number for deterministic output.
-	sort.SliceStable(file.CodeElements, func(i, j int) bool {
-		return file.CodeElements[i].FirstLine < file.CodeElements[j].FirstLine
-	})
-	fnf := len(file.CodeElements) // Total functions found
-	fnh := 0                      // Total functions hit (covered)
-
-	for _, codeElement := range file.CodeElements {
-		if _, err := writer.WriteString(fmt.Sprintf("FN:%d,%s\n", codeElement.FirstLine, codeElement.FullName)); err != nil {
-			return err
-		}
+// NewCoverageGuttersReportBuilder creates a builder for the CoverageGutters report type: an
+// lcov.info for editor extensions like Coverage Gutters, with the paths relative to the
+// workspace root given by WithWorkspaceRoot.
+func NewCoverageGuttersReportBuilder(outputDir string, logger *slog.Logger, opts ...Option) reporter.ReportBuilder {
+	b := NewLcovReportBuilder(outputDir, logger, opts...).(*LcovReportBuilder)
+	b.reportType = "CoverageGutters"
+	return b
+}
 
-		// Determine if the function was hit.
-		// A function is considered "hit" if at least one of its coverable lines was executed.
-		isHit := false
-		for _, line := range file.Lines {
-			if line.Number >= codeElement.FirstLine && line.Number <= codeElement.LastLine {
-				if line.Hits > 0 {
-					isHit = true
-					break
-				}
-			}
-		}
+// ReportType returns the type of report this builder generates.
+func (b *LcovReportBuilder) ReportType() string {
+	return b.reportType
+}
 
-		hitCount := 0
-		if isHit {
-			hitCount = 1 // LCOV FNDA typically uses 1 for hit, 0 for not hit.
-			fnh++
-		}
-		if _, err := writer.WriteString(fmt.Sprintf("FNDA:%d,%s\n", hitCount, codeElement.FullName)); err != nil {
-			return err
-		}
+// CreateReport writes one SF section per source file of the model.SummaryResult. Files
+// that belong to several classes are merged into a single section.
+func (b *LcovReportBuilder) CreateReport(summary *model.SummaryResult) error {
+	if err := os.MkdirAll(b.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create target directory: %w", err)
 	}
 
-	if _, err := writer.WriteString(fmt.Sprintf("FNF:%d\n", fnf)); err != nil {
-		return err
-	}
-	if _, err := writer.WriteString(fmt.Sprintf("FNH:%d\n", fnh)); err != nil {
-		return err
+	targetPath := filepath.Join(b.outputDir, b.fileName)
+	file, err := os.Create(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to create lcov report file '%s': %w", targetPath, err)
 	}
+	defer file.Close()
 
-	// DA: Line data
-	lf := 0 // Lines found (coverable)
-	lh := 0 // Lines hit (covered)
-
-	// Sort lines to ensure deterministic output for DA records
-	sortedLines := make([]model.Line, len(file.Lines))
-	copy(sortedLines, file.Lines)
-	sort.SliceStable(sortedLines, func(i, j int) bool {
-		return sortedLines[i].Number < sortedLines[j].Number
-	})
+	b.logger.Info("Writing lcov report to file", "path", targetPath)
 
-	for _, line := range sortedLines {
-		if line.LineVisitStatus != model.NotCoverable {
-			lf++
-			if line.Hits > 0 {
-				lh++
-			}
-			if _, err := writer.WriteString(fmt.Sprintf("DA:%d,%d\n", line.Number, line.Hits)); err != nil {
-				return err
+	writer := bufio.NewWriter(file)
+	skipped := 0
+	for _, sourceFile := range reporter.MergeFiles(reporter.AllClasses(summary.Assemblies), utils.PathKeyFunc(b.pathCase, false)) {
+		path := sourceFile.Path
+		if b.workspaceRoot != "" {
+			relativePath, inside := utils.WorkspaceRelativePath(path, b.workspaceRoot, b.pathCase)
+			switch {
+			case inside:
+				path = relativePath
+			case b.workspaceOnly:
+				skipped++
+				continue
 			}
 		}
+		writeFileSection(writer, path, sourceFile)
 	}
-
-	if _, err := writer.WriteString(fmt.Sprintf("LF:%d\n", lf)); err != nil {
-		return err
+	if skipped > 0 {
+		b.logger.Info("Left out files outside the workspace root", "count", skipped, "workspaceRoot", b.workspaceRoot)
 	}
-	if _, err := writer.WriteString(fmt.Sprintf("LH:%d\n", lh)); err != nil {
-		return err
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write lcov report file '%s': %w", targetPath, err)
 	}
+	return nil
+}
 
-	// BRDA/BRF/BRH: Branch data
-	brf := 0 // Branches found
-	brh := 0 // Branches hit
-	for _, line := range sortedLines {
-		if line.IsBranchPoint && len(line.Branch) > 0 {
-			for blockIdx, branchDetail := range line.Branch {
-				brf++
-				// In LCOV, the 4th parameter is hits, or '-' if never taken.
-				hits := "-"
-				if branchDetail.Visits > 0 {
-					brh++
-					hits = strconv.Itoa(branchDetail.Visits)
-				}
-				// BRDA:<line_number>,<block_number>,<branch_number>,<hits>
-				// block_number is usually 0 unless you have complex logic.
-				// branch_number is the index of the branch on that line.
-				if _, err := writer.WriteString(fmt.Sprintf("BRDA:%d,%d,%d,%s\n", line.Number, 0, blockIdx, hits)); err != nil {
-					return err
-				}
-			}
+// writeFileSection writes the SF section of a file with the given path. Errors are
+// reported by the bufio.Writer when it is flushed.
+func writeFileSection(writer *bufio.Writer, path string, file *reporter.SourceFile) {
+	fmt.Fprintf(writer, "SF:%s\n", path)
+
+	lineNumbers := file.SortedLineNumbers()
+
+	// FN/FNDA: a function is hit if its coverage quota is above zero, or, without a
+	// quota, if one of its lines was executed.
+	functionsHit := 0
+	for _, element := range file.Elements {
+		fmt.Fprintf(writer, "FN:%d,%s\n", element.FirstLine, element.FullName)
+	}
+	for _, element := range file.Elements {
+		hit := 0
+		if reporter.IsElementHit(element, file.Lines) {
+			hit = 1
+			functionsHit++
 		}
+		fmt.Fprintf(writer, "FNDA:%d,%s\n", hit, element.FullName)
 	}
-
-	if brf > 0 {
-		if _, err := writer.WriteString(fmt.Sprintf("BRF:%d\n", brf)); err != nil {
-			return err
+	fmt.Fprintf(writer, "FNF:%d\n", len(file.Elements))
+	fmt.Fprintf(writer, "FNH:%d\n", functionsHit)
+
+	// BRDA: the block is always 0 and the branch number is the index of the branch on
+	// its line. "-" marks branches of lines that were never executed.
+	branchesFound, branchesHit := 0, 0
+	for _, number := range lineNumbers {
+		line := file.Lines[number]
+		if line.LineVisitStatus == model.NotCoverable || !line.IsBranchPoint {
+			continue
 		}
-		if _, err := writer.WriteString(fmt.Sprintf("BRH:%d\n", brh)); err != nil {
-			return err
+		notTaken := "0"
+		if line.Hits <= 0 {
+			notTaken = "-"
+		}
+		writeBranch := func(index, visits int) {
+			branchesFound++
+			taken := notTaken
+			if visits > 0 {
+				branchesHit++
+				taken = strconv.Itoa(visits)
+			}
+			fmt.Fprintf(writer, "BRDA:%d,0,%d,%s\n", line.Number, index, taken)
 		}
-	}
-
-	if _, err := writer.WriteString("end_of_record\n"); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// getAllFiles collects and deduplicates all CodeFile objects from the summary.
-func getAllFiles(assemblies []model.Assembly) []*model.CodeFile {
-	fileMap := make(map[string]*model.CodeFile)
 
-	for _, assembly := range assemblies {
-		for _, class := range assembly.Classes {
-			for i := range class.Files {
-				file := &class.Files[i]
-				if _, exists := fileMap[file.Path]; !exists {
-					fileMap[file.Path] = file
+		if len(line.Branch) > 0 {
+			for index, branch := range line.Branch {
+				writeBranch(index, branch.Visits)
+			}
+		} else {
+			// Only counts are known, e.g. for approximated Go branch coverage.
+			for index := 0; index < line.TotalBranches; index++ {
+				visits := 0
+				if index < line.CoveredBranches {
+					visits = 1
 				}
+				writeBranch(index, visits)
 			}
 		}
 	}
+	if branchesFound > 0 {
+		fmt.Fprintf(writer, "BRF:%d\n", branchesFound)
+		fmt.Fprintf(writer, "BRH:%d\n", branchesHit)
+	}
 
-	files := make([]*model.CodeFile, 0, len(fileMap))
-	for _, file := range fileMap {
-		files = append(files, file)
+	// DA: every coverable line, with hits clamped to zero.
+	linesFound, linesHit := 0, 0
+	for _, number := range lineNumbers {
+		line := file.Lines[number]
+		if line.LineVisitStatus == model.NotCoverable {
+			continue
+		}
+		hits := max(line.Hits, 0)
+		linesFound++
+		if hits > 0 {
+			linesHit++
+		}
+		fmt.Fprintf(writer, "DA:%d,%d\n", line.Number, hits)
 	}
-	return files
+	fmt.Fprintf(writer, "LF:%d\n", linesFound)
+	fmt.Fprintf(writer, "LH:%d\n", linesHit)
+
+	fmt.Fprintln(writer, "end_of_record")
 }
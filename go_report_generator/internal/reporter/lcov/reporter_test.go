@@ -0,0 +1,340 @@
+package lcov
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+func intPtr(v int) *int { return &v }
+
+func floatPtr(v float64) *float64 { return &v }
+
+// partialClassReport has the class Demo.Calc split over two classes that share
+// Calc.cs, as for C# partial classes, plus a Go file with approximated branch counts.
+func partialClassReport() *model.SummaryResult {
+	calcPart1 := model.Class{
+		Name:            "Demo.Calc",
+		LinesCovered:    1,
+		LinesValid:      2,
+		BranchesCovered: intPtr(1),
+		BranchesValid:   intPtr(2),
+		Files: []model.CodeFile{{
+			Path: "src/Calc.cs",
+			Lines: []model.Line{
+				{Number: 3, Hits: 4, LineVisitStatus: model.Covered},
+				{Number: 4, Hits: -1, LineVisitStatus: model.NotCoverable},
+				{Number: 5, Hits: 0, IsBranchPoint: true, LineVisitStatus: model.NotCovered, CoveredBranches: 1, TotalBranches: 2,
+					Branch: []model.BranchCoverageDetail{{Identifier: "0", Visits: 1}, {Identifier: "1", Visits: 0}}},
+			},
+			CodeElements: []model.CodeElement{
+				{Name: "Add", FullName: "Add(int, int)", Type: model.MethodElementType, FirstLine: 3, LastLine: 3, CoverageQuota: floatPtr(100)},
+			},
+		}},
+	}
+	calcPart2 := model.Class{
+		Name:         "Demo.Calc/Nested",
+		LinesCovered: 0,
+		LinesValid:   1,
+		Files: []model.CodeFile{{
+			Path: "src/Calc.cs",
+			Lines: []model.Line{
+				{Number: 9, Hits: 0, LineVisitStatus: model.NotCovered},
+			},
+			CodeElements: []model.CodeElement{
+				{Name: "Div", FullName: "Div(int, int)", Type: model.MethodElementType, FirstLine: 8, LastLine: 10, CoverageQuota: floatPtr(0)},
+			},
+		}},
+	}
+	goPackage := model.Class{
+		Name:            "example.com/tool/parser",
+		LinesCovered:    2,
+		LinesValid:      2,
+		BranchesCovered: intPtr(1),
+		BranchesValid:   intPtr(3),
+		Files: []model.CodeFile{{
+			Path: "parser/parser.go",
+			Lines: []model.Line{
+				{Number: 4, Hits: 2, LineVisitStatus: model.Covered},
+				{Number: 5, Hits: 2, IsBranchPoint: true, LineVisitStatus: model.PartiallyCovered, CoveredBranches: 1, TotalBranches: 3},
+			},
+			CodeElements: []model.CodeElement{
+				{Name: "Parse", FullName: "Parse", Type: model.MethodElementType, FirstLine: 3, LastLine: 7},
+			},
+		}},
+	}
+	return &model.SummaryResult{
+		LinesCovered:    3,
+		LinesValid:      5,
+		BranchesCovered: intPtr(2),
+		BranchesValid:   intPtr(5),
+		Assemblies: []model.Assembly{
+			{Name: "Demo", Classes: []model.Class{calcPart1, calcPart2}},
+			{Name: "example.com/tool", Classes: []model.Class{goPackage}},
+		},
+	}
+}
+
+func createReport(t *testing.T, summary *model.SummaryResult) string {
+	t.Helper()
+	outputDir := t.TempDir()
+	builder := NewLcovReportBuilder(outputDir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := builder.CreateReport(summary); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "lcov.info"))
+	if err != nil {
+		t.Fatalf("failed to read generated report: %v", err)
+	}
+	return string(content)
+}
+
+func TestCreateReport_Content(t *testing.T) {
+	got := createReport(t, partialClassReport())
+
+	want := `SF:parser/parser.go
+FN:3,Parse
+FNDA:1,Parse
+FNF:1
+FNH:1
+BRDA:5,0,0,1
+BRDA:5,0,1,0
+BRDA:5,0,2,0
+BRF:3
+BRH:1
+DA:4,2
+DA:5,2
+LF:2
+LH:2
+end_of_record
+SF:src/Calc.cs
+FN:3,Add(int, int)
+FN:8,Div(int, int)
+FNDA:1,Add(int, int)
+FNDA:0,Div(int, int)
+FNF:2
+FNH:1
+BRDA:5,0,0,1
+BRDA:5,0,1,-
+BRF:2
+BRH:1
+DA:3,4
+DA:5,0
+DA:9,0
+LF:3
+LH:1
+end_of_record
+`
+	if got != want {
+		t.Errorf("unexpected lcov.info\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+// lcovTotals sums the LF/LH/BRF/BRH records of an lcov.info file.
+type lcovTotals struct {
+	linesFound, linesHit, branchesFound, branchesHit int
+}
+
+func readTotals(t *testing.T, content string) lcovTotals {
+	t.Helper()
+	var totals lcovTotals
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		target := map[string]*int{
+			"LF":  &totals.linesFound,
+			"LH":  &totals.linesHit,
+			"BRF": &totals.branchesFound,
+			"BRH": &totals.branchesHit,
+		}[key]
+		if target == nil {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			t.Fatalf("invalid %s record %q: %v", key, value, err)
+		}
+		*target += n
+	}
+	return totals
+}
+
+// TestCreateReport_TotalsMatchSummary expects the LF/LH/BRF/BRH records to add up to
+// the totals of the summary, so that tools reading lcov.info show the same coverage.
+func TestCreateReport_TotalsMatchSummary(t *testing.T) {
+	summary := partialClassReport()
+	got := readTotals(t, createReport(t, summary))
+
+	want := lcovTotals{
+		linesFound:    summary.LinesValid,
+		linesHit:      summary.LinesCovered,
+		branchesFound: *summary.BranchesValid,
+		branchesHit:   *summary.BranchesCovered,
+	}
+	if got != want {
+		t.Errorf("expected totals %+v, got %+v", want, got)
+	}
+}
+
+func TestMergeLine_AddsHitsAndBranchVisits(t *testing.T) {
+	a := model.Line{Number: 5, Hits: 1, IsBranchPoint: true, LineVisitStatus: model.PartiallyCovered,
+		Branch: []model.BranchCoverageDetail{{Identifier: "0", Visits: 1}, {Identifier: "1", Visits: 0}}}
+	b := model.Line{Number: 5, Hits: 2, IsBranchPoint: true, LineVisitStatus: model.Covered,
+		Branch: []model.BranchCoverageDetail{{Identifier: "1", Visits: 2}}}
+
+	merged := reporter.MergeLine(a, b)
+
+	if merged.Hits != 3 {
+		t.Errorf("expected 3 hits, got %d", merged.Hits)
+	}
+	if len(merged.Branch) != 2 || merged.Branch[0].Visits != 1 || merged.Branch[1].Visits != 2 {
+		t.Errorf("expected branch visits [1 2], got %+v", merged.Branch)
+	}
+	if a.Branch[1].Visits != 0 {
+		t.Errorf("merging must not modify the input lines")
+	}
+	if merged.LineVisitStatus != model.Covered {
+		t.Errorf("expected the merged line to be covered, got %v", merged.LineVisitStatus)
+	}
+}
+
+// readLines parses the DA and BRDA records of an lcov.info file with a single file back
+// into lines, deriving their branch counts and status like a coverage parser would.
+func readLines(t *testing.T, content string) map[int]model.Line {
+	t.Helper()
+	lines := make(map[int]model.Line)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || (key != "DA" && key != "BRDA") {
+			continue
+		}
+		fields := strings.Split(value, ",")
+		number, err := strconv.Atoi(fields[0])
+		if err != nil {
+			t.Fatalf("invalid %s record %q: %v", key, value, err)
+		}
+		line := lines[number]
+		line.Number = number
+		if key == "DA" {
+			if line.Hits, err = strconv.Atoi(fields[1]); err != nil {
+				t.Fatalf("invalid DA record %q: %v", value, err)
+			}
+		} else {
+			visits, _ := strconv.Atoi(fields[3]) // "-" is a branch of a line that was never executed
+			line.IsBranchPoint = true
+			line.TotalBranches++
+			if visits > 0 {
+				line.CoveredBranches++
+			}
+			line.Branch = append(line.Branch, model.BranchCoverageDetail{Identifier: fields[2], Visits: visits})
+		}
+		lines[number] = line
+	}
+	for number, line := range lines {
+		line.LineVisitStatus = line.ComputeVisitStatus()
+		lines[number] = line
+	}
+	return lines
+}
+
+// TestCreateReport_RoundTripOfMergedLines writes a line whose branches are covered by
+// different classes and expects lcov.info to read back as the merged line: covered, as
+// all of its branches are.
+func TestCreateReport_RoundTripOfMergedLines(t *testing.T) {
+	line := func(visits0, visits1 int) model.Line {
+		l := model.Line{Number: 5, Hits: 1, IsBranchPoint: true, CoveredBranches: 1, TotalBranches: 2,
+			Branch: []model.BranchCoverageDetail{{Identifier: "0", Visits: visits0}, {Identifier: "1", Visits: visits1}}}
+		l.LineVisitStatus = l.ComputeVisitStatus()
+		return l
+	}
+	summary := &model.SummaryResult{Assemblies: []model.Assembly{{Name: "Demo", Classes: []model.Class{
+		{Name: "Demo.Calc", Files: []model.CodeFile{{Path: "src/Calc.cs", Lines: []model.Line{line(1, 0)}}}},
+		{Name: "Demo.Calc/Nested", Files: []model.CodeFile{{Path: "src/Calc.cs", Lines: []model.Line{line(0, 2)}}}},
+	}}}}
+
+	merged := reporter.MergeFiles(reporter.AllClasses(summary.Assemblies), utils.PathKey)[0].Lines[5]
+	got := readLines(t, createReport(t, summary))[5]
+
+	if merged.LineVisitStatus != model.Covered || merged.CoveredBranches != 2 {
+		t.Errorf("expected the merged line to have both branches covered, got %+v", merged)
+	}
+	if got.Hits != merged.Hits || got.CoveredBranches != merged.CoveredBranches || got.TotalBranches != merged.TotalBranches || got.LineVisitStatus != merged.LineVisitStatus {
+		t.Errorf("lcov.info reads back as %+v, want %+v", got, merged)
+	}
+}
+
+// workspaceReport has a file inside the workspace /work/app, written with backslashes as
+// by a Windows report, and a vendored dependency outside of it.
+func workspaceReport() *model.SummaryResult {
+	app := model.NewCodeFile(`\work\app\src\calc.go`, []model.Line{
+		{Number: 1, Hits: -1, LineVisitStatus: model.NotCoverable},
+		{Number: 2, Hits: 3, LineVisitStatus: model.Covered},
+		{Number: 3, Hits: 0, IsBranchPoint: true, LineVisitStatus: model.NotCovered, CoveredBranches: 0, TotalBranches: 2,
+			Branch: []model.BranchCoverageDetail{{Identifier: "0", Visits: 0}, {Identifier: "1", Visits: 0}}},
+	})
+	dependency := model.NewCodeFile("/home/dev/go/pkg/mod/example.com/lib/lib.go", []model.Line{
+		{Number: 1, Hits: 1, LineVisitStatus: model.Covered},
+	})
+	return &model.SummaryResult{
+		Assemblies: []model.Assembly{{Name: "app", Classes: []model.Class{
+			{Name: "app/calc", Files: []model.CodeFile{app}},
+			{Name: "example.com/lib", Files: []model.CodeFile{dependency}},
+		}}},
+	}
+}
+
+func createCoverageGuttersReport(t *testing.T, summary *model.SummaryResult, opts ...Option) string {
+	t.Helper()
+	outputDir := t.TempDir()
+	builder := NewCoverageGuttersReportBuilder(outputDir, slog.New(slog.NewTextHandler(io.Discard, nil)), opts...)
+	if builder.ReportType() != "CoverageGutters" {
+		t.Errorf("ReportType() = %q, want CoverageGutters", builder.ReportType())
+	}
+	if err := builder.CreateReport(summary); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "lcov.info"))
+	if err != nil {
+		t.Fatalf("failed to read generated report: %v", err)
+	}
+	return string(content)
+}
+
+func TestCoverageGutters_PathsRelativeToWorkspace(t *testing.T) {
+	content := createCoverageGuttersReport(t, workspaceReport(), WithWorkspaceRoot("/work/app"))
+
+	for _, want := range []string{
+		"SF:src/calc.go\n",
+		"SF:/home/dev/go/pkg/mod/example.com/lib/lib.go\n",
+		"BRDA:3,0,0,-\nBRDA:3,0,1,-\n",
+		"DA:2,3\nDA:3,0\nLF:2\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("report does not contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "DA:1,0") {
+		t.Errorf("report contains the line that is not coverable:\n%s", content)
+	}
+}
+
+func TestCoverageGutters_WorkspaceOnly(t *testing.T) {
+	content := createCoverageGuttersReport(t, workspaceReport(), WithWorkspaceRoot(`\work\app\`), WithWorkspaceOnly(true))
+
+	if got := strings.Count(content, "SF:"); got != 1 || !strings.Contains(content, "SF:src/calc.go\n") {
+		t.Errorf("report has %d files, want only src/calc.go:\n%s", got, content)
+	}
+}
@@ -0,0 +1,159 @@
+package reporter
+
+import (
+	"sort"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// SourceFile holds the merged data of all classes that share a source file.
+type SourceFile struct {
+	Path     string
+	Lines    map[int]model.Line
+	Elements []model.CodeElement
+	Classes  []ClassFile // The classes with lines in the file, in the order of the model
+}
+
+// ClassFile is the part of a class that lies in one source file.
+type ClassFile struct {
+	Class *model.Class
+	File  *model.CodeFile
+}
+
+// MergeFiles collects the files of the classes, merging files that appear in several
+// classes (e.g. partial or nested classes) by the key of their path (see
+// utils.PathKeyFunc), and returns them sorted by path.
+func MergeFiles(classes []*model.Class, keyOf func(string) string) []*SourceFile {
+	filesByPath := make(map[string]*SourceFile)
+	seenElements := make(map[string]map[model.CodeElement]bool)
+
+	for _, class := range classes {
+		for i := range class.Files {
+			codeFile := &class.Files[i]
+			key := keyOf(codeFile.Path)
+			merged, ok := filesByPath[key]
+			if !ok {
+				merged = &SourceFile{Path: codeFile.Path, Lines: make(map[int]model.Line)}
+				filesByPath[key] = merged
+				seenElements[key] = make(map[model.CodeElement]bool)
+			}
+			merged.Classes = append(merged.Classes, ClassFile{Class: class, File: codeFile})
+			for _, line := range codeFile.Lines {
+				if existing, ok := merged.Lines[line.Number]; ok {
+					line = MergeLine(existing, line)
+				}
+				merged.Lines[line.Number] = line
+			}
+			for _, element := range codeFile.CodeElements {
+				elementKey := model.CodeElement{FullName: element.FullName, FirstLine: element.FirstLine}
+				if !seenElements[key][elementKey] {
+					seenElements[key][elementKey] = true
+					merged.Elements = append(merged.Elements, element)
+				}
+			}
+		}
+	}
+
+	files := make([]*SourceFile, 0, len(filesByPath))
+	for _, file := range filesByPath {
+		sort.SliceStable(file.Elements, func(i, j int) bool {
+			if file.Elements[i].FirstLine != file.Elements[j].FirstLine {
+				return file.Elements[i].FirstLine < file.Elements[j].FirstLine
+			}
+			return file.Elements[i].FullName < file.Elements[j].FullName
+		})
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// AllClasses returns pointers to the classes of all assemblies, in the order of the model.
+func AllClasses(assemblies []model.Assembly) []*model.Class {
+	var classes []*model.Class
+	for i := range assemblies {
+		for j := range assemblies[i].Classes {
+			classes = append(classes, &assemblies[i].Classes[j])
+		}
+	}
+	return classes
+}
+
+// CountMissingSourceFiles returns the number of distinct files among the missing source
+// files, which list a file once per class referencing it. Spellings of the same path are
+// counted once, see utils.PathKeyFunc.
+func CountMissingSourceFiles(missing []model.MissingSourceFile, keyOf func(string) string) int {
+	paths := make(map[string]struct{}, len(missing))
+	for _, m := range missing {
+		paths[keyOf(m.Path)] = struct{}{}
+	}
+	return len(paths)
+}
+
+// SortedLineNumbers returns the numbers of the lines of the file in ascending order.
+func (f *SourceFile) SortedLineNumbers() []int {
+	numbers := make([]int, 0, len(f.Lines))
+	for number := range f.Lines {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
+// MergeLine combines the data of a line reported by two classes. Hits and the visits
+// of branches with the same identifier are added, and the covered branches and the
+// status are derived from the merged visits.
+func MergeLine(a, b model.Line) model.Line {
+	if a.LineVisitStatus == model.NotCoverable {
+		return b
+	}
+	if b.LineVisitStatus == model.NotCoverable {
+		return a
+	}
+
+	merged := a
+	merged.Hits = max(a.Hits, 0) + max(b.Hits, 0)
+	merged.IsBranchPoint = a.IsBranchPoint || b.IsBranchPoint
+	merged.CoveredBranches = max(a.CoveredBranches, b.CoveredBranches)
+	merged.TotalBranches = max(a.TotalBranches, b.TotalBranches)
+
+	if len(a.Branch) > 0 || len(b.Branch) > 0 {
+		merged.Branch = append([]model.BranchCoverageDetail{}, a.Branch...)
+		indexes := make(map[string]int, len(merged.Branch))
+		for i, branch := range merged.Branch {
+			indexes[branch.Identifier] = i
+		}
+		for _, branch := range b.Branch {
+			if i, ok := indexes[branch.Identifier]; ok {
+				merged.Branch[i].Visits += branch.Visits
+			} else {
+				indexes[branch.Identifier] = len(merged.Branch)
+				merged.Branch = append(merged.Branch, branch)
+			}
+		}
+		visited := 0
+		for _, branch := range merged.Branch {
+			if branch.Visits > 0 {
+				visited++
+			}
+		}
+		merged.TotalBranches = max(merged.TotalBranches, len(merged.Branch))
+		merged.CoveredBranches = min(max(merged.CoveredBranches, visited), merged.TotalBranches)
+	}
+	merged.LineVisitStatus = merged.ComputeVisitStatus()
+	return merged
+}
+
+// IsElementHit reports whether a code element was executed: its coverage quota is above
+// zero, or, without a quota, one of its lines was executed.
+func IsElementHit(element model.CodeElement, lines map[int]model.Line) bool {
+	if element.CoverageQuota != nil {
+		return *element.CoverageQuota > 0
+	}
+	for number := element.FirstLine; number <= element.LastLine; number++ {
+		if line, ok := lines[number]; ok && line.LineVisitStatus != model.NotCoverable && line.Hits > 0 {
+			return true
+		}
+	}
+	return false
+}
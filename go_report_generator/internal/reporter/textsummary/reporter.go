@@ -3,10 +3,12 @@ package textsummary
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -15,18 +17,148 @@ import (
 	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
 )
 
+// defaultFileName is the name of the summary file unless overridden with WithFileName.
+const defaultFileName = "Summary.txt"
+
+// maxListedMissingSourceFiles limits how many unresolved source files are listed in the warning block.
+const maxListedMissingSourceFiles = 10
+
 // TextReportBuilder generates a text summary report.
 type TextReportBuilder struct {
 	outputDir string
+	fileName  string
+	title     string
 	logger    *slog.Logger
+
+	decimalPlaces           int
+	percentageDecimalPlaces int
+	roundingMode            utils.RoundingMode
+	pathCase                utils.PathCaseMode
+	now                     func() time.Time
+	appVersion              string
+	commandLine             string
+	uncoveredLinesClasses   int
+	directoryTree           bool
+	coverageAgeClasses      int
+	aggregates              *reporter.Aggregates
+}
+
+// Option configures a TextReportBuilder.
+type Option func(*TextReportBuilder)
+
+// WithFileName overrides the name of the generated summary file. Empty names are ignored.
+func WithFileName(name string) Option {
+	return func(b *TextReportBuilder) {
+		if name != "" {
+			b.fileName = name
+		}
+	}
+}
+
+// WithTitle sets the heading of the summary. Empty titles keep the default "Summary".
+func WithTitle(title string) Option {
+	return func(b *TextReportBuilder) {
+		if title != "" {
+			b.title = title
+		}
+	}
+}
+
+// WithDecimalPlaces sets the number of decimal places of the coverage quotas computed
+// without WithAggregates (default 1), see settings.Settings.MaximumDecimalPlacesForCoverageQuotas.
+func WithDecimalPlaces(decimalPlaces int) Option {
+	return func(b *TextReportBuilder) {
+		b.decimalPlaces = decimalPlaces
+	}
+}
+
+// WithPercentageDecimalPlaces sets the number of decimal places of the shown percentages
+// (default 0), see settings.Settings.MaximumDecimalPlacesForPercentageDisplay.
+func WithPercentageDecimalPlaces(decimalPlaces int) Option {
+	return func(b *TextReportBuilder) {
+		b.percentageDecimalPlaces = decimalPlaces
+	}
+}
+
+// WithCoverageQuotaRounding sets how coverage quotas are rounded. The default truncates like ReportGenerator.
+func WithCoverageQuotaRounding(mode utils.RoundingMode) Option {
+	return func(b *TextReportBuilder) {
+		b.roundingMode = mode
+	}
+}
+
+// WithPathCase sets whether file paths that differ only in case are the same file when
+// files are counted. The default is utils.PathCaseAuto.
+func WithPathCase(mode utils.PathCaseMode) Option {
+	return func(b *TextReportBuilder) {
+		b.pathCase = mode
+	}
+}
+
+// WithClock replaces time.Now for the "Generated on" line.
+func WithClock(now func() time.Time) Option {
+	return func(b *TextReportBuilder) {
+		if now != nil {
+			b.now = now
+		}
+	}
+}
+
+// WithGenerator adds the version of the generator and the command line it was run with to
+// the header. Empty values are left out.
+func WithGenerator(appVersion, commandLine string) Option {
+	return func(b *TextReportBuilder) {
+		b.appVersion = appVersion
+		b.commandLine = commandLine
+	}
+}
+
+// WithUncoveredLines adds a section listing the uncovered line ranges of the limit classes
+// with the most uncovered lines. A limit of 0 omits the section.
+func WithUncoveredLines(limit int) Option {
+	return func(b *TextReportBuilder) {
+		b.uncoveredLinesClasses = limit
+	}
+}
+
+// WithDirectoryTree adds a section with the coverage by directory as an indented tree.
+func WithDirectoryTree(enabled bool) Option {
+	return func(b *TextReportBuilder) {
+		b.directoryTree = enabled
+	}
+}
+
+// WithCoverageAge adds a section listing the limit classes whose line coverage has been
+// below the coverage age threshold for the most runs. A limit of 0 omits the section.
+func WithCoverageAge(limit int) Option {
+	return func(b *TextReportBuilder) {
+		b.coverageAgeClasses = limit
+	}
+}
+
+// WithAggregates sets the totals and quotas of the report shared with the other report
+// types. Without it, they are computed from the report with the decimal places set by
+// WithDecimalPlaces.
+func WithAggregates(aggregates *reporter.Aggregates) Option {
+	return func(b *TextReportBuilder) {
+		b.aggregates = aggregates
+	}
 }
 
 // NewTextReportBuilder creates a new TextReportBuilder.
-func NewTextReportBuilder(outputDir string, logger *slog.Logger) reporter.ReportBuilder {
-	return &TextReportBuilder{
-		outputDir: outputDir,
-		logger:    logger,
+func NewTextReportBuilder(outputDir string, logger *slog.Logger, opts ...Option) reporter.ReportBuilder {
+	b := &TextReportBuilder{
+		outputDir:     outputDir,
+		fileName:      defaultFileName,
+		title:         "Summary",
+		logger:        logger,
+		now:           time.Now,
+		decimalPlaces: 1,
+	}
+	for _, opt := range opts {
+		opt(b)
 	}
+	return b
 }
 
 // ReportType returns the type of report this builder generates.
@@ -48,7 +180,7 @@ func (b *TextReportBuilder) CreateReport(summary *model.SummaryResult) error {
 		return fmt.Errorf("failed to create target directory: %w", err)
 	}
 
-	outputPath := filepath.Join(b.outputDir, "Summary.txt")
+	outputPath := filepath.Join(b.outputDir, b.fileName)
 	f, err := os.Create(outputPath)
 	if err != nil {
 		return fmt.Errorf("failed to create report file: %w", err)
@@ -59,11 +191,23 @@ func (b *TextReportBuilder) CreateReport(summary *model.SummaryResult) error {
 
 	sfw := &summaryFileWriter{f: f}
 
-	decimalPlaces := 1                     // Placeholder, should be from settings
-	decimalPlacesForPercentageDisplay := 0 // Placeholder, should be from settings
+	aggregates := b.aggregates
+	if aggregates == nil {
+		aggregates = reporter.NewAggregates(summary, b.decimalPlaces, b.roundingMode, utils.PathKeyFunc(b.pathCase, false))
+	}
+	totals := aggregates.Overall
+	formatQuota := func(quota float64) string {
+		return utils.FormatPercentageWithMode(quota, b.percentageDecimalPlaces, b.roundingMode)
+	}
 
-	sfw.writeLine("Summary")
-	sfw.writeLine("  Generated on: %s", time.Now().Format("02/01/2006 - 15:04:05"))
+	sfw.writeLine("%s", b.title)
+	sfw.writeLine("  Generated on: %s", b.now().Format("02/01/2006 - 15:04:05"))
+	if b.appVersion != "" {
+		sfw.writeLine("  Generated by: ReportGenerator %s", b.appVersion)
+	}
+	if b.commandLine != "" {
+		sfw.writeLine("  Command line: %s", b.commandLine)
+	}
 
 	if summary.Timestamp > 0 {
 		sfw.writeLine("  Coverage date: %s", time.Unix(summary.Timestamp, 0).Format("02/01/2006 - 15:04:05"))
@@ -71,82 +215,190 @@ func (b *TextReportBuilder) CreateReport(summary *model.SummaryResult) error {
 
 	sfw.writeLine("  Parser: %s", summary.ParserName)
 
-	totalClasses := 0
-	totalFiles := 0
-	processedFilePaths := make(map[string]bool)
-	for _, assembly := range summary.Assemblies {
-		totalClasses += len(assembly.Classes)
-		for _, class := range assembly.Classes {
-			for _, codeFile := range class.Files {
-				if !processedFilePaths[codeFile.Path] {
-					processedFilePaths[codeFile.Path] = true
-					totalFiles++
-				}
-			}
-		}
+	sfw.writeLine("  Assemblies: %d", len(summary.Assemblies))
+	sfw.writeLine("  Classes: %d", totals.Classes)
+	if summary.HiddenClasses > 0 {
+		sfw.writeLine("  Classes hidden by coverage filter: %d", summary.HiddenClasses)
+	}
+	sfw.writeLine("  Files: %d", totals.Files)
+	if summary.ExternalFiles > 0 {
+		sfw.writeLine("  External files: %d", summary.ExternalFiles)
 	}
 
-	sfw.writeLine("  Assemblies: %d", len(summary.Assemblies))
-	sfw.writeLine("  Classes: %d", totalClasses)
-	sfw.writeLine("  Files: %d", totalFiles)
-
-	overallLineCoverage := utils.CalculatePercentage(summary.LinesCovered, summary.LinesValid, decimalPlaces)
-	sfw.writeLine("  Line coverage: %s", utils.FormatPercentage(overallLineCoverage, decimalPlacesForPercentageDisplay))
-	sfw.writeLine("  Covered lines: %d", summary.LinesCovered)
-	sfw.writeLine("  Uncovered lines: %d", summary.LinesValid-summary.LinesCovered)
-	sfw.writeLine("  Coverable lines: %d", summary.LinesValid)
-	if summary.TotalLines > 0 {
-		sfw.writeLine("  Total lines: %d", summary.TotalLines)
+	sfw.writeLine("  Line coverage: %s", formatQuota(totals.LineQuota))
+	sfw.writeLine("  Covered lines: %d", totals.LinesCovered)
+	sfw.writeLine("  Uncovered lines: %d", totals.LinesValid-totals.LinesCovered)
+	sfw.writeLine("  Coverable lines: %d", totals.LinesValid)
+	if totals.TotalLines > 0 {
+		sfw.writeLine("  Total lines: %d", totals.TotalLines)
 	} else {
 		sfw.writeLine("  Total lines: N/A")
 	}
 
-	if summary.BranchesValid != nil && summary.BranchesCovered != nil {
-		overallBranchCoverage := utils.CalculatePercentage(*summary.BranchesCovered, *summary.BranchesValid, decimalPlaces)
-		// Only print percentage if there are valid branches (CalculatePercentage returns NaN if total is 0)
-		if *summary.BranchesValid > 0 {
-			sfw.writeLine("  Branch coverage: %s (%d of %d)", utils.FormatPercentage(overallBranchCoverage, decimalPlacesForPercentageDisplay), *summary.BranchesCovered, *summary.BranchesValid)
+	if totals.BranchesValid != nil && totals.BranchesCovered != nil {
+		// Only print percentage if there are valid branches (the quota is NaN otherwise)
+		if *totals.BranchesValid > 0 {
+			sfw.writeLine("  Branch coverage: %s (%d of %d)", formatQuota(totals.BranchQuota), *totals.BranchesCovered, *totals.BranchesValid)
 		} else { // No valid branches, just print counts or N/A for percentage
-			sfw.writeLine("  Branch coverage: N/A (%d of %d)", *summary.BranchesCovered, *summary.BranchesValid)
+			sfw.writeLine("  Branch coverage: N/A (%d of %d)", *totals.BranchesCovered, *totals.BranchesValid)
+		}
+		sfw.writeLine("  Covered branches: %d", *totals.BranchesCovered)
+		sfw.writeLine("  Total branches: %d", *totals.BranchesValid)
+		if summary.HasApproximateBranchCoverage() {
+			sfw.writeLine("  Branch coverage of Go code is approximated from the cover profile blocks.")
 		}
-		sfw.writeLine("  Covered branches: %d", *summary.BranchesCovered)
-		sfw.writeLine("  Total branches: %d", *summary.BranchesValid)
 	}
 
-	totalMethodsAgg, coveredMethodsAgg, fullyCoveredMethodsAgg := 0, 0, 0
-	for _, assembly := range summary.Assemblies {
-		for _, class := range assembly.Classes {
-			// Assuming model.Class now has these pre-aggregated from analyzer/class.go
-			totalMethodsAgg += class.TotalMethods
-			coveredMethodsAgg += class.CoveredMethods
-			fullyCoveredMethodsAgg += class.FullyCoveredMethods
-		}
+	// The method quotas are the same as on the "Full covered code elements" card of the Html report.
+	if summary.MethodCoverageAvailable {
+		sfw.writeLine("  Method coverage: %s (%d of %d)", formatQuota(totals.MethodQuota), totals.CoveredMethods, totals.TotalMethods)
+		sfw.writeLine("  Full method coverage: %s (%d of %d)", formatQuota(totals.FullMethodQuota), totals.FullyCoveredMethods, totals.TotalMethods)
+		sfw.writeLine("  Covered methods: %d", totals.CoveredMethods)
+		sfw.writeLine("  Fully covered methods: %d", totals.FullyCoveredMethods)
+		sfw.writeLine("  Total methods: %d", totals.TotalMethods)
+	} else {
+		sfw.writeLine("  Method coverage: N/A")
+		sfw.writeLine("  Full method coverage: N/A")
+		sfw.writeLine("  Covered methods: N/A")
+		sfw.writeLine("  Fully covered methods: N/A")
+		sfw.writeLine("  Total methods: N/A")
 	}
-	methodCoverage := utils.CalculatePercentage(coveredMethodsAgg, totalMethodsAgg, decimalPlaces)
-	fullMethodCoverage := utils.CalculatePercentage(fullyCoveredMethodsAgg, totalMethodsAgg, decimalPlaces)
 
-	sfw.writeLine("  Method coverage: %s (%d of %d)", utils.FormatPercentage(methodCoverage, decimalPlacesForPercentageDisplay), coveredMethodsAgg, totalMethodsAgg)
-	sfw.writeLine("  Full method coverage: %s (%d of %d)", utils.FormatPercentage(fullMethodCoverage, decimalPlacesForPercentageDisplay), fullyCoveredMethodsAgg, totalMethodsAgg)
-	sfw.writeLine("  Covered methods: %d", coveredMethodsAgg)
-	sfw.writeLine("  Fully covered methods: %d", fullyCoveredMethodsAgg)
-	sfw.writeLine("  Total methods: %d", totalMethodsAgg)
+	writeSkippedReports(sfw, summary.SkippedReports)
+	writeMissingSourceFiles(sfw, summary.MissingSourceFiles, utils.PathKeyFunc(b.pathCase, false))
+	writeUncoveredLines(sfw, reporter.TopUncoveredClasses(summary, b.uncoveredLinesClasses))
+	writeStaleClasses(sfw, reporter.LongestStaleClasses(summary, b.coverageAgeClasses))
+	if b.directoryTree {
+		b.writeDirectoryTree(f, summary.Directories, aggregates, formatQuota)
+	}
 
 	tw := tabwriter.NewWriter(f, 0, 0, 2, ' ', 0)
 	defer tw.Flush()
-	for _, assembly := range summary.Assemblies {
+	for i, assembly := range summary.Assemblies {
+		assemblyAggregates := aggregates.Assemblies[i]
 		fmt.Fprintln(tw)
-		assemblyLineCoverage := utils.CalculatePercentage(assembly.LinesCovered, assembly.LinesValid, decimalPlaces)
-		fmt.Fprintf(tw, "%s\t  %s\n", assembly.Name, utils.FormatPercentage(assemblyLineCoverage, decimalPlacesForPercentageDisplay))
+		// The method columns follow the line quota, so that the class rows below keep their alignment.
+		fmt.Fprintf(tw, "%s\t  %s\t%s\n", assembly.Name, formatQuota(assemblyAggregates.LineQuota),
+			formatMethodCoverage(summary.MethodCoverageAvailable, assemblyAggregates.Totals, formatQuota))
 
-		sortedClasses := make([]model.Class, len(assembly.Classes))
-		copy(sortedClasses, assembly.Classes)
-		sort.Slice(sortedClasses, func(i, j int) bool {
-			return sortedClasses[i].DisplayName < sortedClasses[j].DisplayName
+		order := make([]int, len(assembly.Classes))
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(x, y int) bool {
+			return assembly.Classes[order[x]].DisplayName < assembly.Classes[order[y]].DisplayName
 		})
-		for _, class := range sortedClasses {
-			classLineCoverage := utils.CalculatePercentage(class.LinesCovered, class.LinesValid, decimalPlaces)
-			fmt.Fprintf(tw, "  %s\t  %s\n", class.DisplayName, utils.FormatPercentage(classLineCoverage, decimalPlacesForPercentageDisplay))
+		for _, j := range order {
+			fmt.Fprintf(tw, "  %s\t  %s\n", assembly.Classes[j].DisplayName, formatQuota(assemblyAggregates.ClassTotals[j].LineQuota))
 		}
 	}
 	return nil
 }
+
+// formatMethodCoverage returns the method and full method coverage of an assembly row.
+func formatMethodCoverage(available bool, totals reporter.Totals, formatQuota func(float64) string) string {
+	if !available {
+		return "Methods: N/A"
+	}
+	return fmt.Sprintf("Methods: %s (%d of %d), fully covered: %s (%d of %d)",
+		formatQuota(totals.MethodQuota), totals.CoveredMethods, totals.TotalMethods,
+		formatQuota(totals.FullMethodQuota), totals.FullyCoveredMethods, totals.TotalMethods)
+}
+
+// writeDirectoryTree prints the line coverage of every directory, indented by depth.
+func (b *TextReportBuilder) writeDirectoryTree(w io.Writer, root *model.DirectoryCoverage, aggregates *reporter.Aggregates, formatQuota func(float64) string) {
+	if root == nil || len(root.Children) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	if root.Name != "" {
+		fmt.Fprintf(w, "Coverage by directory: %s\n", root.Name)
+	} else {
+		fmt.Fprintln(w, "Coverage by directory")
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	var walk func(node *model.DirectoryCoverage, depth int)
+	walk = func(node *model.DirectoryCoverage, depth int) {
+		fmt.Fprintf(tw, "%s%s\t  %s\t  (%d of %d)\n", strings.Repeat("  ", depth), node.Name,
+			formatQuota(aggregates.Quota(node.LinesCovered, node.LinesValid)), node.LinesCovered, node.LinesValid)
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+	for _, child := range root.Children {
+		walk(child, 1)
+	}
+	tw.Flush()
+}
+
+// writeSkippedReports prints a warning block for report files that could not be parsed,
+// so that a rise in coverage caused by a dropped report does not go unnoticed.
+func writeSkippedReports(sfw *summaryFileWriter, skipped []model.SkippedReport) {
+	if len(skipped) == 0 {
+		return
+	}
+
+	sfw.writeLine("")
+	sfw.writeLine("WARNING: %d report file(s) could not be parsed. Their coverage is not included.", len(skipped))
+	for _, s := range skipped {
+		if s.Parser == "" {
+			sfw.writeLine("  %s: %s", s.Path, s.Error)
+		} else {
+			sfw.writeLine("  %s (%s): %s", s.Path, s.Parser, s.Error)
+		}
+	}
+}
+
+// writeMissingSourceFiles prints a warning block for source files that could not be
+// found, so that empty line tables are not mistaken for missing coverage. keyOf
+// returns the key by which file paths are compared.
+func writeMissingSourceFiles(sfw *summaryFileWriter, missing []model.MissingSourceFile, keyOf func(string) string) {
+	if len(missing) == 0 {
+		return
+	}
+
+	sfw.writeLine("")
+	sfw.writeLine("WARNING: %d source file(s) could not be found. Coverage is reported, but source lines are missing.", reporter.CountMissingSourceFiles(missing, keyOf))
+	for i, m := range missing {
+		if i == maxListedMissingSourceFiles {
+			sfw.writeLine("  ... and %d more", len(missing)-maxListedMissingSourceFiles)
+			break
+		}
+		sfw.writeLine("  %s (%s)", m.Path, m.Class)
+	}
+}
+
+// writeUncoveredLines lists the uncovered line ranges per class, so that it is obvious
+// which lines to cover next.
+func writeUncoveredLines(sfw *summaryFileWriter, classes []reporter.UncoveredClass) {
+	if len(classes) == 0 {
+		return
+	}
+
+	sfw.writeLine("")
+	sfw.writeLine("Uncovered lines (top %d classes):", len(classes))
+	for _, c := range classes {
+		sfw.writeLine("  %s (%d uncovered)", c.Class.DisplayName, c.UncoveredLines)
+		for _, f := range c.Files {
+			sfw.writeLine("    %s: %s", f.Path, utils.FormatLineRanges(f.Ranges))
+		}
+	}
+}
+
+// writeStaleClasses lists the classes that have been below the coverage age threshold
+// the longest, so that code nobody covers does not go unnoticed.
+func writeStaleClasses(sfw *summaryFileWriter, classes []reporter.StaleClass) {
+	if len(classes) == 0 {
+		return
+	}
+
+	sfw.writeLine("")
+	sfw.writeLine("Below the line coverage target the longest (top %d classes):", len(classes))
+	for _, c := range classes {
+		age := c.Class.CoverageAge
+		sfw.writeLine("  %s: below %s since %s (%d runs)", c.Class.DisplayName,
+			reporter.FormatCoverageAgeThreshold(age), reporter.FormatCoverageAgeSince(age), age.Runs)
+	}
+}
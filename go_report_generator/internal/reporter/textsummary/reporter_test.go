@@ -0,0 +1,181 @@
+package textsummary
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// methodsReport has one assembly with partially and fully covered methods and one whose
+// methods are not covered at all.
+func methodsReport() *model.SummaryResult {
+	calc := model.Class{
+		Name:                "Demo.Calc",
+		DisplayName:         "Demo.Calc",
+		Files:               []model.CodeFile{{Path: "src/Calc.cs"}},
+		LinesCovered:        5,
+		LinesValid:          8,
+		TotalLines:          30,
+		CoveredMethods:      3,
+		FullyCoveredMethods: 1,
+		TotalMethods:        4,
+	}
+	format := model.Class{
+		Name:                "Demo.Format",
+		DisplayName:         "Demo.Format",
+		Files:               []model.CodeFile{{Path: "src/Format.cs"}},
+		LinesCovered:        4,
+		LinesValid:          4,
+		TotalLines:          12,
+		CoveredMethods:      2,
+		FullyCoveredMethods: 2,
+		TotalMethods:        2,
+	}
+	legacy := model.Class{
+		Name:         "Legacy.Importer",
+		DisplayName:  "Legacy.Importer",
+		Files:        []model.CodeFile{{Path: "legacy/Importer.cs"}},
+		LinesCovered: 0,
+		LinesValid:   6,
+		TotalLines:   20,
+		TotalMethods: 2,
+	}
+	return &model.SummaryResult{
+		ParserName:              "Cobertura",
+		LinesCovered:            9,
+		LinesValid:              18,
+		TotalLines:              62,
+		MethodCoverageAvailable: true,
+		Assemblies: []model.Assembly{
+			{Name: "Demo", Classes: []model.Class{calc, format}, LinesCovered: 9, LinesValid: 12, TotalLines: 42},
+			{Name: "Legacy.Tools", Classes: []model.Class{legacy}, LinesCovered: 0, LinesValid: 6, TotalLines: 20},
+		},
+	}
+}
+
+func createReport(t *testing.T, summary *model.SummaryResult, opts ...Option) string {
+	t.Helper()
+	outputDir := t.TempDir()
+	fixedTime := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	opts = append([]Option{WithClock(func() time.Time { return fixedTime })}, opts...)
+	builder := NewTextReportBuilder(outputDir, slog.New(slog.NewTextHandler(io.Discard, nil)), opts...)
+	if err := builder.CreateReport(summary); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(outputDir, defaultFileName))
+	if err != nil {
+		t.Fatalf("failed to read generated report: %v", err)
+	}
+	return string(got)
+}
+
+// TestCreateReport_Golden compares Summary.txt with testdata/Summary.txt.golden. Run
+// `go test ./internal/reporter/textsummary -update` after intended changes.
+func TestCreateReport_Golden(t *testing.T) {
+	got := []byte(createReport(t, methodsReport()))
+
+	goldenPath := filepath.Join("testdata", "Summary.txt.golden")
+	if *update {
+		if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Summary.txt differs from %s; run with -update and review the diff\n--- got ---\n%s", goldenPath, got)
+	}
+}
+
+func TestCreateReport_MethodCoverageUnavailable(t *testing.T) {
+	summary := methodsReport()
+	summary.MethodCoverageAvailable = false
+
+	got := createReport(t, summary)
+
+	for _, want := range []string{
+		"  Method coverage: N/A\n",
+		"  Full method coverage: N/A\n",
+		"  Total methods: N/A\n",
+		"Demo             75%  Methods: N/A\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestCreateReport_DecimalPlaces(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "Default", want: "  Full method coverage: 37% (3 of 8)\n"},
+		{name: "PercentageDisplay", opts: []Option{WithPercentageDecimalPlaces(1)}, want: "  Full method coverage: 37.5% (3 of 8)\n"},
+		{name: "CoverageQuotas", opts: []Option{WithDecimalPlaces(0), WithPercentageDecimalPlaces(1)}, want: "  Full method coverage: 37.0% (3 of 8)\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := createReport(t, methodsReport(), tc.opts...); !strings.Contains(got, tc.want) {
+				t.Errorf("report does not contain %q:\n%s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateReport_CoverageAge(t *testing.T) {
+	summary := methodsReport()
+	// Noon UTC, so that the date is the same in every local time zone
+	since := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC).Unix()
+	summary.Assemblies[0].Classes[0].CoverageAge = &model.CoverageAge{Threshold: 80, Runs: 3, Since: since}
+	summary.Assemblies[1].Classes[0].CoverageAge = &model.CoverageAge{Threshold: 80, Runs: 5, Since: since}
+
+	outputDir := t.TempDir()
+	builder := NewTextReportBuilder(outputDir, slog.New(slog.NewTextHandler(io.Discard, nil)),
+		WithCoverageAge(1),
+	)
+	if err := builder.CreateReport(summary); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, defaultFileName))
+	if err != nil {
+		t.Fatalf("failed to read generated report: %v", err)
+	}
+	got := string(content)
+
+	want := "Below the line coverage target the longest (top 1 classes):\n" +
+		"  Legacy.Importer: below 80% since 2024-03-02 (5 runs)\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("report does not contain %q:\n%s", want, got)
+	}
+	if strings.Contains(got, "Demo.Calc: below") {
+		t.Errorf("report lists more classes than the limit:\n%s", got)
+	}
+}
+
+func TestCreateReport_ExternalFiles(t *testing.T) {
+	summary := methodsReport()
+	if got := createReport(t, summary); strings.Contains(got, "External files") {
+		t.Errorf("report lists external files although there are none:\n%s", got)
+	}
+
+	summary.ExternalFiles = 2
+	got := createReport(t, summary)
+
+	if want := "  Files: 3\n  External files: 2\n"; !strings.Contains(got, want) {
+		t.Errorf("report does not contain %q:\n%s", want, got)
+	}
+}
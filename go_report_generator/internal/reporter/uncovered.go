@@ -0,0 +1,79 @@
+package reporter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// UncoveredFile holds the uncovered line ranges of one file of a class.
+type UncoveredFile struct {
+	Path   string
+	Ranges []utils.LineRange
+}
+
+// UncoveredClass lists where a class lacks coverage, to answer "what to test next".
+type UncoveredClass struct {
+	Assembly       string
+	Class          *model.Class
+	UncoveredLines int
+	Files          []UncoveredFile
+}
+
+// FormatRanges formats the ranges as "12-18, 25", prefixed with the file path when the
+// class spans several files ("A.cs: 3-5; B.cs: 9").
+func (c UncoveredClass) FormatRanges() string {
+	if len(c.Files) == 1 {
+		return utils.FormatLineRanges(c.Files[0].Ranges)
+	}
+	parts := make([]string, len(c.Files))
+	for i, f := range c.Files {
+		parts[i] = f.Path + ": " + utils.FormatLineRanges(f.Ranges)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// TopUncoveredClasses returns up to limit classes with the most uncovered lines, ties
+// ordered by assembly and class name. Classes without uncovered lines are skipped.
+func TopUncoveredClasses(summary *model.SummaryResult, limit int) []UncoveredClass {
+	if limit <= 0 {
+		return nil
+	}
+
+	var classes []UncoveredClass
+	for ai := range summary.Assemblies {
+		assembly := &summary.Assemblies[ai]
+		for ci := range assembly.Classes {
+			class := &assembly.Classes[ci]
+			uncovered := class.LinesValid - class.LinesCovered
+			if uncovered <= 0 {
+				continue
+			}
+			classes = append(classes, UncoveredClass{Assembly: assembly.Name, Class: class, UncoveredLines: uncovered})
+		}
+	}
+
+	sort.SliceStable(classes, func(i, j int) bool {
+		if classes[i].UncoveredLines != classes[j].UncoveredLines {
+			return classes[i].UncoveredLines > classes[j].UncoveredLines
+		}
+		if classes[i].Assembly != classes[j].Assembly {
+			return classes[i].Assembly < classes[j].Assembly
+		}
+		return classes[i].Class.DisplayName < classes[j].Class.DisplayName
+	})
+	if len(classes) > limit {
+		classes = classes[:limit]
+	}
+
+	for i := range classes {
+		for _, file := range classes[i].Class.Files {
+			if ranges := utils.UncoveredLineRanges(file.Lines); len(ranges) > 0 {
+				classes[i].Files = append(classes[i].Files, UncoveredFile{Path: file.Path, Ranges: ranges})
+			}
+		}
+	}
+	return classes
+}
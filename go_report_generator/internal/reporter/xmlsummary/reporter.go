@@ -0,0 +1,265 @@
+package xmlsummary
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/reporter"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+const fileName = "Summary.xml"
+
+// XmlSummaryReportBuilder writes Summary.xml in the layout of the XmlSummary report of
+// the C# ReportGenerator, so that tools consuming that file keep working.
+type XmlSummaryReportBuilder struct {
+	outputDir string
+	logger    *slog.Logger
+
+	decimalPlaces int
+	roundingMode  utils.RoundingMode
+	now           func() time.Time
+	aggregates    *reporter.Aggregates
+	appVersion    string
+	commandLine   string
+}
+
+// Option configures an XmlSummaryReportBuilder.
+type Option func(*XmlSummaryReportBuilder)
+
+// WithDecimalPlaces sets the number of decimal places of the coverage quotas (default 1).
+func WithDecimalPlaces(decimalPlaces int) Option {
+	return func(b *XmlSummaryReportBuilder) {
+		b.decimalPlaces = decimalPlaces
+	}
+}
+
+// WithCoverageQuotaRounding sets how coverage quotas are rounded. The default truncates like ReportGenerator.
+func WithCoverageQuotaRounding(mode utils.RoundingMode) Option {
+	return func(b *XmlSummaryReportBuilder) {
+		b.roundingMode = mode
+	}
+}
+
+// WithClock replaces time.Now for the "Generatedon" element.
+func WithClock(now func() time.Time) Option {
+	return func(b *XmlSummaryReportBuilder) {
+		if now != nil {
+			b.now = now
+		}
+	}
+}
+
+// WithGenerator records the version of the generator and the command line it was run with
+// in the Generatedby and Commandline elements, which the C# ReportGenerator does not
+// write. Empty values leave the elements out.
+func WithGenerator(appVersion, commandLine string) Option {
+	return func(b *XmlSummaryReportBuilder) {
+		b.appVersion = appVersion
+		b.commandLine = commandLine
+	}
+}
+
+// WithAggregates sets the totals and quotas of the report shared with the other report
+// types. Without it, they are computed from the report with the decimal places and the
+// rounding mode of the builder.
+func WithAggregates(aggregates *reporter.Aggregates) Option {
+	return func(b *XmlSummaryReportBuilder) {
+		b.aggregates = aggregates
+	}
+}
+
+// NewXmlSummaryReportBuilder creates a new XmlSummaryReportBuilder.
+func NewXmlSummaryReportBuilder(outputDir string, logger *slog.Logger, opts ...Option) reporter.ReportBuilder {
+	b := &XmlSummaryReportBuilder{
+		outputDir:     outputDir,
+		logger:        logger,
+		decimalPlaces: 1,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// ReportType returns the type of report this builder generates.
+func (b *XmlSummaryReportBuilder) ReportType() string {
+	return "XmlSummary"
+}
+
+// coverageReport is the root element of Summary.xml. Element and attribute names
+// follow the C# ReportGenerator, including their capitalization.
+type coverageReport struct {
+	XMLName  xml.Name        `xml:"CoverageReport"`
+	Scope    string          `xml:"scope,attr"`
+	Summary  summaryElement  `xml:"Summary"`
+	Coverage coverageElement `xml:"Coverage"`
+}
+
+type summaryElement struct {
+	GeneratedOn         string  `xml:"Generatedon"`
+	GeneratedBy         string  `xml:"Generatedby,omitempty"`
+	CommandLine         string  `xml:"Commandline,omitempty"`
+	Parser              string  `xml:"Parser"`
+	Assemblies          int     `xml:"Assemblies"`
+	Classes             int     `xml:"Classes"`
+	Files               int     `xml:"Files"`
+	CoveredLines        int     `xml:"Coveredlines"`
+	UncoveredLines      int     `xml:"Uncoveredlines"`
+	CoverableLines      int     `xml:"Coverablelines"`
+	TotalLines          int     `xml:"Totallines"`
+	LineCoverage        string  `xml:"Linecoverage"`
+	CoveredBranches     *int    `xml:"Coveredbranches,omitempty"`
+	TotalBranches       *int    `xml:"Totalbranches,omitempty"`
+	BranchCoverage      *string `xml:"Branchcoverage,omitempty"`
+	CoveredMethods      int     `xml:"Coveredmethods"`
+	FullyCoveredMethods int     `xml:"Fullcoveredmethods"`
+	TotalMethods        int     `xml:"Totalmethods"`
+	MethodCoverage      string  `xml:"Methodcoverage"`
+	FullMethodCoverage  string  `xml:"Fullmethodcoverage"`
+}
+
+type coverageElement struct {
+	Assemblies []assemblyElement `xml:"Assembly"`
+}
+
+// coverageAttributes are the attributes shared by the Assembly and Class elements.
+type coverageAttributes struct {
+	Name                string `xml:"name,attr"`
+	Classes             *int   `xml:"classes,attr,omitempty"`
+	Coverage            string `xml:"coverage,attr"`
+	CoveredLines        int    `xml:"coveredlines,attr"`
+	CoverableLines      int    `xml:"coverablelines,attr"`
+	TotalLines          int    `xml:"totallines,attr"`
+	BranchCoverage      string `xml:"branchcoverage,attr"`
+	CoveredBranches     int    `xml:"coveredbranches,attr"`
+	TotalBranches       int    `xml:"totalbranches,attr"`
+	CoveredMethods      int    `xml:"coveredmethods,attr"`
+	FullyCoveredMethods int    `xml:"fullcoveredmethods,attr"`
+	TotalMethods        int    `xml:"totalmethods,attr"`
+	MethodCoverage      string `xml:"methodcoverage,attr"`
+	FullMethodCoverage  string `xml:"fullmethodcoverage,attr"`
+}
+
+type assemblyElement struct {
+	coverageAttributes
+	Classes []classElement `xml:"Class"`
+}
+
+type classElement struct {
+	coverageAttributes
+}
+
+// CreateReport writes Summary.xml for the analyzed model.SummaryResult.
+func (b *XmlSummaryReportBuilder) CreateReport(summary *model.SummaryResult) error {
+	if err := os.MkdirAll(b.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	outputPath := filepath.Join(b.outputDir, fileName)
+	b.logger.Info("Writing XML summary to file", "path", outputPath)
+
+	content, err := xml.MarshalIndent(b.buildReport(summary), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal XML summary: %w", err)
+	}
+	content = append([]byte(xml.Header), content...)
+	if err := os.WriteFile(outputPath, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write XML summary '%s': %w", outputPath, err)
+	}
+	return nil
+}
+
+func (b *XmlSummaryReportBuilder) buildReport(summary *model.SummaryResult) coverageReport {
+	report := coverageReport{Scope: "Summary"}
+	aggregates := b.aggregates
+	if aggregates == nil {
+		aggregates = reporter.NewAggregates(summary, b.decimalPlaces, b.roundingMode, utils.PathKey)
+	}
+
+	for i, assembly := range summary.Assemblies {
+		report.Coverage.Assemblies = append(report.Coverage.Assemblies, buildAssembly(assembly, aggregates.Assemblies[i]))
+	}
+
+	totals := aggregates.Overall
+	report.Summary = summaryElement{
+		GeneratedOn:         b.now().Format("02/01/2006 - 15:04:05"),
+		CommandLine:         b.commandLine,
+		Parser:              summary.ParserName,
+		Assemblies:          len(summary.Assemblies),
+		Classes:             totals.Classes,
+		Files:               totals.Files,
+		CoveredLines:        totals.LinesCovered,
+		UncoveredLines:      totals.LinesValid - totals.LinesCovered,
+		CoverableLines:      totals.LinesValid,
+		TotalLines:          totals.TotalLines,
+		LineCoverage:        formatQuota(totals.LineQuota),
+		CoveredMethods:      totals.CoveredMethods,
+		FullyCoveredMethods: totals.FullyCoveredMethods,
+		TotalMethods:        totals.TotalMethods,
+		MethodCoverage:      formatQuota(totals.MethodQuota),
+		FullMethodCoverage:  formatQuota(totals.FullMethodQuota),
+	}
+	if b.appVersion != "" {
+		report.Summary.GeneratedBy = "ReportGenerator " + b.appVersion
+	}
+	if totals.BranchesCovered != nil && totals.BranchesValid != nil {
+		branchCoverage := formatQuota(totals.BranchQuota)
+		report.Summary.CoveredBranches = totals.BranchesCovered
+		report.Summary.TotalBranches = totals.BranchesValid
+		report.Summary.BranchCoverage = &branchCoverage
+	}
+	return report
+}
+
+func buildAssembly(assembly model.Assembly, aggregates reporter.AssemblyAggregates) assemblyElement {
+	classCount := len(assembly.Classes)
+	element := assemblyElement{coverageAttributes: newCoverageAttributes(assembly.Name, aggregates.Totals)}
+	element.Classes = make([]classElement, 0, classCount)
+	element.coverageAttributes.Classes = &classCount
+	for i, class := range assembly.Classes {
+		element.Classes = append(element.Classes, classElement{
+			coverageAttributes: newCoverageAttributes(class.DisplayName, aggregates.ClassTotals[i]),
+		})
+	}
+	return element
+}
+
+func newCoverageAttributes(name string, totals reporter.Totals) coverageAttributes {
+	attrs := coverageAttributes{
+		Name:                name,
+		Coverage:            formatQuota(totals.LineQuota),
+		CoveredLines:        totals.LinesCovered,
+		CoverableLines:      totals.LinesValid,
+		TotalLines:          totals.TotalLines,
+		CoveredMethods:      totals.CoveredMethods,
+		FullyCoveredMethods: totals.FullyCoveredMethods,
+		TotalMethods:        totals.TotalMethods,
+		MethodCoverage:      formatQuota(totals.MethodQuota),
+		FullMethodCoverage:  formatQuota(totals.FullMethodQuota),
+	}
+	if totals.BranchesCovered != nil && totals.BranchesValid != nil {
+		attrs.BranchCoverage = formatQuota(totals.BranchQuota)
+		attrs.CoveredBranches = *totals.BranchesCovered
+		attrs.TotalBranches = *totals.BranchesValid
+	}
+	return attrs
+}
+
+// formatQuota formats a coverage quota like the C# version, which writes the decimal with
+// the invariant culture and without trailing zeros, e.g. "66.6" or "100". An empty string
+// means no coverable elements.
+func formatQuota(percentage float64) string {
+	if math.IsNaN(percentage) {
+		return ""
+	}
+	return strconv.FormatFloat(percentage, 'f', -1, 64)
+}
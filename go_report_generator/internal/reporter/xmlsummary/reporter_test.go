@@ -0,0 +1,151 @@
+package xmlsummary
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// summaryReport has one assembly with branch coverage and one without, as produced by
+// merging a Cobertura and a Go cover report.
+func summaryReport() *model.SummaryResult {
+	branchesCovered, branchesValid := 3, 4
+	calc := model.Class{
+		Name:                "Demo.Calc",
+		DisplayName:         "Demo.Calc",
+		Files:               []model.CodeFile{{Path: "src/Calc.cs"}},
+		LinesCovered:        2,
+		LinesValid:          3,
+		BranchesCovered:     &branchesCovered,
+		BranchesValid:       &branchesValid,
+		TotalLines:          16,
+		CoveredMethods:      2,
+		FullyCoveredMethods: 1,
+		TotalMethods:        2,
+	}
+	parser := model.Class{
+		Name:                "example.com/tool/parser",
+		DisplayName:         "example.com/tool/parser",
+		Files:               []model.CodeFile{{Path: "parser/parser.go"}, {Path: "parser/lexer.go"}},
+		LinesCovered:        0,
+		LinesValid:          7,
+		TotalLines:          40,
+		CoveredMethods:      0,
+		FullyCoveredMethods: 0,
+		TotalMethods:        3,
+	}
+	empty := model.Class{
+		Name:        "example.com/tool/doc",
+		DisplayName: "example.com/tool/doc",
+		Files:       []model.CodeFile{{Path: "doc/doc.go"}},
+		TotalLines:  3,
+	}
+	return &model.SummaryResult{
+		ParserName:      "MultiReportParser (1x Cobertura, 1x GoCover)",
+		LinesCovered:    2,
+		LinesValid:      10,
+		BranchesCovered: &branchesCovered,
+		BranchesValid:   &branchesValid,
+		TotalLines:      59,
+		Assemblies: []model.Assembly{
+			{
+				Name:            "Demo",
+				Classes:         []model.Class{calc},
+				LinesCovered:    2,
+				LinesValid:      3,
+				BranchesCovered: &branchesCovered,
+				BranchesValid:   &branchesValid,
+				TotalLines:      16,
+			},
+			{
+				Name:         "example.com/tool",
+				Classes:      []model.Class{parser, empty},
+				LinesCovered: 0,
+				LinesValid:   7,
+				TotalLines:   43,
+			},
+		},
+	}
+}
+
+// TestCreateReport_Golden compares Summary.xml with testdata/Summary.xml.golden. The
+// golden file is written by hand in the element and attribute layout of the C#
+// XmlSummary report, with the values derived from summaryReport, not from the output of
+// the builder, so it has no -update flag:
+//
+//   - Summary: 2 assemblies, 3 classes and 4 files (Calc.cs, parser.go, lexer.go and
+//     doc.go); 2 of 10 lines covered, so 8 uncovered and 20% line coverage; 3 of 4
+//     branches (75%); 2 covered and 1 fully covered of 2+3 methods (40% and 20%).
+//   - Demo and Demo.Calc: 2 of 3 lines, 66.6% truncated to one decimal place; 2 covered
+//     and 1 fully covered of 2 methods (100% and 50%).
+//   - example.com/tool: 0 of 7 lines and 0 of 3 methods, no branches, so the branch
+//     coverage is empty; its 43 total lines are those of parser (40) and doc (3).
+//   - example.com/tool/doc has neither coverable lines nor methods, so its quotas are empty.
+func TestCreateReport_Golden(t *testing.T) {
+	outputDir := t.TempDir()
+	fixedTime := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	builder := NewXmlSummaryReportBuilder(outputDir, slog.New(slog.NewTextHandler(io.Discard, nil)),
+		WithClock(func() time.Time { return fixedTime }),
+		WithGenerator("1.0.0-test", "ReportGenerator -reports=coverage.xml -reporttypes=XmlSummary"),
+	)
+
+	if err := builder.CreateReport(summaryReport()); err != nil {
+		t.Fatalf("CreateReport returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(outputDir, "Summary.xml"))
+	if err != nil {
+		t.Fatalf("failed to read generated report: %v", err)
+	}
+	goldenPath := filepath.Join("testdata", "Summary.xml.golden")
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Summary.xml differs from %s\n--- got ---\n%s", goldenPath, got)
+	}
+}
+
+func TestCreateReport_DecimalPlaces(t *testing.T) {
+	testCases := []struct {
+		name          string
+		decimalPlaces int
+		want          string
+	}{
+		{name: "Default", decimalPlaces: 1, want: "<Linecoverage>66.6</Linecoverage>"},
+		{name: "TwoPlaces", decimalPlaces: 2, want: "<Linecoverage>66.66</Linecoverage>"},
+		{name: "None", decimalPlaces: 0, want: "<Linecoverage>66</Linecoverage>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			builder := NewXmlSummaryReportBuilder(outputDir, slog.New(slog.NewTextHandler(io.Discard, nil)),
+				WithDecimalPlaces(tc.decimalPlaces),
+			)
+			summary := &model.SummaryResult{LinesCovered: 2, LinesValid: 3}
+
+			if err := builder.CreateReport(summary); err != nil {
+				t.Fatalf("CreateReport returned error: %v", err)
+			}
+
+			got, err := os.ReadFile(filepath.Join(outputDir, "Summary.xml"))
+			if err != nil {
+				t.Fatalf("failed to read generated report: %v", err)
+			}
+			if !bytes.Contains(got, []byte(tc.want)) {
+				t.Errorf("expected %s in report, got:\n%s", tc.want, got)
+			}
+			if bytes.Contains(got, []byte("<Branchcoverage>")) {
+				t.Errorf("expected no branch elements without branch data, got:\n%s", got)
+			}
+		})
+	}
+}
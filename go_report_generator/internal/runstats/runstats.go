@@ -0,0 +1,190 @@
+// Package runstats collects statistics about a report generation run: how many report
+// files were parsed, what the merged coverage data contains and how long each phase took.
+package runstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	v1 "github.com/IgorBayerl/ReportGenerator/go_report_generator/api/v1"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// Phase is the duration of one step of the run, e.g. "parse" or "report:Html".
+type Phase struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Stats collects the statistics of one run. All methods may be called on a nil *Stats,
+// so code paths without statistics (e.g. parsing the baseline reports) pass nil.
+type Stats struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	start time.Time
+
+	ReportFiles    int // Report files found by the -report patterns
+	ParsedReports  int // Report files parsed successfully
+	SkippedReports int // Report files skipped as duplicates
+	FailedReports  int // Report files without a parser or with parse errors
+	Assemblies     int
+	Classes        int
+	Files          int // Distinct code files
+	Phases         []Phase
+}
+
+// Option configures Stats.
+type Option func(*Stats)
+
+// WithClock replaces time.Now, e.g. to get fixed durations in tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Stats) {
+		s.now = now
+	}
+}
+
+// New starts collecting the statistics of a run.
+func New(opts ...Option) *Stats {
+	s := &Stats{now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.start = s.now()
+	return s
+}
+
+// Start begins a phase and returns the function that ends it:
+//
+//	defer stats.Start("merge")()
+//
+// A phase started several times is recorded with the sum of its durations.
+func (s *Stats) Start(name string) (stop func()) {
+	if s == nil {
+		return func() {}
+	}
+	started := s.now()
+	return func() {
+		elapsed := s.now().Sub(started)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i := range s.Phases {
+			if s.Phases[i].Name == name {
+				s.Phases[i].Duration += elapsed
+				return
+			}
+		}
+		s.Phases = append(s.Phases, Phase{Name: name, Duration: elapsed})
+	}
+}
+
+// AddReportFiles counts report files found by the -report patterns.
+func (s *Stats) AddReportFiles(count int) {
+	s.update(func() { s.ReportFiles += count })
+}
+
+// ReportParsed counts a report file that was parsed successfully.
+func (s *Stats) ReportParsed() {
+	s.update(func() { s.ParsedReports++ })
+}
+
+// ReportSkipped counts a report file that was skipped as a duplicate.
+func (s *Stats) ReportSkipped() {
+	s.update(func() { s.SkippedReports++ })
+}
+
+// ReportFailed counts a report file that had no parser or could not be parsed.
+func (s *Stats) ReportFailed() {
+	s.update(func() { s.FailedReports++ })
+}
+
+// RecordSummary records the number of assemblies, classes and distinct files of the
+// merged coverage data. keyOf returns the key by which file paths are compared, see
+// utils.PathKeyFunc.
+func (s *Stats) RecordSummary(summary *model.SummaryResult, keyOf func(string) string) {
+	if summary == nil {
+		return
+	}
+	classes := 0
+	files := make(map[string]struct{})
+	for _, assembly := range summary.Assemblies {
+		classes += len(assembly.Classes)
+		for _, class := range assembly.Classes {
+			for _, file := range class.Files {
+				files[keyOf(file.Path)] = struct{}{}
+			}
+		}
+	}
+	s.update(func() {
+		s.Assemblies = len(summary.Assemblies)
+		s.Classes = classes
+		s.Files = len(files)
+	})
+}
+
+func (s *Stats) update(fn func()) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+// LogAttrs returns the statistics as slog key-value pairs, with durations in milliseconds.
+func (s *Stats) LogAttrs() []any {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attrs := []any{
+		"report_files", s.ReportFiles,
+		"parsed_reports", s.ParsedReports,
+		"skipped_reports", s.SkippedReports,
+		"failed_reports", s.FailedReports,
+		"assemblies", s.Assemblies,
+		"classes", s.Classes,
+		"files", s.Files,
+	}
+	for _, phase := range s.Phases {
+		attrs = append(attrs, phase.Name+"_ms", phase.Duration.Milliseconds())
+	}
+	return append(attrs, "duration_ms", s.now().Sub(s.start).Milliseconds())
+}
+
+// WriteJSONLine writes the statistics as a single line of JSON (v1.RunStatistics), for CI
+// scripts:
+//
+//	{"schemaVersion":"1.0","reportFiles":2,"parsedReports":2,...,"durationMs":41,"phases":[{"name":"glob","durationMs":0},...]}
+func (s *Stats) WriteJSONLine(w io.Writer) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	line := v1.RunStatistics{
+		SchemaVersion:  v1.SchemaVersion,
+		ReportFiles:    s.ReportFiles,
+		ParsedReports:  s.ParsedReports,
+		SkippedReports: s.SkippedReports,
+		FailedReports:  s.FailedReports,
+		Assemblies:     s.Assemblies,
+		Classes:        s.Classes,
+		Files:          s.Files,
+		DurationMs:     s.now().Sub(s.start).Milliseconds(),
+		Phases:         make([]v1.Phase, 0, len(s.Phases)),
+	}
+	for _, phase := range s.Phases {
+		line.Phases = append(line.Phases, v1.Phase{Name: phase.Name, DurationMs: phase.Duration.Milliseconds()})
+	}
+	s.mu.Unlock()
+
+	content, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to marshal run statistics: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", content)
+	return err
+}
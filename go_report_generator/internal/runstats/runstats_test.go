@@ -0,0 +1,119 @@
+package runstats
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/utils"
+)
+
+// stepClock returns a clock that advances by step on every call.
+func stepClock(step time.Duration) func() time.Time {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestStats_NilIsSafe(t *testing.T) {
+	var s *Stats
+
+	s.Start("parse")()
+	s.AddReportFiles(2)
+	s.ReportParsed()
+	s.ReportSkipped()
+	s.ReportFailed()
+	s.RecordSummary(&model.SummaryResult{}, utils.PathKey)
+
+	if attrs := s.LogAttrs(); attrs != nil {
+		t.Errorf("expected no log attributes, got %v", attrs)
+	}
+	var buf bytes.Buffer
+	if err := s.WriteJSONLine(&buf); err != nil || buf.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %q (err: %v)", buf.String(), err)
+	}
+}
+
+func TestStats_PhasesAreSummedByName(t *testing.T) {
+	s := New(WithClock(stepClock(10 * time.Millisecond)))
+
+	s.Start("parse")()
+	s.Start("merge")()
+	s.Start("parse")()
+
+	if len(s.Phases) != 2 {
+		t.Fatalf("expected 2 phases, got %v", s.Phases)
+	}
+	if s.Phases[0].Name != "parse" || s.Phases[0].Duration != 20*time.Millisecond {
+		t.Errorf("unexpected parse phase: %+v", s.Phases[0])
+	}
+	if s.Phases[1].Name != "merge" || s.Phases[1].Duration != 10*time.Millisecond {
+		t.Errorf("unexpected merge phase: %+v", s.Phases[1])
+	}
+}
+
+func TestStats_RecordSummaryCountsDistinctFiles(t *testing.T) {
+	s := New()
+	summary := &model.SummaryResult{Assemblies: []model.Assembly{
+		{Name: "A", Classes: []model.Class{
+			{Name: "A.One", Files: []model.CodeFile{{Path: "/src/one.cs"}}},
+			{Name: "A.OneNested", Files: []model.CodeFile{{Path: "/src/one.cs"}}},
+		}},
+		{Name: "B", Classes: []model.Class{
+			{Name: "B.Two", Files: []model.CodeFile{{Path: "/src/two.cs"}, {Path: "/src/two.partial.cs"}}},
+		}},
+	}}
+
+	s.RecordSummary(summary, utils.PathKey)
+
+	if s.Assemblies != 2 || s.Classes != 3 || s.Files != 3 {
+		t.Errorf("expected 2 assemblies, 3 classes and 3 files, got %d, %d and %d", s.Assemblies, s.Classes, s.Files)
+	}
+}
+
+func TestStats_WriteJSONLine(t *testing.T) {
+	s := New(WithClock(stepClock(5 * time.Millisecond)))
+	s.AddReportFiles(3)
+	s.ReportParsed()
+	s.ReportParsed()
+	s.ReportFailed()
+	s.Start("glob")()
+
+	var buf bytes.Buffer
+	if err := s.WriteJSONLine(&buf); err != nil {
+		t.Fatalf("WriteJSONLine returned error: %v", err)
+	}
+
+	line := buf.String()
+	if bytes.Count(buf.Bytes(), []byte("\n")) != 1 || line[len(line)-1] != '\n' {
+		t.Fatalf("expected a single line, got %q", line)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON %q: %v", line, err)
+	}
+	expected := map[string]any{
+		"schemaVersion":  "1.0",
+		"reportFiles":    3.0,
+		"parsedReports":  2.0,
+		"skippedReports": 0.0,
+		"failedReports":  1.0,
+		"durationMs":     15.0,
+	}
+	for key, want := range expected {
+		if decoded[key] != want {
+			t.Errorf("expected %s to be %v, got %v", key, want, decoded[key])
+		}
+	}
+	phases, _ := decoded["phases"].([]any)
+	if len(phases) != 1 {
+		t.Fatalf("expected one phase, got %v", decoded["phases"])
+	}
+	if phase := phases[0].(map[string]any); phase["name"] != "glob" || phase["durationMs"] != 5.0 {
+		t.Errorf("unexpected phase %v", phase)
+	}
+}
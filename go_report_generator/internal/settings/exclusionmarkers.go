@@ -0,0 +1,79 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultExclusionMarkersLanguage is the key of the markers used for files of languages
+// without markers of their own.
+const DefaultExclusionMarkersLanguage = "Default"
+
+// ExclusionMarkers are the comments that exclude source lines from the coverage when
+// ExcludeCoverageByComments is set. A line contains a marker if the marker occurs on it
+// as a whole word, e.g. "// coverage:ignore" but not "// coverage:ignored". Empty markers
+// are not looked for.
+type ExclusionMarkers struct {
+	Line  string // Excludes the line it is on
+	Start string // Excludes the lines from this marker up to and including the matching End
+	End   string
+	Next  string // Excludes the next line that is not blank
+}
+
+// DefaultExclusionMarkers returns the markers per language, keyed by the name of its
+// language processor (e.g. "Go", "C#").
+func DefaultExclusionMarkers() map[string]ExclusionMarkers {
+	generic := ExclusionMarkers{Line: "coverage:ignore", Start: "coverage:ignore-start", End: "coverage:ignore-end", Next: "coverage:ignore-next"}
+	return map[string]ExclusionMarkers{
+		"Go": generic,
+		"C#": generic,
+		// Other languages, e.g. JavaScript, mostly use the comments of istanbul and c8.
+		DefaultExclusionMarkersLanguage: {Line: "coverage:ignore", Start: "c8 ignore start", End: "c8 ignore stop", Next: "istanbul ignore next"},
+	}
+}
+
+// ExclusionMarkersFor returns the markers of the language, matched case-insensitively,
+// or those of DefaultExclusionMarkersLanguage.
+func ExclusionMarkersFor(markers map[string]ExclusionMarkers, language string) ExclusionMarkers {
+	if m, ok := markers[language]; ok {
+		return m
+	}
+	for name, m := range markers {
+		if strings.EqualFold(name, language) {
+			return m
+		}
+	}
+	return markers[DefaultExclusionMarkersLanguage]
+}
+
+// ParseExclusionMarkers parses semicolon-separated markers of the form
+// "Language=line|start|end|next", e.g. "Go=nocover|nocover-start|nocover-end|".
+// Empty markers are not looked for; start and end markers must be given together.
+func ParseExclusionMarkers(s string) (map[string]ExclusionMarkers, error) {
+	markers := make(map[string]ExclusionMarkers)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		language, values, ok := strings.Cut(entry, "=")
+		language = strings.TrimSpace(language)
+		if !ok || language == "" {
+			return nil, fmt.Errorf("invalid exclusion markers '%s' (expected Language=line|start|end|next)", entry)
+		}
+		parts := strings.Split(values, "|")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("invalid exclusion markers for language '%s': expected 4 markers separated by '|' (line|start|end|next), got %d", language, len(parts))
+		}
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		m := ExclusionMarkers{Line: parts[0], Start: parts[1], End: parts[2], Next: parts[3]}
+		if (m.Start == "") != (m.End == "") {
+			return nil, fmt.Errorf("invalid exclusion markers for language '%s': the start and end markers must be given together", language)
+		}
+		markers[language] = m
+	}
+	return markers, nil
+}
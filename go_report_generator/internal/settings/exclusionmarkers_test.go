@@ -0,0 +1,38 @@
+package settings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseExclusionMarkers(t *testing.T) {
+	got, err := ParseExclusionMarkers("Go=nocover|nocover-start|nocover-end| ; JavaScript = |c8 ignore start|c8 ignore stop|istanbul ignore next;")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]ExclusionMarkers{
+		"Go":         {Line: "nocover", Start: "nocover-start", End: "nocover-end"},
+		"JavaScript": {Start: "c8 ignore start", End: "c8 ignore stop", Next: "istanbul ignore next"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseExclusionMarkers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseExclusionMarkers_Invalid(t *testing.T) {
+	for _, input := range []string{"Go", "=a|b|c|d", "Go=a|b|c", "Go=a|b||d"} {
+		if _, err := ParseExclusionMarkers(input); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+func TestExclusionMarkersFor(t *testing.T) {
+	markers := DefaultExclusionMarkers()
+	if got := ExclusionMarkersFor(markers, "go"); got != markers["Go"] {
+		t.Errorf("ExclusionMarkersFor(go) = %+v, want the Go markers", got)
+	}
+	if got := ExclusionMarkersFor(markers, "JavaScript"); got != markers[DefaultExclusionMarkersLanguage] {
+		t.Errorf("ExclusionMarkersFor(JavaScript) = %+v, want the default markers", got)
+	}
+}
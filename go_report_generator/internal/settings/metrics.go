@@ -0,0 +1,59 @@
+package settings
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// ParseMetricDefinitions parses semicolon-separated metric definitions of the form
+// "Name=[url][,order]", e.g. "Mutation score=https://stryker-mutator.io/,5;CrapScore=,1",
+// and returns a copy of registry with them applied. Known metrics are matched
+// case-insensitively and only get the given parts changed; other names add a custom metric,
+// which is not aggregated into the class metrics.
+func ParseMetricDefinitions(s string, registry model.MetricRegistry) (model.MetricRegistry, error) {
+	result := make(model.MetricRegistry, len(registry))
+	for name, definition := range registry {
+		result[name] = definition
+	}
+
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, parts, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid metric definition '%s' (expected Name=[url][,order])", entry)
+		}
+		definition, known := result.Find(name)
+		if !known {
+			definition = model.MetricDefinition{Name: name, Aggregation: model.AggregateNone}
+		}
+
+		for _, part := range strings.Split(parts, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			if order, err := strconv.Atoi(part); err == nil {
+				if order <= 0 {
+					return nil, fmt.Errorf("invalid order %d of metric '%s' (must be greater than 0)", order, name)
+				}
+				definition.Order = order
+				continue
+			}
+			if u, err := url.Parse(part); err != nil || u.Scheme == "" || u.Host == "" {
+				return nil, fmt.Errorf("invalid explanation URL '%s' of metric '%s'", part, name)
+			}
+			definition.ExplanationURL = part
+		}
+		result[definition.Name] = definition
+	}
+	return result, nil
+}
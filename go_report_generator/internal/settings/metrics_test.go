@@ -0,0 +1,39 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+func TestParseMetricDefinitions(t *testing.T) {
+	defaults := model.DefaultMetricRegistry()
+	got, err := ParseMetricDefinitions("Mutation score=https://stryker-mutator.io/,5; crapscore=,1 ;", defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mutation := got.Lookup("Mutation score")
+	want := model.MetricDefinition{Name: "Mutation score", ExplanationURL: "https://stryker-mutator.io/", Order: 5, Aggregation: model.AggregateNone}
+	if mutation != want {
+		t.Errorf("Mutation score = %+v, want %+v", mutation, want)
+	}
+	crap := got.Lookup("CrapScore")
+	if crap.Order != 1 || crap.ExplanationURL != defaults["CrapScore"].ExplanationURL || crap.Aggregation != model.AggregateMax {
+		t.Errorf("CrapScore = %+v, want the default definition with order 1", crap)
+	}
+	if _, ok := got.Find("crapscore"); !ok || len(got) != len(defaults)+1 {
+		t.Errorf("expected the known metric to be changed, not added: %v", got)
+	}
+	if defaults["CrapScore"].Order == 1 {
+		t.Error("the given registry was modified")
+	}
+}
+
+func TestParseMetricDefinitions_Invalid(t *testing.T) {
+	for _, input := range []string{"Mutation score", "=https://example.com", "Mutation score=example.com", "Mutation score=,0"} {
+		if _, err := ParseMetricDefinitions(input, model.DefaultMetricRegistry()); err == nil {
+			t.Errorf("ParseMetricDefinitions(%q) expected an error", input)
+		}
+	}
+}
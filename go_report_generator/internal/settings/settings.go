@@ -1,5 +1,7 @@
 package settings
 
+import "github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+
 // Settings corresponds to C#'s ReportGenerator.Core.Settings.
 // It holds various global settings that control the behavior of the report generation.
 type Settings struct {
@@ -23,11 +25,39 @@ type Settings struct {
 	// Default: false
 	DisableRiskHotspots bool
 
-	// ExcludeTestProjects, if true, attempts to exclude assemblies/classes that look like test projects (primarily for Clover reports).
+	// ExcludeTestProjects, if true, excludes assemblies and files that look like tests with the default filters
+	// of each parser (reportconfig.TestExcludesForParser), in addition to the filters of the user.
 	// Default: false
 	ExcludeTestProjects bool
 
-	// CreateSubdirectoryForAllReportTypes, if true, creates a subdirectory for each report type in the target directory.
+	// ExcludeGeneratedCode, if true, excludes files that look generated (e.g. *.pb.go, *.Designer.cs, *.generated.*,
+	// or Go files with a "// Code generated ... DO NOT EDIT." header) from the reports.
+	// Default: true
+	ExcludeGeneratedCode bool
+
+	// SourceRootHints are extra roots the source files of Cobertura reports are looked up in, after the
+	// <source> roots of the report and before the source directories of the configuration.
+	// Default: nil
+	SourceRootHints []string
+
+	// MergeMode controls how the hits of a line and the visits of a branch reported more than once
+	// are combined: "sum" for reports of separate runs, such as test shards, or "max" for several
+	// exports of the same run. The covered and coverable line counts are not affected.
+	// Default: "sum"
+	MergeMode string
+
+	// FailOnDuplicateReports, if true, fails the run when a coverage report is passed twice (same content
+	// or identical coverage data) instead of skipping the duplicate with a warning.
+	// Default: false
+	FailOnDuplicateReports bool
+
+	// FailOnParseError, if true, fails the run when a coverage report cannot be parsed instead of
+	// skipping it and listing it in the reports.
+	// Default: false
+	FailOnParseError bool
+
+	// CreateSubdirectoryForAllReportTypes, if true, creates a subdirectory for each report type in the target directory
+	// (e.g. Html is written to <target>/html and TextSummary to <target>/text).
 	// Default: false
 	CreateSubdirectoryForAllReportTypes bool
 
@@ -36,6 +66,10 @@ type Settings struct {
 	// Default: ""
 	CustomHeadersForRemoteFiles string
 
+	// TextSummaryFileName is the name of the file written by the TextSummary report.
+	// Default: "Summary.txt"
+	TextSummaryFileName string
+
 	// DefaultAssemblyName is used for reports (like GCov, LCov) that don't inherently contain assembly names.
 	// Default: "Default"
 	DefaultAssemblyName string
@@ -48,15 +82,228 @@ type Settings struct {
 	// Default: 0
 	MaximumDecimalPlacesForPercentageDisplay int
 
+	// CoverageQuotaRoundingMode controls how coverage quotas are reduced to MaximumDecimalPlacesForCoverageQuotas
+	// and MaximumDecimalPlacesForPercentageDisplay: "truncate" (C#-compatible), "round" or "floor".
+	// Default: "truncate"
+	CoverageQuotaRoundingMode string
+
+	// MetricThresholds maps method metric names (e.g. "CrapScore", "Cyclomatic complexity") to the limits
+	// above which their values are flagged as warning or error in the reports. Risk hotspots use the same limits.
+	// Default: CrapScore > 30 warning, > 80 error; Cyclomatic complexity > 15 warning, > 30 error
+	MetricThresholds map[string]model.MetricThreshold
+
+	// Metrics defines the method metrics: their explanation links, the order of their columns and how
+	// they are formatted. Metrics that are not defined are shown without link, after the defined ones.
+	// Default: model.DefaultMetricRegistry()
+	Metrics model.MetricRegistry
+
 	// HistoryFileNamePrefix is an optional prefix for history files.
 	// Default: ""
 	HistoryFileNamePrefix string
 
-	// RawMode, if true, attempts to report on compiler-generated/nested classes separately rather than merging them into parent classes.
+	// HistoryRetentionDays, if greater than 0, ignores history files older than this number of days.
+	// The age limit is applied before MaximumNumberOfHistoricCoverageFiles.
+	// Default: 0 (no limit)
+	HistoryRetentionDays int
+
+	// PruneHistory, if true, deletes the history files that are ignored because of their age or count.
+	// Default: false
+	PruneHistory bool
+
+	// CoverageAgeThreshold is the line coverage in percent below which the reports note since which run of
+	// the history a class has stayed below it. 0 disables the note.
+	// Default: 80
+	CoverageAgeThreshold float64
+
+	// MaximumHistoricCoveragesPerClass is the number of the newest history entries of a class embedded
+	// into the Html report. Older entries are summarized as the range of their coverage quotas.
+	// Default: 30 (0: no limit)
+	MaximumHistoricCoveragesPerClass int
+
+	// SummarySort is the order of the classes of each assembly embedded into the summary page of the Html
+	// report and the initial sorting of its class table: name, linecoverage, branchcoverage or uncoveredlines,
+	// optionally followed by ":asc" or ":desc".
+	// Default: "" (the order of the report, the table sorted by name)
+	SummarySort string
+
+	// SummaryTopN is the number of classes of each assembly embedded into the summary page of the Html
+	// report, in the order of SummarySort. The classes left out are listed on the page of the assembly;
+	// the totals of the assemblies still include them.
+	// Default: 0 (no limit)
+	SummaryTopN int
+
+	// StoreSources, if true, makes the parsers keep the whole source of the covered files in the
+	// Content of their lines, so reports can be generated without reading the source files again.
+	// Default: false
+	StoreSources bool
+
+	// SourceFileEncoding is the name of the encoding (e.g. "windows-1252") of source files that have
+	// no byte order mark and are not valid UTF-8. Files with a BOM are decoded as UTF-8 or UTF-16.
+	// Default: "" (the bytes are kept as they are)
+	SourceFileEncoding string
+
+	// CoverageConverter is the path of the tool that converts Visual Studio .coverage files to
+	// Cobertura (dotnet-coverage or Microsoft.CodeCoverage.Console). If empty, they are looked up in PATH.
+	// Default: ""
+	CoverageConverter string
+
+	// CoverageConverterTimeoutInSeconds is the time a conversion of a .coverage file may take before
+	// the converter is stopped.
+	// Default: 600 (0: no limit)
+	CoverageConverterTimeoutInSeconds int
+
+	// RawMode, if true, reports compiler-generated/nested classes separately rather than merging them into parent classes,
+	// and leaves class names exactly as they appear in the coverage report.
 	// This is a PRO feature in C#.
 	// Default: false
 	RawMode bool
 
+	// KeepNestedClasses, if true, reports nested types as separate classes (e.g. "Outer.Inner") instead of
+	// merging them into their outermost class. Compiler-generated nested types are still merged into the
+	// type that contains them. RawMode takes precedence.
+	// Default: false
+	KeepNestedClasses bool
+
+	// LanguageProcessor, if set, forces the named language processor (e.g. "csharp", "go", "default") for all files
+	// instead of detecting it from the file extension.
+	// Default: "" (detect by file extension)
+	LanguageProcessor string
+
+	// AssemblyGroupingLevel controls how classes are grouped in the reports. 0 groups by assembly only,
+	// N > 0 splits each assembly into "<Assembly> - <Namespace>" groups using up to N namespace levels.
+	// Default: 0
+	AssemblyGroupingLevel int
+
+	// UncoveredLinesClassLimit is the number of classes (those with the most uncovered lines) whose
+	// uncovered line ranges are listed in the TextSummary and Html reports. 0 disables the listing.
+	// Default: 0
+	UncoveredLinesClassLimit int
+
+	// GoApproximateBranchCoverage, if true, derives pseudo branch points for the if, switch and select
+	// statements of Go files from the blocks of the cover profile, which has no branch data of its own.
+	// Default: false
+	GoApproximateBranchCoverage bool
+
+	// FullMethodCoverageMinimumLineRate is the share of the coverable lines of a method (greater than 0, at
+	// most 1) that must be covered for the method to count as fully covered. Methods without coverable
+	// lines are never fully covered.
+	// Default: 1
+	FullMethodCoverageMinimumLineRate float64
+
+	// FullMethodCoverageRequiresBranches, if true, counts a method with branches as fully covered only if all
+	// of its branches are covered, too. Go functions have no branch data of their own and are not affected.
+	// Default: false
+	FullMethodCoverageRequiresBranches bool
+
+	// ExcludeCoverageByComments, if true, marks the lines excluded by the CoverageExclusionMarkers in the
+	// source files as not coverable and recomputes the class, assembly and overall totals. The coverage of
+	// methods is kept as reported.
+	// Default: false
+	ExcludeCoverageByComments bool
+
+	// CoverageExclusionMarkers maps the names of language processors (e.g. "Go", "C#") to the comments that
+	// exclude lines with ExcludeCoverageByComments. Files of other languages use the "Default" markers.
+	// Default: DefaultExclusionMarkers()
+	CoverageExclusionMarkers map[string]ExclusionMarkers
+
+	// DeclaredTotalsTolerance is the relative difference allowed between the totals a report declares
+	// (e.g. lines-covered of the Cobertura root element) and the totals computed from its line data
+	// before a warning is logged. Declared rates (0 to 1) may differ by the tolerance itself.
+	// A negative value disables the check.
+	// Default: 0.01
+	DeclaredTotalsTolerance float64
+
+	// RecomputeAggregates, if true, recalculates the assembly and overall totals over the classes
+	// that remain after the class coverage filter. Otherwise the totals keep describing all classes.
+	// Default: false
+	RecomputeAggregates bool
+
+	// Language selects the built-in translation of the Html report strings, e.g. "de" or "pt-BR". Locales
+	// like "de_DE.UTF-8" are accepted; unsupported values fall back to English.
+	// Default: "" (English)
+	Language string
+
+	// TranslationsFile is a JSON file whose keys override individual Html report strings of the selected language.
+	// Default: "" (none)
+	TranslationsFile string
+
+	// SyntaxHighlight, if true, colors the keywords, strings, comments and numbers of the source code on the
+	// class pages of the Html report. Files of languages without a lexer are rendered plain.
+	// Default: false
+	SyntaxHighlight bool
+
+	// MaximumLineLength is the number of characters of a source line shown on the class pages of the Html
+	// report; longer lines are truncated. Files whose average line length is too long to be readable, such
+	// as minified JavaScript, are shown without their code, see MinifiedAverageLineLength. 0 disables both.
+	// Default: 2000
+	MaximumLineLength int
+
+	// MinifiedAverageLineLength is the average number of characters per line above which a file counts as
+	// minified code and is shown without its code on the class pages of the Html report. 0 shows the code
+	// of all files.
+	// Default: 500
+	MinifiedAverageLineLength int
+
+	// Incremental, if true, makes the Html report keep a manifest of the content hashes of its class pages
+	// in the output directory and skip rewriting the pages whose content did not change since the last run.
+	// Pages of classes that are no longer part of the report are deleted.
+	// Default: false
+	Incremental bool
+
+	// ResolveSymlinks, if true, makes the analyzer compare source files by the targets of their paths with all
+	// symbolic links resolved, so that a file reached through a link and through its target is merged and
+	// counted once. It accesses the file system for every path.
+	// Default: false
+	ResolveSymlinks bool
+
+	// FileRetries is the number of retries of a report file that is locked by another process or not
+	// accessible, e.g. still held by the test host on Windows, see utils.FileRetryPolicy.
+	// Default: 5
+	FileRetries int
+
+	// FileRetryDelayInMilliseconds is the wait before the first retry of a report file. It doubles with every
+	// further retry.
+	// Default: 100
+	FileRetryDelayInMilliseconds int
+
+	// FileRetryTimeoutInSeconds limits the total wait for a report file, 0 for no limit besides FileRetries.
+	// Default: 10
+	FileRetryTimeoutInSeconds int
+
+	// PathCase controls whether file paths that differ only in case are the same file: "auto" (case-insensitive
+	// on Windows), "sensitive" or "insensitive", see utils.ParsePathCaseMode.
+	// Default: "auto"
+	PathCase string
+
+	// MergeVendoredFiles, if true, treats a file in a vendor directory as a copy of the file of the assembly
+	// whose path ends with the path after "vendor/", and merges their coverage.
+	// Default: true
+	MergeVendoredFiles bool
+
+	// ExcludeExternalFiles, if true, makes the parsers leave out the files outside the source directories
+	// (see model.CodeFile.InSourceDirs), so that they count towards no total and appear in no report.
+	// Default: false
+	ExcludeExternalFiles bool
+
+	// StrictExternalFiles, if true, classifies the files whose source could not be found as external
+	// instead of as part of the source directories.
+	// Default: false
+	StrictExternalFiles bool
+
+	// DumpModel writes the coverage model as JSON files into the output directory for debugging: "merged"
+	// writes the merged SummaryResult, "perfile" also the ParserResult of each report file before merging.
+	// Default: "" (no dump)
+	DumpModel string
+
+	// DumpModelIncludeSource, if true, keeps the source code of the lines in the files of DumpModel.
+	// Default: false
+	DumpModelIncludeSource bool
+
+	// AutoDiscoverSourceFiles, if true, indexes the source directories (or the working directory when none are given)
+	// and resolves report paths that cannot be found directly by their longest matching path suffix.
+	// Default: false
+	AutoDiscoverSourceFiles bool
+
 	// VerbosityLevelFromConfig is a placeholder if you decide to load verbosity from settings too,
 	// though it's often handled by ReportConfiguration directly from command line.
 	// VerbosityLevelFromConfig string
@@ -72,12 +319,58 @@ func NewSettings() *Settings {
 		CachingDurationOfRemoteFilesInMinutes:    7 * 24 * 60, // 10080 minutes = 7 days
 		DisableRiskHotspots:                      false,
 		ExcludeTestProjects:                      false,
+		ExcludeGeneratedCode:                     true,
+		StoreSources:                             false,
+		SourceFileEncoding:                       "",
+		CoverageConverter:                        "",
+		CoverageConverterTimeoutInSeconds:        600,
+		MergeMode:                                "sum",
+		FailOnDuplicateReports:                   false,
+		FailOnParseError:                         false,
 		CreateSubdirectoryForAllReportTypes:      false,
 		CustomHeadersForRemoteFiles:              "",
+		TextSummaryFileName:                      "Summary.txt",
 		DefaultAssemblyName:                      "Default",
 		MaximumDecimalPlacesForCoverageQuotas:    1,
 		MaximumDecimalPlacesForPercentageDisplay: 0,
+		CoverageQuotaRoundingMode:                "truncate",
+		MetricThresholds:                         DefaultMetricThresholds(),
+		Metrics:                                  model.DefaultMetricRegistry(),
 		HistoryFileNamePrefix:                    "",
+		HistoryRetentionDays:                     0,
+		PruneHistory:                             false,
+		MaximumHistoricCoveragesPerClass:         30,
+		CoverageAgeThreshold:                     80,
+		SummarySort:                              "",
+		SummaryTopN:                              0,
 		RawMode:                                  false,
+		KeepNestedClasses:                        false,
+		LanguageProcessor:                        "",
+		AssemblyGroupingLevel:                    0,
+		UncoveredLinesClassLimit:                 0,
+		GoApproximateBranchCoverage:              false,
+		FullMethodCoverageMinimumLineRate:        1,
+		FullMethodCoverageRequiresBranches:       false,
+		ExcludeCoverageByComments:                false,
+		CoverageExclusionMarkers:                 DefaultExclusionMarkers(),
+		DeclaredTotalsTolerance:                  0.01,
+		RecomputeAggregates:                      false,
+		Language:                                 "",
+		TranslationsFile:                         "",
+		SyntaxHighlight:                          false,
+		MaximumLineLength:                        2000,
+		MinifiedAverageLineLength:                500,
+		Incremental:                              false,
+		ResolveSymlinks:                          false,
+		FileRetries:                              5,
+		FileRetryDelayInMilliseconds:             100,
+		FileRetryTimeoutInSeconds:                10,
+		PathCase:                                 "auto",
+		MergeVendoredFiles:                       true,
+		ExcludeExternalFiles:                     false,
+		StrictExternalFiles:                      false,
+		DumpModel:                                "",
+		DumpModelIncludeSource:                   false,
+		AutoDiscoverSourceFiles:                  false,
 	}
 }
@@ -0,0 +1,79 @@
+package settings
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// thresholdMetricNames lists the method metrics that can be given thresholds.
+var thresholdMetricNames = []string{"Cyclomatic complexity", "CrapScore", "NPath complexity", "Nesting depth"}
+
+// DefaultMetricThresholds returns the default limits for method metrics.
+func DefaultMetricThresholds() map[string]model.MetricThreshold {
+	return map[string]model.MetricThreshold{
+		"CrapScore":             {Warning: 30, Error: 80},
+		"Cyclomatic complexity": {Warning: 15, Error: 30},
+	}
+}
+
+// ParseMetricThresholds parses semicolon-separated overrides of the form
+// "Name=warning[:error]", e.g. "CrapScore=20:60;Cyclomatic complexity=10".
+// Metric names are matched case-insensitively; a limit of 0 disables it.
+func ParseMetricThresholds(s string) (map[string]model.MetricThreshold, error) {
+	thresholds := make(map[string]model.MetricThreshold)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, limits, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid metric threshold '%s' (expected Name=warning[:error])", entry)
+		}
+		metricName, err := canonicalMetricName(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+
+		warning, errorLimit, hasError := strings.Cut(limits, ":")
+		var threshold model.MetricThreshold
+		if threshold.Warning, err = parseLimit(warning); err != nil {
+			return nil, fmt.Errorf("invalid warning limit for metric '%s': %w", metricName, err)
+		}
+		if hasError {
+			if threshold.Error, err = parseLimit(errorLimit); err != nil {
+				return nil, fmt.Errorf("invalid error limit for metric '%s': %w", metricName, err)
+			}
+		}
+		thresholds[metricName] = threshold
+	}
+	return thresholds, nil
+}
+
+func canonicalMetricName(name string) (string, error) {
+	for _, known := range thresholdMetricNames {
+		if strings.EqualFold(name, known) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("unknown metric '%s' (expected one of: %s)", name, strings.Join(thresholdMetricNames, ", "))
+}
+
+func parseLimit(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	limit, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("limit must not be negative")
+	}
+	return limit, nil
+}
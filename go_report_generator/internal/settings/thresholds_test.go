@@ -0,0 +1,52 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+func TestParseMetricThresholds(t *testing.T) {
+	got, err := ParseMetricThresholds("crapscore=20:60; Cyclomatic Complexity=10 ;")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]model.MetricThreshold{
+		"CrapScore":             {Warning: 20, Error: 60},
+		"Cyclomatic complexity": {Warning: 10},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for name, threshold := range want {
+		if got[name] != threshold {
+			t.Errorf("%s = %+v, want %+v", name, got[name], threshold)
+		}
+	}
+}
+
+func TestParseMetricThresholds_Invalid(t *testing.T) {
+	for _, input := range []string{"CrapScore", "Coverage=10", "CrapScore=abc", "CrapScore=10:-1"} {
+		if _, err := ParseMetricThresholds(input); err == nil {
+			t.Errorf("ParseMetricThresholds(%q) expected an error", input)
+		}
+	}
+}
+
+func TestMetricThreshold_Evaluate(t *testing.T) {
+	threshold := DefaultMetricThresholds()["CrapScore"]
+	tests := []struct {
+		value float64
+		want  model.MetricStatus
+	}{
+		{30, model.StatusOk},
+		{30.5, model.StatusWarning},
+		{80, model.StatusWarning},
+		{81, model.StatusError},
+	}
+	for _, tc := range tests {
+		if got := threshold.Evaluate(tc.value); got != tc.want {
+			t.Errorf("Evaluate(%v) = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
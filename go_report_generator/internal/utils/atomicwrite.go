@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic writes content to path through a temporary file in the same directory
+// that is renamed to path once it is complete. An interrupted write leaves the previous
+// file, if any, instead of a truncated one.
+func WriteFileAtomic(path string, content []byte, perm os.FileMode) error {
+	file, err := CreateFileAtomic(path, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Discard()
+		return err
+	}
+	return file.Close()
+}
+
+// AtomicFile is a temporary file that replaces the file at its path when it is closed,
+// see CreateFileAtomic.
+type AtomicFile struct {
+	tmp  *os.File
+	path string
+	perm os.FileMode
+}
+
+// CreateFileAtomic creates a temporary file in the directory of path. Its content
+// replaces the file at path once it is closed; Discard removes it instead, e.g. if
+// writing failed.
+func CreateFileAtomic(path string, perm os.FileMode) (*AtomicFile, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &AtomicFile{tmp: tmp, path: path, perm: perm}, nil
+}
+
+// Write writes to the temporary file.
+func (f *AtomicFile) Write(p []byte) (int, error) {
+	return f.tmp.Write(p)
+}
+
+// Close closes the temporary file and renames it to the path of the file.
+func (f *AtomicFile) Close() error {
+	tmpName := f.tmp.Name()
+	if err := f.tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	// CreateTemp creates the file with mode 0600.
+	if err := os.Chmod(tmpName, f.perm); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, f.path); err != nil {
+		os.Remove(tmpName)
+		return fmt.Errorf("failed to replace %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Discard closes and removes the temporary file, leaving the file at the path as it was.
+func (f *AtomicFile) Discard() {
+	f.tmp.Close()
+	os.Remove(f.tmp.Name())
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index.html")
+	if err := os.WriteFile(path, []byte("old content that is longer"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("new"), 0o644); err != nil {
+		t.Fatalf("WriteFileAtomic returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil || string(content) != "new" {
+		t.Errorf("expected the file to be replaced, got %q (err: %v)", content, err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary files to remain, got %v", entries)
+	}
+}
+
+func TestWriteFileAtomic_MissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "index.html")
+
+	if err := WriteFileAtomic(path, []byte("content"), 0o644); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestCreateFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.css")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	discarded, err := CreateFileAtomic(path, 0o644)
+	if err != nil {
+		t.Fatalf("CreateFileAtomic returned error: %v", err)
+	}
+	discarded.Write([]byte("partial"))
+	discarded.Discard()
+	if content, _ := os.ReadFile(path); string(content) != "old" {
+		t.Errorf("expected a discarded file to leave the file as it was, got %q", content)
+	}
+
+	file, err := CreateFileAtomic(path, 0o644)
+	if err != nil {
+		t.Fatalf("CreateFileAtomic returned error: %v", err)
+	}
+	file.Write([]byte("new"))
+	if content, _ := os.ReadFile(path); string(content) != "old" {
+		t.Errorf("expected the file to be unchanged until the writer is closed, got %q", content)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "new" {
+		t.Errorf("expected the file to be replaced, got %q", content)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary files to remain, got %v", entries)
+	}
+}
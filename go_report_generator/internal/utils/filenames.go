@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxFilenameLengthBase limits the length of generated filenames without extension.
+const maxFilenameLengthBase = 95
+
+// UniqueSanitizedFilename returns baseName with the characters that are invalid in paths
+// replaced, shortened and made unique with a number suffix, plus extension (e.g. ".html").
+// The existingFilenames map holds the lowercase names of all files generated so far and
+// is modified, so the names of one report are checked for collisions by sharing it.
+func UniqueSanitizedFilename(baseName, extension string, existingFilenames map[string]struct{}) string {
+	sanitizedName := ReplaceInvalidPathChars(baseName)
+
+	if len(sanitizedName) > maxFilenameLengthBase {
+		sanitizedName = sanitizedName[:50] + sanitizedName[len(sanitizedName)-(maxFilenameLengthBase-50):]
+	}
+
+	fileName := sanitizedName + extension
+	for counter := 2; ; counter++ {
+		if _, exists := existingFilenames[strings.ToLower(fileName)]; !exists {
+			break
+		}
+		fileName = fmt.Sprintf("%s%d%s", sanitizedName, counter, extension)
+	}
+
+	existingFilenames[strings.ToLower(fileName)] = struct{}{}
+	return fileName
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// FileRetryPolicy decides how often a file that is transiently inaccessible, e.g. still
+// locked by the test host that wrote it, is accessed again before giving up.
+type FileRetryPolicy struct {
+	// Retries is the number of retries after the first attempt, 0 to not retry.
+	Retries int
+	// Delay is the wait before the first retry. It is doubled for each further retry.
+	Delay time.Duration
+	// Timeout limits the total wait, 0 for no limit besides Retries.
+	Timeout time.Duration
+
+	// Now and Sleep are the clock of the policy, time.Now and time.Sleep if nil.
+	Now   func() time.Time
+	Sleep func(time.Duration)
+}
+
+// DefaultFileRetryPolicy is the policy of the command line defaults.
+var DefaultFileRetryPolicy = FileRetryPolicy{Retries: 5, Delay: 100 * time.Millisecond, Timeout: 10 * time.Second}
+
+// FileRetryError is returned by FileRetryPolicy.Do if the file stayed inaccessible.
+type FileRetryError struct {
+	Path     string
+	Attempts int
+	Waited   time.Duration
+	Err      error // Error of the last attempt
+}
+
+func (e *FileRetryError) Error() string {
+	return fmt.Sprintf("%s still inaccessible after %d attempts in %s: %v", e.Path, e.Attempts, e.Waited, e.Err)
+}
+
+func (e *FileRetryError) Unwrap() error { return e.Err }
+
+// Do runs op on the file path until it succeeds, fails with an error that is not
+// transient (see IsTransientFileError) or the policy is exhausted. In the latter case the
+// error is a *FileRetryError with the time waited.
+func (p FileRetryPolicy) Do(path string, op func() error) error {
+	now, sleep := p.Now, p.Sleep
+	if now == nil {
+		now = time.Now
+	}
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	start := now()
+	delay := p.Delay
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || !IsTransientFileError(err) {
+			return err
+		}
+		waited := now().Sub(start)
+		if attempt > p.Retries || (p.Timeout > 0 && waited+delay > p.Timeout) {
+			if attempt == 1 {
+				return err
+			}
+			return &FileRetryError{Path: path, Attempts: attempt, Waited: waited, Err: err}
+		}
+		sleep(delay)
+		delay *= 2
+	}
+}
+
+// IsTransientFileError reports whether err may go away if the file is accessed again:
+// sharing and lock violations on Windows and denied permissions, which Windows also
+// reports for files that are about to be replaced or deleted. Missing files are not.
+func IsTransientFileError(err error) bool {
+	if errors.Is(err, fs.ErrNotExist) {
+		return false
+	}
+	return errors.Is(err, fs.ErrPermission) || isSharingViolation(err)
+}
+
+// Stat is os.Stat for report files, retried according to the policy.
+func (p FileRetryPolicy) Stat(path string) (fs.FileInfo, error) {
+	var info fs.FileInfo
+	err := p.Do(path, func() error {
+		var err error
+		info, err = os.Stat(LongPath(path))
+		return err
+	})
+	return info, err
+}
+
+// Open is os.Open for report files, retried according to the policy.
+func (p FileRetryPolicy) Open(path string) (*os.File, error) {
+	var f *os.File
+	err := p.Do(path, func() error {
+		var err error
+		f, err = os.Open(LongPath(path))
+		return err
+	})
+	return f, err
+}
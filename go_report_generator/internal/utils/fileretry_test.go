@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when the policy sleeps.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) policy(retries int, delay, timeout time.Duration) FileRetryPolicy {
+	return FileRetryPolicy{
+		Retries: retries, Delay: delay, Timeout: timeout,
+		Now:   func() time.Time { return c.now },
+		Sleep: func(d time.Duration) { c.sleeps = append(c.sleeps, d); c.now = c.now.Add(d) },
+	}
+}
+
+var errLocked = &fs.PathError{Op: "open", Path: "coverage.xml", Err: fs.ErrPermission}
+
+func TestFileRetryPolicy_SucceedsAfterTransientErrors(t *testing.T) {
+	clock := &fakeClock{}
+	calls := 0
+
+	err := clock.policy(5, 100*time.Millisecond, 0).Do("coverage.xml", func() error {
+		if calls++; calls < 3 {
+			return errLocked
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}; fmt.Sprint(clock.sleeps) != fmt.Sprint(want) {
+		t.Errorf("sleeps = %v, want %v", clock.sleeps, want)
+	}
+}
+
+func TestFileRetryPolicy_GivesUp(t *testing.T) {
+	cases := []struct {
+		name         string
+		retries      int
+		timeout      time.Duration
+		wantAttempts int
+		wantWaited   time.Duration
+	}{
+		{"after the retries", 2, 0, 3, 300 * time.Millisecond},
+		{"before the timeout is exceeded", 10, time.Second, 4, 700 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &fakeClock{}
+
+			err := clock.policy(tc.retries, 100*time.Millisecond, tc.timeout).Do("coverage.xml", func() error { return errLocked })
+
+			var retryErr *FileRetryError
+			if !errors.As(err, &retryErr) {
+				t.Fatalf("expected a *FileRetryError, got %v", err)
+			}
+			if retryErr.Attempts != tc.wantAttempts || retryErr.Waited != tc.wantWaited || retryErr.Path != "coverage.xml" {
+				t.Errorf("got %d attempts in %s for %q, want %d in %s", retryErr.Attempts, retryErr.Waited, retryErr.Path, tc.wantAttempts, tc.wantWaited)
+			}
+			if !errors.Is(err, fs.ErrPermission) {
+				t.Errorf("expected the error to wrap the last error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFileRetryPolicy_DoesNotRetryPermanentErrors(t *testing.T) {
+	for _, permanent := range []error{fs.ErrNotExist, errors.New("invalid content")} {
+		clock := &fakeClock{}
+		calls := 0
+
+		err := clock.policy(5, 100*time.Millisecond, 0).Do("coverage.xml", func() error { calls++; return permanent })
+
+		if err != permanent || calls != 1 || len(clock.sleeps) != 0 {
+			t.Errorf("%v: got %v after %d calls, want the error of the only call", permanent, err, calls)
+		}
+	}
+}
+
+func TestFileRetryPolicy_NoRetriesReturnsTheError(t *testing.T) {
+	clock := &fakeClock{}
+
+	err := clock.policy(0, 100*time.Millisecond, 0).Do("coverage.xml", func() error { return errLocked })
+
+	if err != errLocked || len(clock.sleeps) != 0 {
+		t.Errorf("got %v, want the unwrapped error without waiting", err)
+	}
+}
+
+func TestFileRetryPolicy_OpenAndStat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.xml")
+	if err := os.WriteFile(path, []byte("<coverage/>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	clock := &fakeClock{}
+	policy := clock.policy(3, time.Second, 0)
+
+	f, err := policy.Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	f.Close()
+	if _, err := policy.Stat(path + ".missing"); !errors.Is(err, fs.ErrNotExist) || len(clock.sleeps) != 0 {
+		t.Errorf("expected a missing file to fail at once, got %v after %v", err, clock.sleeps)
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// LineRange is an inclusive range of line numbers.
+type LineRange struct {
+	First int
+	Last  int
+}
+
+// String formats the range as "12-18", or "25" for a single line.
+func (r LineRange) String() string {
+	if r.First == r.Last {
+		return strconv.Itoa(r.First)
+	}
+	return strconv.Itoa(r.First) + "-" + strconv.Itoa(r.Last)
+}
+
+// UncoveredLineRanges collapses the uncovered lines (Hits == 0) of a file into ranges.
+// Only covered lines end a range; non-coverable lines (Hits < 0) and line numbers
+// missing from lines are bridged, so a blank line or comment does not split a block.
+func UncoveredLineRanges(lines []model.Line) []LineRange {
+	var ranges []LineRange
+	open := false
+	sorted := make([]model.Line, len(lines))
+	copy(sorted, lines)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+
+	for _, line := range sorted {
+		switch {
+		case line.Hits == 0:
+			if open {
+				ranges[len(ranges)-1].Last = line.Number
+			} else {
+				ranges = append(ranges, LineRange{First: line.Number, Last: line.Number})
+				open = true
+			}
+		case line.Hits > 0:
+			open = false
+		}
+	}
+	return ranges
+}
+
+// FormatLineRanges joins the ranges as "12-18, 25, 31-40".
+func FormatLineRanges(ranges []LineRange) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ", ")
+}
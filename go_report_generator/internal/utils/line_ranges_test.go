@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/model"
+)
+
+// linesWithHits builds lines numbered from 1 with the given hits (-1: not coverable).
+func linesWithHits(hits ...int) []model.Line {
+	lines := make([]model.Line, len(hits))
+	for i, h := range hits {
+		lines[i] = model.Line{Number: i + 1, Hits: h}
+	}
+	return lines
+}
+
+func TestUncoveredLineRanges(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lines    []model.Line
+		expected []LineRange
+	}{
+		{
+			name:     "NoLines",
+			lines:    nil,
+			expected: nil,
+		},
+		{
+			name:     "FullyCovered",
+			lines:    linesWithHits(1, 2, -1, 3),
+			expected: nil,
+		},
+		{
+			name:     "AdjacentLinesAreCollapsed",
+			lines:    linesWithHits(0, 0, 0),
+			expected: []LineRange{{1, 3}},
+		},
+		{
+			name:     "SingleLines",
+			lines:    linesWithHits(0, 1, 0, 1),
+			expected: []LineRange{{1, 1}, {3, 3}},
+		},
+		{
+			name:     "NonCoverableLinesAreBridged",
+			lines:    linesWithHits(0, -1, -1, 0, 1),
+			expected: []LineRange{{1, 4}},
+		},
+		{
+			name:     "TrailingNonCoverableLinesAreNotIncluded",
+			lines:    linesWithHits(1, 0, -1, -1),
+			expected: []LineRange{{2, 2}},
+		},
+		{
+			name:     "MissingLineNumbersAreBridged",
+			lines:    []model.Line{{Number: 12, Hits: 0}, {Number: 18, Hits: 0}, {Number: 20, Hits: 4}, {Number: 25, Hits: 0}},
+			expected: []LineRange{{12, 18}, {25, 25}},
+		},
+		{
+			name:     "UnsortedLines",
+			lines:    []model.Line{{Number: 3, Hits: 0}, {Number: 1, Hits: 0}, {Number: 2, Hits: 5}},
+			expected: []LineRange{{1, 1}, {3, 3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := UncoveredLineRanges(tc.lines)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("UncoveredLineRanges() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFormatLineRanges(t *testing.T) {
+	testCases := []struct {
+		ranges   []LineRange
+		expected string
+	}{
+		{nil, ""},
+		{[]LineRange{{25, 25}}, "25"},
+		{[]LineRange{{12, 18}, {25, 25}, {31, 40}}, "12-18, 25, 31-40"},
+	}
+
+	for _, tc := range testCases {
+		if got := FormatLineRanges(tc.ranges); got != tc.expected {
+			t.Errorf("FormatLineRanges(%v) = %q, want %q", tc.ranges, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// maxPath is the length from which Windows APIs reject paths without the long path prefix.
+const maxPath = 260
+
+var longPathsEnabled atomic.Bool
+
+// EnableLongPaths makes LongPath add the `\\?\` prefix to long paths on Windows. It is
+// opt-in, as prefixed paths are passed to the file system unchanged, e.g. "/" is not
+// accepted as a separator anymore.
+func EnableLongPaths(enabled bool) {
+	longPathsEnabled.Store(enabled)
+}
+
+// longPathForWindows adds the long path prefix to a cleaned, absolute Windows path of at
+// least maxPath characters: `C:\dir` becomes `\\?\C:\dir` and `\\server\share\dir`
+// becomes `\\?\UNC\server\share\dir`. Other paths are returned unchanged.
+func longPathForWindows(p string) string {
+	if len(p) < maxPath || strings.HasPrefix(p, `\\?\`) || strings.HasPrefix(p, `\\.\`) {
+		return p
+	}
+	switch {
+	case strings.HasPrefix(p, `\\`):
+		return `\\?\UNC\` + p[len(`\\`):]
+	case len(p) >= 3 && p[1] == ':' && p[2] == '\\':
+		return `\\?\` + p
+	}
+	return p
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package utils
+
+// LongPath returns the path to pass to os.Stat, os.Open and similar calls. Only Windows
+// limits the path length, so the path is returned unchanged.
+func LongPath(p string) string {
+	return p
+}
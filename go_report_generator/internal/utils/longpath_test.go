@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLongPathForWindows(t *testing.T) {
+	longDir := strings.Repeat(`\very_long_directory_name`, 12)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"short path", `C:\src\Calc.cs`, `C:\src\Calc.cs`},
+		{"long drive path", `C:` + longDir + `\Calc.cs`, `\\?\C:` + longDir + `\Calc.cs`},
+		{"long UNC path", `\\server\share` + longDir + `\Calc.cs`, `\\?\UNC\server\share` + longDir + `\Calc.cs`},
+		{"already prefixed", `\\?\C:` + longDir + `\Calc.cs`, `\\?\C:` + longDir + `\Calc.cs`},
+		{"relative path", `src` + longDir + `\Calc.cs`, `src` + longDir + `\Calc.cs`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longPathForWindows(tt.path); got != tt.want {
+				t.Errorf("longPathForWindows() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package utils
+
+import "path/filepath"
+
+// LongPath returns the path to pass to os.Stat, os.Open and similar calls. With
+// EnableLongPaths, paths of 260 characters or more are made absolute and get the `\\?\`
+// prefix, so that files in deeply nested directories or on network shares are found.
+func LongPath(p string) string {
+	if !longPathsEnabled.Load() || len(p) < maxPath {
+		return p
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return p
+	}
+	return longPathForWindows(abs)
+}
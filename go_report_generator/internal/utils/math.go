@@ -3,52 +3,112 @@ package utils
 import (
 	"fmt"
 	"math"
+	"strings"
 )
 
+// RoundingMode controls how a coverage quota is reduced to the configured number of
+// decimal places.
+type RoundingMode int
+
+const (
+	// RoundingTruncate cuts off further digits, as the C# ReportGenerator does (default).
+	RoundingTruncate RoundingMode = iota
+	// RoundingRound rounds half away from zero.
+	RoundingRound
+	// RoundingFloor rounds towards negative infinity.
+	RoundingFloor
+)
+
+// ParseRoundingMode parses "truncate", "round" or "floor" (case-insensitive). An
+// empty string selects RoundingTruncate.
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "truncate":
+		return RoundingTruncate, nil
+	case "round":
+		return RoundingRound, nil
+	case "floor":
+		return RoundingFloor, nil
+	default:
+		return RoundingTruncate, fmt.Errorf("invalid rounding mode %q (expected round, truncate or floor)", s)
+	}
+}
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundingRound:
+		return "round"
+	case RoundingFloor:
+		return "floor"
+	default:
+		return "truncate"
+	}
+}
+
 // CalculatePercentage calculates (value / total) * 100 with specific truncation.
 // decimalPlaces controls the number of decimal places in the result.
 // Mimics C# ReportGenerator.Core.Common.MathExtensions.CalculatePercentage.
 func CalculatePercentage(value, total int, decimalPlaces int) float64 {
-	if total == 0 {
-		// Depending on C# behavior for 0 total: NaN, 0, or specific handling.
-		// C# MathExtensions.CalculatePercentage throws ArgumentException if number2 (total) is 0.
-		// Let's return NaN for undefined, or 0.0 if preferred for "no coverage".
-		// For display, "N/A" is often used.
-		return math.NaN() // Or 0.0
-	}
+	return CalculatePercentageWithMode(value, total, decimalPlaces, RoundingTruncate)
+}
 
-	if decimalPlaces < 0 {
-		decimalPlaces = 0
-	} else if decimalPlaces > 8 { // Max from C#
-		decimalPlaces = 8
+// CalculatePercentageWithMode calculates (value / total) * 100 and reduces the result
+// to decimalPlaces (0-8) using the given rounding mode. It returns NaN if total is 0.
+//
+// The operations follow the C# implementation
+//
+//	Math.Truncate(100 * 10^dp * number1 / number2) / 10^dp
+//
+// so that e.g. 29/100 yields exactly 29.0 instead of 28.9 due to binary rounding errors.
+func CalculatePercentageWithMode(value, total int, decimalPlaces int, mode RoundingMode) float64 {
+	if total == 0 {
+		// C# MathExtensions.CalculatePercentage throws for a total of 0; NaN is shown as "N/A".
+		return math.NaN()
 	}
 
-	percentage := (float64(value) / float64(total)) * 100.0
+	factor := decimalFactor(decimalPlaces)
+	return roundScaled(100*factor*float64(value)/float64(total), factor, mode)
+}
 
+// ApplyRoundingMode reduces an already calculated percentage to decimalPlaces (clamped
+// to 0-8) using the given rounding mode. NaN and infinite values are returned unchanged.
+func ApplyRoundingMode(percentage float64, decimalPlaces int, mode RoundingMode) float64 {
 	if math.IsNaN(percentage) || math.IsInf(percentage, 0) {
 		return percentage // Propagate NaN/Inf
 	}
 
-	factor := math.Pow(10, float64(decimalPlaces))
-	// C# uses Truncate: Math.Truncate(factor * (double)number1 / (double)number2) / divisor;
-	// which means Math.Truncate(percentage * 10^dp_for_calc_internal) / 10^dp_for_calc_internal
-	// The factor/divisor in C# are related to the internal calculation before final scaling.
-	// MathExtensions.factor = 100 * Math.Pow(10, maximumDecimalPlaces);
-	// MathExtensions.divisor = (int)Math.Pow(10, maximumDecimalPlaces);
-	// It effectively calculates (value/total) then truncates to `maximumDecimalPlaces` for the *percentage value*.
+	factor := decimalFactor(decimalPlaces)
+	scaled := percentage * factor
+	// Values like 86.6 are not exact in binary (86.6 * 10 = 865.9999...), which must
+	// not lose a digit when truncated again.
+	if nearest := math.Round(scaled); math.Abs(scaled-nearest) < 1e-9 {
+		scaled = nearest
+	}
+	return roundScaled(scaled, factor, mode)
+}
 
-	// Let's re-evaluate the C# logic:
-	// return (decimal)Math.Truncate(factor * (double)number1 / (double)number2) / divisor;
-	// factor = 100 * Math.Pow(10, maximumDecimalPlaces);
-	// divisor = (int)Math.Pow(10, maximumDecimalPlaces);
-	// This becomes: Math.Truncate(100 * ( (float64)value / (float64)total ) * 10^dp ) / 10^dp
-	// This is equivalent to: Truncate(percentage * 10^dp) / 10^dp
-	// E.g., if percentage = 75.12345 and dp = 1:
-	// Truncate(75.12345 * 10) / 10 = Truncate(751.2345) / 10 = 751 / 10 = 75.1
+// decimalFactor returns 10^decimalPlaces, with decimalPlaces clamped to the C# range 0-8.
+func decimalFactor(decimalPlaces int) float64 {
+	if decimalPlaces < 0 {
+		decimalPlaces = 0
+	} else if decimalPlaces > 8 { // Max from C#
+		decimalPlaces = 8
+	}
+	return math.Pow(10, float64(decimalPlaces))
+}
 
-	scaled := percentage * factor
-	truncated := math.Trunc(scaled)
-	return truncated / factor
+func roundScaled(scaled, factor float64, mode RoundingMode) float64 {
+	if math.IsNaN(scaled) || math.IsInf(scaled, 0) {
+		return scaled
+	}
+	switch mode {
+	case RoundingRound:
+		return math.Round(scaled) / factor
+	case RoundingFloor:
+		return math.Floor(scaled) / factor
+	default:
+		return math.Trunc(scaled) / factor
+	}
 }
 
 // FormatPercentage formats a float64 percentage value (0-100) as a string
@@ -63,3 +123,13 @@ func FormatPercentage(percentage float64, decimalPlaces int) string {
 	}
 	return fmt.Sprintf(fmt.Sprintf("%%.%df%%%%", decimalPlaces), percentage)
 }
+
+// FormatPercentageWithMode formats a percentage like FormatPercentage, but first reduces
+// it to decimalPlaces with the given rounding mode, so that the displayed value agrees
+// with quotas calculated by CalculatePercentageWithMode.
+func FormatPercentageWithMode(percentage float64, decimalPlaces int, mode RoundingMode) string {
+	if decimalPlaces < 0 {
+		decimalPlaces = 0
+	}
+	return FormatPercentage(ApplyRoundingMode(percentage, decimalPlaces, mode), decimalPlaces)
+}
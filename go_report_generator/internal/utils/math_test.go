@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculatePercentageWithMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		value, total  int
+		decimalPlaces int
+		mode          RoundingMode
+		expected      float64
+	}{
+		// 2/3 = 66.666...: C# truncates, so only "round" goes up.
+		{"2/3 truncate 1dp", 2, 3, 1, RoundingTruncate, 66.6},
+		{"2/3 round 1dp", 2, 3, 1, RoundingRound, 66.7},
+		{"2/3 floor 1dp", 2, 3, 1, RoundingFloor, 66.6},
+		{"2/3 truncate 2dp", 2, 3, 2, RoundingTruncate, 66.66},
+		{"2/3 round 2dp", 2, 3, 2, RoundingRound, 66.67},
+		{"2/3 floor 2dp", 2, 3, 2, RoundingFloor, 66.66},
+
+		// 199/200 = 99.5 exactly: no mode may change it.
+		{"199/200 truncate 1dp", 199, 200, 1, RoundingTruncate, 99.5},
+		{"199/200 round 1dp", 199, 200, 1, RoundingRound, 99.5},
+		{"199/200 floor 1dp", 199, 200, 1, RoundingFloor, 99.5},
+		{"199/200 truncate 2dp", 199, 200, 2, RoundingTruncate, 99.5},
+		{"199/200 round 2dp", 199, 200, 2, RoundingRound, 99.5},
+		{"199/200 floor 2dp", 199, 200, 2, RoundingFloor, 99.5},
+
+		// 199/200 at 0dp is where the modes differ for a value just below 100.
+		{"199/200 truncate 0dp", 199, 200, 0, RoundingTruncate, 99},
+		{"199/200 round 0dp", 199, 200, 0, RoundingRound, 100},
+
+		// 29/100 * 100 is 28.999... in binary when divided first; C# multiplies first.
+		{"29/100 truncate 1dp", 29, 100, 1, RoundingTruncate, 29.0},
+		{"866/1000 truncate 1dp", 866, 1000, 1, RoundingTruncate, 86.6},
+		{"8669/10000 truncate 1dp", 8669, 10000, 1, RoundingTruncate, 86.6},
+		{"8669/10000 round 1dp", 8669, 10000, 1, RoundingRound, 86.7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CalculatePercentageWithMode(tc.value, tc.total, tc.decimalPlaces, tc.mode)
+			if got != tc.expected {
+				t.Errorf("CalculatePercentageWithMode(%d, %d, %d, %s) = %v, want %v",
+					tc.value, tc.total, tc.decimalPlaces, tc.mode, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCalculatePercentage_DefaultsToTruncation(t *testing.T) {
+	if got := CalculatePercentage(2, 3, 1); got != 66.6 {
+		t.Errorf("CalculatePercentage(2, 3, 1) = %v, want 66.6", got)
+	}
+	if got := CalculatePercentage(1, 0, 1); !math.IsNaN(got) {
+		t.Errorf("CalculatePercentage(1, 0, 1) = %v, want NaN", got)
+	}
+}
+
+func TestFormatPercentageWithMode(t *testing.T) {
+	tests := []struct {
+		percentage    float64
+		decimalPlaces int
+		mode          RoundingMode
+		expected      string
+	}{
+		{66.66, 1, RoundingTruncate, "66.6%"},
+		{66.66, 1, RoundingRound, "66.7%"},
+		{66.6, 0, RoundingTruncate, "66%"},
+		{66.6, 0, RoundingRound, "67%"},
+		{86.6, 1, RoundingTruncate, "86.6%"},
+		{99.5, 2, RoundingFloor, "99.50%"},
+		{math.NaN(), 1, RoundingRound, "N/A"},
+	}
+
+	for _, tc := range tests {
+		got := FormatPercentageWithMode(tc.percentage, tc.decimalPlaces, tc.mode)
+		if got != tc.expected {
+			t.Errorf("FormatPercentageWithMode(%v, %d, %s) = %q, want %q",
+				tc.percentage, tc.decimalPlaces, tc.mode, got, tc.expected)
+		}
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected RoundingMode
+		wantErr  bool
+	}{
+		{"", RoundingTruncate, false},
+		{"truncate", RoundingTruncate, false},
+		{"Round", RoundingRound, false},
+		{" floor ", RoundingFloor, false},
+		{"ceiling", RoundingTruncate, true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseRoundingMode(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseRoundingMode(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			continue
+		}
+		if got != tc.expected {
+			t.Errorf("ParseRoundingMode(%q) = %s, want %s", tc.input, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeMode controls how the hits of a line and the visits of a branch are combined
+// when several reports cover the same line. Listings of a line within one report are
+// combined by the parsers. It does not change which lines count as covered.
+type MergeMode int
+
+const (
+	// MergeSum adds the counts up, which is right for reports of separate runs, such as
+	// the shards of a test suite (default).
+	MergeSum MergeMode = iota
+	// MergeMax keeps the highest count, which is right for several exports of one run,
+	// e.g. an LCOV and a Cobertura file of the same execution.
+	MergeMax
+)
+
+// ParseMergeMode parses "sum" or "max" (case-insensitive). An empty string selects
+// MergeSum.
+func ParseMergeMode(s string) (MergeMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "sum":
+		return MergeSum, nil
+	case "max":
+		return MergeMax, nil
+	default:
+		return MergeSum, fmt.Errorf("invalid merge mode %q (expected sum or max)", s)
+	}
+}
+
+func (m MergeMode) String() string {
+	if m == MergeMax {
+		return "max"
+	}
+	return "sum"
+}
+
+// Combine returns the count of two reports of the same line or branch.
+func (m MergeMode) Combine(a, b int) int {
+	if m == MergeMax {
+		return max(a, b)
+	}
+	return a + b
+}
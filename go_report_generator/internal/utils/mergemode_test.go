@@ -0,0 +1,36 @@
+package utils
+
+import "testing"
+
+func TestParseMergeMode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected MergeMode
+		wantErr  bool
+	}{
+		{"", MergeSum, false},
+		{"sum", MergeSum, false},
+		{" MAX ", MergeMax, false},
+		{"average", MergeSum, true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseMergeMode(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseMergeMode(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			continue
+		}
+		if got != tc.expected {
+			t.Errorf("ParseMergeMode(%q) = %s, want %s", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestMergeMode_Combine(t *testing.T) {
+	if got := MergeSum.Combine(3, 4); got != 7 {
+		t.Errorf("MergeSum.Combine(3, 4) = %d, want 7", got)
+	}
+	if got := MergeMax.Combine(3, 4); got != 4 {
+		t.Errorf("MergeMax.Combine(3, 4) = %d, want 4", got)
+	}
+}
@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// PathCaseMode decides whether file paths that differ only in case refer to the same file.
+type PathCaseMode int32
+
+const (
+	// PathCaseAuto compares paths case-insensitively on Windows and case-sensitively elsewhere.
+	PathCaseAuto PathCaseMode = iota
+	// PathCaseSensitive treats paths that differ in case as different files, e.g. for
+	// case-sensitive network shares mounted on Windows.
+	PathCaseSensitive
+	// PathCaseInsensitive treats paths that differ only in case as the same file, e.g. for
+	// reports written on Windows that are processed on another system.
+	PathCaseInsensitive
+)
+
+// ParsePathCaseMode parses "auto", "sensitive" or "insensitive" (case-insensitively).
+// An empty value selects PathCaseAuto.
+func ParsePathCaseMode(value string) (PathCaseMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "auto":
+		return PathCaseAuto, nil
+	case "sensitive":
+		return PathCaseSensitive, nil
+	case "insensitive":
+		return PathCaseInsensitive, nil
+	}
+	return PathCaseAuto, fmt.Errorf("unknown path case mode %q (expected auto, sensitive or insensitive)", value)
+}
+
+// String returns the name of the mode as accepted by ParsePathCaseMode.
+func (m PathCaseMode) String() string {
+	switch m {
+	case PathCaseSensitive:
+		return "sensitive"
+	case PathCaseInsensitive:
+		return "insensitive"
+	}
+	return "auto"
+}
+
+// caseInsensitive reports whether paths that differ only in case are the same file.
+func (m PathCaseMode) caseInsensitive() bool {
+	switch m {
+	case PathCaseSensitive:
+		return false
+	case PathCaseInsensitive:
+		return true
+	}
+	return runtime.GOOS == "windows"
+}
+
+// resolvedPaths caches the targets of the paths resolved by ResolveSymlinks, as resolving
+// accesses the file system once per path segment.
+var resolvedPaths sync.Map
+
+// ResolveSymlinks returns the target of p with all symbolic links resolved, so that a file
+// reached through a link (e.g. bazel-out or node_modules links) has the path of its
+// target, or p itself if it cannot be resolved, e.g. because the file does not exist.
+func ResolveSymlinks(p string) string {
+	if resolved, ok := resolvedPaths.Load(p); ok {
+		return resolved.(string)
+	}
+	resolved, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		resolved = p
+	}
+	resolvedPaths.Store(p, resolved)
+	return resolved
+}
+
+// PathKeyFunc returns a function like PathKey that treats the case of paths as mode says
+// and, if resolveSymlinks is set, returns the key of the target of a path (see
+// ResolveSymlinks). Resolving is meant to be opt-in because it accesses the file system,
+// which can be slow on network mounts.
+func PathKeyFunc(mode PathCaseMode, resolveSymlinks bool) func(string) string {
+	if !resolveSymlinks {
+		return func(p string) string { return pathKey(p, mode) }
+	}
+	return func(p string) string {
+		if p == "" {
+			return ""
+		}
+		return pathKey(ResolveSymlinks(p), mode)
+	}
+}
+
+// PathKey returns the canonical form of a file path for use as a map key, so that the
+// spellings of the same file in different reports are counted once. Backslashes become
+// slashes, the path is cleaned and, on Windows, lower-cased. The file system is not
+// accessed. See PathKeyFunc for other case modes and for resolving symbolic links.
+// PathKey is meant for comparisons only; the original path should be kept for display.
+func PathKey(p string) string {
+	return pathKey(p, PathCaseAuto)
+}
+
+func pathKey(p string, mode PathCaseMode) string {
+	if p == "" {
+		return ""
+	}
+	key := slashPath(p)
+	if mode.caseInsensitive() {
+		key = strings.ToLower(key)
+	}
+	return key
+}
+
+// slashPath returns the cleaned path with backslashes replaced by slashes.
+func slashPath(p string) string {
+	p = strings.ReplaceAll(p, `\`, "/")
+	if strings.HasPrefix(p, "//") {
+		// Keep the double slash of a UNC path (//server/share), which path.Clean would drop.
+		return "/" + path.Clean(p[1:])
+	}
+	return path.Clean(p)
+}
+
+// isAbsSlashPath reports whether a path returned by slashPath is absolute on Unix or
+// Windows, e.g. "/src", "//server/share" or "C:/src".
+func isAbsSlashPath(p string) bool {
+	if strings.HasPrefix(p, "/") {
+		return true
+	}
+	return len(p) >= 3 && p[1] == ':' && p[2] == '/' &&
+		('a' <= p[0] && p[0] <= 'z' || 'A' <= p[0] && p[0] <= 'Z')
+}
+
+// WorkspaceRelativePath returns the slash-separated path of p relative to the workspace
+// root, e.g. "src/app/main.go" for `C:\work\src\app\main.go` and the root "c:/work", and
+// whether p is inside the root. Slashes and backslashes are treated alike and the case
+// of the paths is compared as mode says. A relative p is taken as relative to the root
+// already. Reporters use it to write paths that editors and code analysis servers match
+// against their checkout.
+func WorkspaceRelativePath(p, root string, mode PathCaseMode) (string, bool) {
+	slashed := slashPath(p)
+	if root == "" || !isAbsSlashPath(slashed) {
+		return slashed, true
+	}
+	if relative, ok := cutDirectoryPrefix(slashed, slashPath(root), mode); ok && relative != "" {
+		return relative, true
+	}
+	return slashed, false
+}
+
+// cutDirectoryPrefix returns the part of the slash path p below the slash path dir, ""
+// for dir itself, and whether p is dir or below it. The case is compared as mode says.
+func cutDirectoryPrefix(p, dir string, mode PathCaseMode) (string, bool) {
+	if p == dir || mode.caseInsensitive() && strings.EqualFold(p, dir) {
+		return "", true
+	}
+	prefix := dir
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	if len(p) <= len(prefix) {
+		return "", false
+	}
+	head := p[:len(prefix)]
+	if head == prefix || mode.caseInsensitive() && strings.EqualFold(head, prefix) {
+		return p[len(prefix):], true
+	}
+	return "", false
+}
+
+// IsPathInDirectories reports whether the path p is one of dirs or below one of them,
+// e.g. whether a source file belongs to the source directories of a project. Slashes and
+// backslashes are treated alike and the case is compared as mode says. If p is absolute,
+// relative directories are taken as relative to the working directory.
+func IsPathInDirectories(p string, dirs []string, mode PathCaseMode) bool {
+	if p == "" {
+		return false
+	}
+	slashed := slashPath(p)
+	for _, dir := range dirs {
+		if strings.TrimSpace(dir) == "" {
+			continue
+		}
+		dirSlashed := slashPath(dir)
+		if !isAbsSlashPath(dirSlashed) && isAbsSlashPath(slashed) {
+			if abs, err := filepath.Abs(dir); err == nil {
+				dirSlashed = slashPath(abs)
+			}
+		}
+		if _, ok := cutDirectoryPrefix(slashed, dirSlashed, mode); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// VendoredPathSuffix returns the part of a path key (see PathKey) after its last vendor
+// directory, e.g. "github.com/pkg/errors/errors.go" for
+// "/src/app/vendor/github.com/pkg/errors/errors.go", and whether the path is vendored.
+// The same package vendored into several modules, or once vendored and once checked out,
+// has the same suffix.
+func VendoredPathSuffix(key string) (string, bool) {
+	var suffix string
+	if i := strings.LastIndex(key, "/vendor/"); i >= 0 {
+		suffix = key[i+len("/vendor/"):]
+	} else if rest, ok := strings.CutPrefix(key, "vendor/"); ok {
+		suffix = rest
+	}
+	return suffix, suffix != ""
+}
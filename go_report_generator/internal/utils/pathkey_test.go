@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestPathKey(t *testing.T) {
+	tests := []struct {
+		name string
+		mode PathCaseMode
+		a, b string
+		same bool
+	}{
+		{"drive letter case", PathCaseInsensitive, `c:\Work\src\Foo.cs`, `C:\work\src\foo.cs`, true},
+		{"mixed separators", PathCaseInsensitive, `C:\work\src/Foo.cs`, `C:/work/src/Foo.cs`, true},
+		{"dot segments", PathCaseSensitive, `/src/./app/../app/Foo.cs`, `/src/app/Foo.cs`, true},
+		{"UNC path", PathCaseInsensitive, `\\Build\Share\Foo.cs`, `//build/share/foo.cs`, true},
+		{"UNC path is not rooted path", PathCaseSensitive, `\\build\share\Foo.cs`, `/build/share/Foo.cs`, false},
+		{"case-sensitive", PathCaseSensitive, `C:\Work\Foo.cs`, `C:\work\foo.cs`, false},
+		{"different files", PathCaseInsensitive, `C:\work\Foo.cs`, `C:\work\Bar.cs`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyOf := PathKeyFunc(tt.mode, false)
+			if same := keyOf(tt.a) == keyOf(tt.b); same != tt.same {
+				t.Errorf("key of %q = %q, key of %q = %q, want same = %v", tt.a, keyOf(tt.a), tt.b, keyOf(tt.b), tt.same)
+			}
+		})
+	}
+}
+
+func TestParsePathCaseMode(t *testing.T) {
+	for value, want := range map[string]PathCaseMode{"": PathCaseAuto, "Auto": PathCaseAuto, "sensitive": PathCaseSensitive, "INSENSITIVE": PathCaseInsensitive} {
+		if got, err := ParsePathCaseMode(value); err != nil || got != want {
+			t.Errorf("ParsePathCaseMode(%q) = %v, %v, want %v", value, got, err, want)
+		}
+		if got, err := ParsePathCaseMode(want.String()); err != nil || got != want {
+			t.Errorf("ParsePathCaseMode(%q) = %v, %v, want %v", want.String(), got, err, want)
+		}
+	}
+	if _, err := ParsePathCaseMode("ignore"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestPathKeyFunc_ResolveSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symbolic links needs extra privileges on Windows")
+	}
+	dir := t.TempDir()
+	target := filepath.Join(dir, "src", "Foo.go")
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "src"), filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link", "Foo.go")
+
+	if keyOf := PathKeyFunc(PathCaseAuto, false); keyOf(link) == keyOf(target) {
+		t.Errorf("key of %q = key of %q without resolving links", link, target)
+	}
+
+	keyOf := PathKeyFunc(PathCaseAuto, true)
+	if keyOf(link) != PathKey(target) {
+		t.Errorf("key of %q = %q, want the key of its target %q", link, keyOf(link), PathKey(target))
+	}
+	if missing := filepath.Join(dir, "link", "Missing.go"); keyOf(missing) != filepath.ToSlash(missing) {
+		t.Errorf("key of %q = %q, want the path of a missing file unchanged", missing, keyOf(missing))
+	}
+}
+
+func TestVendoredPathSuffix(t *testing.T) {
+	tests := []struct {
+		key, suffix string
+		vendored    bool
+	}{
+		{"/src/app/vendor/github.com/pkg/errors/errors.go", "github.com/pkg/errors/errors.go", true},
+		{"vendor/golang.org/x/sys/unix.go", "golang.org/x/sys/unix.go", true},
+		{"/src/a/vendor/b/vendor/c/c.go", "c/c.go", true},
+		{"/src/app/vendors/x.go", "", false},
+		{"/src/app/myvendor/x.go", "", false},
+		{"/src/app/vendor/", "", false},
+		{"/src/app/main.go", "", false},
+	}
+	for _, tt := range tests {
+		if suffix, vendored := VendoredPathSuffix(tt.key); suffix != tt.suffix || vendored != tt.vendored {
+			t.Errorf("VendoredPathSuffix(%q) = %q, %v, want %q, %v", tt.key, suffix, vendored, tt.suffix, tt.vendored)
+		}
+	}
+}
+
+func TestWorkspaceRelativePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     PathCaseMode
+		path     string
+		root     string
+		relative string
+		inside   bool
+	}{
+		{"unix path", PathCaseSensitive, "/home/dev/app/src/main.go", "/home/dev/app", "src/main.go", true},
+		{"unix root with trailing slash", PathCaseSensitive, "/home/dev/app/src/main.go", "/home/dev/app/", "src/main.go", true},
+		{"windows path", PathCaseInsensitive, `C:\Work\App\src\Calc.cs`, `c:\work\app`, "src/Calc.cs", true},
+		{"windows path with slash root", PathCaseInsensitive, `C:\Work\App\src\Calc.cs`, "C:/Work/App", "src/Calc.cs", true},
+		{"slash path with windows root", PathCaseInsensitive, "C:/Work/App/src/Calc.cs", `C:\Work\App\`, "src/Calc.cs", true},
+		{"drive root", PathCaseInsensitive, `D:\src\Calc.cs`, `D:\`, "src/Calc.cs", true},
+		{"case-sensitive", PathCaseSensitive, "/home/Dev/app/main.go", "/home/dev/app", "/home/Dev/app/main.go", false},
+		{"sibling with common prefix", PathCaseSensitive, "/home/dev/app2/main.go", "/home/dev/app", "/home/dev/app2/main.go", false},
+		{"outside root", PathCaseInsensitive, `C:\Users\dev\go\pkg\mod\lib.go`, `C:\Work\App`, "C:/Users/dev/go/pkg/mod/lib.go", false},
+		{"relative path", PathCaseSensitive, `src\app\.\main.go`, "/home/dev/app", "src/app/main.go", true},
+		{"no root", PathCaseSensitive, "/home/dev/app/main.go", "", "/home/dev/app/main.go", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relative, inside := WorkspaceRelativePath(tt.path, tt.root, tt.mode)
+			if relative != tt.relative || inside != tt.inside {
+				t.Errorf("WorkspaceRelativePath(%q, %q) = %q, %v, want %q, %v", tt.path, tt.root, relative, inside, tt.relative, tt.inside)
+			}
+		})
+	}
+}
+
+func TestIsPathInDirectories(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   PathCaseMode
+		path   string
+		dirs   []string
+		inside bool
+	}{
+		{"below dir", PathCaseSensitive, "/home/dev/app/src/main.go", []string{"/home/dev/app"}, true},
+		{"below second dir", PathCaseSensitive, "/home/dev/lib/util.go", []string{"/home/dev/app", "/home/dev/lib/"}, true},
+		{"dir itself", PathCaseSensitive, "/home/dev/app", []string{"/home/dev/app"}, true},
+		{"sibling with common prefix", PathCaseSensitive, "/home/dev/app2/main.go", []string{"/home/dev/app"}, false},
+		{"outside", PathCaseSensitive, "/usr/lib/go/src/fmt/print.go", []string{"/home/dev/app"}, false},
+		{"case-sensitive", PathCaseSensitive, "/home/Dev/app/main.go", []string{"/home/dev/app"}, false},
+		{"windows path", PathCaseInsensitive, `C:\Work\App\obj\Debug\Gen.cs`, []string{`c:\work\app`}, true},
+		{"windows path outside", PathCaseInsensitive, `C:\Program Files\dotnet\Lib.cs`, []string{"C:/Work/App"}, false},
+		{"relative path in relative dir", PathCaseSensitive, `src\app\main.go`, []string{"src"}, true},
+		{"no dirs", PathCaseSensitive, "/home/dev/app/main.go", nil, false},
+		{"empty dir", PathCaseSensitive, "/home/dev/app/main.go", []string{""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if inside := IsPathInDirectories(tt.path, tt.dirs, tt.mode); inside != tt.inside {
+				t.Errorf("IsPathInDirectories(%q, %q) = %v, want %v", tt.path, tt.dirs, inside, tt.inside)
+			}
+		})
+	}
+}
+
+func TestIsPathInDirectories_RelativeDirectory(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if !IsPathInDirectories(filepath.Join(wd, "src", "main.go"), []string{"src"}, PathCaseSensitive) {
+		t.Error("an absolute path below a relative directory is not inside it")
+	}
+}
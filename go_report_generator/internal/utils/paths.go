@@ -15,7 +15,7 @@ type Stater interface {
 type DefaultStater struct{}
 
 func (ds DefaultStater) Stat(name string) (fs.FileInfo, error) {
-	return os.Stat(name)
+	return os.Stat(LongPath(name))
 }
 
 // Attempts to locate a file using a Stater interface.
@@ -29,19 +29,28 @@ func FindFileInSourceDirs(relativePath string, sourceDirs []string, stater State
 	cleanedRelativePath := filepath.Clean(relativePath)
 
 	for _, dir := range sourceDirs {
-		absPath := filepath.Join(filepath.Clean(dir), cleanedRelativePath)
-		if _, err := stater.Stat(absPath); err == nil {
-			return absPath, nil
+		if path, ok := findFileInSourceDir(cleanedRelativePath, dir, stater); ok {
+			return path, nil
 		}
+	}
+	return "", fmt.Errorf("file %q not found in any source directory (%v) or as absolute path", relativePath, sourceDirs)
+}
 
-		pathParts := strings.Split(cleanedRelativePath, string(os.PathSeparator))
-		for i := 0; i < len(pathParts); i++ {
-			suffixToTry := filepath.Join(pathParts[i:]...)
-			potentialPath := filepath.Join(filepath.Clean(dir), suffixToTry)
-			if _, err := stater.Stat(potentialPath); err == nil {
-				return potentialPath, nil
-			}
+// findFileInSourceDir looks for the cleaned path below dir, first as a whole and then by
+// dropping leading path segments one at a time.
+func findFileInSourceDir(cleanedRelativePath, dir string, stater Stater) (string, bool) {
+	cleanedDir := filepath.Clean(dir)
+	absPath := filepath.Join(cleanedDir, cleanedRelativePath)
+	if _, err := stater.Stat(absPath); err == nil {
+		return absPath, true
+	}
+
+	pathParts := strings.Split(cleanedRelativePath, string(os.PathSeparator))
+	for i := 1; i < len(pathParts); i++ {
+		potentialPath := filepath.Join(cleanedDir, filepath.Join(pathParts[i:]...))
+		if _, err := stater.Stat(potentialPath); err == nil {
+			return potentialPath, true
 		}
 	}
-	return "", fmt.Errorf("file %q not found in any source directory (%v) or as absolute path", relativePath, sourceDirs)
+	return "", false
 }
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SourceFileIndex maps file base names to every matching path found below a
+// set of source roots. It is used as a last resort when a path from a coverage
+// report cannot be resolved by FindFileInSourceDirs, e.g. because the report was
+// produced in a checkout with a different directory layout.
+//
+// The index is built lazily on the first lookup and at most once, so a single
+// instance can be shared by all parsers of a run.
+type SourceFileIndex struct {
+	roots  func() []string
+	logger *slog.Logger
+
+	once   sync.Once
+	byName map[string][]string
+}
+
+// NewSourceFileIndex creates an index over the directories returned by roots.
+// roots is evaluated when the index is first built; if it yields no directories
+// the current working directory is scanned instead.
+func NewSourceFileIndex(roots func() []string, logger *slog.Logger) *SourceFileIndex {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SourceFileIndex{roots: roots, logger: logger}
+}
+
+// Resolve finds the indexed file whose path shares the longest common suffix
+// (compared by path segment) with reportPath. An error is returned when no file
+// has the same base name, or when several candidates tie for the longest suffix.
+func (idx *SourceFileIndex) Resolve(reportPath string) (string, error) {
+	idx.once.Do(idx.build)
+
+	reportSegments := splitPathSegments(reportPath)
+	if len(reportSegments) == 0 {
+		return "", fmt.Errorf("cannot resolve empty path")
+	}
+	candidates := idx.byName[strings.ToLower(reportSegments[len(reportSegments)-1])]
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("file %q not found by source auto-discovery", reportPath)
+	}
+
+	bestLength := 0
+	var best []string
+	for _, candidate := range candidates {
+		length := commonSuffixLength(reportSegments, splitPathSegments(candidate))
+		switch {
+		case length > bestLength:
+			bestLength = length
+			best = []string{candidate}
+		case length == bestLength:
+			best = append(best, candidate)
+		}
+	}
+
+	if len(best) > 1 {
+		idx.logger.Warn("Ambiguous source file match, leaving file unresolved.", "file", reportPath, "candidates", best)
+		return "", fmt.Errorf("file %q matches %d discovered files equally well", reportPath, len(best))
+	}
+	return best[0], nil
+}
+
+// build walks all roots once and records every regular file by lower-cased base name.
+func (idx *SourceFileIndex) build() {
+	idx.byName = make(map[string][]string)
+
+	var roots []string
+	if idx.roots != nil {
+		for _, root := range idx.roots() {
+			if strings.TrimSpace(root) != "" {
+				roots = append(roots, root)
+			}
+		}
+	}
+	if len(roots) == 0 {
+		cwd, err := os.Getwd()
+		if err != nil {
+			idx.logger.Warn("Could not determine working directory for source auto-discovery.", "error", err)
+			return
+		}
+		roots = []string{cwd}
+	}
+
+	seen := make(map[string]struct{})
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				idx.logger.Debug("Skipping unreadable path during source auto-discovery.", "path", path, "error", err)
+				if d != nil && d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				if path != root && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			if _, dup := seen[path]; dup {
+				return nil
+			}
+			seen[path] = struct{}{}
+			key := strings.ToLower(d.Name())
+			idx.byName[key] = append(idx.byName[key], path)
+			return nil
+		})
+		if err != nil {
+			idx.logger.Warn("Source auto-discovery failed for root.", "root", root, "error", err)
+		}
+	}
+	idx.logger.Debug("Source auto-discovery index built.", "roots", roots, "names", len(idx.byName))
+}
+
+// splitPathSegments splits a path on both '/' and '\' so report paths produced
+// on another platform can still be compared.
+func splitPathSegments(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' })
+}
+
+// commonSuffixLength counts how many trailing segments a and b share.
+func commonSuffixLength(a, b []string) int {
+	n := 0
+	for i, j := len(a)-1, len(b)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if !strings.EqualFold(a[i], b[j]) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// FindFileInSourceDirsOrIndex behaves like FindFileInSourceDirs and falls back
+// to the auto-discovery index when the file cannot be found. A nil index
+// disables the fallback.
+func FindFileInSourceDirsOrIndex(relativePath string, sourceDirs []string, stater Stater, index *SourceFileIndex) (string, error) {
+	resolved, err := FindFileInSourceDirs(relativePath, sourceDirs, stater)
+	if err == nil || index == nil {
+		return resolved, err
+	}
+	return index.Resolve(relativePath)
+}
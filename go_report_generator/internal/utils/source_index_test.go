@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFiles(t *testing.T, root string, relPaths ...string) {
+	t.Helper()
+	for _, rel := range relPaths {
+		full := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(full, []byte("x"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+}
+
+func newTestIndex(roots ...string) *SourceFileIndex {
+	return NewSourceFileIndex(func() []string { return roots }, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestSourceFileIndex_Resolve(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root,
+		"checkout/src/lib/foo.c",
+		"checkout/src/app/foo.c",
+		"checkout/src/app/main.c",
+		"vendor/a/util.c",
+		"vendor/b/util.c",
+	)
+
+	tests := []struct {
+		name       string
+		reportPath string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "longest suffix wins over same-named file",
+			reportPath: "src/lib/foo.c",
+			want:       filepath.Join(root, "checkout", "src", "lib", "foo.c"),
+		},
+		{
+			name:       "unique base name resolves",
+			reportPath: "/build/agent/work/main.c",
+			want:       filepath.Join(root, "checkout", "src", "app", "main.c"),
+		},
+		{
+			name:       "windows separators in report path",
+			reportPath: `D:\ci\src\app\foo.c`,
+			want:       filepath.Join(root, "checkout", "src", "app", "foo.c"),
+		},
+		{
+			name:       "ambiguous suffix stays unresolved",
+			reportPath: "util.c",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown file",
+			reportPath: "src/missing.c",
+			wantErr:    true,
+		},
+	}
+
+	idx := newTestIndex(root)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := idx.Resolve(tt.reportPath)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.reportPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSourceFileIndex_BuildsOnlyOnce(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, "pkg/first.go")
+
+	calls := 0
+	idx := NewSourceFileIndex(func() []string { calls++; return []string{root} }, nil)
+
+	if _, err := idx.Resolve("pkg/first.go"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Files created after the first lookup are not picked up: the index is a snapshot.
+	writeTestFiles(t, root, "pkg/second.go")
+	if _, err := idx.Resolve("pkg/second.go"); err == nil {
+		t.Errorf("expected second.go to be missing from the already built index")
+	}
+	if calls != 1 {
+		t.Errorf("roots evaluated %d times, want 1", calls)
+	}
+}
+
+func TestFindFileInSourceDirsOrIndex_FallsBackToIndex(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, "repo/src/lib/foo.c")
+	want := filepath.Join(root, "repo", "src", "lib", "foo.c")
+
+	if _, err := FindFileInSourceDirsOrIndex("other/lib/foo.c", []string{filepath.Join(root, "nope")}, DefaultStater{}, nil); err == nil {
+		t.Fatalf("expected error without index")
+	}
+
+	got, err := FindFileInSourceDirsOrIndex("other/lib/foo.c", []string{filepath.Join(root, "nope")}, DefaultStater{}, newTestIndex(root))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SourceFileResolver resolves report paths like FindFileInSourceDirsOrIndex, but
+// remembers every result (including failures), so that each path is probed at most
+// once per run no matter how many classes reference it. Unlike FindFileInSourceDirs
+// it probes all source directories and warns when a path exists below several of
+// them, instead of silently depending on the order of the directories.
+//
+// A single instance is meant to be shared by all parsers of a run; it is safe for
+// concurrent use.
+type SourceFileResolver struct {
+	index  *SourceFileIndex
+	logger *slog.Logger
+
+	mu    sync.Mutex
+	cache map[sourceResolutionKey]sourceResolution
+}
+
+type sourceResolutionKey struct {
+	sourceDirs string // The source directories joined with NUL
+	path       string
+}
+
+type sourceResolution struct {
+	path string
+	err  error
+}
+
+// NewSourceFileResolver creates a resolver that falls back to index for paths that
+// cannot be found in the source directories. A nil index disables the fallback.
+func NewSourceFileResolver(index *SourceFileIndex, logger *slog.Logger) *SourceFileResolver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SourceFileResolver{
+		index:  index,
+		logger: logger,
+		cache:  make(map[sourceResolutionKey]sourceResolution),
+	}
+}
+
+// Resolve returns the path of the source file for reportPath. Absolute paths that
+// exist are returned as they are; otherwise reportPath is looked up below every
+// source directory and the match in the first directory wins.
+func (r *SourceFileResolver) Resolve(reportPath string, sourceDirs []string, stater Stater) (string, error) {
+	key := sourceResolutionKey{sourceDirs: strings.Join(sourceDirs, "\x00"), path: reportPath}
+
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok {
+		return cached.path, cached.err
+	}
+
+	path, err := r.resolve(reportPath, sourceDirs, stater)
+
+	r.mu.Lock()
+	r.cache[key] = sourceResolution{path: path, err: err}
+	r.mu.Unlock()
+	return path, err
+}
+
+func (r *SourceFileResolver) resolve(reportPath string, sourceDirs []string, stater Stater) (string, error) {
+	if filepath.IsAbs(reportPath) {
+		if _, err := stater.Stat(reportPath); err == nil {
+			return reportPath, nil
+		}
+	}
+
+	cleanedPath := filepath.Clean(reportPath)
+	var candidates []string
+	for _, dir := range sourceDirs {
+		if path, ok := findFileInSourceDir(cleanedPath, dir, stater); ok && !slices.Contains(candidates, path) {
+			candidates = append(candidates, path)
+		}
+	}
+
+	switch {
+	case len(candidates) > 1:
+		r.logger.Warn("Source file exists in several source directories, using the first one.", "file", reportPath, "candidates", candidates)
+		return candidates[0], nil
+	case len(candidates) == 1:
+		return candidates[0], nil
+	case r.index != nil:
+		return r.index.Resolve(reportPath)
+	default:
+		return "", fmt.Errorf("file %q not found in any source directory (%v) or as absolute path", reportPath, sourceDirs)
+	}
+}
@@ -0,0 +1,120 @@
+// Package version reports the version of the running binary.
+package version
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// devVersion is reported when the binary carries no module version or VCS information,
+// e.g. for `go run` or test binaries.
+const devVersion = "dev"
+
+// Release builds override the build information with -ldflags, e.g.
+//
+//	-X github.com/IgorBayerl/ReportGenerator/go_report_generator/internal/version.version=v1.2.0
+//
+// and likewise for commit and date. Empty values keep the build information.
+var (
+	version string
+	commit  string
+	date    string
+)
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Info describes the build of the running binary.
+type Info struct {
+	Version string // Module version, short VCS revision or "dev"
+	Commit  string // Full VCS revision with a "-dirty" suffix for modified trees, empty if unknown
+	Date    string // Commit or build time as RFC 3339, empty if unknown
+}
+
+// String formats the info as shown by -version, e.g. "v1.2.0 (commit 0123456, 2026-01-02T15:04:05Z)".
+func (i Info) String() string {
+	switch {
+	case i.Commit != "" && i.Date != "":
+		return fmt.Sprintf("%s (commit %s, %s)", i.Version, i.Commit, i.Date)
+	case i.Commit != "":
+		return fmt.Sprintf("%s (commit %s)", i.Version, i.Commit)
+	case i.Date != "":
+		return fmt.Sprintf("%s (%s)", i.Version, i.Date)
+	}
+	return i.Version
+}
+
+// Get returns the info of the running binary, read from the build information and
+// overridden by the values set with -ldflags.
+func Get() Info {
+	once.Do(func() {
+		if buildInfo, ok := debug.ReadBuildInfo(); ok {
+			info = fromBuildInfo(buildInfo)
+		} else {
+			info = Info{Version: devVersion}
+		}
+		info = info.override(version, commit, date)
+	})
+	return info
+}
+
+// Version returns the module version the binary was built from (e.g. "v1.2.0" when
+// installed with `go install ...@v1.2.0`), the short VCS revision for local builds, or
+// "dev" if neither is available.
+func Version() string {
+	return Get().Version
+}
+
+// override replaces the fields with the non-empty values.
+func (i Info) override(version, commit, date string) Info {
+	if version != "" {
+		i.Version = version
+	}
+	if commit != "" {
+		i.Commit = commit
+	}
+	if date != "" {
+		i.Date = date
+	}
+	return i
+}
+
+func fromBuildInfo(buildInfo *debug.BuildInfo) Info {
+	var revision, vcsTime string
+	var modified bool
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		case "vcs.time":
+			vcsTime = setting.Value
+		}
+	}
+
+	i := Info{Commit: revision, Date: vcsTime}
+	if modified && revision != "" {
+		i.Commit += "-dirty"
+	}
+
+	switch v := buildInfo.Main.Version; {
+	case v != "" && v != "(devel)":
+		i.Version = v
+	case revision == "":
+		i.Version = devVersion
+	default:
+		short := revision
+		if len(short) > 12 {
+			short = short[:12]
+		}
+		if modified {
+			short += "-dirty"
+		}
+		i.Version = short
+	}
+	return i
+}
@@ -0,0 +1,61 @@
+package version
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestFromBuildInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		info debug.BuildInfo
+		want Info
+	}{
+		{
+			name: "module version",
+			info: debug.BuildInfo{Main: debug.Module{Version: "v1.2.0"}},
+			want: Info{Version: "v1.2.0"},
+		},
+		{
+			name: "vcs revision",
+			info: debug.BuildInfo{
+				Main: debug.Module{Version: "(devel)"},
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "0123456789abcdef0123"},
+					{Key: "vcs.time", Value: "2026-01-02T15:04:05Z"},
+					{Key: "vcs.modified", Value: "true"},
+				},
+			},
+			want: Info{Version: "0123456789ab-dirty", Commit: "0123456789abcdef0123-dirty", Date: "2026-01-02T15:04:05Z"},
+		},
+		{
+			name: "no information",
+			info: debug.BuildInfo{Main: debug.Module{Version: "(devel)"}},
+			want: Info{Version: "dev"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fromBuildInfo(&tc.info); got != tc.want {
+				t.Errorf("fromBuildInfo() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInfo_OverrideAndString(t *testing.T) {
+	i := Info{Version: "0123456789ab", Commit: "0123456789abcdef0123"}
+
+	if got, want := i.String(), "0123456789ab (commit 0123456789abcdef0123)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	i = i.override("v1.2.0", "", "2026-01-02T15:04:05Z")
+	if got, want := i.String(), "v1.2.0 (commit 0123456789abcdef0123, 2026-01-02T15:04:05Z)"; got != want {
+		t.Errorf("String() after override = %q, want %q", got, want)
+	}
+	if got := (Info{Version: "dev"}).String(); got != "dev" {
+		t.Errorf("String() = %q, want %q", got, "dev")
+	}
+}
@@ -1,7 +1,7 @@
 package assert
 
 import (
-	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -18,8 +18,12 @@ import (
 
 // ToSlash converts every string (or every string inside a slice / map / struct
 // field) to the Unix path separator before comparison.  Handy when the same
-// test runs on both Windows and POSIX machines.
-var ToSlash = cmp.Transformer("toSlash", filepath.ToSlash)
+// test runs on both Windows and POSIX machines.  Backslashes are replaced on
+// every host (filepath.ToSlash only does so on Windows), so that simulated
+// Windows paths compare equal on POSIX machines too.
+var ToSlash = cmp.Transformer("toSlash", func(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+})
 
 // SortPaths orders string slices lexicographically before comparing them.
 // Combine it with ToSlash to make path-list comparisons order-insensitive.